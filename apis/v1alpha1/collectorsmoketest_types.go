@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CollectorSmokeTestSpec defines the collector rollout verified by the smoke test.
+type CollectorSmokeTestSpec struct {
+	// Collector is the name of the OpenTelemetryCollector whose Deployment rollout is verified.
+	// +required
+	Collector string `json:"collector"`
+	// Revision is the revision of the collector Deployment verified by the smoke test.
+	// +required
+	Revision string `json:"revision"`
+	// ConfigHash is the hash of the collector config deployed by the revision.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+	// Image is the collector image deployed by the revision.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+type (
+	// SmokeTestPhase represents the progress of a smoke test.
+	// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+	SmokeTestPhase string
+)
+
+const (
+	// SmokeTestPhaseRunning specifies that the smoke test Job is running.
+	SmokeTestPhaseRunning SmokeTestPhase = "Running"
+
+	// SmokeTestPhaseSucceeded specifies that the spans sent to the collector were received.
+	SmokeTestPhaseSucceeded SmokeTestPhase = "Succeeded"
+
+	// SmokeTestPhaseFailed specifies that the collector didn't receive the spans sent by the smoke test.
+	SmokeTestPhaseFailed SmokeTestPhase = "Failed"
+)
+
+// CollectorSmokeTestStatus defines the result of the smoke test.
+type CollectorSmokeTestStatus struct {
+	// Phase is the progress of the smoke test.
+	// +optional
+	Phase SmokeTestPhase `json:"phase,omitempty"`
+	// Message describes the result of the smoke test.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the smoke test Job was created.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the smoke test succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// RolledBack indicates whether the collector Deployment was rolled back to its previous revision after the failure.
+	// +optional
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Conditions represent the latest available observations of the CollectorSmokeTest's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=otelsmoketest;otelsmoketests
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Collector",type="string",JSONPath=".spec.collector"
+// +kubebuilder:printcolumn:name="Revision",type="string",JSONPath=".spec.revision"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector Smoke Test"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Job,v1}}
+
+// CollectorSmokeTest is the result of the smoke test run after a rollout of a collector Deployment.
+type CollectorSmokeTest struct {
+	Status            CollectorSmokeTestStatus `json:"status,omitempty"`
+	metav1.TypeMeta   `json:",inline"`
+	Spec              CollectorSmokeTestSpec `json:"spec,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CollectorSmokeTestList contains a list of CollectorSmokeTest.
+type CollectorSmokeTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CollectorSmokeTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CollectorSmokeTest{}, &CollectorSmokeTestList{})
+}
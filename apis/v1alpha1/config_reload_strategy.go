@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ConfigReloadStrategy represents how the collector pods pick up the changes of the config
+	// +kubebuilder:validation:Enum=restart;reload
+	ConfigReloadStrategy string
+)
+
+const (
+	// ConfigReloadStrategyRestart specifies that the collector pods are rolled out when the config changes.
+	ConfigReloadStrategyRestart ConfigReloadStrategy = "restart"
+
+	// ConfigReloadStrategyReload specifies that the running collectors are sent a SIGHUP to reload the config when it changes.
+	ConfigReloadStrategyReload ConfigReloadStrategy = "reload"
+)
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// GCPolicy represents what happens to the resources created for the CR when the CR is deleted
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	GCPolicy string
+)
+
+const (
+	// GCPolicyForeground specifies that the resources created for the CR are deleted before the CR is deleted.
+	GCPolicyForeground GCPolicy = "Foreground"
+
+	// GCPolicyBackground specifies that the CR is deleted right away, the resources created for it being garbage collected
+	// afterwards through their owner references.
+	GCPolicyBackground GCPolicy = "Background"
+
+	// GCPolicyOrphan specifies that the resources created for the CR are left in place, without owner references, when the CR is deleted.
+	GCPolicyOrphan GCPolicy = "Orphan"
+)
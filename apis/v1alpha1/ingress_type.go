@@ -16,7 +16,7 @@ package v1alpha1
 
 type (
 	// IngressType represents how a collector should be exposed (ingress vs route).
-	// +kubebuilder:validation:Enum=ingress;route
+	// +kubebuilder:validation:Enum=ingress;route;gateway
 	IngressType string
 )
 
@@ -25,6 +25,24 @@ const (
 	IngressTypeNginx IngressType = "ingress"
 	// IngressTypeOpenshiftRoute specifies that an route entry should be created.
 	IngressTypeRoute IngressType = "route"
+	// IngressTypeGateway specifies that Gateway API HTTPRoute and GRPCRoute entries should be created.
+	IngressTypeGateway IngressType = "gateway"
+)
+
+type (
+	// IngressRuleType defines how the collector receivers will be exposed in the Ingress.
+	// +kubebuilder:validation:Enum=path;subdomain
+	IngressRuleType string
+)
+
+const (
+	// IngressRuleTypePath configures Ingress to use single host with multiple paths.
+	// This configuration might require additional ingress setting to rewrite paths.
+	IngressRuleTypePath IngressRuleType = "path"
+
+	// IngressRuleTypeSubdomain configures Ingress to use multiple hosts - one for each exposed
+	// receiver port. The port name is used as a subdomain for the host defined in the Ingress e.g. otlp-http.example.com.
+	IngressRuleTypeSubdomain IngressRuleType = "subdomain"
 )
 
 type (
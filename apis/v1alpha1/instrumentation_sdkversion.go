@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+)
+
+const (
+	defaultSDKImageRepository = "ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-%s"
+	sdkImageCheckTimeout      = 5 * time.Second
+)
+
+// pythonVersion matches the PEP 440 versions of the Python SDK, like 0.36b0, which aren't valid semver.
+var pythonVersion = regexp.MustCompile(`^\d+(\.\d+)*((a|b|rc)\d+)?(\.post\d+)?(\.dev\d+)?$`)
+
+// SDKImageExists returns whether the images selected by the sdkVersion fields exist in their registry.
+// The verification is skipped when nil, it runs in the background of the validation and only logs the missing images.
+var SDKImageExists func(ctx context.Context, image string) (bool, error)
+
+// DefaultSDKVersions returns the SDK versions shipped with this release of the operator, by language.
+func DefaultSDKVersions() map[string]string {
+	return map[string]string{
+		"java":   version.AutoInstrumentationJava(),
+		"nodejs": version.AutoInstrumentationNodeJS(),
+		"python": version.AutoInstrumentationPython(),
+	}
+}
+
+// defaultSDKImage returns the image of the SDK version for the language. The version replaces the tag of the
+// default image from the annotation. Without a version, the default image is used as is, and without both the
+// image of the version shipped with the operator is used.
+func (r *Instrumentation) defaultSDKImage(annotation, language, sdkVersion string) string {
+	defaultImage := r.Annotations[annotation]
+	if sdkVersion == "" {
+		if defaultImage != "" {
+			return defaultImage
+		}
+		sdkVersion = DefaultSDKVersions()[language]
+	}
+
+	repository := fmt.Sprintf(defaultSDKImageRepository, language)
+	if defaultImage != "" {
+		repository = imageRepository(defaultImage)
+	}
+	return fmt.Sprintf("%s:%s", repository, sdkVersion)
+}
+
+// sdkVersionImages returns the images selected by the sdkVersion of the languages without an image, by language.
+func (r *Instrumentation) sdkVersionImages() map[string]string {
+	images := map[string]string{}
+	if r.Spec.Java.Image == "" && r.Spec.Java.SDKVersion != "" {
+		images["java"] = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationJava, "java", r.Spec.Java.SDKVersion)
+	}
+	if r.Spec.NodeJS.Image == "" && r.Spec.NodeJS.SDKVersion != "" {
+		images["nodejs"] = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationNodeJS, "nodejs", r.Spec.NodeJS.SDKVersion)
+	}
+	if r.Spec.Python.Image == "" && r.Spec.Python.SDKVersion != "" {
+		images["python"] = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationPython, "python", r.Spec.Python.SDKVersion)
+	}
+	return images
+}
+
+// WithSDKVersionImages returns the instrumentation with the images selected by its sdkVersion fields. They are resolved
+// when injecting the pods rather than persisted in the spec, so they follow the changes of the versions and of the
+// default images. The instrumentation is returned as is when no image is selected by a version.
+func (r *Instrumentation) WithSDKVersionImages() *Instrumentation {
+	if r == nil {
+		return nil
+	}
+	images := r.sdkVersionImages()
+	if len(images) == 0 {
+		return r
+	}
+	// the instrumentation can be shared by several languages
+	resolved := r.DeepCopy()
+	if image, ok := images["java"]; ok {
+		resolved.Spec.Java.Image = image
+	}
+	if image, ok := images["nodejs"]; ok {
+		resolved.Spec.NodeJS.Image = image
+	}
+	if image, ok := images["python"]; ok {
+		resolved.Spec.Python.Image = image
+	}
+	return resolved
+}
+
+// validateSDKVersion verifies the format of the SDK version.
+func validateSDKVersion(language, sdkVersion string) error {
+	if sdkVersion == "" {
+		return nil
+	}
+	if _, err := semver.StrictNewVersion(sdkVersion); err != nil && !(language == "python" && pythonVersion.MatchString(sdkVersion)) {
+		return fmt.Errorf("spec.%s.sdkVersion is not a valid version: %s", language, sdkVersion)
+	}
+	return nil
+}
+
+// verifySDKImages verifies in the background that the images selected by the sdk versions exist, and logs the missing
+// ones. The registry isn't queried during the admission, it might be slow or not reachable from the cluster.
+func (r *Instrumentation) verifySDKImages() {
+	exists := SDKImageExists
+	if exists == nil {
+		return
+	}
+	images := r.sdkVersionImages()
+	if len(images) == 0 {
+		return
+	}
+	name := r.Name
+	go func() {
+		for language, image := range images {
+			if err := verifySDKImage(exists, image); err != nil {
+				instrumentationlog.Error(err, "the sdk version can't be injected", "name", name, "language", language)
+			}
+		}
+	}()
+}
+
+// verifySDKImage returns an error when the image doesn't exist in its registry, or can't be verified.
+func verifySDKImage(exists func(ctx context.Context, image string) (bool, error), image string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sdkImageCheckTimeout)
+	defer cancel()
+	ok, err := exists(ctx, image)
+	if err != nil {
+		return fmt.Errorf("failed to verify the image %s: %w", image, err)
+	}
+	if !ok {
+		return fmt.Errorf("the image %s doesn't exist", image)
+	}
+	return nil
+}
+
+// imageRepository returns the image without its tag.
+func imageRepository(image string) string {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+	return image
+}
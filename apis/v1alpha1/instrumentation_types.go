@@ -30,6 +30,7 @@ type InstrumentationSpec struct {
 	Resource Resource `json:"resource,omitempty"`
 
 	// Propagators defines inter-process context propagation configuration.
+	// Defaults to W3C Trace Context and Baggage.
 	// +optional
 	Propagators []Propagator `json:"propagators,omitempty"`
 
@@ -58,6 +59,29 @@ type InstrumentationSpec struct {
 	// DotNet defines configuration for DotNet auto-instrumentation.
 	// +optional
 	DotNet DotNet `json:"dotnet,omitempty"`
+
+	// Go defines configuration for Go auto-instrumentation.
+	// +optional
+	Go Go `json:"go,omitempty"`
+
+	// ApacheHttpd defines configuration for Apache HTTPD auto-instrumentation.
+	// +optional
+	ApacheHttpd ApacheHttpd `json:"apacheHttpd,omitempty"`
+
+	// Nginx defines configuration for Nginx auto-instrumentation.
+	// +optional
+	Nginx Nginx `json:"nginx,omitempty"`
+
+	// ImagePullSecrets are added to the pods the auto-instrumentation is injected into, so the images can be pulled
+	// from a private registry. The secrets must exist in the namespace of the pods.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// RequireImageDigests defines whether the images must be referenced by digest, e.g. image@sha256:<digest>,
+	// rather than by a mutable tag. The default images of the operator are referenced by tag, so all the images
+	// have to be set when it's enabled.
+	// +optional
+	RequireImageDigests bool `json:"requireImageDigests,omitempty"`
 }
 
 // Resource defines the configuration for the resource attributes, as defined by the OpenTelemetry specification.
@@ -71,6 +95,17 @@ type Resource struct {
 	// AddK8sUIDAttributes defines whether K8s UID attributes should be collected (e.g. k8s.deployment.uid).
 	// +optional
 	AddK8sUIDAttributes bool `json:"addK8sUIDAttributes,omitempty"`
+
+	// FromLabels maps the labels of the pods to resource attributes, e.g. app.kubernetes.io/version: service.version.
+	// The labels of the namespace are used when the pod doesn't have them. The attributes take precedence over
+	// the resource attributes.
+	// +optional
+	FromLabels map[string]string `json:"fromLabels,omitempty"`
+
+	// FromAnnotations maps the annotations of the pods to resource attributes, like FromLabels. An attribute mapped
+	// from an annotation takes precedence over the one mapped from a label.
+	// +optional
+	FromAnnotations map[string]string `json:"fromAnnotations,omitempty"`
 }
 
 // Exporter defines OTLP exporter configuration.
@@ -78,6 +113,28 @@ type Exporter struct {
 	// Endpoint is address of the collector with OTLP endpoint.
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// TLS defines the certificates used by the exporter to connect to the endpoint.
+	// +optional
+	TLS *TLS `json:"tls,omitempty"`
+}
+
+// TLS defines the certificates of the OTLP exporter, read from a Secret in the namespace of the instrumented pods.
+type TLS struct {
+	// SecretName is the name of the Secret with the certificates.
+	SecretName string `json:"secretName"`
+
+	// CA is the key of the CA certificate in the Secret, used to verify the certificate of the endpoint.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// Cert is the key of the client certificate in the Secret, used along with Key for mTLS.
+	// +optional
+	Cert string `json:"cert,omitempty"`
+
+	// Key is the key of the client private key in the Secret.
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // Sampler defines sampling configuration.
@@ -90,6 +147,8 @@ type Sampler struct {
 	// Argument defines sampler argument.
 	// The value depends on the sampler type.
 	// For instance for parentbased_traceidratio sampler type it is a number in range [0..1] e.g. 0.25.
+	// For jaeger_remote sampler type it is the endpoint of the remote sampler e.g. http://jaeger:14250, or the
+	// key-value configuration e.g. endpoint=http://jaeger:14250,pollingIntervalMs=5000,initialSamplingRate=0.25.
 	// +optional
 	Argument string `json:"argument,omitempty"`
 }
@@ -100,6 +159,16 @@ type Java struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// WindowsImage is a Windows container image with javaagent auto-instrumentation JAR, used for the pods running on
+	// Windows nodes. The JAR is copied from C:\javaagent.jar.
+	// +optional
+	WindowsImage string `json:"windowsImage,omitempty"`
+
+	// SDKVersion is the version of the javaagent to inject, e.g. 1.20.2. It selects the tag of the default
+	// auto-instrumentation image and is ignored when Image is set. Defaults to the version shipped with the operator.
+	// +optional
+	SDKVersion string `json:"sdkVersion,omitempty"`
+
 	// Env defines java specific env vars. There are four layers for env vars' definitions and
 	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
 	// If the former var had been defined, then the other vars would be ignored.
@@ -113,6 +182,11 @@ type NodeJS struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// SDKVersion is the version of the NodeJS SDK to inject, e.g. 1.20.2. It selects the tag of the default
+	// auto-instrumentation image and is ignored when Image is set. Defaults to the version shipped with the operator.
+	// +optional
+	SDKVersion string `json:"sdkVersion,omitempty"`
+
 	// Env defines nodejs specific env vars. There are four layers for env vars' definitions and
 	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
 	// If the former var had been defined, then the other vars would be ignored.
@@ -126,6 +200,16 @@ type Python struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// MuslImage is a container image with Python SDK and auto-instrumentation built for musl libc, used for the pods
+	// annotated with instrumentation.opentelemetry.io/otel-python-platform: musl, e.g. Alpine based containers.
+	// +optional
+	MuslImage string `json:"muslImage,omitempty"`
+
+	// SDKVersion is the version of the Python SDK to inject, e.g. 1.20.2. It selects the tag of the default
+	// auto-instrumentation image and is ignored when Image is set. Defaults to the version shipped with the operator.
+	// +optional
+	SDKVersion string `json:"sdkVersion,omitempty"`
+
 	// Env defines python specific env vars. There are four layers for env vars' definitions and
 	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
 	// If the former var had been defined, then the other vars would be ignored.
@@ -138,6 +222,16 @@ type DotNet struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
+	// WindowsImage is a Windows container image with DotNet SDK and auto-instrumentation, used for the pods running on
+	// Windows nodes. The auto-instrumentation is copied from C:\autoinstrumentation.
+	// +optional
+	WindowsImage string `json:"windowsImage,omitempty"`
+
+	// MuslImage is a container image with DotNet SDK and auto-instrumentation built for musl libc, used for the pods
+	// annotated with instrumentation.opentelemetry.io/otel-dotnet-auto-runtime: linux-musl-x64, e.g. Alpine based containers.
+	// +optional
+	MuslImage string `json:"muslImage,omitempty"`
+
 	// Env defines DotNet specific env vars. There are four layers for env vars' definitions and
 	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
 	// If the former var had been defined, then the other vars would be ignored.
@@ -145,8 +239,89 @@ type DotNet struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
+// Go defines the Go eBPF auto-instrumentation agent configuration.
+type Go struct {
+	// Image is a container image with the Go eBPF auto-instrumentation agent, run as a privileged sidecar.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Env defines Go specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources describes the compute resource requirements of the Go agent sidecar.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ApacheHttpd defines the Apache HTTPD otel-webserver-module configuration.
+type ApacheHttpd struct {
+	// Image is a container image with the otel-webserver-module for Apache HTTPD.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Env defines Apache HTTPD specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Attrs defines the directives of the agent configuration, e.g. ApacheModuleOtelMaxQueueSize. They take
+	// precedence over the directives rendered from the Instrumentation.
+	// See https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/otel-webserver-module
+	// +optional
+	Attrs []corev1.EnvVar `json:"attrs,omitempty"`
+
+	// Version is the version of the Apache HTTPD server, 2.2 or 2.4. Defaults to 2.4.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ConfigPath is the directory of the Apache HTTPD configuration in the container. Defaults to /usr/local/apache2/conf.
+	// +optional
+	ConfigPath string `json:"configPath,omitempty"`
+
+	// Resources describes the compute resource requirements of the init containers setting up the agent.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Nginx defines the Nginx otel-webserver-module configuration.
+type Nginx struct {
+	// Image is a container image with the otel-webserver-module for Nginx.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Env defines Nginx specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Attrs defines the directives of the agent configuration, e.g. NginxModuleOtelMaxQueueSize. They take
+	// precedence over the directives rendered from the Instrumentation.
+	// See https://github.com/open-telemetry/opentelemetry-cpp-contrib/tree/main/instrumentation/otel-webserver-module
+	// +optional
+	Attrs []corev1.EnvVar `json:"attrs,omitempty"`
+
+	// ConfigFile is the path of the main Nginx configuration file in the container. It can be overridden per pod
+	// with the instrumentation.opentelemetry.io/nginx-config-file annotation. Defaults to /etc/nginx/nginx.conf.
+	// +optional
+	ConfigFile string `json:"configFile,omitempty"`
+
+	// Resources describes the compute resource requirements of the init containers setting up the agent.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// Conditions represent the latest available observations of the Instrumentation's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -16,6 +16,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -27,14 +29,20 @@ import (
 )
 
 const (
-	AnnotationDefaultAutoInstrumentationJava   = "instrumentation.opentelemetry.io/default-auto-instrumentation-java-image"
-	AnnotationDefaultAutoInstrumentationNodeJS = "instrumentation.opentelemetry.io/default-auto-instrumentation-nodejs-image"
-	AnnotationDefaultAutoInstrumentationPython = "instrumentation.opentelemetry.io/default-auto-instrumentation-python-image"
-	AnnotationDefaultAutoInstrumentationDotNet = "instrumentation.opentelemetry.io/default-auto-instrumentation-dotnet-image"
-	envPrefix                                  = "OTEL_"
-	envSplunkPrefix                            = "SPLUNK_"
+	AnnotationDefaultAutoInstrumentationJava        = "instrumentation.opentelemetry.io/default-auto-instrumentation-java-image"
+	AnnotationDefaultAutoInstrumentationNodeJS      = "instrumentation.opentelemetry.io/default-auto-instrumentation-nodejs-image"
+	AnnotationDefaultAutoInstrumentationPython      = "instrumentation.opentelemetry.io/default-auto-instrumentation-python-image"
+	AnnotationDefaultAutoInstrumentationDotNet      = "instrumentation.opentelemetry.io/default-auto-instrumentation-dotnet-image"
+	AnnotationDefaultAutoInstrumentationGo          = "instrumentation.opentelemetry.io/default-auto-instrumentation-go-image"
+	AnnotationDefaultAutoInstrumentationApacheHttpd = "instrumentation.opentelemetry.io/default-auto-instrumentation-apache-httpd-image"
+	AnnotationDefaultAutoInstrumentationNginx       = "instrumentation.opentelemetry.io/default-auto-instrumentation-nginx-image"
+	envPrefix                                       = "OTEL_"
+	envSplunkPrefix                                 = "SPLUNK_"
 )
 
+// imageDigestRegexp matches the sha256 and sha512 digests of an image reference.
+var imageDigestRegexp = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
 // log is for logging in this package.
 var instrumentationlog = logf.Log.WithName("instrumentation-resource")
 
@@ -58,26 +66,38 @@ func (r *Instrumentation) Default() {
 		r.Labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
 	}
 
-	if r.Spec.Java.Image == "" {
-		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationJava]; ok {
-			r.Spec.Java.Image = val
-		}
+	if len(r.Spec.Propagators) == 0 {
+		r.Spec.Propagators = []Propagator{TraceContext, Baggage}
 	}
-	if r.Spec.NodeJS.Image == "" {
-		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationNodeJS]; ok {
-			r.Spec.NodeJS.Image = val
-		}
+	if r.Spec.Java.Image == "" && r.Spec.Java.SDKVersion == "" {
+		r.Spec.Java.Image = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationJava, "java", r.Spec.Java.SDKVersion)
 	}
-	if r.Spec.Python.Image == "" {
-		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationPython]; ok {
-			r.Spec.Python.Image = val
-		}
+	if r.Spec.NodeJS.Image == "" && r.Spec.NodeJS.SDKVersion == "" {
+		r.Spec.NodeJS.Image = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationNodeJS, "nodejs", r.Spec.NodeJS.SDKVersion)
+	}
+	if r.Spec.Python.Image == "" && r.Spec.Python.SDKVersion == "" {
+		r.Spec.Python.Image = r.defaultSDKImage(AnnotationDefaultAutoInstrumentationPython, "python", r.Spec.Python.SDKVersion)
 	}
 	if r.Spec.DotNet.Image == "" {
 		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationDotNet]; ok {
 			r.Spec.DotNet.Image = val
 		}
 	}
+	if r.Spec.Go.Image == "" {
+		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationGo]; ok {
+			r.Spec.Go.Image = val
+		}
+	}
+	if r.Spec.ApacheHttpd.Image == "" {
+		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationApacheHttpd]; ok {
+			r.Spec.ApacheHttpd.Image = val
+		}
+	}
+	if r.Spec.Nginx.Image == "" {
+		if val, ok := r.Annotations[AnnotationDefaultAutoInstrumentationNginx]; ok {
+			r.Spec.Nginx.Image = val
+		}
+	}
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-instrumentation,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=instrumentations,versions=v1alpha1,name=vinstrumentationcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -115,7 +135,54 @@ func (r *Instrumentation) validate() error {
 				return fmt.Errorf("spec.sampler.argument should be in rage [0..1]: %s", r.Spec.Sampler.Argument)
 			}
 		}
-	case AlwaysOn, AlwaysOff, JaegerRemote, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
+	case JaegerRemote:
+		if r.Spec.Sampler.Argument != "" {
+			if _, err := NormalizeJaegerRemoteArgument(r.Spec.Sampler.Argument); err != nil {
+				return fmt.Errorf("spec.sampler.argument is not a valid jaeger_remote configuration: %w", err)
+			}
+		}
+	case "", AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
+	default:
+		return fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
+	}
+
+	for _, propagator := range r.Spec.Propagators {
+		switch propagator {
+		case TraceContext, Baggage, B3, B3Multi, Jaeger, XRay, OTTrace, None:
+		default:
+			return fmt.Errorf("spec.propagators has an unknown propagator: %s", propagator)
+		}
+	}
+
+	if r.Spec.Exporter.Endpoint != "" {
+		if err := validateEndpoint(r.Spec.Exporter.Endpoint); err != nil {
+			return fmt.Errorf("spec.exporter.%w", err)
+		}
+		if u, _ := url.Parse(r.Spec.Exporter.Endpoint); u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("spec.exporter.endpoint should be a http or https URL: %s", r.Spec.Exporter.Endpoint)
+		}
+	}
+
+	// validate sdk versions
+	if err := validateSDKVersion("java", r.Spec.Java.SDKVersion); err != nil {
+		return err
+	}
+	if err := validateSDKVersion("nodejs", r.Spec.NodeJS.SDKVersion); err != nil {
+		return err
+	}
+	if err := validateSDKVersion("python", r.Spec.Python.SDKVersion); err != nil {
+		return err
+	}
+
+	r.verifySDKImages()
+
+	if err := validateExporterTLS(r.Spec.Exporter.TLS); err != nil {
+		return err
+	}
+
+	// validate images
+	if err := r.validateImages(); err != nil {
+		return err
 	}
 
 	// validate env vars
@@ -134,10 +201,84 @@ func (r *Instrumentation) validate() error {
 	if err := r.validateEnv(r.Spec.DotNet.Env); err != nil {
 		return err
 	}
+	if err := r.validateEnv(r.Spec.Go.Env); err != nil {
+		return err
+	}
+	if err := r.validateEnv(r.Spec.ApacheHttpd.Env); err != nil {
+		return err
+	}
+	switch r.Spec.ApacheHttpd.Version {
+	case "", "2.2", "2.4":
+	default:
+		return fmt.Errorf("spec.apacheHttpd.version is not valid: %s, should be 2.2 or 2.4", r.Spec.ApacheHttpd.Version)
+	}
+	if err := r.validateEnv(r.Spec.Nginx.Env); err != nil {
+		return err
+	}
+	if r.Spec.Nginx.ConfigFile != "" && !strings.HasPrefix(r.Spec.Nginx.ConfigFile, "/") {
+		return fmt.Errorf("spec.nginx.configFile should be an absolute path: %s", r.Spec.Nginx.ConfigFile)
+	}
 
 	return nil
 }
 
+func validateExporterTLS(tls *TLS) error {
+	if tls == nil {
+		return nil
+	}
+	if tls.SecretName == "" {
+		return fmt.Errorf("spec.exporter.tls.secretName should be set")
+	}
+	if (tls.Cert == "") != (tls.Key == "") {
+		return fmt.Errorf("spec.exporter.tls.cert and spec.exporter.tls.key should be set together")
+	}
+	for _, key := range []string{tls.CA, tls.Cert, tls.Key} {
+		if strings.Contains(key, "/") {
+			return fmt.Errorf("spec.exporter.tls keys should be keys of the secret %s: %s", tls.SecretName, key)
+		}
+	}
+	return nil
+}
+
+// validateImages verifies the digests of the images referenced by digest. All the images must be referenced by
+// digest when spec.requireImageDigests is set.
+func (r *Instrumentation) validateImages() error {
+	for _, image := range []struct {
+		field, value string
+	}{
+		{field: "spec.java.image", value: r.Spec.Java.Image},
+		{field: "spec.java.windowsImage", value: r.Spec.Java.WindowsImage},
+		{field: "spec.nodejs.image", value: r.Spec.NodeJS.Image},
+		{field: "spec.python.image", value: r.Spec.Python.Image},
+		{field: "spec.python.muslImage", value: r.Spec.Python.MuslImage},
+		{field: "spec.dotnet.image", value: r.Spec.DotNet.Image},
+		{field: "spec.dotnet.windowsImage", value: r.Spec.DotNet.WindowsImage},
+		{field: "spec.dotnet.muslImage", value: r.Spec.DotNet.MuslImage},
+		{field: "spec.go.image", value: r.Spec.Go.Image},
+		{field: "spec.apacheHttpd.image", value: r.Spec.ApacheHttpd.Image},
+		{field: "spec.nginx.image", value: r.Spec.Nginx.Image},
+		{field: "spec.java.sdkVersion", value: r.sdkVersionImages()["java"]},
+		{field: "spec.nodejs.sdkVersion", value: r.sdkVersionImages()["nodejs"]},
+		{field: "spec.python.sdkVersion", value: r.sdkVersionImages()["python"]},
+	} {
+		if image.value == "" {
+			// the optional images aren't pulled when unset
+			continue
+		}
+		i := strings.LastIndex(image.value, "@")
+		if i == -1 {
+			if r.Spec.RequireImageDigests {
+				return fmt.Errorf("%s should be referenced by digest when spec.requireImageDigests is set: %s", image.field, image.value)
+			}
+			continue
+		}
+		if !imageDigestRegexp.MatchString(image.value[i+1:]) {
+			return fmt.Errorf("%s has an invalid digest: %s", image.field, image.value)
+		}
+	}
+	return nil
+}
+
 func (r *Instrumentation) validateEnv(envs []corev1.EnvVar) error {
 	for _, env := range envs {
 		if !strings.HasPrefix(env.Name, envPrefix) && !strings.HasPrefix(env.Name, envSplunkPrefix) {
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,10 +27,13 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 	inst := &Instrumentation{
 		ObjectMeta: metav1.ObjectMeta{
 			Annotations: map[string]string{
-				AnnotationDefaultAutoInstrumentationJava:   "java-img:1",
-				AnnotationDefaultAutoInstrumentationNodeJS: "nodejs-img:1",
-				AnnotationDefaultAutoInstrumentationPython: "python-img:1",
-				AnnotationDefaultAutoInstrumentationDotNet: "dotnet-img:1",
+				AnnotationDefaultAutoInstrumentationJava:        "java-img:1",
+				AnnotationDefaultAutoInstrumentationNodeJS:      "nodejs-img:1",
+				AnnotationDefaultAutoInstrumentationPython:      "python-img:1",
+				AnnotationDefaultAutoInstrumentationDotNet:      "dotnet-img:1",
+				AnnotationDefaultAutoInstrumentationGo:          "go-img:1",
+				AnnotationDefaultAutoInstrumentationApacheHttpd: "apache-httpd-img:1",
+				AnnotationDefaultAutoInstrumentationNginx:       "nginx-img:1",
 			},
 		},
 	}
@@ -37,6 +42,118 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 	assert.Equal(t, "nodejs-img:1", inst.Spec.NodeJS.Image)
 	assert.Equal(t, "python-img:1", inst.Spec.Python.Image)
 	assert.Equal(t, "dotnet-img:1", inst.Spec.DotNet.Image)
+	assert.Equal(t, "go-img:1", inst.Spec.Go.Image)
+	assert.Equal(t, "apache-httpd-img:1", inst.Spec.ApacheHttpd.Image)
+	assert.Equal(t, "nginx-img:1", inst.Spec.Nginx.Image)
+	assert.Equal(t, []Propagator{TraceContext, Baggage}, inst.Spec.Propagators)
+}
+
+func TestInstrumentationDefaultingWebhookPropagators(t *testing.T) {
+	inst := &Instrumentation{
+		Spec: InstrumentationSpec{
+			Propagators: []Propagator{B3, XRay},
+		},
+	}
+	inst.Default()
+	assert.Equal(t, []Propagator{B3, XRay}, inst.Spec.Propagators)
+}
+
+func TestInstrumentationDefaultingWebhookSDKVersion(t *testing.T) {
+	inst := &Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				AnnotationDefaultAutoInstrumentationJava: "registry:5000/java-img:1",
+			},
+		},
+		Spec: InstrumentationSpec{
+			Java:   Java{SDKVersion: "1.20.2"},
+			NodeJS: NodeJS{SDKVersion: "0.34.0"},
+			Python: Python{Image: "python-img:custom", SDKVersion: "0.36b0"},
+		},
+	}
+	inst.Default()
+	// the images selected by the versions are resolved when injecting the pods
+	assert.Equal(t, "", inst.Spec.Java.Image)
+	assert.Equal(t, "", inst.Spec.NodeJS.Image)
+	assert.Equal(t, "python-img:custom", inst.Spec.Python.Image)
+	assert.Equal(t, "", inst.Spec.DotNet.Image)
+
+	resolved := inst.WithSDKVersionImages()
+	assert.Equal(t, "registry:5000/java-img:1.20.2", resolved.Spec.Java.Image)
+	assert.Equal(t, "ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:0.34.0", resolved.Spec.NodeJS.Image)
+	assert.Equal(t, "python-img:custom", resolved.Spec.Python.Image)
+	assert.Equal(t, "", inst.Spec.Java.Image, "the instrumentation is shared by the injected pods")
+}
+
+func TestVerifySDKImage(t *testing.T) {
+	exists := func(_ context.Context, image string) (bool, error) {
+		if image == "unreachable:1.20.2" {
+			return false, errors.New("connection refused")
+		}
+		return image == "java-img:1.20.2", nil
+	}
+
+	assert.NoError(t, verifySDKImage(exists, "java-img:1.20.2"))
+	assert.ErrorContains(t, verifySDKImage(exists, "java-img:9.9.9"), "the image java-img:9.9.9 doesn't exist")
+	assert.ErrorContains(t, verifySDKImage(exists, "unreachable:1.20.2"), "connection refused")
+}
+
+func TestInstrumentationValidatingWebhookSDKVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		err  string
+		inst Instrumentation
+	}{
+		{
+			name: "available version",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Java: Java{Image: "java-img:1.20.2", SDKVersion: "1.20.2"},
+				},
+			},
+		},
+		{
+			name: "python pre-release version",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Python: Python{Image: "python-img:custom", SDKVersion: "0.36b0"},
+				},
+			},
+		},
+		{
+			name: "invalid version",
+			err:  "spec.nodejs.sdkVersion is not a valid version",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					NodeJS: NodeJS{SDKVersion: "latest"},
+				},
+			},
+		},
+		{
+			name: "version without digest",
+			err:  "spec.java.sdkVersion should be referenced by digest",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					RequireImageDigests: true,
+					Java:                Java{SDKVersion: "1.20.2"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err == "" {
+				assert.Nil(t, test.inst.ValidateCreate())
+				assert.Nil(t, test.inst.ValidateUpdate(nil))
+			} else {
+				err := test.inst.ValidateCreate()
+				assert.Contains(t, err.Error(), test.err)
+				err = test.inst.ValidateUpdate(nil)
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
 }
 
 func TestInstrumentationValidatingWebhook(t *testing.T) {
@@ -69,6 +186,52 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "jaeger remote endpoint",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type:     JaegerRemote,
+						Argument: "http://jaeger:14250",
+					},
+				},
+			},
+		},
+		{
+			name: "jaeger remote configuration",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type:     JaegerRemote,
+						Argument: "endpoint=http://jaeger:14250,pollingIntervalMs=5000,initialSamplingRate=0.25",
+					},
+				},
+			},
+		},
+		{
+			name: "jaeger remote endpoint is not a URL",
+			err:  "spec.sampler.argument is not a valid jaeger_remote configuration: endpoint should be a URL",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type:     JaegerRemote,
+						Argument: "jaeger",
+					},
+				},
+			},
+		},
+		{
+			name: "jaeger remote initial sampling rate out of range",
+			err:  "initialSamplingRate should be in range [0..1]",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type:     JaegerRemote,
+						Argument: "endpoint=http://jaeger:14250,initialSamplingRate=2",
+					},
+				},
+			},
+		},
 		{
 			name: "argument is a number",
 			inst: Instrumentation{
@@ -90,6 +253,152 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "apache httpd version",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					ApacheHttpd: ApacheHttpd{
+						Version: "2.2",
+					},
+				},
+			},
+		},
+		{
+			name: "relative nginx config file",
+			err:  "spec.nginx.configFile should be an absolute path: nginx.conf",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Nginx: Nginx{
+						ConfigFile: "nginx.conf",
+					},
+				},
+			},
+		},
+		{
+			name: "unsupported apache httpd version",
+			err:  "spec.apacheHttpd.version is not valid: 2.0, should be 2.2 or 2.4",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					ApacheHttpd: ApacheHttpd{
+						Version: "2.0",
+					},
+				},
+			},
+		},
+		{
+			name: "image referenced by digest",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					RequireImageDigests: true,
+					Java: Java{
+						Image: "registry.example.com/java@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					},
+					NodeJS: NodeJS{
+						Image: "registry.example.com/nodejs:0.34.0@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					},
+				},
+			},
+		},
+		{
+			name: "image referenced by tag with required digests",
+			err:  "spec.nodejs.image should be referenced by digest when spec.requireImageDigests is set: registry.example.com/nodejs:0.34.0",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					RequireImageDigests: true,
+					Java: Java{
+						Image: "registry.example.com/java@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					},
+					NodeJS: NodeJS{
+						Image: "registry.example.com/nodejs:0.34.0",
+					},
+				},
+			},
+		},
+		{
+			name: "unknown sampler",
+			err:  "spec.sampler.type is not valid: parentbased_traceid_ratio",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: "parentbased_traceid_ratio",
+					},
+				},
+			},
+		},
+		{
+			name: "unknown propagator",
+			err:  "spec.propagators has an unknown propagator: w3c",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Propagators: []Propagator{TraceContext, "w3c"},
+				},
+			},
+		},
+		{
+			name: "endpoint without scheme",
+			err:  "spec.exporter.endpoint should be a URL: otel-collector:4317",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						Endpoint: "otel-collector:4317",
+					},
+				},
+			},
+		},
+		{
+			name: "endpoint with unsupported scheme",
+			err:  "spec.exporter.endpoint should be a http or https URL: grpc://otel-collector:4317",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						Endpoint: "grpc://otel-collector:4317",
+					},
+				},
+			},
+		},
+		{
+			name: "exporter mTLS",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						TLS: &TLS{SecretName: "otel-certs", CA: "ca.crt", Cert: "tls.crt", Key: "tls.key"},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter client certificate without key",
+			err:  "spec.exporter.tls.cert and spec.exporter.tls.key should be set together",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						TLS: &TLS{SecretName: "otel-certs", Cert: "tls.crt"},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter TLS without secret",
+			err:  "spec.exporter.tls.secretName should be set",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						TLS: &TLS{CA: "ca.crt"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid image digest",
+			err:  "spec.dotnet.muslImage has an invalid digest: registry.example.com/dotnet@sha256:1234",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					DotNet: DotNet{
+						MuslImage: "registry.example.com/dotnet@sha256:1234",
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
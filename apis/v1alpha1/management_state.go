@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ManagementStateType defines whether the operator manages the resources of the CR
+	// +kubebuilder:validation:Enum=managed;unmanaged
+	ManagementStateType string
+)
+
+const (
+	// ManagementStateManaged specifies that the operator reconciles the resources of the CR.
+	ManagementStateManaged ManagementStateType = "managed"
+
+	// ManagementStateUnmanaged specifies that the operator leaves the resources of the CR as they are, including
+	// the manual changes made to them, until the CR is managed again.
+	ManagementStateUnmanaged ManagementStateType = "unmanaged"
+)
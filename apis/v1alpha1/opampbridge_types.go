@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpAMPBridgeCapability is a capability the OpAMP bridge reports to the OpAMP server.
+type OpAMPBridgeCapability string
+
+const (
+	OpAMPBridgeCapabilityReportsStatus                  OpAMPBridgeCapability = "ReportsStatus"
+	OpAMPBridgeCapabilityAcceptsRemoteConfig            OpAMPBridgeCapability = "AcceptsRemoteConfig"
+	OpAMPBridgeCapabilityReportsEffectiveConfig         OpAMPBridgeCapability = "ReportsEffectiveConfig"
+	OpAMPBridgeCapabilityAcceptsPackages                OpAMPBridgeCapability = "AcceptsPackages"
+	OpAMPBridgeCapabilityReportsPackageStatuses         OpAMPBridgeCapability = "ReportsPackageStatuses"
+	OpAMPBridgeCapabilityReportsOwnTraces               OpAMPBridgeCapability = "ReportsOwnTraces"
+	OpAMPBridgeCapabilityReportsOwnMetrics              OpAMPBridgeCapability = "ReportsOwnMetrics"
+	OpAMPBridgeCapabilityReportsOwnLogs                 OpAMPBridgeCapability = "ReportsOwnLogs"
+	OpAMPBridgeCapabilityAcceptsOpAMPConnectionSettings OpAMPBridgeCapability = "AcceptsOpAMPConnectionSettings"
+	OpAMPBridgeCapabilityAcceptsOtherConnectionSettings OpAMPBridgeCapability = "AcceptsOtherConnectionSettings"
+	OpAMPBridgeCapabilityAcceptsRestartCommand          OpAMPBridgeCapability = "AcceptsRestartCommand"
+	OpAMPBridgeCapabilityReportsHealth                  OpAMPBridgeCapability = "ReportsHealth"
+	OpAMPBridgeCapabilityReportsRemoteConfig            OpAMPBridgeCapability = "ReportsRemoteConfig"
+)
+
+// OpAMPBridgeSpec defines the desired state of an OpAMP bridge, connecting the OpenTelemetryCollectors of its
+// namespace to an OpAMP server.
+type OpAMPBridgeSpec struct {
+	// Endpoint is the URL of the OpAMP server, e.g. wss://opamp.example.com/v1/opamp.
+	// +required
+	Endpoint string `json:"endpoint"`
+	// Headers are sent to the OpAMP server when the bridge connects, e.g. to authenticate it. Their values are read
+	// from Secrets in the namespace of the OpAMPBridge.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Headers []OpAMPBridgeHeader `json:"headers,omitempty"`
+	// Capabilities are the capabilities the bridge reports to the OpAMP server, e.g. AcceptsRemoteConfig lets the
+	// server change the config of the managed collectors. ReportsStatus is always reported.
+	// +optional
+	Capabilities map[OpAMPBridgeCapability]bool `json:"capabilities,omitempty"`
+	// ComponentsAllowed is the components the remote configurations can use, by kind, e.g. receivers: [otlp]. All the
+	// components are allowed when empty.
+	// +optional
+	ComponentsAllowed map[string][]string `json:"componentsAllowed,omitempty"`
+	// Selector is the labels of the OpenTelemetryCollectors, in the namespace of the OpAMPBridge, listed in the status
+	// of the bridge. All the OpenTelemetryCollectors of the namespace are listed when empty.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
+	// the operator will not automatically create a ServiceAccount, nor grant it the access to the collectors.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Image indicates the container image to use for the OpAMP bridge.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
+	// +optional
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Env to set on the OpAMP bridge container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// Resources to set on the OpAMP bridge pod.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector to schedule the OpAMP bridge pod.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the OpAMP bridge pod.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// Tolerations to schedule the OpAMP bridge pod.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// PodAnnotations is the set of annotations that will be attached to the OpAMP bridge pod.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// OpAMPBridgeHeader is a header sent to the OpAMP server, with its value read from a Secret.
+type OpAMPBridgeHeader struct {
+	// Name of the header.
+	// +required
+	Name string `json:"name"`
+	// ValueFrom selects the key of the Secret holding the value of the header.
+	// +required
+	ValueFrom v1.SecretKeySelector `json:"valueFrom"`
+}
+
+// OpAMPBridgeStatus defines the observed state of OpAMPBridge.
+type OpAMPBridgeStatus struct {
+	// Collectors are the names of the OpenTelemetryCollectors managed by the bridge.
+	// +optional
+	// +listType=atomic
+	Collectors []string `json:"collectors,omitempty"`
+	// Conditions represent the latest available observations of the OpAMPBridge's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=opampbridge
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpAMP Bridge"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Deployment,apps/v1},{ConfigMaps,v1},{ServiceAccount,v1},{Role,rbac.authorization.k8s.io/v1},{RoleBinding,rbac.authorization.k8s.io/v1}}
+
+// OpAMPBridge is the Schema for an OpAMP bridge managing the OpenTelemetryCollectors of its namespace on behalf of
+// an OpAMP server.
+type OpAMPBridge struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpAMPBridgeSpec   `json:"spec,omitempty"`
+	Status OpAMPBridgeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpAMPBridgeList contains a list of OpAMPBridge.
+type OpAMPBridgeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpAMPBridge `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpAMPBridge{}, &OpAMPBridgeList{})
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// log is for logging in this package.
+var opampbridgelog = logf.Log.WithName("opampbridge-resource")
+
+var opampBridgeCapabilities = map[OpAMPBridgeCapability]bool{
+	OpAMPBridgeCapabilityReportsStatus:                  true,
+	OpAMPBridgeCapabilityAcceptsRemoteConfig:            true,
+	OpAMPBridgeCapabilityReportsEffectiveConfig:         true,
+	OpAMPBridgeCapabilityAcceptsPackages:                true,
+	OpAMPBridgeCapabilityReportsPackageStatuses:         true,
+	OpAMPBridgeCapabilityReportsOwnTraces:               true,
+	OpAMPBridgeCapabilityReportsOwnMetrics:              true,
+	OpAMPBridgeCapabilityReportsOwnLogs:                 true,
+	OpAMPBridgeCapabilityAcceptsOpAMPConnectionSettings: true,
+	OpAMPBridgeCapabilityAcceptsOtherConnectionSettings: true,
+	OpAMPBridgeCapabilityAcceptsRestartCommand:          true,
+	OpAMPBridgeCapabilityReportsHealth:                  true,
+	OpAMPBridgeCapabilityReportsRemoteConfig:            true,
+}
+
+func (r *OpAMPBridge) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-opentelemetry-io-v1alpha1-opampbridge,mutating=true,failurePolicy=fail,groups=opentelemetry.io,resources=opampbridges,verbs=create;update,versions=v1alpha1,name=mopampbridge.kb.io,sideEffects=none,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OpAMPBridge{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *OpAMPBridge) Default() {
+	opampbridgelog.Info("default", "name", r.Name)
+
+	if r.Labels == nil {
+		r.Labels = map[string]string{}
+	}
+	if r.Labels["app.kubernetes.io/managed-by"] == "" {
+		r.Labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
+	}
+
+	if r.Spec.Capabilities == nil {
+		r.Spec.Capabilities = map[OpAMPBridgeCapability]bool{}
+	}
+	r.Spec.Capabilities[OpAMPBridgeCapabilityReportsStatus] = true
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-opampbridge,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=opampbridges,versions=v1alpha1,name=vopampbridgecreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=delete,path=/validate-opentelemetry-io-v1alpha1-opampbridge,mutating=false,failurePolicy=ignore,groups=opentelemetry.io,resources=opampbridges,versions=v1alpha1,name=vopampbridgedelete.kb.io,sideEffects=none,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OpAMPBridge{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *OpAMPBridge) ValidateCreate() error {
+	opampbridgelog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *OpAMPBridge) ValidateUpdate(old runtime.Object) error {
+	opampbridgelog.Info("validate update", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *OpAMPBridge) ValidateDelete() error {
+	opampbridgelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *OpAMPBridge) validate() error {
+	if !featuregate.Gates.Enabled(featuregate.OpAMPBridge) {
+		return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the OpAMP bridge deployment", featuregate.OpAMPBridge)
+	}
+
+	endpoint, err := url.Parse(r.Spec.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Errorf("the OpAMPBridge Spec endpoint configuration is incorrect, %q isn't a URL", r.Spec.Endpoint)
+	}
+	switch endpoint.Scheme {
+	case "ws", "wss", "http", "https":
+	default:
+		return fmt.Errorf("the OpAMPBridge Spec endpoint configuration is incorrect, the %s scheme is neither ws, wss, http nor https", endpoint.Scheme)
+	}
+
+	for capability := range r.Spec.Capabilities {
+		if !opampBridgeCapabilities[capability] {
+			return fmt.Errorf("the OpAMPBridge Spec capabilities configuration is incorrect, %s isn't an OpAMP capability", capability)
+		}
+	}
+	if enabled, ok := r.Spec.Capabilities[OpAMPBridgeCapabilityReportsStatus]; ok && !enabled {
+		return fmt.Errorf("the OpAMPBridge Spec capabilities configuration is incorrect, the %s capability can't be disabled", OpAMPBridgeCapabilityReportsStatus)
+	}
+
+	for _, header := range r.Spec.Headers {
+		if header.Name == "" {
+			return fmt.Errorf("the OpAMPBridge Spec headers configuration is incorrect, the headers require a name")
+		}
+		if header.ValueFrom.Name == "" || header.ValueFrom.Key == "" {
+			return fmt.Errorf("the OpAMPBridge Spec headers configuration is incorrect, the header %s requires the name and key of its Secret", header.Name)
+		}
+	}
+
+	return nil
+}
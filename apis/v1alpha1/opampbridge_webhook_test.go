@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestOpAMPBridgeDefaultingWebhook(t *testing.T) {
+	bridge := OpAMPBridge{
+		Spec: OpAMPBridgeSpec{
+			Capabilities: map[OpAMPBridgeCapability]bool{
+				OpAMPBridgeCapabilityAcceptsRemoteConfig: true,
+			},
+		},
+	}
+
+	bridge.Default()
+
+	assert.Equal(t, OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			},
+		},
+		Spec: OpAMPBridgeSpec{
+			Capabilities: map[OpAMPBridgeCapability]bool{
+				OpAMPBridgeCapabilityReportsStatus:       true,
+				OpAMPBridgeCapabilityAcceptsRemoteConfig: true,
+			},
+		},
+	}, bridge)
+}
+
+func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
+	require.NoError(t, featuregate.Gates.Set("OpAMPBridge=true"))
+	defer func() {
+		require.NoError(t, featuregate.Gates.Set("OpAMPBridge=false"))
+	}()
+
+	tests := []struct { //nolint:govet
+		name        string
+		bridge      OpAMPBridge
+		expectedErr string
+	}{
+		{
+			name: "valid spec",
+			bridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint: "wss://opamp.example.com/v1/opamp",
+					Headers: []OpAMPBridgeHeader{{
+						Name: "Authorization",
+						ValueFrom: v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "opamp-credentials"},
+							Key:                  "token",
+						},
+					}},
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus:       true,
+						OpAMPBridgeCapabilityAcceptsRemoteConfig: true,
+						OpAMPBridgeCapabilityReportsHealth:       false,
+					},
+					Selector: map[string]string{"fleet": "edge"},
+				},
+			},
+		},
+		{
+			name:        "missing endpoint",
+			bridge:      OpAMPBridge{},
+			expectedErr: "the OpAMPBridge Spec endpoint configuration is incorrect",
+		},
+		{
+			name: "invalid endpoint scheme",
+			bridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{Endpoint: "tcp://opamp.example.com:4320"},
+			},
+			expectedErr: "the tcp scheme is neither ws, wss, http nor https",
+		},
+		{
+			name: "unknown capability",
+			bridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:     "ws://opamp:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{"ReportsEverything": true},
+				},
+			},
+			expectedErr: "ReportsEverything isn't an OpAMP capability",
+		},
+		{
+			name: "disabled status reports",
+			bridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:     "ws://opamp:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: false},
+				},
+			},
+			expectedErr: "the ReportsStatus capability can't be disabled",
+		},
+		{
+			name: "header without secret key",
+			bridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint: "ws://opamp:4320/v1/opamp",
+					Headers: []OpAMPBridgeHeader{{
+						Name: "Authorization",
+						ValueFrom: v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "opamp-credentials"},
+						},
+					}},
+				},
+			},
+			expectedErr: "the header Authorization requires the name and key of its Secret",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.bridge.validate()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOpAMPBridgeValidatingWebhookFeatureGate(t *testing.T) {
+	bridge := OpAMPBridge{
+		Spec: OpAMPBridgeSpec{Endpoint: "ws://opamp:4320/v1/opamp"},
+	}
+	assert.ErrorContains(t, bridge.validate(), "the operator feature gate OpAMPBridge is disabled")
+}
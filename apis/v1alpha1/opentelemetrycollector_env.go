@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const envFromCheckTimeout = 5 * time.Second
+
+// runtimeEnvVars are set in every container by the kubelet, or by most images.
+var runtimeEnvVars = map[string]bool{"HOSTNAME": true, "HOME": true, "PATH": true}
+
+// serviceLinkEnvVar matches the environment variables the kubelet sets for the services of the namespace, e.g.
+// KUBERNETES_SERVICE_HOST or KUBERNETES_PORT_443_TCP_ADDR.
+var serviceLinkEnvVar = regexp.MustCompile(`^[A-Z0-9_]+_(SERVICE_HOST|SERVICE_PORT(_[A-Z0-9_]+)?|PORT(_[0-9]+_(TCP|UDP|SCTP)(_PROTO|_PORT|_ADDR)?)?)$`)
+
+// EnvFromKeys returns the keys of the ConfigMap or Secret of an envFrom source in the namespace. When nil, or when
+// the keys can't be read, every environment variable with the prefix of the source is considered set.
+var EnvFromKeys func(ctx context.Context, namespace string, source v1.EnvFromSource) ([]string, error)
+
+// missingEnvVars returns the referenced environment variables the collector container doesn't get from env, envFrom,
+// the operator itself, or the kubelet.
+func (r *OpenTelemetryCollector) missingEnvVars(references []string) []string {
+	if len(references) == 0 || r.Spec.PodTemplateOverrides != nil {
+		// the overrides can add environment variables to the collector container
+		return nil
+	}
+
+	set := map[string]bool{"POD_NAME": true}
+	if r.Spec.TargetAllocator.Enabled || r.Spec.TargetAllocatorRef != "" {
+		set["SHARD"] = true
+	}
+	if r.Spec.Mode == ModeSidecar {
+		set["OTEL_RESOURCE_ATTRIBUTES"] = true
+		set[constants.EnvPodName] = true
+		set[constants.EnvPodUID] = true
+		set[constants.EnvNodeName] = true
+	}
+	for _, env := range r.Spec.Env {
+		set[env.Name] = true
+	}
+
+	var unknownPrefixes []string
+	ctx, cancel := context.WithTimeout(context.Background(), envFromCheckTimeout)
+	defer cancel()
+	for _, source := range r.Spec.EnvFrom {
+		if EnvFromKeys == nil {
+			unknownPrefixes = append(unknownPrefixes, source.Prefix)
+			continue
+		}
+		keys, err := EnvFromKeys(ctx, r.Namespace, source)
+		if err != nil {
+			// the source might be created along with the collector
+			opentelemetrycollectorlog.Error(err, "failed to get the keys of the envFrom source, skipping its verification", "name", r.Name)
+			unknownPrefixes = append(unknownPrefixes, source.Prefix)
+			continue
+		}
+		for _, key := range keys {
+			set[source.Prefix+key] = true
+		}
+	}
+
+	var missing []string
+	for _, name := range references {
+		if !set[name] && !runtimeEnvVars[name] && !serviceLinkEnvVar.MatchString(name) && !hasAnyPrefix(name, unknownPrefixes) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// UnsupportedArchitecturesAnnotation lists the node architectures the collector image isn't available for,
+	// as found by the defaulting webhook.
+	UnsupportedArchitecturesAnnotation = "opentelemetry.io/unsupported-architectures"
+
+	imageArchCheckTimeout = 5 * time.Second
+)
+
+// ImageArchitectures returns the architectures the image is available for. The verification of spec.image is
+// skipped when nil.
+var ImageArchitectures func(ctx context.Context, image string) ([]string, error)
+
+// NodeArchitectures returns the architectures of the cluster nodes.
+var NodeArchitectures func(ctx context.Context) ([]string, error)
+
+// defaultUnsupportedArchitectures records the node architectures missing from the manifest list of spec.image.
+// The instance is admitted anyway, the reconciliation reports them with the UnsupportedArchitecture condition.
+func (r *OpenTelemetryCollector) defaultUnsupportedArchitectures() {
+	if ImageArchitectures == nil || NodeArchitectures == nil {
+		return
+	}
+	if r.Spec.Image == "" {
+		// the operator's default collector image is multi-arch
+		delete(r.Annotations, UnsupportedArchitecturesAnnotation)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageArchCheckTimeout)
+	defer cancel()
+	nodes, err := NodeArchitectures(ctx)
+	if err != nil {
+		opentelemetrycollectorlog.Error(err, "failed to get the node architectures, skipping the image verification", "name", r.Name)
+		return
+	}
+	available, err := ImageArchitectures(ctx, r.Spec.Image)
+	if err != nil {
+		// the registry might not be reachable from the cluster, which shouldn't prevent the collector
+		opentelemetrycollectorlog.Error(err, "failed to get the image architectures, skipping the image verification", "name", r.Name, "image", r.Spec.Image)
+		return
+	}
+
+	supported := map[string]bool{}
+	for _, arch := range available {
+		supported[arch] = true
+	}
+	var missing []string
+	for _, arch := range nodes {
+		if !supported[arch] {
+			missing = append(missing, arch)
+		}
+	}
+	if len(missing) == 0 {
+		delete(r.Annotations, UnsupportedArchitecturesAnnotation)
+		return
+	}
+	sort.Strings(missing)
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[UnsupportedArchitecturesAnnotation] = strings.Join(missing, ",")
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MutatedFields are the fields of the OpenTelemetryCollector instances written by the defaulting webhook or by the
+// operator, which can be left to the sources of the instances with SkipMutations.
+var MutatedFields = []string{
+	"metadata.annotations",
+	"metadata.labels",
+	"spec.autoscaler",
+	"spec.configReloadStrategy",
+	"spec.gcPolicy",
+	"spec.ingress",
+	"spec.livenessProbe",
+	"spec.managementState",
+	"spec.mode",
+	"spec.podDisruptionBudget",
+	"spec.reconcilePolicy",
+	"spec.replicas",
+	"spec.revisionHistoryLimit",
+	"spec.service",
+	"spec.targetAllocator.replicas",
+	"spec.upgradeStrategy",
+}
+
+// skippedMutations holds the fields of the instances which aren't written by the defaulting webhook and the operator.
+var skippedMutations = map[string]bool{}
+
+// SkipMutations makes the defaulting webhook and the operator leave the given fields of the instances as set by their
+// sources, so that the GitOps tools don't see the instances drift from their sources. The reconciliation still applies
+// the defaults of these fields to its copy of the instances.
+func SkipMutations(fields []string) error {
+	skipped := map[string]bool{}
+	for _, field := range fields {
+		if !isMutatedField(field) {
+			return fmt.Errorf("the %s field isn't mutated, the mutated fields are %s", field, strings.Join(MutatedFields, ", "))
+		}
+		skipped[field] = true
+	}
+	skippedMutations = skipped
+	return nil
+}
+
+// Mutable returns whether the defaulting webhook and the operator can write the given field of the instances.
+func Mutable(field string) bool {
+	return !skippedMutations[field]
+}
+
+func isMutatedField(field string) bool {
+	for _, mutated := range MutatedFields {
+		if mutated == field {
+			return true
+		}
+	}
+	return false
+}
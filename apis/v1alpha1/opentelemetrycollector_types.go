@@ -15,10 +15,13 @@
 package v1alpha1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Ingress is used to specify how OpenTelemetry Collector is exposed. This
@@ -33,7 +36,7 @@ import (
 // SEE: OpenTelemetryCollector.spec.ports[index].
 type Ingress struct {
 	// Type default value is: ""
-	// Supported types are: ingress
+	// Supported types are: ingress, route and gateway
 	Type IngressType `json:"type,omitempty"`
 
 	// Hostname by which the ingress proxy can be reached.
@@ -55,16 +58,57 @@ type Ingress struct {
 	// +optional
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// RuleType defines how Ingress exposes collector receivers.
+	// IngressRuleTypePath ("path") exposes each receiver port on a unique path on single domain defined in Hostname.
+	// IngressRuleTypeSubdomain ("subdomain") exposes each receiver port on a unique subdomain of Hostname.
+	// Default is IngressRuleTypePath ("path").
+	// +optional
+	RuleType IngressRuleType `json:"ruleType,omitempty"`
+
 	// Route is an OpenShift specific section that is only considered when
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
+
+	// Gateway is a Gateway API specific section that is only considered when
+	// type "gateway" is used.
+	// +optional
+	Gateway GatewayRoute `json:"gateway,omitempty"`
 }
 
 // OpenShiftRoute defines openshift route specific settings.
 type OpenShiftRoute struct {
 	// Termination indicates termination type. By default "edge" is used.
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+
+	// HostnameTemplate is the host of the route created for each exposed port. The placeholders
+	// {port}, {hostname}, {name} and {namespace} are replaced by the port name, the ingress hostname,
+	// and the name and namespace of the OpenTelemetryCollector. By default "{port}.{hostname}" is used.
+	// +optional
+	HostnameTemplate string `json:"hostnameTemplate,omitempty"`
+}
+
+// GatewayRoute defines the Gateway API specific settings.
+type GatewayRoute struct {
+	// ParentRef is the Gateway the HTTPRoutes and GRPCRoutes are attached to.
+	// +optional
+	ParentRef GatewayParentReference `json:"parentRef,omitempty"`
+}
+
+// GatewayParentReference identifies the Gateway, and optionally its listener, the routes are attached to.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the Gateway. By default the namespace of the OpenTelemetryCollector is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener the routes are attached to.
+	// By default the routes are attached to all the listeners allowing them.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
@@ -93,21 +137,52 @@ type OpenTelemetryCollectorSpec struct {
 	//
 	// +optional
 	Autoscaler *AutoscalerSpec `json:"autoscaler,omitempty"`
-	// SecurityContext will be set as the container security context.
+	// PodDisruptionBudget makes the operator manage a PodDisruptionBudget limiting the collector pods evicted at once,
+	// e.g. by node drains. It's only supported in the deployment and statefulset modes.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// SecurityContext will be set as the container security context. Unless set otherwise, the privilege escalation
+	// is disabled and all the capabilities are dropped, as required by the restricted Pod Security Standard.
 	// +optional
 	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
-
+	// PodSecurityContext will be set as the pod security context. Unless set otherwise, the pods run as non-root with
+	// the RuntimeDefault seccomp profile, as required by the restricted Pod Security Standard.
+	// +optional
 	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// ShareProcessNamespace indicates if the containers of the collector pods share a single process namespace.
+	// Defaults to true with the reload config strategy, which needs it to signal the collector, and false otherwise.
+	// Not supported with the sidecar mode.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
 	// PodAnnotations is the set of annotations that will be attached to
 	// Collector and Target Allocator pods.
 	// +optional
 	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// AdditionalLabels are added to the labels of all the objects generated for the instance, including the pods. They
+	// override the labels of the instance, but not the labels set by the operator.
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+	// AdditionalAnnotations are added to the annotations propagated from the instance to the generated objects, and to
+	// the annotations of the pods. They override the annotations of the instance, and are overridden by
+	// spec.podAnnotations on the pods.
+	// +optional
+	AdditionalAnnotations map[string]string `json:"additionalAnnotations,omitempty"`
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator OpenTelemetryTargetAllocator `json:"targetAllocator,omitempty"`
+	// TargetAllocatorRef is the name of a TargetAllocator, in the namespace of the instance, allocating the targets of
+	// its prometheus receiver among the collectors of all the instances referencing it. It can't be set along with an
+	// enabled TargetAllocator, and it's only supported in the statefulset and daemonset modes.
+	// +optional
+	TargetAllocatorRef string `json:"targetAllocatorRef,omitempty"`
 	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
+	// NativeSidecar injects the collector as a native sidecar, i.e. an init container restarting always, so it starts
+	// before the containers of the pod and terminates after them. Only available when the mode=sidecar, and it requires
+	// the SidecarContainers feature gate of Kubernetes 1.28+, enabled by default since 1.29.
+	// +optional
+	NativeSidecar bool `json:"nativeSidecar,omitempty"`
 	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
 	// the operator will not automatically create a ServiceAccount for the collector.
 	// +optional
@@ -115,16 +190,56 @@ type OpenTelemetryCollectorSpec struct {
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
+	// Version pins the version of the OpenTelemetry Collector, e.g. 0.61.0. It selects the tag of the default collector
+	// image, and the automatic upgrades of the CR stop at this version instead of the one shipped with the operator.
+	// +optional
+	Version string `json:"version,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
 	// +optional
 	UpgradeStrategy UpgradeStrategy `json:"upgradeStrategy"`
+	// ConfigReloadStrategy represents how the collector pods pick up the changes of the config: they're either rolled
+	// out (restart), or a sidecar sends a SIGHUP to the running collectors once the kubelet updated their config file
+	// (reload). The config changes affecting the ports or the liveness probe of the collector container still roll out
+	// the pods, as do all the changes of the collectors running on Windows nodes. The reload strategy isn't supported
+	// in the sidecar mode.
+	// +optional
+	ConfigReloadStrategy ConfigReloadStrategy `json:"configReloadStrategy,omitempty"`
+	// ManagementState defines whether the operator manages the resources of the CR (managed) or leaves them, and the
+	// manual changes made to them, as they are (unmanaged). The deletion of an unmanaged CR is still handled.
+	// +optional
+	ManagementState ManagementStateType `json:"managementState,omitempty"`
+	// ReconcilePolicy represents when the operator reconciles the CR: on every change (Always), only when the
+	// "opentelemetry.io/trigger-reconcile" annotation is set to "true" (OnDemand) or following the
+	// ReconcileSchedule (Scheduled).
+	// +optional
+	ReconcilePolicy ReconcilePolicy `json:"reconcilePolicy,omitempty"`
+	// ReconcileSchedule is the cron schedule, in the standard five fields format, used with the Scheduled reconcile policy.
+	// +optional
+	ReconcileSchedule string `json:"reconcileSchedule,omitempty"`
+	// GCPolicy represents what happens to the resources created for the CR when the CR is deleted (Foreground, Background or Orphan).
+	// With Foreground, the resources are deleted before the CR is deleted. With Background, the CR is deleted right away
+	// and the resources are garbage collected afterwards. With Orphan, the owner references are removed from the
+	// resources before the CR is deleted, leaving them in place. A foreground cascading deletion of the CR still deletes
+	// the resources.
+	// +optional
+	GCPolicy GCPolicy `json:"gcPolicy,omitempty"`
 
 	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	// Config is the raw JSON to be used as the collector's configuration. Refer to the OpenTelemetry Collector documentation for details.
+	// Values can embed CEL expressions written as ${expression}, with the spec and metadata of this resource available as cr,
+	// e.g. ${cr.metadata.name}.
 	// +required
 	Config string `json:"config,omitempty"`
+	// ConfigSources are ConfigMap and Secret keys holding parts of the collector config, labeled
+	// opentelemetry.io/config-source=true. The ConfigMap sources are merged in order under the inline Config, which takes
+	// precedence, and written to the collector's ConfigMap. The Secret sources are passed to the collector as
+	// environment variables, and merged by the collector under that config. The maps of the configs are merged
+	// recursively, the other values replaced.
+	// +optional
+	// +listType=atomic
+	ConfigSources []ConfigSource `json:"configSources,omitempty"`
 	// VolumeMounts represents the mount points to use in the underlying collector deployment(s)
 	// +optional
 	// +listType=atomic
@@ -144,9 +259,30 @@ type OpenTelemetryCollectorSpec struct {
 	// +optional
 	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
 	// VolumeClaimTemplates will provide stable storage using PersistentVolumes. Only available when the mode=statefulset.
+	// The claims that aren't mounted by VolumeMounts are mounted at /var/lib/otelcol/<claim name>.
 	// +optional
 	// +listType=atomic
 	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	// DeploymentUpdateStrategy is the strategy replacing the collector pods of the Deployment with new ones.
+	// Only supported with the deployment mode.
+	// +optional
+	DeploymentUpdateStrategy appsv1.DeploymentStrategy `json:"deploymentUpdateStrategy,omitempty"`
+	// DaemonSetUpdateStrategy is the strategy replacing the collector pods of the DaemonSet with new ones, e.g. a rolling
+	// update with a maxUnavailable or maxSurge, or OnDelete. Only supported with the daemonset mode.
+	// +optional
+	DaemonSetUpdateStrategy appsv1.DaemonSetUpdateStrategy `json:"daemonSetUpdateStrategy,omitempty"`
+	// StatefulSetUpdateStrategy is the strategy replacing the collector pods of the StatefulSet with new ones.
+	// Only supported with the statefulset mode.
+	// +optional
+	StatefulSetUpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"statefulSetUpdateStrategy,omitempty"`
+	// PodManagementPolicy controls how the collector pods of the StatefulSet are created and deleted, defaults to Parallel.
+	// Changing it recreates the StatefulSet. Only supported with the statefulset mode.
+	// +optional
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy describes the lifecycle of the claims created from VolumeClaimTemplates.
+	// Only available when the mode=statefulset, and it requires the StatefulSetAutoDeletePVC feature gate of Kubernetes.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
 	// Toleration to schedule OpenTelemetry Collector pods.
 	// This is only relevant to daemonset, statefulset, and deployment mode
 	// +optional
@@ -155,6 +291,23 @@ type OpenTelemetryCollectorSpec struct {
 	// +optional
 	// +listType=atomic
 	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// InitContainers run before the collector in its pods, e.g. to fetch GeoIP databases or render secrets to disk
+	// in one of the volumes. Not supported with the sidecar mode.
+	// +optional
+	// +listType=atomic
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// AdditionalContainers run alongside the collector in its pods. Their names can't start with "otc-", which is
+	// reserved for the containers of the operator. Not supported with the sidecar mode.
+	// +optional
+	// +listType=atomic
+	AdditionalContainers []v1.Container `json:"additionalContainers,omitempty"`
+	// PodTemplateOverrides is a strategic merge patch applied to the pod template of the collector as a last step,
+	// e.g. to set the hostAliases or fields not exposed by the CR. The selector labels of the pods can't be patched.
+	// Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	PodTemplateOverrides *runtime.RawExtension `json:"podTemplateOverrides,omitempty"`
 	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
 	// functionality is only available if one of the valid modes is set.
 	// Valid modes are: deployment, daemonset and statefulset.
@@ -163,25 +316,438 @@ type OpenTelemetryCollectorSpec struct {
 	// HostNetwork indicates if the pod should run in the host networking namespace.
 	// +optional
 	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// DNSPolicy is the DNS policy of the collector pods. Defaults to ClusterFirstWithHostNet when the pods run in the
+	// host networking namespace and to ClusterFirst otherwise. Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNSConfig is the DNS parameters of the collector pods, merged with the ones generated from the DNSPolicy.
+	// It's required with the None DNS policy. Not supported with the sidecar mode.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
 	// If specified, indicates the pod's priority.
 	// If not specified, the pod priority will be default or zero if there is no
 	// default.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// ShutdownTimeout is the time given to the collector to drain its connections when its pods are terminated,
+	// e.g. "30s". The pods get a termination grace period of this duration plus 10 seconds.
+	// Only supported with the deployment mode.
+	// +optional
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty"`
+	// ShutdownPreStopSleep adds a preStop hook sleeping for 5 seconds along with the shutdownTimeout, so that load
+	// balancers stop sending traffic before the collector receives SIGTERM. The hook runs the sleep command, which
+	// isn't part of the distroless collector images.
+	// +optional
+	ShutdownPreStopSleep bool `json:"shutdownPreStopSleep,omitempty"`
+	// Lifecycle is the lifecycle of the collector container, e.g. a preStop hook draining the in-flight batches
+	// before the collector receives SIGTERM. It replaces the preStop hook set up by the shutdownPreStopSleep.
+	// +optional
+	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
+	// TerminationGracePeriodSeconds is the time given to the collector pods to terminate, including the preStop hook.
+	// It takes precedence over the grace period derived from the shutdownTimeout.
+	// Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets of the collector Deployment kept to allow rollbacks.
+	// Defaults to 1. Only supported with the deployment mode.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
 	// If specified, indicates the pod's scheduling constraints
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints describes how the collector pods are spread across the topology domains, e.g. the zones
+	// of the cluster. It's not supported in the sidecar mode.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// AntiAffinityTarget defines the pods the collector pods aren't scheduled next to, on top of Affinity.
+	// +optional
+	AntiAffinityTarget *AntiAffinityTargetSpec `json:"antiAffinityTarget,omitempty"`
+	// RuntimeClassName is the name of the RuntimeClass running the collector pods, e.g. kata or gvisor
+	// for VM-based container runtimes.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// Overhead is the resource overhead of the collector pods on top of their containers, consumed by the
+	// VM-based runtime set with RuntimeClassName. It needs to match the overhead of the RuntimeClass, if any.
+	// +optional
+	Overhead v1.ResourceList `json:"overhead,omitempty"`
+	// Service defines how the Service exposing the OpenTelemetry Collector receivers is created.
+	// +optional
+	Service ServiceSpec `json:"service,omitempty"`
+	// EncryptionKeyRef references the AES-256 key used to decrypt the values of the config wrapped as
+	// "enc:<base64-ciphertext>". The decrypted values are only written to the collector's ConfigMap.
+	// +optional
+	EncryptionKeyRef *v1.SecretKeySelector `json:"encryptionKeyRef,omitempty"`
+	// PreDeployCheck defines a Job the operator runs, and waits to succeed, before applying a config or image
+	// update to the collector.
+	// +optional
+	PreDeployCheck *PreDeployCheckSpec `json:"preDeployCheck,omitempty"`
+	// Build defines a custom collector distribution, containing only the components of its manifest. The operator
+	// builds it with the OpenTelemetry Collector Builder, pushes its image, and deploys it instead of the image of
+	// the spec. Not supported with the sidecar mode.
+	// +optional
+	Build *BuildSpec `json:"build,omitempty"`
+	// SmokeTest defines the test sending spans to the collector after each rollout of its Deployment.
+	// The results are recorded as CollectorSmokeTest objects. Only supported with the deployment mode.
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+	// Canary defines the canary rollout of the collector config and image changes: they first run in a separate canary
+	// Deployment, and are only applied to the collector Deployment once the canary stayed healthy for a bake period.
+	// Only supported with the deployment mode.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// LivenessProbe defines the liveness probe of the collector container. When unset, the webhook chooses the
+	// gRPC probe on clusters supporting it (Kubernetes 1.24+), except for sidecars.
+	// +optional
+	LivenessProbe *LivenessProbeSpec `json:"livenessProbe,omitempty"`
+	// ReadinessProbe defines the readiness probe of the collector container, which checks the same endpoint as its
+	// liveness probe. The collector container has no readiness probe when unset.
+	// +optional
+	ReadinessProbe *ProbeSpec `json:"readinessProbe,omitempty"`
+	// StartupProbe defines the startup probe of the collector container, which checks the same endpoint as its liveness
+	// probe. It holds the other probes until the collector started, e.g. for the collectors with large tail sampling
+	// buffers. The collector container has no startup probe when unset.
+	// +optional
+	StartupProbe *ProbeSpec `json:"startupProbe,omitempty"`
+	// MemoryTuning derives the memory settings of the collector from the memory limit of its container, keeping them
+	// in sync with the resources. Nothing is derived unless one of its percentages is set.
+	// +optional
+	MemoryTuning MemoryTuningSpec `json:"memoryTuning,omitempty"`
+	// TLS defines how the TLS settings of the receivers and exporters in the config are checked.
+	// +optional
+	TLS TLSSpec `json:"tls,omitempty"`
+	// FederationRef references the Cluster API cluster the collector is deployed to, instead of the cluster
+	// running the operator.
+	// +optional
+	FederationRef *FederationRef `json:"federationRef,omitempty"`
+	// EventExport defines an external event bus the operator publishes the state transitions of this instance to.
+	// +optional
+	EventExport EventExportSpec `json:"eventExport,omitempty"`
+	// Observability defines how the self-telemetry of the collector is collected.
+	// +optional
+	Observability ObservabilitySpec `json:"observability,omitempty"`
+	// NetworkPolicy makes the operator manage NetworkPolicies only allowing the ingress traffic to the ports of the
+	// collector and target allocator pods.
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+	// RBAC defines the permissions the operator grants to the collector for the components of its config reading from
+	// the Kubernetes API.
+	// +optional
+	RBAC RBACSpec `json:"rbac,omitempty"`
+}
+
+// ConfigSource references a ConfigMap or Secret key, in the namespace of the instance, holding a part of the collector config.
+type ConfigSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret.
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ServiceSpec defines the OpenTelemetryCollector's Service specification.
+type ServiceSpec struct {
+	// Type determines how the collector Service is exposed. Valid options are ClusterIP, NodePort and LoadBalancer.
+	// Defaults to ClusterIP.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type v1.ServiceType `json:"type,omitempty"`
+	// NodePorts maps a receiver port name to the node port it should be exposed on.
+	// Only considered when type is NodePort or LoadBalancer. Ports not listed here get a
+	// node port allocated by Kubernetes.
+	// +optional
+	NodePorts map[string]int32 `json:"nodePorts,omitempty"`
+	// LoadBalancerIP is the IP requested from the cloud provider for the load balancer.
+	// Only considered when type is LoadBalancer.
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// LoadBalancerSourceRanges restricts the client IPs allowed to reach the load balancer.
+	// Only considered when type is LoadBalancer.
+	// +optional
+	// +listType=atomic
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// AllowLoadBalancerWithDaemonSet acknowledges that a LoadBalancer Service in front of a
+	// daemonset is intended. Without it, the type LoadBalancer is rejected for the daemonset mode.
+	// +optional
+	AllowLoadBalancerWithDaemonSet bool `json:"allowLoadBalancerWithDaemonSet,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness of the collector Services, including the headless, monitoring,
+	// split and target allocator Services. Valid options are SingleStack, PreferDualStack and RequireDualStack. The
+	// dual-stack policies are only accepted when the cluster supports them.
+	// +optional
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// IPFamilies lists the IP families (IPv4, IPv6) assigned to the collector Services, the first one being their
+	// primary family.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=2
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// Split generates a ClusterIP Service for each receiver ("receiver") or for each receiver port ("protocol"), next to
+	// the collector Service. The ports of .Spec.Ports get a Service each in both cases.
+	// +optional
+	Split ServiceSplitType `json:"split,omitempty"`
+	// SplitServices customizes the split Services, keyed by the receiver name, e.g. "otlp/internal", when split by
+	// receiver, or by the port name, e.g. "otlp-grpc", when split by protocol.
+	// +optional
+	SplitServices map[string]SplitServiceSpec `json:"splitServices,omitempty"`
+}
+
+// SplitServiceSpec defines the settings of a split collector Service.
+type SplitServiceSpec struct {
+	// Headless makes the Service headless, for instance for the client-side load-balancing of gRPC. The headless
+	// Services are named with a "-headless" suffix.
+	// +optional
+	Headless bool `json:"headless,omitempty"`
+	// Annotations to add to the Service, on top of the annotations of the OpenTelemetryCollector.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AntiAffinityTargetSpec defines the pods whose nodes the collector pods must not be scheduled on.
+type AntiAffinityTargetSpec struct {
+	// PodLabelSelector selects the pods, e.g. latency-sensitive applications, the collector pods avoid.
+	// +required
+	PodLabelSelector *metav1.LabelSelector `json:"podLabelSelector"`
+	// Namespaces are the namespaces of the selected pods. Defaults to the namespace of the collector.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// TLSSpec defines how the TLS settings of the collector config are checked.
+type TLSSpec struct {
+	// EnforceSecure rejects configs with receivers or exporters setting "insecure: true" or
+	// "insecure_skip_verify: true". Otherwise, those settings are reported with the InsecureTLSConfig condition.
+	// +optional
+	EnforceSecure bool `json:"enforceSecure,omitempty"`
+	// ExpiryWarningDays is the number of days before the expiry of a receiver TLS certificate the operator starts
+	// reporting it with CertificateExpiringSoon events and the CertificateExpiringSoon condition. Defaults to 30.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ExpiryWarningDays *int32 `json:"expiryWarningDays,omitempty"`
+	// IssuerRef makes the operator issue the certificates of the receivers serving TLS with cert-manager. Each
+	// receiver with a "cert_file" and a "key_file" in its TLS settings gets a Certificate, whose Secret is mounted at
+	// the directory of these files, unless they're mounted by the volumeMounts. The collector pods are restarted when
+	// the certificates are renewed.
+	// +optional
+	IssuerRef *CertificateIssuerRef `json:"issuerRef,omitempty"`
+	// DNSNames are added to the names of the collector Services in the receiver certificates, e.g. the host of an
+	// ingress.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// PreDeployCheckSpec defines the Job verifying a collector update before it's applied.
+type PreDeployCheckSpec struct {
+	// Image is the container image running the check.
+	// +required
+	Image string `json:"image"`
+	// Command is the entrypoint of the check container. The check passes when it exits successfully.
+	// +optional
+	// +listType=atomic
+	Command []string `json:"command,omitempty"`
+}
+
+// BuildSpec defines the custom collector distribution built with the OpenTelemetry Collector Builder (OCB).
+type BuildSpec struct {
+	// Manifest is the OCB manifest listing the Go modules of the components of the distribution. The operator sets
+	// the name and the output path of its dist section, and its otelcol_version defaults to the collector version of
+	// the operator.
+	// +required
+	Manifest string `json:"manifest"`
+	// Image is the repository the image of the distribution is pushed to, e.g. registry.example.com/team-a/otelcol.
+	// The image is tagged with the hash of the manifest.
+	// +required
+	Image string `json:"image"`
+	// PushSecret references the Secret of type kubernetes.io/dockerconfigjson holding the credentials of the registry.
+	// +optional
+	PushSecret *v1.LocalObjectReference `json:"pushSecret,omitempty"`
+	// Resources of the build, compiling the distribution needs a few GiB of memory.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// FederationRef references a cluster managed by Cluster API.
+type FederationRef struct {
+	// ClusterName is the name of the Cluster API Cluster. The operator connects to it with the kubeconfig
+	// stored in the "<clusterName>-kubeconfig" Secret.
+	// +required
+	ClusterName string `json:"clusterName"`
+	// Namespace is the namespace of the Cluster API Cluster and of its kubeconfig Secret. It has to be the namespace
+	// of the OpenTelemetryCollector, which it defaults to.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// SmokeTestSpec defines the smoke test of the collector rollouts.
+// The test sends 10 spans to the OTLP/HTTP receiver on port 4318 of a collector pod, and checks that the
+// zpages extension on port 55679 lists the received traces in /debug/tracez.
+type SmokeTestSpec struct {
+	// Image is the container image running the test, it needs a shell and curl.
+	// Defaults to curlimages/curl.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Rollback indicates whether the collector Deployment is rolled back to its previous revision when the test fails.
+	// The failed config and image aren't deployed again until they change.
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// CanarySpec defines the canary rollout of the collector changes. The canary replicas receive their share of the
+// traffic of the collector Service, and fail when one of their containers restarts or when they fail to export more
+// than the allowed percentage of the spans, metric points and log records they send, according to the
+// otelcol_exporter_sent_* and otelcol_exporter_send_failed_* metrics of their telemetry endpoint.
+type CanarySpec struct {
+	// Replicas is the number of replicas of the canary Deployment. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// BakeDuration is how long the canary replicas must stay healthy before the changes are promoted. Defaults to 10m.
+	// +optional
+	BakeDuration *metav1.Duration `json:"bakeDuration,omitempty"`
+	// MaxExportFailurePercent is the percentage of failed exports above which the canary fails. Defaults to 5.
+	// +optional
+	MaxExportFailurePercent *int32 `json:"maxExportFailurePercent,omitempty"`
+}
+
+type (
+	// ProbeProtocol represents how the liveness of the collector is checked.
+	// +kubebuilder:validation:Enum=http;grpc
+	ProbeProtocol string
+)
+
+const (
+	// ProbeProtocolHTTP checks the endpoint of the health_check extension.
+	ProbeProtocolHTTP ProbeProtocol = "http"
+
+	// ProbeProtocolGRPC uses the gRPC health checking protocol on the port of the OTLP gRPC receiver.
+	ProbeProtocolGRPC ProbeProtocol = "grpc"
+)
+
+// LivenessProbeSpec defines the liveness probe of the collector container.
+type LivenessProbeSpec struct {
+	// Protocol is either http, probing the health_check extension, or grpc, probing the OTLP gRPC receiver with the
+	// gRPC health checking protocol. The http probe is used when the config has no OTLP gRPC receiver.
+	// +optional
+	Protocol ProbeProtocol `json:"protocol,omitempty"`
+
+	ProbeSpec `json:",inline"`
+}
+
+// MemoryTuningSpec defines the memory settings of the collector derived from the memory limit of its container. Nothing
+// is derived when the container has no memory limit.
+type MemoryTuningSpec struct {
+	// GoMemLimitPercentage is the percentage of the memory limit set as the GOMEMLIMIT environment variable of the
+	// collector container. The variable isn't set when unset, or when it's already part of spec.env.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	GoMemLimitPercentage *int32 `json:"goMemLimitPercentage,omitempty"`
+	// MemoryLimiterPercentage is the percentage of the memory limit set as the limit_mib of the memory_limiter
+	// processors of the config. The processors are left untouched when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MemoryLimiterPercentage *int32 `json:"memoryLimiterPercentage,omitempty"`
+	// MemoryLimiterSpikePercentage is the percentage of the memory limit set as the spike_limit_mib of the
+	// memory_limiter processors, along with their limit_mib. The processors default to 20% of their limit_mib when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MemoryLimiterSpikePercentage *int32 `json:"memoryLimiterSpikePercentage,omitempty"`
+}
+
+// ProbeSpec tunes a probe of the collector container. The unset fields keep the defaults of Kubernetes.
+type ProbeSpec struct {
+	// InitialDelaySeconds is the number of seconds after the container started before the probe starts.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// PeriodSeconds is how often the probe is performed, in seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// SuccessThreshold is the number of consecutive successes for the probe to be considered successful after having
+	// failed. It must be 1 for the liveness and startup probes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// FailureThreshold is the number of consecutive failures for the probe to be considered failed after having
+	// succeeded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+type (
+	// EventExportType represents the kind of event bus the events are published to.
+	// +kubebuilder:validation:Enum=nats;kafka
+	EventExportType string
+)
+
+const (
+	// EventExportTypeNATS specifies that events are published to a NATS server.
+	EventExportTypeNATS EventExportType = "nats"
+
+	// EventExportTypeKafka specifies that events are published to a Kafka topic.
+	EventExportTypeKafka EventExportType = "kafka"
+)
+
+// EventExportSpec defines the event bus the state transitions of the OpenTelemetryCollector are published to.
+type EventExportSpec struct {
+	// Enabled indicates whether the operator publishes events for this instance.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Type is the kind of event bus to publish to (nats or kafka).
+	// +optional
+	Type EventExportType `json:"type,omitempty"`
+	// Brokers is the list of NATS server URLs or Kafka broker addresses.
+	// +optional
+	// +listType=atomic
+	Brokers []string `json:"brokers,omitempty"`
+	// Topic is the NATS subject or Kafka topic the events are published to.
+	// Defaults to opentelemetry-operator.events.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+}
+
+// ObservabilitySpec defines how the self-telemetry of the collector is collected.
+type ObservabilitySpec struct {
+	// Metrics defines how the internal metrics of the collector are scraped.
+	// +optional
+	Metrics MetricsConfigSpec `json:"metrics,omitempty"`
+}
+
+// MetricsConfigSpec defines the Prometheus operator objects scraping the internal metrics of the collector.
+type MetricsConfigSpec struct {
+	// EnableMetrics makes the operator create a ServiceMonitor scraping the monitoring Service of the collector, or a
+	// PodMonitor scraping the collector pods in the daemonset and sidecar modes. The Prometheus operator needs to be
+	// installed.
+	// +optional
+	EnableMetrics bool `json:"enableMetrics,omitempty"`
+	// Labels are added to the ServiceMonitor or PodMonitor, e.g. to match the serviceMonitorSelector or
+	// podMonitorSelector of a Prometheus.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // OpenTelemetryTargetAllocator defines the configurations for the Prometheus target allocator.
 type OpenTelemetryTargetAllocator struct {
 	// Replicas is the number of pod instances for the underlying TargetAllocator. This should only be set to a value
-	// other than 1 if a strategy that allows for high availability is chosen. Currently, the only allocation strategy
-	// that can be run in a high availability mode is consistent-hashing.
+	// other than 1 if a strategy that allows for high availability is chosen. The consistent-hashing and per-node
+	// allocation strategies assign the same targets on all the replicas, which are spread over the nodes and evicted
+	// one at a time.
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted and consistent-hashing. The default option is least-weighted
+	// The current options are least-weighted, consistent-hashing and per-node. The default option is least-weighted.
+	// The per-node strategy assigns the targets to the collector running on their node, and is the only one supported
+	// in the daemonset mode.
 	// +optional
 	AllocationStrategy OpenTelemetryTargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
@@ -200,9 +766,74 @@ type OpenTelemetryTargetAllocator struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
 	// PrometheusCR defines the configuration for the retrieval of PrometheusOperator CRDs ( servicemonitor.monitoring.coreos.com/v1 and podmonitor.monitoring.coreos.com/v1 )  retrieval.
-	// All CR instances which the ServiceAccount has access to will be retrieved. This includes other namespaces.
+	// All CR instances which the ServiceAccount has access to, and which match the selectors, will be retrieved. The other namespaces are only included when selected by the namespace selectors.
 	// +optional
 	PrometheusCR OpenTelemetryTargetAllocatorPrometheusCR `json:"prometheusCR,omitempty"`
+	// MTLS secures the distribution of the scrape configs between the TargetAllocator and the collector with mutual
+	// TLS. The TargetAllocator Service is then exposed on port 443.
+	// +optional
+	MTLS *TargetAllocatorMTLS `json:"mtls,omitempty"`
+	// AllocationRules override the allocation of the targets of the matching jobs, in order, with the first
+	// matching rule applying. The targets of the other jobs are allocated with the AllocationStrategy among the
+	// collectors not dedicated to a rule.
+	// +optional
+	// +listType=atomic
+	AllocationRules []TargetAllocatorAllocationRule `json:"allocationRules,omitempty"`
+}
+
+// TargetAllocatorAllocationRuleAction is the action of an allocation rule on the targets of its jobs.
+// +kubebuilder:validation:Enum=allocate;drop
+type TargetAllocatorAllocationRuleAction string
+
+const (
+	// TargetAllocatorAllocationRuleActionAllocate allocates the targets with the strategy and among the collectors of the rule.
+	TargetAllocatorAllocationRuleActionAllocate TargetAllocatorAllocationRuleAction = "allocate"
+
+	// TargetAllocatorAllocationRuleActionDrop drops the targets, which are allocated to no collector.
+	TargetAllocatorAllocationRuleActionDrop TargetAllocatorAllocationRuleAction = "drop"
+)
+
+// TargetAllocatorAllocationRule overrides the allocation of the targets of some scrape jobs.
+type TargetAllocatorAllocationRule struct {
+	// Name of the rule, which labels its allocation metrics. It has to be unique, and can't be default.
+	Name string `json:"name"`
+	// Jobs are the regular expressions matching the whole names of the scrape jobs of the rule.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Jobs []string `json:"jobs"`
+	// Action is allocate, the default, or drop.
+	// +optional
+	Action TargetAllocatorAllocationRuleAction `json:"action,omitempty"`
+	// AllocationStrategy allocating the targets of the rule. Defaults to the AllocationStrategy of the target
+	// allocator.
+	// +optional
+	AllocationStrategy OpenTelemetryTargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// Collectors is the regular expression matching the whole names of the collector pods dedicated to the rule,
+	// which are assigned no other targets. The targets are allocated among the collectors not dedicated to a rule
+	// when unset.
+	// +optional
+	Collectors string `json:"collectors,omitempty"`
+}
+
+// TargetAllocatorMTLS defines the certificates of the TargetAllocator server and of the collector client.
+type TargetAllocatorMTLS struct {
+	// IssuerRef references the cert-manager Issuer, or ClusterIssuer, issuing the server and client certificates.
+	// The issued secrets have to include the CA certificate, like with the CA and self-signed issuers. When unset, the
+	// operator issues the certificates from a self-signed CA it generates, and renews them before they expire.
+	// +optional
+	IssuerRef *CertificateIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// CertificateIssuerRef references a cert-manager issuer.
+type CertificateIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer or ClusterIssuer. Defaults to Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. Defaults to cert-manager.io, the group of the external issuers can be set instead.
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 type OpenTelemetryTargetAllocatorPrometheusCR struct {
@@ -219,6 +850,16 @@ type OpenTelemetryTargetAllocatorPrometheusCR struct {
 	// ServiceMonitor's meta labels. The requirements are ANDed.
 	// +optional
 	ServiceMonitorSelector map[string]string `json:"serviceMonitorSelector,omitempty"`
+	// Namespaces to select PodMonitors from.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in the
+	// namespace's meta labels. The requirements are ANDed. When unset, only the PodMonitors of the namespace of the target allocator are selected.
+	// +optional
+	PodMonitorNamespaceSelector map[string]string `json:"podMonitorNamespaceSelector,omitempty"`
+	// Namespaces to select ServiceMonitors from.
+	// This is a map of {key,value} pairs. Each {key,value} in the map is going to exactly match a label in the
+	// namespace's meta labels. The requirements are ANDed. When unset, only the ServiceMonitors of the namespace of the target allocator are selected.
+	// +optional
+	ServiceMonitorNamespaceSelector map[string]string `json:"serviceMonitorNamespaceSelector,omitempty"`
 }
 
 // ScaleSubresourceStatus defines the observed state of the OpenTelemetryCollector's
@@ -233,8 +874,58 @@ type ScaleSubresourceStatus struct {
 	// OpenTelemetryCollector's deployment or statefulSet.
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// StatusReplicas is the number of ready pods over the desired number of pods of the OpenTelemetryCollector's
+	// deployment, daemonSet or statefulSet, e.g. 2/3.
+	// +optional
+	StatusReplicas string `json:"statusReplicas,omitempty"`
 }
 
+const (
+	// ConditionTypePreDeployCheckFailed is set when the pre-deploy check Job of a collector update fails.
+	ConditionTypePreDeployCheckFailed = "PreDeployCheckFailed"
+
+	// ConditionTypeBuildFailed is set when the build of the custom collector distribution fails.
+	ConditionTypeBuildFailed = "BuildFailed"
+
+	// ConditionTypeUnsupportedComponents is set when the config defines components which aren't part of the
+	// distribution of the collector image.
+	ConditionTypeUnsupportedComponents = "UnsupportedComponents"
+
+	// ConditionTypeInsecureTLSConfig is set when receivers or exporters of the config have insecure TLS settings.
+	ConditionTypeInsecureTLSConfig = "InsecureTLSConfig"
+
+	// ConditionTypeIncompleteConfig is set when the config references undefined components or defines unused ones.
+	// The collector ConfigMap isn't updated while the condition is set.
+	ConditionTypeIncompleteConfig = "IncompleteConfig"
+
+	// ConditionTypeCertificateExpiringSoon is set when the TLS certificate of a receiver expires within
+	// spec.tls.expiryWarningDays.
+	ConditionTypeCertificateExpiringSoon = "CertificateExpiringSoon"
+
+	// ConditionTypeUnsupportedArchitecture is set when spec.image isn't available for some of the node architectures.
+	ConditionTypeUnsupportedArchitecture = "UnsupportedArchitecture"
+
+	// ConditionTypeConfigValid reports whether the config, after merging its sources and evaluating its templates,
+	// can be parsed.
+	ConditionTypeConfigValid = "ConfigValid"
+
+	// ConditionTypeWorkloadReady reports whether all the replicas of the collector Deployment, DaemonSet or StatefulSet
+	// are ready. It isn't set for the sidecar mode.
+	ConditionTypeWorkloadReady = "WorkloadReady"
+
+	// ConditionTypeReconciled reports whether the last reconciliation of the instance succeeded.
+	ConditionTypeReconciled = "Reconciled"
+
+	// ConditionTypeCanaryRollout reports the canary rollout of the collector changes: it is True while the canary
+	// runs, and False once the changes are promoted or the canary failed.
+	ConditionTypeCanaryRollout = "CanaryRollout"
+
+	// ConditionTypeRestartPending is set on the sidecar collectors and the Instrumentations when some pods run the
+	// sidecar or the auto-instrumentation injected from an older generation of them.
+	ConditionTypeRestartPending = "RestartPending"
+)
+
 // OpenTelemetryCollectorStatus defines the observed state of OpenTelemetryCollector.
 type OpenTelemetryCollectorStatus struct {
 	// Scale is the OpenTelemetryCollector's scale subresource status.
@@ -245,6 +936,16 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	Version string `json:"version,omitempty"`
 
+	// Conditions represent the latest available observations of the OpenTelemetryCollector's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastScheduledReconcile is the last time the CR was reconciled following the Scheduled reconcile policy.
+	// +optional
+	LastScheduledReconcile *metav1.Time `json:"lastScheduledReconcile,omitempty"`
+
 	// Messages about actions performed by the operator on this resource.
 	// +optional
 	// +listType=atomic
@@ -259,10 +960,13 @@ type OpenTelemetryCollectorStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=otelcol;otelcols
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas",description="Desired replicas",priority=1
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.scale.statusReplicas",description="Ready replicas over the desired replicas"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector"
 // This annotation provides a hint for OLM which resources are managed by OpenTelemetryCollector kind.
@@ -298,6 +1002,147 @@ type AutoscalerSpec struct {
 	// +optional
 	// TargetMemoryUtilization sets the target average memory utilization across all replicas
 	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// Keda scales the collector with a KEDA ScaledObject on its triggers instead of a HorizontalPodAutoscaler, e.g. on
+	// the consumer lag of a Kafka topic. The utilization targets are ignored, and KEDA needs to be installed in the cluster.
+	// +optional
+	Keda *KedaSpec `json:"keda,omitempty"`
+	// TargetsPerReplica scales the statefulset collector on the number of targets its target allocator distributes,
+	// instead of a HorizontalPodAutoscaler. The operator scales the StatefulSet to the number of targets divided by
+	// targetsPerReplica, between minReplicas and maxReplicas, and the utilization targets are ignored.
+	// +optional
+	TargetsPerReplica *int32 `json:"targetsPerReplica,omitempty"`
+	// VPA makes the operator manage a VerticalPodAutoscaler adjusting the resources of the collector container of the
+	// daemonset collector to the load of each node. The VerticalPodAutoscaler needs to be installed in the cluster.
+	// +optional
+	VPA *VPASpec `json:"vpa,omitempty"`
+}
+
+// VPAUpdateMode is how the VerticalPodAutoscaler applies its recommendations to the collector pods.
+// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+type VPAUpdateMode string
+
+const (
+	// VPAUpdateModeOff only computes the recommendations, without applying them.
+	VPAUpdateModeOff VPAUpdateMode = "Off"
+	// VPAUpdateModeInitial applies the recommendations to the collector pods when they're created.
+	VPAUpdateModeInitial VPAUpdateMode = "Initial"
+	// VPAUpdateModeRecreate evicts the collector pods whose resources are too far from the recommendations.
+	VPAUpdateModeRecreate VPAUpdateMode = "Recreate"
+	// VPAUpdateModeAuto applies the recommendations with the best mechanism available, currently like Recreate.
+	VPAUpdateModeAuto VPAUpdateMode = "Auto"
+)
+
+// VPAControlledValues are the resource values of the collector container the VerticalPodAutoscaler updates.
+// +kubebuilder:validation:Enum=RequestsOnly;RequestsAndLimits
+type VPAControlledValues string
+
+const (
+	// VPAControlledValuesRequestsOnly only updates the resource requests.
+	VPAControlledValuesRequestsOnly VPAControlledValues = "RequestsOnly"
+	// VPAControlledValuesRequestsAndLimits updates the requests, and the limits keeping their ratio to the requests.
+	VPAControlledValuesRequestsAndLimits VPAControlledValues = "RequestsAndLimits"
+)
+
+// VPASpec defines the VerticalPodAutoscaler of the collector, and the resource policy of its collector container. The
+// other containers of the collector pods, e.g. the config reloader, keep their resources.
+type VPASpec struct {
+	// UpdateMode is how the recommendations are applied to the collector pods. Defaults to Auto.
+	// +optional
+	UpdateMode VPAUpdateMode `json:"updateMode,omitempty"`
+	// MinAllowed are the lowest resources the VerticalPodAutoscaler recommends for the collector container.
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed are the highest resources the VerticalPodAutoscaler recommends for the collector container.
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+	// ControlledResources are the resources of the collector container the VerticalPodAutoscaler updates. Defaults to
+	// cpu and memory.
+	// +optional
+	ControlledResources []v1.ResourceName `json:"controlledResources,omitempty"`
+	// ControlledValues are the resource values of the collector container the VerticalPodAutoscaler updates. Defaults
+	// to RequestsAndLimits.
+	// +optional
+	ControlledValues VPAControlledValues `json:"controlledValues,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the PodDisruptionBudget of the collector pods. Only one of minAvailable and
+// maxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number, or percentage, of collector pods that must remain available after an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number, or percentage, of collector pods that can be unavailable after an eviction.
+	// Defaults to 1 when minAvailable isn't set.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkPolicySpec defines the NetworkPolicies of the collector and target allocator pods. The collector pods accept
+// traffic on the ports of their receivers and on their metrics port, the target allocator pods on their target
+// allocation port.
+type NetworkPolicySpec struct {
+	// From lists the sources, e.g. the namespaces or pods matching a label selector, allowed to reach the ports of the
+	// pods. The ports are reachable from any source when empty. The target allocator pods are always reachable from
+	// the collector pods.
+	// +optional
+	From []networkingv1.NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// RBACSpec defines the permissions granted to the collector service account. The namespaced permissions required by
+// the components of the config, like the k8s_cluster receiver, are always granted with a Role.
+type RBACSpec struct {
+	// ClusterRole makes the operator manage a ClusterRole, bound to the collector service account, granting the cluster
+	// wide permissions required by the components of the config, e.g. reading the nodes and namespaces for the
+	// k8sattributes processor and the kubeletstats, k8s_cluster and prometheus receivers. The operator has to hold these
+	// permissions itself.
+	// +optional
+	ClusterRole bool `json:"clusterRole,omitempty"`
+}
+
+// KedaSpec defines the KEDA ScaledObject scaling the collector between minReplicas and maxReplicas.
+type KedaSpec struct {
+	// Triggers are the KEDA scalers activating the collector scaling.
+	// +kubebuilder:validation:MinItems=1
+	Triggers []KedaTrigger `json:"triggers"`
+	// PollingInterval is the interval, in seconds, at which KEDA checks the triggers. Defaults to 30 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// CooldownPeriod is the period, in seconds, KEDA waits after the last active trigger before scaling the collector
+	// down to minReplicas. Defaults to 300 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
+
+// KedaTrigger defines a KEDA scaler, see https://keda.sh/docs/latest/scalers/ for their types and metadata.
+type KedaTrigger struct {
+	// Type is the type of the scaler, e.g. kafka or prometheus.
+	Type string `json:"type"`
+	// Name of the trigger, it must be unique within the triggers.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Metadata is the configuration of the scaler, e.g. the bootstrapServers, consumerGroup, topic and lagThreshold of
+	// the kafka scaler.
+	Metadata map[string]string `json:"metadata"`
+	// AuthenticationRef references the TriggerAuthentication, or ClusterTriggerAuthentication, holding the credentials
+	// of the scaler.
+	// +optional
+	AuthenticationRef *KedaAuthenticationRef `json:"authenticationRef,omitempty"`
+	// MetricType is the type of the metric target of the trigger. Defaults to AverageValue.
+	// +optional
+	// +kubebuilder:validation:Enum=AverageValue;Value;Utilization
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+}
+
+// KedaAuthenticationRef references a KEDA TriggerAuthentication or ClusterTriggerAuthentication.
+type KedaAuthenticationRef struct {
+	// Name of the TriggerAuthentication, in the namespace of the collector, or of the ClusterTriggerAuthentication.
+	Name string `json:"name"`
+	// Kind is either TriggerAuthentication or ClusterTriggerAuthentication. Defaults to TriggerAuthentication.
+	// +optional
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	Kind string `json:"kind,omitempty"`
 }
 
 func init() {
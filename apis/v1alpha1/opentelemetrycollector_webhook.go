@@ -15,26 +15,199 @@
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/robfig/cron/v3"
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
+// encryptedValueRegex matches the config values wrapped as "enc:<base64-ciphertext>".
+var encryptedValueRegex = regexp.MustCompile(`[:-]\s+["']?enc:`)
+
+// routeHostnamePlaceholder matches the placeholders of the route hostname templates, e.g. "{port}".
+var routeHostnamePlaceholder = regexp.MustCompile(`\{[^}]*\}`)
+
+// ServiceNodePortRange is the node port range allocated by the Kubernetes API server (--service-node-port-range), the
+// node ports of the collector Services are validated against it. Defaults to the range of the API server, 30000-32767.
+var ServiceNodePortRange = utilnet.PortRange{Base: 30000, Size: 2768}
+
+const (
+	// minServiceAccountTokenExpirationSeconds is the shortest expiry of the projected service account tokens accepted
+	// by the Kubernetes API server.
+	minServiceAccountTokenExpirationSeconds = 600
+
+	// secretsStoreCSIDriver is the driver of the Secrets Store CSI volumes, delivering the secrets of external stores.
+	secretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+)
+
+// validatingWebhookPath is the path of the validating webhook, as generated by the webhook builder.
+const validatingWebhookPath = "/validate-opentelemetry-io-v1alpha1-opentelemetrycollector"
+
+// DualStackSupported returns whether the cluster supports dual-stack Services. When unset, the dual-stack
+// configurations aren't checked against the cluster.
+var DualStackSupported func() (bool, error)
+
+// GRPCProbeSupported indicates whether the cluster supports gRPC container probes, as detected at the operator startup.
+var GRPCProbeSupported bool
+
 // log is for logging in this package.
 var opentelemetrycollectorlog = logf.Log.WithName("opentelemetrycollector-resource")
 
 func (r *OpenTelemetryCollector) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	// the builder skips the validating webhook already registered, which adds the warnings to the admission responses
+	mgr.GetWebhookServer().Register(validatingWebhookPath, &webhook.Admission{
+		Handler: &warningHandler{Handler: admission.ValidatingWebhookFor(r).Handler},
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
+// warningHandler adds the warnings of the admitted instances to the responses of the validating handler.
+type warningHandler struct {
+	admission.Handler
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &warningHandler{}
+
+// InjectDecoder injects the decoder into the handler and the validating handler.
+func (h *warningHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	_, err := admission.InjectDecoderInto(d, h.Handler)
+	return err
+}
+
+// Handle handles admission requests.
+func (h *warningHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.Handler.Handle(ctx, req)
+	if !resp.Allowed || len(req.Object.Raw) == 0 {
+		return resp
+	}
+	otelcol := &OpenTelemetryCollector{}
+	if err := h.decoder.Decode(req, otelcol); err != nil {
+		return resp
+	}
+	return resp.WithWarnings(otelcol.warnings()...)
+}
+
+// warnings returns the settings of the instance which are valid, but likely to be unintended.
+func (r *OpenTelemetryCollector) warnings() []string {
+	var warnings []string
+	if r.Spec.RevisionHistoryLimit != nil && *r.Spec.RevisionHistoryLimit == 0 {
+		warnings = append(warnings, "revisionHistoryLimit is set to 0, the previous ReplicaSets of the collector Deployment are deleted and rolling back is impossible")
+	}
+	if missing := r.Annotations[UnsupportedArchitecturesAnnotation]; missing != "" {
+		warnings = append(warnings, fmt.Sprintf("the image %s isn't available for the architectures %s of the cluster nodes, the collector pods can't start on them", r.Spec.Image, missing))
+	}
+	if _, limited := r.Spec.Resources.Limits[v1.ResourceMemory]; !limited && r.Spec.MemoryTuning.MemoryLimiterPercentage != nil {
+		warnings = append(warnings, "memoryTuning.memoryLimiterPercentage is set, but the collector container has no memory limit, the memory_limiter processors are left untouched")
+	}
+	if r.vpaUpdatesMemoryLimit() {
+		warnings = append(warnings, "autoscaler.vpa updates the memory limit of the collector container, but the memory settings of memoryTuning are derived from the memory limit of spec.resources")
+	}
+	if value, ok := r.Annotations[AnnotationReconcile]; ok && value != ReconcilePaused {
+		warnings = append(warnings, fmt.Sprintf("the %s annotation is set to %q, only %q pauses the reconciliation", AnnotationReconcile, value, ReconcilePaused))
+	}
+	if missing := r.missingConfigEnvVars(); len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the config references the environment variables %s, which aren't set by env or envFrom, the collector fails to start unless its image sets them", strings.Join(missing, ", ")))
+	}
+	return warnings
+}
+
+// missingConfigEnvVars returns the environment variables referenced by the config the collector container doesn't
+// get. An empty or unparsable config isn't reported.
+func (r *OpenTelemetryCollector) missingConfigEnvVars() []string {
+	config, err := adapters.ConfigFromTemplate(r.Spec.Config, r)
+	if err != nil || strings.TrimSpace(config) == "" {
+		return nil
+	}
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return nil
+	}
+	return r.missingEnvVars(adapters.ConfigToEnvReferences(cfg))
+}
+
+// vpaUpdatesMemoryLimit returns whether the VerticalPodAutoscaler changes the memory limit the GOMEMLIMIT, and the
+// limits of the memory_limiter processors, are derived from.
+func (r *OpenTelemetryCollector) vpaUpdatesMemoryLimit() bool {
+	if r.Spec.Autoscaler == nil || r.Spec.Autoscaler.VPA == nil {
+		return false
+	}
+	vpa := r.Spec.Autoscaler.VPA
+	if _, limited := r.Spec.Resources.Limits[v1.ResourceMemory]; !limited || vpa.ControlledValues == VPAControlledValuesRequestsOnly {
+		return false
+	}
+	if vpa.UpdateMode == VPAUpdateModeOff {
+		return false
+	}
+	if len(vpa.ControlledResources) > 0 && !containsResource(vpa.ControlledResources, v1.ResourceMemory) {
+		return false
+	}
+	return r.Spec.MemoryTuning.GoMemLimitPercentage != nil || r.Spec.MemoryTuning.MemoryLimiterPercentage != nil
+}
+
+func containsResource(resources []v1.ResourceName, resource v1.ResourceName) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLivenessProbe rejects the collectors without a liveness probe, whose config doesn't enable the health_check
+// extension.
+func (r *OpenTelemetryCollector) validateLivenessProbe() error {
+	if r.Spec.Mode != ModeSidecar && !r.hasLivenessProbe() {
+		return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, the health_check extension isn't enabled in the service, the collector container would have no liveness probe")
+	}
+	return nil
+}
+
+// hasLivenessProbe returns whether the collector container gets a liveness probe, which requires the health_check
+// extension, or an OTLP gRPC receiver with the gRPC probe. An empty or unparsable config, or one completed by config
+// sources, isn't reported.
+func (r *OpenTelemetryCollector) hasLivenessProbe() bool {
+	config, err := adapters.ConfigFromTemplate(r.Spec.Config, r)
+	if err != nil || strings.TrimSpace(config) == "" || len(r.Spec.ConfigSources) > 0 {
+		return true
+	}
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return true
+	}
+	if r.Spec.LivenessProbe != nil && r.Spec.LivenessProbe.Protocol == ProbeProtocolGRPC {
+		if _, err := adapters.ConfigToGRPCContainerProbe(cfg); err == nil {
+			return true
+		}
+	}
+	_, err = adapters.ConfigToContainerProbe(cfg)
+	return err == nil
+}
+
 // +kubebuilder:webhook:path=/mutate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=true,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=create;update,versions=v1alpha1,name=mopentelemetrycollector.kb.io,sideEffects=none,admissionReviewVersions=v1
 
 var _ webhook.Defaulter = &OpenTelemetryCollector{}
@@ -43,31 +216,64 @@ var _ webhook.Defaulter = &OpenTelemetryCollector{}
 func (r *OpenTelemetryCollector) Default() {
 	opentelemetrycollectorlog.Info("default", "name", r.Name)
 
-	if len(r.Spec.Mode) == 0 {
+	r.setDefaults(Mutable)
+	if Mutable("metadata.annotations") {
+		r.defaultUnsupportedArchitectures()
+	}
+}
+
+// WithDefaults returns a copy of the instance with the defaults of the webhook, including the ones of the fields
+// skipped by the webhook, for the reconciliation to get the same instance whether the defaults were written or not.
+func (r *OpenTelemetryCollector) WithDefaults() OpenTelemetryCollector {
+	instance := r.DeepCopy()
+	instance.setDefaults(func(string) bool { return true })
+	return *instance
+}
+
+// setDefaults sets the defaults of the mutable fields of the instance.
+func (r *OpenTelemetryCollector) setDefaults(mutable func(field string) bool) {
+	if len(r.Spec.Mode) == 0 && mutable("spec.mode") {
 		r.Spec.Mode = ModeDeployment
 	}
-	if len(r.Spec.UpgradeStrategy) == 0 {
+	if len(r.Spec.UpgradeStrategy) == 0 && mutable("spec.upgradeStrategy") {
 		r.Spec.UpgradeStrategy = UpgradeStrategyAutomatic
 	}
-
-	if r.Labels == nil {
-		r.Labels = map[string]string{}
+	if len(r.Spec.ReconcilePolicy) == 0 && mutable("spec.reconcilePolicy") {
+		r.Spec.ReconcilePolicy = ReconcilePolicyAlways
+	}
+	if len(r.Spec.ManagementState) == 0 && mutable("spec.managementState") {
+		r.Spec.ManagementState = ManagementStateManaged
 	}
-	if r.Labels["app.kubernetes.io/managed-by"] == "" {
+	if len(r.Spec.GCPolicy) == 0 && mutable("spec.gcPolicy") {
+		r.Spec.GCPolicy = GCPolicyForeground
+	}
+	if len(r.Spec.ConfigReloadStrategy) == 0 && mutable("spec.configReloadStrategy") {
+		r.Spec.ConfigReloadStrategy = ConfigReloadStrategyRestart
+	}
+
+	if r.Labels["app.kubernetes.io/managed-by"] == "" && mutable("metadata.labels") {
+		if r.Labels == nil {
+			r.Labels = map[string]string{}
+		}
 		r.Labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
 	}
 
 	// We can default to one because dependent objects Deployment and HorizontalPodAutoScaler
 	// default to 1 as well.
 	one := int32(1)
-	if r.Spec.Replicas == nil {
+	if r.Spec.Replicas == nil && mutable("spec.replicas") {
 		r.Spec.Replicas = &one
 	}
-	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.Replicas == nil {
+	if r.Spec.TargetAllocator.Enabled && r.Spec.TargetAllocator.Replicas == nil && mutable("spec.targetAllocator.replicas") {
 		r.Spec.TargetAllocator.Replicas = &one
 	}
 
-	if r.Spec.MaxReplicas != nil {
+	if r.Spec.PodDisruptionBudget != nil && r.Spec.PodDisruptionBudget.MinAvailable == nil && r.Spec.PodDisruptionBudget.MaxUnavailable == nil && mutable("spec.podDisruptionBudget") {
+		maxUnavailable := intstr.FromInt(1)
+		r.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+	}
+
+	if r.Spec.MaxReplicas != nil && mutable("spec.autoscaler") {
 		if r.Spec.Autoscaler == nil {
 			r.Spec.Autoscaler = &AutoscalerSpec{}
 		}
@@ -77,9 +283,34 @@ func (r *OpenTelemetryCollector) Default() {
 			r.Spec.Autoscaler.TargetCPUUtilization = &defaultCPUTarget
 		}
 	}
-	if r.Spec.Ingress.Type == IngressTypeRoute && r.Spec.Ingress.Route.Termination == "" {
+	if (r.Spec.Ingress.Type == IngressTypeNginx || r.Spec.Ingress.Type == IngressTypeGateway) && r.Spec.Ingress.RuleType == "" && mutable("spec.ingress") {
+		r.Spec.Ingress.RuleType = IngressRuleTypePath
+	}
+	if r.Spec.Ingress.Type == IngressTypeRoute && r.Spec.Ingress.Route.Termination == "" && mutable("spec.ingress") {
 		r.Spec.Ingress.Route.Termination = TLSRouteTerminationTypeEdge
 	}
+	if r.Spec.Mode != ModeSidecar && r.Spec.Service.Type == "" && mutable("spec.service") {
+		r.Spec.Service.Type = v1.ServiceTypeClusterIP
+	}
+	// keep a single previous ReplicaSet instead of the 10 of Kubernetes, the configs change frequently
+	if r.Spec.Mode == ModeDeployment && r.Spec.RevisionHistoryLimit == nil && mutable("spec.revisionHistoryLimit") {
+		revisionHistoryLimit := int32(1)
+		r.Spec.RevisionHistoryLimit = &revisionHistoryLimit
+	}
+	if !mutable("spec.livenessProbe") {
+		return
+	}
+	if r.Spec.LivenessProbe == nil {
+		r.Spec.LivenessProbe = &LivenessProbeSpec{}
+	}
+	if r.Spec.LivenessProbe.Protocol == "" {
+		// sidecars keep the HTTP probe, so that injecting them doesn't change the probes of existing application pods
+		if GRPCProbeSupported && r.Spec.Mode != ModeSidecar {
+			r.Spec.LivenessProbe.Protocol = ProbeProtocolGRPC
+		} else {
+			r.Spec.LivenessProbe.Protocol = ProbeProtocolHTTP
+		}
+	}
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-opentelemetrycollector,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,versions=v1alpha1,name=vopentelemetrycollectorcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -90,13 +321,59 @@ var _ webhook.Validator = &OpenTelemetryCollector{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *OpenTelemetryCollector) ValidateCreate() error {
 	opentelemetrycollectorlog.Info("validate create", "name", r.Name)
-	return r.validateCRDSpec()
+	if err := r.validateDefaulted(); err != nil {
+		return err
+	}
+	return r.validateLivenessProbe()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *OpenTelemetryCollector) ValidateUpdate(old runtime.Object) error {
 	opentelemetrycollectorlog.Info("validate update", "name", r.Name)
-	return r.validateCRDSpec()
+	previous, ok := old.(*OpenTelemetryCollector)
+	// the instances being deleted, and the updates of the metadata only, like the removal of the finalizers by the
+	// operator, are admitted even when the instance is no longer valid, e.g. after a feature gate was disabled, so
+	// that it can always be deleted
+	if r.DeletionTimestamp != nil || (ok && apiequality.Semantic.DeepEqual(previous.Spec, r.Spec)) {
+		return nil
+	}
+	if err := r.validateDefaulted(); err != nil {
+		return err
+	}
+	if !ok {
+		return r.validateLivenessProbe()
+	}
+	if err := r.validateVersionPin(*previous); err != nil {
+		return err
+	}
+	// the instances created without the health_check extension can still be updated, e.g. by the operator, as long as
+	// their config isn't changed
+	if previous.Spec.Config == r.Spec.Config {
+		return nil
+	}
+	return r.validateLivenessProbe()
+}
+
+// validateVersionPin rejects the new version pins older than the version the instance was already upgraded to, as the
+// upgrades can't be reverted.
+func (r *OpenTelemetryCollector) validateVersionPin(previous OpenTelemetryCollector) error {
+	if r.Spec.Version == "" || r.Spec.Version == previous.Spec.Version || previous.Status.Version == "" {
+		return nil
+	}
+	current, err := semver.NewVersion(previous.Status.Version)
+	if err != nil {
+		// the upgrade of the instance reports its unknown version
+		return nil
+	}
+	pinned, err := semver.NewVersion(r.Spec.Version)
+	if err != nil {
+		// already rejected as an invalid version
+		return nil
+	}
+	if pinned.LessThan(current) {
+		return fmt.Errorf("the OpenTelemetry Spec version is incorrect, the instance is already at version %s, which can't be downgraded to %s", previous.Status.Version, r.Spec.Version)
+	}
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -105,11 +382,109 @@ func (r *OpenTelemetryCollector) ValidateDelete() error {
 	return nil
 }
 
+// validateDefaulted validates the instance as reconciled, with the defaults of the fields skipped by SkipMutations.
+func (r *OpenTelemetryCollector) validateDefaulted() error {
+	instance := r.WithDefaults()
+	return instance.validateCRDSpec()
+}
+
 func (r *OpenTelemetryCollector) validateCRDSpec() error {
 	// validate volumeClaimTemplates
 	if r.Spec.Mode != ModeStatefulSet && len(r.Spec.VolumeClaimTemplates) > 0 {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'volumeClaimTemplates'", r.Spec.Mode)
 	}
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.PersistentVolumeClaimRetentionPolicy != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistentVolumeClaimRetentionPolicy'", r.Spec.Mode)
+	}
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.PodManagementPolicy != "" {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podManagementPolicy'", r.Spec.Mode)
+	}
+
+	// validate the update strategies
+	if r.Spec.Mode != ModeDeployment && (r.Spec.DeploymentUpdateStrategy.Type != "" || r.Spec.DeploymentUpdateStrategy.RollingUpdate != nil) {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'deploymentUpdateStrategy'", r.Spec.Mode)
+	}
+	if r.Spec.Mode != ModeDaemonSet && (r.Spec.DaemonSetUpdateStrategy.Type != "" || r.Spec.DaemonSetUpdateStrategy.RollingUpdate != nil) {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'daemonSetUpdateStrategy'", r.Spec.Mode)
+	}
+	if r.Spec.Mode != ModeStatefulSet && (r.Spec.StatefulSetUpdateStrategy.Type != "" || r.Spec.StatefulSetUpdateStrategy.RollingUpdate != nil) {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'statefulSetUpdateStrategy'", r.Spec.Mode)
+	}
+
+	if r.Spec.Version != "" {
+		if _, err := semver.StrictNewVersion(r.Spec.Version); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec version is not a valid version: %s", r.Spec.Version)
+		}
+	}
+
+	if r.Spec.Mode != ModeSidecar && r.Spec.NativeSidecar {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'nativeSidecar'", r.Spec.Mode)
+	}
+
+	if r.Spec.Mode == ModeSidecar && r.Spec.ConfigReloadStrategy == ConfigReloadStrategyReload {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the config reload strategy %s", r.Spec.Mode, ConfigReloadStrategyReload)
+	}
+
+	// validate hostNetwork, dnsPolicy and dnsConfig
+	if r.Spec.Mode == ModeSidecar && r.Spec.HostNetwork {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'hostNetwork'", r.Spec.Mode)
+	}
+	if r.Spec.Mode == ModeSidecar && r.Spec.DNSPolicy != "" {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'dnsPolicy'", r.Spec.Mode)
+	}
+	if r.Spec.Mode == ModeSidecar && r.Spec.DNSConfig != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'dnsConfig'", r.Spec.Mode)
+	}
+	if r.Spec.DNSPolicy == v1.DNSNone && (r.Spec.DNSConfig == nil || len(r.Spec.DNSConfig.Nameservers) == 0) {
+		return fmt.Errorf("the OpenTelemetry Spec dnsConfig configuration is incorrect, at least one nameserver is required with the dnsPolicy %s", v1.DNSNone)
+	}
+
+	// validate initContainers and additionalContainers
+	if r.Spec.Mode == ModeSidecar && len(r.Spec.InitContainers) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'initContainers'", r.Spec.Mode)
+	}
+	if r.Spec.Mode == ModeSidecar && len(r.Spec.AdditionalContainers) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'additionalContainers'", r.Spec.Mode)
+	}
+	containerNames := map[string]bool{}
+	for _, c := range append(append([]v1.Container{}, r.Spec.InitContainers...), r.Spec.AdditionalContainers...) {
+		if strings.HasPrefix(c.Name, "otc-") {
+			return fmt.Errorf("the OpenTelemetry Spec containers configuration is incorrect, the container name %s uses the reserved prefix otc-", c.Name)
+		}
+		if containerNames[c.Name] {
+			return fmt.Errorf("the OpenTelemetry Spec containers configuration is incorrect, the container name %s is used more than once", c.Name)
+		}
+		containerNames[c.Name] = true
+	}
+
+	// validate volumes
+	if err := validateVolumes(r.Spec.Volumes); err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec volumes configuration is incorrect, %w", err)
+	}
+
+	// validate podTemplateOverrides
+	if r.Spec.PodTemplateOverrides != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podTemplateOverrides'", r.Spec.Mode)
+		}
+		merged, err := strategicpatch.StrategicMergePatch([]byte("{}"), r.Spec.PodTemplateOverrides.Raw, v1.PodTemplateSpec{})
+		if err == nil {
+			err = json.Unmarshal(merged, &v1.PodTemplateSpec{})
+		}
+		if err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec podTemplateOverrides configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate shareProcessNamespace
+	if r.Spec.ShareProcessNamespace != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'shareProcessNamespace'", r.Spec.Mode)
+		}
+		if !*r.Spec.ShareProcessNamespace && r.Spec.ConfigReloadStrategy == ConfigReloadStrategyReload {
+			return fmt.Errorf("the OpenTelemetry Spec shareProcessNamespace configuration is incorrect, the config reload strategy %s needs a shared process namespace", ConfigReloadStrategyReload)
+		}
+	}
 
 	// validate tolerations
 	if r.Spec.Mode == ModeSidecar && len(r.Spec.Tolerations) > 0 {
@@ -126,17 +501,146 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'affinity'", r.Spec.Mode)
 	}
 
+	// validate topologySpreadConstraints
+	if r.Spec.Mode == ModeSidecar && len(r.Spec.TopologySpreadConstraints) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'topologySpreadConstraints'", r.Spec.Mode)
+	}
+
+	// validate antiAffinityTarget
+	if r.Spec.AntiAffinityTarget != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'antiAffinityTarget'", r.Spec.Mode)
+		}
+		if r.Spec.AntiAffinityTarget.PodLabelSelector == nil {
+			return fmt.Errorf("the OpenTelemetry Spec antiAffinityTarget configuration is incorrect, podLabelSelector is required")
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.AntiAffinityTarget.PodLabelSelector); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec antiAffinityTarget configuration is incorrect, invalid podLabelSelector: %w", err)
+		}
+	}
+
+	// validate runtimeClassName and overhead
+	if r.Spec.Mode == ModeSidecar && r.Spec.RuntimeClassName != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'runtimeClassName'", r.Spec.Mode)
+	}
+	if r.Spec.Mode == ModeSidecar && len(r.Spec.Overhead) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'overhead'", r.Spec.Mode)
+	}
+	if len(r.Spec.Overhead) > 0 && r.Spec.RuntimeClassName == nil {
+		return fmt.Errorf("the OpenTelemetry Spec overhead configuration is incorrect, overhead can only be used with a runtimeClassName")
+	}
+
+	// validate revisionHistoryLimit
+	if r.Spec.RevisionHistoryLimit != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'revisionHistoryLimit'", r.Spec.Mode)
+		}
+		if *r.Spec.RevisionHistoryLimit < 0 {
+			return fmt.Errorf("the OpenTelemetry Spec revisionHistoryLimit configuration is incorrect, the limit can't be negative")
+		}
+		if *r.Spec.RevisionHistoryLimit == 0 && r.Spec.SmokeTest != nil && r.Spec.SmokeTest.Rollback {
+			return fmt.Errorf("the OpenTelemetry Spec revisionHistoryLimit configuration is incorrect, the smoke test rollbacks need at least one previous revision")
+		}
+	}
+
+	// validate shutdownTimeout
+	if r.Spec.ShutdownTimeout != "" {
+		if r.Spec.Mode != ModeDeployment {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'shutdownTimeout'", r.Spec.Mode)
+		}
+		timeout, err := time.ParseDuration(r.Spec.ShutdownTimeout)
+		if err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec shutdownTimeout configuration is incorrect, %w", err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("the OpenTelemetry Spec shutdownTimeout configuration is incorrect, the timeout can't be negative")
+		}
+	}
+	if r.Spec.ShutdownPreStopSleep && r.Spec.ShutdownTimeout == "" {
+		return fmt.Errorf("the OpenTelemetry Spec shutdownPreStopSleep configuration is incorrect, the preStop hook is only added along with the shutdownTimeout")
+	}
+
+	// validate terminationGracePeriodSeconds
+	if r.Spec.TerminationGracePeriodSeconds != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'terminationGracePeriodSeconds'", r.Spec.Mode)
+		}
+		if *r.Spec.TerminationGracePeriodSeconds < 0 {
+			return fmt.Errorf("the OpenTelemetry Spec terminationGracePeriodSeconds configuration is incorrect, the grace period can't be negative")
+		}
+	}
+
+	// validate memory tuning
+	if tuning := r.Spec.MemoryTuning; tuning.MemoryLimiterSpikePercentage != nil {
+		if tuning.MemoryLimiterPercentage == nil {
+			return fmt.Errorf("the OpenTelemetry Spec memoryTuning configuration is incorrect, memoryLimiterSpikePercentage requires memoryLimiterPercentage")
+		}
+		if *tuning.MemoryLimiterSpikePercentage >= *tuning.MemoryLimiterPercentage {
+			return fmt.Errorf("the OpenTelemetry Spec memoryTuning configuration is incorrect, memoryLimiterSpikePercentage must be lower than memoryLimiterPercentage")
+		}
+	}
+
+	// validate the probes, Kubernetes only accepts one success for the liveness and startup probes
+	if r.Spec.LivenessProbe != nil && r.Spec.LivenessProbe.SuccessThreshold != nil && *r.Spec.LivenessProbe.SuccessThreshold != 1 {
+		return fmt.Errorf("the OpenTelemetry Spec livenessProbe configuration is incorrect, successThreshold must be 1")
+	}
+	if r.Spec.StartupProbe != nil && r.Spec.StartupProbe.SuccessThreshold != nil && *r.Spec.StartupProbe.SuccessThreshold != 1 {
+		return fmt.Errorf("the OpenTelemetry Spec startupProbe configuration is incorrect, successThreshold must be 1")
+	}
+
 	// validate target allocation
-	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode != ModeStatefulSet {
+	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
 		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
 	}
+	if r.Spec.TargetAllocator.Enabled && r.Spec.Mode == ModeDaemonSet &&
+		r.Spec.TargetAllocator.AllocationStrategy != OpenTelemetryTargetAllocatorAllocationStrategyPerNode {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which only supports the %s allocation strategy of the target allocation deployment",
+			r.Spec.Mode, OpenTelemetryTargetAllocatorAllocationStrategyPerNode)
+	}
+	if r.Spec.TargetAllocator.Enabled && !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+		return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the target allocation deployment", featuregate.TargetAllocator)
+	}
+
+	if r.Spec.TargetAllocator.Enabled {
+		if err := validateTargetAllocatorReplicas(r.Spec.TargetAllocator.Replicas, r.Spec.TargetAllocator.AllocationStrategy); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocator replicas configuration is incorrect, %w", err)
+		}
+		if err := validateAllocationRules(r.Spec.TargetAllocator.AllocationRules, r.Spec.TargetAllocator.Replicas, r.Spec.TargetAllocator.AllocationStrategy); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocator allocationRules configuration is incorrect, %w", err)
+		}
+		for _, rule := range r.Spec.TargetAllocator.AllocationRules {
+			if r.Spec.Mode == ModeDaemonSet && rule.AllocationStrategy != "" && rule.AllocationStrategy != OpenTelemetryTargetAllocatorAllocationStrategyPerNode {
+				return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which only supports the %s allocation strategy of the target allocation deployment",
+					r.Spec.Mode, OpenTelemetryTargetAllocatorAllocationStrategyPerNode)
+			}
+		}
+	}
+
+	// validate the reference to a shared target allocator
+	if r.Spec.TargetAllocatorRef != "" {
+		if r.Spec.TargetAllocator.Enabled {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocatorRef configuration is incorrect, it can't be set along with an enabled targetAllocator")
+		}
+		if r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
+		}
+		if !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+			return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the target allocation deployment", featuregate.TargetAllocator)
+		}
+		if err := validatePrometheusReceiver(r.Spec.Config); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocatorRef configuration is incorrect, %w", err)
+		}
+	}
 
 	// validate Prometheus config for target allocation
 	if r.Spec.TargetAllocator.Enabled {
-		_, err := ta.ConfigToPromConfig(r.Spec.Config)
+		promCfg, err := ta.ConfigToPromConfig(r.Spec.Config)
 		if err != nil {
 			return fmt.Errorf("the OpenTelemetry Spec Prometheus configuration is incorrect, %w", err)
 		}
+		if err := ta.ValidatePromConfig(promCfg); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Prometheus configuration is incorrect, %w", err)
+		}
 	}
 
 	// validator port config
@@ -186,11 +690,425 @@ func (r *OpenTelemetryCollector) validateCRDSpec() error {
 		}
 	}
 
-	if r.Spec.Ingress.Type == IngressTypeNginx && r.Spec.Mode == ModeSidecar {
+	// validate pod disruption budget
+	if r.Spec.PodDisruptionBudget != nil {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podDisruptionBudget'", r.Spec.Mode)
+		}
+		if r.Spec.PodDisruptionBudget.MinAvailable != nil && r.Spec.PodDisruptionBudget.MaxUnavailable != nil {
+			return fmt.Errorf("the OpenTelemetry Spec podDisruptionBudget configuration is incorrect, minAvailable and maxUnavailable are mutually exclusive")
+		}
+		for _, value := range []*intstr.IntOrString{r.Spec.PodDisruptionBudget.MinAvailable, r.Spec.PodDisruptionBudget.MaxUnavailable} {
+			if value == nil {
+				continue
+			}
+			if _, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true); err != nil {
+				return fmt.Errorf("the OpenTelemetry Spec podDisruptionBudget configuration is incorrect, %w", err)
+			}
+		}
+	}
+
+	// validate network policy
+	if r.Spec.NetworkPolicy != nil && r.Spec.Mode == ModeSidecar {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'networkPolicy'", r.Spec.Mode)
+	}
+
+	// validate target allocator mtls
+	if mtls := r.Spec.TargetAllocator.MTLS; mtls != nil && mtls.IssuerRef != nil {
+		if mtls.IssuerRef.Name == "" {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocator mtls configuration is incorrect, the issuer name is required")
+		}
+		if kind := mtls.IssuerRef.Kind; kind != "" && kind != "Issuer" && kind != "ClusterIssuer" {
+			return fmt.Errorf("the OpenTelemetry Spec targetAllocator mtls configuration is incorrect, the issuer kind %s is neither Issuer nor ClusterIssuer", kind)
+		}
+	}
+
+	// validate cluster role
+	if r.Spec.RBAC.ClusterRole && r.Spec.Mode == ModeSidecar {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rbac.clusterRole'", r.Spec.Mode)
+	}
+	if r.Spec.RBAC.ClusterRole && !featuregate.Gates.Enabled(featuregate.CollectorClusterRoles) {
+		return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the attribute 'rbac.clusterRole'", featuregate.CollectorClusterRoles)
+	}
+
+	// validate autoscale with keda
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.Keda != nil {
+		if r.Spec.MaxReplicas == nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas is required to scale with keda")
+		}
+		if len(r.Spec.Autoscaler.Keda.Triggers) == 0 {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, keda requires at least one trigger")
+		}
+		for _, trigger := range r.Spec.Autoscaler.Keda.Triggers {
+			if trigger.Type == "" {
+				return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, the keda triggers require a type")
+			}
+		}
+	}
+
+	// validate autoscale on the scrape targets
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.TargetsPerReplica != nil {
+		if r.Spec.Mode != ModeStatefulSet {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'autoscaler.targetsPerReplica'", r.Spec.Mode)
+		}
+		if r.Spec.MaxReplicas == nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas is required to scale on the targets")
+		}
+		if *r.Spec.Autoscaler.TargetsPerReplica < int32(1) {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetsPerReplica should be one or more")
+		}
+		if r.Spec.Autoscaler.Keda != nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetsPerReplica can't be combined with keda")
+		}
+		if !r.Spec.TargetAllocator.Enabled {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetsPerReplica requires the target allocator")
+		}
+		// the operator reads the targets from the metrics of the target allocator
+		if r.Spec.TargetAllocator.MTLS != nil || r.Spec.NetworkPolicy != nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetsPerReplica can't be combined with the target allocator mtls or a networkPolicy")
+		}
+		if r.Spec.FederationRef != nil {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, targetsPerReplica can't be combined with a federationRef")
+		}
+	}
+
+	// validate the vertical pod autoscaler
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.VPA != nil {
+		if err := validateVPA(r.Spec.Mode, *r.Spec.Autoscaler.VPA); err != nil {
+			return err
+		}
+	}
+
+	if (r.Spec.Ingress.Type == IngressTypeNginx || r.Spec.Ingress.Type == IngressTypeGateway) && r.Spec.Mode == ModeSidecar {
 		return fmt.Errorf("the OptenTelemetry Spec Ingress configuiration is incorrect. Ingress can only be used in combination with the modes: %s, %s, %s",
 			ModeDeployment, ModeDaemonSet, ModeStatefulSet,
 		)
 	}
+	if r.Spec.Ingress.Type == IngressTypeRoute && r.Spec.Ingress.Route.HostnameTemplate != "" {
+		for _, placeholder := range routeHostnamePlaceholder.FindAllString(r.Spec.Ingress.Route.HostnameTemplate, -1) {
+			switch placeholder {
+			case "{port}", "{hostname}", "{name}", "{namespace}":
+			default:
+				return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, the route hostname template has an unknown placeholder %s", placeholder)
+			}
+		}
+	}
+	if r.Spec.Ingress.Type == IngressTypeGateway && r.Spec.Ingress.Gateway.ParentRef.Name == "" {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, the gateway type requires the name of the parent Gateway")
+	}
+	if r.Spec.Ingress.RuleType == IngressRuleTypeSubdomain && (r.Spec.Ingress.Hostname == "" || r.Spec.Ingress.Hostname == "*") {
+		return fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect, a hostname is required when the rule type is %s", IngressRuleTypeSubdomain)
+	}
+
+	// validate service
+	if err := r.validateService(); err != nil {
+		return err
+	}
 
+	// validate pre-deploy check
+	if r.Spec.PreDeployCheck != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'preDeployCheck'", r.Spec.Mode)
+		}
+		if r.Spec.PreDeployCheck.Image == "" {
+			return fmt.Errorf("the OpenTelemetry Spec preDeployCheck configuration is incorrect, image is required")
+		}
+	}
+
+	// validate custom distribution build
+	if r.Spec.Build != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'build'", r.Spec.Mode)
+		}
+		if r.Spec.Build.Manifest == "" {
+			return fmt.Errorf("the OpenTelemetry Spec build configuration is incorrect, manifest is required")
+		}
+		if _, err := adapters.ConfigFromString(r.Spec.Build.Manifest); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec build configuration is incorrect, manifest: %w", err)
+		}
+		if r.Spec.Build.Image == "" {
+			return fmt.Errorf("the OpenTelemetry Spec build configuration is incorrect, image is required")
+		}
+		// the operator tags the image with the manifest hash
+		if repository := r.Spec.Build.Image[strings.LastIndex(r.Spec.Build.Image, "/")+1:]; strings.ContainsAny(repository, ":@") {
+			return fmt.Errorf("the OpenTelemetry Spec build configuration is incorrect, image %s should not have a tag or digest", r.Spec.Build.Image)
+		}
+	}
+
+	// validate smoke test
+	if r.Spec.SmokeTest != nil && r.Spec.Mode != ModeDeployment {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'smokeTest'", r.Spec.Mode)
+	}
+
+	// validate canary rollout
+	if r.Spec.Canary != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'canary'", r.Spec.Mode)
+		}
+		if r.Spec.Canary.Replicas != nil && *r.Spec.Canary.Replicas < 1 {
+			return fmt.Errorf("the OpenTelemetry Spec canary configuration is incorrect, replicas should be one or more")
+		}
+		if r.Spec.Canary.BakeDuration != nil && r.Spec.Canary.BakeDuration.Duration < 0 {
+			return fmt.Errorf("the OpenTelemetry Spec canary configuration is incorrect, bakeDuration should not be negative")
+		}
+		if p := r.Spec.Canary.MaxExportFailurePercent; p != nil && (*p < 0 || *p > 100) {
+			return fmt.Errorf("the OpenTelemetry Spec canary configuration is incorrect, maxExportFailurePercent should be between 0 and 100")
+		}
+	}
+
+	// validate federation reference
+	if r.Spec.FederationRef != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'federationRef'", r.Spec.Mode)
+		}
+		if r.Spec.FederationRef.ClusterName == "" {
+			return fmt.Errorf("the OpenTelemetry Spec federationRef configuration is incorrect, clusterName is required")
+		}
+		if r.Spec.FederationRef.Namespace != "" && r.Spec.FederationRef.Namespace != r.Namespace {
+			return fmt.Errorf("the OpenTelemetry Spec federationRef configuration is incorrect, the cluster has to be in the namespace %s of the instance", r.Namespace)
+		}
+		if r.Spec.SmokeTest != nil {
+			return fmt.Errorf("the OpenTelemetry Spec federationRef configuration is incorrect, smoke tests aren't supported for remote clusters")
+		}
+		if r.Spec.Canary != nil {
+			return fmt.Errorf("the OpenTelemetry Spec federationRef configuration is incorrect, canary rollouts aren't supported for remote clusters")
+		}
+		if !featuregate.Gates.Enabled(featuregate.MultiClusterFederation) {
+			return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the attribute 'federationRef'", featuregate.MultiClusterFederation)
+		}
+	}
+
+	// validate config templates
+	config, err := adapters.ConfigFromTemplate(r.Spec.Config, r)
+	if err != nil {
+		return fmt.Errorf("the OpenTelemetry Spec Config template is incorrect, %w", err)
+	}
+
+	// validate tls settings
+	if r.Spec.TLS.EnforceSecure {
+		cfg, err := adapters.ConfigFromString(config)
+		if err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, %w", err)
+		}
+		if insecure := adapters.ConfigToInsecureTLSSettings(cfg); len(insecure) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec Config configuration is insecure, %s", strings.Join(insecure, ", "))
+		}
+	}
+	if issuer := r.Spec.TLS.IssuerRef; issuer != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'tls.issuerRef'", r.Spec.Mode)
+		}
+		if issuer.Name == "" {
+			return fmt.Errorf("the OpenTelemetry Spec tls configuration is incorrect, the issuer name is required")
+		}
+		if kind := issuer.Kind; kind != "" && kind != "Issuer" && kind != "ClusterIssuer" {
+			return fmt.Errorf("the OpenTelemetry Spec tls configuration is incorrect, the issuer kind %s is neither Issuer nor ClusterIssuer", kind)
+		}
+		cfg, err := adapters.ConfigFromString(config)
+		if err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, %w", err)
+		}
+		mounted := make([]string, 0, len(r.Spec.VolumeMounts))
+		for _, mount := range r.Spec.VolumeMounts {
+			mounted = append(mounted, mount.MountPath)
+		}
+		if _, err := adapters.ReceiverTLSDirs(adapters.ConfigToReceiverTLSFiles(cfg), mounted); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec tls configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate encrypted values
+	if r.Spec.EncryptionKeyRef == nil && encryptedValueRegex.MatchString(r.Spec.Config) {
+		return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, encrypted values require the attribute 'encryptionKeyRef'")
+	}
+
+	// validate config sources
+	for i, source := range r.Spec.ConfigSources {
+		if (source.ConfigMapKeyRef == nil) == (source.SecretKeyRef == nil) {
+			return fmt.Errorf("the OpenTelemetry Spec configSources configuration is incorrect, source %d must set exactly one of configMapKeyRef and secretKeyRef", i)
+		}
+		if source.SecretKeyRef != nil && r.Spec.FederationRef != nil {
+			return fmt.Errorf("the OpenTelemetry Spec configSources configuration is incorrect, source %d is a secret, which the collectors of a remote cluster can't read", i)
+		}
+	}
+
+	// validate the pipelines, an empty config is left to the operator defaults. The config sources are only read by
+	// the operator, so the pipelines of an inline config completed by them aren't checked.
+	if strings.TrimSpace(config) != "" {
+		cfg, err := adapters.ConfigFromString(config)
+		if err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, %w", err)
+		}
+		if problems := adapters.ConfigToInvalidPipelines(cfg); len(problems) > 0 && len(r.Spec.ConfigSources) == 0 {
+			return fmt.Errorf("the OpenTelemetry Spec Config configuration is incorrect, %s", strings.Join(problems, ", "))
+		}
+	}
+
+	// validate event export
+	if r.Spec.EventExport.Enabled {
+		if r.Spec.EventExport.Type == "" {
+			return fmt.Errorf("the OpenTelemetry Spec eventExport configuration is incorrect, type is required when the event export is enabled")
+		}
+		if len(r.Spec.EventExport.Brokers) == 0 {
+			return fmt.Errorf("the OpenTelemetry Spec eventExport configuration is incorrect, at least one broker is required when the event export is enabled")
+		}
+	}
+
+	// validate reconcile policy
+	if r.Spec.ReconcilePolicy == ReconcilePolicyScheduled {
+		if r.Spec.ReconcileSchedule == "" {
+			return fmt.Errorf("the OpenTelemetry Spec reconcile configuration is incorrect, reconcileSchedule is required with the reconcile policy %s", ReconcilePolicyScheduled)
+		}
+		if _, err := cron.ParseStandard(r.Spec.ReconcileSchedule); err != nil {
+			return fmt.Errorf("the OpenTelemetry Spec reconcile configuration is incorrect, reconcileSchedule is not a valid cron schedule: %w", err)
+		}
+	} else if r.Spec.ReconcileSchedule != "" {
+		return fmt.Errorf("the OpenTelemetry Spec reconcile configuration is incorrect, reconcileSchedule can only be used with the reconcile policy %s", ReconcilePolicyScheduled)
+	}
+
+	return nil
+}
+
+// validateVolumes checks the projected service account tokens and the CSI volumes, which are otherwise only rejected
+// when the collector pods are created.
+func validateVolumes(volumes []v1.Volume) error {
+	for _, volume := range volumes {
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				token := source.ServiceAccountToken
+				if token == nil {
+					continue
+				}
+				if token.Path == "" {
+					return fmt.Errorf("the serviceAccountToken of the volume %s requires a path", volume.Name)
+				}
+				if token.ExpirationSeconds != nil && *token.ExpirationSeconds < minServiceAccountTokenExpirationSeconds {
+					return fmt.Errorf("the serviceAccountToken of the volume %s must expire after at least %d seconds", volume.Name, minServiceAccountTokenExpirationSeconds)
+				}
+			}
+		}
+		if csi := volume.CSI; csi != nil {
+			if csi.Driver == "" {
+				return fmt.Errorf("the csi volume %s requires a driver", volume.Name)
+			}
+			if csi.Driver == secretsStoreCSIDriver {
+				if csi.ReadOnly == nil || !*csi.ReadOnly {
+					return fmt.Errorf("the csi volume %s of the driver %s must be read-only", volume.Name, secretsStoreCSIDriver)
+				}
+				if csi.VolumeAttributes["secretProviderClass"] == "" {
+					return fmt.Errorf("the csi volume %s of the driver %s requires the secretProviderClass volume attribute", volume.Name, secretsStoreCSIDriver)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *OpenTelemetryCollector) validateService() error {
+	svc := r.Spec.Service
+	if r.Spec.Mode == ModeSidecar && (svc.Type != "" || len(svc.NodePorts) > 0 || svc.LoadBalancerIP != "" || len(svc.LoadBalancerSourceRanges) > 0 ||
+		svc.IPFamilyPolicy != nil || len(svc.IPFamilies) > 0 || svc.Split != "" || len(svc.SplitServices) > 0) {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'service'", r.Spec.Mode)
+	}
+
+	if len(svc.NodePorts) > 0 && svc.Type != v1.ServiceTypeNodePort && svc.Type != v1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, nodePorts can only be used with the service types %s and %s",
+			v1.ServiceTypeNodePort, v1.ServiceTypeLoadBalancer,
+		)
+	}
+	for name, port := range svc.NodePorts {
+		if !ServiceNodePortRange.Contains(int(port)) {
+			return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, node port '%d' of port '%s' is outside of the range %s",
+				port, name, ServiceNodePortRange.String(),
+			)
+		}
+	}
+
+	if svc.Type != v1.ServiceTypeLoadBalancer && (svc.LoadBalancerIP != "" || len(svc.LoadBalancerSourceRanges) > 0) {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, loadBalancerIP and loadBalancerSourceRanges can only be used with the service type %s",
+			v1.ServiceTypeLoadBalancer,
+		)
+	}
+
+	if svc.Split == "" && len(svc.SplitServices) > 0 {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, splitServices can only be used when split is set")
+	}
+
+	if svc.Type == v1.ServiceTypeLoadBalancer && r.Spec.Mode == ModeDaemonSet && !svc.AllowLoadBalancerWithDaemonSet {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, the service type %s can only be used with the mode %s when 'allowLoadBalancerWithDaemonSet' is set",
+			v1.ServiceTypeLoadBalancer, ModeDaemonSet,
+		)
+	}
+
+	return r.validateServiceIPFamilies()
+}
+
+func (r *OpenTelemetryCollector) validateServiceIPFamilies() error {
+	svc := r.Spec.Service
+	seen := map[v1.IPFamily]bool{}
+	for _, family := range svc.IPFamilies {
+		if family != v1.IPv4Protocol && family != v1.IPv6Protocol {
+			return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, ipFamilies can only contain %s and %s", v1.IPv4Protocol, v1.IPv6Protocol)
+		}
+		if seen[family] {
+			return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, ipFamilies contains %s twice", family)
+		}
+		seen[family] = true
+	}
+
+	singleStack := svc.IPFamilyPolicy != nil && *svc.IPFamilyPolicy == v1.IPFamilyPolicySingleStack
+	if singleStack && len(svc.IPFamilies) > 1 {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, ipFamilies can only contain one family with the ipFamilyPolicy %s", v1.IPFamilyPolicySingleStack)
+	}
+
+	dualStack := len(svc.IPFamilies) > 1 || (svc.IPFamilyPolicy != nil && !singleStack)
+	if !dualStack || DualStackSupported == nil {
+		return nil
+	}
+	supported, err := DualStackSupported()
+	if err != nil {
+		// the services are still checked by the API server
+		opentelemetrycollectorlog.Error(err, "failed to verify the dual-stack support of the cluster", "name", r.Name)
+		return nil
+	}
+	if !supported {
+		return fmt.Errorf("the OpenTelemetry Spec Service configuration is incorrect, dual-stack services aren't available in this cluster")
+	}
+	return nil
+}
+
+// validateVPA checks the VerticalPodAutoscaler of the collector, which only adjusts the resources of daemonset
+// collectors, as the other modes scale horizontally.
+func validateVPA(mode Mode, vpa VPASpec) error {
+	if mode != ModeDaemonSet {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'autoscaler.vpa'", mode)
+	}
+	for _, resource := range vpa.ControlledResources {
+		if resource != v1.ResourceCPU && resource != v1.ResourceMemory {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, the vpa can only control the cpu and memory resources, not %s", resource)
+		}
+	}
+	for name, max := range vpa.MaxAllowed {
+		if min, ok := vpa.MinAllowed[name]; ok && min.Cmp(max) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, the vpa minAllowed %s must not be greater than its maxAllowed", name)
+		}
+	}
+	return nil
+}
+
+// validatePrometheusReceiver checks that the config has a prometheus receiver, whose scrape configs are replaced by the
+// ones of the referenced target allocator.
+func validatePrometheusReceiver(config string) error {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return err
+	}
+	receivers, ok := cfg["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("the config has no receivers")
+	}
+	if _, ok := receivers["prometheus"]; !ok {
+		return fmt.Errorf("the config has no prometheus receiver to allocate the targets to")
+	}
 	return nil
 }
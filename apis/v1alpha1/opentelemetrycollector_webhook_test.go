@@ -15,13 +15,26 @@
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestOTELColDefaultingWebhook(t *testing.T) {
@@ -44,9 +57,18 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeDeployment,
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Mode:                 ModeDeployment,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeClusterIP,
+					},
 				},
 			},
 		},
@@ -66,9 +88,14 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeSidecar,
-					Replicas:        &five,
-					UpgradeStrategy: "adhoc",
+					Mode:                 ModeSidecar,
+					Replicas:             &five,
+					UpgradeStrategy:      "adhoc",
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
 				},
 			},
 		},
@@ -86,13 +113,58 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 					},
 				},
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeDeployment,
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Mode:                 ModeDeployment,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
 					Autoscaler: &AutoscalerSpec{
 						TargetCPUUtilization: &defaultCPUTarget,
 					},
 					MaxReplicas: &five,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeClusterIP,
+					},
+				},
+			},
+		},
+		{
+			name: "Missing ingress rule type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type: IngressTypeNginx,
+					},
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type:     IngressTypeNginx,
+						RuleType: IngressRuleTypePath,
+					},
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeClusterIP,
+					},
 				},
 			},
 		},
@@ -120,8 +192,109 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 							Termination: TLSRouteTerminationTypeEdge,
 						},
 					},
-					Replicas:        &one,
-					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeClusterIP,
+					},
+				},
+			},
+		},
+		{
+			name: "provided service type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeNodePort,
+					},
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeDeployment,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeNodePort,
+					},
+				},
+			},
+		},
+		{
+			name: "pod disruption budget without limits",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                ModeDeployment,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{},
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeDeployment,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyAlways,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyForeground,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
+					RevisionHistoryLimit: &one,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					},
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeClusterIP,
+					},
+				},
+			},
+		},
+		{
+			name: "provided reconcile and gc policies",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeSidecar,
+					ReconcilePolicy: ReconcilePolicyOnDemand,
+					GCPolicy:        GCPolicyOrphan,
+				},
+			},
+			expected: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeSidecar,
+					Replicas:             &one,
+					UpgradeStrategy:      UpgradeStrategyAutomatic,
+					ReconcilePolicy:      ReconcilePolicyOnDemand,
+					ManagementState:      ManagementStateManaged,
+					GCPolicy:             GCPolicyOrphan,
+					ConfigReloadStrategy: ConfigReloadStrategyRestart,
+					LivenessProbe:        &LivenessProbeSpec{Protocol: ProbeProtocolHTTP},
 				},
 			},
 		},
@@ -135,11 +308,64 @@ func TestOTELColDefaultingWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColDefaultingWebhookLivenessProbe(t *testing.T) {
+	defer func() {
+		GRPCProbeSupported = false
+	}()
+
+	for _, tt := range []struct {
+		name      string
+		supported bool
+		mode      Mode
+		probe     *LivenessProbeSpec
+		expected  ProbeProtocol
+	}{
+		{name: "grpc probes not supported", mode: ModeDeployment, expected: ProbeProtocolHTTP},
+		{name: "grpc probes supported", supported: true, mode: ModeDaemonSet, expected: ProbeProtocolGRPC},
+		{name: "sidecar", supported: true, mode: ModeSidecar, expected: ProbeProtocolHTTP},
+		{name: "provided protocol", supported: true, mode: ModeDeployment, probe: &LivenessProbeSpec{Protocol: ProbeProtocolHTTP}, expected: ProbeProtocolHTTP},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			GRPCProbeSupported = tt.supported
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          tt.mode,
+					LivenessProbe: tt.probe,
+				},
+			}
+
+			otelcol.Default()
+
+			assert.Equal(t, tt.expected, otelcol.Spec.LivenessProbe.Protocol)
+		})
+	}
+}
+
 func TestOTELColValidatingWebhook(t *testing.T) {
+	singleStack := v1.IPFamilyPolicySingleStack
+	preferDualStack := v1.IPFamilyPolicyPreferDualStack
+	kata := "kata"
 	zero := int32(0)
 	one := int32(1)
 	three := int32(3)
 	five := int32(5)
+	hundredOne := int32(101)
+	thirty := int64(30)
+	minusOne := int64(-1)
+	sixty := int64(60)
+	readOnly := true
+	shareProcessNamespace, unsharedProcessNamespace := true, false
+	scraperConfig := `receivers:
+  prometheus:
+    config: {}
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [logging]
+`
 
 	tests := []struct { //nolint:govet
 		name        string
@@ -154,11 +380,13 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			name: "valid full spec",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:            ModeStatefulSet,
-					MinReplicas:     &one,
-					Replicas:        &three,
-					MaxReplicas:     &five,
-					UpgradeStrategy: "adhoc",
+					Mode:              ModeStatefulSet,
+					MinReplicas:       &one,
+					Replicas:          &three,
+					MaxReplicas:       &five,
+					UpgradeStrategy:   "adhoc",
+					ReconcilePolicy:   ReconcilePolicyScheduled,
+					ReconcileSchedule: "*/30 * * * *",
 					TargetAllocator: OpenTelemetryTargetAllocator{
 						Enabled: true,
 					},
@@ -169,13 +397,23 @@ func TestOTELColValidatingWebhook(t *testing.T) {
     endpoint: "0.0.0.0:12346"
   prometheus:
     config:
-      scrape_config:
-        job_name: otel-collector
+      scrape_configs:
+      - job_name: otel-collector
         scrape_interval: 10s
   jaeger/custom:
     protocols:
       thrift_http:
         endpoint: 0.0.0.0:15268
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [examplereceiver, jaeger/custom]
+      exporters: [logging]
+    metrics:
+      receivers: [examplereceiver/settings, prometheus]
+      exporters: [logging]
 `,
 					Ports: []v1.ServicePort{
 						{
@@ -202,6 +440,62 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid autoscaling on the targets",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeStatefulSet,
+					MaxReplicas: &five,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+					Autoscaler: &AutoscalerSpec{
+						TargetsPerReplica: &five,
+					},
+					Config: scraperConfig,
+				},
+			},
+		},
+		{
+			name: "valid config completed by config sources",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+					ConfigSources: []ConfigSource{
+						{
+							ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "shared-config"},
+								Key:                  "receivers.yaml",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "valid target allocator reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeStatefulSet,
+					TargetAllocatorRef: "shared",
+					Config: `receivers:
+  prometheus:
+exporters:
+  otlp:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [otlp]
+`,
+				},
+			},
+		},
 		{
 			name: "invalid mode with volume claim templates",
 			otelcol: OpenTelemetryCollector{
@@ -213,226 +507,2279 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			expectedErr: "does not support the attribute 'volumeClaimTemplates'",
 		},
 		{
-			name: "invalid mode with tolerations",
+			name: "invalid mode with persistent volume claim retention policy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:        ModeSidecar,
-					Tolerations: []v1.Toleration{{}, {}},
+					Mode:                                 ModeDeployment,
+					PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{},
 				},
 			},
-			expectedErr: "does not support the attribute 'tolerations'",
+			expectedErr: "does not support the attribute 'persistentVolumeClaimRetentionPolicy'",
 		},
 		{
-			name: "invalid mode with target allocator",
+			name: "invalid mode with podManagementPolicy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeDeployment,
-					TargetAllocator: OpenTelemetryTargetAllocator{
-						Enabled: true,
-					},
+					Mode:                ModeDeployment,
+					PodManagementPolicy: appsv1.OrderedReadyPodManagement,
 				},
 			},
-			expectedErr: "does not support the target allocation deployment",
+			expectedErr: "does not support the attribute 'podManagementPolicy'",
 		},
 		{
-			name: "invalid target allocator config",
+			name: "invalid mode with deploymentUpdateStrategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeStatefulSet,
-					TargetAllocator: OpenTelemetryTargetAllocator{
-						Enabled: true,
+					Mode: ModeDaemonSet,
+					DeploymentUpdateStrategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RecreateDeploymentStrategyType,
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Prometheus configuration is incorrect",
+			expectedErr: "does not support the attribute 'deploymentUpdateStrategy'",
 		},
 		{
-			name: "invalid port name",
+			name: "invalid mode with daemonSetUpdateStrategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							// this port name contains a non alphanumeric character, which is invalid.
-							Name:     "-test🦄port",
-							Port:     12345,
-							Protocol: v1.ProtocolTCP,
-						},
+					Mode: ModeDeployment,
+					DaemonSetUpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+						Type: appsv1.OnDeleteDaemonSetStrategyType,
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "does not support the attribute 'daemonSetUpdateStrategy'",
 		},
 		{
-			name: "invalid port name, too long",
+			name: "invalid mode with statefulSetUpdateStrategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccdddd", // len: 16, too long
-							Port: 5555,
-						},
+					Mode: ModeDeployment,
+					StatefulSetUpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.OnDeleteStatefulSetStrategyType,
 					},
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "does not support the attribute 'statefulSetUpdateStrategy'",
 		},
 		{
-			name: "invalid port num",
+			name: "invalid pinned version",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Ports: []v1.ServicePort{
-						{
-							Name: "aaaabbbbccccddd", // len: 15
-							// no port set means it's 0, which is invalid
-						},
-					},
+					Version: "latest",
 				},
 			},
-			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+			expectedErr: "the OpenTelemetry Spec version is not a valid version: latest",
 		},
 		{
-			name: "invalid max replicas",
+			name: "invalid mode with native sidecar",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &zero,
+					Mode:          ModeDeployment,
+					NativeSidecar: true,
 				},
 			},
-			expectedErr: "maxReplicas should be defined and one or more",
+			expectedErr: "does not support the attribute 'nativeSidecar'",
 		},
 		{
-			name: "invalid replicas, greater than max",
+			name: "invalid mode with config reload strategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Replicas:    &five,
+					Mode:                 ModeSidecar,
+					ConfigReloadStrategy: ConfigReloadStrategyReload,
 				},
 			},
-			expectedErr: "replicas must not be greater than maxReplicas",
+			expectedErr: "does not support the config reload strategy reload",
 		},
 		{
-			name: "invalid min replicas, greater than max",
+			name: "invalid mode with podTemplateOverrides",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &five,
+					Mode:                 ModeSidecar,
+					PodTemplateOverrides: &runtime.RawExtension{Raw: []byte(`{"spec":{"hostAliases":[]}}`)},
 				},
 			},
-			expectedErr: "minReplicas must not be greater than maxReplicas",
+			expectedErr: "does not support the attribute 'podTemplateOverrides'",
 		},
 		{
-			name: "invalid min replicas, lesser than 1",
+			name: "invalid podTemplateOverrides",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					MinReplicas: &zero,
+					Mode:                 ModeDeployment,
+					PodTemplateOverrides: &runtime.RawExtension{Raw: []byte(`{"spec":{"containers":"otc-container"}}`)},
 				},
 			},
-			expectedErr: "minReplicas should be one or more",
+			expectedErr: "the OpenTelemetry Spec podTemplateOverrides configuration is incorrect",
 		},
 		{
-			name: "invalid autoscaler scale down",
+			name: "invalid mode with initContainers",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleDown: &autoscalingv2.HPAScalingRules{
-								StabilizationWindowSeconds: &zero,
-							},
-						},
-					},
+					Mode:           ModeSidecar,
+					InitContainers: []v1.Container{{Name: "geoip"}},
 				},
 			},
-			expectedErr: "scaleDown should be one or more",
+			expectedErr: "does not support the attribute 'initContainers'",
 		},
 		{
-			name: "invalid autoscaler scale up",
+			name: "invalid mode with additionalContainers",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
-							ScaleUp: &autoscalingv2.HPAScalingRules{
-								StabilizationWindowSeconds: &zero,
-							},
-						},
-					},
+					Mode:                 ModeSidecar,
+					AdditionalContainers: []v1.Container{{Name: "proxy"}},
 				},
 			},
-			expectedErr: "scaleUp should be one or more",
+			expectedErr: "does not support the attribute 'additionalContainers'",
 		},
 		{
-			name: "invalid autoscaler target cpu utilization",
+			name: "reserved container name",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					MaxReplicas: &three,
-					Autoscaler: &AutoscalerSpec{
-						TargetCPUUtilization: &zero,
-					},
+					Mode:                 ModeDeployment,
+					AdditionalContainers: []v1.Container{{Name: "otc-container"}},
 				},
 			},
-			expectedErr: "targetCPUUtilization should be greater than 0 and less than 100",
+			expectedErr: "the container name otc-container uses the reserved prefix otc-",
 		},
 		{
-			name: "invalid deployment mode incompabible with ingress settings",
+			name: "duplicate container name",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Ingress: Ingress{
-						Type: IngressTypeNginx,
-					},
+					Mode:                 ModeDeployment,
+					InitContainers:       []v1.Container{{Name: "geoip"}},
+					AdditionalContainers: []v1.Container{{Name: "geoip"}},
 				},
 			},
-			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
-				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
-			),
+			expectedErr: "the container name geoip is used more than once",
 		},
 		{
-			name: "invalid mode with priorityClassName",
+			name: "invalid mode with shareProcessNamespace",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode:              ModeSidecar,
-					PriorityClassName: "test-class",
+					Mode:                  ModeSidecar,
+					ShareProcessNamespace: &shareProcessNamespace,
 				},
 			},
-			expectedErr: "does not support the attribute 'priorityClassName'",
+			expectedErr: "does not support the attribute 'shareProcessNamespace'",
 		},
 		{
-			name: "invalid mode with affinity",
+			name: "unshared process namespace with the reload strategy",
 			otelcol: OpenTelemetryCollector{
 				Spec: OpenTelemetryCollectorSpec{
-					Mode: ModeSidecar,
-					Affinity: &v1.Affinity{
-						NodeAffinity: &v1.NodeAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
-								NodeSelectorTerms: []v1.NodeSelectorTerm{
-									{
-										MatchExpressions: []v1.NodeSelectorRequirement{
-											{
-												Key:      "node",
-												Operator: v1.NodeSelectorOpIn,
-												Values:   []string{"test-node"},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
+					Mode:                  ModeDeployment,
+					ConfigReloadStrategy:  ConfigReloadStrategyReload,
+					ShareProcessNamespace: &unsharedProcessNamespace,
 				},
 			},
-			expectedErr: "does not support the attribute 'affinity'",
+			expectedErr: "the OpenTelemetry Spec shareProcessNamespace configuration is incorrect",
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := test.otelcol.validateCRDSpec()
-			if test.expectedErr == "" {
-				assert.NoError(t, err)
-				return
+		{
+			name: "invalid mode with hostNetwork",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeSidecar,
+					HostNetwork: true,
+				},
+			},
+			expectedErr: "does not support the attribute 'hostNetwork'",
+		},
+		{
+			name: "invalid mode with dnsPolicy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeSidecar,
+					DNSPolicy: v1.DNSClusterFirstWithHostNet,
+				},
+			},
+			expectedErr: "does not support the attribute 'dnsPolicy'",
+		},
+		{
+			name: "invalid mode with dnsConfig",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeSidecar,
+					DNSConfig: &v1.PodDNSConfig{},
+				},
+			},
+			expectedErr: "does not support the attribute 'dnsConfig'",
+		},
+		{
+			name: "dnsPolicy None without nameservers",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeDaemonSet,
+					DNSPolicy: v1.DNSNone,
+					DNSConfig: &v1.PodDNSConfig{Searches: []string{"svc.cluster.local"}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec dnsConfig configuration is incorrect",
+		},
+		{
+			name: "invalid mode with tolerations",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeSidecar,
+					Tolerations: []v1.Toleration{{}, {}},
+				},
+			},
+			expectedErr: "does not support the attribute 'tolerations'",
+		},
+		{
+			name: "invalid mode with target allocator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+				},
+			},
+			expectedErr: "does not support the target allocation deployment",
+		},
+		{
+			name: "invalid target allocator config",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Prometheus configuration is incorrect",
+		},
+		{
+			name: "invalid target allocator relabel config",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+					Config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: app
+        relabel_configs:
+        - source_labels: [__address__]
+          regex: (.+):\d+
+          target_label: host
+          replacement: $1
+`,
+				},
+			},
+			expectedErr: "the relabel_configs 0 of the job app references $1 in its replacement, which the collector expands as an environment variable, use $$1 instead",
+		},
+		{
+			name: "invalid port name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							// this port name contains a non alphanumeric character, which is invalid.
+							Name:     "-test🦄port",
+							Port:     12345,
+							Protocol: v1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "invalid port name, too long",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							Name: "aaaabbbbccccdddd", // len: 16, too long
+							Port: 5555,
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "invalid port num",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Ports: []v1.ServicePort{
+						{
+							Name: "aaaabbbbccccddd", // len: 15
+							// no port set means it's 0, which is invalid
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
+		},
+		{
+			name: "invalid max replicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &zero,
+				},
+			},
+			expectedErr: "maxReplicas should be defined and one or more",
+		},
+		{
+			name: "invalid replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Replicas:    &five,
+				},
+			},
+			expectedErr: "replicas must not be greater than maxReplicas",
+		},
+		{
+			name: "invalid min replicas, greater than max",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &five,
+				},
+			},
+			expectedErr: "minReplicas must not be greater than maxReplicas",
+		},
+		{
+			name: "invalid min replicas, lesser than 1",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					MinReplicas: &zero,
+				},
+			},
+			expectedErr: "minReplicas should be one or more",
+		},
+		{
+			name: "invalid autoscaler scale down",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &zero,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleDown should be one or more",
+		},
+		{
+			name: "invalid autoscaler scale up",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleUp: &autoscalingv2.HPAScalingRules{
+								StabilizationWindowSeconds: &zero,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "scaleUp should be one or more",
+		},
+		{
+			name: "invalid autoscaler target cpu utilization",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						TargetCPUUtilization: &zero,
+					},
+				},
+			},
+			expectedErr: "targetCPUUtilization should be greater than 0 and less than 100",
+		},
+		{
+			name: "invalid deployment mode incompabible with ingress settings",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Ingress: Ingress{
+						Type: IngressTypeNginx,
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s",
+				ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+			),
+		},
+		{
+			name: "invalid gateway ingress without parent gateway",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type: IngressTypeGateway,
+					},
+				},
+			},
+			expectedErr: "the gateway type requires the name of the parent Gateway",
+		},
+		{
+			name: "invalid route hostname template",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type: IngressTypeRoute,
+						Route: OpenShiftRoute{
+							HostnameTemplate: "{port}.{cluster}.example.com",
+						},
+					},
+				},
+			},
+			expectedErr: "the route hostname template has an unknown placeholder {cluster}",
+		},
+		{
+			name: "invalid ingress subdomain rule type without hostname",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Ingress: Ingress{
+						Type:     IngressTypeNginx,
+						RuleType: IngressRuleTypeSubdomain,
+						Hostname: "*",
+					},
+				},
+			},
+			expectedErr: "a hostname is required when the rule type is subdomain",
+		},
+		{
+			name: "invalid mode with priorityClassName",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:              ModeSidecar,
+					PriorityClassName: "test-class",
+				},
+			},
+			expectedErr: "does not support the attribute 'priorityClassName'",
+		},
+		{
+			name: "invalid mode with affinity",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Affinity: &v1.Affinity{
+						NodeAffinity: &v1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+								NodeSelectorTerms: []v1.NodeSelectorTerm{
+									{
+										MatchExpressions: []v1.NodeSelectorRequirement{
+											{
+												Key:      "node",
+												Operator: v1.NodeSelectorOpIn,
+												Values:   []string{"test-node"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'affinity'",
+		},
+		{
+			name: "invalid mode with antiAffinityTarget",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					AntiAffinityTarget: &AntiAffinityTargetSpec{
+						PodLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "latency-sensitive"}},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'antiAffinityTarget'",
+		},
+		{
+			name: "antiAffinityTarget without podLabelSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					AntiAffinityTarget: &AntiAffinityTargetSpec{},
+				},
+			},
+			expectedErr: "podLabelSelector is required",
+		},
+		{
+			name: "invalid antiAffinityTarget podLabelSelector",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					AntiAffinityTarget: &AntiAffinityTargetSpec{
+						PodLabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+						},
+					},
+				},
+			},
+			expectedErr: "invalid podLabelSelector",
+		},
+		{
+			name: "invalid mode with service",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeNodePort,
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'service'",
+		},
+		{
+			name: "invalid node ports with cluster ip service",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						Type:      v1.ServiceTypeClusterIP,
+						NodePorts: map[string]int32{"otlp-grpc": 30317},
+					},
+				},
+			},
+			expectedErr: "nodePorts can only be used with the service types NodePort and LoadBalancer",
+		},
+		{
+			name: "invalid split services without split",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						SplitServices: map[string]SplitServiceSpec{"otlp-grpc": {Headless: true}},
+					},
+				},
+			},
+			expectedErr: "splitServices can only be used when split is set",
+		},
+		{
+			name: "invalid mode with split service",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Service: ServiceSpec{
+						Split: ServiceSplitReceiver,
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'service'",
+		},
+		{
+			name: "invalid node port out of range",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						Type:      v1.ServiceTypeNodePort,
+						NodePorts: map[string]int32{"otlp-grpc": 4317},
+					},
+				},
+			},
+			expectedErr: "node port '4317' of port 'otlp-grpc' is outside of the range 30000-32767",
+		},
+		{
+			name: "invalid load balancer settings with node port service",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						Type:           v1.ServiceTypeNodePort,
+						LoadBalancerIP: "10.0.0.1",
+					},
+				},
+			},
+			expectedErr: "loadBalancerIP and loadBalancerSourceRanges can only be used with the service type LoadBalancer",
+		},
+		{
+			name: "invalid load balancer with daemonset",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Service: ServiceSpec{
+						Type: v1.ServiceTypeLoadBalancer,
+					},
+				},
+			},
+			expectedErr: "the service type LoadBalancer can only be used with the mode daemonset when 'allowLoadBalancerWithDaemonSet' is set",
+		},
+		{
+			name: "invalid ip family",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilies: []v1.IPFamily{"IPv5"},
+					},
+				},
+			},
+			expectedErr: "ipFamilies can only contain IPv4 and IPv6",
+		},
+		{
+			name: "duplicate ip family",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilies: []v1.IPFamily{v1.IPv6Protocol, v1.IPv6Protocol},
+					},
+				},
+			},
+			expectedErr: "ipFamilies contains IPv6 twice",
+		},
+		{
+			name: "two ip families with single stack",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilyPolicy: &singleStack,
+						IPFamilies:     []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol},
+					},
+				},
+			},
+			expectedErr: "ipFamilies can only contain one family with the ipFamilyPolicy SingleStack",
+		},
+		{
+			name: "invalid mode with ip family policy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Service: ServiceSpec{
+						IPFamilyPolicy: &preferDualStack,
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'service'",
+		},
+		{
+			name: "invalid mode with runtime class name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:             ModeSidecar,
+					RuntimeClassName: &kata,
+				},
+			},
+			expectedErr: "does not support the attribute 'runtimeClassName'",
+		},
+		{
+			name: "overhead without runtime class name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Overhead: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("250m"),
+					},
+				},
+			},
+			expectedErr: "overhead can only be used with a runtimeClassName",
+		},
+		{
+			name: "invalid mode with revision history limit",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeStatefulSet,
+					RevisionHistoryLimit: &one,
+				},
+			},
+			expectedErr: "does not support the attribute 'revisionHistoryLimit'",
+		},
+		{
+			name: "no revision history with smoke test rollbacks",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeDeployment,
+					RevisionHistoryLimit: &zero,
+					SmokeTest: &SmokeTestSpec{
+						Rollback: true,
+					},
+				},
+			},
+			expectedErr: "the smoke test rollbacks need at least one previous revision",
+		},
+		{
+			name: "invalid mode with terminationGracePeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                          ModeSidecar,
+					TerminationGracePeriodSeconds: &thirty,
+				},
+			},
+			expectedErr: "does not support the attribute 'terminationGracePeriodSeconds'",
+		},
+		{
+			name: "negative terminationGracePeriodSeconds",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                          ModeDeployment,
+					TerminationGracePeriodSeconds: &minusOne,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec terminationGracePeriodSeconds configuration is incorrect",
+		},
+		{
+			name: "projected service account token and secrets store csi volumes",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{
+						{
+							Name: "token",
+							VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+								Sources: []v1.VolumeProjection{{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Audience: "vault", Path: "token"}}},
+							}},
+						},
+						{
+							Name: "certs",
+							VolumeSource: v1.VolumeSource{CSI: &v1.CSIVolumeSource{
+								Driver:           "secrets-store.csi.k8s.io",
+								ReadOnly:         &readOnly,
+								VolumeAttributes: map[string]string{"secretProviderClass": "vault-certs"},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "projected service account token without path",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{{
+						Name: "token",
+						VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Audience: "vault"}}},
+						}},
+					}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec volumes configuration is incorrect, the serviceAccountToken of the volume token requires a path",
+		},
+		{
+			name: "projected service account token expiring too soon",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{{
+						Name: "token",
+						VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Path: "token", ExpirationSeconds: &sixty}}},
+						}},
+					}},
+				},
+			},
+			expectedErr: "the serviceAccountToken of the volume token must expire after at least 600 seconds",
+		},
+		{
+			name: "csi volume without driver",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{{Name: "certs", VolumeSource: v1.VolumeSource{CSI: &v1.CSIVolumeSource{}}}},
+				},
+			},
+			expectedErr: "the csi volume certs requires a driver",
+		},
+		{
+			name: "writable secrets store csi volume",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{{Name: "certs", VolumeSource: v1.VolumeSource{CSI: &v1.CSIVolumeSource{
+						Driver:           "secrets-store.csi.k8s.io",
+						VolumeAttributes: map[string]string{"secretProviderClass": "vault-certs"},
+					}}}},
+				},
+			},
+			expectedErr: "the csi volume certs of the driver secrets-store.csi.k8s.io must be read-only",
+		},
+		{
+			name: "secrets store csi volume without secret provider class",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Volumes: []v1.Volume{{Name: "certs", VolumeSource: v1.VolumeSource{CSI: &v1.CSIVolumeSource{
+						Driver:   "secrets-store.csi.k8s.io",
+						ReadOnly: &readOnly,
+					}}}},
+				},
+			},
+			expectedErr: "the csi volume certs of the driver secrets-store.csi.k8s.io requires the secretProviderClass volume attribute",
+		},
+		{
+			name: "memory limiter spike percentage without limit percentage",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MemoryTuning: MemoryTuningSpec{MemoryLimiterSpikePercentage: &five},
+				},
+			},
+			expectedErr: "memoryLimiterSpikePercentage requires memoryLimiterPercentage",
+		},
+		{
+			name: "memory limiter spike percentage above limit percentage",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MemoryTuning: MemoryTuningSpec{MemoryLimiterPercentage: &three, MemoryLimiterSpikePercentage: &five},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec memoryTuning configuration is incorrect, memoryLimiterSpikePercentage must be lower than memoryLimiterPercentage",
+		},
+		{
+			name: "liveness probe successThreshold other than 1",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					LivenessProbe: &LivenessProbeSpec{ProbeSpec: ProbeSpec{SuccessThreshold: &three}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec livenessProbe configuration is incorrect, successThreshold must be 1",
+		},
+		{
+			name: "startup probe successThreshold other than 1",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					StartupProbe: &ProbeSpec{SuccessThreshold: &three},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec startupProbe configuration is incorrect, successThreshold must be 1",
+		},
+		{
+			name: "invalid mode with shutdown timeout",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDaemonSet,
+					ShutdownTimeout: "30s",
+				},
+			},
+			expectedErr: "does not support the attribute 'shutdownTimeout'",
+		},
+		{
+			name: "invalid shutdown timeout",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDeployment,
+					ShutdownTimeout: "30",
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec shutdownTimeout configuration is incorrect",
+		},
+		{
+			name: "negative shutdown timeout",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeDeployment,
+					ShutdownTimeout: "-5s",
+				},
+			},
+			expectedErr: "the timeout can't be negative",
+		},
+		{
+			name: "prestop sleep without shutdown timeout",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeDeployment,
+					ShutdownPreStopSleep: true,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec shutdownPreStopSleep configuration is incorrect",
+		},
+		{
+			name: "invalid mode with pre-deploy check",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					PreDeployCheck: &PreDeployCheckSpec{
+						Image: "busybox",
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'preDeployCheck'",
+		},
+		{
+			name: "missing pre-deploy check image",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					PreDeployCheck: &PreDeployCheckSpec{
+						Command: []string{"/check.sh"},
+					},
+				},
+			},
+			expectedErr: "preDeployCheck configuration is incorrect, image is required",
+		},
+		{
+			name: "invalid mode with build",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Build: &BuildSpec{
+						Manifest: "receivers:\n  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.74.0\n",
+						Image:    "registry.example.com/otelcol",
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'build'",
+		},
+		{
+			name: "missing build manifest",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Build: &BuildSpec{
+						Image: "registry.example.com/otelcol",
+					},
+				},
+			},
+			expectedErr: "build configuration is incorrect, manifest is required",
+		},
+		{
+			name: "invalid build manifest",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Build: &BuildSpec{
+						Manifest: "receivers: [",
+						Image:    "registry.example.com/otelcol",
+					},
+				},
+			},
+			expectedErr: "build configuration is incorrect, manifest",
+		},
+		{
+			name: "tagged build image",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Build: &BuildSpec{
+						Manifest: "receivers:\n  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.74.0\n",
+						Image:    "registry.example.com:5000/otelcol:latest",
+					},
+				},
+			},
+			expectedErr: "should not have a tag or digest",
+		},
+		{
+			name: "invalid mode with topology spread constraints",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					TopologySpreadConstraints: []v1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       v1.LabelTopologyZone,
+						WhenUnsatisfiable: v1.ScheduleAnyway,
+					}},
+				},
+			},
+			expectedErr: "does not support the attribute 'topologySpreadConstraints'",
+		},
+		{
+			name: "invalid mode with pod disruption budget",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                ModeDaemonSet,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'podDisruptionBudget'",
+		},
+		{
+			name: "invalid mode with network policy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeSidecar,
+					NetworkPolicy: &NetworkPolicySpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'networkPolicy'",
+		},
+		{
+			name: "invalid mode with cluster role",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					RBAC: RBACSpec{ClusterRole: true},
+				},
+			},
+			expectedErr: "does not support the attribute 'rbac.clusterRole'",
+		},
+		{
+			name: "missing target allocator mtls issuer name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						MTLS: &TargetAllocatorMTLS{IssuerRef: &CertificateIssuerRef{Kind: "ClusterIssuer"}},
+					},
+				},
+			},
+			expectedErr: "the issuer name is required",
+		},
+		{
+			name: "invalid target allocator mtls issuer kind",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						MTLS: &TargetAllocatorMTLS{IssuerRef: &CertificateIssuerRef{Name: "ca", Kind: "Certificate"}},
+					},
+				},
+			},
+			expectedErr: "the issuer kind Certificate is neither Issuer nor ClusterIssuer",
+		},
+		{
+			name: "invalid target allocator allocation strategy in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:            true,
+						AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+					},
+				},
+			},
+			expectedErr: "which only supports the per-node allocation strategy",
+		},
+		{
+			name: "invalid target allocator replicas with the least-weighted allocation strategy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:  true,
+						Replicas: &three,
+					},
+				},
+			},
+			expectedErr: "the least-weighted allocation strategy doesn't assign the same targets on all replicas",
+		},
+		{
+			name: "invalid target allocator allocation rule without a change",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:         true,
+						AllocationRules: []TargetAllocatorAllocationRule{{Name: "federation", Jobs: []string{"federate"}}},
+					},
+				},
+			},
+			expectedErr: "the allocation rule federation doesn't change the allocation",
+		},
+		{
+			name: "invalid target allocator allocation rule replicas with the least-weighted allocation strategy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:            true,
+						Replicas:           &three,
+						AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+						AllocationRules: []TargetAllocatorAllocationRule{{
+							Name:               "federation",
+							Jobs:               []string{"federate"},
+							AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted,
+						}},
+					},
+				},
+			},
+			expectedErr: "the allocation rule federation is incorrect, the least-weighted allocation strategy doesn't assign the same targets on all replicas",
+		},
+		{
+			name: "invalid target allocator allocation rule strategy in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled:            true,
+						AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyPerNode,
+						AllocationRules: []TargetAllocatorAllocationRule{{
+							Name:               "federation",
+							Jobs:               []string{"federate"},
+							AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+						}},
+					},
+				},
+			},
+			expectedErr: "which only supports the per-node allocation strategy",
+		},
+		{
+			name: "invalid target allocator reference with an enabled target allocator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeStatefulSet,
+					TargetAllocatorRef: "shared",
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+					},
+				},
+			},
+			expectedErr: "it can't be set along with an enabled targetAllocator",
+		},
+		{
+			name: "invalid mode with target allocator reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeDeployment,
+					TargetAllocatorRef: "shared",
+				},
+			},
+			expectedErr: "does not support the target allocation deployment",
+		},
+		{
+			name: "invalid target allocator reference without prometheus receiver",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:               ModeStatefulSet,
+					TargetAllocatorRef: "shared",
+					Config:             "receivers:\n  otlp: {}\n",
+				},
+			},
+			expectedErr: "the config has no prometheus receiver",
+		},
+		{
+			name: "pod disruption budget with minAvailable and maxUnavailable",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{
+						MinAvailable:   &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					},
+				},
+			},
+			expectedErr: "minAvailable and maxUnavailable are mutually exclusive",
+		},
+		{
+			name: "pod disruption budget with an invalid percentage",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					PodDisruptionBudget: &PodDisruptionBudgetSpec{
+						MinAvailable: &intstr.IntOrString{Type: intstr.String, StrVal: "half"},
+					},
+				},
+			},
+			expectedErr: "podDisruptionBudget configuration is incorrect",
+		},
+		{
+			name: "keda without maxReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Autoscaler: &AutoscalerSpec{
+						Keda: &KedaSpec{Triggers: []KedaTrigger{{Type: "kafka"}}},
+					},
+				},
+			},
+			expectedErr: "maxReplicas is required to scale with keda",
+		},
+		{
+			name: "keda without triggers",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Keda: &KedaSpec{},
+					},
+				},
+			},
+			expectedErr: "keda requires at least one trigger",
+		},
+		{
+			name: "keda trigger without type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					MaxReplicas: &three,
+					Autoscaler: &AutoscalerSpec{
+						Keda: &KedaSpec{Triggers: []KedaTrigger{{Metadata: map[string]string{"topic": "otlp_spans"}}}},
+					},
+				},
+			},
+			expectedErr: "the keda triggers require a type",
+		},
+		{
+			name: "targets per replica with an invalid mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeDeployment,
+					MaxReplicas: &three,
+					Autoscaler:  &AutoscalerSpec{TargetsPerReplica: &three},
+				},
+			},
+			expectedErr: "does not support the attribute 'autoscaler.targetsPerReplica'",
+		},
+		{
+			name: "targets per replica without maxReplicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{Enabled: true},
+					Autoscaler:      &AutoscalerSpec{TargetsPerReplica: &three},
+					Config:          scraperConfig,
+				},
+			},
+			expectedErr: "maxReplicas is required to scale on the targets",
+		},
+		{
+			name: "invalid targets per replica",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeStatefulSet,
+					MaxReplicas:     &three,
+					TargetAllocator: OpenTelemetryTargetAllocator{Enabled: true},
+					Autoscaler:      &AutoscalerSpec{TargetsPerReplica: &zero},
+					Config:          scraperConfig,
+				},
+			},
+			expectedErr: "targetsPerReplica should be one or more",
+		},
+		{
+			name: "targets per replica without target allocator",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeStatefulSet,
+					MaxReplicas: &three,
+					Autoscaler:  &AutoscalerSpec{TargetsPerReplica: &three},
+				},
+			},
+			expectedErr: "targetsPerReplica requires the target allocator",
+		},
+		{
+			name: "targets per replica with target allocator mtls",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:        ModeStatefulSet,
+					MaxReplicas: &three,
+					TargetAllocator: OpenTelemetryTargetAllocator{
+						Enabled: true,
+						MTLS:    &TargetAllocatorMTLS{},
+					},
+					Autoscaler: &AutoscalerSpec{TargetsPerReplica: &three},
+					Config:     scraperConfig,
+				},
+			},
+			expectedErr: "targetsPerReplica can't be combined with the target allocator mtls or a networkPolicy",
+		},
+		{
+			name: "invalid mode with vpa",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:       ModeDeployment,
+					Autoscaler: &AutoscalerSpec{VPA: &VPASpec{}},
+				},
+			},
+			expectedErr: "does not support the attribute 'autoscaler.vpa'",
+		},
+		{
+			name: "invalid vpa controlled resources",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Autoscaler: &AutoscalerSpec{VPA: &VPASpec{
+						ControlledResources: []v1.ResourceName{v1.ResourceMemory, v1.ResourceEphemeralStorage},
+					}},
+				},
+			},
+			expectedErr: "the vpa can only control the cpu and memory resources, not ephemeral-storage",
+		},
+		{
+			name: "invalid vpa allowed resources",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Autoscaler: &AutoscalerSpec{VPA: &VPASpec{
+						MinAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+						MaxAllowed: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+					}},
+				},
+			},
+			expectedErr: "the vpa minAllowed memory must not be greater than its maxAllowed",
+		},
+		{
+			name: "invalid mode with smoke test",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:      ModeDaemonSet,
+					SmokeTest: &SmokeTestSpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'smokeTest'",
+		},
+		{
+			name: "invalid mode with canary",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeStatefulSet,
+					Canary: &CanarySpec{},
+				},
+			},
+			expectedErr: "does not support the attribute 'canary'",
+		},
+		{
+			name: "invalid canary replicas",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Canary: &CanarySpec{Replicas: &zero},
+				},
+			},
+			expectedErr: "replicas should be one or more",
+		},
+		{
+			name: "invalid canary failure percent",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:   ModeDeployment,
+					Canary: &CanarySpec{MaxExportFailurePercent: &hundredOne},
+				},
+			},
+			expectedErr: "maxExportFailurePercent should be between 0 and 100",
+		},
+		{
+			name: "invalid mode with federation reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeSidecar,
+					FederationRef: &FederationRef{ClusterName: "workload"},
+				},
+			},
+			expectedErr: "does not support the attribute 'federationRef'",
+		},
+		{
+			name: "missing federation cluster name",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{Namespace: "fleet"},
+				},
+			},
+			expectedErr: "federationRef configuration is incorrect, clusterName is required",
+		},
+		{
+			name: "federation cluster in another namespace",
+			otelcol: OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "observability"},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{ClusterName: "workload", Namespace: "fleet"},
+				},
+			},
+			expectedErr: "the cluster has to be in the namespace observability of the instance",
+		},
+		{
+			name: "smoke test with federation reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{ClusterName: "workload"},
+					SmokeTest:     &SmokeTestSpec{},
+				},
+			},
+			expectedErr: "smoke tests aren't supported for remote clusters",
+		},
+		{
+			name: "insecure tls settings with enforced security",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TLS: TLSSpec{
+						EnforceSecure: true,
+					},
+					Config: `exporters:
+  otlp:
+    endpoint: collector:4317
+    tls:
+      insecure: true
+`,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Config configuration is insecure, exporter 'otlp' sets 'tls.insecure: true'",
+		},
+		{
+			name: "receiver certificates with sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					TLS: TLSSpec{
+						IssuerRef: &CertificateIssuerRef{Name: "ca-issuer"},
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'tls.issuerRef'",
+		},
+		{
+			name: "receiver certificates with an invalid issuer kind",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TLS: TLSSpec{
+						IssuerRef: &CertificateIssuerRef{Name: "ca-issuer", Kind: "Secret"},
+					},
+				},
+			},
+			expectedErr: "the issuer kind Secret is neither Issuer nor ClusterIssuer",
+		},
+		{
+			name: "receiver certificates in a shared directory",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					TLS: TLSSpec{
+						IssuerRef: &CertificateIssuerRef{Name: "ca-issuer"},
+					},
+					Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/otlp.crt
+          key_file: /certs/otlp.key
+  jaeger:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/jaeger.crt
+          key_file: /certs/jaeger.key
+`,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec tls configuration is incorrect, the TLS files of receivers 'jaeger' and 'otlp' are both in the directory /certs",
+		},
+		{
+			name: "invalid config template",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `exporters:
+  otlp:
+    endpoint: ${cr.spec.hostname}:4317
+`,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Config template is incorrect, failed to evaluate the expression \"cr.spec.hostname\": no such key: hostname",
+		},
+		{
+			name: "invalid config yaml",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: "receivers: [",
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Config configuration is incorrect, couldn't parse the opentelemetry-collector configuration",
+		},
+		{
+			name: "invalid config pipelines",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `receivers:
+  otlp:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`,
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec Config configuration is incorrect, pipeline 'traces' references the undefined exporter 'otlp'",
+		},
+		{
+			name: "config source without reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ConfigSources: []ConfigSource{{}},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec configSources configuration is incorrect, source 0 must set exactly one of configMapKeyRef and secretKeyRef",
+		},
+		{
+			name: "encrypted values without encryption key",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: `exporters:
+  otlp:
+    headers:
+      api-key: enc:bm90LXJlYWxseS1lbmNyeXB0ZWQ=
+`,
+				},
+			},
+			expectedErr: "encrypted values require the attribute 'encryptionKeyRef'",
+		},
+		{
+			name: "missing event export type",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					EventExport: EventExportSpec{
+						Enabled: true,
+						Brokers: []string{"nats://nats:4222"},
+					},
+				},
+			},
+			expectedErr: "type is required when the event export is enabled",
+		},
+		{
+			name: "missing event export brokers",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					EventExport: EventExportSpec{
+						Enabled: true,
+						Type:    EventExportTypeKafka,
+					},
+				},
+			},
+			expectedErr: "at least one broker is required when the event export is enabled",
+		},
+		{
+			name: "missing reconcile schedule",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ReconcilePolicy: ReconcilePolicyScheduled,
+				},
+			},
+			expectedErr: "reconcileSchedule is required with the reconcile policy Scheduled",
+		},
+		{
+			name: "invalid reconcile schedule",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ReconcilePolicy:   ReconcilePolicyScheduled,
+					ReconcileSchedule: "every hour",
+				},
+			},
+			expectedErr: "reconcileSchedule is not a valid cron schedule",
+		},
+		{
+			name: "reconcile schedule without scheduled policy",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ReconcilePolicy:   ReconcilePolicyOnDemand,
+					ReconcileSchedule: "0 * * * *",
+				},
+			},
+			expectedErr: "reconcileSchedule can only be used with the reconcile policy Scheduled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.otelcol.validateCRDSpec()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookDualStack(t *testing.T) {
+	defer func() {
+		DualStackSupported = nil
+	}()
+	requireDualStack := v1.IPFamilyPolicyRequireDualStack
+
+	tests := []struct { //nolint:govet
+		name        string
+		supported   bool
+		err         error
+		otelcol     OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:      "dual-stack policy in a dual-stack cluster",
+			supported: true,
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilyPolicy: &requireDualStack,
+						IPFamilies:     []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol},
+					},
+				},
+			},
+		},
+		{
+			name: "dual-stack policy in a single-stack cluster",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilyPolicy: &requireDualStack,
+					},
+				},
+			},
+			expectedErr: "dual-stack services aren't available in this cluster",
+		},
+		{
+			name: "two ip families in a single-stack cluster",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilies: []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol},
+					},
+				},
+			},
+			expectedErr: "dual-stack services aren't available in this cluster",
+		},
+		{
+			name: "single ip family in a single-stack cluster",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilies: []v1.IPFamily{v1.IPv6Protocol},
+					},
+				},
+			},
+		},
+		{
+			name: "failed detection",
+			err:  fmt.Errorf("connection refused"),
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						IPFamilyPolicy: &requireDualStack,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			DualStackSupported = func() (bool, error) {
+				return test.supported, test.err
+			}
+			err := test.otelcol.validateCRDSpec()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookServiceNodePortRange(t *testing.T) {
+	defaultRange := ServiceNodePortRange
+	defer func() {
+		ServiceNodePortRange = defaultRange
+	}()
+	require.NoError(t, ServiceNodePortRange.Set("20000-22767"))
+
+	tests := []struct { //nolint:govet
+		name        string
+		nodePort    int32
+		expectedErr string
+	}{
+		{
+			name:     "node port in the configured range",
+			nodePort: 20317,
+		},
+		{
+			name:        "node port in the default range only",
+			nodePort:    30317,
+			expectedErr: "node port '30317' of port 'otlp-grpc' is outside of the range 20000-22767",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Service: ServiceSpec{
+						Type:      v1.ServiceTypeNodePort,
+						NodePorts: map[string]int32{"otlp-grpc": test.nodePort},
+					},
+				},
+			}
+			err := otelcol.validateCRDSpec()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookFeatureGates(t *testing.T) {
+	defer func() {
+		require.NoError(t, featuregate.Gates.Set("MultiClusterFederation=false,TargetAllocator=true,CollectorClusterRoles=false"))
+	}()
+
+	tests := []struct { //nolint:govet
+		name        string
+		gates       string
+		otelcol     OpenTelemetryCollector
+		expectedErr string
+	}{
+		{
+			name:  "target allocator with the gate disabled",
+			gates: "TargetAllocator=false",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeStatefulSet,
+					TargetAllocator: OpenTelemetryTargetAllocator{Enabled: true},
+				},
+			},
+			expectedErr: "the operator feature gate TargetAllocator is disabled",
+		},
+		{
+			name:  "federation reference with the gate disabled",
+			gates: "MultiClusterFederation=false",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{ClusterName: "workload"},
+				},
+			},
+			expectedErr: "the operator feature gate MultiClusterFederation is disabled",
+		},
+		{
+			name:  "cluster role with the gate disabled",
+			gates: "CollectorClusterRoles=false",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					RBAC: RBACSpec{ClusterRole: true},
+				},
+			},
+			expectedErr: "the operator feature gate CollectorClusterRoles is disabled",
+		},
+		{
+			name:  "cluster role with the gate enabled",
+			gates: "CollectorClusterRoles=true",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					RBAC: RBACSpec{ClusterRole: true},
+				},
+			},
+		},
+		{
+			name:  "secret config source with federation reference",
+			gates: "MultiClusterFederation=true",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{ClusterName: "workload"},
+					ConfigSources: []ConfigSource{{
+						SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "shared"}, Key: "exporters.yaml"},
+					}},
+				},
+			},
+			expectedErr: "source 0 is a secret, which the collectors of a remote cluster can't read",
+		},
+		{
+			name:  "federation reference with the gate enabled",
+			gates: "MultiClusterFederation=true",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          ModeDeployment,
+					FederationRef: &FederationRef{ClusterName: "workload"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, featuregate.Gates.Set(test.gates))
+			err := test.otelcol.validateCRDSpec()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
 			}
 			assert.ErrorContains(t, err, test.expectedErr)
 		})
 	}
 }
+
+func TestOTELColWarningHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	decoder, err := admission.NewDecoder(scheme)
+	require.NoError(t, err)
+	handler := &warningHandler{Handler: admission.ValidatingWebhookFor(&OpenTelemetryCollector{}).Handler}
+	require.NoError(t, handler.InjectDecoder(decoder))
+
+	zero := int32(0)
+	for _, tt := range []struct {
+		name             string
+		otelcol          OpenTelemetryCollector
+		expectedAllowed  bool
+		expectedWarnings []string
+	}{
+		{
+			name: "no revision history",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeDeployment,
+					RevisionHistoryLimit: &zero,
+				},
+			},
+			expectedAllowed:  true,
+			expectedWarnings: []string{"revisionHistoryLimit is set to 0, the previous ReplicaSets of the collector Deployment are deleted and rolling back is impossible"},
+		},
+		{
+			name: "denied",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:                 ModeSidecar,
+					RevisionHistoryLimit: &zero,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.otelcol.APIVersion = GroupVersion.String()
+			tt.otelcol.Kind = "OpenTelemetryCollector"
+			raw, err := json.Marshal(tt.otelcol)
+			require.NoError(t, err)
+
+			resp := handler.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			assert.Equal(t, tt.expectedAllowed, resp.Allowed)
+			assert.Equal(t, tt.expectedWarnings, resp.Warnings)
+		})
+	}
+}
+
+func TestOTELColWarnings(t *testing.T) {
+	zero := int32(0)
+	one := int32(1)
+
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:                 ModeDeployment,
+			RevisionHistoryLimit: &one,
+		},
+	}
+	assert.Empty(t, otelcol.warnings())
+
+	otelcol.Spec.RevisionHistoryLimit = &zero
+	assert.Equal(t, []string{
+		"revisionHistoryLimit is set to 0, the previous ReplicaSets of the collector Deployment are deleted and rolling back is impossible",
+	}, otelcol.warnings())
+}
+
+func TestOTELColWarningsReconcileAnnotation(t *testing.T) {
+	otelcol := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationReconcile: ReconcilePaused},
+		},
+		Spec: OpenTelemetryCollectorSpec{Mode: ModeDeployment},
+	}
+	assert.Empty(t, otelcol.warnings())
+
+	otelcol.Annotations[AnnotationReconcile] = "pause"
+	assert.Equal(t, []string{
+		`the opentelemetry.io/reconcile annotation is set to "pause", only "paused" pauses the reconciliation`,
+	}, otelcol.warnings())
+}
+
+func TestOTELColWarningsMemoryTuning(t *testing.T) {
+	eighty := int32(80)
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:         ModeDeployment,
+			MemoryTuning: MemoryTuningSpec{MemoryLimiterPercentage: &eighty},
+		},
+	}
+	assert.Equal(t, []string{
+		"memoryTuning.memoryLimiterPercentage is set, but the collector container has no memory limit, the memory_limiter processors are left untouched",
+	}, otelcol.warnings())
+
+	otelcol.Spec.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}
+	assert.Empty(t, otelcol.warnings())
+}
+
+func TestOTELColWarningsVPA(t *testing.T) {
+	eighty := int32(80)
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Mode:         ModeSidecar,
+			Resources:    v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}},
+			Autoscaler:   &AutoscalerSpec{VPA: &VPASpec{}},
+			MemoryTuning: MemoryTuningSpec{GoMemLimitPercentage: &eighty},
+		},
+	}
+	assert.Equal(t, []string{
+		"autoscaler.vpa updates the memory limit of the collector container, but the memory settings of memoryTuning are derived from the memory limit of spec.resources",
+	}, otelcol.warnings())
+
+	otelcol.Spec.Autoscaler.VPA.ControlledValues = VPAControlledValuesRequestsOnly
+	assert.Empty(t, otelcol.warnings())
+
+	otelcol.Spec.Autoscaler.VPA.ControlledValues = VPAControlledValuesRequestsAndLimits
+	otelcol.Spec.Autoscaler.VPA.ControlledResources = []v1.ResourceName{v1.ResourceCPU}
+	assert.Empty(t, otelcol.warnings())
+
+	// nothing is derived from the memory limit without memory tuning
+	otelcol.Spec.Autoscaler.VPA.ControlledResources = nil
+	otelcol.Spec.MemoryTuning.GoMemLimitPercentage = nil
+	assert.Empty(t, otelcol.warnings())
+}
+
+func TestOTELColValidateLivenessProbe(t *testing.T) {
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`
+	for _, tt := range []struct {
+		name     string
+		mode     Mode
+		config   string
+		protocol ProbeProtocol
+		expected bool
+	}{
+		{name: "empty config"},
+		{name: "no health_check", config: config, protocol: ProbeProtocolHTTP, expected: true},
+		{name: "no health_check with the grpc probe", config: config, protocol: ProbeProtocolGRPC},
+		{name: "no health_check in sidecar mode", mode: ModeSidecar, config: config, protocol: ProbeProtocolHTTP},
+		{
+			name: "health_check enabled",
+			config: config + `  extensions: [health_check]
+extensions:
+  health_check:
+`,
+			protocol: ProbeProtocolHTTP,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:          tt.mode,
+					Config:        tt.config,
+					LivenessProbe: &LivenessProbeSpec{Protocol: tt.protocol},
+				},
+			}
+			err := otelcol.validateLivenessProbe()
+			if tt.expected {
+				assert.ErrorContains(t, err, "the health_check extension isn't enabled in the service")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("update keeping the config", func(t *testing.T) {
+		old := &OpenTelemetryCollector{Spec: OpenTelemetryCollectorSpec{Config: config}}
+		otelcol := old.DeepCopy()
+		otelcol.Finalizers = []string{"opentelemetry.io/orphan-resources"}
+		assert.NoError(t, otelcol.ValidateUpdate(old))
+
+		otelcol.Spec.Config = config + "extensions:\n  zpages:\n"
+		assert.ErrorContains(t, otelcol.ValidateUpdate(old), "the health_check extension isn't enabled in the service")
+	})
+}
+
+func TestOTELColDefaultingWebhookImageArchitectures(t *testing.T) {
+	NodeArchitectures = func(context.Context) ([]string, error) {
+		return []string{"amd64", "arm64"}, nil
+	}
+	ImageArchitectures = func(_ context.Context, image string) ([]string, error) {
+		if image == "unreachable/collector:1.0" {
+			return nil, errors.New("registry unreachable")
+		}
+		if image == "multi-arch/collector:1.0" {
+			return []string{"amd64", "arm64", "ppc64le"}, nil
+		}
+		return []string{"amd64"}, nil
+	}
+	defer func() {
+		NodeArchitectures = nil
+		ImageArchitectures = nil
+	}()
+
+	tests := []struct {
+		name        string
+		image       string
+		annotations map[string]string
+		expected    string
+	}{
+		{name: "default image"},
+		{name: "multi-arch image", image: "multi-arch/collector:1.0"},
+		{name: "single-arch image", image: "amd64/collector:1.0", expected: "arm64"},
+		{
+			name:        "image fixed",
+			image:       "multi-arch/collector:1.0",
+			annotations: map[string]string{UnsupportedArchitecturesAnnotation: "arm64"},
+		},
+		{
+			name:        "registry unreachable",
+			image:       "unreachable/collector:1.0",
+			annotations: map[string]string{UnsupportedArchitecturesAnnotation: "arm64"},
+			expected:    "arm64",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otelcol := OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+				Spec:       OpenTelemetryCollectorSpec{Image: test.image},
+			}
+
+			otelcol.Default()
+
+			assert.Equal(t, test.expected, otelcol.Annotations[UnsupportedArchitecturesAnnotation])
+			if test.expected != "" {
+				assert.Contains(t, otelcol.warnings(), fmt.Sprintf("the image %s isn't available for the architectures %s of the cluster nodes, the collector pods can't start on them", test.image, test.expected))
+			}
+		})
+	}
+}
+
+func TestOTELColValidatingWebhookEnvVars(t *testing.T) {
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: ${env:MY_POD_IP}:4317
+  otlp/kubernetes:
+    protocols:
+      grpc:
+        endpoint: ${env:HOSTNAME}:4318
+exporters:
+  otlp:
+    endpoint: ${env:BACKEND_ENDPOINT}
+    headers:
+      api-key: ${env:BACKEND_API_KEY}
+      pod: ${env:POD_NAME}
+      api-server: ${env:KUBERNETES_SERVICE_HOST}:${env:KUBERNETES_SERVICE_PORT}
+      backend: ${env:BACKEND_PORT_4317_TCP_ADDR}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]`
+	env := []v1.EnvVar{
+		{Name: "MY_POD_IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+		{Name: "BACKEND_ENDPOINT", Value: "backend:4317"},
+	}
+	secretRef := v1.EnvFromSource{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "backend"}}}
+	prefixed := v1.EnvFromSource{Prefix: "BACKEND_", ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "backend"}}}
+	unreachable := v1.EnvFromSource{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "unreachable"}}}
+
+	tests := []struct {
+		name            string
+		envFromKeys     func(ctx context.Context, namespace string, source v1.EnvFromSource) ([]string, error)
+		env             []v1.EnvVar
+		envFrom         []v1.EnvFromSource
+		expectedWarning string
+	}{
+		{
+			name:            "missing env vars",
+			expectedWarning: "the environment variables BACKEND_API_KEY, BACKEND_ENDPOINT, MY_POD_IP, which aren't set by env or envFrom",
+		},
+		{
+			name:            "missing env var",
+			env:             env,
+			expectedWarning: "the environment variables BACKEND_API_KEY, which aren't set by env or envFrom",
+		},
+		{
+			name:    "envFrom keys unknown",
+			env:     env,
+			envFrom: []v1.EnvFromSource{prefixed},
+		},
+		{
+			name: "envFrom keys",
+			envFromKeys: func(_ context.Context, _ string, _ v1.EnvFromSource) ([]string, error) {
+				return []string{"API_KEY"}, nil
+			},
+			env:     env,
+			envFrom: []v1.EnvFromSource{prefixed},
+		},
+		{
+			name: "envFrom without the key",
+			envFromKeys: func(_ context.Context, _ string, _ v1.EnvFromSource) ([]string, error) {
+				return []string{"TOKEN"}, nil
+			},
+			env:             env,
+			envFrom:         []v1.EnvFromSource{secretRef},
+			expectedWarning: "the environment variables BACKEND_API_KEY, which aren't set by env or envFrom",
+		},
+		{
+			name: "envFrom source unreachable",
+			envFromKeys: func(_ context.Context, _ string, _ v1.EnvFromSource) ([]string, error) {
+				return nil, errors.New("secret not found")
+			},
+			env:     env,
+			envFrom: []v1.EnvFromSource{unreachable},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			EnvFromKeys = test.envFromKeys
+			defer func() {
+				EnvFromKeys = nil
+			}()
+			otelcol := OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config:  config,
+					Env:     test.env,
+					EnvFrom: test.envFrom,
+				},
+			}
+
+			err := otelcol.validateCRDSpec()
+			warnings := strings.Join(otelcol.warnings(), "\n")
+
+			assert.NoError(t, err, "the missing environment variables only warn, the image can set them")
+			if test.expectedWarning == "" {
+				assert.NotContains(t, warnings, "environment variables")
+				return
+			}
+			assert.Contains(t, warnings, test.expectedWarning)
+		})
+	}
+}
+
+func TestOTELColDefaultingWebhookSkippedMutations(t *testing.T) {
+	defer func() {
+		require.NoError(t, SkipMutations(nil))
+	}()
+
+	// prepare
+	err := SkipMutations([]string{"spec.image"})
+	require.EqualError(t, err, fmt.Sprintf("the spec.image field isn't mutated, the mutated fields are %s", strings.Join(MutatedFields, ", ")))
+	require.NoError(t, SkipMutations([]string{"metadata.labels", "spec.mode", "spec.replicas"}))
+	otelcol := OpenTelemetryCollector{}
+
+	// test
+	otelcol.Default()
+	defaulted := otelcol.WithDefaults()
+
+	// verify
+	assert.False(t, Mutable("spec.replicas"))
+	assert.True(t, Mutable("spec.upgradeStrategy"))
+	assert.Empty(t, otelcol.Labels)
+	assert.Empty(t, otelcol.Spec.Mode)
+	assert.Nil(t, otelcol.Spec.Replicas)
+	assert.Equal(t, UpgradeStrategyAutomatic, otelcol.Spec.UpgradeStrategy)
+
+	assert.Equal(t, "opentelemetry-operator", defaulted.Labels["app.kubernetes.io/managed-by"])
+	assert.Equal(t, ModeDeployment, defaulted.Spec.Mode)
+	require.NotNil(t, defaulted.Spec.Replicas)
+	assert.Equal(t, int32(1), *defaulted.Spec.Replicas)
+	assert.Empty(t, otelcol.Spec.Mode, "the defaults are applied to a copy")
+}
+
+func TestOTELColValidatingWebhookSkippedMutations(t *testing.T) {
+	defer func() {
+		require.NoError(t, SkipMutations(nil))
+	}()
+
+	// prepare
+	require.NoError(t, SkipMutations([]string{"spec.mode"}))
+	otelcol := OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			DeploymentUpdateStrategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+		},
+	}
+	otelcol.Default()
+
+	// test
+	createErr := otelcol.ValidateCreate()
+	updateErr := otelcol.ValidateUpdate(&OpenTelemetryCollector{})
+
+	// verify
+	assert.Empty(t, otelcol.Spec.Mode)
+	assert.NoError(t, createErr, "the instance is reconciled as a deployment")
+	assert.NoError(t, updateErr, "the instance is reconciled as a deployment")
+}
+
+func TestOTELColValidateUpdateInvalidInstance(t *testing.T) {
+	// prepare
+	previous := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-instance",
+			Finalizers: []string{"opentelemetry.io/foreground-deletion"},
+		},
+		Spec: OpenTelemetryCollectorSpec{
+			// no longer valid, like an instance admitted before a feature gate was disabled
+			Mode:                 ModeSidecar,
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{{}},
+		},
+	}
+	require.Error(t, previous.validateDefaulted())
+
+	t.Run("should admit the removal of the finalizers", func(t *testing.T) {
+		// prepare
+		otelcol := previous.DeepCopy()
+		otelcol.Finalizers = nil
+
+		// test
+		err := otelcol.ValidateUpdate(&previous)
+
+		// verify
+		assert.NoError(t, err)
+	})
+
+	t.Run("should admit the updates of the instances being deleted", func(t *testing.T) {
+		// prepare
+		otelcol := previous.DeepCopy()
+		now := metav1.Now()
+		otelcol.DeletionTimestamp = &now
+		otelcol.Spec.Image = "other-image"
+
+		// test
+		err := otelcol.ValidateUpdate(&previous)
+
+		// verify
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject the spec changes", func(t *testing.T) {
+		// prepare
+		otelcol := previous.DeepCopy()
+		otelcol.Spec.Image = "other-image"
+
+		// test
+		err := otelcol.ValidateUpdate(&previous)
+
+		// verify
+		assert.ErrorContains(t, err, "volumeClaimTemplates")
+	})
+}
+
+func TestOTELColValidateVersionPin(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		previous    string
+		pinned      string
+		status      string
+		expectedErr string
+	}{
+		{name: "no pin", status: "0.61.0"},
+		{name: "no status", pinned: "0.50.0"},
+		{name: "same version", pinned: "0.61.0", status: "0.61.0"},
+		{name: "newer version", pinned: "0.62.0", status: "0.61.0"},
+		{name: "unchanged older pin", previous: "0.50.0", pinned: "0.50.0", status: "0.61.0"},
+		{
+			name:        "older version",
+			pinned:      "0.50.0",
+			status:      "0.61.0",
+			expectedErr: "the instance is already at version 0.61.0, which can't be downgraded to 0.50.0",
+		},
+		{
+			name:        "older version replacing a pin",
+			previous:    "0.61.0",
+			pinned:      "0.60.0",
+			status:      "0.61.0",
+			expectedErr: "which can't be downgraded to 0.60.0",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := OpenTelemetryCollector{
+				Spec:   OpenTelemetryCollectorSpec{Version: tt.previous},
+				Status: OpenTelemetryCollectorStatus{Version: tt.status},
+			}
+			otelcol := previous.DeepCopy()
+			otelcol.Spec.Version = tt.pinned
+
+			err := otelcol.validateVersionPin(previous)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// AnnotationTriggerReconcile is the annotation that requests a reconciliation of a CR using the OnDemand reconcile policy.
+const AnnotationTriggerReconcile = "opentelemetry.io/trigger-reconcile"
+
+const (
+	// AnnotationReconcile is the annotation pausing the reconciliation of an operator-owned CR, when set to "paused".
+	AnnotationReconcile = "opentelemetry.io/reconcile"
+
+	// ReconcilePaused is the value of the "opentelemetry.io/reconcile" annotation pausing the reconciliation of a CR.
+	ReconcilePaused = "paused"
+
+	// ConditionTypePaused is set while the reconciliation of a CR is paused by the "opentelemetry.io/reconcile"
+	// annotation, and removed once it's resumed.
+	ConditionTypePaused = "Paused"
+)
+
+// IsReconcilePaused returns whether the reconciliation of the CR is paused by the "opentelemetry.io/reconcile"
+// annotation, or by the unmanaged state of a collector. The operator leaves the resources of a paused CR as they are,
+// until the annotation is removed, or the collector is managed again.
+func IsReconcilePaused(obj metav1.Object) bool {
+	if otelcol, ok := obj.(*OpenTelemetryCollector); ok && otelcol.Spec.ManagementState == ManagementStateUnmanaged {
+		return true
+	}
+	return obj.GetAnnotations()[AnnotationReconcile] == ReconcilePaused
+}
+
+type (
+	// ReconcilePolicy represents when the operator reconciles the CR
+	// +kubebuilder:validation:Enum=Always;OnDemand;Scheduled
+	ReconcilePolicy string
+)
+
+const (
+	// ReconcilePolicyAlways specifies that the operator reconciles the CR on every change.
+	ReconcilePolicyAlways ReconcilePolicy = "Always"
+
+	// ReconcilePolicyOnDemand specifies that the operator reconciles the CR only when the
+	// "opentelemetry.io/trigger-reconcile" annotation is set to "true". The annotation is removed afterwards.
+	ReconcilePolicyOnDemand ReconcilePolicy = "OnDemand"
+
+	// ReconcilePolicyScheduled specifies that the operator reconciles the CR following the cron schedule
+	// set in the reconcileSchedule property.
+	ReconcilePolicyScheduled ReconcilePolicy = "Scheduled"
+)
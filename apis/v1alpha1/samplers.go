@@ -14,6 +14,13 @@
 
 package v1alpha1
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 type (
 	// SamplerType represents sampler type.
 	// +kubebuilder:validation:Enum=always_on;always_off;traceidratio;parentbased_always_on;parentbased_always_off;parentbased_traceidratio;jaeger_remote;xray
@@ -38,3 +45,43 @@ const (
 	// XRay represents AWS X-Ray Centralized Sampling.
 	XRaySampler SamplerType = "xray"
 )
+
+// NormalizeJaegerRemoteArgument returns the jaeger_remote sampler argument in the format expected by the SDKs,
+// e.g. endpoint=http://jaeger:14250,pollingIntervalMs=5000,initialSamplingRate=0.25. A bare endpoint URL is accepted as a shorthand.
+func NormalizeJaegerRemoteArgument(argument string) (string, error) {
+	if !strings.Contains(argument, "=") {
+		if err := validateEndpoint(argument); err != nil {
+			return "", err
+		}
+		return "endpoint=" + argument, nil
+	}
+
+	for _, pair := range strings.Split(argument, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		switch key {
+		case "endpoint":
+			if err := validateEndpoint(value); err != nil {
+				return "", err
+			}
+		case "pollingIntervalMs":
+			if interval, err := strconv.Atoi(value); err != nil || interval <= 0 {
+				return "", fmt.Errorf("pollingIntervalMs should be a positive number of milliseconds: %s", value)
+			}
+		case "initialSamplingRate":
+			if rate, err := strconv.ParseFloat(value, 64); err != nil || rate < 0 || rate > 1 {
+				return "", fmt.Errorf("initialSamplingRate should be in range [0..1]: %s", value)
+			}
+		default:
+			return "", fmt.Errorf("unknown key %q", key)
+		}
+	}
+	return argument, nil
+}
+
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("endpoint should be a URL: %s", endpoint)
+	}
+	return nil
+}
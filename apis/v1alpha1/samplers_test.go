@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeJaegerRemoteArgument(t *testing.T) {
+	for _, tt := range []struct {
+		argument string
+		expected string
+		err      string
+	}{
+		{argument: "http://jaeger:14250", expected: "endpoint=http://jaeger:14250"},
+		{argument: "endpoint=http://jaeger:14250,pollingIntervalMs=5000", expected: "endpoint=http://jaeger:14250,pollingIntervalMs=5000"},
+		{argument: "endpoint=http://jaeger:14250,pollingIntervalMs=-1", err: "pollingIntervalMs should be a positive number"},
+		{argument: "endpoint=http://jaeger:14250,timeout=1", err: `unknown key "timeout"`},
+	} {
+		t.Run(tt.argument, func(t *testing.T) {
+			actual, err := NormalizeJaegerRemoteArgument(tt.argument)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+type (
+	// ServiceSplitType defines how the collector ports are split into separate Services.
+	// +kubebuilder:validation:Enum=receiver;protocol
+	ServiceSplitType string
+)
+
+const (
+	// ServiceSplitReceiver generates a Service for each receiver, with all its ports.
+	ServiceSplitReceiver ServiceSplitType = "receiver"
+
+	// ServiceSplitProtocol generates a Service for each receiver port, which receivers have one of per protocol.
+	ServiceSplitProtocol ServiceSplitType = "protocol"
+)
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetAllocatorSpec defines the desired state of a TargetAllocator deployed independently of the collectors
+// scraping its targets.
+type TargetAllocatorSpec struct {
+	// Replicas is the number of pod instances for the TargetAllocator. Like with the target allocator of an
+	// OpenTelemetryCollector, more than one replica requires the consistent-hashing or per-node allocation strategy.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// AllocationStrategy determines which strategy the target allocator should use for allocation.
+	// The current options are least-weighted, consistent-hashing and per-node. The default option is least-weighted.
+	// +optional
+	AllocationStrategy OpenTelemetryTargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// AllocationRules override the allocation of the targets of the matching jobs, like the ones of the target
+	// allocator of an OpenTelemetryCollector.
+	// +optional
+	// +listType=atomic
+	AllocationRules []TargetAllocatorAllocationRule `json:"allocationRules,omitempty"`
+	// FilterStrategy determines how to filter targets before allocating them among the collectors.
+	// The only current option is relabel-config (drops targets based on prom relabel_config).
+	// Filtering is disabled by default.
+	// +optional
+	FilterStrategy string `json:"filterStrategy,omitempty"`
+	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
+	// the operator will not automatically create a ServiceAccount for the TargetAllocator.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Image indicates the container image to use for the OpenTelemetry TargetAllocator.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Resources to set on the TargetAllocator pods.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector to schedule the TargetAllocator pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the TargetAllocator pods. Defaults to spreading the replicas over the nodes when there are more
+	// than one.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// Tolerations to schedule the TargetAllocator pods.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// TopologySpreadConstraints describes how the TargetAllocator pods are spread across the topology domains, e.g.
+	// the zones of the cluster.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PrometheusCR defines the configuration for the retrieval of PrometheusOperator CRDs ( servicemonitor.monitoring.coreos.com/v1 and podmonitor.monitoring.coreos.com/v1 )  retrieval.
+	// All CR instances which the ServiceAccount has access to, and which match the selectors, will be retrieved. The other namespaces are only included when selected by the namespace selectors.
+	// +optional
+	PrometheusCR OpenTelemetryTargetAllocatorPrometheusCR `json:"prometheusCR,omitempty"`
+	// Config is the Prometheus configuration, in YAML, holding the scrape_configs of the targets allocated to the
+	// collectors referencing the TargetAllocator. It's the config of their prometheus receiver, which the operator
+	// replaces with the targets served by the TargetAllocator.
+	// +optional
+	Config string `json:"config,omitempty"`
+}
+
+// TargetAllocatorStatus defines the observed state of TargetAllocator.
+type TargetAllocatorStatus struct {
+	// Collectors are the names of the OpenTelemetryCollectors referencing the TargetAllocator, which it allocates
+	// the targets to.
+	// +optional
+	// +listType=atomic
+	Collectors []string `json:"collectors,omitempty"`
+	// Conditions represent the latest available observations of the TargetAllocator's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=otelta;otelallocators
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Strategy",type="string",JSONPath=".spec.allocationStrategy"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Target Allocator"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Deployment,apps/v1},{ConfigMaps,v1},{Service,v1},{ServiceAccount,v1}}
+
+// TargetAllocator is the Schema for a Prometheus target allocator shared by the OpenTelemetryCollectors
+// referencing it.
+type TargetAllocator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TargetAllocatorSpec   `json:"spec,omitempty"`
+	Status TargetAllocatorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TargetAllocatorList contains a list of TargetAllocator.
+type TargetAllocatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TargetAllocator `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TargetAllocator{}, &TargetAllocatorList{})
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
+)
+
+// log is for logging in this package.
+var targetallocatorlog = logf.Log.WithName("targetallocator-resource")
+
+func (r *TargetAllocator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-opentelemetry-io-v1alpha1-targetallocator,mutating=true,failurePolicy=fail,groups=opentelemetry.io,resources=targetallocators,verbs=create;update,versions=v1alpha1,name=mtargetallocator.kb.io,sideEffects=none,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &TargetAllocator{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *TargetAllocator) Default() {
+	targetallocatorlog.Info("default", "name", r.Name)
+
+	if r.Labels == nil {
+		r.Labels = map[string]string{}
+	}
+	if r.Labels["app.kubernetes.io/managed-by"] == "" {
+		r.Labels["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
+	}
+
+	one := int32(1)
+	if r.Spec.Replicas == nil {
+		r.Spec.Replicas = &one
+	}
+	if r.Spec.AllocationStrategy == "" {
+		r.Spec.AllocationStrategy = OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-opentelemetry-io-v1alpha1-targetallocator,mutating=false,failurePolicy=fail,groups=opentelemetry.io,resources=targetallocators,versions=v1alpha1,name=vtargetallocatorcreateupdate.kb.io,sideEffects=none,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=delete,path=/validate-opentelemetry-io-v1alpha1-targetallocator,mutating=false,failurePolicy=ignore,groups=opentelemetry.io,resources=targetallocators,versions=v1alpha1,name=vtargetallocatordelete.kb.io,sideEffects=none,admissionReviewVersions=v1
+
+var _ webhook.Validator = &TargetAllocator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *TargetAllocator) ValidateCreate() error {
+	targetallocatorlog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *TargetAllocator) ValidateUpdate(old runtime.Object) error {
+	targetallocatorlog.Info("validate update", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *TargetAllocator) ValidateDelete() error {
+	targetallocatorlog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *TargetAllocator) validate() error {
+	if !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+		return fmt.Errorf("the operator feature gate %s is disabled, which does not allow the target allocation deployment", featuregate.TargetAllocator)
+	}
+
+	if err := validateTargetAllocatorReplicas(r.Spec.Replicas, r.Spec.AllocationStrategy); err != nil {
+		return fmt.Errorf("the TargetAllocator Spec replicas configuration is incorrect, %w", err)
+	}
+	if err := validateAllocationRules(r.Spec.AllocationRules, r.Spec.Replicas, r.Spec.AllocationStrategy); err != nil {
+		return fmt.Errorf("the TargetAllocator Spec allocationRules configuration is incorrect, %w", err)
+	}
+
+	promCfg, err := adapters.ConfigFromString(r.Spec.Config)
+	if err != nil {
+		return fmt.Errorf("the TargetAllocator Spec Prometheus configuration is incorrect, %w", err)
+	}
+	// the config is set as the one of the prometheus receivers of the collectors, with its dollar signs escaped
+	if err := ta.ValidatePromConfig(ta.EscapeDollarSigns(promCfg)); err != nil {
+		return fmt.Errorf("the TargetAllocator Spec Prometheus configuration is incorrect, %w", err)
+	}
+	if r.Spec.Config == "" && !r.Spec.PrometheusCR.Enabled {
+		return fmt.Errorf("the TargetAllocator Spec Prometheus configuration is incorrect, it has no scrape configs and the prometheusCR retrieval is disabled")
+	}
+
+	return nil
+}
+
+// validateTargetAllocatorReplicas checks that the replicas of a target allocator assign the targets the same way,
+// whichever one the collectors query.
+func validateTargetAllocatorReplicas(replicas *int32, strategy OpenTelemetryTargetAllocatorAllocationStrategy) error {
+	if replicas == nil || *replicas <= 1 {
+		return nil
+	}
+	if strategy == "" {
+		strategy = OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted
+	}
+	if strategy != OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing && strategy != OpenTelemetryTargetAllocatorAllocationStrategyPerNode {
+		return fmt.Errorf("the %s allocation strategy doesn't assign the same targets on all replicas, more than one replica requires the %s or %s strategy",
+			strategy, OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing, OpenTelemetryTargetAllocatorAllocationStrategyPerNode)
+	}
+	return nil
+}
+
+// validateAllocationRules checks that the allocation rules are accepted by the target allocator, and that the
+// strategies they allocate with support the replicas.
+func validateAllocationRules(rules []TargetAllocatorAllocationRule, replicas *int32, strategy OpenTelemetryTargetAllocatorAllocationStrategy) error {
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if rule.Name == "" || rule.Name == "default" {
+			return fmt.Errorf("the name of an allocation rule can't be empty or default")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("the allocation rule %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+		if len(rule.Jobs) == 0 {
+			return fmt.Errorf("the allocation rule %s matches no job", rule.Name)
+		}
+		for _, job := range rule.Jobs {
+			if _, err := regexp.Compile(job); err != nil {
+				return fmt.Errorf("the job %s of the allocation rule %s is invalid: %w", job, rule.Name, err)
+			}
+		}
+		if _, err := regexp.Compile(rule.Collectors); err != nil {
+			return fmt.Errorf("the collectors %s of the allocation rule %s are invalid: %w", rule.Collectors, rule.Name, err)
+		}
+
+		switch rule.Action {
+		case TargetAllocatorAllocationRuleActionDrop:
+			if rule.AllocationStrategy != "" || rule.Collectors != "" {
+				return fmt.Errorf("the allocation rule %s drops the targets, it can't set an allocationStrategy or collectors", rule.Name)
+			}
+			continue
+		case "", TargetAllocatorAllocationRuleActionAllocate:
+		default:
+			return fmt.Errorf("the action %s of the allocation rule %s must be %s or %s",
+				rule.Action, rule.Name, TargetAllocatorAllocationRuleActionAllocate, TargetAllocatorAllocationRuleActionDrop)
+		}
+		if rule.AllocationStrategy == "" && rule.Collectors == "" {
+			return fmt.Errorf("the allocation rule %s doesn't change the allocation, it has to set an allocationStrategy, collectors or the %s action",
+				rule.Name, TargetAllocatorAllocationRuleActionDrop)
+		}
+		ruleStrategy := rule.AllocationStrategy
+		if ruleStrategy == "" {
+			ruleStrategy = strategy
+		}
+		if err := validateTargetAllocatorReplicas(replicas, ruleStrategy); err != nil {
+			return fmt.Errorf("the allocation rule %s is incorrect, %w", rule.Name, err)
+		}
+	}
+	return nil
+}
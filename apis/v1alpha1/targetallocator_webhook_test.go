@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestTargetAllocatorDefaultingWebhook(t *testing.T) {
+	one := int32(1)
+	three := int32(3)
+
+	tests := []struct {
+		name     string
+		ta       TargetAllocator
+		expected TargetAllocator
+	}{
+		{
+			name: "all fields default",
+			ta:   TargetAllocator{},
+			expected: TargetAllocator{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: TargetAllocatorSpec{
+					Replicas:           &one,
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted,
+				},
+			},
+		},
+		{
+			name: "provided values in spec",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					Replicas:           &three,
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+				},
+			},
+			expected: TargetAllocator{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+				Spec: TargetAllocatorSpec{
+					Replicas:           &three,
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.ta.Default()
+			assert.Equal(t, test.expected, test.ta)
+		})
+	}
+}
+
+func TestTargetAllocatorValidatingWebhook(t *testing.T) {
+	three := int32(3)
+
+	tests := []struct { //nolint:govet
+		name        string
+		ta          TargetAllocator
+		expectedErr string
+	}{
+		{
+			name: "valid scrape configs",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					Replicas:           &three,
+					AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+					Config: `scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+`,
+				},
+			},
+		},
+		{
+			name: "valid prometheus CR retrieval",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "invalid replicas with the least-weighted allocation strategy",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					Replicas:     &three,
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+				},
+			},
+			expectedErr: "the least-weighted allocation strategy doesn't assign the same targets on all replicas",
+		},
+		{
+			name: "valid allocation rules",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+					AllocationRules: []TargetAllocatorAllocationRule{
+						{
+							Name:               "federation",
+							Jobs:               []string{"federate-.*"},
+							AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+							Collectors:         "federation-collector-.*",
+						},
+						{Name: "probes", Jobs: []string{"blackbox"}, Action: TargetAllocatorAllocationRuleActionDrop},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid allocation rule names",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+					AllocationRules: []TargetAllocatorAllocationRule{
+						{Name: "probes", Jobs: []string{"blackbox"}, Action: TargetAllocatorAllocationRuleActionDrop},
+						{Name: "probes", Jobs: []string{"icmp"}, Action: TargetAllocatorAllocationRuleActionDrop},
+					},
+				},
+			},
+			expectedErr: "the allocation rule probes is defined twice",
+		},
+		{
+			name: "invalid allocation rule job",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+					AllocationRules: []TargetAllocatorAllocationRule{
+						{Name: "probes", Jobs: []string{"blackbox-("}, Action: TargetAllocatorAllocationRuleActionDrop},
+					},
+				},
+			},
+			expectedErr: "the job blackbox-( of the allocation rule probes is invalid",
+		},
+		{
+			name: "invalid allocation rule dropping with collectors",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+					AllocationRules: []TargetAllocatorAllocationRule{
+						{Name: "probes", Jobs: []string{"blackbox"}, Action: TargetAllocatorAllocationRuleActionDrop, Collectors: "probes-.*"},
+					},
+				},
+			},
+			expectedErr: "the allocation rule probes drops the targets, it can't set an allocationStrategy or collectors",
+		},
+		{
+			name: "invalid config",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					Config: "scrape_configs: [",
+				},
+			},
+			expectedErr: "the TargetAllocator Spec Prometheus configuration is incorrect",
+		},
+		{
+			name: "invalid relabel config",
+			ta: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					Config: `scrape_configs:
+  - job_name: otel-collector
+    relabel_configs:
+    - source_labels: [__address__]
+      action: replace
+      regex: (.+
+`,
+				},
+			},
+			expectedErr: "the prometheus config is incorrect",
+		},
+		{
+			name:        "no targets to allocate",
+			ta:          TargetAllocator{},
+			expectedErr: "it has no scrape configs and the prometheusCR retrieval is disabled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.ta.validate()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestTargetAllocatorValidatingWebhookFeatureGate(t *testing.T) {
+	defer func() {
+		require.NoError(t, featuregate.Gates.Set("TargetAllocator=true"))
+	}()
+	require.NoError(t, featuregate.Gates.Set("TargetAllocator=false"))
+
+	ta := TargetAllocator{
+		Spec: TargetAllocatorSpec{
+			PrometheusCR: OpenTelemetryTargetAllocatorPrometheusCR{Enabled: true},
+		},
+	}
+	assert.ErrorContains(t, ta.validate(), "the operator feature gate TargetAllocator is disabled")
+}
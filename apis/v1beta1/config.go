@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AnyConfig holds a section of the collector config whose content depends on the components.
+// +kubebuilder:pruning:PreserveUnknownFields
+// +kubebuilder:validation:Type=object
+// +kubebuilder:object:generate=false
+type AnyConfig struct {
+	Object map[string]interface{} `json:"-"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (c *AnyConfig) DeepCopyInto(out *AnyConfig) {
+	*out = AnyConfig{}
+	if c.Object != nil {
+		out.Object = runtime.DeepCopyJSON(c.Object)
+	}
+}
+
+// DeepCopy copies the receiver, creating a new AnyConfig.
+func (c *AnyConfig) DeepCopy() *AnyConfig {
+	if c == nil {
+		return nil
+	}
+	out := new(AnyConfig)
+	c.DeepCopyInto(out)
+	return out
+}
+
+var _ json.Marshaler = AnyConfig{}
+var _ json.Unmarshaler = &AnyConfig{}
+
+// UnmarshalJSON implements json.Unmarshaler. The numbers are kept as they're written, without the float conversion
+// rounding the large integers.
+func (c *AnyConfig) UnmarshalJSON(b []byte) error {
+	vals := map[string]interface{}{}
+	if err := unmarshalJSONNumbers(b, &vals); err != nil {
+		return err
+	}
+	c.Object = vals
+	return nil
+}
+
+func unmarshalJSONNumbers(b []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c AnyConfig) MarshalJSON() ([]byte, error) {
+	if c.Object == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c.Object)
+}
+
+// Config is the collector config, with its known top-level sections.
+// +kubebuilder:pruning:PreserveUnknownFields
+type Config struct {
+	// Receivers are the receivers of the collector, by ID.
+	Receivers AnyConfig `json:"receivers"`
+	// Exporters are the exporters of the collector, by ID.
+	Exporters AnyConfig `json:"exporters"`
+	// Processors are the processors of the collector, by ID.
+	// +optional
+	Processors *AnyConfig `json:"processors,omitempty"`
+	// Connectors are the connectors of the collector, by ID.
+	// +optional
+	Connectors *AnyConfig `json:"connectors,omitempty"`
+	// Extensions are the extensions of the collector, by ID.
+	// +optional
+	Extensions *AnyConfig `json:"extensions,omitempty"`
+	// Service defines the extensions, pipelines and telemetry of the collector.
+	Service Service `json:"service"`
+	// Other holds the top-level sections unknown to this version, which are kept as they are.
+	Other AnyConfig `json:"-"`
+}
+
+// knownSections are the top-level sections of the config with a field of their own.
+var knownSections = []string{"receivers", "exporters", "processors", "connectors", "extensions", "service"}
+
+// config has the fields of Config without its JSON methods.
+type config Config
+
+var _ json.Marshaler = Config{}
+var _ json.Unmarshaler = &Config{}
+
+// MarshalJSON implements json.Marshaler, writing the unknown sections along with the known ones.
+func (c Config) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(config(c))
+	if err != nil || len(c.Other.Object) == 0 {
+		return data, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, section := range c.Other.Object {
+		if _, known := fields[name]; known {
+			continue
+		}
+		raw, err := json.Marshal(section)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = raw
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping the unknown sections in Other.
+func (c *Config) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, (*config)(c)); err != nil {
+		return err
+	}
+	fields := map[string]interface{}{}
+	if err := unmarshalJSONNumbers(b, &fields); err != nil {
+		return err
+	}
+	for _, name := range knownSections {
+		delete(fields, name)
+	}
+	c.Other = AnyConfig{}
+	if len(fields) > 0 {
+		c.Other.Object = fields
+	}
+	return nil
+}
+
+// Service defines the extensions, pipelines and telemetry of the collector.
+type Service struct {
+	// Extensions are the IDs of the enabled extensions.
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+	// Telemetry is the configuration of the collector's own telemetry.
+	// +optional
+	Telemetry *AnyConfig `json:"telemetry,omitempty"`
+	// Pipelines are the pipelines of the collector, by ID, e.g. traces or metrics/backend.
+	// +kubebuilder:validation:MinProperties=1
+	Pipelines map[string]*Pipeline `json:"pipelines"`
+}
+
+// Pipeline is a pipeline of the collector.
+type Pipeline struct {
+	// Receivers are the IDs of the receivers of the pipeline.
+	// +kubebuilder:validation:MinItems=1
+	Receivers []string `json:"receivers"`
+	// Processors are the IDs of the processors of the pipeline, in order.
+	// +optional
+	Processors []string `json:"processors,omitempty"`
+	// Exporters are the IDs of the exporters of the pipeline.
+	// +kubebuilder:validation:MinItems=1
+	Exporters []string `json:"exporters"`
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// configAnnotation holds the config of a v1alpha1 resource read as v1beta1. Writing the resource back keeps the
+	// comments and the formatting of the config when the v1beta1 client didn't change it.
+	configAnnotation = "opentelemetry.io/v1alpha1-config"
+
+	// maxConfigAnnotationSize keeps the annotation well below the 256KiB limit of the annotations of an object; the
+	// larger configs are written back formatted from their structure.
+	maxConfigAnnotationSize = 128 * 1024
+)
+
+var _ conversion.Convertible = &OpenTelemetryCollector{}
+
+// ConvertTo converts this OpenTelemetryCollector to the v1alpha1 version, with the config written as YAML.
+func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.OpenTelemetryCollector)
+	if !ok {
+		return errors.New("the conversion target isn't a v1alpha1 OpenTelemetryCollector")
+	}
+
+	configJSON, err := json.Marshal(src.Spec.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the config: %w", err)
+	}
+	configYAML, err := yaml.JSONToYAML(configJSON)
+	if err != nil {
+		return fmt.Errorf("failed to convert the config to YAML: %w", err)
+	}
+	config := string(configYAML)
+	if original, ok := src.Annotations[configAnnotation]; ok {
+		// the original config is restored as it was written, unless the v1beta1 client changed it
+		if originalJSON, err := structuredConfigJSON(original); err == nil && bytes.Equal(originalJSON, configJSON) {
+			config = original
+		}
+	}
+
+	// the other fields of the spec are the same in both versions
+	if err := convertSpec(src.Spec, config, &dst.Spec); err != nil {
+		return err
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, configAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 version of an OpenTelemetryCollector to this version. It fails when the config
+// isn't a valid YAML document.
+func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.OpenTelemetryCollector)
+	if !ok {
+		return errors.New("the conversion source isn't a v1alpha1 OpenTelemetryCollector")
+	}
+
+	config, err := parseConfig(src.Spec.Config)
+	if err != nil {
+		return err
+	}
+
+	if err := convertSpec(src.Spec, config, &dst.Spec); err != nil {
+		return err
+	}
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if len(src.Spec.Config) <= maxConfigAnnotationSize {
+		metav1.SetMetaDataAnnotation(&dst.ObjectMeta, configAnnotation, src.Spec.Config)
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// parseConfig parses the YAML config of the v1alpha1 version into a JSON object.
+func parseConfig(config string) (map[string]interface{}, error) {
+	configJSON, err := yaml.YAMLToJSON([]byte(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the config: %w", err)
+	}
+	var object map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(configJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("the config isn't a YAML object: %w", err)
+	}
+	return object, nil
+}
+
+// structuredConfigJSON returns the JSON of the v1beta1 config of the YAML config of the v1alpha1 version.
+func structuredConfigJSON(config string) ([]byte, error) {
+	object, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	structured := Config{}
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return nil, err
+	}
+	return json.Marshal(structured)
+}
+
+// convertSpec copies the src spec into dst through their JSON representation, replacing the config with the given value.
+func convertSpec(src interface{}, config interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the spec: %w", err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal the spec: %w", err)
+	}
+	fields["config"] = config
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the spec: %w", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to convert the spec: %w", err)
+	}
+	return nil
+}
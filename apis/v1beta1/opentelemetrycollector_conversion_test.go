@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const collectorConfig = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  batch: {}
+exporters:
+  logging:
+    verbosity: detailed
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+`
+
+func TestConvertFrom(t *testing.T) {
+	// prepare
+	replicas := int32(2)
+	src := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:     v1alpha1.ModeStatefulSet,
+			Replicas: &replicas,
+			Config:   collectorConfig,
+			Env:      []v1.EnvVar{{Name: "MY_ENV", Value: "value"}},
+		},
+		Status: v1alpha1.OpenTelemetryCollectorStatus{
+			Version: "0.66.0",
+		},
+	}
+
+	// test
+	dst := OpenTelemetryCollector{}
+	err := dst.ConvertFrom(&src)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, src.Name, dst.Name)
+	assert.Equal(t, map[string]string{configAnnotation: collectorConfig}, dst.Annotations)
+	assert.Nil(t, src.Annotations, "the source shouldn't be changed")
+	assert.Equal(t, src.Status, dst.Status)
+	assert.Equal(t, v1alpha1.ModeStatefulSet, dst.Spec.Mode)
+	assert.Equal(t, &replicas, dst.Spec.Replicas)
+	assert.Equal(t, src.Spec.Env, dst.Spec.Env)
+	assert.Equal(t, map[string]interface{}{"verbosity": "detailed"}, dst.Spec.Config.Exporters.Object["logging"])
+	assert.Contains(t, dst.Spec.Config.Receivers.Object, "otlp")
+	require.NotNil(t, dst.Spec.Config.Processors)
+	assert.Contains(t, dst.Spec.Config.Processors.Object, "batch")
+	assert.Nil(t, dst.Spec.Config.Extensions)
+	assert.Equal(t, &Pipeline{
+		Receivers:  []string{"otlp"},
+		Processors: []string{"batch"},
+		Exporters:  []string{"logging"},
+	}, dst.Spec.Config.Service.Pipelines["traces"])
+}
+
+func TestConvertFromInvalidConfig(t *testing.T) {
+	for _, config := range []string{"receivers: [otlp", "just a string"} {
+		src := v1alpha1.OpenTelemetryCollector{
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{Config: config},
+		}
+		dst := OpenTelemetryCollector{}
+		assert.Error(t, dst.ConvertFrom(&src), config)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	// prepare
+	src := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:         v1alpha1.ModeDaemonSet,
+			Config:       collectorConfig,
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+			},
+		},
+	}
+
+	// test
+	beta := OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertFrom(&src))
+	dst := v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertTo(&dst))
+
+	// verify
+	assert.Equal(t, src, dst)
+}
+
+func TestConvertRoundTripKeepsFields(t *testing.T) {
+	// prepare
+	src := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: collectorConfig + `# the sections unknown to v1beta1 are kept
+experimental:
+  enabled: true
+`,
+		},
+	}
+	src.Spec.Config = strings.Replace(src.Spec.Config, "verbosity: detailed", "sampling_initial: 12345678901234567890", 1)
+
+	// test
+	beta := OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertFrom(&src))
+	// the v1beta1 client doesn't know the annotation, or drops it
+	delete(beta.Annotations, configAnnotation)
+	data, err := json.Marshal(beta)
+	require.NoError(t, err)
+	written := OpenTelemetryCollector{}
+	require.NoError(t, json.Unmarshal(data, &written))
+	dst := v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, written.ConvertTo(&dst))
+
+	// verify
+	var expected, actual map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(src.Spec.Config), &expected))
+	require.NoError(t, yaml.Unmarshal([]byte(dst.Spec.Config), &actual))
+	assert.Equal(t, expected, actual)
+	assert.Contains(t, dst.Spec.Config, "12345678901234567890")
+	assert.Nil(t, dst.Annotations)
+}
+
+func TestConvertToChangedConfig(t *testing.T) {
+	// prepare
+	src := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{Config: collectorConfig},
+	}
+	beta := OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertFrom(&src))
+	beta.Spec.Config.Processors = nil
+	beta.Spec.Config.Service.Pipelines["traces"].Processors = nil
+
+	// test
+	dst := v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, beta.ConvertTo(&dst))
+
+	// verify
+	assert.NotContains(t, dst.Spec.Config, "batch")
+	assert.Nil(t, dst.Annotations)
+}
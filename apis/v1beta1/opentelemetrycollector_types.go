@@ -0,0 +1,385 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector. It has the same fields as the
+// v1alpha1 spec, except the config being structured.
+type OpenTelemetryCollectorSpec struct {
+	// Resources to set on the OpenTelemetry Collector pods.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector to schedule OpenTelemetry Collector pods.
+	// This is only relevant to daemonset, statefulset, and deployment mode
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Args is the set of arguments to pass to the OpenTelemetry Collector binary
+	// +optional
+	Args map[string]string `json:"args,omitempty"`
+	// Replicas is the number of pod instances for the underlying OpenTelemetry Collector. Set this if your are not using autoscaling
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// MinReplicas sets a lower bound to the autoscaling feature.  Set this if your are using autoscaling. It must be at least 1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas sets an upper bound to the autoscaling feature. If MaxReplicas is set autoscaling is enabled.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Autoscaler specifies the pod autoscaling configuration to use
+	// for the OpenTelemetryCollector workload.
+	//
+	// +optional
+	Autoscaler *v1alpha1.AutoscalerSpec `json:"autoscaler,omitempty"`
+	// PodDisruptionBudget makes the operator manage a PodDisruptionBudget limiting the collector pods evicted at once,
+	// e.g. by node drains. It's only supported in the deployment and statefulset modes.
+	// +optional
+	PodDisruptionBudget *v1alpha1.PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	// SecurityContext will be set as the container security context. Unless set otherwise, the privilege escalation
+	// is disabled and all the capabilities are dropped, as required by the restricted Pod Security Standard.
+	// +optional
+	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
+	// PodSecurityContext will be set as the pod security context. Unless set otherwise, the pods run as non-root with
+	// the RuntimeDefault seccomp profile, as required by the restricted Pod Security Standard.
+	// +optional
+	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// ShareProcessNamespace indicates if the containers of the collector pods share a single process namespace.
+	// Defaults to true with the reload config strategy, which needs it to signal the collector, and false otherwise.
+	// Not supported with the sidecar mode.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
+	// PodAnnotations is the set of annotations that will be attached to
+	// Collector and Target Allocator pods.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// AdditionalLabels are added to the labels of all the objects generated for the instance, including the pods. They
+	// override the labels of the instance, but not the labels set by the operator.
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+	// AdditionalAnnotations are added to the annotations propagated from the instance to the generated objects, and to
+	// the annotations of the pods. They override the annotations of the instance, and are overridden by
+	// spec.podAnnotations on the pods.
+	// +optional
+	AdditionalAnnotations map[string]string `json:"additionalAnnotations,omitempty"`
+	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
+	// +optional
+	TargetAllocator v1alpha1.OpenTelemetryTargetAllocator `json:"targetAllocator,omitempty"`
+	// TargetAllocatorRef is the name of a TargetAllocator, in the namespace of the instance, allocating the targets of
+	// its prometheus receiver among the collectors of all the instances referencing it. It can't be set along with an
+	// enabled TargetAllocator, and it's only supported in the statefulset and daemonset modes.
+	// +optional
+	TargetAllocatorRef string `json:"targetAllocatorRef,omitempty"`
+	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
+	// +optional
+	Mode v1alpha1.Mode `json:"mode,omitempty"`
+	// NativeSidecar injects the collector as a native sidecar, i.e. an init container restarting always, so it starts
+	// before the containers of the pod and terminates after them. Only available when the mode=sidecar, and it requires
+	// the SidecarContainers feature gate of Kubernetes 1.28+, enabled by default since 1.29.
+	// +optional
+	NativeSidecar bool `json:"nativeSidecar,omitempty"`
+	// ServiceAccount indicates the name of an existing service account to use with this instance. When set,
+	// the operator will not automatically create a ServiceAccount for the collector.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Image indicates the container image to use for the OpenTelemetry Collector.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version pins the version of the OpenTelemetry Collector, e.g. 0.61.0. It selects the tag of the default collector
+	// image, and the automatic upgrades of the CR stop at this version instead of the one shipped with the operator.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
+	// +optional
+	UpgradeStrategy v1alpha1.UpgradeStrategy `json:"upgradeStrategy"`
+	// ConfigReloadStrategy represents how the collector pods pick up the changes of the config: they're either rolled
+	// out (restart), or a sidecar sends a SIGHUP to the running collectors once the kubelet updated their config file
+	// (reload). The config changes affecting the ports or the liveness probe of the collector container still roll out
+	// the pods, as do all the changes of the collectors running on Windows nodes. The reload strategy isn't supported
+	// in the sidecar mode.
+	// +optional
+	ConfigReloadStrategy v1alpha1.ConfigReloadStrategy `json:"configReloadStrategy,omitempty"`
+	// ManagementState defines whether the operator manages the resources of the CR (managed) or leaves them, and the
+	// manual changes made to them, as they are (unmanaged). The deletion of an unmanaged CR is still handled.
+	// +optional
+	ManagementState v1alpha1.ManagementStateType `json:"managementState,omitempty"`
+	// ReconcilePolicy represents when the operator reconciles the CR: on every change (Always), only when the
+	// "opentelemetry.io/trigger-reconcile" annotation is set to "true" (OnDemand) or following the
+	// ReconcileSchedule (Scheduled).
+	// +optional
+	ReconcilePolicy v1alpha1.ReconcilePolicy `json:"reconcilePolicy,omitempty"`
+	// ReconcileSchedule is the cron schedule, in the standard five fields format, used with the Scheduled reconcile policy.
+	// +optional
+	ReconcileSchedule string `json:"reconcileSchedule,omitempty"`
+	// GCPolicy represents what happens to the resources created for the CR when the CR is deleted (Foreground, Background or Orphan).
+	// With Foreground, the resources are deleted before the CR is deleted. With Background, the CR is deleted right away
+	// and the resources are garbage collected afterwards. With Orphan, the owner references are removed from the
+	// resources before the CR is deleted, leaving them in place. A foreground cascading deletion of the CR still deletes
+	// the resources.
+	// +optional
+	GCPolicy v1alpha1.GCPolicy `json:"gcPolicy,omitempty"`
+
+	// ImagePullPolicy indicates the pull policy to be used for retrieving the container image (Always, Never, IfNotPresent)
+	// +optional
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Config is the collector's configuration, with its known top-level sections. Refer to the OpenTelemetry Collector
+	// documentation for details. Values can embed CEL expressions written as ${expression}, with the spec and metadata
+	// of this resource available as cr, e.g. ${cr.metadata.name}.
+	// +required
+	Config Config `json:"config"`
+	// ConfigSources are ConfigMap and Secret keys holding parts of the collector config, labeled
+	// opentelemetry.io/config-source=true. The ConfigMap sources are merged in order under the inline Config, which takes
+	// precedence, and written to the collector's ConfigMap. The Secret sources are passed to the collector as
+	// environment variables, and merged by the collector under that config. The maps of the configs are merged
+	// recursively, the other values replaced.
+	// +optional
+	// +listType=atomic
+	ConfigSources []v1alpha1.ConfigSource `json:"configSources,omitempty"`
+	// VolumeMounts represents the mount points to use in the underlying collector deployment(s)
+	// +optional
+	// +listType=atomic
+	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Ports allows a set of ports to be exposed by the underlying v1.Service. By default, the operator
+	// will attempt to infer the required ports by parsing the .Spec.Config property but this property can be
+	// used to open additional ports that can't be inferred by the operator, like for custom receivers.
+	// +optional
+	// +listType=atomic
+	Ports []v1.ServicePort `json:"ports,omitempty"`
+	// ENV vars to set on the OpenTelemetry Collector's Pods. These can then in certain cases be
+	// consumed in the config file for the Collector.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// List of sources to populate environment variables on the OpenTelemetry Collector's Pods.
+	// These can then in certain cases be consumed in the config file for the Collector.
+	// +optional
+	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
+	// VolumeClaimTemplates will provide stable storage using PersistentVolumes. Only available when the mode=statefulset.
+	// The claims that aren't mounted by VolumeMounts are mounted at /var/lib/otelcol/<claim name>.
+	// +optional
+	// +listType=atomic
+	VolumeClaimTemplates []v1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	// DeploymentUpdateStrategy is the strategy replacing the collector pods of the Deployment with new ones.
+	// Only supported with the deployment mode.
+	// +optional
+	DeploymentUpdateStrategy appsv1.DeploymentStrategy `json:"deploymentUpdateStrategy,omitempty"`
+	// DaemonSetUpdateStrategy is the strategy replacing the collector pods of the DaemonSet with new ones, e.g. a rolling
+	// update with a maxUnavailable or maxSurge, or OnDelete. Only supported with the daemonset mode.
+	// +optional
+	DaemonSetUpdateStrategy appsv1.DaemonSetUpdateStrategy `json:"daemonSetUpdateStrategy,omitempty"`
+	// StatefulSetUpdateStrategy is the strategy replacing the collector pods of the StatefulSet with new ones.
+	// Only supported with the statefulset mode.
+	// +optional
+	StatefulSetUpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"statefulSetUpdateStrategy,omitempty"`
+	// PodManagementPolicy controls how the collector pods of the StatefulSet are created and deleted, defaults to Parallel.
+	// Changing it recreates the StatefulSet. Only supported with the statefulset mode.
+	// +optional
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy describes the lifecycle of the claims created from VolumeClaimTemplates.
+	// Only available when the mode=statefulset, and it requires the StatefulSetAutoDeletePVC feature gate of Kubernetes.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	// Toleration to schedule OpenTelemetry Collector pods.
+	// This is only relevant to daemonset, statefulset, and deployment mode
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// Volumes represents which volumes to use in the underlying collector deployment(s).
+	// +optional
+	// +listType=atomic
+	Volumes []v1.Volume `json:"volumes,omitempty"`
+	// InitContainers run before the collector in its pods, e.g. to fetch GeoIP databases or render secrets to disk
+	// in one of the volumes. Not supported with the sidecar mode.
+	// +optional
+	// +listType=atomic
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// AdditionalContainers run alongside the collector in its pods. Their names can't start with "otc-", which is
+	// reserved for the containers of the operator. Not supported with the sidecar mode.
+	// +optional
+	// +listType=atomic
+	AdditionalContainers []v1.Container `json:"additionalContainers,omitempty"`
+	// PodTemplateOverrides is a strategic merge patch applied to the pod template of the collector as a last step,
+	// e.g. to set the hostAliases or fields not exposed by the CR. The selector labels of the pods can't be patched.
+	// Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	PodTemplateOverrides *runtime.RawExtension `json:"podTemplateOverrides,omitempty"`
+	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
+	// functionality is only available if one of the valid modes is set.
+	// Valid modes are: deployment, daemonset and statefulset.
+	// +optional
+	Ingress v1alpha1.Ingress `json:"ingress,omitempty"`
+	// HostNetwork indicates if the pod should run in the host networking namespace.
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// DNSPolicy is the DNS policy of the collector pods. Defaults to ClusterFirstWithHostNet when the pods run in the
+	// host networking namespace and to ClusterFirst otherwise. Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNSConfig is the DNS parameters of the collector pods, merged with the ones generated from the DNSPolicy.
+	// It's required with the None DNS policy. Not supported with the sidecar mode.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// If specified, indicates the pod's priority.
+	// If not specified, the pod priority will be default or zero if there is no
+	// default.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// ShutdownTimeout is the time given to the collector to drain its connections when its pods are terminated,
+	// e.g. "30s". The pods get a termination grace period of this duration plus 10 seconds.
+	// Only supported with the deployment mode.
+	// +optional
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty"`
+	// ShutdownPreStopSleep adds a preStop hook sleeping for 5 seconds along with the shutdownTimeout, so that load
+	// balancers stop sending traffic before the collector receives SIGTERM. The hook runs the sleep command, which
+	// isn't part of the distroless collector images.
+	// +optional
+	ShutdownPreStopSleep bool `json:"shutdownPreStopSleep,omitempty"`
+	// Lifecycle is the lifecycle of the collector container, e.g. a preStop hook draining the in-flight batches
+	// before the collector receives SIGTERM. It replaces the preStop hook set up by the shutdownPreStopSleep.
+	// +optional
+	Lifecycle *v1.Lifecycle `json:"lifecycle,omitempty"`
+	// TerminationGracePeriodSeconds is the time given to the collector pods to terminate, including the preStop hook.
+	// It takes precedence over the grace period derived from the shutdownTimeout.
+	// Not supported with the sidecar mode.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets of the collector Deployment kept to allow rollbacks.
+	// Defaults to 1. Only supported with the deployment mode.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// If specified, indicates the pod's scheduling constraints
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints describes how the collector pods are spread across the topology domains, e.g. the zones
+	// of the cluster. It's not supported in the sidecar mode.
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// AntiAffinityTarget defines the pods the collector pods aren't scheduled next to, on top of Affinity.
+	// +optional
+	AntiAffinityTarget *v1alpha1.AntiAffinityTargetSpec `json:"antiAffinityTarget,omitempty"`
+	// RuntimeClassName is the name of the RuntimeClass running the collector pods, e.g. kata or gvisor
+	// for VM-based container runtimes.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// Overhead is the resource overhead of the collector pods on top of their containers, consumed by the
+	// VM-based runtime set with RuntimeClassName. It needs to match the overhead of the RuntimeClass, if any.
+	// +optional
+	Overhead v1.ResourceList `json:"overhead,omitempty"`
+	// Service defines how the Service exposing the OpenTelemetry Collector receivers is created.
+	// +optional
+	Service v1alpha1.ServiceSpec `json:"service,omitempty"`
+	// EncryptionKeyRef references the AES-256 key used to decrypt the values of the config wrapped as
+	// "enc:<base64-ciphertext>". The decrypted values are only written to the collector's ConfigMap.
+	// +optional
+	EncryptionKeyRef *v1.SecretKeySelector `json:"encryptionKeyRef,omitempty"`
+	// PreDeployCheck defines a Job the operator runs, and waits to succeed, before applying a config or image
+	// update to the collector.
+	// +optional
+	PreDeployCheck *v1alpha1.PreDeployCheckSpec `json:"preDeployCheck,omitempty"`
+	// Build defines a custom collector distribution, containing only the components of its manifest. The operator
+	// builds it with the OpenTelemetry Collector Builder, pushes its image, and deploys it instead of the image of
+	// the spec. Not supported with the sidecar mode.
+	// +optional
+	Build *v1alpha1.BuildSpec `json:"build,omitempty"`
+	// SmokeTest defines the test sending spans to the collector after each rollout of its Deployment.
+	// The results are recorded as CollectorSmokeTest objects. Only supported with the deployment mode.
+	// +optional
+	SmokeTest *v1alpha1.SmokeTestSpec `json:"smokeTest,omitempty"`
+	// Canary defines the canary rollout of the collector config and image changes: they first run in a separate canary
+	// Deployment, and are only applied to the collector Deployment once the canary stayed healthy for a bake period.
+	// Only supported with the deployment mode.
+	// +optional
+	Canary *v1alpha1.CanarySpec `json:"canary,omitempty"`
+	// LivenessProbe defines the liveness probe of the collector container. When unset, the webhook chooses the
+	// gRPC probe on clusters supporting it (Kubernetes 1.24+), except for sidecars.
+	// +optional
+	LivenessProbe *v1alpha1.LivenessProbeSpec `json:"livenessProbe,omitempty"`
+	// ReadinessProbe defines the readiness probe of the collector container, which checks the same endpoint as its
+	// liveness probe. The collector container has no readiness probe when unset.
+	// +optional
+	ReadinessProbe *v1alpha1.ProbeSpec `json:"readinessProbe,omitempty"`
+	// StartupProbe defines the startup probe of the collector container, which checks the same endpoint as its liveness
+	// probe. It holds the other probes until the collector started, e.g. for the collectors with large tail sampling
+	// buffers. The collector container has no startup probe when unset.
+	// +optional
+	StartupProbe *v1alpha1.ProbeSpec `json:"startupProbe,omitempty"`
+	// MemoryTuning derives the memory settings of the collector from the memory limit of its container, keeping them
+	// in sync with the resources. Nothing is derived unless one of its percentages is set.
+	// +optional
+	MemoryTuning v1alpha1.MemoryTuningSpec `json:"memoryTuning,omitempty"`
+	// TLS defines how the TLS settings of the receivers and exporters in the config are checked.
+	// +optional
+	TLS v1alpha1.TLSSpec `json:"tls,omitempty"`
+	// FederationRef references the Cluster API cluster the collector is deployed to, instead of the cluster
+	// running the operator.
+	// +optional
+	FederationRef *v1alpha1.FederationRef `json:"federationRef,omitempty"`
+	// EventExport defines an external event bus the operator publishes the state transitions of this instance to.
+	// +optional
+	EventExport v1alpha1.EventExportSpec `json:"eventExport,omitempty"`
+	// Observability defines how the self-telemetry of the collector is collected.
+	// +optional
+	Observability v1alpha1.ObservabilitySpec `json:"observability,omitempty"`
+	// NetworkPolicy makes the operator manage NetworkPolicies only allowing the ingress traffic to the ports of the
+	// collector and target allocator pods.
+	// +optional
+	NetworkPolicy *v1alpha1.NetworkPolicySpec `json:"networkPolicy,omitempty"`
+	// RBAC defines the permissions the operator grants to the collector for the components of its config reading from
+	// the Kubernetes API.
+	// +optional
+	RBAC v1alpha1.RBACSpec `json:"rbac,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=otelcol;otelcols
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Deployment Mode"
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas",description="Desired replicas",priority=1
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="OpenTelemetry Version"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.scale.statusReplicas",description="Ready replicas over the desired replicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector"
+
+// OpenTelemetryCollector is the Schema for the opentelemetrycollectors API.
+type OpenTelemetryCollector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenTelemetryCollectorSpec            `json:"spec,omitempty"`
+	Status v1alpha1.OpenTelemetryCollectorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenTelemetryCollectorList contains a list of OpenTelemetryCollector.
+type OpenTelemetryCollectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenTelemetryCollector `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenTelemetryCollector{}, &OpenTelemetryCollectorList{})
+}
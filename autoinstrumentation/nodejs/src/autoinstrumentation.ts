@@ -1,11 +1,38 @@
+import { TextMapPropagator } from '@opentelemetry/api';
 import { getNodeAutoInstrumentations } from '@opentelemetry/auto-instrumentations-node';
+import { CompositePropagator, W3CBaggagePropagator, W3CTraceContextPropagator } from '@opentelemetry/core';
 import { OTLPTraceExporter } from '@opentelemetry/exporter-trace-otlp-grpc';
+import { AWSXRayPropagator } from '@opentelemetry/propagator-aws-xray';
+import { B3InjectEncoding, B3Propagator } from '@opentelemetry/propagator-b3';
+import { JaegerPropagator } from '@opentelemetry/propagator-jaeger';
+import { OTTracePropagator } from '@opentelemetry/propagator-ot-trace';
 
 import { NodeSDK } from '@opentelemetry/sdk-node';
 
+// The SDK only knows the W3C, B3 and Jaeger propagators, so OTEL_PROPAGATORS is translated here to support all
+// the propagators of the Instrumentation resource.
+const propagators: { [name: string]: () => TextMapPropagator } = {
+    tracecontext: () => new W3CTraceContextPropagator(),
+    baggage: () => new W3CBaggagePropagator(),
+    b3: () => new B3Propagator(),
+    b3multi: () => new B3Propagator({ injectEncoding: B3InjectEncoding.MULTI_HEADER }),
+    jaeger: () => new JaegerPropagator(),
+    xray: () => new AWSXRayPropagator(),
+    ottrace: () => new OTTracePropagator(),
+};
+
+function textMapPropagator(): TextMapPropagator {
+    const names = (process.env.OTEL_PROPAGATORS || 'tracecontext,baggage')
+        .split(',')
+        .map((name) => name.trim())
+        .filter((name) => name in propagators);
+    return new CompositePropagator({ propagators: names.map((name) => propagators[name]()) });
+}
+
 const sdk = new NodeSDK({
     autoDetectResources: true,
     instrumentations: [getNodeAutoInstrumentations()],
+    textMapPropagator: textMapPropagator(),
     traceExporter: new OTLPTraceExporter(),
 });
 
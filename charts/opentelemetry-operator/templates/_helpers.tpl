@@ -0,0 +1,87 @@
+{{/*
+Expand the name of the chart.
+*/}}
+{{- define "opentelemetry-operator.name" -}}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Create a default fully qualified app name, truncated at 63 chars because of the DNS naming spec.
+*/}}
+{{- define "opentelemetry-operator.fullname" -}}
+{{- if .Values.fullnameOverride }}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" }}
+{{- else }}
+{{- $name := default .Chart.Name .Values.nameOverride }}
+{{- if contains $name .Release.Name }}
+{{- .Release.Name | trunc 63 | trimSuffix "-" }}
+{{- else }}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{/*
+Common labels.
+*/}}
+{{- define "opentelemetry-operator.labels" -}}
+helm.sh/chart: {{ printf "%s-%s" .Chart.Name .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" }}
+{{ include "opentelemetry-operator.selectorLabels" . }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end }}
+
+{{/*
+Selector labels.
+*/}}
+{{- define "opentelemetry-operator.selectorLabels" -}}
+app.kubernetes.io/name: {{ include "opentelemetry-operator.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+control-plane: controller-manager
+{{- end }}
+
+{{/*
+The name of the service account of the operator.
+*/}}
+{{- define "opentelemetry-operator.serviceAccountName" -}}
+{{- if .Values.serviceAccount.create }}
+{{- default (include "opentelemetry-operator.fullname" .) .Values.serviceAccount.name }}
+{{- else }}
+{{- default "default" .Values.serviceAccount.name }}
+{{- end }}
+{{- end }}
+
+{{/*
+The name of the Secret holding the serving certificate of the webhooks.
+*/}}
+{{- define "opentelemetry-operator.webhookSecretName" -}}
+{{- default (printf "%s-webhook-cert" (include "opentelemetry-operator.fullname" .)) .Values.admissionWebhooks.secretName }}
+{{- end }}
+
+{{/*
+The client config of the webhooks, calling the given path of the webhook Service.
+*/}}
+{{- define "opentelemetry-operator.webhookClientConfig" -}}
+clientConfig:
+  {{- if and .root.Values.admissionWebhooks.caBundle (not .root.Values.admissionWebhooks.certManager.enabled) }}
+  caBundle: {{ .root.Values.admissionWebhooks.caBundle | b64enc }}
+  {{- end }}
+  service:
+    name: {{ include "opentelemetry-operator.fullname" .root }}-webhook
+    namespace: {{ .root.Release.Namespace }}
+    path: {{ .path }}
+{{- end }}
+
+{{/*
+The selectors of the namespaces and of the objects handled by the webhooks.
+*/}}
+{{- define "opentelemetry-operator.webhookSelectors" -}}
+{{- with .namespaceSelector }}
+namespaceSelector:
+  {{- toYaml . | nindent 2 }}
+{{- end }}
+{{- with .objectSelector }}
+objectSelector:
+  {{- toYaml . | nindent 2 }}
+{{- end }}
+{{- end }}
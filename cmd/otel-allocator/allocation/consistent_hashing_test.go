@@ -103,3 +103,26 @@ func TestNumRemapped(t *testing.T) {
 	}
 	assert.InDelta(t, numItems/numFinalCols, countRemapped, expectedDelta)
 }
+
+func TestSameAssignmentsOnAllReplicas(t *testing.T) {
+	// prepare
+	cols := MakeNCollectors(5, 0)
+	first := newConsistentHashingAllocator(logger)
+	second := newConsistentHashingAllocator(logger)
+
+	// test
+	first.SetCollectors(cols)
+	first.SetTargets(MakeNNewTargets(50, 5, 0))
+	// the second replica sees the collectors one at a time, and the targets first
+	second.SetCollectors(MakeNCollectors(1, 0))
+	second.SetTargets(MakeNNewTargets(50, 5, 0))
+	for i := 2; i <= len(cols); i++ {
+		second.SetCollectors(MakeNCollectors(i, 0))
+	}
+
+	// verify
+	secondItems := second.TargetItems()
+	for hash, item := range first.TargetItems() {
+		assert.Equal(t, item.CollectorName, secondItems[hash].CollectorName)
+	}
+}
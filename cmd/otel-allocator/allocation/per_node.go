@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var _ Allocator = &perNodeAllocator{}
+
+const perNodeStrategyName = "per-node"
+
+// nodeLabels are the discovered labels holding the node of a target, in order of precedence.
+var nodeLabels = []model.LabelName{
+	"__meta_kubernetes_endpoint_node_name",
+	"__meta_kubernetes_pod_node_name",
+	"__meta_kubernetes_node_name",
+}
+
+var targetsUnassigned = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "opentelemetry_allocator_targets_unassigned",
+	Help: "Number of targets without a collector running on their node.",
+})
+
+// perNodeAllocator assigns each target to the collector running on the node of the target,
+// like the collectors of a DaemonSet scraping their node-local targets.
+// The targets without a node, or without a collector running on their node, aren't assigned.
+// Users need to call SetTargets when they have new targets in their
+// clusters and call SetCollectors when the collectors have changed.
+type perNodeAllocator struct {
+	// m protects collectors and targetItems for concurrent use.
+	m sync.RWMutex
+	// collectors is a map from a Collector's node name to a Collector instance
+	collectors map[string]*Collector
+	// targetItems is a map from a target item's hash to the target items allocated state
+	targetItems map[string]*target.Item
+
+	// collectorKey -> job -> target item hash -> true
+	targetItemsPerJobPerCollector map[string]map[string]map[string]bool
+
+	log logr.Logger
+
+	filter Filter
+}
+
+// SetFilter sets the filtering hook to use.
+func (allocator *perNodeAllocator) SetFilter(filter Filter) {
+	allocator.filter = filter
+}
+
+func (allocator *perNodeAllocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
+	allocator.m.RLock()
+	defer allocator.m.RUnlock()
+	if _, ok := allocator.targetItemsPerJobPerCollector[collector]; !ok {
+		return []*target.Item{}
+	}
+	if _, ok := allocator.targetItemsPerJobPerCollector[collector][job]; !ok {
+		return []*target.Item{}
+	}
+	targetItemsCopy := make([]*target.Item, len(allocator.targetItemsPerJobPerCollector[collector][job]))
+	index := 0
+	for targetHash := range allocator.targetItemsPerJobPerCollector[collector][job] {
+		targetItemsCopy[index] = allocator.targetItems[targetHash]
+		index++
+	}
+	return targetItemsCopy
+}
+
+// TargetItems returns a shallow copy of the targetItems map.
+func (allocator *perNodeAllocator) TargetItems() map[string]*target.Item {
+	allocator.m.RLock()
+	defer allocator.m.RUnlock()
+	targetItemsCopy := make(map[string]*target.Item)
+	for k, v := range allocator.targetItems {
+		targetItemsCopy[k] = v
+	}
+	return targetItemsCopy
+}
+
+// Collectors returns a shallow copy of the collectors map, keyed by the collector names.
+func (allocator *perNodeAllocator) Collectors() map[string]*Collector {
+	allocator.m.RLock()
+	defer allocator.m.RUnlock()
+	collectorsCopy := make(map[string]*Collector)
+	for _, v := range allocator.collectors {
+		collectorsCopy[v.Name] = v
+	}
+	return collectorsCopy
+}
+
+// nodeName returns the node of the target, if any.
+func nodeName(tg *target.Item) string {
+	for _, label := range nodeLabels {
+		if node, ok := tg.Labels[label]; ok && node != "" {
+			return string(node)
+		}
+	}
+	return ""
+}
+
+// addTargetToTargetItems assigns a target to the collector running on its node and adds it to the allocator's
+// targetItems. The target is kept without a collector when there's none on its node.
+// This method is called from within SetTargets and SetCollectors, which acquire the needed lock.
+func (allocator *perNodeAllocator) addTargetToTargetItems(tg *target.Item) {
+	tg.CollectorName = ""
+	allocator.targetItems[tg.Hash()] = tg
+
+	chosenCollector, ok := allocator.collectors[nodeName(tg)]
+	if !ok {
+		return
+	}
+	tg.CollectorName = chosenCollector.Name
+	if allocator.targetItemsPerJobPerCollector[tg.CollectorName] == nil {
+		allocator.targetItemsPerJobPerCollector[tg.CollectorName] = make(map[string]map[string]bool)
+	}
+	if allocator.targetItemsPerJobPerCollector[tg.CollectorName][tg.JobName] == nil {
+		allocator.targetItemsPerJobPerCollector[tg.CollectorName][tg.JobName] = make(map[string]bool)
+	}
+	allocator.targetItemsPerJobPerCollector[tg.CollectorName][tg.JobName][tg.Hash()] = true
+	chosenCollector.NumTargets++
+	TargetsPerCollector.WithLabelValues(chosenCollector.Name, perNodeStrategyName).Set(float64(chosenCollector.NumTargets))
+}
+
+// handleTargets receives the new and removed targets and reconciles the current state.
+// Any removals are removed from the allocator's targetItems and unassigned from the corresponding collector.
+// Any net-new additions are assigned to the collector of their node.
+func (allocator *perNodeAllocator) handleTargets(diff diff.Changes[*target.Item]) {
+	// Check for removals
+	for k, item := range allocator.targetItems {
+		// if the current item is in the removals list
+		if _, ok := diff.Removals()[k]; ok {
+			delete(allocator.targetItems, k)
+			if c, ok := allocator.collectors[nodeName(item)]; ok && c.Name == item.CollectorName {
+				c.NumTargets--
+				delete(allocator.targetItemsPerJobPerCollector[item.CollectorName][item.JobName], item.Hash())
+				TargetsPerCollector.WithLabelValues(item.CollectorName, perNodeStrategyName).Set(float64(c.NumTargets))
+			}
+		}
+	}
+
+	// Check for additions
+	for k, item := range diff.Additions() {
+		// Do nothing if the item is already there
+		if _, ok := allocator.targetItems[k]; !ok {
+			allocator.addTargetToTargetItems(item)
+		}
+	}
+}
+
+// handleCollectors reconciles the collectors of the nodes with the given collectors, and reassigns the targets of
+// the nodes whose collector changed. Only the collectors scheduled on a node can be assigned targets. When several
+// collectors run on the same node, the first one by name is assigned the node targets, so that all the replicas of
+// the allocator make the same choice.
+func (allocator *perNodeAllocator) handleCollectors(collectors map[string]*Collector) bool {
+	desired := map[string]*Collector{}
+	for _, c := range collectors {
+		if c.NodeName == "" {
+			continue
+		}
+		if existing, ok := desired[c.NodeName]; !ok || c.Name < existing.Name {
+			desired[c.NodeName] = c
+		}
+	}
+
+	changed := false
+	// Clear removed collectors
+	for node, c := range allocator.collectors {
+		if d, ok := desired[node]; !ok || d.Name != c.Name {
+			delete(allocator.collectors, node)
+			delete(allocator.targetItemsPerJobPerCollector, c.Name)
+			TargetsPerCollector.WithLabelValues(c.Name, perNodeStrategyName).Set(0)
+			changed = true
+		}
+	}
+	// Insert the new collectors
+	for node, d := range desired {
+		if _, ok := allocator.collectors[node]; !ok {
+			allocator.collectors[node] = NewCollector(d.Name, node)
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	// Re-Allocate the targets of the removed collectors and the unassigned ones
+	for _, item := range allocator.targetItems {
+		if c, ok := allocator.collectors[nodeName(item)]; !ok || c.Name != item.CollectorName {
+			allocator.addTargetToTargetItems(item)
+		}
+	}
+	return true
+}
+
+func (allocator *perNodeAllocator) recordTargetsUnassigned() {
+	unassigned := 0
+	for _, item := range allocator.targetItems {
+		if item.CollectorName == "" {
+			unassigned++
+		}
+	}
+	targetsUnassigned.Set(float64(unassigned))
+}
+
+// SetTargets accepts a list of targets that will be used to make
+// load balancing decisions. This method should be called when there are
+// new targets discovered or existing targets are shutdown.
+func (allocator *perNodeAllocator) SetTargets(targets map[string]*target.Item) {
+	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetTargets", perNodeStrategyName))
+	defer timer.ObserveDuration()
+
+	if allocator.filter != nil {
+		targets = allocator.filter.Apply(targets)
+	}
+	RecordTargetsKept(targets)
+
+	allocator.m.Lock()
+	defer allocator.m.Unlock()
+
+	// Check for target changes
+	targetsDiff := diff.Maps(allocator.targetItems, targets)
+	// If there are any additions or removals
+	if len(targetsDiff.Additions()) != 0 || len(targetsDiff.Removals()) != 0 {
+		allocator.handleTargets(targetsDiff)
+		allocator.recordTargetsUnassigned()
+	}
+}
+
+// SetCollectors sets the set of collectors with key=collectorName, value=Collector object.
+// This method is called when Collectors are added, removed or scheduled.
+func (allocator *perNodeAllocator) SetCollectors(collectors map[string]*Collector) {
+	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("SetCollectors", perNodeStrategyName))
+	defer timer.ObserveDuration()
+
+	CollectorsAllocatable.WithLabelValues(perNodeStrategyName).Set(float64(len(collectors)))
+	if len(collectors) == 0 {
+		allocator.log.Info("No collector instances present")
+	}
+
+	allocator.m.Lock()
+	defer allocator.m.Unlock()
+
+	if allocator.handleCollectors(collectors) {
+		allocator.recordTargetsUnassigned()
+	}
+}
+
+func newPerNodeAllocator(log logr.Logger, opts ...AllocationOption) Allocator {
+	pnAllocator := &perNodeAllocator{
+		log:                           log,
+		collectors:                    make(map[string]*Collector),
+		targetItems:                   make(map[string]*target.Item),
+		targetItemsPerJobPerCollector: make(map[string]map[string]map[string]bool),
+	}
+
+	for _, opt := range opts {
+		opt(pnAllocator)
+	}
+
+	return pnAllocator
+}
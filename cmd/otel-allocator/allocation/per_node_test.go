@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestPerNodeAllocation(t *testing.T) {
+	// prepare
+	s, _ := New("per-node", logger)
+	s.SetCollectors(map[string]*Collector{
+		"collector-0": NewCollector("collector-0", "node-0"),
+		"collector-1": NewCollector("collector-1", "node-1"),
+	})
+	endpoint := target.NewItem("test-job", "10.0.0.1:8080", model.LabelSet{"__meta_kubernetes_endpoint_node_name": "node-0"}, "")
+	pod := target.NewItem("test-job", "10.0.1.1:8080", model.LabelSet{"__meta_kubernetes_pod_node_name": "node-1"}, "")
+	node := target.NewItem("test-job", "10.0.2.1:10250", model.LabelSet{"__meta_kubernetes_node_name": "node-2"}, "")
+	service := target.NewItem("test-job", "my-service:8080", model.LabelSet{}, "")
+
+	// test
+	s.SetTargets(map[string]*target.Item{
+		endpoint.Hash(): endpoint,
+		pod.Hash():      pod,
+		node.Hash():     node,
+		service.Hash():  service,
+	})
+
+	// verify
+	assert.Len(t, s.TargetItems(), 4)
+	assert.Equal(t, []*target.Item{endpoint}, s.GetTargetsForCollectorAndJob("collector-0", "test-job"))
+	assert.Equal(t, []*target.Item{pod}, s.GetTargetsForCollectorAndJob("collector-1", "test-job"))
+	assert.Equal(t, "", node.CollectorName)
+	assert.Equal(t, "", service.CollectorName)
+	assert.Equal(t, 1, s.Collectors()["collector-0"].NumTargets)
+}
+
+func TestPerNodeCollectorChanges(t *testing.T) {
+	// prepare
+	s, _ := New("per-node", logger)
+	item := target.NewItem("test-job", "10.0.0.1:8080", model.LabelSet{"__meta_kubernetes_endpoint_node_name": "node-0"}, "")
+	s.SetTargets(map[string]*target.Item{item.Hash(): item})
+
+	t.Run("should not assign the targets to collectors not scheduled yet", func(t *testing.T) {
+		s.SetCollectors(map[string]*Collector{"collector-0": NewCollector("collector-0", "")})
+
+		assert.Empty(t, s.Collectors())
+		assert.Equal(t, "", item.CollectorName)
+	})
+
+	t.Run("should assign the targets once the collector runs on their node", func(t *testing.T) {
+		s.SetCollectors(map[string]*Collector{"collector-0": NewCollector("collector-0", "node-0")})
+
+		assert.Len(t, s.Collectors(), 1)
+		assert.Equal(t, []*target.Item{item}, s.GetTargetsForCollectorAndJob("collector-0", "test-job"))
+	})
+
+	t.Run("should reassign the targets to the collector replacing the removed one", func(t *testing.T) {
+		s.SetCollectors(map[string]*Collector{"collector-1": NewCollector("collector-1", "node-0")})
+
+		assert.Empty(t, s.GetTargetsForCollectorAndJob("collector-0", "test-job"))
+		assert.Equal(t, []*target.Item{item}, s.GetTargetsForCollectorAndJob("collector-1", "test-job"))
+		assert.Equal(t, 1, s.Collectors()["collector-1"].NumTargets)
+	})
+
+	t.Run("should unassign the removed targets", func(t *testing.T) {
+		s.SetTargets(map[string]*target.Item{})
+
+		assert.Empty(t, s.TargetItems())
+		assert.Empty(t, s.GetTargetsForCollectorAndJob("collector-1", "test-job"))
+		assert.Equal(t, 0, s.Collectors()["collector-1"].NumTargets)
+	})
+}
+
+func TestPerNodeSameAssignmentsOnAllReplicas(t *testing.T) {
+	// prepare
+	item := target.NewItem("test-job", "10.0.0.1:8080", model.LabelSet{"__meta_kubernetes_endpoint_node_name": "node-0"}, "")
+	collectors := map[string]*Collector{
+		"collector-a": NewCollector("collector-a", "node-0"),
+		"collector-b": NewCollector("collector-b", "node-0"),
+	}
+
+	for i := 0; i < 10; i++ {
+		s, _ := New("per-node", logger)
+
+		// test
+		s.SetCollectors(collectors)
+		s.SetTargets(map[string]*target.Item{item.Hash(): item})
+
+		// verify
+		assert.Equal(t, []*target.Item{item}, s.GetTargetsForCollectorAndJob("collector-a", "test-job"))
+		assert.Empty(t, s.GetTargetsForCollectorAndJob("collector-b", "test-job"))
+	}
+}
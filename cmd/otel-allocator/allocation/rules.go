@@ -0,0 +1,351 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+const (
+	// RuleActionAllocate allocates the targets of the jobs of a rule, the default action.
+	RuleActionAllocate = "allocate"
+	// RuleActionDrop drops the targets of the jobs of a rule, which aren't scraped.
+	RuleActionDrop = "drop"
+
+	// defaultRuleName is the rule reported for the targets of the jobs matched by no rule.
+	defaultRuleName = "default"
+)
+
+var (
+	// TargetsPerRule records how many targets the jobs of each rule have, including the dropped ones.
+	TargetsPerRule = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_targets_per_rule",
+		Help: "The number of targets of the jobs of each allocation rule.",
+	}, []string{"rule", "action"})
+	// CollectorsPerRule records how many collectors the targets of each rule are allocated to.
+	CollectorsPerRule = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_collectors_per_rule",
+		Help: "The number of collectors the targets of each allocation rule are allocated to.",
+	}, []string{"rule"})
+)
+
+// Rule overrides the allocation of the targets of the jobs it matches.
+type Rule struct {
+	Name string
+	// Jobs match the whole names of the jobs of the rule.
+	Jobs []*regexp.Regexp
+	// Drop drops the targets of the jobs in place of allocating them.
+	Drop bool
+	// Strategy allocates the targets of the jobs, the default strategy when empty.
+	Strategy string
+	// Collectors match the whole names of the collectors dedicated to the rule, which don't get the targets of the
+	// other jobs. The targets are allocated to the collectors dedicated to no rule when nil.
+	Collectors *regexp.Regexp
+}
+
+// NewRule creates the rule with the given action, allocate when empty, from the patterns of the names of its jobs
+// and collectors.
+func NewRule(name string, jobs []string, action, strategy, collectors string) (Rule, error) {
+	rule := Rule{Name: name, Strategy: strategy}
+	if name == "" || name == defaultRuleName {
+		return Rule{}, fmt.Errorf("the name of the allocation rule can't be empty or %s", defaultRuleName)
+	}
+	if len(jobs) == 0 {
+		return Rule{}, fmt.Errorf("the allocation rule %s matches no job", name)
+	}
+	for _, job := range jobs {
+		re, err := regexp.Compile("^(?:" + job + ")$")
+		if err != nil {
+			return Rule{}, fmt.Errorf("the job %s of the allocation rule %s is invalid: %w", job, name, err)
+		}
+		rule.Jobs = append(rule.Jobs, re)
+	}
+
+	switch action {
+	case "", RuleActionAllocate:
+	case RuleActionDrop:
+		if strategy != "" || collectors != "" {
+			return Rule{}, fmt.Errorf("the allocation rule %s drops the targets, it can't set a strategy or collectors", name)
+		}
+		rule.Drop = true
+		return rule, nil
+	default:
+		return Rule{}, fmt.Errorf("the action %s of the allocation rule %s must be %s or %s", action, name, RuleActionAllocate, RuleActionDrop)
+	}
+	if strategy != "" {
+		if _, ok := registry[strategy]; !ok {
+			return Rule{}, fmt.Errorf("the allocation rule %s has the unregistered strategy %s", name, strategy)
+		}
+	}
+	if collectors != "" {
+		re, err := regexp.Compile("^(?:" + collectors + ")$")
+		if err != nil {
+			return Rule{}, fmt.Errorf("the collectors %s of the allocation rule %s are invalid: %w", collectors, name, err)
+		}
+		rule.Collectors = re
+	}
+	if rule.Strategy == "" && rule.Collectors == nil {
+		return Rule{}, fmt.Errorf("the allocation rule %s doesn't change the allocation, it has to set a strategy, collectors or the %s action", name, RuleActionDrop)
+	}
+	return rule, nil
+}
+
+// matches returns whether the rule applies to the targets of the job.
+func (r Rule) matches(job string) bool {
+	for _, re := range r.Jobs {
+		if re.MatchString(job) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ Allocator = &rulesAllocator{}
+
+// rulesAllocator allocates the targets of the jobs matched by a rule with the strategy of the rule, among the
+// collectors dedicated to it. The rules sharing their strategy and collectors share a pool, allocating their targets
+// together, and the targets of the other jobs are allocated by the default pool. The rules and pools are fixed, the
+// pools synchronize their own state.
+type rulesAllocator struct {
+	rules []Rule
+	// rulePools are the indexes of the pools of the rules, -1 for the rules dropping their targets
+	rulePools []int
+	// pools allocate the targets, the default pool is the last one
+	pools []pool
+
+	log logr.Logger
+
+	filter Filter
+}
+
+// pool allocates the targets of its rules among the collectors matched by its pattern.
+type pool struct {
+	collectors *regexp.Regexp
+	strategy   string
+	allocator  Allocator
+	rules      []string
+}
+
+// NewWithRules creates an allocator applying the rules to the targets of the jobs they match, the first matching rule
+// applying. The targets of the other jobs are allocated with the named strategy.
+func NewWithRules(name string, rules []Rule, log logr.Logger, opts ...AllocationOption) (Allocator, error) {
+	if _, ok := registry[name]; !ok {
+		return nil, fmt.Errorf("unregistered strategy: %s", name)
+	}
+	allocator := &rulesAllocator{
+		rules:     rules,
+		rulePools: make([]int, len(rules)),
+		log:       log,
+	}
+	names := map[string]bool{}
+	for i, rule := range rules {
+		if names[rule.Name] {
+			return nil, fmt.Errorf("the allocation rule %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+		allocator.rulePools[i] = -1
+		if rule.Drop {
+			continue
+		}
+		strategy := rule.Strategy
+		if strategy == "" {
+			strategy = name
+		}
+		allocator.rulePools[i] = allocator.addPool(rule.Collectors, strategy, rule.Name)
+	}
+	allocator.addPool(nil, name, defaultRuleName)
+
+	for _, opt := range opts {
+		opt(allocator)
+	}
+	return allocator, nil
+}
+
+// addPool returns the index of the pool of the collectors and strategy, which is created when needed.
+func (r *rulesAllocator) addPool(collectors *regexp.Regexp, strategy, rule string) int {
+	for i, p := range r.pools {
+		if p.strategy == strategy && sameCollectors(p.collectors, collectors) {
+			r.pools[i].rules = append(r.pools[i].rules, rule)
+			return i
+		}
+	}
+	// the pools don't filter the targets, the rules allocator filters them before partitioning them
+	allocator, _ := New(strategy, r.log.WithValues("rule", rule))
+	r.pools = append(r.pools, pool{collectors: collectors, strategy: strategy, allocator: allocator, rules: []string{rule}})
+	return len(r.pools) - 1
+}
+
+// sameCollectors returns whether the patterns match the same collectors, nil matching the collectors of the
+// default pool.
+func sameCollectors(a, b *regexp.Regexp) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// SetFilter sets the filtering hook to use.
+func (r *rulesAllocator) SetFilter(filter Filter) {
+	r.filter = filter
+}
+
+// rule returns the index of the first rule matching the job, -1 when no rule matches it.
+func (r *rulesAllocator) rule(job string) int {
+	for i, rule := range r.rules {
+		if rule.matches(job) {
+			return i
+		}
+	}
+	return -1
+}
+
+// poolOf returns the pool allocating the targets of the job, nil when they're dropped.
+func (r *rulesAllocator) poolOf(job string) *pool {
+	i := r.rule(job)
+	if i < 0 {
+		return &r.pools[len(r.pools)-1]
+	}
+	if r.rulePools[i] < 0 {
+		return nil
+	}
+	return &r.pools[r.rulePools[i]]
+}
+
+// SetTargets partitions the targets among the pools of their rules, dropping the targets of the dropping rules.
+func (r *rulesAllocator) SetTargets(targets map[string]*target.Item) {
+	if r.filter != nil {
+		targets = r.filter.Apply(targets)
+	}
+
+	partitions := make([]map[string]*target.Item, len(r.pools))
+	for i := range partitions {
+		partitions[i] = map[string]*target.Item{}
+	}
+	perRule := map[string]int{defaultRuleName: 0}
+	for _, rule := range r.rules {
+		perRule[rule.Name] = 0
+	}
+	allocatable := 0
+	for hash, item := range targets {
+		i := r.rule(item.JobName)
+		switch {
+		case i < 0:
+			perRule[defaultRuleName]++
+			partitions[len(r.pools)-1][hash] = item
+		case r.rulePools[i] < 0:
+			perRule[r.rules[i].Name]++
+			continue
+		default:
+			perRule[r.rules[i].Name]++
+			partitions[r.rulePools[i]][hash] = item
+		}
+		allocatable++
+	}
+
+	for i, p := range r.pools {
+		p.allocator.SetTargets(partitions[i])
+	}
+	// each pool records its own targets, the allocatable targets are the ones of all the pools
+	TargetsAllocatable.Set(float64(allocatable))
+	for _, rule := range r.rules {
+		action := RuleActionAllocate
+		if rule.Drop {
+			action = RuleActionDrop
+		}
+		TargetsPerRule.WithLabelValues(rule.Name, action).Set(float64(perRule[rule.Name]))
+	}
+	TargetsPerRule.WithLabelValues(defaultRuleName, RuleActionAllocate).Set(float64(perRule[defaultRuleName]))
+}
+
+// SetCollectors sets the collectors of the pools, a collector being dedicated to the first rule matching it, or
+// being a collector of the default pool.
+func (r *rulesAllocator) SetCollectors(collectors map[string]*Collector) {
+	partitions := make([]map[string]*Collector, len(r.pools))
+	for i := range partitions {
+		partitions[i] = map[string]*Collector{}
+	}
+	for name, collector := range collectors {
+		var dedicated *regexp.Regexp
+		for i, rule := range r.rules {
+			if r.rulePools[i] >= 0 && rule.Collectors != nil && rule.Collectors.MatchString(name) {
+				dedicated = rule.Collectors
+				break
+			}
+		}
+		for i, p := range r.pools {
+			if sameCollectors(p.collectors, dedicated) {
+				partitions[i][name] = collector
+			}
+		}
+	}
+
+	perStrategy := map[string]int{}
+	for i, p := range r.pools {
+		if len(partitions[i]) == 0 {
+			r.log.Info("No collector instances present for the allocation rules", "rules", p.rules)
+		}
+		p.allocator.SetCollectors(partitions[i])
+		perStrategy[p.strategy] += len(partitions[i])
+		for _, rule := range p.rules {
+			CollectorsPerRule.WithLabelValues(rule).Set(float64(len(partitions[i])))
+		}
+	}
+	// the pools of a strategy record their own collectors, the allocatable collectors are the ones of all its pools
+	for strategy, count := range perStrategy {
+		CollectorsAllocatable.WithLabelValues(strategy).Set(float64(count))
+	}
+}
+
+// GetTargetsForCollectorAndJob returns the targets of the job allocated to the collector by the pool of the job.
+func (r *rulesAllocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
+	p := r.poolOf(job)
+	if p == nil {
+		return []*target.Item{}
+	}
+	return p.allocator.GetTargetsForCollectorAndJob(collector, job)
+}
+
+// TargetItems returns the targets of all the pools.
+func (r *rulesAllocator) TargetItems() map[string]*target.Item {
+	targetItems := make(map[string]*target.Item)
+	for _, p := range r.pools {
+		for k, v := range p.allocator.TargetItems() {
+			targetItems[k] = v
+		}
+	}
+	return targetItems
+}
+
+// Collectors returns the collectors of all the pools, with the number of targets allocated to them by all the pools.
+func (r *rulesAllocator) Collectors() map[string]*Collector {
+	collectors := make(map[string]*Collector)
+	for _, p := range r.pools {
+		for name, c := range p.allocator.Collectors() {
+			if existing, ok := collectors[name]; ok {
+				existing.NumTargets += c.NumTargets
+				continue
+			}
+			collectors[name] = &Collector{Name: c.Name, NodeName: c.NodeName, NumTargets: c.NumTargets}
+		}
+	}
+	return collectors
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func makeJobTargets(job string, n int) map[string]*target.Item {
+	targets := map[string]*target.Item{}
+	for i := 0; i < n; i++ {
+		item := target.NewItem(job, fmt.Sprintf("%s-%d:8080", job, i), model.LabelSet{"i": model.LabelValue(strconv.Itoa(i))}, "")
+		targets[item.Hash()] = item
+	}
+	return targets
+}
+
+func TestRulesAllocation(t *testing.T) {
+	// prepare
+	federation, err := NewRule("federation", []string{"federate-.*"}, "", consistentHashingStrategyName, "collector-[01]")
+	require.NoError(t, err)
+	probes, err := NewRule("probes", []string{"blackbox"}, RuleActionDrop, "", "")
+	require.NoError(t, err)
+	a, err := NewWithRules(leastWeightedStrategyName, []Rule{federation, probes}, logger)
+	require.NoError(t, err)
+	a.SetCollectors(MakeNCollectors(4, 0))
+
+	targets := makeJobTargets("federate-eu", 6)
+	for job, n := range map[string]int{"federate-us": 4, "blackbox": 3, "kubelet": 8} {
+		for hash, item := range makeJobTargets(job, n) {
+			targets[hash] = item
+		}
+	}
+
+	// test
+	a.SetTargets(targets)
+
+	// verify
+	items := a.TargetItems()
+	assert.Len(t, items, 18)
+	for _, item := range items {
+		switch item.JobName {
+		case "federate-eu", "federate-us":
+			assert.Contains(t, []string{"collector-0", "collector-1"}, item.CollectorName)
+		case "kubelet":
+			assert.Contains(t, []string{"collector-2", "collector-3"}, item.CollectorName)
+		default:
+			assert.Failf(t, "the target should be dropped", "job %s", item.JobName)
+		}
+	}
+	assert.Len(t, a.GetTargetsForCollectorAndJob("collector-2", "kubelet"), 4)
+	assert.Empty(t, a.GetTargetsForCollectorAndJob("collector-2", "blackbox"))
+	assert.Empty(t, a.GetTargetsForCollectorAndJob("collector-2", "federate-eu"))
+
+	collectors := a.Collectors()
+	assert.Len(t, collectors, 4)
+	assert.Equal(t, 10, collectors["collector-0"].NumTargets+collectors["collector-1"].NumTargets)
+	assert.Equal(t, 4, collectors["collector-3"].NumTargets)
+
+	assert.Equal(t, float64(18), testutil.ToFloat64(TargetsAllocatable))
+	assert.Equal(t, float64(10), testutil.ToFloat64(TargetsPerRule.WithLabelValues("federation", RuleActionAllocate)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(TargetsPerRule.WithLabelValues("probes", RuleActionDrop)))
+	assert.Equal(t, float64(8), testutil.ToFloat64(TargetsPerRule.WithLabelValues(defaultRuleName, RuleActionAllocate)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(CollectorsPerRule.WithLabelValues("federation")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(CollectorsPerRule.WithLabelValues(defaultRuleName)))
+}
+
+func TestRulesSharingCollectors(t *testing.T) {
+	// prepare
+	federation, err := NewRule("federation", []string{"federate"}, "", consistentHashingStrategyName, "")
+	require.NoError(t, err)
+	a, err := NewWithRules(leastWeightedStrategyName, []Rule{federation}, logger)
+	require.NoError(t, err)
+	a.SetCollectors(MakeNCollectors(2, 0))
+	targets := makeJobTargets("federate", 4)
+	for hash, item := range makeJobTargets("kubelet", 4) {
+		targets[hash] = item
+	}
+
+	// test
+	a.SetTargets(targets)
+
+	// verify
+	collectors := a.Collectors()
+	assert.Len(t, collectors, 2)
+	assert.Equal(t, 8, collectors["collector-0"].NumTargets+collectors["collector-1"].NumTargets)
+	assert.Len(t, a.GetTargetsForCollectorAndJob("collector-0", "kubelet"), 2)
+	assert.Equal(t, float64(2), testutil.ToFloat64(CollectorsPerRule.WithLabelValues("federation")))
+}
+
+func TestNewRule(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		rule       string
+		jobs       []string
+		action     string
+		strategy   string
+		collectors string
+		err        string
+	}{
+		{name: "strategy", rule: "federation", jobs: []string{"federate"}, strategy: consistentHashingStrategyName},
+		{name: "collectors", rule: "federation", jobs: []string{"federate"}, collectors: "collector-[01]"},
+		{name: "drop", rule: "probes", jobs: []string{"blackbox"}, action: RuleActionDrop},
+		{name: "default name", rule: "default", jobs: []string{"federate"}, strategy: consistentHashingStrategyName,
+			err: "the name of the allocation rule can't be empty or default"},
+		{name: "no job", rule: "federation", strategy: consistentHashingStrategyName, err: "the allocation rule federation matches no job"},
+		{name: "invalid job", rule: "federation", jobs: []string{"federate-("}, strategy: consistentHashingStrategyName,
+			err: "the job federate-( of the allocation rule federation is invalid"},
+		{name: "invalid collectors", rule: "federation", jobs: []string{"federate"}, collectors: "collector-[",
+			err: "the collectors collector-[ of the allocation rule federation are invalid"},
+		{name: "unknown action", rule: "federation", jobs: []string{"federate"}, action: "keep",
+			err: "the action keep of the allocation rule federation must be allocate or drop"},
+		{name: "drop with strategy", rule: "probes", jobs: []string{"blackbox"}, action: RuleActionDrop, strategy: consistentHashingStrategyName,
+			err: "the allocation rule probes drops the targets, it can't set a strategy or collectors"},
+		{name: "unknown strategy", rule: "federation", jobs: []string{"federate"}, strategy: "round-robin",
+			err: "the allocation rule federation has the unregistered strategy round-robin"},
+		{name: "no change", rule: "federation", jobs: []string{"federate"},
+			err: "the allocation rule federation doesn't change the allocation, it has to set a strategy, collectors or the drop action"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewRule(tt.rule, tt.jobs, tt.action, tt.strategy, tt.collectors)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, rule.matches(tt.jobs[0]))
+			assert.False(t, rule.matches(tt.jobs[0]+"-other"))
+		})
+	}
+}
+
+func TestNewWithRulesDuplicateNames(t *testing.T) {
+	rule, err := NewRule("federation", []string{"federate"}, "", consistentHashingStrategyName, "")
+	require.NoError(t, err)
+
+	_, err = NewWithRules(leastWeightedStrategyName, []Rule{rule, rule}, logger)
+
+	assert.EqualError(t, err, "the allocation rule federation is defined twice")
+}
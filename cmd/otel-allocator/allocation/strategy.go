@@ -49,6 +49,11 @@ var (
 		Name: "opentelemetry_allocator_targets_remaining",
 		Help: "Number of targets kept after filtering.",
 	})
+	// TargetsAllocatable records the total scrape load, the operator scales the collectors on it.
+	TargetsAllocatable = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_targets_allocatable",
+		Help: "Number of targets the allocator distributes among the collectors.",
+	})
 )
 
 type AllocationOption func(Allocator)
@@ -65,6 +70,7 @@ func WithFilter(filter Filter) AllocationOption {
 
 func RecordTargetsKept(targets map[string]*target.Item) {
 	targetsRemaining.Add(float64(len(targets)))
+	TargetsAllocatable.Set(float64(len(targets)))
 }
 
 func New(name string, log logr.Logger, opts ...AllocationOption) (Allocator, error) {
@@ -106,19 +112,21 @@ var _ consistent.Member = Collector{}
 // This struct can be extended with information like annotations and labels in the future.
 type Collector struct {
 	Name       string
+	NodeName   string
 	NumTargets int
 }
 
+// Hash includes the node name, so that the collectors scheduled after they were first seen are updated.
 func (c Collector) Hash() string {
-	return c.Name
+	return c.Name + c.NodeName
 }
 
 func (c Collector) String() string {
 	return c.Name
 }
 
-func NewCollector(name string) *Collector {
-	return &Collector{Name: name}
+func NewCollector(name, node string) *Collector {
+	return &Collector{Name: name, NodeName: node}
 }
 
 func init() {
@@ -130,4 +138,8 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	err = Register(perNodeStrategyName, newPerNodeAllocator)
+	if err != nil {
+		panic(err)
+	}
 }
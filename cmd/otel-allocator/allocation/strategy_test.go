@@ -19,6 +19,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/diff"
 )
 
@@ -89,11 +92,11 @@ func Benchmark_Setting(b *testing.B) {
 }
 
 func TestCollectorDiff(t *testing.T) {
-	collector0 := NewCollector("collector-0")
-	collector1 := NewCollector("collector-1")
-	collector2 := NewCollector("collector-2")
-	collector3 := NewCollector("collector-3")
-	collector4 := NewCollector("collector-4")
+	collector0 := NewCollector("collector-0", "")
+	collector1 := NewCollector("collector-1", "")
+	collector2 := NewCollector("collector-2", "")
+	collector3 := NewCollector("collector-3", "")
+	collector4 := NewCollector("collector-4", "")
 	type args struct {
 		current map[string]*Collector
 		new     map[string]*Collector
@@ -134,3 +137,19 @@ func TestCollectorDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetsAllocatable(t *testing.T) {
+	for _, s := range GetRegisteredAllocatorNames() {
+		t.Run(s, func(t *testing.T) {
+			a, err := New(s, logger)
+			assert.NoError(t, err)
+			a.SetCollectors(MakeNCollectors(3, 0))
+
+			a.SetTargets(MakeNNewTargets(12, 3, 0))
+			assert.Equal(t, float64(12), testutil.ToFloat64(TargetsAllocatable))
+
+			a.SetTargets(MakeNNewTargets(5, 3, 0))
+			assert.Equal(t, float64(5), testutil.ToFloat64(TargetsAllocatable))
+		})
+	}
+}
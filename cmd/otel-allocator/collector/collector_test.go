@@ -136,6 +136,29 @@ func Test_runWatch(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod scheduled",
+			args: args{
+				kubeFn: func(t *testing.T, client Client, group *sync.WaitGroup) {
+					p := pod("test-pod1")
+					p.Spec.NodeName = "node-1"
+					group.Add(1)
+					_, err := client.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), p, metav1.UpdateOptions{})
+					assert.NoError(t, err)
+				},
+				collectorMap: map[string]*allocation.Collector{
+					"test-pod1": {
+						Name: "test-pod1",
+					},
+				},
+			},
+			want: map[string]*allocation.Collector{
+				"test-pod1": {
+					Name:     "test-pod1",
+					NodeName: "node-1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
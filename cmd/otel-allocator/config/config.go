@@ -46,6 +46,24 @@ type Config struct {
 	FilterStrategy         *string            `yaml:"filter_strategy,omitempty"`
 	PodMonitorSelector     map[string]string  `yaml:"pod_monitor_selector,omitempty"`
 	ServiceMonitorSelector map[string]string  `yaml:"service_monitor_selector,omitempty"`
+	// PodMonitorNamespaceSelector and ServiceMonitorNamespaceSelector restrict the monitors to the namespaces
+	// matching the labels, an empty selector selects all the namespaces, and only the namespace of the target
+	// allocator is selected when unset.
+	PodMonitorNamespaceSelector     map[string]string `yaml:"pod_monitor_namespace_selector,omitempty"`
+	ServiceMonitorNamespaceSelector map[string]string `yaml:"service_monitor_namespace_selector,omitempty"`
+	// AllocationRules override the allocation of the targets of the jobs they match, the first matching rule applying.
+	AllocationRules []AllocationRule `yaml:"allocation_rules,omitempty"`
+}
+
+// AllocationRule overrides the allocation of the targets of the jobs matching one of the Jobs patterns. The targets
+// are dropped with the drop action, or allocated with the AllocationStrategy among the collectors matching the
+// Collectors pattern, which are dedicated to the rule.
+type AllocationRule struct {
+	Name               string   `yaml:"name"`
+	Jobs               []string `yaml:"jobs"`
+	Action             string   `yaml:"action,omitempty"`
+	AllocationStrategy string   `yaml:"allocation_strategy,omitempty"`
+	Collectors         string   `yaml:"collectors,omitempty"`
 }
 
 func (c Config) GetAllocationStrategy() string {
@@ -66,9 +84,22 @@ type PrometheusCRWatcherConfig struct {
 	Enabled *bool
 }
 
+// TLSConfig holds the paths of the certificates securing the server with mutual TLS.
+type TLSConfig struct {
+	CertFile     *string
+	KeyFile      *string
+	ClientCAFile *string
+}
+
+// Enabled returns whether the server has a certificate to serve over TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != nil && *c.CertFile != ""
+}
+
 type CLIConfig struct {
 	ListenAddr     *string
 	ConfigFilePath *string
+	TLS            TLSConfig
 	ClusterConfig  *rest.Config
 	// KubeConfigFilePath empty if in cluster configuration is in use
 	KubeConfigFilePath string
@@ -105,6 +136,11 @@ func ParseCLI() (CLIConfig, error) {
 		PromCRWatcherConf: PrometheusCRWatcherConfig{
 			Enabled: pflag.Bool("enable-prometheus-cr-watcher", false, "Enable Prometheus CRs as target sources"),
 		},
+		TLS: TLSConfig{
+			CertFile:     pflag.String("tls-cert-file", "", "The path to the server certificate. When set, the server only serves over TLS."),
+			KeyFile:      pflag.String("tls-key-file", "", "The path to the private key of the server certificate."),
+			ClientCAFile: pflag.String("tls-client-ca-file", "", "The path to the CA certificate verifying the client certificates. When set, clients must present a certificate."),
+		},
 	}
 	kubeconfigPath := pflag.String("kubeconfig-path", filepath.Join(homedir.HomeDir(), ".kube", "config"), "absolute path to the KubeconfigPath file")
 	pflag.Parse()
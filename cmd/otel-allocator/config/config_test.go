@@ -29,6 +29,7 @@ import (
 )
 
 func TestLoad(t *testing.T) {
+	leastWeighted := "least-weighted"
 	type args struct {
 		file string
 	}
@@ -143,6 +144,70 @@ func TestLoad(t *testing.T) {
 				ServiceMonitorSelector: map[string]string{
 					"release": "test",
 				},
+				PodMonitorNamespaceSelector: map[string]string{
+					"team": "test",
+				},
+				ServiceMonitorNamespaceSelector: map[string]string{
+					"team": "test",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "allocation rules",
+			args: args{
+				file: "./testdata/allocation_rules_test.yaml",
+			},
+			want: Config{
+				LabelSelector: map[string]string{
+					"app.kubernetes.io/instance":   "default.test",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				},
+				AllocationStrategy: &leastWeighted,
+				AllocationRules: []AllocationRule{
+					{
+						Name:               "federation",
+						Jobs:               []string{"federate-.*"},
+						AllocationStrategy: "consistent-hashing",
+						Collectors:         "test-collector-[01]",
+					},
+					{
+						Name:   "probes",
+						Jobs:   []string{"blackbox"},
+						Action: "drop",
+					},
+				},
+				Config: &promconfig.Config{
+					GlobalConfig: promconfig.GlobalConfig{
+						ScrapeInterval:     model.Duration(60 * time.Second),
+						ScrapeTimeout:      model.Duration(10 * time.Second),
+						EvaluationInterval: model.Duration(60 * time.Second),
+					},
+					ScrapeConfigs: []*promconfig.ScrapeConfig{
+						{
+							JobName:         "prometheus",
+							HonorTimestamps: true,
+							ScrapeInterval:  model.Duration(60 * time.Second),
+							ScrapeTimeout:   model.Duration(10 * time.Second),
+							MetricsPath:     "/metrics",
+							Scheme:          "http",
+							HTTPClientConfig: commonconfig.HTTPClientConfig{
+								FollowRedirects: true,
+								EnableHTTP2:     true,
+							},
+							ServiceDiscoveryConfigs: []discovery.Config{
+								discovery.StaticConfig{
+									{
+										Targets: []model.LabelSet{
+											{model.AddressLabel: "prom.domain:9001"},
+										},
+										Source: "0",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 			wantErr: assert.NoError,
 		},
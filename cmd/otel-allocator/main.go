@@ -21,6 +21,7 @@ import (
 	"syscall"
 
 	gokitlog "github.com/go-kit/log"
+	"github.com/go-logr/logr"
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -79,7 +80,7 @@ func main() {
 	log := ctrl.Log.WithName("allocator")
 
 	allocatorPrehook = prehook.New(cfg.GetTargetsFilterStrategy(), log)
-	allocator, err = allocation.New(cfg.GetAllocationStrategy(), log, allocation.WithFilter(allocatorPrehook))
+	allocator, err = newAllocator(cfg, log, allocatorPrehook)
 	if err != nil {
 		setupLog.Error(err, "Unable to initialize allocation strategy")
 		os.Exit(1)
@@ -172,7 +173,12 @@ func main() {
 		})
 	runGroup.Add(
 		func() error {
-			err := srv.Start()
+			var err error
+			if cliConf.TLS.Enabled() {
+				err = srv.StartTLS(*cliConf.TLS.CertFile, *cliConf.TLS.KeyFile, *cliConf.TLS.ClientCAFile)
+			} else {
+				err = srv.Start()
+			}
 			setupLog.Info("Server failed to start")
 			return err
 		},
@@ -229,3 +235,19 @@ func main() {
 	}
 	setupLog.Info("Target allocator exited.")
 }
+
+// newAllocator creates the allocator of the allocation strategy, which applies the allocation rules when there are some.
+func newAllocator(cfg config.Config, log logr.Logger, filter prehook.Hook) (allocation.Allocator, error) {
+	if len(cfg.AllocationRules) == 0 {
+		return allocation.New(cfg.GetAllocationStrategy(), log, allocation.WithFilter(filter))
+	}
+	rules := make([]allocation.Rule, 0, len(cfg.AllocationRules))
+	for _, r := range cfg.AllocationRules {
+		rule, err := allocation.NewRule(r.Name, r.Jobs, r.Action, r.AllocationStrategy, r.Collectors)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return allocation.NewWithRules(cfg.GetAllocationStrategy(), rules, log, allocation.WithFilter(filter))
+}
@@ -16,6 +16,7 @@ package watcher
 
 import (
 	"fmt"
+	"os"
 
 	allocatorconfig "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 
@@ -30,6 +31,10 @@ import (
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -65,7 +70,7 @@ func NewPrometheusCRWatcher(cfg allocatorconfig.Config, cliConfig allocatorconfi
 
 	podMonSelector := getSelector(cfg.PodMonitorSelector)
 
-	return &PrometheusCRWatcher{
+	w := &PrometheusCRWatcher{
 		kubeMonitoringClient:   mClient,
 		informers:              monitoringInformers,
 		stopChannel:            make(chan struct{}),
@@ -73,7 +78,22 @@ func NewPrometheusCRWatcher(cfg allocatorconfig.Config, cliConfig allocatorconfi
 		kubeConfigPath:         cliConfig.KubeConfigFilePath,
 		serviceMonitorSelector: servMonSelector,
 		podMonitorSelector:     podMonSelector,
-	}, nil
+		namespace:              os.Getenv("OTELCOL_NAMESPACE"),
+	}
+
+	// the namespaces are only watched when they select the monitors, so that the ServiceAccount doesn't need to
+	// read them otherwise
+	if cfg.ServiceMonitorNamespaceSelector != nil || cfg.PodMonitorNamespaceSelector != nil {
+		kubeClient, err := kubernetes.NewForConfig(cliConfig.ClusterConfig)
+		if err != nil {
+			return nil, err
+		}
+		w.namespaceInformer = kubeinformers.NewSharedInformerFactory(kubeClient, allocatorconfig.DefaultResyncTime).Core().V1().Namespaces()
+		w.serviceMonitorNamespaceSelector = getNamespaceSelector(cfg.ServiceMonitorNamespaceSelector)
+		w.podMonitorNamespaceSelector = getNamespaceSelector(cfg.PodMonitorNamespaceSelector)
+	}
+
+	return w, nil
 }
 
 type PrometheusCRWatcher struct {
@@ -85,6 +105,13 @@ type PrometheusCRWatcher struct {
 
 	serviceMonitorSelector labels.Selector
 	podMonitorSelector     labels.Selector
+
+	// namespace is the namespace of the target allocator, the only one selected when no namespace selector is set
+	namespace string
+
+	namespaceInformer               coreinformers.NamespaceInformer
+	serviceMonitorNamespaceSelector labels.Selector
+	podMonitorNamespaceSelector     labels.Selector
 }
 
 func getSelector(s map[string]string) labels.Selector {
@@ -95,6 +122,28 @@ func getSelector(s map[string]string) labels.Selector {
 	return labels.SelectorFromSet(s)
 }
 
+// getNamespaceSelector returns nil when no namespace selector is set, an empty selector selecting all the namespaces.
+func getNamespaceSelector(s map[string]string) labels.Selector {
+	if s == nil {
+		return nil
+	}
+	return labels.SelectorFromSet(s)
+}
+
+// namespaceSelected returns whether the monitors of the namespace are selected. Like with the Prometheus Operator, only
+// the monitors of the namespace of the target allocator are selected without a selector.
+func namespaceSelected(lister corelisters.NamespaceLister, selector labels.Selector, ownNamespace, namespace string) bool {
+	if selector == nil {
+		// outside of a pod, the namespace of the target allocator is unknown
+		return ownNamespace == "" || namespace == ownNamespace
+	}
+	ns, err := lister.Get(namespace)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
 // Watch wrapped informers and wait for an initial sync.
 func (w *PrometheusCRWatcher) Watch(upstreamEvents chan Event, upstreamErrors chan error) error {
 	event := Event{
@@ -121,6 +170,25 @@ func (w *PrometheusCRWatcher) Watch(upstreamEvents chan Event, upstreamErrors ch
 			},
 		})
 	}
+	if w.namespaceInformer != nil {
+		informer := w.namespaceInformer.Informer()
+		go informer.Run(w.stopChannel)
+		if ok := cache.WaitForNamedCacheSync("namespace", w.stopChannel, informer.HasSynced); !ok {
+			success = false
+		}
+		// the label changes of the namespaces can select or deselect monitors
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				upstreamEvents <- event
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				upstreamEvents <- event
+			},
+			DeleteFunc: func(obj interface{}) {
+				upstreamEvents <- event
+			},
+		})
+	}
 	if !success {
 		return fmt.Errorf("failed to sync cache")
 	}
@@ -134,10 +202,18 @@ func (w *PrometheusCRWatcher) Close() error {
 }
 
 func (w *PrometheusCRWatcher) LoadConfig() (*promconfig.Config, error) {
+	var namespaces corelisters.NamespaceLister
+	if w.namespaceInformer != nil {
+		namespaces = w.namespaceInformer.Lister()
+	}
+
 	serviceMonitorInstances := make(map[string]*monitoringv1.ServiceMonitor)
 
 	smRetrieveErr := w.informers[monitoringv1.ServiceMonitorName].ListAll(w.serviceMonitorSelector, func(sm interface{}) {
 		monitor := sm.(*monitoringv1.ServiceMonitor)
+		if !namespaceSelected(namespaces, w.serviceMonitorNamespaceSelector, w.namespace, monitor.Namespace) {
+			return
+		}
 		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(monitor)
 		serviceMonitorInstances[key] = monitor
 	})
@@ -148,6 +224,9 @@ func (w *PrometheusCRWatcher) LoadConfig() (*promconfig.Config, error) {
 	podMonitorInstances := make(map[string]*monitoringv1.PodMonitor)
 	pmRetrieveErr := w.informers[monitoringv1.PodMonitorName].ListAll(w.podMonitorSelector, func(pm interface{}) {
 		monitor := pm.(*monitoringv1.PodMonitor)
+		if !namespaceSelected(namespaces, w.podMonitorNamespaceSelector, w.namespace, monitor.Namespace) {
+			return
+		}
 		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(monitor)
 		podMonitorInstances[key] = monitor
 	})
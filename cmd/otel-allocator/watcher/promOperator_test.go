@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNamespaceSelected(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+	} {
+		assert.NoError(t, indexer.Add(ns))
+	}
+	lister := corelisters.NewNamespaceLister(indexer)
+
+	tests := []struct {
+		name         string
+		selector     map[string]string
+		ownNamespace string
+		namespace    string
+		want         bool
+	}{
+		{name: "no selector", ownNamespace: "team-a", namespace: "team-a", want: true},
+		{name: "no selector and other namespace", ownNamespace: "team-a", namespace: "team-b", want: false},
+		{name: "no selector and unknown own namespace", namespace: "team-b", want: true},
+		{name: "empty selector", selector: map[string]string{}, ownNamespace: "team-a", namespace: "team-b", want: true},
+		{name: "matching namespace", selector: map[string]string{"team": "a"}, ownNamespace: "team-a", namespace: "team-a", want: true},
+		{name: "other namespace", selector: map[string]string{"team": "a"}, ownNamespace: "team-a", namespace: "team-b", want: false},
+		{name: "unknown namespace", selector: map[string]string{"team": "a"}, ownNamespace: "team-a", namespace: "unknown", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, namespaceSelected(lister, getNamespaceSelector(tt.selector), tt.ownNamespace, tt.namespace))
+		})
+	}
+}
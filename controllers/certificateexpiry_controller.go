@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	// defaultExpiryWarningDays is used when the instance doesn't set spec.tls.expiryWarningDays.
+	defaultExpiryWarningDays = 30
+
+	// certificateCheckInterval is how often the receiver certificates are checked.
+	certificateCheckInterval = 24 * time.Hour
+)
+
+// CertificateExpiryReconciler reports the receiver TLS certificates of the collectors that are about to expire.
+type CertificateExpiryReconciler struct {
+	client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+}
+
+// NewCertificateExpiryReconciler creates a new reconciler checking the receiver certificates of OpenTelemetryCollector objects.
+func NewCertificateExpiryReconciler(p Params) *CertificateExpiryReconciler {
+	return &CertificateExpiryReconciler{
+		Client:   p.Client,
+		log:      p.Log,
+		recorder: p.Recorder,
+	}
+}
+
+// Reconcile checks the certificates of the receivers read from the Secrets mounted in the collector.
+func (r *CertificateExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var otelcol v1alpha1.OpenTelemetryCollector
+	if err := r.Get(ctx, req.NamespacedName, &otelcol); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollector")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if v1alpha1.IsReconcilePaused(&otelcol) {
+		// the conditions of a paused instance are left as they are
+		return ctrl.Result{RequeueAfter: certificateCheckInterval}, nil
+	}
+	if otelcol.Spec.FederationRef != nil {
+		// the Secrets of a federated collector live in its target cluster
+		return ctrl.Result{}, nil
+	}
+
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		// the config errors are reported by the collector reconciliation
+		log.V(2).Info("failed to parse the config, skipping the certificate check", "error", err)
+		return ctrl.Result{}, nil
+	}
+	files := adapters.ConfigToReceiverCertFiles(cfg)
+	if len(files) == 0 {
+		return ctrl.Result{}, r.setCondition(ctx, otelcol, nil)
+	}
+
+	days := int32(defaultExpiryWarningDays)
+	if otelcol.Spec.TLS.ExpiryWarningDays != nil {
+		days = *otelcol.Spec.TLS.ExpiryWarningDays
+	}
+	now := time.Now()
+	threshold := now.Add(time.Duration(days) * 24 * time.Hour)
+
+	var expiring []string
+	for _, file := range files {
+		secretName, key, ok := collector.SecretKeyForFile(otelcol, file.Path)
+		if !ok {
+			log.V(2).Info("the certificate isn't mounted from a secret, skipping", "receiver", file.Receiver, "path", file.Path)
+			continue
+		}
+		cert, err := r.certificate(ctx, otelcol.Namespace, secretName, key)
+		if err != nil {
+			log.Error(err, "failed to read the receiver certificate", "receiver", file.Receiver, "secret", secretName)
+			continue
+		}
+		if cert.NotAfter.After(threshold) {
+			continue
+		}
+		verb := "expires"
+		if cert.NotAfter.Before(now) {
+			verb = "expired"
+		}
+		expiring = append(expiring, fmt.Sprintf("the certificate of receiver '%s' in secret %s (key %s) %s on %s",
+			file.Receiver, secretName, key, verb, cert.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	for _, message := range expiring {
+		r.recorder.Event(&otelcol, corev1.EventTypeWarning, "CertificateExpiringSoon", message)
+	}
+	if err := r.setCondition(ctx, otelcol, expiring); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: certificateCheckInterval}, nil
+}
+
+// certificate parses the first PEM encoded certificate of the Secret key, which is the leaf of a certificate chain.
+func (r *CertificateExpiryReconciler) certificate(ctx context.Context, namespace, name, key string) (*x509.Certificate, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("the secret %s has no key %s", name, key)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("the key %s of secret %s has no PEM encoded certificate", key, name)
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+func (r *CertificateExpiryReconciler) setCondition(ctx context.Context, otelcol v1alpha1.OpenTelemetryCollector, expiring []string) error {
+	existing := meta.FindStatusCondition(otelcol.Status.Conditions, v1alpha1.ConditionTypeCertificateExpiringSoon)
+	if len(expiring) == 0 && existing == nil {
+		return nil
+	}
+	message := strings.Join(expiring, ", ")
+	if existing != nil && existing.Message == message {
+		return nil
+	}
+
+	changed := otelcol.DeepCopy()
+	if len(expiring) == 0 {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeCertificateExpiringSoon)
+	} else {
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ConditionTypeCertificateExpiringSoon,
+			Status:  metav1.ConditionTrue,
+			Reason:  "CertificateExpiringSoon",
+			Message: message,
+		})
+	}
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&otelcol)); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+	}
+	return nil
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *CertificateExpiryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the status changes don't trigger a check, the certificates are checked again after certificateCheckInterval
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("certificateexpiry").
+		For(&v1alpha1.OpenTelemetryCollector{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+)
+
+func selfSignedCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "collector"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificateExpiryReconciliation(t *testing.T) {
+	// prepare
+	recorder := record.NewFakeRecorder(10)
+	reconciler := controllers.NewCertificateExpiryReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Recorder: recorder,
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "receiver-tls",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"tls.crt": selfSignedCertificate(t, time.Now().Add(10*24*time.Hour)),
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), secret))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), secret))
+	}()
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-tls-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+          key_file: /certs/tls.key
+exporters:
+  logging:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`,
+			VolumeMounts: []corev1.VolumeMount{{Name: "certs", MountPath: "/certs"}},
+			Volumes: []corev1.Volume{{
+				Name:         "certs",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret.Name}},
+			}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), otelcol))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), otelcol))
+	}()
+	nsn := types.NamespacedName{Name: otelcol.Name, Namespace: otelcol.Namespace}
+	req := k8sreconcile.Request{NamespacedName: nsn}
+
+	// test
+	result, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, result.RequeueAfter)
+	assert.Contains(t, <-recorder.Events, "CertificateExpiringSoon")
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, otelcol))
+	condition := meta.FindStatusCondition(otelcol.Status.Conditions, v1alpha1.ConditionTypeCertificateExpiringSoon)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "the certificate of receiver 'otlp' in secret receiver-tls (key tls.crt) expires on")
+
+	// the warning starts closer to the expiry
+	five := int32(5)
+	otelcol.Spec.TLS.ExpiryWarningDays = &five
+	require.NoError(t, k8sClient.Update(context.Background(), otelcol))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, otelcol))
+	assert.Nil(t, meta.FindStatusCondition(otelcol.Status.Conditions, v1alpha1.ConditionTypeCertificateExpiringSoon))
+	assert.Empty(t, recorder.Events)
+}
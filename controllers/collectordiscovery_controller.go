@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// CollectorDiscoveryAnnotation opts a namespace in for the discovery of its annotated Deployments.
+	CollectorDiscoveryAnnotation = "opentelemetry.io/collector-discovery"
+
+	// CreateCollectorAnnotation asks for an OpenTelemetryCollector to be created along with the Deployment.
+	CreateCollectorAnnotation = "opentelemetry.io/create-collector"
+)
+
+// DiscoveredCollectorConfig is the config of the collectors created for the annotated Deployments. It can be
+// changed in the created OpenTelemetryCollector, which isn't overwritten afterwards.
+const DiscoveredCollectorConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+processors:
+  batch:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+    logs:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+`
+
+// CollectorDiscoveryReconciler creates an OpenTelemetryCollector for each annotated Deployment of the opted-in namespaces.
+type CollectorDiscoveryReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+}
+
+// NewCollectorDiscoveryReconciler creates a new reconciler for the Deployments asking for a collector.
+func NewCollectorDiscoveryReconciler(p Params) *CollectorDiscoveryReconciler {
+	return &CollectorDiscoveryReconciler{
+		Client: p.Client,
+		log:    p.Log,
+		scheme: p.Scheme,
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=create;delete
+
+// Reconcile creates the OpenTelemetryCollector of the Deployment, or deletes it when the Deployment or its namespace
+// isn't annotated anymore. The collectors of the deleted Deployments are garbage collected.
+func (r *CollectorDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("deployment", req.NamespacedName)
+
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, req.NamespacedName, &deployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Deployment")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: deployment.Namespace}, &ns); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get the namespace: %w", err)
+	}
+	requested := ns.Annotations[CollectorDiscoveryAnnotation] == "true" && deployment.Annotations[CreateCollectorAnnotation] == "true"
+
+	existing := &v1alpha1.OpenTelemetryCollector{}
+	err := r.Get(ctx, req.NamespacedName, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get: %w", err)
+	}
+	found := err == nil
+
+	switch {
+	case found && !metav1.IsControlledBy(existing, &deployment):
+		if requested {
+			log.Info("an OpenTelemetryCollector with the name of the deployment already exists, skipping")
+		}
+	case found && v1alpha1.IsReconcilePaused(existing):
+		log.V(2).Info("skipping the paused OpenTelemetryCollector of the deployment")
+	case found && !requested:
+		if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete: %w", err)
+		}
+		log.V(2).Info("deleted", "opentelemetrycollector.name", existing.Name, "opentelemetrycollector.namespace", existing.Namespace)
+	case !found && requested:
+		desired := discoveredCollector(deployment)
+		if err := controllerutil.SetControllerReference(&deployment, &desired, r.scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, &desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create: %w", err)
+		}
+		log.V(2).Info("created", "opentelemetrycollector.name", desired.Name, "opentelemetrycollector.namespace", desired.Namespace)
+	}
+	return ctrl.Result{}, nil
+}
+
+// discoveredCollector returns the OpenTelemetryCollector of the Deployment. The image and the other settings not set
+// here are defaulted from the operator's config.
+func discoveredCollector(deployment appsv1.Deployment) v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Config: DiscoveredCollectorConfig,
+		},
+	}
+}
+
+// namespaceDeployments returns the annotated Deployments of the namespace, so that they're reconciled when the
+// namespace opts in or out.
+func (r *CollectorDiscoveryReconciler) namespaceDeployments(obj client.Object) []reconcile.Request {
+	list := &appsv1.DeploymentList{}
+	if err := r.List(context.Background(), list, client.InNamespace(obj.GetName())); err != nil {
+		r.log.Error(err, "failed to list the deployments", "namespace", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, deployment := range list.Items {
+		if _, ok := deployment.Annotations[CreateCollectorAnnotation]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: deployment.Namespace,
+				Name:      deployment.Name,
+			}})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *CollectorDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the status changes of the deployments are ignored, only their annotations matter
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("collectordiscovery").
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Owns(&v1alpha1.OpenTelemetryCollector{}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(r.namespaceDeployments),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
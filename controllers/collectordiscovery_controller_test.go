@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+)
+
+func TestCollectorDiscoveryReconciliation(t *testing.T) {
+	// prepare
+	reconciler := controllers.NewCollectorDiscoveryReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: testScheme,
+	})
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "discovery",
+			Annotations: map[string]string{controllers.CollectorDiscoveryAnnotation: "true"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), ns))
+	labels := map[string]string{"app": "my-app"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-app",
+			Namespace:   ns.Name,
+			Annotations: map[string]string{controllers.CreateCollectorAnnotation: "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "my-app:1.0"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), deployment))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), deployment))
+	}()
+	nsn := types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}
+	req := k8sreconcile.Request{NamespacedName: nsn}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	otelcol := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, otelcol))
+	assert.True(t, metav1.IsControlledBy(otelcol, deployment))
+	assert.Equal(t, controllers.DiscoveredCollectorConfig, otelcol.Spec.Config)
+
+	// the deployment opts out
+	delete(deployment.Annotations, controllers.CreateCollectorAnnotation)
+	require.NoError(t, k8sClient.Update(context.Background(), deployment))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), nsn, otelcol)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCollectorDiscoveryNamespaceNotOptedIn(t *testing.T) {
+	// prepare
+	reconciler := controllers.NewCollectorDiscoveryReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: testScheme,
+	})
+	labels := map[string]string{"app": "my-other-app"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-other-app",
+			Namespace:   "default",
+			Annotations: map[string]string{controllers.CreateCollectorAnnotation: "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "my-app:1.0"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), deployment))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), deployment))
+	}()
+	nsn := types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), k8sreconcile.Request{NamespacedName: nsn})
+
+	// verify
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), nsn, &v1alpha1.OpenTelemetryCollector{})
+	assert.True(t, apierrors.IsNotFound(err), "the default namespace didn't opt in")
+}
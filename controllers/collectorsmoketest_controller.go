@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// CollectorSmokeTestReconciler runs the smoke tests created after the rollouts of the collector Deployments.
+type CollectorSmokeTestReconciler struct {
+	client.Client
+	recorder record.EventRecorder
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	config   config.Config
+}
+
+// NewSmokeTestReconciler creates a new reconciler for CollectorSmokeTest objects.
+func NewSmokeTestReconciler(p Params) *CollectorSmokeTestReconciler {
+	return &CollectorSmokeTestReconciler{
+		Client:   p.Client,
+		log:      p.Log,
+		scheme:   p.Scheme,
+		config:   p.Config,
+		recorder: p.Recorder,
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=collectorsmoketests/status,verbs=get;update;patch
+
+// Reconcile runs the smoke test Job and records its result.
+func (r *CollectorSmokeTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("collectorsmoketest", req.NamespacedName)
+
+	var smokeTest v1alpha1.CollectorSmokeTest
+	if err := r.Get(ctx, req.NamespacedName, &smokeTest); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch CollectorSmokeTest")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	changed := smokeTest.DeepCopy()
+	if paused, err := updatePaused(ctx, r.Client, &smokeTest, changed, &changed.Status.Conditions); err != nil {
+		return ctrl.Result{}, err
+	} else if paused {
+		log.V(2).Info("skipping the paused smoke test")
+		return ctrl.Result{}, nil
+	}
+	if smokeTest.Status.Phase == v1alpha1.SmokeTestPhaseSucceeded || smokeTest.Status.Phase == v1alpha1.SmokeTestPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	var otelcol v1alpha1.OpenTelemetryCollector
+	nns := types.NamespacedName{Namespace: smokeTest.Namespace, Name: smokeTest.Spec.Collector}
+	if err := r.Get(ctx, nns, &otelcol); err != nil {
+		// the smoke tests of a deleted instance are garbage collected along with it
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if v1alpha1.IsReconcilePaused(&otelcol) {
+		// the roll back of the collector waits for its reconciliation to resume
+		log.V(2).Info("skipping the smoke test of the paused instance")
+		return ctrl.Result{}, nil
+	}
+	if otelcol.Spec.SmokeTest == nil {
+		// the smoke test is deleted by the collector reconciliation
+		return ctrl.Result{}, nil
+	}
+
+	desired := collector.SmokeTestJob(r.config, log, otelcol, smokeTest)
+	existing := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if err != nil && apierrors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(&smokeTest, &desired, r.scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Create(ctx, &desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create: %w", err)
+		}
+		log.V(2).Info("created", "job.name", desired.Name, "job.namespace", desired.Namespace)
+		return ctrl.Result{}, r.setStatus(ctx, smokeTest, v1alpha1.SmokeTestPhaseRunning, "the smoke test job is running", false)
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get: %w", err)
+	}
+
+	switch jobResult(*existing) {
+	case batchv1.JobComplete:
+		return ctrl.Result{}, r.setStatus(ctx, smokeTest, v1alpha1.SmokeTestPhaseSucceeded, "the spans sent to the collector were received", false)
+	case batchv1.JobFailed:
+		return ctrl.Result{}, r.failed(ctx, otelcol, smokeTest, existing.Name)
+	}
+
+	// the job is still running, the job status change triggers a new reconciliation
+	return ctrl.Result{}, nil
+}
+
+// failed records the failure of the smoke test and rolls the collector back to its previous revision if requested.
+func (r *CollectorSmokeTestReconciler) failed(ctx context.Context, otelcol v1alpha1.OpenTelemetryCollector, smokeTest v1alpha1.CollectorSmokeTest, job string) error {
+	message := fmt.Sprintf("the smoke test job %s failed", job)
+	if !otelcol.Spec.SmokeTest.Rollback {
+		r.recorder.Event(&otelcol, corev1.EventTypeWarning, "SmokeTestFailed", message)
+		return r.setStatus(ctx, smokeTest, v1alpha1.SmokeTestPhaseFailed, message, false)
+	}
+
+	// the status is recorded first, so that the collector reconciliation holds the failed revision before the rollback
+	rolledBack := fmt.Sprintf("%s, the collector was rolled back to its previous revision", message)
+	if err := r.setStatus(ctx, smokeTest, v1alpha1.SmokeTestPhaseFailed, rolledBack, true); err != nil {
+		return err
+	}
+	smokeTest.Status.Phase = v1alpha1.SmokeTestPhaseFailed
+	smokeTest.Status.Message = rolledBack
+	smokeTest.Status.RolledBack = true
+
+	if err := r.rollback(ctx, otelcol, smokeTest.Spec.Revision); err != nil {
+		message = fmt.Sprintf("%s, the collector couldn't be rolled back: %s", message, err)
+		r.recorder.Event(&otelcol, corev1.EventTypeWarning, "SmokeTestFailed", message)
+		return r.setStatus(ctx, smokeTest, v1alpha1.SmokeTestPhaseFailed, message, false)
+	}
+	r.recorder.Event(&otelcol, corev1.EventTypeWarning, "SmokeTestFailed", rolledBack)
+	return nil
+}
+
+// rollback restores the pod template of the ReplicaSet preceding the given revision in the collector Deployment.
+func (r *CollectorSmokeTestReconciler) rollback(ctx context.Context, otelcol v1alpha1.OpenTelemetryCollector, revision string) error {
+	deployment := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: otelcol.Namespace, Name: naming.Collector(otelcol)}
+	if err := r.Get(ctx, nns, deployment); err != nil {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	if deployment.Annotations[collector.DeploymentRevisionAnnotation] != revision {
+		return fmt.Errorf("the deployment moved on to revision %s", deployment.Annotations[collector.DeploymentRevisionAnnotation])
+	}
+	failed, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid revision %q: %w", revision, err)
+	}
+
+	list := &appsv1.ReplicaSetList{}
+	if err := r.List(ctx, list, client.InNamespace(deployment.Namespace), client.MatchingLabels(deployment.Spec.Selector.MatchLabels)); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	var previous *appsv1.ReplicaSet
+	var previousRevision int64
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		rev, err := strconv.ParseInt(rs.Annotations[collector.DeploymentRevisionAnnotation], 10, 64)
+		if err != nil || rev >= failed {
+			continue
+		}
+		if previous == nil || rev > previousRevision {
+			previous, previousRevision = rs, rev
+		}
+	}
+	if previous == nil {
+		return fmt.Errorf("no previous revision to roll back to")
+	}
+
+	updated := deployment.DeepCopy()
+	updated.Spec.Template = *previous.Spec.Template.DeepCopy()
+	delete(updated.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	if err := r.Patch(ctx, updated, client.MergeFrom(deployment)); err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+	r.log.V(2).Info("rolled back", "deployment.name", deployment.Name, "deployment.namespace", deployment.Namespace, "revision", previousRevision)
+	return nil
+}
+
+func (r *CollectorSmokeTestReconciler) setStatus(ctx context.Context, smokeTest v1alpha1.CollectorSmokeTest, phase v1alpha1.SmokeTestPhase, message string, rolledBack bool) error {
+	changed := smokeTest.DeepCopy()
+	now := metav1.Now()
+	if changed.Status.StartTime == nil {
+		changed.Status.StartTime = &now
+	}
+	if phase == v1alpha1.SmokeTestPhaseSucceeded || phase == v1alpha1.SmokeTestPhaseFailed {
+		changed.Status.CompletionTime = &now
+	}
+	changed.Status.Phase = phase
+	changed.Status.Message = message
+	changed.Status.RolledBack = rolledBack
+
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&smokeTest)); err != nil {
+		return fmt.Errorf("failed to apply status changes to the CollectorSmokeTest: %w", err)
+	}
+	return nil
+}
+
+// jobResult returns the terminal condition of the Job, or an empty string while it's running.
+func jobResult(job batchv1.Job) batchv1.JobConditionType {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return c.Type
+		}
+	}
+	return ""
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *CollectorSmokeTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.CollectorSmokeTest{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
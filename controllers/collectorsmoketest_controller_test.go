@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestSmokeTestReconciliation(t *testing.T) {
+	// prepare
+	recorder := record.NewFakeRecorder(10)
+	reconciler := controllers.NewSmokeTestReconciler(controllers.Params{
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   testScheme,
+		Config:   config.New(),
+		Recorder: recorder,
+	})
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-smoketested-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      v1alpha1.ModeDeployment,
+			SmokeTest: &v1alpha1.SmokeTestSpec{},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), otelcol))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), otelcol))
+	}()
+	smokeTest := &v1alpha1.CollectorSmokeTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-smoketested-instance-smoketest-1",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.CollectorSmokeTestSpec{
+			Collector: otelcol.Name,
+			Revision:  "1",
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), smokeTest))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), smokeTest))
+	}()
+	nsn := types.NamespacedName{Name: smokeTest.Name, Namespace: smokeTest.Namespace}
+	req := k8sreconcile.Request{NamespacedName: nsn}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	job := &batchv1.Job{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, job))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, smokeTest))
+	assert.Equal(t, v1alpha1.SmokeTestPhaseRunning, smokeTest.Status.Phase)
+	assert.NotNil(t, smokeTest.Status.StartTime)
+
+	// the job fails
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	require.NoError(t, k8sClient.Status().Update(context.Background(), job))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, smokeTest))
+	assert.Equal(t, v1alpha1.SmokeTestPhaseFailed, smokeTest.Status.Phase)
+	assert.False(t, smokeTest.Status.RolledBack)
+	assert.NotNil(t, smokeTest.Status.CompletionTime)
+	assert.Contains(t, <-recorder.Events, "SmokeTestFailed")
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/opampbridge"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/tracing"
+)
+
+// OpAMPBridgeReconciler deploys the OpAMP bridges managing collectors on behalf of an OpAMP server.
+type OpAMPBridgeReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+	config config.Config
+}
+
+// NewOpAMPBridgeReconciler creates a new reconciler for OpAMPBridge objects.
+func NewOpAMPBridgeReconciler(p Params) *OpAMPBridgeReconciler {
+	return &OpAMPBridgeReconciler{
+		Client: p.Client,
+		log:    p.Log,
+		scheme: p.Scheme,
+		config: p.Config,
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opampbridges,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opampbridges/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile deploys the OpAMP bridge and records the collectors it manages.
+func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	log := r.log.WithValues("opampbridge", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "OpAMPBridge", req.NamespacedName)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	var bridge v1alpha1.OpAMPBridge
+	if err := r.Get(ctx, req.NamespacedName, &bridge); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpAMPBridge")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if bridge.GetDeletionTimestamp() != nil {
+		// the owned objects are garbage collected along with it
+		return ctrl.Result{}, nil
+	}
+	changed := bridge.DeepCopy()
+	if paused, err := updatePaused(ctx, r.Client, &bridge, changed, &changed.Status.Conditions); err != nil {
+		return ctrl.Result{}, err
+	} else if paused {
+		log.V(2).Info("skipping the paused OpAMP bridge")
+		return ctrl.Result{}, nil
+	}
+	if !featuregate.Gates.Enabled(featuregate.OpAMPBridge) {
+		log.V(2).Info("skipping the OpAMP bridge, the feature gate is disabled", "featureGate", featuregate.OpAMPBridge)
+		return ctrl.Result{}, nil
+	}
+
+	configMap, err := opampbridge.ConfigMap(bridge)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to build the config: %w", err)
+	}
+	existingConfigMap := &corev1.ConfigMap{}
+	if err := applyOwned(ctx, r.Client, r.scheme, log, &bridge, &configMap, existingConfigMap, func() {
+		existingConfigMap.Data = configMap.Data
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile the configmap: %w", err)
+	}
+
+	serviceAccount := opampbridge.ServiceAccount(bridge)
+	role := opampbridge.Role(bridge)
+	roleBinding := opampbridge.RoleBinding(bridge)
+	if bridge.Spec.ServiceAccount == "" {
+		if err := applyOwned(ctx, r.Client, r.scheme, log, &bridge, &serviceAccount, &corev1.ServiceAccount{}, func() {}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the service account: %w", err)
+		}
+		existingRole := &rbacv1.Role{}
+		if err := applyOwned(ctx, r.Client, r.scheme, log, &bridge, &role, existingRole, func() {
+			existingRole.Rules = role.Rules
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the role: %w", err)
+		}
+		if err := applyOwned(ctx, r.Client, r.scheme, log, &bridge, &roleBinding, &rbacv1.RoleBinding{}, func() {}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the role binding: %w", err)
+		}
+	} else {
+		// the permissions of a service account set in the spec are up to its owner
+		for _, obj := range []client.Object{&roleBinding, &role, &serviceAccount} {
+			if err := deleteOwned(ctx, r.Client, log, &bridge, obj); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to delete the service account permissions: %w", err)
+			}
+		}
+	}
+
+	deployment := opampbridge.Deployment(r.config, bridge, configMap)
+	existingDeployment := &appsv1.Deployment{}
+	if err := applyOwned(ctx, r.Client, r.scheme, log, &bridge, &deployment, existingDeployment, func() {
+		existingDeployment.Spec.Replicas = deployment.Spec.Replicas
+		existingDeployment.Spec.Template = deployment.Spec.Template
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile the deployment: %w", err)
+	}
+
+	return ctrl.Result{}, r.updateCollectors(ctx, bridge)
+}
+
+// updateCollectors records the names of the instances managed by the bridge in its status.
+func (r *OpAMPBridgeReconciler) updateCollectors(ctx context.Context, bridge v1alpha1.OpAMPBridge) error {
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, list, client.InNamespace(bridge.Namespace)); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	collectors := opampbridge.ManagedCollectors(bridge, list.Items)
+	if reflect.DeepEqual(collectors, bridge.Status.Collectors) {
+		return nil
+	}
+
+	changed := bridge.DeepCopy()
+	changed.Status.Collectors = collectors
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&bridge)); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpAMPBridge: %w", err)
+	}
+	return nil
+}
+
+// managingBridges returns the OpAMPBridges managing the instance, which list it in their status.
+func (r *OpAMPBridgeReconciler) managingBridges(obj client.Object) []ctrl.Request {
+	instance, ok := obj.(*v1alpha1.OpenTelemetryCollector)
+	if !ok {
+		return nil
+	}
+	list := &v1alpha1.OpAMPBridgeList{}
+	if err := r.List(context.Background(), list, client.InNamespace(instance.Namespace)); err != nil {
+		r.log.Error(err, "failed to list the OpAMP bridges", "namespace", instance.Namespace)
+		return nil
+	}
+
+	// an instance no longer matching the selector still needs to leave the status of its former bridge
+	var requests []ctrl.Request
+	for _, bridge := range list.Items {
+		managed := opampbridge.Manages(bridge, *instance)
+		listed := false
+		for _, name := range bridge.Status.Collectors {
+			listed = listed || name == instance.Name
+		}
+		if managed || listed {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: bridge.Namespace, Name: bridge.Name}})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *OpAMPBridgeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OpAMPBridge{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Owns(&appsv1.Deployment{}).
+		Watches(&source.Kind{Type: &v1alpha1.OpenTelemetryCollector{}}, handler.EnqueueRequestsFromMapFunc(r.managingBridges)).
+		Complete(r)
+}
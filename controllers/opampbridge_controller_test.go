@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestOpAMPBridgeReconciliation(t *testing.T) {
+	// prepare
+	require.NoError(t, featuregate.Gates.Set("OpAMPBridge=true"))
+	defer func() {
+		require.NoError(t, featuregate.Gates.Set("OpAMPBridge=false"))
+	}()
+	reconciler := controllers.NewOpAMPBridgeReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: testScheme,
+		Config: config.New(),
+	})
+	bridge := &v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-bridge",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			Endpoint: "wss://opamp.example.com/v1/opamp",
+			Selector: map[string]string{"opamp": "managed"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), bridge))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), bridge))
+	}()
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-managed-instance",
+			Namespace: "default",
+			Labels:    map[string]string{"opamp": "managed"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), otelcol))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), otelcol))
+	}()
+	req := k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: bridge.Name, Namespace: bridge.Namespace}}
+	nsn := types.NamespacedName{Name: "my-bridge-opamp-bridge", Namespace: bridge.Namespace}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &corev1.ConfigMap{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &corev1.ServiceAccount{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &rbacv1.Role{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &rbacv1.RoleBinding{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &appsv1.Deployment{}))
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, bridge))
+	assert.Equal(t, []string{"my-managed-instance"}, bridge.Status.Collectors)
+
+	// the permissions of a service account set in the spec aren't managed by the operator
+	bridge.Spec.ServiceAccount = "my-service-account"
+	require.NoError(t, k8sClient.Update(context.Background(), bridge))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), nsn, &rbacv1.RoleBinding{})
+	assert.True(t, apierrors.IsNotFound(err))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, deployment))
+	assert.Equal(t, "my-service-account", deployment.Spec.Template.Spec.ServiceAccountName)
+}
@@ -17,37 +17,100 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/reconcile"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/eventexport"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/federation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/tracing"
+)
+
+const (
+	// orphanFinalizer holds the deletion of instances using the Orphan GC policy until their resources are orphaned.
+	orphanFinalizer = "opentelemetry.io/orphan-resources"
+
+	// foregroundFinalizer holds the deletion of instances using the Foreground GC policy until their resources are deleted.
+	foregroundFinalizer = "opentelemetry.io/foreground-deletion"
+
+	// foregroundDeletionPeriod is the interval between the checks of the resources being deleted along with an instance
+	// using the Foreground GC policy.
+	foregroundDeletionPeriod = 5 * time.Second
+
+	// federationFinalizer holds the deletion of instances deployed to a remote cluster until their resources are deleted.
+	federationFinalizer = "opentelemetry.io/federated-resources"
+
+	// clusterRBACFinalizer holds the deletion of instances with a cluster role until it is deleted, being cluster
+	// scoped it can't be garbage collected along with the instance.
+	clusterRBACFinalizer = "opentelemetry.io/cluster-rbac"
+
+	// federationResyncPeriod is the interval between the reconciliations of the instances deployed to a remote cluster,
+	// as the changes of their resources aren't watched.
+	federationResyncPeriod = 5 * time.Minute
+
+	// targetScalingPeriod is the interval between the polls of the target allocators of the instances scaled on their
+	// targets, and between the reconciliations of these instances, which read the polled numbers.
+	targetScalingPeriod = 30 * time.Second
+
+	// canaryCheckPeriod is the interval between the reconciliations of the instances using the canary rollout, which
+	// evaluate the health of their canary.
+	canaryCheckPeriod = 30 * time.Second
 )
 
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
 type OpenTelemetryCollectorReconciler struct {
 	client.Client
-	recorder record.EventRecorder
-	scheme   *runtime.Scheme
-	log      logr.Logger
-	config   config.Config
+	recorder   record.EventRecorder
+	scheme     *runtime.Scheme
+	log        logr.Logger
+	config     config.Config
+	events     *eventexport.Exporter
+	federation *federation.Clients
+	targets    *targetallocator.TargetCounts
+	httpClient *http.Client
 
 	tasks   []Task
 	muTasks sync.RWMutex
+
+	unwatchedConfigSources bool
 }
 
 // Task represents a reconciliation task to be executed by the reconciler.
@@ -65,6 +128,9 @@ type Params struct {
 	Log      logr.Logger
 	Tasks    []Task
 	Config   config.Config
+	// UnwatchedConfigSources accepts the config sources which aren't labeled, for the reconciler which doesn't watch
+	// them, like the one rendering the manifests offline.
+	UnwatchedConfigSources bool
 }
 
 func (r *OpenTelemetryCollectorReconciler) onPlatformChange() error {
@@ -116,16 +182,37 @@ func (r *OpenTelemetryCollectorReconciler) removeRouteTask(plt platform.Platform
 // NewReconciler creates a new reconciler for OpenTelemetryCollector objects.
 func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 	r := &OpenTelemetryCollectorReconciler{
-		Client:   p.Client,
-		log:      p.Log,
-		scheme:   p.Scheme,
-		config:   p.Config,
-		tasks:    p.Tasks,
-		recorder: p.Recorder,
+		Client:     p.Client,
+		log:        p.Log,
+		scheme:     p.Scheme,
+		config:     p.Config,
+		tasks:      p.Tasks,
+		recorder:   p.Recorder,
+		events:     eventexport.New(p.Log),
+		federation: federation.NewClients(p.Scheme),
+		targets:    targetallocator.NewTargetCounts(p.Log, targetScalingPeriod),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+
+		unwatchedConfigSources: p.UnwatchedConfigSources,
 	}
 
 	if len(r.tasks) == 0 {
 		r.tasks = []Task{
+			{
+				reconcile.ConfigComponents,
+				"config components",
+				false,
+			},
+			{
+				reconcile.CollectorBuild,
+				"collector builds",
+				true,
+			},
+			{
+				reconcile.Canaries,
+				"canaries",
+				true,
+			},
 			{
 				reconcile.ConfigMaps,
 				"config maps",
@@ -136,21 +223,71 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 				"service accounts",
 				true,
 			},
+			{
+				reconcile.Roles,
+				"roles",
+				true,
+			},
+			{
+				reconcile.RoleBindings,
+				"role bindings",
+				true,
+			},
+			{
+				reconcile.ClusterRoles,
+				"cluster roles",
+				true,
+			},
+			{
+				reconcile.ClusterRoleBindings,
+				"cluster role bindings",
+				true,
+			},
 			{
 				reconcile.Services,
 				"services",
 				true,
 			},
+			{
+				reconcile.TargetAllocatorCertificates,
+				"target allocator certificates",
+				true,
+			},
+			{
+				reconcile.ReceiverCertificates,
+				"receiver certificates",
+				true,
+			},
 			{
 				reconcile.Deployments,
 				"deployments",
 				true,
 			},
+			{
+				reconcile.SmokeTests,
+				"smoke tests",
+				true,
+			},
 			{
 				reconcile.HorizontalPodAutoscalers,
 				"horizontal pod autoscalers",
 				true,
 			},
+			{
+				reconcile.ScaledObjects,
+				"scaled objects",
+				true,
+			},
+			{
+				reconcile.VerticalPodAutoscalers,
+				"vertical pod autoscalers",
+				true,
+			},
+			{
+				reconcile.Monitors,
+				"monitors",
+				true,
+			},
 			{
 				reconcile.DaemonSets,
 				"daemon sets",
@@ -161,11 +298,26 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 				"stateful sets",
 				true,
 			},
+			{
+				reconcile.PodDisruptionBudgets,
+				"pod disruption budgets",
+				true,
+			},
+			{
+				reconcile.NetworkPolicies,
+				"network policies",
+				true,
+			},
 			{
 				reconcile.Ingresses,
 				"ingresses",
 				true,
 			},
+			{
+				reconcile.GatewayRoutes,
+				"gateway routes",
+				true,
+			},
 			{
 				reconcile.Self,
 				"opentelemetry",
@@ -186,8 +338,13 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile the current state of an OpenTelemetry collector resource with the desired state.
-func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+	start := time.Now()
+	ctx, span := tracing.StartReconcile(ctx, "OpenTelemetryCollector", req.NamespacedName)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	var instance v1alpha1.OpenTelemetryCollector
 	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
@@ -198,22 +355,395 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		// we'll ignore not-found errors, since they can't be fixed by an immediate
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
+		if apierrors.IsNotFound(err) {
+			r.events.Forget(req.NamespacedName)
+			r.targets.Forget(req.NamespacedName)
+			metrics.ForgetCollector(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// the defaults of the fields the webhook doesn't write are only applied in memory
+	instance = instance.WithDefaults()
+	metrics.ObserveCollector(instance)
+	defer func() {
+		metrics.ObserveReconcile(req.NamespacedName, time.Since(start), err)
+	}()
 
 	params := reconcile.Params{
-		Config:   r.config,
-		Client:   r.Client,
-		Instance: instance,
-		Log:      log,
-		Scheme:   r.scheme,
-		Recorder: r.recorder,
+		Config:     r.config,
+		Client:     r.Client,
+		Instance:   instance,
+		Log:        log,
+		Scheme:     r.scheme,
+		Recorder:   r.recorder,
+		HTTPClient: r.httpClient,
+
+		UnwatchedConfigSources: r.unwatchedConfigSources,
+	}
+
+	if instance.GetDeletionTimestamp() != nil {
+		return r.finalize(ctx, params)
+	}
+	if err := r.ensureFinalizers(ctx, &params.Instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	changed := instance.DeepCopy()
+	paused, err := updatePaused(ctx, r.Client, &instance, changed, &changed.Status.Conditions)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// the status patches of the tasks are based on the instance, which mustn't bring back a removed Paused condition
+	instance.Status.Conditions = changed.Status.Conditions
+	params.Instance.Status.Conditions = changed.Status.Conditions
+	if paused {
+		// the resources, and the manual changes made to them, are left as they are, until the annotation is removed
+		// or the instance is managed again
+		log.V(2).Info("skipping the paused instance")
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Spec.FederationRef != nil && !featuregate.Gates.Enabled(featuregate.MultiClusterFederation) {
+		log.Info("skipping the instance deployed to a remote cluster, the feature gate is disabled", "featureGate", featuregate.MultiClusterFederation)
+		return ctrl.Result{}, nil
+	}
+	if params.Instance.Spec.TargetAllocator.Enabled && !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+		// the target allocator resources created before the gate was disabled are removed by the tasks
+		log.V(2).Info("ignoring the target allocator, the feature gate is disabled", "featureGate", featuregate.TargetAllocator)
+		params.Instance.Spec.TargetAllocator.Enabled = false
+	}
+	if params.Instance.Spec.TargetAllocatorRef != "" && !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+		log.V(2).Info("ignoring the referenced target allocator, the feature gate is disabled", "featureGate", featuregate.TargetAllocator)
+		params.Instance.Spec.TargetAllocatorRef = ""
+	}
+
+	if instance.Spec.FederationRef != nil {
+		remote, err := r.federation.ClientFor(ctx, r.Client, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		params.Client = remote
+	}
+
+	now := time.Now()
+	if ok, requeueAfter := reconcilePolicyAllows(instance, now); !ok {
+		log.V(2).Info("skipping reconciliation due to the reconcile policy", "policy", instance.Spec.ReconcilePolicy)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// the config sources are read from the cluster of the instance, and merged before the templates are evaluated
+	config, err := reconcile.ConfigWithSources(ctx, r.Client, params.Instance, !params.UnwatchedConfigSources)
+	if err != nil {
+		err = fmt.Errorf("failed to merge the config sources: %w", err)
+		r.updateConditions(ctx, log, instance, err, err)
+		return ctrl.Result{}, err
+	}
+
+	// the config templates are evaluated once, for all the tasks
+	config, err = adapters.ConfigFromTemplate(config, params.Instance)
+	if err != nil {
+		err = fmt.Errorf("failed to evaluate the config template: %w", err)
+		r.updateConditions(ctx, log, instance, err, err)
+		return ctrl.Result{}, err
+	}
+	params.Instance.Spec.Config = config
+	_, configErr := adapters.ConfigFromString(config)
+
+	// the referenced target allocator lives in the cluster of the instance, next to the collectors it allocates to
+	if ref := params.Instance.Spec.TargetAllocatorRef; ref != "" {
+		ta := &v1alpha1.TargetAllocator{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: ref}, ta); err != nil {
+			err = fmt.Errorf("failed to get the target allocator %s: %w", ref, err)
+			r.updateConditions(ctx, log, instance, configErr, err)
+			return ctrl.Result{}, err
+		}
+		params.TargetAllocator = ta
+	}
+
+	if collector.UsesTargetScaling(params.Instance) {
+		if err := r.scaleOnTargets(ctx, &params.Instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		r.targets.Forget(req.NamespacedName)
 	}
 
-	if err := r.RunTasks(ctx, params); err != nil {
+	err = r.RunTasks(ctx, params)
+	r.events.Observe(params.Instance, err)
+	r.updateConditions(ctx, log, instance, configErr, err)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	result, err = r.completeReconcilePolicy(ctx, params.Instance, now)
+	if err == nil && instance.Spec.FederationRef != nil && (result.RequeueAfter == 0 || result.RequeueAfter > federationResyncPeriod) {
+		result.RequeueAfter = federationResyncPeriod
+	}
+	if err == nil && collector.UsesTargetScaling(params.Instance) && (result.RequeueAfter == 0 || result.RequeueAfter > targetScalingPeriod) {
+		result.RequeueAfter = targetScalingPeriod
+	}
+	if err == nil && collector.UsesCanaryRollout(params.Instance) && (result.RequeueAfter == 0 || result.RequeueAfter > canaryCheckPeriod) {
+		result.RequeueAfter = canaryCheckPeriod
+	}
+	return result, err
+}
+
+// updateConditions reports the outcome of the reconciliation with the ConfigValid and Reconciled conditions, and with
+// a warning event when it failed. The status is only patched when the conditions change.
+func (r *OpenTelemetryCollectorReconciler) updateConditions(ctx context.Context, log logr.Logger, instance v1alpha1.OpenTelemetryCollector, configErr, reconcileErr error) {
+	configValid := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeConfigValid,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ConfigValid",
+		Message: "the config is valid",
+	}
+	if configErr != nil {
+		configValid.Status = metav1.ConditionFalse
+		configValid.Reason = "InvalidConfig"
+		configValid.Message = configErr.Error()
+	}
+
+	reconciled := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeReconciled,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReconcileSucceeded",
+		Message: "all the resources of the instance are reconciled",
+	}
+	if reconcileErr != nil {
+		reconciled.Status = metav1.ConditionFalse
+		reconciled.Reason = reconcileFailureReason(reconcileErr)
+		if configErr != nil && errors.Is(reconcileErr, configErr) {
+			reconciled.Reason = "InvalidConfig"
+		}
+		reconciled.Message = reconcileErr.Error()
+	}
+
+	previous := r.patchConditions(ctx, log, instance, configValid, reconciled)
+
+	// the event is only emitted when the failure changes, not on each retry of the same failure, and the conflicts of
+	// the concurrent updates are retried without an event
+	if reconcileErr == nil || reconciled.Reason == "UpdateConflict" || r.recorder == nil {
+		return
+	}
+	if last := meta.FindStatusCondition(previous, v1alpha1.ConditionTypeReconciled); last != nil &&
+		last.Status == metav1.ConditionFalse && last.Reason == reconciled.Reason && last.Message == reconciled.Message {
+		return
+	}
+	r.recorder.Event(&instance, corev1.EventTypeWarning, reconciled.Reason, reconciled.Message)
+}
+
+// patchConditions sets the conditions on the current instance, and patches its status when they change. It returns
+// the conditions of the instance before the patch.
+func (r *OpenTelemetryCollectorReconciler) patchConditions(ctx context.Context, log logr.Logger, instance v1alpha1.OpenTelemetryCollector, conditions ...metav1.Condition) []metav1.Condition {
+	// the status might have been updated by the tasks
+	current := v1alpha1.OpenTelemetryCollector{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(&instance), &current); err != nil {
+		log.Error(err, "failed to get the instance to update its conditions")
+		return nil
+	}
+	changed := current.DeepCopy()
+	for _, condition := range conditions {
+		meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	}
+	if equality.Semantic.DeepEqual(current.Status.Conditions, changed.Status.Conditions) {
+		return current.Status.Conditions
+	}
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&current)); err != nil {
+		log.Error(err, "failed to update the conditions of the instance")
+	}
+	return current.Status.Conditions
+}
+
+// reconcileFailureReason classifies the reconciliation errors for the Reconciled condition and the events.
+func reconcileFailureReason(err error) string {
+	switch {
+	case apierrors.IsForbidden(err):
+		// the operator is missing RBAC permissions
+		return "Forbidden"
+	case apierrors.IsConflict(err):
+		// the resource changed since it was read, which is retried
+		return "UpdateConflict"
+	case apierrors.IsAlreadyExists(err), isAlreadyOwned(err):
+		// the resource exists, or belongs to another owner
+		return "ConflictingResource"
+	case apierrors.IsInvalid(err):
+		return "InvalidResource"
+	}
+	return "ReconcileFailed"
+}
+
+// isAlreadyOwned returns whether the error is about a resource already controlled by another owner.
+func isAlreadyOwned(err error) bool {
+	var owned *controllerutil.AlreadyOwnedError
+	return errors.As(err, &owned)
+}
+
+// scaleOnTargets sets the replicas of the collector StatefulSet from the last number of targets polled from its
+// target allocator. The replicas of the instance are only changed in memory, for the StatefulSet built from it, the
+// spec of the user isn't patched. The StatefulSet keeps its replicas while the number of targets is unknown.
+func (r *OpenTelemetryCollectorReconciler) scaleOnTargets(ctx context.Context, instance *v1alpha1.OpenTelemetryCollector) error {
+	nsn := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	r.targets.Watch(*instance)
+
+	targets, ok := r.targets.Targets(nsn)
+	if !ok {
+		existing := &appsv1.StatefulSet{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: naming.Collector(*instance)}, existing)
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get the statefulset to scale: %w", err)
+		}
+		if err == nil && existing.Spec.Replicas != nil {
+			instance.Spec.Replicas = existing.Spec.Replicas
+		}
+		return nil
+	}
+
+	replicas := collector.TargetScaledReplicas(*instance, targets)
+	r.log.V(2).Info("scaled on the targets", "targets", targets, "replicas", replicas)
+	instance.Spec.Replicas = &replicas
+	return nil
+}
+
+// ensureFinalizers adds the orphan finalizer to instances using the Orphan GC policy, the federation finalizer to
+// the other instances deployed to a remote cluster, the foreground finalizer to the local instances using the
+// Foreground GC policy, and the cluster RBAC finalizer to the other instances with a cluster role. The instances
+// using the Background GC policy are let go right away, leaving the garbage collection of their resources to the
+// owner references. The finalizers no longer needed are removed.
+func (r *OpenTelemetryCollectorReconciler) ensureFinalizers(ctx context.Context, instance *v1alpha1.OpenTelemetryCollector) error {
+	orphan := instance.Spec.GCPolicy == v1alpha1.GCPolicyOrphan
+	federated := instance.Spec.FederationRef != nil && !orphan
+	foreground := instance.Spec.FederationRef == nil && !orphan && instance.Spec.GCPolicy != v1alpha1.GCPolicyBackground
+	clusterRBAC := instance.Spec.RBAC.ClusterRole && instance.Spec.FederationRef == nil && !orphan
+
+	existing := instance.DeepCopy()
+	for finalizer, needed := range map[string]bool{
+		orphanFinalizer:      orphan,
+		federationFinalizer:  federated,
+		foregroundFinalizer:  foreground,
+		clusterRBACFinalizer: clusterRBAC,
+	} {
+		if needed {
+			controllerutil.AddFinalizer(instance, finalizer)
+		} else {
+			controllerutil.RemoveFinalizer(instance, finalizer)
+		}
+	}
+	if equality.Semantic.DeepEqual(existing.Finalizers, instance.Finalizers) {
+		return nil
+	}
+	if err := r.Patch(ctx, instance, client.MergeFrom(existing)); err != nil {
+		return fmt.Errorf("failed to update the finalizers: %w", err)
+	}
+	return nil
+}
+
+// finalize orphans the resources created for an instance using the Orphan GC policy, deletes the resources of an
+// instance using the Foreground GC policy and waits for them to be gone, and deletes the resources created in a
+// remote cluster and the cluster roles, before letting it go.
+func (r *OpenTelemetryCollectorReconciler) finalize(ctx context.Context, params reconcile.Params) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(&params.Instance, orphanFinalizer) &&
+		!controllerutil.ContainsFinalizer(&params.Instance, federationFinalizer) &&
+		!controllerutil.ContainsFinalizer(&params.Instance, foregroundFinalizer) &&
+		!controllerutil.ContainsFinalizer(&params.Instance, clusterRBACFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.ContainsFinalizer(&params.Instance, orphanFinalizer) && params.Instance.Spec.GCPolicy == v1alpha1.GCPolicyOrphan {
+		if err := reconcile.Orphan(ctx, params); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to orphan the resources: %w", err)
+		}
+	}
+
+	if controllerutil.ContainsFinalizer(&params.Instance, federationFinalizer) && params.Instance.Spec.FederationRef != nil {
+		if err := r.deleteRemoteResources(ctx, params); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete the resources of the remote cluster: %w", err)
+		}
+	}
+
+	if controllerutil.ContainsFinalizer(&params.Instance, foregroundFinalizer) && params.Instance.Spec.FederationRef == nil &&
+		params.Instance.Spec.GCPolicy != v1alpha1.GCPolicyOrphan && params.Instance.Spec.GCPolicy != v1alpha1.GCPolicyBackground {
+		remaining, err := reconcile.DeleteOwned(ctx, params)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete the resources: %w", err)
+		}
+		if remaining {
+			// not all the deletions are watched, the instance is checked again until its resources are gone
+			return ctrl.Result{RequeueAfter: foregroundDeletionPeriod}, nil
+		}
+	}
+
+	if controllerutil.ContainsFinalizer(&params.Instance, clusterRBACFinalizer) && params.Instance.Spec.FederationRef == nil {
+		if err := reconcile.DeleteClusterRBAC(ctx, params); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete the cluster roles: %w", err)
+		}
+	}
+
+	existing := params.Instance.DeepCopy()
+	controllerutil.RemoveFinalizer(&params.Instance, orphanFinalizer)
+	controllerutil.RemoveFinalizer(&params.Instance, federationFinalizer)
+	controllerutil.RemoveFinalizer(&params.Instance, foregroundFinalizer)
+	controllerutil.RemoveFinalizer(&params.Instance, clusterRBACFinalizer)
+	if err := r.Patch(ctx, &params.Instance, client.MergeFrom(existing)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove the finalizer: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *OpenTelemetryCollectorReconciler) deleteRemoteResources(ctx context.Context, params reconcile.Params) error {
+	remote, err := r.federation.ClientFor(ctx, r.Client, params.Instance)
+	if apierrors.IsNotFound(err) {
+		// the kubeconfig secret is deleted along with the cluster, so there's nothing left to delete
+		params.Log.Info("the kubeconfig of the remote cluster is gone, skipping the deletion of its resources", "cluster", params.Instance.Spec.FederationRef.ClusterName)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	params.Client = remote
+	return reconcile.Delete(ctx, params)
+}
+
+// reconcilePolicyAllows returns whether the instance should be reconciled now according to its reconcile policy.
+// When it shouldn't, the returned duration is the time left until the next scheduled reconciliation, if any.
+func reconcilePolicyAllows(instance v1alpha1.OpenTelemetryCollector, now time.Time) (bool, time.Duration) {
+	switch instance.Spec.ReconcilePolicy {
+	case v1alpha1.ReconcilePolicyOnDemand:
+		return instance.Annotations[v1alpha1.AnnotationTriggerReconcile] == "true", 0
+	case v1alpha1.ReconcilePolicyScheduled:
+		schedule, err := cron.ParseStandard(instance.Spec.ReconcileSchedule)
+		if err != nil || instance.Status.LastScheduledReconcile == nil {
+			// the schedule is validated by the webhook, so we only get here for the first run
+			return true, 0
+		}
+		if next := schedule.Next(instance.Status.LastScheduledReconcile.Time); now.Before(next) {
+			return false, next.Sub(now)
+		}
+	}
+	return true, 0
+}
+
+// completeReconcilePolicy records the reconciliation of the instance according to its reconcile policy:
+// the trigger annotation is removed for OnDemand and the next run is scheduled for Scheduled.
+func (r *OpenTelemetryCollectorReconciler) completeReconcilePolicy(ctx context.Context, instance v1alpha1.OpenTelemetryCollector, now time.Time) (ctrl.Result, error) {
+	switch instance.Spec.ReconcilePolicy {
+	case v1alpha1.ReconcilePolicyOnDemand:
+		updated := instance.DeepCopy()
+		delete(updated.Annotations, v1alpha1.AnnotationTriggerReconcile)
+		if err := r.Patch(ctx, updated, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove the %s annotation: %w", v1alpha1.AnnotationTriggerReconcile, err)
+		}
+	case v1alpha1.ReconcilePolicyScheduled:
+		schedule, err := cron.ParseStandard(instance.Spec.ReconcileSchedule)
+		if err != nil {
+			r.log.Error(err, "invalid reconcile schedule", "schedule", instance.Spec.ReconcileSchedule)
+			return ctrl.Result{}, nil
+		}
+		updated := instance.DeepCopy()
+		updated.Status.LastScheduledReconcile = &metav1.Time{Time: now}
+		if err := r.Status().Patch(ctx, updated, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to record the scheduled reconciliation: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: schedule.Next(now).Sub(now)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -222,7 +752,10 @@ func (r *OpenTelemetryCollectorReconciler) RunTasks(ctx context.Context, params
 	r.muTasks.RLock()
 	defer r.muTasks.RUnlock()
 	for _, task := range r.tasks {
-		if err := task.Do(ctx, params); err != nil {
+		taskCtx, span := tracing.Tracer().Start(ctx, task.Name)
+		err := task.Do(taskCtx, params)
+		tracing.End(span, err)
+		if err != nil {
 			// If we get an error that occurs because a pod is being terminated, then exit this loop
 			if apierrors.IsForbidden(err) && apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
 				r.log.V(2).Info("Exiting reconcile loop because namespace is being terminated", "namespace", params.Instance.Namespace)
@@ -238,20 +771,90 @@ func (r *OpenTelemetryCollectorReconciler) RunTasks(ctx context.Context, params
 	return nil
 }
 
+// configSourceObject returns whether the ConfigMap or Secret is labeled as a config source.
+func configSourceObject(obj client.Object) bool {
+	return obj.GetLabels()[reconcile.ConfigSourceLabel] == "true"
+}
+
+// certificateSecret returns whether the Secret was issued by cert-manager, like the ones of the receiver certificates.
+func certificateSecret(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()["cert-manager.io/certificate-name"]
+	return ok
+}
+
+// configSourceInstances returns the instances using the ConfigMap or Secret as a config source, or the Secret as a
+// receiver certificate, so that they're reconciled when it changes.
+func (r *OpenTelemetryCollectorReconciler) configSourceInstances(obj client.Object) []ctrl.Request {
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(context.Background(), list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list the OpenTelemetryCollectors", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, instance := range list.Items {
+		if reconcile.ConfigSourceReferenced(instance, obj) || reconcile.ReceiverCertificateReferenced(instance, obj) {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}})
+		}
+	}
+	return requests
+}
+
+// referencingInstances returns the instances referencing the TargetAllocator, whose collector config holds its jobs.
+func (r *OpenTelemetryCollectorReconciler) referencingInstances(obj client.Object) []ctrl.Request {
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(context.Background(), list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list the OpenTelemetryCollectors", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []ctrl.Request
+	for _, instance := range list.Items {
+		if instance.Spec.TargetAllocatorRef == obj.GetName() {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}})
+		}
+	}
+	return requests
+}
+
 // SetupWithManager tells the manager what our controller is interested in.
 func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	err := r.config.AutoDetect() // We need to call this so we can get the correct autodetect version
 	if err != nil {
 		return err
 	}
+	// the events of the reconciliations are published in the background
+	if err := mgr.Add(r.events); err != nil {
+		return err
+	}
+	// the target allocators of the instances scaled on their targets are polled in the background
+	if err := mgr.Add(r.targets); err != nil {
+		return err
+	}
+
+	// only the labeled config sources and the certificates are watched, not every ConfigMap and Secret of the cluster
+	configMaps := builder.WithPredicates(predicate.NewPredicateFuncs(configSourceObject))
+	secrets := builder.WithPredicates(predicate.Or(predicate.NewPredicateFuncs(configSourceObject), predicate.NewPredicateFuncs(certificateSecret)))
+
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.OpenTelemetryCollector{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.DaemonSet{}).
-		Owns(&appsv1.StatefulSet{})
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&batchv1.Job{}).
+		Owns(&v1alpha1.CollectorSmokeTest{}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.configSourceInstances), configMaps).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.configSourceInstances), secrets).
+		Watches(&source.Kind{Type: &v1alpha1.TargetAllocator{}}, handler.EnqueueRequestsFromMapFunc(r.referencingInstances))
+
+	// the policy/v1 PodDisruptionBudgets are only served from Kubernetes 1.21 onwards
+	if r.config.PodDisruptionBudgets() {
+		builder = builder.Owns(&policyv1.PodDisruptionBudget{})
+	}
 
 	autoscalingVersion := r.config.AutoscalingVersion()
 	if autoscalingVersion == autodetect.AutoscalingVersionV2 {
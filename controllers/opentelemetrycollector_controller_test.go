@@ -25,8 +25,11 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -284,11 +287,13 @@ func TestBreakOnUnrecoverableError(t *testing.T) {
 	taskCalled := false
 	expectedErr := errors.New("should fail")
 	nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+	recorder := record.NewFakeRecorder(10)
 	reconciler := controllers.NewReconciler(controllers.Params{
-		Client: k8sClient,
-		Log:    logger,
-		Scheme: scheme.Scheme,
-		Config: cfg,
+		Client:   k8sClient,
+		Log:      logger,
+		Scheme:   scheme.Scheme,
+		Config:   cfg,
+		Recorder: recorder,
 		Tasks: []controllers.Task{
 			{
 				Name: "should-fail",
@@ -326,10 +331,391 @@ func TestBreakOnUnrecoverableError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 	assert.True(t, taskCalled)
 
+	actual := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	reconciled := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeReconciled)
+	require.NotNil(t, reconciled)
+	assert.Equal(t, metav1.ConditionFalse, reconciled.Status)
+	assert.Equal(t, "ReconcileFailed", reconciled.Reason)
+	assert.Equal(t, "should fail", reconciled.Message)
+	assert.True(t, meta.IsStatusConditionTrue(actual.Status.Conditions, v1alpha1.ConditionTypeConfigValid))
+
+	// the same failure is only reported once
+	_, err = reconciler.Reconcile(context.Background(), req)
+	assert.Equal(t, expectedErr, err)
+	assert.Len(t, recorder.Events, 1)
+
 	// cleanup
 	assert.NoError(t, k8sClient.Delete(context.Background(), created))
 }
 
+func TestUnmanagedInstance(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	nsn := types.NamespacedName{Name: "my-unmanaged-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "should-not-be-called",
+				Do: func(context.Context, reconcile.Params) error {
+					assert.Fail(t, "should not have been called")
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ManagementState: v1alpha1.ManagementStateUnmanaged,
+		},
+	}
+	err := k8sClient.Create(context.Background(), created)
+	require.NoError(t, err)
+
+	// test
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+
+	actual := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	paused := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypePaused)
+	require.NotNil(t, paused)
+	assert.Equal(t, metav1.ConditionTrue, paused.Status)
+	assert.Equal(t, "Unmanaged", paused.Reason)
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
+func TestPausedInstance(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	taskCalls := 0
+	nsn := types.NamespacedName{Name: "my-paused-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "count-calls",
+				Do: func(context.Context, reconcile.Params) error {
+					taskCalls++
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        nsn.Name,
+			Namespace:   nsn.Namespace,
+			Annotations: map[string]string{v1alpha1.AnnotationReconcile: v1alpha1.ReconcilePaused},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	req := k8sreconcile.Request{NamespacedName: nsn}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, 0, taskCalls)
+	actual := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	paused := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypePaused)
+	require.NotNil(t, paused)
+	assert.Equal(t, metav1.ConditionTrue, paused.Status)
+	assert.Equal(t, "ReconcilePaused", paused.Reason)
+
+	// test
+	delete(actual.Annotations, v1alpha1.AnnotationReconcile)
+	require.NoError(t, k8sClient.Update(context.Background(), actual))
+	_, err = reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, 1, taskCalls)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	assert.Nil(t, meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypePaused))
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
+func TestOnDemandReconcilePolicy(t *testing.T) {
+	// prepare
+	cfg := config.New()
+	taskCalls := 0
+	nsn := types.NamespacedName{Name: "my-on-demand-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "should-be-called-on-demand",
+				Do: func(context.Context, reconcile.Params) error {
+					taskCalls++
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ReconcilePolicy: v1alpha1.ReconcilePolicyOnDemand,
+		},
+	}
+	err := k8sClient.Create(context.Background(), created)
+	require.NoError(t, err)
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, taskCalls)
+
+	triggered := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, triggered))
+	triggered.Annotations = map[string]string{v1alpha1.AnnotationTriggerReconcile: "true"}
+	require.NoError(t, k8sClient.Update(context.Background(), triggered))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, 1, taskCalls)
+	actual := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, actual))
+	assert.NotContains(t, actual.Annotations, v1alpha1.AnnotationTriggerReconcile)
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), created))
+}
+
+func TestOrphanGCPolicy(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithAutoDetect(mockAutoDetector))
+	require.NoError(t, cfg.AutoDetect())
+	nsn := types.NamespacedName{Name: "my-orphan-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "no-op",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			GCPolicy: v1alpha1.GCPolicyOrphan,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+
+	trueVal := true
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-orphan-instance-collector",
+			Namespace: nsn.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":   "default.my-orphan-instance",
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "opentelemetry.io/v1alpha1",
+				Kind:       "OpenTelemetryCollector",
+				Name:       created.Name,
+				UID:        created.UID,
+				Controller: &trueVal,
+			}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), cm))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	withFinalizer := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, withFinalizer))
+	assert.NotEmpty(t, withFinalizer.Finalizers)
+
+	require.NoError(t, k8sClient.Delete(context.Background(), withFinalizer))
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// verify
+	orphaned := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, orphaned))
+	assert.Empty(t, orphaned.OwnerReferences)
+	err = k8sClient.Get(context.Background(), nsn, &v1alpha1.OpenTelemetryCollector{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// cleanup
+	assert.NoError(t, k8sClient.Delete(context.Background(), orphaned))
+}
+
+func TestForegroundGCPolicy(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithAutoDetect(mockAutoDetector))
+	require.NoError(t, cfg.AutoDetect())
+	nsn := types.NamespacedName{Name: "my-foreground-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "no-op",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			GCPolicy: v1alpha1.GCPolicyForeground,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+
+	trueVal := true
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-foreground-instance-collector",
+			Namespace: nsn.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":   "default.my-foreground-instance",
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "opentelemetry.io/v1alpha1",
+				Kind:       "OpenTelemetryCollector",
+				Name:       created.Name,
+				UID:        created.UID,
+				Controller: &trueVal,
+			}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), cm))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	withFinalizer := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, withFinalizer))
+	assert.Contains(t, withFinalizer.Finalizers, "opentelemetry.io/foreground-deletion")
+
+	require.NoError(t, k8sClient.Delete(context.Background(), withFinalizer))
+	result, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	// verify
+	assert.NotZero(t, result.RequeueAfter)
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &v1alpha1.OpenTelemetryCollector{}))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	err = k8sClient.Get(context.Background(), nsn, &v1alpha1.OpenTelemetryCollector{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestBackgroundGCPolicy(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithAutoDetect(mockAutoDetector))
+	require.NoError(t, cfg.AutoDetect())
+	nsn := types.NamespacedName{Name: "my-background-instance", Namespace: "default"}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: scheme.Scheme,
+		Config: cfg,
+		Tasks: []controllers.Task{
+			{
+				Name: "no-op",
+				Do: func(context.Context, reconcile.Params) error {
+					return nil
+				},
+			},
+		},
+	})
+	created := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsn.Name,
+			Namespace: nsn.Namespace,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			GCPolicy: v1alpha1.GCPolicyBackground,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), created))
+	req := k8sreconcile.Request{
+		NamespacedName: nsn,
+	}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	withoutFinalizer := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, withoutFinalizer))
+	assert.Empty(t, withoutFinalizer.Finalizers)
+
+	// verify
+	require.NoError(t, k8sClient.Delete(context.Background(), withoutFinalizer))
+	err = k8sClient.Get(context.Background(), nsn, &v1alpha1.OpenTelemetryCollector{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func TestSkipWhenInstanceDoesNotExist(t *testing.T) {
 	// prepare
 	cfg := config.New()
@@ -387,6 +773,18 @@ func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
 	return m.HPAVersionFunc()
 }
 
+func (m *mockAutoDetect) PodDisruptionBudgets() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) DualStack() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) GRPCProbes() (bool, error) {
+	return true, nil
+}
+
 func (m *mockAutoDetect) Platform() (platform.Platform, error) {
 	if m.PlatformFunc != nil {
 		return m.PlatformFunc()
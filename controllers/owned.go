@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// applyOwned creates the desired object, or patches the existing one with the labels and owner of the desired object,
+// and the changes made by update. The other owner references of the existing object are kept, and an object controlled
+// by another owner isn't changed.
+func applyOwned(ctx context.Context, c client.Client, scheme *runtime.Scheme, log logr.Logger, owner, desired, existing client.Object, update func()) error {
+	if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil && apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		log.V(2).Info("created", "name", desired.GetName(), "namespace", desired.GetNamespace())
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+
+	patch := client.MergeFrom(existing.DeepCopyObject().(client.Object))
+	if err := controllerutil.SetControllerReference(owner, existing, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range desired.GetLabels() {
+		labels[k] = v
+	}
+	existing.SetLabels(labels)
+	update()
+
+	if err := c.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+	log.V(2).Info("applied", "name", desired.GetName(), "namespace", desired.GetNamespace())
+	return nil
+}
+
+// deleteOwned deletes the object when it exists and is controlled by the owner.
+func deleteOwned(ctx context.Context, c client.Client, log logr.Logger, owner, obj client.Object) error {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, owner) {
+		return nil
+	}
+	if err := c.Delete(ctx, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	log.V(2).Info("deleted", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	return nil
+}
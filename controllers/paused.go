@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// pausedCondition returns the condition of the CRs whose reconciliation is paused, by the annotation or by the
+// unmanaged state of a collector.
+func pausedCondition(obj client.Object) metav1.Condition {
+	if otelcol, ok := obj.(*v1alpha1.OpenTelemetryCollector); ok && otelcol.Spec.ManagementState == v1alpha1.ManagementStateUnmanaged {
+		return metav1.Condition{
+			Type:    v1alpha1.ConditionTypePaused,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Unmanaged",
+			Message: "the instance is unmanaged, its resources aren't reconciled",
+		}
+	}
+	return metav1.Condition{
+		Type:    v1alpha1.ConditionTypePaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReconcilePaused",
+		Message: fmt.Sprintf("the reconciliation is paused by the %s annotation", v1alpha1.AnnotationReconcile),
+	}
+}
+
+// updatePaused sets the Paused condition of the CR while its reconciliation is paused, and removes it once it's
+// resumed. The conditions belong to the status of changed, a copy of the original CR. It returns whether the CR is
+// paused, and skipped by the reconciliation.
+func updatePaused(ctx context.Context, c client.Client, original, changed client.Object, conditions *[]metav1.Condition) (bool, error) {
+	paused := v1alpha1.IsReconcilePaused(original)
+	existing := meta.FindStatusCondition(*conditions, v1alpha1.ConditionTypePaused)
+	desired := pausedCondition(original)
+	switch {
+	case paused && (existing == nil || existing.Reason != desired.Reason):
+		meta.SetStatusCondition(conditions, desired)
+	case !paused && existing != nil:
+		meta.RemoveStatusCondition(conditions, v1alpha1.ConditionTypePaused)
+	default:
+		return paused, nil
+	}
+
+	if err := c.Status().Patch(ctx, changed, client.MergeFrom(original)); err != nil {
+		return paused, fmt.Errorf("failed to apply the paused condition: %w", err)
+	}
+	return paused, nil
+}
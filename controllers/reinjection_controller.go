@@ -0,0 +1,329 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+const (
+	// ReinjectionPolicyNone leaves the pods injected from an older generation of their CRs as they are.
+	ReinjectionPolicyNone = "none"
+
+	// ReinjectionPolicyReport sets the RestartPending condition of the CRs some pods are injected from an older
+	// generation of.
+	ReinjectionPolicyReport = "report"
+
+	// ReinjectionPolicyRestart also restarts the Deployments, StatefulSets and DaemonSets of these pods, which are
+	// rolled out following their update strategy.
+	ReinjectionPolicyRestart = "restart"
+
+	// annotationInstrumentationRestartedGenerations and annotationSidecarRestartedGenerations hold, in the pod
+	// template of the restarted workloads, the generations of the CRs they're restarted for, so that a workload isn't
+	// restarted again while it rolls out.
+	annotationInstrumentationRestartedGenerations = "instrumentation.opentelemetry.io/restarted-generations"
+	annotationSidecarRestartedGenerations         = "sidecar.opentelemetry.io/restarted-generations"
+
+	// reinjectionCheckInterval is how often the pods are checked again while some are injected from an older generation.
+	reinjectionCheckInterval = time.Minute
+)
+
+// ReinjectionReconciler reports, and optionally restarts, the workloads whose pods are injected from an older
+// generation of their Instrumentations or sidecar collectors.
+type ReinjectionReconciler struct {
+	client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+	config   config.Config
+}
+
+// NewReinjectionReconciler creates a new reconciler for the pods injected from the Instrumentation and sidecar
+// OpenTelemetryCollector objects.
+func NewReinjectionReconciler(p Params) *ReinjectionReconciler {
+	return &ReinjectionReconciler{
+		Client:   p.Client,
+		log:      p.Log,
+		recorder: p.Recorder,
+		config:   p.Config,
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+
+// ReconcileInstrumentation checks the pods injected from the Instrumentation.
+func (r *ReinjectionReconciler) ReconcileInstrumentation(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var inst v1alpha1.Instrumentation
+	if err := r.Get(ctx, req.NamespacedName, &inst); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if v1alpha1.IsReconcilePaused(&inst) {
+		return ctrl.Result{}, nil
+	}
+
+	// the pods can be injected from the Instrumentations of other namespaces, the index holds the injected ones
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingFields{instrumentation.InjectedInstrumentationsIndex: req.NamespacedName.String()}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the pods: %w", err)
+	}
+	pending, err := r.reinject(ctx, log, &inst, pods.Items, instrumentation.AnnotationInjectedGenerations, annotationInstrumentationRestartedGenerations)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	changed := inst.DeepCopy()
+	if setRestartPendingCondition(&changed.Status.Conditions, pending) {
+		if err := r.Status().Patch(ctx, changed, client.MergeFromWithOptions(&inst, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation: %w", err)
+		}
+	}
+	if len(pending) > 0 {
+		return ctrl.Result{RequeueAfter: reinjectionCheckInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// ReconcileSidecar checks the pods injected from the sidecar OpenTelemetryCollector.
+func (r *ReinjectionReconciler) ReconcileSidecar(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollector", req.NamespacedName)
+
+	var otelcol v1alpha1.OpenTelemetryCollector
+	if err := r.Get(ctx, req.NamespacedName, &otelcol); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollector")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if v1alpha1.IsReconcilePaused(&otelcol) {
+		return ctrl.Result{}, nil
+	}
+
+	var pending []string
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		// the sidecars are only injected from the collectors of the namespace of the pods
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(otelcol.Namespace)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to list the pods: %w", err)
+		}
+		var err error
+		pending, err = r.reinject(ctx, log, &otelcol, pods.Items, sidecar.AnnotationInjectedGenerations, annotationSidecarRestartedGenerations)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	changed := otelcol.DeepCopy()
+	if setRestartPendingCondition(&changed.Status.Conditions, pending) {
+		if err := r.Status().Patch(ctx, changed, client.MergeFromWithOptions(&otelcol, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+		}
+	}
+	if len(pending) > 0 {
+		return ctrl.Result{RequeueAfter: reinjectionCheckInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reinject returns the workloads of the pods injected from an older generation of the CR, and restarts them with the
+// restart policy.
+func (r *ReinjectionReconciler) reinject(ctx context.Context, log logr.Logger, cr client.Object, pods []corev1.Pod, injectedAnnotation, restartedAnnotation string) ([]string, error) {
+	name := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}
+	workloads := map[string]client.Object{}
+	for i := range pods {
+		pod := pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		generation, injected := webhookhandler.ParseInjectedGenerations(pod.Annotations[injectedAnnotation])[name]
+		if !injected || generation >= cr.GetGeneration() {
+			continue
+		}
+		workload, workloadName, err := r.podWorkload(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if workloadName != "" {
+			workloads[workloadName] = workload
+		}
+	}
+
+	pending := make([]string, 0, len(workloads))
+	for workloadName := range workloads {
+		pending = append(pending, workloadName)
+	}
+	sort.Strings(pending)
+	if r.config.ReinjectionPolicy() != ReinjectionPolicyRestart {
+		return pending, nil
+	}
+
+	for _, workloadName := range pending {
+		workload := workloads[workloadName]
+		if workload == nil {
+			log.V(2).Info("the pods can't be restarted through a workload, skipping", "workload", workloadName)
+			continue
+		}
+		restarted, err := r.restart(ctx, workload, name, cr.GetGeneration(), restartedAnnotation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart %s: %w", workloadName, err)
+		}
+		if restarted {
+			r.recorder.Event(cr, corev1.EventTypeNormal, "Restarted", fmt.Sprintf("restarted %s to inject generation %d", workloadName, cr.GetGeneration()))
+			log.V(2).Info("restarted", "workload", workloadName, "generation", cr.GetGeneration())
+		}
+	}
+	return pending, nil
+}
+
+// podWorkload returns the Deployment, StatefulSet or DaemonSet of the pod, with the name reported for it. The
+// workload is nil for the pods which can't be restarted through one, like the pods of Jobs, which are reported
+// with the name of their owner or their own name. The name is empty for the pods of a deleted ReplicaSet.
+func (r *ReinjectionReconciler) podWorkload(ctx context.Context, pod corev1.Pod) (client.Object, string, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return nil, fmt.Sprintf("Pod %s/%s", pod.Namespace, pod.Name), nil
+	}
+	objectMeta := metav1.ObjectMeta{Namespace: pod.Namespace, Name: owner.Name}
+	switch owner.Kind {
+	case "ReplicaSet":
+		replicaSet := &appsv1.ReplicaSet{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}, replicaSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("failed to get the replicaset of the pod %s: %w", pod.Name, err)
+		}
+		deployment := metav1.GetControllerOf(replicaSet)
+		if deployment == nil || deployment.Kind != "Deployment" {
+			return nil, fmt.Sprintf("ReplicaSet %s/%s", pod.Namespace, owner.Name), nil
+		}
+		objectMeta.Name = deployment.Name
+		return &appsv1.Deployment{ObjectMeta: objectMeta}, fmt.Sprintf("Deployment %s/%s", pod.Namespace, deployment.Name), nil
+	case "StatefulSet":
+		return &appsv1.StatefulSet{ObjectMeta: objectMeta}, fmt.Sprintf("StatefulSet %s/%s", pod.Namespace, owner.Name), nil
+	case "DaemonSet":
+		return &appsv1.DaemonSet{ObjectMeta: objectMeta}, fmt.Sprintf("DaemonSet %s/%s", pod.Namespace, owner.Name), nil
+	}
+	return nil, fmt.Sprintf("%s %s/%s", owner.Kind, pod.Namespace, owner.Name), nil
+}
+
+// restart records the generation of the CR in the pod template of the workload, which rolls it out. The workloads
+// already restarted for the generation aren't changed.
+func (r *ReinjectionReconciler) restart(ctx context.Context, workload client.Object, cr types.NamespacedName, generation int64, annotation string) (bool, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(workload), workload); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	existing := workload.DeepCopyObject().(client.Object)
+
+	var template *corev1.PodTemplateSpec
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		template = &w.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &w.Spec.Template
+	case *appsv1.DaemonSet:
+		template = &w.Spec.Template
+	default:
+		return false, fmt.Errorf("the workload %T can't be restarted", workload)
+	}
+
+	restarted := webhookhandler.ParseInjectedGenerations(template.Annotations[annotation])
+	if restarted[cr] >= generation {
+		return false, nil
+	}
+	restarted[cr] = generation
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[annotation] = restarted.String()
+	// the workload is restarted only once per generation, even when it's changed concurrently
+	if err := r.Patch(ctx, workload, client.MergeFromWithOptions(existing, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setRestartPendingCondition sets the RestartPending condition for the pending workloads, or removes it when there's
+// none. It returns whether the conditions changed.
+func setRestartPendingCondition(conditions *[]metav1.Condition, pending []string) bool {
+	existing := meta.FindStatusCondition(*conditions, v1alpha1.ConditionTypeRestartPending)
+	if len(pending) == 0 {
+		if existing == nil {
+			return false
+		}
+		meta.RemoveStatusCondition(conditions, v1alpha1.ConditionTypeRestartPending)
+		return true
+	}
+	message := fmt.Sprintf("the pods of %s are injected from an older generation", strings.Join(pending, ", "))
+	if existing != nil && existing.Message == message {
+		return false
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    v1alpha1.ConditionTypeRestartPending,
+		Status:  metav1.ConditionTrue,
+		Reason:  "OutdatedInjection",
+		Message: message,
+	})
+	return true
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *ReinjectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, instrumentation.InjectedInstrumentationsIndex, instrumentation.InjectedInstrumentations); err != nil {
+		return err
+	}
+
+	// the pod changes don't trigger a check, the pods are checked again after reinjectionCheckInterval while some
+	// are pending
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation-reinjection").
+		For(&v1alpha1.Instrumentation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconcile.Func(r.ReconcileInstrumentation))
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("sidecar-reinjection").
+		For(&v1alpha1.OpenTelemetryCollector{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconcile.Func(r.ReconcileSidecar))
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// indexedPodsClient returns a client reading the pods from a cache with the index of the manager, and waiting for the
+// cached pods injected from the Instrumentation to be the expected ones.
+func indexedPodsClient(t *testing.T, inst types.NamespacedName) (client.Client, func(int)) {
+	informers, err := cache.New(cfg, cache.Options{Scheme: testScheme})
+	require.NoError(t, err)
+	require.NoError(t, informers.IndexField(context.Background(), &corev1.Pod{}, instrumentation.InjectedInstrumentationsIndex, instrumentation.InjectedInstrumentations))
+	cacheCtx, stop := context.WithCancel(context.Background())
+	t.Cleanup(stop)
+	go func() {
+		assert.NoError(t, informers.Start(cacheCtx))
+	}()
+	require.True(t, informers.WaitForCacheSync(cacheCtx))
+
+	c, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+		CacheReader:     informers,
+		Client:          k8sClient,
+		UncachedObjects: []client.Object{&v1alpha1.Instrumentation{}, &appsv1.Deployment{}, &appsv1.ReplicaSet{}},
+	})
+	require.NoError(t, err)
+	waitForPods := func(count int) {
+		assert.Eventually(t, func() bool {
+			pods := &corev1.PodList{}
+			err := c.List(context.Background(), pods, client.MatchingFields{instrumentation.InjectedInstrumentationsIndex: inst.String()})
+			return err == nil && len(pods.Items) == count
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	return c, waitForPods
+}
+
+func TestReinjectionReconciliation(t *testing.T) {
+	// prepare
+	nsn := types.NamespacedName{Name: "my-reinjected-instrumentation", Namespace: "default"}
+	podsClient, waitForPods := indexedPodsClient(t, nsn)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := controllers.NewReinjectionReconciler(controllers.Params{
+		Client:   podsClient,
+		Log:      logger,
+		Recorder: recorder,
+		Config:   config.New(config.WithReinjectionPolicy(controllers.ReinjectionPolicyRestart)),
+	})
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-reinjected-instrumentation",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), inst))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), inst))
+	}()
+	inst.Spec.Exporter.Endpoint = "http://other-collector:4317"
+	require.NoError(t, k8sClient.Update(context.Background(), inst))
+	require.Equal(t, int64(2), inst.Generation)
+
+	trueVal := true
+	labels := map[string]string{"app": "my-reinjected-app"}
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "my-app:1.0"}},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-reinjected-app",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), deployment))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), deployment))
+	}()
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-reinjected-app-1",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+				UID:        deployment.UID,
+				Controller: &trueVal,
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), replicaSet))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), replicaSet))
+	}()
+
+	outdated := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-reinjected-app-1-abcde",
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: map[string]string{instrumentation.AnnotationInjectedGenerations: "default/my-reinjected-instrumentation=1"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       replicaSet.Name,
+				UID:        replicaSet.UID,
+				Controller: &trueVal,
+			}},
+		},
+		Spec: template.Spec,
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), outdated))
+	defer func() {
+		_ = k8sClient.Delete(context.Background(), outdated)
+	}()
+	current := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-reinjected-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{instrumentation.AnnotationInjectedGenerations: "default/my-reinjected-instrumentation=2"},
+		},
+		Spec: template.Spec,
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), current))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), current))
+	}()
+	unrelated := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-uninstrumented-pod",
+			Namespace: "default",
+		},
+		Spec: template.Spec,
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), unrelated))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), unrelated))
+	}()
+	waitForPods(2)
+	req := k8sreconcile.Request{NamespacedName: nsn}
+
+	// test
+	result, err := reconciler.ReconcileInstrumentation(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+	assert.Contains(t, <-recorder.Events, "restarted Deployment default/my-reinjected-app to inject generation 2")
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, deployment))
+	assert.Equal(t, "default/my-reinjected-instrumentation=2", deployment.Spec.Template.Annotations["instrumentation.opentelemetry.io/restarted-generations"])
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, inst))
+	condition := meta.FindStatusCondition(inst.Status.Conditions, v1alpha1.ConditionTypeRestartPending)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "the pods of Deployment default/my-reinjected-app are injected from an older generation", condition.Message)
+
+	// the deployment isn't restarted again while it rolls out
+	_, err = reconciler.ReconcileInstrumentation(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
+	// the pod injected from the first generation is replaced
+	require.NoError(t, k8sClient.Delete(context.Background(), outdated))
+	waitForPods(1)
+	result, err = reconciler.ReconcileInstrumentation(context.Background(), req)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, inst))
+	assert.Nil(t, meta.FindStatusCondition(inst.Status.Conditions, v1alpha1.ConditionTypeRestartPending))
+}
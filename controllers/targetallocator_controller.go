@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/tracing"
+)
+
+// TargetAllocatorReconciler deploys the TargetAllocators shared by the collectors referencing them.
+type TargetAllocatorReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+	config config.Config
+}
+
+// NewTargetAllocatorReconciler creates a new reconciler for TargetAllocator objects.
+func NewTargetAllocatorReconciler(p Params) *TargetAllocatorReconciler {
+	return &TargetAllocatorReconciler{
+		Client: p.Client,
+		log:    p.Log,
+		scheme: p.Scheme,
+		config: p.Config,
+	}
+}
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=targetallocators,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=targetallocators/status,verbs=get;update;patch
+
+// Reconcile deploys the target allocator and records the collectors referencing it.
+func (r *TargetAllocatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	log := r.log.WithValues("targetallocator", req.NamespacedName)
+	ctx, span := tracing.StartReconcile(ctx, "TargetAllocator", req.NamespacedName)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	var ta v1alpha1.TargetAllocator
+	if err := r.Get(ctx, req.NamespacedName, &ta); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch TargetAllocator")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if ta.GetDeletionTimestamp() != nil {
+		// the owned objects are garbage collected along with it
+		return ctrl.Result{}, nil
+	}
+	changed := ta.DeepCopy()
+	if paused, err := updatePaused(ctx, r.Client, &ta, changed, &changed.Status.Conditions); err != nil {
+		return ctrl.Result{}, err
+	} else if paused {
+		log.V(2).Info("skipping the paused target allocator")
+		return ctrl.Result{}, nil
+	}
+	if !featuregate.Gates.Enabled(featuregate.TargetAllocator) {
+		log.V(2).Info("skipping the target allocator, the feature gate is disabled", "featureGate", featuregate.TargetAllocator)
+		return ctrl.Result{}, nil
+	}
+
+	configMap, err := targetallocator.StandaloneConfigMap(r.config, ta)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	existingConfigMap := &corev1.ConfigMap{}
+	if err := applyOwned(ctx, r.Client, r.scheme, log, &ta, &configMap, existingConfigMap, func() {
+		existingConfigMap.Data = configMap.Data
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile the configmap: %w", err)
+	}
+
+	serviceAccount := targetallocator.StandaloneServiceAccount(ta)
+	if ta.Spec.ServiceAccount == "" {
+		if err := applyOwned(ctx, r.Client, r.scheme, log, &ta, &serviceAccount, &corev1.ServiceAccount{}, func() {}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the service account: %w", err)
+		}
+	} else if err := deleteOwned(ctx, r.Client, log, &ta, &serviceAccount); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete the service account: %w", err)
+	}
+
+	deployment := targetallocator.StandaloneDeployment(r.config, ta)
+	existingDeployment := &appsv1.Deployment{}
+	if err := applyOwned(ctx, r.Client, r.scheme, log, &ta, &deployment, existingDeployment, func() {
+		existingDeployment.Spec.Replicas = deployment.Spec.Replicas
+		existingDeployment.Spec.Template = deployment.Spec.Template
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile the deployment: %w", err)
+	}
+
+	service := targetallocator.StandaloneService(ta)
+	existingService := &corev1.Service{}
+	if err := applyOwned(ctx, r.Client, r.scheme, log, &ta, &service, existingService, func() {
+		existingService.Spec.Selector = service.Spec.Selector
+		existingService.Spec.Ports = service.Spec.Ports
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile the service: %w", err)
+	}
+
+	pdb := targetallocator.StandalonePodDisruptionBudget(ta)
+	if !r.config.PodDisruptionBudgets() {
+		log.V(2).Info("skipping the pod disruption budget, the cluster doesn't serve policy/v1")
+	} else if targetallocator.StandaloneHighlyAvailable(ta) {
+		existingPDB := &policyv1.PodDisruptionBudget{}
+		if err := applyOwned(ctx, r.Client, r.scheme, log, &ta, &pdb, existingPDB, func() {
+			existingPDB.Spec = pdb.Spec
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the pod disruption budget: %w", err)
+		}
+	} else if err := deleteOwned(ctx, r.Client, log, &ta, &pdb); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete the pod disruption budget: %w", err)
+	}
+
+	return ctrl.Result{}, r.updateCollectors(ctx, ta)
+}
+
+// updateCollectors records the names of the instances referencing the target allocator in its status.
+func (r *TargetAllocatorReconciler) updateCollectors(ctx context.Context, ta v1alpha1.TargetAllocator) error {
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, list, client.InNamespace(ta.Namespace)); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+	var collectors []string
+	for _, instance := range list.Items {
+		if instance.Spec.TargetAllocatorRef == ta.Name {
+			collectors = append(collectors, instance.Name)
+		}
+	}
+	sort.Strings(collectors)
+	if reflect.DeepEqual(collectors, ta.Status.Collectors) {
+		return nil
+	}
+
+	changed := ta.DeepCopy()
+	changed.Status.Collectors = collectors
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&ta)); err != nil {
+		return fmt.Errorf("failed to apply status changes to the TargetAllocator: %w", err)
+	}
+	return nil
+}
+
+// referencedTargetAllocator returns the TargetAllocator referenced by the instance, which lists it in its status.
+func (r *TargetAllocatorReconciler) referencedTargetAllocator(obj client.Object) []ctrl.Request {
+	instance, ok := obj.(*v1alpha1.OpenTelemetryCollector)
+	if !ok || instance.Spec.TargetAllocatorRef == "" {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.TargetAllocatorRef}}}
+}
+
+// SetupWithManager tells the manager what our controller is interested in.
+func (r *TargetAllocatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := r.config.AutoDetect(); err != nil {
+		return err
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.TargetAllocator{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.Deployment{}).
+		Watches(&source.Kind{Type: &v1alpha1.OpenTelemetryCollector{}}, handler.EnqueueRequestsFromMapFunc(r.referencedTargetAllocator))
+	if r.config.PodDisruptionBudgets() {
+		builder = builder.Owns(&policyv1.PodDisruptionBudget{})
+	}
+	return builder.Complete(r)
+}
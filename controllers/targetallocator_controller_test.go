@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestTargetAllocatorReconciliation(t *testing.T) {
+	// prepare
+	reconciler := controllers.NewTargetAllocatorReconciler(controllers.Params{
+		Client: k8sClient,
+		Log:    logger,
+		Scheme: testScheme,
+		Config: config.New(),
+	})
+	two := int32(2)
+	ta := &v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TargetAllocatorSpec{
+			Replicas:           &two,
+			AllocationStrategy: v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+			Config: `scrape_configs:
+  - job_name: otel-collector
+    static_configs:
+      - targets: ["0.0.0.0:8888"]
+`,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), ta))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), ta))
+	}()
+	otelcol := &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-sharing-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:               v1alpha1.ModeStatefulSet,
+			TargetAllocatorRef: ta.Name,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), otelcol))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), otelcol))
+	}()
+	req := k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: ta.Name, Namespace: ta.Namespace}}
+	nsn := types.NamespacedName{Name: "shared-standalone-ta", Namespace: ta.Namespace}
+
+	// test
+	_, err := reconciler.Reconcile(context.Background(), req)
+
+	// verify
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &corev1.ConfigMap{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &corev1.ServiceAccount{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &corev1.Service{}))
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, &policyv1.PodDisruptionBudget{}))
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, deployment))
+	assert.Equal(t, two, *deployment.Spec.Replicas)
+	require.NoError(t, k8sClient.Get(context.Background(), req.NamespacedName, ta))
+	assert.Equal(t, []string{"my-sharing-instance"}, ta.Status.Collectors)
+
+	// a single replica doesn't need a disruption budget
+	one := int32(1)
+	ta.Spec.Replicas = &one
+	require.NoError(t, k8sClient.Update(context.Background(), ta))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, k8sClient.Get(context.Background(), nsn, deployment))
+	assert.Equal(t, one, *deployment.Spec.Replicas)
+	err = k8sClient.Get(context.Background(), nsn, &policyv1.PodDisruptionBudget{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command check-collector-ready waits until an OpenTelemetryCollector is healthy: its workload has the desired
+// replicas, the health_check extension of its pods responds, and its TargetAllocator serves the assignments.
+//
+//	go run ./hack/check-collector-ready --name=simplest --namespace=default
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// sidecarLabel is set by the operator on the pods its sidecars are injected into.
+const sidecarLabel = "sidecar.opentelemetry.io/injected"
+
+var scheme *k8sruntime.Scheme
+
+func init() {
+	scheme = k8sruntime.NewScheme()
+	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+func main() {
+	var timeout int
+	var kubeconfigPath string
+	var name string
+	var namespace string
+	var checkHealth bool
+	var checkTargetAllocator bool
+
+	defaultKubeconfigPath := filepath.Join(homedir.HomeDir(), ".kube", "config")
+
+	pflag.IntVar(&timeout, "timeout", 300, "The timeout for the check.")
+	pflag.StringVar(&kubeconfigPath, "kubeconfig-path", defaultKubeconfigPath, "Absolute path to the KubeconfigPath file")
+	pflag.StringVar(&name, "name", "", "The name of the OpenTelemetryCollector.")
+	pflag.StringVar(&namespace, "namespace", "default", "The namespace of the OpenTelemetryCollector.")
+	pflag.BoolVar(&checkHealth, "health-check", true, "Check that the health_check extension of the collector pods responds, when the collector has one.")
+	pflag.BoolVar(&checkTargetAllocator, "target-allocator", true, "Check that the TargetAllocator of the collector serves the assignments, when it's enabled.")
+	pflag.Parse()
+
+	if name == "" {
+		fmt.Println("The --name of the OpenTelemetryCollector is required")
+		os.Exit(1)
+	}
+
+	pollInterval := 500 * time.Millisecond
+	timeoutPoll := time.Duration(timeout) * time.Second
+	ctx := context.Background()
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		println("Error reading the kubeconfig:", err.Error())
+		os.Exit(1)
+	}
+
+	clusterClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		println("Creating the Kubernetes client", err)
+		os.Exit(1)
+	}
+	// the pods and services are reached through the proxy of the API server
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		println("Creating the Kubernetes clientset", err)
+		os.Exit(1)
+	}
+
+	// poll retries the check until it passes, printing why it didn't, or until the timeout
+	poll := func(check func() error) {
+		err := wait.Poll(pollInterval, timeoutPoll, func() (done bool, err error) {
+			if err := check(); err != nil {
+				fmt.Println(err)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	instance := otelv1alpha1.OpenTelemetryCollector{}
+	fmt.Printf("Waiting until the OpenTelemetryCollector %s is created\n", key)
+	poll(func() error {
+		return clusterClient.Get(ctx, key, &instance)
+	})
+
+	selector := collector.SelectorLabels(instance)
+	if instance.Spec.Mode == otelv1alpha1.ModeSidecar {
+		// the sidecars are part of the workloads of the pods they're injected into
+		selector = map[string]string{sidecarLabel: fmt.Sprintf("%s.%s", instance.Namespace, instance.Name)}
+	} else {
+		fmt.Printf("Waiting until the %s of the collector has the desired replicas\n", instance.Spec.Mode)
+		poll(func() error {
+			return checkWorkloadReady(ctx, clusterClient, instance)
+		})
+	}
+
+	if checkHealth {
+		fmt.Println("Waiting until the health_check extension of the collector pods responds")
+		poll(func() error {
+			return checkPodsHealthy(ctx, clusterClient, clientset, namespace, selector)
+		})
+	}
+
+	if checkTargetAllocator && instance.Spec.TargetAllocator.Enabled {
+		taKey := client.ObjectKey{Namespace: namespace, Name: naming.TargetAllocator(instance)}
+		fmt.Println("Waiting until the TargetAllocator has the desired replicas")
+		poll(func() error {
+			deployment := &appsv1.Deployment{}
+			if err := clusterClient.Get(ctx, taKey, deployment); err != nil {
+				return err
+			}
+			return checkReplicas(taKey, deployment.Generation, deployment.Status.ObservedGeneration, deployment.Spec.Replicas,
+				deployment.Status.UpdatedReplicas, deployment.Status.ReadyReplicas)
+		})
+
+		if targetallocator.UsesMTLS(instance) {
+			// the proxy of the API server has no client certificate of the TargetAllocator
+			fmt.Println("The TargetAllocator uses mTLS, its assignments aren't checked")
+		} else {
+			fmt.Println("Waiting until the TargetAllocator serves the assignments")
+			poll(func() error {
+				return checkTargetAllocatorServing(ctx, clientset, namespace, naming.TAService(instance))
+			})
+		}
+	}
+
+	fmt.Printf("OpenTelemetryCollector %s is ready!\n", key)
+}
+
+// checkWorkloadReady returns an error until the latest revision of the workload of the collector has the desired
+// ready replicas.
+func checkWorkloadReady(ctx context.Context, c client.Client, instance otelv1alpha1.OpenTelemetryCollector) error {
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: naming.Collector(instance)}
+	switch instance.Spec.Mode {
+	case otelv1alpha1.ModeDaemonSet:
+		daemonSet := &appsv1.DaemonSet{}
+		if err := c.Get(ctx, key, daemonSet); err != nil {
+			return err
+		}
+		desired := daemonSet.Status.DesiredNumberScheduled
+		return checkReplicas(key, daemonSet.Generation, daemonSet.Status.ObservedGeneration, &desired,
+			daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.NumberReady)
+	case otelv1alpha1.ModeStatefulSet:
+		statefulSet := &appsv1.StatefulSet{}
+		if err := c.Get(ctx, key, statefulSet); err != nil {
+			return err
+		}
+		return checkReplicas(key, statefulSet.Generation, statefulSet.Status.ObservedGeneration, statefulSet.Spec.Replicas,
+			statefulSet.Status.UpdatedReplicas, statefulSet.Status.ReadyReplicas)
+	default:
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, key, deployment); err != nil {
+			return err
+		}
+		return checkReplicas(key, deployment.Generation, deployment.Status.ObservedGeneration, deployment.Spec.Replicas,
+			deployment.Status.UpdatedReplicas, deployment.Status.ReadyReplicas)
+	}
+}
+
+// checkReplicas returns an error until the workload controller observed the latest generation, and all the desired
+// replicas are updated and ready.
+func checkReplicas(key client.ObjectKey, generation, observedGeneration int64, desired *int32, updated, ready int32) error {
+	replicas := int32(1)
+	if desired != nil {
+		replicas = *desired
+	}
+	switch {
+	case observedGeneration < generation:
+		return fmt.Errorf("the workload %s isn't observed by its controller yet", key)
+	case updated < replicas:
+		return fmt.Errorf("the workload %s is rolling out, %d of %d replicas are updated", key, updated, replicas)
+	case ready < replicas:
+		return fmt.Errorf("the workload %s has %d of %d replicas ready", key, ready, replicas)
+	}
+	return nil
+}
+
+// checkPodsHealthy returns an error until the health_check extension of each collector pod responds. The extension
+// endpoint is the liveness probe of the collector container, which the operator only sets when the collector has one.
+// It waits for at least one collector pod, so that the sidecars not injected yet aren't reported healthy.
+func checkPodsHealthy(ctx context.Context, c client.Client, clientset kubernetes.Interface, namespace string, selector map[string]string) error {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return err
+	}
+
+	observed := 0
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		observed++
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if container.Name != naming.Container() || container.LivenessProbe == nil || container.LivenessProbe.HTTPGet == nil {
+				continue
+			}
+			probe := container.LivenessProbe.HTTPGet
+			scheme := strings.ToLower(string(probe.Scheme))
+			if scheme == "" {
+				scheme = "http"
+			}
+			if _, err := clientset.CoreV1().Pods(namespace).ProxyGet(scheme, pod.Name, probe.Port.String(), probe.Path, nil).DoRaw(ctx); err != nil {
+				return fmt.Errorf("the health check of the pod %s/%s doesn't respond: %w", namespace, pod.Name, err)
+			}
+		}
+	}
+	if observed == 0 {
+		return fmt.Errorf("no collector pod matching %v is running in the namespace %s yet", selector, namespace)
+	}
+	return nil
+}
+
+// checkTargetAllocatorServing returns an error until the TargetAllocator Service responds with the jobs of the
+// assignments.
+func checkTargetAllocatorServing(ctx context.Context, clientset kubernetes.Interface, namespace, service string) error {
+	body, err := clientset.CoreV1().Services(namespace).ProxyGet("http", service, "targetallocation", "/jobs", nil).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("the TargetAllocator %s/%s doesn't serve the assignments: %w", namespace, service, err)
+	}
+	jobs := map[string]interface{}{}
+	if err := json.Unmarshal(body, &jobs); err != nil {
+		return fmt.Errorf("the TargetAllocator %s/%s responds with invalid jobs: %w", namespace, service, err)
+	}
+	return nil
+}
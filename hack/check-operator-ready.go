@@ -16,51 +16,87 @@ package main
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
+// managedByLabel is set by the mutating webhooks of the operator on the collectors and instrumentations.
+const managedByLabel = "app.kubernetes.io/managed-by"
+
 var scheme *k8sruntime.Scheme
 
 func init() {
 	scheme = k8sruntime.NewScheme()
 	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(appsv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(admissionregistrationv1.AddToScheme(scheme))
 }
 
 func main() {
 	var timeout int
 	var kubeconfigPath string
+	var probeConfigPath string
+	var probeNamespace string
+	var verbose bool
+	var exitOnPermanentError bool
+	var namespace string
+	var deploymentName string
+	var certSecretName string
+	var mutatingWebhookConfiguration string
+	var validatingWebhookConfiguration string
 
 	defaultKubeconfigPath := filepath.Join(homedir.HomeDir(), ".kube", "config")
 
 	pflag.IntVar(&timeout, "timeout", 300, "The timeout for the check.")
 	pflag.StringVar(&kubeconfigPath, "kubeconfig-path", defaultKubeconfigPath, "Absolute path to the KubeconfigPath file")
+	pflag.StringVar(&probeConfigPath, "probe-config", "", "Path to an OpenTelemetryCollector YAML used to probe the webhook instead of a minimal one.")
+	pflag.StringVar(&probeNamespace, "probe-namespace", "default", "The namespace of the probes created with a server-side dry run, when the probe config doesn't set one.")
+	pflag.BoolVar(&verbose, "verbose", false, "Print the admission response of the webhook to the probe.")
+	pflag.BoolVar(&exitOnPermanentError, "exit-on-permanent-error", false, "Stop retrying the checks when they're denied by RBAC or invalid, instead of waiting for the timeout.")
+	pflag.StringVar(&namespace, "namespace", "opentelemetry-operator-system", "The namespace of the operator.")
+	pflag.StringVar(&deploymentName, "deployment", "opentelemetry-operator-controller-manager", "The name of the Deployment of the operator.")
+	pflag.StringVar(&certSecretName, "cert-secret", "opentelemetry-operator-controller-manager-service-cert", "The name of the Secret of the webhook certificate, in the namespace of the operator. The certificate isn't checked when empty.")
+	pflag.StringVar(&mutatingWebhookConfiguration, "mutating-webhook-configuration", "opentelemetry-operator-mutating-webhook-configuration", "The name of the MutatingWebhookConfiguration of the operator. Its CA bundle isn't checked when empty.")
+	pflag.StringVar(&validatingWebhookConfiguration, "validating-webhook-configuration", "opentelemetry-operator-validating-webhook-configuration", "The name of the ValidatingWebhookConfiguration of the operator. Its CA bundle isn't checked when empty.")
 	pflag.Parse()
 
 	pollInterval := 500 * time.Millisecond
 	timeoutPoll := time.Duration(timeout) * time.Second
+	ctx := context.Background()
 
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 	if err != nil {
 		println("Error reading the kubeconfig:", err.Error())
 		os.Exit(1)
 	}
+	if verbose {
+		// the admission warnings are part of the webhook response
+		config.WarningHandler = rest.NewWarningWriter(os.Stdout, rest.WarningWriterOptions{})
+	}
 
 	clusterClient, err := client.New(config, client.Options{Scheme: scheme})
 	if err != nil {
@@ -68,61 +104,257 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println("Waiting until the OTEL Collector Operator is deployed")
-	operatorDeployment := &appsv1.Deployment{}
-
-	err = wait.Poll(pollInterval, timeoutPoll, func() (done bool, err error) {
-		err = clusterClient.Get(
-			context.Background(),
-			client.ObjectKey{
-				Name:      "opentelemetry-operator-controller-manager",
-				Namespace: "opentelemetry-operator-system",
-			},
-			operatorDeployment,
-		)
+	// poll retries the check until it passes, printing why it didn't, or until the timeout
+	poll := func(check func() error) {
+		err := wait.Poll(pollInterval, timeoutPoll, func() (done bool, err error) {
+			if err := check(); err != nil {
+				if reason := permanentErrorReason(err); exitOnPermanentError && reason != "" {
+					return false, fmt.Errorf("%s, not retrying: %w", reason, err)
+				}
+				fmt.Println(err)
+				return false, nil
+			}
+			return true, nil
+		})
 		if err != nil {
 			fmt.Println(err)
-			return false, nil
+			os.Exit(1)
 		}
-		return true, nil
+	}
+
+	fmt.Printf("Waiting until the OTEL Collector Operator %s/%s is available\n", namespace, deploymentName)
+	poll(func() error {
+		return checkDeploymentAvailable(ctx, clusterClient, client.ObjectKey{Namespace: namespace, Name: deploymentName})
 	})
+	fmt.Println("OTEL Collector Operator is deployed properly!")
 
-	if err != nil {
-		fmt.Println(err)
+	var cert *x509.Certificate
+	if certSecretName != "" {
+		fmt.Println("Ensure the webhook certificate is issued")
+		poll(func() error {
+			cert, err = webhookCertificate(ctx, clusterClient, client.ObjectKey{Namespace: namespace, Name: certSecretName})
+			return err
+		})
+	}
+
+	// the CA bundles are injected asynchronously, e.g. by the cert-manager CA injector
+	fmt.Println("Ensure the webhook CA bundles are injected")
+	if mutatingWebhookConfiguration != "" {
+		poll(func() error {
+			webhooks := &admissionregistrationv1.MutatingWebhookConfiguration{}
+			if err := clusterClient.Get(ctx, client.ObjectKey{Name: mutatingWebhookConfiguration}, webhooks); err != nil {
+				return err
+			}
+			for _, webhook := range webhooks.Webhooks {
+				if err := checkCABundle(webhook.Name, webhook.ClientConfig.CABundle, cert); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if validatingWebhookConfiguration != "" {
+		poll(func() error {
+			webhooks := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+			if err := clusterClient.Get(ctx, client.ObjectKey{Name: validatingWebhookConfiguration}, webhooks); err != nil {
+				return err
+			}
+			for _, webhook := range webhooks.Webhooks {
+				if err := checkCABundle(webhook.Name, webhook.ClientConfig.CABundle, cert); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	}
-	fmt.Println("OTEL Collector Operator is deployed properly!")
 
 	// Sometimes, the deployment of the OTEL Operator is ready but, when
 	// creating new instances of the OTEL Collector, the webhook is not reachable
-	// and kubectl apply fails. This code deployes an OTEL Collector instance
-	// until success (or timeout)
+	// and kubectl apply fails. The probes are created with a server-side dry run
+	// until the webhooks admit them (or timeout), so nothing is left behind.
 	collectorInstance := otelv1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "operator-check",
-			Namespace: "default",
+			Namespace: probeNamespace,
 		},
 	}
-
-	// Ensure the collector is not there before the check
-	_ = clusterClient.Delete(context.Background(), &collectorInstance)
+	if probeConfigPath != "" {
+		collectorInstance, err = readProbeConfig(probeConfigPath, probeNamespace)
+		if err != nil {
+			fmt.Println("Error reading the probe config:", err)
+			os.Exit(1)
+		}
+	}
 
 	fmt.Println("Ensure the creation of OTEL Collectors is available")
-	err = wait.Poll(pollInterval, timeoutPoll, func() (done bool, err error) {
-		err = clusterClient.Create(
-			context.Background(),
-			&collectorInstance,
-		)
-		if err != nil {
-			fmt.Println(err)
-			return false, nil
+	poll(func() error {
+		probe := collectorInstance.DeepCopy()
+		if err := checkMutatingWebhook(ctx, clusterClient, probe); err != nil {
+			return err
+		}
+		if verbose {
+			printAdmissionResponse(probe)
+		}
+		// the sidecars don't support priority classes
+		invalid := collectorInstance.DeepCopy()
+		invalid.Spec.Mode = otelv1alpha1.ModeSidecar
+		invalid.Spec.PriorityClassName = "operator-check"
+		return checkValidatingWebhook(ctx, clusterClient, invalid)
+	})
+
+	fmt.Println("Ensure the creation of Instrumentations is available")
+	poll(func() error {
+		instrumentation := otelv1alpha1.Instrumentation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "operator-check",
+				Namespace: probeNamespace,
+			},
 		}
-		return true, nil
+		if err := checkMutatingWebhook(ctx, clusterClient, instrumentation.DeepCopy()); err != nil {
+			return err
+		}
+		// the sampling ratio is between 0 and 1
+		instrumentation.Spec.Sampler = otelv1alpha1.Sampler{Type: otelv1alpha1.TraceIDRatio, Argument: "2"}
+		return checkValidatingWebhook(ctx, clusterClient, &instrumentation)
 	})
 
+	fmt.Println("OTEL Collector Operator is ready!")
+}
+
+// checkDeploymentAvailable returns an error until the latest revision of the Deployment is available.
+func checkDeploymentAvailable(ctx context.Context, c client.Client, key client.ObjectKey) error {
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, key, deployment); err != nil {
+		return err
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return fmt.Errorf("the deployment %s isn't observed by its controller yet", key)
+	}
+	if deployment.Spec.Replicas != nil && deployment.Status.UpdatedReplicas < *deployment.Spec.Replicas {
+		return fmt.Errorf("the deployment %s is rolling out, %d of %d replicas are updated", key, deployment.Status.UpdatedReplicas, *deployment.Spec.Replicas)
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type != appsv1.DeploymentAvailable {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			return nil
+		}
+		return fmt.Errorf("the deployment %s isn't available: %s", key, condition.Message)
+	}
+	return fmt.Errorf("the deployment %s doesn't report its availability yet", key)
+}
+
+// webhookCertificate returns the valid certificate of the Secret of the webhook server.
+func webhookCertificate(ctx context.Context, c client.Client, key client.ObjectKey) (*x509.Certificate, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return nil, err
+	}
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("the secret %s has no %s yet", key, corev1.TLSPrivateKeyKey)
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, fmt.Errorf("the secret %s has no PEM encoded %s yet", key, corev1.TLSCertKey)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return nil, fmt.Errorf("the certificate of the secret %s is invalid: %w", key, err)
+	}
+	if now := time.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("the certificate of the secret %s is only valid from %s to %s", key, cert.NotBefore, cert.NotAfter)
+	}
+	return cert, nil
+}
+
+// checkCABundle returns an error when the CA bundle of the webhook is missing, or doesn't sign the certificate of the
+// webhook server when it's known.
+func checkCABundle(webhook string, caBundle []byte, cert *x509.Certificate) error {
+	if len(caBundle) == 0 {
+		return fmt.Errorf("the CA bundle of the webhook %s isn't injected yet", webhook)
 	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("the CA bundle of the webhook %s has no PEM encoded certificate", webhook)
+	}
+	if cert == nil {
+		return nil
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+		return fmt.Errorf("the CA bundle of the webhook %s doesn't sign the webhook certificate: %w", webhook, err)
+	}
+	return nil
+}
+
+// checkMutatingWebhook creates the probe with a server-side dry run, and returns an error when the mutating webhook
+// didn't default it.
+func checkMutatingWebhook(ctx context.Context, c client.Client, probe client.Object) error {
+	if err := c.Create(ctx, probe, client.DryRunAll); err != nil {
+		return err
+	}
+	if probe.GetLabels()[managedByLabel] == "" {
+		return fmt.Errorf("the mutating webhook didn't default the %T probe", probe)
+	}
+	return nil
+}
 
-	_ = clusterClient.Delete(context.Background(), &collectorInstance)
+// checkValidatingWebhook creates the invalid probe with a server-side dry run, and returns an error unless the
+// validating webhook denied it.
+func checkValidatingWebhook(ctx context.Context, c client.Client, invalid client.Object) error {
+	err := c.Create(ctx, invalid, client.DryRunAll)
+	if err == nil {
+		return fmt.Errorf("the validating webhook admitted the invalid %T probe", invalid)
+	}
+	if !strings.Contains(err.Error(), "denied the request") {
+		// the webhook isn't reachable yet, e.g. connection refused or its certificate isn't trusted yet
+		return err
+	}
+	return nil
+}
+
+// permanentErrorReason describes the errors that retrying the checks can't fix, and returns an empty string for the
+// other errors.
+func permanentErrorReason(err error) string {
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return "the kubeconfig credentials were rejected"
+	case apierrors.IsForbidden(err):
+		return "the check is forbidden by RBAC or the probe is denied by the webhook"
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return "the probe doesn't match the schema of its custom resource"
+	}
+	return ""
+}
+
+// readProbeConfig reads the OpenTelemetryCollector used as the probe, defaulting its name and namespace like the
+// minimal probe.
+func readProbeConfig(path, namespace string) (otelv1alpha1.OpenTelemetryCollector, error) {
+	otelcol := otelv1alpha1.OpenTelemetryCollector{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return otelcol, err
+	}
+	if err := yaml.Unmarshal(data, &otelcol); err != nil {
+		return otelcol, err
+	}
+	if otelcol.Name == "" {
+		otelcol.Name = "operator-check"
+	}
+	if otelcol.Namespace == "" {
+		otelcol.Namespace = namespace
+	}
+	return otelcol, nil
+}
+
+// printAdmissionResponse prints the probe as admitted by the webhooks, including the defaulted fields.
+func printAdmissionResponse(probe *otelv1alpha1.OpenTelemetryCollector) {
+	// the server-set metadata isn't part of the admission
+	probe.ManagedFields = nil
+	out, err := yaml.Marshal(probe)
+	if err != nil {
+		fmt.Println("Error printing the admission response:", err)
+		return
+	}
+	fmt.Printf("The webhook admitted the probe:\n%s", out)
 }
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// The components of the FIPS-validated images, used as keys of the image mapping ConfigMap and, upper cased with the
+// FIPS_IMAGE_ prefix, as env vars of the operator, e.g. FIPS_IMAGE_TARGET_ALLOCATOR.
+const (
+	FIPSImageCollector          = "collector"
+	FIPSImageTargetAllocator    = "target-allocator"
+	FIPSImageConfigReloader     = "config-reloader"
+	FIPSImageOpAMPBridge        = "opamp-bridge"
+	FIPSImageCollectorBuilder   = "collector-builder"
+	FIPSImageImageBuilder       = "image-builder"
+	FIPSImageCollectorBuildBase = "collector-build-base"
+	FIPSImageJava               = "java"
+	FIPSImageNodeJS             = "nodejs"
+	FIPSImagePython             = "python"
+	FIPSImageDotNet             = "dotnet"
+	FIPSImageGo                 = "go"
+	FIPSImageApacheHttpd        = "apache-httpd"
+	FIPSImageNginx              = "nginx"
+)
+
+// FIPSRequiredImageComponents lists the components the operator deploys itself, which need a FIPS-validated image
+// for the operator to run in FIPS mode. The auto-instrumentation of the languages without such an image is skipped
+// instead.
+var FIPSRequiredImageComponents = []string{
+	FIPSImageCollector,
+	FIPSImageTargetAllocator,
+	FIPSImageConfigReloader,
+	FIPSImageOpAMPBridge,
+	FIPSImageCollectorBuilder,
+	FIPSImageImageBuilder,
+	FIPSImageCollectorBuildBase,
+}
+
+// FIPSImageComponents lists the components having a FIPS-validated image.
+var FIPSImageComponents = append(append([]string{}, FIPSRequiredImageComponents...),
+	FIPSImageJava,
+	FIPSImageNodeJS,
+	FIPSImagePython,
+	FIPSImageDotNet,
+	FIPSImageGo,
+	FIPSImageApacheHttpd,
+	FIPSImageNginx,
+)
+
+// fipsEnabledPath is the file of the kernel telling whether the host runs in FIPS mode.
+const fipsEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+// FIPSEnabledOnHost returns whether the kernel of the host running the operator is in FIPS mode.
+func FIPSEnabledOnHost() bool {
+	content, err := os.ReadFile(fipsEnabledPath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(content)) == "1"
+}
+
+// FIPSImageEnvVar returns the env var holding the FIPS-validated image of the given component.
+func FIPSImageEnvVar(component string) string {
+	return "FIPS_IMAGE_" + strings.ToUpper(strings.ReplaceAll(component, "-", "_"))
+}
+
+// FIPSImages returns the FIPS-validated images of the given mapping, overridden by the env vars of the operator.
+func FIPSImages(mapping map[string]string, getenv func(string) string) map[string]string {
+	images := map[string]string{}
+	for _, component := range FIPSImageComponents {
+		if image := strings.TrimSpace(mapping[component]); image != "" {
+			images[component] = image
+		}
+		if image := getenv(FIPSImageEnvVar(component)); image != "" {
+			images[component] = image
+		}
+	}
+	return images
+}
+
+// MissingFIPSImages returns the required components without a FIPS-validated image in the given images.
+func MissingFIPSImages(images map[string]string) []string {
+	var missing []string
+	for _, component := range FIPSRequiredImageComponents {
+		if images[component] == "" {
+			missing = append(missing, component)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestFIPSImageEnvVar(t *testing.T) {
+	assert.Equal(t, "FIPS_IMAGE_COLLECTOR", config.FIPSImageEnvVar(config.FIPSImageCollector))
+	assert.Equal(t, "FIPS_IMAGE_TARGET_ALLOCATOR", config.FIPSImageEnvVar(config.FIPSImageTargetAllocator))
+	assert.Equal(t, "FIPS_IMAGE_APACHE_HTTPD", config.FIPSImageEnvVar(config.FIPSImageApacheHttpd))
+}
+
+func TestFIPSImages(t *testing.T) {
+	// prepare
+	mapping := map[string]string{
+		config.FIPSImageCollector: "registry/collector-fips:1.0",
+		config.FIPSImageJava:      "registry/java-fips:1.0",
+		config.FIPSImagePython:    " ",
+		"unknown":                 "registry/unknown:1.0",
+	}
+	env := map[string]string{
+		"FIPS_IMAGE_JAVA":             "registry/java-fips:2.0",
+		"FIPS_IMAGE_TARGET_ALLOCATOR": "registry/ta-fips:1.0",
+	}
+
+	// test
+	images := config.FIPSImages(mapping, func(key string) string { return env[key] })
+
+	// verify
+	assert.Equal(t, map[string]string{
+		config.FIPSImageCollector:       "registry/collector-fips:1.0",
+		config.FIPSImageJava:            "registry/java-fips:2.0",
+		config.FIPSImageTargetAllocator: "registry/ta-fips:1.0",
+	}, images)
+}
+
+func TestFIPSImage(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithFIPS(true), config.WithFIPSImages(map[string]string{config.FIPSImageJava: "java-fips"}))
+
+	// test
+	java, javaOK := cfg.FIPSImage(config.FIPSImageJava)
+	_, nodeJSOK := cfg.FIPSImage(config.FIPSImageNodeJS)
+
+	// verify
+	assert.True(t, cfg.FIPS())
+	assert.Equal(t, "java-fips", java)
+	assert.True(t, javaOK)
+	assert.False(t, nodeJSOK)
+}
+
+func TestMissingFIPSImages(t *testing.T) {
+	// prepare
+	images := map[string]string{}
+	for _, component := range config.FIPSRequiredImageComponents {
+		images[component] = component + "-fips"
+	}
+
+	// test
+	complete := config.MissingFIPSImages(images)
+	delete(images, config.FIPSImageConfigReloader)
+	incomplete := config.MissingFIPSImages(images)
+
+	// verify
+	assert.Empty(t, complete)
+	assert.Equal(t, []string{config.FIPSImageConfigReloader}, incomplete)
+	assert.NotContains(t, config.FIPSRequiredImageComponents, config.FIPSImageJava)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/components"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
@@ -35,21 +36,37 @@ const (
 
 // Config holds the static configuration for this operator.
 type Config struct {
-	autoDetect                     autodetect.AutoDetect
-	logger                         logr.Logger
-	targetAllocatorImage           string
-	autoInstrumentationPythonImage string
-	collectorImage                 string
-	collectorConfigMapEntry        string
-	autoInstrumentationDotNetImage string
-	targetAllocatorConfigMapEntry  string
-	autoInstrumentationNodeJSImage string
-	autoInstrumentationJavaImage   string
-	onPlatformChange               changeHandler
-	labelsFilter                   []string
-	platform                       platformStore
-	autoDetectFrequency            time.Duration
-	autoscalingVersion             autodetect.AutoscalingVersion
+	autoDetect                          autodetect.AutoDetect
+	logger                              logr.Logger
+	targetAllocatorImage                string
+	opampBridgeImage                    string
+	autoInstrumentationPythonImage      string
+	collectorImage                      string
+	collectorConfigMapEntry             string
+	configReloaderImage                 string
+	autoInstrumentationDotNetImage      string
+	autoInstrumentationGoImage          string
+	autoInstrumentationApacheHttpdImage string
+	autoInstrumentationNginxImage       string
+	targetAllocatorConfigMapEntry       string
+	autoInstrumentationNodeJSImage      string
+	autoInstrumentationJavaImage        string
+	onPlatformChange                    changeHandler
+	labelsFilter                        []string
+	annotationsFilter                   []string
+	reinjectionPolicy                   string
+	platform                            platformStore
+	autoDetectFrequency                 time.Duration
+	autoscalingVersion                  autodetect.AutoscalingVersion
+	podDisruptionBudgets                bool
+	containerRuntime                    string
+	watchNamespaces                     []string
+	collectorBuilderImage               string
+	imageBuilderImage                   string
+	collectorBuildBaseImage             string
+	fipsImages                          map[string]string
+	fips                                bool
+	componentRegistry                   components.Registry
 }
 
 // New constructs a new configuration based on the given options.
@@ -63,6 +80,7 @@ func New(opts ...Option) Config {
 		platform:                      newPlatformWrapper(),
 		version:                       version.Get(),
 		autoscalingVersion:            autodetect.DefaultAutoscalingVersion,
+		podDisruptionBudgets:          true,
 		onPlatformChange:              newOnChange(),
 	}
 	for _, opt := range opts {
@@ -70,21 +88,37 @@ func New(opts ...Option) Config {
 	}
 
 	return Config{
-		autoDetect:                     o.autoDetect,
-		autoDetectFrequency:            o.autoDetectFrequency,
-		collectorImage:                 o.collectorImage,
-		collectorConfigMapEntry:        o.collectorConfigMapEntry,
-		targetAllocatorImage:           o.targetAllocatorImage,
-		targetAllocatorConfigMapEntry:  o.targetAllocatorConfigMapEntry,
-		logger:                         o.logger,
-		onPlatformChange:               o.onPlatformChange,
-		platform:                       o.platform,
-		autoInstrumentationJavaImage:   o.autoInstrumentationJavaImage,
-		autoInstrumentationNodeJSImage: o.autoInstrumentationNodeJSImage,
-		autoInstrumentationPythonImage: o.autoInstrumentationPythonImage,
-		autoInstrumentationDotNetImage: o.autoInstrumentationDotNetImage,
-		labelsFilter:                   o.labelsFilter,
-		autoscalingVersion:             o.autoscalingVersion,
+		autoDetect:                          o.autoDetect,
+		autoDetectFrequency:                 o.autoDetectFrequency,
+		collectorImage:                      o.collectorImage,
+		collectorConfigMapEntry:             o.collectorConfigMapEntry,
+		configReloaderImage:                 o.configReloaderImage,
+		targetAllocatorImage:                o.targetAllocatorImage,
+		opampBridgeImage:                    o.opampBridgeImage,
+		targetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
+		logger:                              o.logger,
+		onPlatformChange:                    o.onPlatformChange,
+		platform:                            o.platform,
+		autoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
+		autoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
+		autoInstrumentationPythonImage:      o.autoInstrumentationPythonImage,
+		autoInstrumentationDotNetImage:      o.autoInstrumentationDotNetImage,
+		autoInstrumentationGoImage:          o.autoInstrumentationGoImage,
+		autoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
+		autoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
+		labelsFilter:                        o.labelsFilter,
+		annotationsFilter:                   o.annotationsFilter,
+		reinjectionPolicy:                   o.reinjectionPolicy,
+		autoscalingVersion:                  o.autoscalingVersion,
+		podDisruptionBudgets:                o.podDisruptionBudgets,
+		containerRuntime:                    o.containerRuntime,
+		watchNamespaces:                     o.watchNamespaces,
+		collectorBuilderImage:               o.collectorBuilderImage,
+		imageBuilderImage:                   o.imageBuilderImage,
+		collectorBuildBaseImage:             o.collectorBuildBaseImage,
+		fipsImages:                          o.fipsImages,
+		fips:                                o.fips,
+		componentRegistry:                   o.componentRegistry,
 	}
 }
 
@@ -132,6 +166,13 @@ func (c *Config) AutoDetect() error {
 	c.autoscalingVersion = hpaVersion
 	c.logger.V(2).Info("autoscaling version detected", "autoscaling-version", c.autoscalingVersion.String())
 
+	podDisruptionBudgets, err := c.autoDetect.PodDisruptionBudgets()
+	if err != nil {
+		return err
+	}
+	c.podDisruptionBudgets = podDisruptionBudgets
+	c.logger.V(2).Info("pod disruption budgets detected", "available", c.podDisruptionBudgets)
+
 	return nil
 }
 
@@ -145,11 +186,42 @@ func (c *Config) CollectorConfigMapEntry() string {
 	return c.collectorConfigMapEntry
 }
 
+// ConfigReloaderImage represents the flag to override the image of the container reloading the collector config.
+func (c *Config) ConfigReloaderImage() string {
+	return c.configReloaderImage
+}
+
+// CollectorBuilderImage returns the Go image running the OpenTelemetry Collector Builder.
+func (c *Config) CollectorBuilderImage() string {
+	return c.collectorBuilderImage
+}
+
+// ImageBuilderImage returns the kaniko image building and pushing the images of the custom collector distributions.
+func (c *Config) ImageBuilderImage() string {
+	return c.imageBuilderImage
+}
+
+// CollectorBuildBaseImage returns the base image of the custom collector distributions.
+func (c *Config) CollectorBuildBaseImage() string {
+	return c.collectorBuildBaseImage
+}
+
+// ComponentRegistry returns the registry of the components of the collector distributions, checked against the
+// components of the collector configs.
+func (c *Config) ComponentRegistry() components.Registry {
+	return c.componentRegistry
+}
+
 // TargetAllocatorImage represents the flag to override the OpenTelemetry TargetAllocator container image.
 func (c *Config) TargetAllocatorImage() string {
 	return c.targetAllocatorImage
 }
 
+// OpAMPBridgeImage represents the flag to override the OpAMP bridge container image.
+func (c *Config) OpAMPBridgeImage() string {
+	return c.opampBridgeImage
+}
+
 // TargetAllocatorConfigMapEntry represents the configuration file name for the TargetAllocator. Immutable.
 func (c *Config) TargetAllocatorConfigMapEntry() string {
 	return c.targetAllocatorConfigMapEntry
@@ -165,6 +237,11 @@ func (c *Config) AutoscalingVersion() autodetect.AutoscalingVersion {
 	return c.autoscalingVersion
 }
 
+// PodDisruptionBudgets returns whether the cluster serves the policy/v1 PodDisruptionBudgets managed by the operator.
+func (c *Config) PodDisruptionBudgets() bool {
+	return c.podDisruptionBudgets
+}
+
 // AutoInstrumentationJavaImage returns OpenTelemetry Java auto-instrumentation container image.
 func (c *Config) AutoInstrumentationJavaImage() string {
 	return c.autoInstrumentationJavaImage
@@ -185,11 +262,72 @@ func (c *Config) AutoInstrumentationDotNetImage() string {
 	return c.autoInstrumentationDotNetImage
 }
 
+// AutoInstrumentationGoImage returns OpenTelemetry Go auto-instrumentation container image.
+func (c *Config) AutoInstrumentationGoImage() string {
+	return c.autoInstrumentationGoImage
+}
+
+// AutoInstrumentationApacheHttpdImage returns OpenTelemetry Apache HTTPD auto-instrumentation container image.
+func (c *Config) AutoInstrumentationApacheHttpdImage() string {
+	return c.autoInstrumentationApacheHttpdImage
+}
+
+// AutoInstrumentationNginxImage returns OpenTelemetry Nginx auto-instrumentation container image.
+func (c *Config) AutoInstrumentationNginxImage() string {
+	return c.autoInstrumentationNginxImage
+}
+
+// ContainerRuntime returns the container runtime the injected init containers are adjusted to.
+func (c *Config) ContainerRuntime() string {
+	return c.containerRuntime
+}
+
+// WatchNamespaces returns the namespaces watched by the operator, all the namespaces are watched when empty.
+func (c *Config) WatchNamespaces() []string {
+	return c.watchNamespaces
+}
+
+// IsWatchedNamespace returns whether the given namespace is watched by the operator.
+func (c *Config) IsWatchedNamespace(namespace string) bool {
+	if len(c.watchNamespaces) == 0 {
+		return true
+	}
+	for _, ns := range c.watchNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// FIPS returns whether the operator runs in FIPS mode.
+func (c *Config) FIPS() bool {
+	return c.fips
+}
+
+// FIPSImage returns the FIPS-validated image of the given component, and whether it's set.
+func (c *Config) FIPSImage(component string) (string, bool) {
+	image, ok := c.fipsImages[component]
+	return image, ok
+}
+
 // Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
 func (c *Config) LabelsFilter() []string {
 	return c.labelsFilter
 }
 
+// AnnotationsFilter returns the filters converted to regex strings used to filter out unwanted annotations from
+// propagations.
+func (c *Config) AnnotationsFilter() []string {
+	return c.annotationsFilter
+}
+
+// ReinjectionPolicy returns what is done with the pods injected from an older generation of their Instrumentations or
+// sidecar collectors.
+func (c *Config) ReinjectionPolicy() string {
+	return c.reinjectionPolicy
+}
+
 // RegisterPlatformChangeCallback registers the given function as a callback that
 // is called when the platform detection detects a change.
 func (c *Config) RegisterPlatformChangeCallback(f func() error) {
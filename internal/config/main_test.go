@@ -41,6 +41,39 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, platform.Kubernetes, cfg.Platform())
 }
 
+func TestFilters(t *testing.T) {
+	// prepare
+	cfg := config.New(
+		config.WithLabelFilters([]string{"app.kubernetes.io/*"}),
+		config.WithAnnotationFilters([]string{"kubectl.kubernetes.io/*", "argocd.argoproj.io/sync-wave"}),
+	)
+
+	// test
+	assert.Equal(t, []string{`app\.kubernetes\.io/.*`}, cfg.LabelsFilter())
+	assert.Equal(t, []string{`kubectl\.kubernetes\.io/.*`, `argocd\.argoproj\.io/sync-wave`}, cfg.AnnotationsFilter())
+}
+
+func TestWatchNamespaces(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		namespaces []string
+		namespace  string
+		expected   bool
+	}{
+		{name: "all namespaces", namespace: "team-a", expected: true},
+		{name: "watched namespace", namespaces: []string{"team-a", "team-b"}, namespace: "team-b", expected: true},
+		{name: "unwatched namespace", namespaces: []string{"team-a"}, namespace: "team-b", expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			cfg := config.New(config.WithWatchNamespaces(tt.namespaces))
+
+			// test and verify
+			assert.Equal(t, tt.expected, cfg.IsWatchedNamespace(tt.namespace))
+		})
+	}
+}
+
 func TestOnPlatformChangeCallback(t *testing.T) {
 	// prepare
 	calledBack := false
@@ -106,6 +139,18 @@ func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
 	return autodetect.DefaultAutoscalingVersion, nil
 }
 
+func (m *mockAutoDetect) PodDisruptionBudgets() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) DualStack() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) GRPCProbes() (bool, error) {
+	return true, nil
+}
+
 func (m *mockAutoDetect) Platform() (platform.Platform, error) {
 	if m.PlatformFunc != nil {
 		return m.PlatformFunc()
@@ -23,6 +23,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/components"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
 )
 
@@ -30,22 +31,38 @@ import (
 type Option func(c *options)
 
 type options struct {
-	autoDetect                     autodetect.AutoDetect
-	version                        version.Version
-	logger                         logr.Logger
-	autoInstrumentationDotNetImage string
-	autoInstrumentationJavaImage   string
-	autoInstrumentationNodeJSImage string
-	autoInstrumentationPythonImage string
-	collectorImage                 string
-	collectorConfigMapEntry        string
-	targetAllocatorConfigMapEntry  string
-	targetAllocatorImage           string
-	onPlatformChange               changeHandler
-	labelsFilter                   []string
-	platform                       platformStore
-	autoDetectFrequency            time.Duration
-	autoscalingVersion             autodetect.AutoscalingVersion
+	autoDetect                          autodetect.AutoDetect
+	version                             version.Version
+	logger                              logr.Logger
+	autoInstrumentationDotNetImage      string
+	autoInstrumentationGoImage          string
+	autoInstrumentationApacheHttpdImage string
+	autoInstrumentationNginxImage       string
+	autoInstrumentationJavaImage        string
+	autoInstrumentationNodeJSImage      string
+	autoInstrumentationPythonImage      string
+	collectorImage                      string
+	collectorConfigMapEntry             string
+	configReloaderImage                 string
+	targetAllocatorConfigMapEntry       string
+	targetAllocatorImage                string
+	opampBridgeImage                    string
+	onPlatformChange                    changeHandler
+	labelsFilter                        []string
+	annotationsFilter                   []string
+	reinjectionPolicy                   string
+	platform                            platformStore
+	autoDetectFrequency                 time.Duration
+	autoscalingVersion                  autodetect.AutoscalingVersion
+	podDisruptionBudgets                bool
+	containerRuntime                    string
+	watchNamespaces                     []string
+	collectorBuilderImage               string
+	imageBuilderImage                   string
+	collectorBuildBaseImage             string
+	fipsImages                          map[string]string
+	fips                                bool
+	componentRegistry                   components.Registry
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -64,11 +81,51 @@ func WithTargetAllocatorImage(s string) Option {
 	}
 }
 
+func WithOpAMPBridgeImage(s string) Option {
+	return func(o *options) {
+		o.opampBridgeImage = s
+	}
+}
+
 func WithCollectorImage(s string) Option {
 	return func(o *options) {
 		o.collectorImage = s
 	}
 }
+
+// WithCollectorBuilderImage sets the Go image running the OpenTelemetry Collector Builder.
+func WithCollectorBuilderImage(s string) Option {
+	return func(o *options) {
+		o.collectorBuilderImage = s
+	}
+}
+
+// WithImageBuilderImage sets the kaniko image building and pushing the images of the custom collector distributions.
+func WithImageBuilderImage(s string) Option {
+	return func(o *options) {
+		o.imageBuilderImage = s
+	}
+}
+
+// WithCollectorBuildBaseImage sets the base image of the custom collector distributions.
+func WithCollectorBuildBaseImage(s string) Option {
+	return func(o *options) {
+		o.collectorBuildBaseImage = s
+	}
+}
+
+// WithComponentRegistry sets the registry of the components of the collector distributions.
+func WithComponentRegistry(r components.Registry) Option {
+	return func(o *options) {
+		o.componentRegistry = r
+	}
+}
+
+func WithConfigReloaderImage(s string) Option {
+	return func(o *options) {
+		o.configReloaderImage = s
+	}
+}
 func WithCollectorConfigMapEntry(s string) Option {
 	return func(o *options) {
 		o.collectorConfigMapEntry = s
@@ -127,27 +184,91 @@ func WithAutoInstrumentationDotNetImage(s string) Option {
 	}
 }
 
+func WithAutoInstrumentationGoImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationGoImage = s
+	}
+}
+
+func WithAutoInstrumentationApacheHttpdImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationApacheHttpdImage = s
+	}
+}
+
+func WithAutoInstrumentationNginxImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationNginxImage = s
+	}
+}
+
+func WithContainerRuntime(s string) Option {
+	return func(o *options) {
+		o.containerRuntime = s
+	}
+}
+
+// WithWatchNamespaces sets the namespaces watched by the operator, all the namespaces are watched when empty.
+func WithWatchNamespaces(namespaces []string) Option {
+	return func(o *options) {
+		o.watchNamespaces = namespaces
+	}
+}
+
+// WithFIPS enables the FIPS mode, substituting the FIPS-validated images of the operands.
+func WithFIPS(enabled bool) Option {
+	return func(o *options) {
+		o.fips = enabled
+	}
+}
+
+// WithFIPSImages sets the FIPS-validated images, keyed by component.
+func WithFIPSImages(images map[string]string) Option {
+	return func(o *options) {
+		o.fipsImages = images
+	}
+}
+
 func WithLabelFilters(labelFilters []string) Option {
 	return func(o *options) {
+		o.labelsFilter = filterRegexps(labelFilters)
+	}
+}
 
-		filters := []string{}
-		for _, pattern := range labelFilters {
-			var result strings.Builder
+// WithAnnotationFilters sets the patterns of the annotations of the instances which aren't propagated to the
+// generated objects, with * matching any characters.
+func WithAnnotationFilters(annotationFilters []string) Option {
+	return func(o *options) {
+		o.annotationsFilter = filterRegexps(annotationFilters)
+	}
+}
 
-			for i, literal := range strings.Split(pattern, "*") {
+// WithReinjectionPolicy sets what is done with the pods injected from an older generation of their Instrumentations
+// or sidecar collectors.
+func WithReinjectionPolicy(policy string) Option {
+	return func(o *options) {
+		o.reinjectionPolicy = policy
+	}
+}
 
-				// Replace * with .*
-				if i > 0 {
-					result.WriteString(".*")
-				}
+// filterRegexps converts the filter patterns, with * matching any characters, to regular expressions.
+func filterRegexps(patterns []string) []string {
+	filters := []string{}
+	for _, pattern := range patterns {
+		var result strings.Builder
 
-				// Quote any regular expression meta characters in the
-				// literal text.
-				result.WriteString(regexp.QuoteMeta(literal))
+		for i, literal := range strings.Split(pattern, "*") {
+
+			// Replace * with .*
+			if i > 0 {
+				result.WriteString(".*")
 			}
-			filters = append(filters, result.String())
-		}
 
-		o.labelsFilter = filters
+			// Quote any regular expression meta characters in the
+			// literal text.
+			result.WriteString(regexp.QuoteMeta(literal))
+		}
+		filters = append(filters, result.String())
 	}
+	return filters
 }
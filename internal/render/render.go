@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render prints the resources the operator creates for the collectors of manifests, without a cluster.
+package render
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// defaultNamespace is the namespace of the input resources without one.
+const defaultNamespace = "default"
+
+// Render reconciles the collectors of the input manifests against an in-memory client, and writes the resources the
+// operator creates for them to out, as YAML documents. The other resources of the input, like the ConfigMaps of the
+// config sources, are read by the reconciliation but aren't written.
+func Render(ctx context.Context, scheme *runtime.Scheme, cfg config.Config, logger logr.Logger, in io.Reader, out io.Writer) error {
+	collectors, inputs, err := decode(scheme, in)
+	if err != nil {
+		return err
+	}
+	if len(collectors) == 0 {
+		return errors.New("the input has no OpenTelemetryCollector")
+	}
+
+	for i := range collectors {
+		// the operator only reconciles the collectors admitted by its webhook
+		collectors[i].Default()
+		if err := collectors[i].ValidateCreate(); err != nil {
+			return fmt.Errorf("the collector %s/%s is invalid: %w", collectors[i].Namespace, collectors[i].Name, err)
+		}
+		inputs = append(inputs, &collectors[i])
+	}
+
+	recorder := &recordingClient{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(inputs...).Build(),
+		scheme: scheme,
+	}
+	reconciler := controllers.NewReconciler(controllers.Params{
+		Client:   recorder,
+		Log:      logger,
+		Scheme:   scheme,
+		Config:   cfg,
+		Recorder: record.NewFakeRecorder(100),
+		// the input files aren't watched, their config sources don't need to be labeled
+		UnwatchedConfigSources: true,
+	})
+	for _, otelcol := range collectors {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			return fmt.Errorf("failed to render the collector %s/%s: %w", otelcol.Namespace, otelcol.Name, err)
+		}
+	}
+
+	return write(out, recorder.objects())
+}
+
+// decode returns the collectors of the input, converted to v1alpha1, and the other resources of the input.
+func decode(scheme *runtime.Scheme, in io.Reader) ([]v1alpha1.OpenTelemetryCollector, []client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(in))
+
+	var collectors []v1alpha1.OpenTelemetryCollector
+	var inputs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the input: %w", err)
+		}
+		if len(doc) == 0 || string(doc) == "---\n" {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			if runtime.IsMissingKind(err) {
+				// empty documents, or comments only
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to decode the input: %w", err)
+		}
+
+		switch o := obj.(type) {
+		case *v1alpha1.OpenTelemetryCollector:
+			collectors = append(collectors, *o)
+		case *v1beta1.OpenTelemetryCollector:
+			converted := v1alpha1.OpenTelemetryCollector{}
+			if err := o.ConvertTo(&converted); err != nil {
+				return nil, nil, fmt.Errorf("failed to convert the collector %s: %w", o.Name, err)
+			}
+			collectors = append(collectors, converted)
+		case client.Object:
+			inputs = append(inputs, o)
+		default:
+			return nil, nil, fmt.Errorf("the input kind %s isn't supported", obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+	}
+
+	for i := range collectors {
+		if collectors[i].Namespace == "" {
+			collectors[i].Namespace = defaultNamespace
+		}
+	}
+	for _, obj := range inputs {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+	}
+	return collectors, inputs, nil
+}
+
+// write writes the objects as YAML documents, without their status and server-side metadata.
+func write(out io.Writer, objects []client.Object) error {
+	for i, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", obj.GetName(), err)
+		}
+		delete(content, "status")
+		if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+			delete(metadata, "resourceVersion")
+		}
+
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordingClient records the objects created, updated or patched through it.
+type recordingClient struct {
+	client.Client
+	scheme  *runtime.Scheme
+	written map[objectKey]client.Object
+}
+
+type objectKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return c.record(obj)
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return c.record(obj)
+}
+
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() == types.ApplyPatchType {
+		return c.apply(ctx, obj)
+	}
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	return c.record(obj)
+}
+
+// apply creates or replaces the applied object, the fake client doesn't support the server-side apply.
+func (c *recordingClient) apply(ctx context.Context, obj client.Object) error {
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("the applied object %s isn't a client object", obj.GetName())
+	}
+	err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		err = c.Client.Create(ctx, obj)
+	case err == nil:
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		err = c.Client.Update(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	return c.record(obj)
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	delete(c.written, objectKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()})
+	return nil
+}
+
+func (c *recordingClient) record(obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	if gvk.Group == v1alpha1.GroupVersion.Group && gvk.Kind == "OpenTelemetryCollector" {
+		// the finalizers and annotations of the rendered collector aren't output
+		return nil
+	}
+
+	copied := obj.DeepCopyObject().(client.Object)
+	copied.GetObjectKind().SetGroupVersionKind(gvk)
+	if c.written == nil {
+		c.written = map[objectKey]client.Object{}
+	}
+	c.written[objectKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}] = copied
+	return nil
+}
+
+// objects returns the written objects, sorted by kind, namespace and name.
+func (c *recordingClient) objects() []client.Object {
+	keys := make([]objectKey, 0, len(c.written))
+	for k := range c.written {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].gvk.Kind != keys[j].gvk.Kind {
+			return keys[i].gvk.Kind < keys[j].gvk.Kind
+		}
+		if keys[i].namespace != keys[j].namespace {
+			return keys[i].namespace < keys[j].namespace
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	objects := make([]client.Object, 0, len(keys))
+	for _, k := range keys {
+		objects = append(objects, c.written[k])
+	}
+	return objects
+}
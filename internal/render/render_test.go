@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const collector = `apiVersion: opentelemetry.io/v1alpha1
+kind: OpenTelemetryCollector
+metadata:
+  name: simplest
+spec:
+  config: |
+    receivers:
+      otlp:
+        protocols:
+          grpc:
+    exporters:
+      logging:
+    extensions:
+      health_check:
+    service:
+      extensions: [health_check]
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [logging]
+`
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestRender(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithCollectorImage("default-collector"))
+	out := &bytes.Buffer{}
+
+	// test
+	err := Render(context.Background(), testScheme(), cfg, logr.Discard(), strings.NewReader(collector), out)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ConfigMap/default/simplest-collector",
+		"Deployment/default/simplest-collector",
+		"Service/default/simplest-collector",
+		"Service/default/simplest-collector-headless",
+		"Service/default/simplest-collector-monitoring",
+		"ServiceAccount/default/simplest-collector",
+	}, rendered(t, out.String()))
+	assert.Contains(t, out.String(), "image: default-collector")
+	assert.NotContains(t, out.String(), "resourceVersion")
+}
+
+func TestRenderWithInputs(t *testing.T) {
+	// prepare
+	input := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared-exporters
+  namespace: observability
+data:
+  exporters.yaml: |
+    exporters:
+      logging:
+---
+apiVersion: opentelemetry.io/v1beta1
+kind: OpenTelemetryCollector
+metadata:
+  name: with-sources
+  namespace: observability
+spec:
+  mode: statefulset
+  configSources:
+    - configMapKeyRef:
+        name: shared-exporters
+        key: exporters.yaml
+  config:
+    receivers:
+      otlp:
+        protocols:
+          http:
+    extensions:
+      health_check:
+    service:
+      extensions: [health_check]
+      pipelines:
+        traces:
+          receivers: [otlp]
+          exporters: [logging]
+`
+	out := &bytes.Buffer{}
+
+	// test
+	err := Render(context.Background(), testScheme(), config.New(), logr.Discard(), strings.NewReader(input), out)
+
+	// verify
+	require.NoError(t, err)
+	objects := rendered(t, out.String())
+	assert.Contains(t, objects, "StatefulSet/observability/with-sources-collector")
+	assert.NotContains(t, objects, "ConfigMap/observability/shared-exporters")
+	assert.Contains(t, out.String(), "logging:")
+}
+
+func TestRenderInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		input       string
+		expectedErr string
+	}{
+		{
+			desc:        "no collector",
+			input:       "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+			expectedErr: "the input has no OpenTelemetryCollector",
+		},
+		{
+			desc:        "rejected by the webhook",
+			input:       strings.Replace(collector, "spec:\n", "spec:\n  mode: sidecar\n  priorityClassName: high\n", 1),
+			expectedErr: "the collector default/simplest is invalid",
+		},
+		{
+			desc:        "not a manifest",
+			input:       "- not a manifest",
+			expectedErr: "failed to decode the input",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			err := Render(context.Background(), testScheme(), config.New(), logr.Discard(), strings.NewReader(tt.input), &bytes.Buffer{})
+
+			// verify
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// rendered returns the kind, namespace and name of the rendered objects.
+func rendered(t *testing.T, out string) []string {
+	var objects []string
+	for _, doc := range strings.Split(out, "---\n") {
+		obj := map[string]interface{}{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj))
+		metadata := obj["metadata"].(map[string]interface{})
+		objects = append(objects, fmt.Sprintf("%s/%s/%s", obj["kind"], metadata["namespace"], metadata["name"]))
+	}
+	return objects
+}
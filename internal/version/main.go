@@ -21,56 +21,68 @@ import (
 )
 
 var (
-	version                   string
-	buildDate                 string
-	otelCol                   string
-	targetAllocator           string
-	autoInstrumentationJava   string
-	autoInstrumentationNodeJS string
-	autoInstrumentationPython string
-	autoInstrumentationDotNet string
+	version                        string
+	buildDate                      string
+	otelCol                        string
+	targetAllocator                string
+	operatorOpAMPBridge            string
+	autoInstrumentationJava        string
+	autoInstrumentationNodeJS      string
+	autoInstrumentationPython      string
+	autoInstrumentationDotNet      string
+	autoInstrumentationGo          string
+	autoInstrumentationApacheHttpd string
 )
 
 // Version holds this Operator's version as well as the version of some of the components it uses.
 type Version struct {
-	Operator                  string `json:"opentelemetry-operator"`
-	BuildDate                 string `json:"build-date"`
-	OpenTelemetryCollector    string `json:"opentelemetry-collector-version"`
-	Go                        string `json:"go-version"`
-	TargetAllocator           string `json:"target-allocator-version"`
-	AutoInstrumentationJava   string `json:"auto-instrumentation-java"`
-	AutoInstrumentationNodeJS string `json:"auto-instrumentation-nodejs"`
-	AutoInstrumentationPython string `json:"auto-instrumentation-python"`
-	AutoInstrumentationDotNet string `json:"auto-instrumentation-dotnet"`
+	Operator                       string `json:"opentelemetry-operator"`
+	BuildDate                      string `json:"build-date"`
+	OpenTelemetryCollector         string `json:"opentelemetry-collector-version"`
+	Go                             string `json:"go-version"`
+	TargetAllocator                string `json:"target-allocator-version"`
+	OperatorOpAMPBridge            string `json:"operator-opamp-bridge-version"`
+	AutoInstrumentationJava        string `json:"auto-instrumentation-java"`
+	AutoInstrumentationNodeJS      string `json:"auto-instrumentation-nodejs"`
+	AutoInstrumentationPython      string `json:"auto-instrumentation-python"`
+	AutoInstrumentationDotNet      string `json:"auto-instrumentation-dotnet"`
+	AutoInstrumentationGo          string `json:"auto-instrumentation-go"`
+	AutoInstrumentationApacheHttpd string `json:"auto-instrumentation-apache-httpd"`
 }
 
 // Get returns the Version object with the relevant information.
 func Get() Version {
 	return Version{
-		Operator:                  version,
-		BuildDate:                 buildDate,
-		OpenTelemetryCollector:    OpenTelemetryCollector(),
-		Go:                        runtime.Version(),
-		TargetAllocator:           TargetAllocator(),
-		AutoInstrumentationJava:   AutoInstrumentationJava(),
-		AutoInstrumentationNodeJS: AutoInstrumentationNodeJS(),
-		AutoInstrumentationPython: AutoInstrumentationPython(),
-		AutoInstrumentationDotNet: AutoInstrumentationDotNet(),
+		Operator:                       version,
+		BuildDate:                      buildDate,
+		OpenTelemetryCollector:         OpenTelemetryCollector(),
+		Go:                             runtime.Version(),
+		TargetAllocator:                TargetAllocator(),
+		OperatorOpAMPBridge:            OperatorOpAMPBridge(),
+		AutoInstrumentationJava:        AutoInstrumentationJava(),
+		AutoInstrumentationNodeJS:      AutoInstrumentationNodeJS(),
+		AutoInstrumentationPython:      AutoInstrumentationPython(),
+		AutoInstrumentationDotNet:      AutoInstrumentationDotNet(),
+		AutoInstrumentationGo:          AutoInstrumentationGo(),
+		AutoInstrumentationApacheHttpd: AutoInstrumentationApacheHttpd(),
 	}
 }
 
 func (v Version) String() string {
 	return fmt.Sprintf(
-		"Version(Operator='%v', BuildDate='%v', OpenTelemetryCollector='%v', Go='%v', TargetAllocator='%v', AutoInstrumentationJava='%v', AutoInstrumentationNodeJS='%v', AutoInstrumentationPython='%v', AutoInstrumentationDotNet='%v')",
+		"Version(Operator='%v', BuildDate='%v', OpenTelemetryCollector='%v', Go='%v', TargetAllocator='%v', OperatorOpAMPBridge='%v', AutoInstrumentationJava='%v', AutoInstrumentationNodeJS='%v', AutoInstrumentationPython='%v', AutoInstrumentationDotNet='%v', AutoInstrumentationGo='%v', AutoInstrumentationApacheHttpd='%v')",
 		v.Operator,
 		v.BuildDate,
 		v.OpenTelemetryCollector,
 		v.Go,
 		v.TargetAllocator,
+		v.OperatorOpAMPBridge,
 		v.AutoInstrumentationJava,
 		v.AutoInstrumentationNodeJS,
 		v.AutoInstrumentationPython,
 		v.AutoInstrumentationDotNet,
+		v.AutoInstrumentationGo,
+		v.AutoInstrumentationApacheHttpd,
 	)
 }
 
@@ -96,6 +108,17 @@ func TargetAllocator() string {
 	return "0.0.0"
 }
 
+// OperatorOpAMPBridge returns the default OpAMP bridge version to use when no versions are specified via CLI or configuration.
+func OperatorOpAMPBridge() string {
+	if len(operatorOpAMPBridge) > 0 {
+		// this should always be set, as it's specified during the build
+		return operatorOpAMPBridge
+	}
+
+	// fallback value, useful for tests
+	return "0.0.0"
+}
+
 func AutoInstrumentationJava() string {
 	if len(autoInstrumentationJava) > 0 {
 		return autoInstrumentationJava
@@ -123,3 +146,17 @@ func AutoInstrumentationDotNet() string {
 	}
 	return "0.0.0"
 }
+
+func AutoInstrumentationGo() string {
+	if len(autoInstrumentationGo) > 0 {
+		return autoInstrumentationGo
+	}
+	return "0.0.0"
+}
+
+func AutoInstrumentationApacheHttpd() string {
+	if len(autoInstrumentationApacheHttpd) > 0 {
+		return autoInstrumentationApacheHttpd
+	}
+	return "0.0.0"
+}
@@ -50,6 +50,21 @@ func TestTargetAllocatorVersionFromBuild(t *testing.T) {
 	assert.Contains(t, Get().String(), targetAllocator)
 }
 
+func TestOperatorOpAMPBridgeFallbackVersion(t *testing.T) {
+	assert.Equal(t, "0.0.0", OperatorOpAMPBridge())
+}
+
+func TestOperatorOpAMPBridgeVersionFromBuild(t *testing.T) {
+	// prepare
+	operatorOpAMPBridge = "0.0.2" // set during the build
+	defer func() {
+		operatorOpAMPBridge = ""
+	}()
+
+	assert.Equal(t, operatorOpAMPBridge, OperatorOpAMPBridge())
+	assert.Contains(t, Get().String(), operatorOpAMPBridge)
+}
+
 func TestAutoInstrumentationJavaFallbackVersion(t *testing.T) {
 	assert.Equal(t, "0.0.0", AutoInstrumentationJava())
 }
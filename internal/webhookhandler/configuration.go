@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodWebhookName is the name of the pod webhook in the MutatingWebhookConfiguration of the operator.
+const PodWebhookName = "mpod.kb.io"
+
+// The operator is only allowed to patch its own MutatingWebhookConfiguration, the Helm chart substitutes the name of its release.
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,resourceNames=opentelemetry-operator-mutating-webhook-configuration,verbs=get;patch
+
+// PodWebhookSettings holds the settings of the pod webhook managed by the operator, the unset ones are left as installed.
+type PodWebhookSettings struct {
+	FailurePolicy      *admissionregistrationv1.FailurePolicyType
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType
+	TimeoutSeconds     *int32
+	NamespaceSelector  *metav1.LabelSelector
+	ObjectSelector     *metav1.LabelSelector
+}
+
+// IsEmpty returns whether none of the settings is set.
+func (s PodWebhookSettings) IsEmpty() bool {
+	return s == PodWebhookSettings{}
+}
+
+// Validate checks the policies of the settings.
+func (s PodWebhookSettings) Validate() error {
+	if s.FailurePolicy != nil && *s.FailurePolicy != admissionregistrationv1.Fail && *s.FailurePolicy != admissionregistrationv1.Ignore {
+		return fmt.Errorf("the failure policy of the pod webhook must be %s or %s, got %s", admissionregistrationv1.Fail, admissionregistrationv1.Ignore, *s.FailurePolicy)
+	}
+	if s.ReinvocationPolicy != nil && *s.ReinvocationPolicy != admissionregistrationv1.NeverReinvocationPolicy && *s.ReinvocationPolicy != admissionregistrationv1.IfNeededReinvocationPolicy {
+		return fmt.Errorf("the reinvocation policy of the pod webhook must be %s or %s, got %s", admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy, *s.ReinvocationPolicy)
+	}
+	// the API server accepts timeouts between 1 and 30 seconds
+	if s.TimeoutSeconds != nil && (*s.TimeoutSeconds < 1 || *s.TimeoutSeconds > 30) {
+		return fmt.Errorf("the timeout of the pod webhook must be between 1 and 30 seconds, got %d", *s.TimeoutSeconds)
+	}
+	return nil
+}
+
+// ApplyPodWebhookSettings patches the pod webhook of the given MutatingWebhookConfiguration with the settings, retrying
+// on conflicts.
+func ApplyPodWebhookSettings(ctx context.Context, reader client.Reader, cl client.Client, name string, settings PodWebhookSettings) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: name}, existing); err != nil {
+			return fmt.Errorf("failed to get the mutating webhook configuration %s: %w", name, err)
+		}
+
+		updated := existing.DeepCopy()
+		found := false
+		for i := range updated.Webhooks {
+			webhook := &updated.Webhooks[i]
+			if webhook.Name != PodWebhookName {
+				continue
+			}
+			found = true
+			if settings.FailurePolicy != nil {
+				webhook.FailurePolicy = settings.FailurePolicy
+			}
+			if settings.ReinvocationPolicy != nil {
+				webhook.ReinvocationPolicy = settings.ReinvocationPolicy
+			}
+			if settings.TimeoutSeconds != nil {
+				webhook.TimeoutSeconds = settings.TimeoutSeconds
+			}
+			if settings.NamespaceSelector != nil {
+				webhook.NamespaceSelector = settings.NamespaceSelector
+			}
+			if settings.ObjectSelector != nil {
+				webhook.ObjectSelector = settings.ObjectSelector
+			}
+		}
+		if !found {
+			return fmt.Errorf("the mutating webhook configuration %s has no webhook %s", name, PodWebhookName)
+		}
+
+		// the merge patch replaces the whole list of webhooks, the lock prevents overwriting a concurrent change such as the CA injection
+		if err := cl.Patch(ctx, updated, client.MergeFromWithOptions(existing, client.MergeFromWithOptimisticLock{})); err != nil {
+			return fmt.Errorf("failed to patch the mutating webhook configuration %s: %w", name, err)
+		}
+		return nil
+	})
+}
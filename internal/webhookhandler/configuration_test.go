@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+)
+
+func TestApplyPodWebhookSettings(t *testing.T) {
+	// prepare
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	ignore := admissionregistrationv1.Ignore
+	webhook := func(name string) admissionregistrationv1.MutatingWebhook {
+		return admissionregistrationv1.MutatingWebhook{
+			Name:                    name,
+			AdmissionReviewVersions: []string{"v1"},
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{Name: "webhook-service", Namespace: "default"},
+			},
+			FailurePolicy: &ignore,
+			SideEffects:   &sideEffects,
+		}
+	}
+	existing := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-operator-mutation"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{webhook("minstrumentation.kb.io"), webhook(PodWebhookName)},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), existing))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), existing))
+	}()
+
+	fail := admissionregistrationv1.Fail
+	five := int32(5)
+	ifNeeded := admissionregistrationv1.IfNeededReinvocationPolicy
+	settings := PodWebhookSettings{
+		FailurePolicy:      &fail,
+		ReinvocationPolicy: &ifNeeded,
+		TimeoutSeconds:     &five,
+		NamespaceSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team-a"}},
+	}
+
+	// test
+	err := ApplyPodWebhookSettings(context.Background(), k8sClient, k8sClient, existing.Name, settings)
+	require.NoError(t, err)
+
+	// verify
+	actual := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: existing.Name}, actual))
+	require.Len(t, actual.Webhooks, 2)
+	assert.Equal(t, ignore, *actual.Webhooks[0].FailurePolicy)
+	assert.Equal(t, fail, *actual.Webhooks[1].FailurePolicy)
+	assert.Equal(t, ifNeeded, *actual.Webhooks[1].ReinvocationPolicy)
+	assert.Equal(t, int32(5), *actual.Webhooks[1].TimeoutSeconds)
+	assert.Equal(t, settings.NamespaceSelector, actual.Webhooks[1].NamespaceSelector)
+	// the unset settings are left as installed
+	assert.Equal(t, &metav1.LabelSelector{}, actual.Webhooks[1].ObjectSelector)
+}
+
+func TestApplyPodWebhookSettingsMissingConfiguration(t *testing.T) {
+	// prepare
+	five := int32(5)
+
+	// test
+	err := ApplyPodWebhookSettings(context.Background(), k8sClient, k8sClient, "missing", PodWebhookSettings{TimeoutSeconds: &five})
+
+	// verify
+	assert.ErrorContains(t, err, "failed to get the mutating webhook configuration missing")
+}
+
+func TestValidatePodWebhookSettings(t *testing.T) {
+	invalidFailurePolicy := admissionregistrationv1.FailurePolicyType("Retry")
+	invalidReinvocationPolicy := admissionregistrationv1.ReinvocationPolicyType("Always")
+	thirty, thirtyOne := int32(30), int32(31)
+	for _, tt := range []struct {
+		name        string
+		settings    PodWebhookSettings
+		expectedErr string
+	}{
+		{name: "empty"},
+		{name: "valid timeout", settings: PodWebhookSettings{TimeoutSeconds: &thirty}},
+		{name: "invalid timeout", settings: PodWebhookSettings{TimeoutSeconds: &thirtyOne}, expectedErr: "between 1 and 30 seconds"},
+		{name: "invalid failure policy", settings: PodWebhookSettings{FailurePolicy: &invalidFailurePolicy}, expectedErr: "must be Fail or Ignore"},
+		{name: "invalid reinvocation policy", settings: PodWebhookSettings{ReinvocationPolicy: &invalidReinvocationPolicy}, expectedErr: "must be Never or IfNeeded"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			err := tt.settings.Validate()
+
+			// verify
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// InjectedGenerations are the generations of the CRs a pod is injected from, keyed by CR. They're kept in an
+// annotation of the pod, so that the pods injected from an older generation of the CRs can be found.
+type InjectedGenerations map[types.NamespacedName]int64
+
+// ParseInjectedGenerations parses the generations of a pod annotation, as formatted by String. The malformed entries
+// are skipped.
+func ParseInjectedGenerations(value string) InjectedGenerations {
+	generations := InjectedGenerations{}
+	for _, entry := range strings.Split(value, ",") {
+		name, generation, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		namespace, name, ok := strings.Cut(name, "/")
+		if !ok || namespace == "" || name == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(generation, 10, 64)
+		if err != nil {
+			continue
+		}
+		generations[types.NamespacedName{Namespace: namespace, Name: name}] = parsed
+	}
+	return generations
+}
+
+// String formats the generations as the comma-separated namespace/name=generation of the CRs, sorted by CR.
+func (g InjectedGenerations) String() string {
+	entries := make([]string, 0, len(g))
+	for cr, generation := range g {
+		entries = append(entries, cr.String()+"="+strconv.FormatInt(generation, 10))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookhandler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+)
+
+func TestInjectedGenerations(t *testing.T) {
+	// prepare
+	generations := InjectedGenerations{
+		{Namespace: "observability", Name: "java"}: 3,
+		{Namespace: "default", Name: "python"}:     1,
+	}
+
+	// test
+	value := generations.String()
+	parsed := ParseInjectedGenerations(value + ",malformed,default/=2,default/nodejs=x")
+
+	// verify
+	assert.Equal(t, "default/python=1,observability/java=3", value)
+	assert.Equal(t, generations, parsed)
+	assert.Empty(t, ParseInjectedGenerations(""))
+	assert.Equal(t, InjectedGenerations{types.NamespacedName{Namespace: "default", Name: "sidecar"}: 7}, ParseInjectedGenerations("default/sidecar=7"))
+}
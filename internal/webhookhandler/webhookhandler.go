@@ -18,15 +18,20 @@ package webhookhandler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/tracing"
 )
 
 // +kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=ignore,groups="",resources=pods,verbs=create;update,versions=v1,name=mpod.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -34,6 +39,7 @@ import (
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="batch",resources=jobs;cronjobs,verbs=get;list;watch
 
 var _ WebhookHandler = (*podSidecarInjector)(nil)
 
@@ -68,6 +74,17 @@ func NewWebhookHandler(cfg config.Config, logger logr.Logger, cl client.Client,
 }
 
 func (p *podSidecarInjector) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx, span := tracing.Tracer().Start(ctx, "Mutate Pod", trace.WithAttributes(
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("opentelemetry-operator.admission.operation", string(req.Operation)),
+	))
+	defer span.End()
+
+	// the pods of the namespaces not watched by this operator are left to the operator watching them
+	if !p.config.IsWatchedNamespace(req.Namespace) {
+		return admission.Allowed("the namespace isn't watched by the operator")
+	}
+
 	pod := corev1.Pod{}
 	err := p.decoder.Decode(req, &pod)
 	if err != nil {
@@ -89,7 +106,11 @@ func (p *podSidecarInjector) Handle(ctx context.Context, req admission.Request)
 	}
 
 	for _, m := range p.podMutators {
-		pod, err = m.Mutate(ctx, ns, pod)
+		mutatorCtx, mutatorSpan := tracing.Tracer().Start(ctx, "PodMutator", trace.WithAttributes(
+			attribute.String("opentelemetry-operator.admission.mutator", fmt.Sprintf("%T", m)),
+		))
+		pod, err = m.Mutate(mutatorCtx, ns, pod)
+		tracing.End(mutatorSpan, err)
 		if err != nil {
 			res := admission.Errored(http.StatusInternalServerError, err)
 			res.Allowed = true
@@ -98,6 +119,9 @@ func (p *podSidecarInjector) Handle(ctx context.Context, req admission.Request)
 	}
 
 	marshaledPod, err := json.Marshal(pod)
+	if err == nil {
+		marshaledPod, err = withInitContainerRestartPolicies(req.Object.Raw, marshaledPod)
+	}
 	if err != nil {
 		res := admission.Errored(http.StatusInternalServerError, err)
 		res.Allowed = true
@@ -110,3 +134,56 @@ func (p *podSidecarInjector) InjectDecoder(d *admission.Decoder) error {
 	p.decoder = d
 	return nil
 }
+
+// withInitContainerRestartPolicies sets the restartPolicy of the init containers of the mutated pod, which the core API
+// of the operator predates: the native sidecars of the original pod keep their policy, and the collector injected as
+// an init container restarts always.
+func withInitContainerRestartPolicies(original, mutated []byte) ([]byte, error) {
+	var mutatedPod map[string]interface{}
+	if err := json.Unmarshal(mutated, &mutatedPod); err != nil {
+		return nil, err
+	}
+	spec, _ := mutatedPod["spec"].(map[string]interface{})
+	initContainers, _ := spec["initContainers"].([]interface{})
+	if len(initContainers) == 0 {
+		return mutated, nil
+	}
+
+	var originalPod struct {
+		Spec struct {
+			InitContainers []struct {
+				Name          string `json:"name"`
+				RestartPolicy string `json:"restartPolicy"`
+			} `json:"initContainers"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(original, &originalPod); err != nil {
+		return nil, err
+	}
+	policies := map[string]string{}
+	for _, c := range originalPod.Spec.InitContainers {
+		if c.RestartPolicy != "" {
+			policies[c.Name] = c.RestartPolicy
+		}
+	}
+	if _, ok := policies[naming.Container()]; !ok {
+		policies[naming.Container()] = "Always"
+	}
+
+	changed := false
+	for _, item := range initContainers {
+		c, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := c["name"].(string)
+		if policy, ok := policies[name]; ok {
+			c["restartPolicy"] = policy
+			changed = true
+		}
+	}
+	if !changed {
+		return mutated, nil
+	}
+	return json.Marshal(mutatedPod)
+}
@@ -192,6 +192,119 @@ func TestShouldInjectSidecar(t *testing.T) {
 	}
 }
 
+func TestShouldInjectNativeSidecar(t *testing.T) {
+	// prepare
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-namespace-native-sidecar",
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &ns))
+	defer func() {
+		_ = k8sClient.Delete(context.Background(), &ns)
+	}()
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: ns.Name,
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:          v1alpha1.ModeSidecar,
+			NativeSidecar: true,
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &otelcol))
+	defer func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), &otelcol))
+	}()
+
+	cfg := config.New()
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+	injector := NewWebhookHandler(cfg, logger, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+	require.NoError(t, injector.InjectDecoder(decoder))
+
+	for _, tt := range []struct {
+		name string
+		pod  corev1.Pod
+		// the init containers restarting always, the restartPolicy of the containers isn't part of the core API used by the operator
+		nativeSidecars  []string
+		expectedPatches int
+	}{
+		{
+			name: "inject as an init container restarting always",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{sidecar.Annotation: "my-instance"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "my-app"}},
+				},
+			},
+			expectedPatches: 3,
+		},
+		{
+			name: "keep the restart policy of the existing init containers",
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{sidecar.Annotation: "false"},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "my-proxy"}},
+					Containers:     []corev1.Container{{Name: "my-app"}},
+				},
+			},
+			nativeSidecars:  []string{"my-proxy"},
+			expectedPatches: 0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.pod)
+			require.NoError(t, err)
+			if len(tt.nativeSidecars) > 0 {
+				var raw map[string]interface{}
+				require.NoError(t, json.Unmarshal(encoded, &raw))
+				initContainers := raw["spec"].(map[string]interface{})["initContainers"].([]interface{})
+				for i, name := range tt.nativeSidecars {
+					initContainer := initContainers[i].(map[string]interface{})
+					require.Equal(t, name, initContainer["name"])
+					initContainer["restartPolicy"] = "Always"
+				}
+				encoded, err = json.Marshal(raw)
+				require.NoError(t, err)
+			}
+
+			req := admission.Request{
+				AdmissionRequest: admv1.AdmissionRequest{
+					Namespace: ns.Name,
+					Object: runtime.RawExtension{
+						Raw: encoded,
+					},
+				},
+			}
+
+			// test
+			res := injector.Handle(context.Background(), req)
+
+			// verify
+			assert.True(t, res.Allowed)
+			assert.Nil(t, res.AdmissionResponse.Result)
+			assert.Len(t, res.Patches, tt.expectedPatches)
+			for _, patch := range res.Patches {
+				if patch.Path == "/spec/initContainers" {
+					initContainers, ok := patch.Value.([]interface{})
+					require.True(t, ok)
+					require.Len(t, initContainers, 1)
+					initContainer := initContainers[0].(map[string]interface{})
+					assert.Equal(t, naming.Container(), initContainer["name"])
+					assert.Equal(t, "Always", initContainer["restartPolicy"])
+				}
+			}
+		})
+	}
+}
+
 func TestPodShouldNotBeChanged(t *testing.T) {
 	for _, tt := range []struct {
 		name     string
@@ -451,3 +564,33 @@ func TestFailOnInvalidRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipUnwatchedNamespace(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithWatchNamespaces([]string{"team-a"}))
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	require.NoError(t, err)
+
+	injector := NewWebhookHandler(cfg, logger, k8sClient, []PodMutator{sidecar.NewMutator(logger, cfg, k8sClient)})
+	err = injector.InjectDecoder(decoder)
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(corev1.Pod{})
+	require.NoError(t, err)
+	req := admission.Request{
+		AdmissionRequest: admv1.AdmissionRequest{
+			Namespace: "team-b",
+			Object: runtime.RawExtension{
+				Raw: encoded,
+			},
+		},
+	}
+
+	// test
+	res := injector.Handle(context.Background(), req)
+
+	// verify
+	assert.True(t, res.Allowed)
+	assert.Empty(t, res.Patches)
+	assert.Equal(t, int32(http.StatusOK), res.AdmissionResponse.Result.Code)
+}
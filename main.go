@@ -17,39 +17,55 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/spf13/pflag"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	k8sapiflag "k8s.io/component-base/cli/flag"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/render"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/components"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/registry"
 	instrumentationupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/upgrade"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -67,6 +83,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(otelv1beta1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
@@ -81,18 +98,46 @@ func main() {
 
 	// add flags related to this operator
 	var (
-		metricsAddr               string
-		probeAddr                 string
-		enableLeaderElection      bool
-		collectorImage            string
-		targetAllocatorImage      string
-		autoInstrumentationJava   string
-		autoInstrumentationNodeJS string
-		autoInstrumentationPython string
-		autoInstrumentationDotNet string
-		labelsFilter              []string
-		webhookPort               int
-		tlsOpt                    tlsConfig
+		metricsAddr                    string
+		probeAddr                      string
+		enableLeaderElection           bool
+		collectorImage                 string
+		targetAllocatorImage           string
+		opampBridgeImage               string
+		configReloaderImage            string
+		collectorBuilderImage          string
+		imageBuilderImage              string
+		collectorBuildBaseImage        string
+		autoInstrumentationJava        string
+		autoInstrumentationNodeJS      string
+		autoInstrumentationPython      string
+		autoInstrumentationDotNet      string
+		autoInstrumentationGo          string
+		autoInstrumentationApacheHttpd string
+		autoInstrumentationNginx       string
+		labelsFilter                   []string
+		annotationsFilter              []string
+		skipMutationFields             []string
+		reinjectionPolicy              string
+		verifySDKVersions              bool
+		verifyImageArch                bool
+		enableCollectorUpgrades        bool
+		containerRuntime               string
+		watchNamespace                 string
+		watchLabelSelector             string
+		fipsMode                       string
+		fipsImageMapping               string
+		componentRegistryFile          string
+		checkConfigComponents          bool
+		webhookPort                    int
+		mutatingWebhookConfiguration   string
+		podWebhookFailurePolicy        string
+		podWebhookReinvocationPolicy   string
+		podWebhookTimeoutSeconds       int32
+		podWebhookNamespaceSelector    string
+		podWebhookObjectSelector       string
+		serviceNodePortRange           = otelv1alpha1.ServiceNodePortRange
+		tlsOpt                         tlsConfig
 	)
 
 	pflag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
@@ -100,38 +145,182 @@ func main() {
 	pflag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	pflag.StringVar(&collectorImage, "collector-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
-	pflag.StringVar(&targetAllocatorImage, "target-allocator-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
-	pflag.StringVar(&autoInstrumentationJava, "auto-instrumentation-java-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
-	pflag.StringVar(&autoInstrumentationNodeJS, "auto-instrumentation-nodejs-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS), "The default OpenTelemetry NodeJS instrumentation image. This image is used when no image is specified in the CustomResource.")
-	pflag.StringVar(&autoInstrumentationPython, "auto-instrumentation-python-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython), "The default OpenTelemetry Python instrumentation image. This image is used when no image is specified in the CustomResource.")
-	pflag.StringVar(&autoInstrumentationDotNet, "auto-instrumentation-dotnet-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-dotnet:%s", v.AutoInstrumentationDotNet), "The default OpenTelemetry DotNet instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&collectorImage, "collector-image", relatedImage("COLLECTOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector)), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&targetAllocatorImage, "target-allocator-image", relatedImage("TARGET_ALLOCATOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator)), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&opampBridgeImage, "opamp-bridge-image", relatedImage("OPAMP_BRIDGE", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge)), "The default OpAMP bridge image. This image is used when no image is specified in the OpAMPBridge.")
+	pflag.StringVar(&configReloaderImage, "config-reloader-image", relatedImage("CONFIG_RELOADER", "busybox:1.36"), "The image of the sidecar reloading the config of the collectors using the reload config strategy. It needs sh, sha256sum, sleep and pkill.")
+	pflag.StringVar(&collectorBuilderImage, "collector-builder-image", relatedImage("COLLECTOR_BUILDER", "golang:1.19"), "The Go image building the custom collector distributions with the OpenTelemetry Collector Builder.")
+	pflag.StringVar(&imageBuilderImage, "image-builder-image", relatedImage("IMAGE_BUILDER", "gcr.io/kaniko-project/executor:v1.9.1"), "The kaniko image building and pushing the images of the custom collector distributions.")
+	pflag.StringVar(&collectorBuildBaseImage, "collector-build-base-image", relatedImage("COLLECTOR_BUILD_BASE", "alpine:3.17"), "The base image of the custom collector distributions.")
+	pflag.StringVar(&autoInstrumentationJava, "auto-instrumentation-java-image", relatedImage("AUTO_INSTRUMENTATION_JAVA", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava)), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationNodeJS, "auto-instrumentation-nodejs-image", relatedImage("AUTO_INSTRUMENTATION_NODEJS", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS)), "The default OpenTelemetry NodeJS instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationPython, "auto-instrumentation-python-image", relatedImage("AUTO_INSTRUMENTATION_PYTHON", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython)), "The default OpenTelemetry Python instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationDotNet, "auto-instrumentation-dotnet-image", relatedImage("AUTO_INSTRUMENTATION_DOTNET", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-dotnet:%s", v.AutoInstrumentationDotNet)), "The default OpenTelemetry DotNet instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationGo, "auto-instrumentation-go-image", relatedImage("AUTO_INSTRUMENTATION_GO", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo)), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", relatedImage("AUTO_INSTRUMENTATION_APACHE_HTTPD", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd)), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", relatedImage("AUTO_INSTRUMENTATION_NGINX", relatedImage("AUTO_INSTRUMENTATION_APACHE_HTTPD", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd))), "The default OpenTelemetry Nginx instrumentation image, the webserver module image is shared with Apache HTTPD. This image is used when no image is specified in the CustomResource.")
+	pflag.BoolVar(&verifySDKVersions, "verify-instrumentation-sdk-versions", true, "Verify in the background that the auto-instrumentation images selected by the sdkVersion of the Instrumentation exist in their registry, logging the missing ones.")
+	pflag.BoolVar(&verifyImageArch, "verify-image-arch", false, "Verify that the collector images set in the OpenTelemetryCollector are available for all the node architectures of the cluster.")
+	pflag.BoolVar(&enableCollectorUpgrades, "enable-collector-upgrades", true, "Upgrade the OpenTelemetryCollector instances to the collector version of the operator when the operator starts.")
+	pflag.StringVar(&containerRuntime, "runtime", "", "The container runtime of the cluster nodes. When set to containerd, the injected auto-instrumentation init containers are adjusted to the containerd-specific annotations of the pods.")
+	pflag.StringVar(&watchNamespace, "watch-namespace", os.Getenv("WATCH_NAMESPACE"), "The namespaces watched by the operator, separated with commas. All the namespaces are watched when empty. Defaults to the WATCH_NAMESPACE env var.")
+	pflag.StringVar(&watchLabelSelector, "watch-label-selector", os.Getenv("WATCH_LABEL_SELECTOR"), "The label selector of the custom resources reconciled by the operator, e.g. tenant=team-a. All the custom resources are reconciled when empty. Defaults to the WATCH_LABEL_SELECTOR env var.")
+	pflag.StringVar(&fipsMode, "fips-mode", fipsModeDisabled, "The FIPS mode of the operator: enabled substitutes the FIPS-validated images of the operands and doesn't inject the auto-instrumentation without such an image, auto enables it when the kernel of the host runs in FIPS mode, disabled.")
+	pflag.StringVar(&fipsImageMapping, "fips-image-mapping", "", "The ConfigMap mapping the components to their FIPS-validated image, as namespace/name. The FIPS_IMAGE_<COMPONENT> env vars override it.")
+	pflag.StringVar(&componentRegistryFile, "component-registry", "", "The file listing the components of the collector distributions per version, completing the registry bundled with the operator.")
+	pflag.BoolVar(&checkConfigComponents, "check-config-components", true, "Hold the rollout of the collectors whose config defines components missing from the distribution of their image.")
 	pflag.StringArrayVar(&labelsFilter, "labels", []string{}, "Labels to filter away from propagating onto deploys")
+	pflag.StringArrayVar(&annotationsFilter, "annotations-filter", []string{}, "Annotations of the instances to filter away from propagating onto the generated objects, e.g. argocd.argoproj.io/*. The kubectl.kubernetes.io/last-applied-configuration annotation is never propagated.")
+	pflag.StringVar(&reinjectionPolicy, "reinjection-policy", controllers.ReinjectionPolicyNone, "What is done with the pods injected from an older generation of their Instrumentation or sidecar collector: none, report sets the RestartPending condition of the Instrumentation or collector, restart also restarts their Deployments, StatefulSets and DaemonSets.")
+	pflag.StringArrayVar(&skipMutationFields, "skip-mutation-fields", []string{}, "Fields of the OpenTelemetryCollector instances the defaulting webhook and the operator don't write, e.g. spec.replicas, so that they keep matching their sources in Git. Their defaults are only applied when reconciling the instances.")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.StringVar(&mutatingWebhookConfiguration, "mutating-webhook-configuration", "opentelemetry-operator-mutating-webhook-configuration", "The name of the MutatingWebhookConfiguration holding the pod webhook of the operator.")
+	pflag.StringVar(&podWebhookFailurePolicy, "pod-webhook-failure-policy", "", "The failure policy of the pod webhook, Fail or Ignore. The installed policy is kept when empty.")
+	pflag.StringVar(&podWebhookReinvocationPolicy, "pod-webhook-reinvocation-policy", "", "The reinvocation policy of the pod webhook, Never or IfNeeded. The installed policy is kept when empty.")
+	pflag.Int32Var(&podWebhookTimeoutSeconds, "pod-webhook-timeout-seconds", 0, "The timeout of the pod webhook, between 1 and 30 seconds. The installed timeout is kept when 0.")
+	pflag.StringVar(&podWebhookNamespaceSelector, "pod-webhook-namespace-selector", "", "The label selector of the namespaces of the pods mutated by the pod webhook, e.g. tenant=team-a. The installed selector is kept when empty.")
+	pflag.StringVar(&podWebhookObjectSelector, "pod-webhook-object-selector", "", "The label selector of the pods mutated by the pod webhook. The installed selector is kept when empty.")
+	pflag.Var(&serviceNodePortRange, "service-node-port-range", "The node port range of the Kubernetes API server (--service-node-port-range), the node ports of the collector Services are validated against it.")
 	pflag.StringVar(&tlsOpt.minVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.cipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
+	featuregate.Gates.AddFlag(pflag.CommandLine)
+
+	// the render subcommand prints the resources of the collectors of manifests instead of running the operator
+	var renderFiles []string
+	renderMode := len(os.Args) > 1 && os.Args[1] == "render"
+	if renderMode {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		pflag.StringArrayVarP(&renderFiles, "filename", "f", nil, "The files of the OpenTelemetryCollector manifests to render, - for stdin. The ConfigMaps and Secrets of the collectors can be in the same files.")
+	}
 	pflag.Parse()
 
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	fips, err := fipsEnabled(fipsMode)
+	if err != nil {
+		setupLog.Error(err, "invalid FIPS mode")
+		os.Exit(1)
+	}
+
+	if err := otelv1alpha1.SkipMutations(skipMutationFields); err != nil {
+		setupLog.Error(err, "invalid fields to skip the mutation of")
+		os.Exit(1)
+	}
+
+	switch reinjectionPolicy {
+	case controllers.ReinjectionPolicyNone, controllers.ReinjectionPolicyReport, controllers.ReinjectionPolicyRestart:
+	default:
+		setupLog.Error(fmt.Errorf("the reinjection policy must be %s, %s or %s, got %s", controllers.ReinjectionPolicyNone,
+			controllers.ReinjectionPolicyReport, controllers.ReinjectionPolicyRestart, reinjectionPolicy), "invalid reinjection policy")
+		os.Exit(1)
+	}
+
+	var componentRegistry components.Registry
+	if checkConfigComponents {
+		componentRegistry = components.Default()
+		if componentRegistryFile != "" {
+			componentRegistry, err = components.Load(componentRegistryFile)
+			if err != nil {
+				setupLog.Error(err, "failed to load the component registry")
+				os.Exit(1)
+			}
+		}
+	}
+
+	configOptions := []config.Option{
+		config.WithLogger(ctrl.Log.WithName("config")),
+		config.WithVersion(v),
+		config.WithCollectorImage(collectorImage),
+		config.WithTargetAllocatorImage(targetAllocatorImage),
+		config.WithOpAMPBridgeImage(opampBridgeImage),
+		config.WithConfigReloaderImage(configReloaderImage),
+		config.WithCollectorBuilderImage(collectorBuilderImage),
+		config.WithImageBuilderImage(imageBuilderImage),
+		config.WithCollectorBuildBaseImage(collectorBuildBaseImage),
+		config.WithComponentRegistry(componentRegistry),
+		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
+		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
+		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
+		config.WithAutoInstrumentationDotNetImage(autoInstrumentationDotNet),
+		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
+		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
+		config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
+		config.WithLabelFilters(labelsFilter),
+		config.WithAnnotationFilters(annotationsFilter),
+		config.WithReinjectionPolicy(reinjectionPolicy),
+		config.WithContainerRuntime(containerRuntime),
+		config.WithFIPS(fips),
+	}
+
+	if renderMode {
+		// without a cluster, the FIPS-validated images only come from the env vars
+		fipsImages := config.FIPSImages(nil, os.Getenv)
+		if err := checkFIPSImages(fips, fipsImages); err != nil {
+			setupLog.Error(err, "missing FIPS-validated images")
+			os.Exit(1)
+		}
+		cfg := config.New(append(configOptions, config.WithFIPSImages(fipsImages))...)
+		if err := renderCollectors(cfg, renderFiles); err != nil {
+			setupLog.Error(err, "failed to render the collectors")
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger.Info("Starting the OpenTelemetry Operator",
 		"opentelemetry-operator", v.Operator,
 		"opentelemetry-collector", collectorImage,
 		"opentelemetry-targetallocator", targetAllocatorImage,
+		"opamp-bridge", opampBridgeImage,
+		"config-reloader", configReloaderImage,
 		"auto-instrumentation-java", autoInstrumentationJava,
 		"auto-instrumentation-nodejs", autoInstrumentationNodeJS,
 		"auto-instrumentation-python", autoInstrumentationPython,
 		"auto-instrumentation-dotnet", autoInstrumentationDotNet,
+		"auto-instrumentation-go", autoInstrumentationGo,
+		"auto-instrumentation-apache-httpd", autoInstrumentationApacheHttpd,
+		"auto-instrumentation-nginx", autoInstrumentationNginx,
 		"build-date", v.BuildDate,
 		"go-version", v.Go,
 		"go-arch", runtime.GOARCH,
 		"go-os", runtime.GOOS,
 		"labels-filter", labelsFilter,
+		"annotations-filter", annotationsFilter,
+		"skip-mutation-fields", skipMutationFields,
+		"reinjection-policy", reinjectionPolicy,
+		"watch-label-selector", watchLabelSelector,
+		"runtime", containerRuntime,
+		"feature-gates", featuregate.States(),
 	)
 
+	// the spans of the operator are only exported when an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Setup(context.Background(), v.Operator)
+	if err != nil {
+		setupLog.Error(err, "failed to set up the operator traces")
+		os.Exit(1)
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
 
+	var watchNamespaces []string
+	for _, ns := range strings.Split(watchNamespace, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			watchNamespaces = append(watchNamespaces, ns)
+		}
+	}
+	if len(watchNamespaces) > 0 {
+		setupLog.Info("watching namespace(s)", "namespaces", watchNamespaces)
+	} else {
+		setupLog.Info("the watched namespaces aren't set, watching all namespaces")
+	}
+
+	watchSelector, err := labels.Parse(watchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid label selector of the watched custom resources", "selector", watchLabelSelector)
+		os.Exit(1)
+	}
+
 	// builds the operator's configuration
 	ad, err := autodetect.New(restConfig)
 	if err != nil {
@@ -139,26 +328,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	cfg := config.New(
-		config.WithLogger(ctrl.Log.WithName("config")),
-		config.WithVersion(v),
-		config.WithCollectorImage(collectorImage),
-		config.WithTargetAllocatorImage(targetAllocatorImage),
-		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
-		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
-		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
-		config.WithAutoInstrumentationDotNetImage(autoInstrumentationDotNet),
-		config.WithAutoDetect(ad),
-		config.WithLabelFilters(labelsFilter),
-	)
-
-	watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE")
-	if found {
-		setupLog.Info("watching namespace(s)", "namespaces", watchNamespace)
-	} else {
-		setupLog.Info("the env var WATCH_NAMESPACE isn't set, watching all namespaces")
+	var fipsImages map[string]string
+	if fips {
+		fipsImages, err = loadFIPSImages(context.Background(), restConfig, fipsImageMapping)
+		if err != nil {
+			setupLog.Error(err, "failed to load the FIPS-validated images")
+			os.Exit(1)
+		}
+		if err := checkFIPSImages(fips, fipsImages); err != nil {
+			setupLog.Error(err, "missing FIPS-validated images")
+			os.Exit(1)
+		}
+		setupLog.Info("running in FIPS mode", "images", fipsImages)
 	}
 
+	cfg := config.New(append(configOptions,
+		config.WithAutoDetect(ad),
+		config.WithWatchNamespaces(watchNamespaces),
+		config.WithFIPSImages(fipsImages),
+	)...)
+
 	// see https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
 	leaseDuration := time.Second * 137
 	renewDeadline := time.Second * 107
@@ -176,15 +365,13 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "9f7554c3.opentelemetry.io",
-		Namespace:              watchNamespace,
 		LeaseDuration:          &leaseDuration,
 		RenewDeadline:          &renewDeadline,
 		RetryPeriod:            &retryPeriod,
+		NewCache:               newCache(watchNamespaces, watchSelector),
 	}
-
-	if strings.Contains(watchNamespace, ",") {
-		mgrOptions.Namespace = ""
-		mgrOptions.NewCache = cache.MultiNamespacedCacheBuilder(strings.Split(watchNamespace, ","))
+	if len(watchNamespaces) == 1 {
+		mgrOptions.Namespace = watchNamespaces[0]
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
@@ -194,7 +381,7 @@ func main() {
 	}
 
 	ctx := ctrl.SetupSignalHandler()
-	err = addDependencies(ctx, mgr, cfg, v)
+	err = addDependencies(ctx, mgr, cfg, v, enableCollectorUpgrades)
 	if err != nil {
 		setupLog.Error(err, "failed to add/run bootstrap dependencies to the controller manager")
 		os.Exit(1)
@@ -211,7 +398,83 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.NewSmokeTestReconciler(controllers.Params{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("CollectorSmokeTest"),
+		Scheme:   mgr.GetScheme(),
+		Config:   cfg,
+		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CollectorSmokeTest")
+		os.Exit(1)
+	}
+
+	if err = controllers.NewTargetAllocatorReconciler(controllers.Params{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("TargetAllocator"),
+		Scheme: mgr.GetScheme(),
+		Config: cfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TargetAllocator")
+		os.Exit(1)
+	}
+
+	if err = controllers.NewOpAMPBridgeReconciler(controllers.Params{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("OpAMPBridge"),
+		Scheme: mgr.GetScheme(),
+		Config: cfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpAMPBridge")
+		os.Exit(1)
+	}
+
+	if err = controllers.NewCertificateExpiryReconciler(controllers.Params{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("CertificateExpiry"),
+		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CertificateExpiry")
+		os.Exit(1)
+	}
+
+	if err = controllers.NewCollectorDiscoveryReconciler(controllers.Params{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("CollectorDiscovery"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CollectorDiscovery")
+		os.Exit(1)
+	}
+
+	if reinjectionPolicy != controllers.ReinjectionPolicyNone {
+		if err = controllers.NewReinjectionReconciler(controllers.Params{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("Reinjection"),
+			Config:   cfg,
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Reinjection")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if verifySDKVersions {
+			otelv1alpha1.SDKImageExists = registry.New().Exists
+		}
+		if verifyImageArch {
+			otelv1alpha1.ImageArchitectures = registry.New().Architectures
+			otelv1alpha1.NodeArchitectures = nodeArchitectures(mgr.GetAPIReader())
+		}
+		otelv1alpha1.EnvFromKeys = envFromKeys(mgr.GetAPIReader())
+		otelv1alpha1.DualStackSupported = ad.DualStack
+		otelv1alpha1.ServiceNodePortRange = serviceNodePortRange
+		grpcProbes, err := ad.GRPCProbes()
+		if err != nil {
+			setupLog.Error(err, "failed to detect the gRPC probes support, defaulting to HTTP probes")
+		}
+		otelv1alpha1.GRPCProbeSupported = grpcProbes
 		if err = (&otelv1alpha1.OpenTelemetryCollector{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpenTelemetryCollector")
 			os.Exit(1)
@@ -219,23 +482,55 @@ func main() {
 		if err = (&otelv1alpha1.Instrumentation{
 			ObjectMeta: metav1.ObjectMeta{
 				Annotations: map[string]string{
-					otelv1alpha1.AnnotationDefaultAutoInstrumentationJava:   autoInstrumentationJava,
-					otelv1alpha1.AnnotationDefaultAutoInstrumentationNodeJS: autoInstrumentationNodeJS,
-					otelv1alpha1.AnnotationDefaultAutoInstrumentationPython: autoInstrumentationPython,
-					otelv1alpha1.AnnotationDefaultAutoInstrumentationDotNet: autoInstrumentationDotNet,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationJava:        autoInstrumentationJava,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationNodeJS:      autoInstrumentationNodeJS,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationPython:      autoInstrumentationPython,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationDotNet:      autoInstrumentationDotNet,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationGo:          autoInstrumentationGo,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationApacheHttpd: autoInstrumentationApacheHttpd,
+					otelv1alpha1.AnnotationDefaultAutoInstrumentationNginx:       autoInstrumentationNginx,
 				},
 			},
 		}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Instrumentation")
 			os.Exit(1)
 		}
+		if err = (&otelv1alpha1.TargetAllocator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "TargetAllocator")
+			os.Exit(1)
+		}
+		if err = (&otelv1alpha1.OpAMPBridge{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpAMPBridge")
+			os.Exit(1)
+		}
+
+		podWebhook, err := podWebhookSettings(podWebhookFailurePolicy, podWebhookReinvocationPolicy, podWebhookTimeoutSeconds,
+			podWebhookNamespaceSelector, podWebhookObjectSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid pod webhook settings")
+			os.Exit(1)
+		}
+		if !podWebhook.IsEmpty() {
+			// failing to apply the settings leaves the webhook as installed, it's retried instead of stopping the manager
+			err = mgr.Add(manager.RunnableFunc(func(c context.Context) error {
+				_ = wait.PollImmediateUntilWithContext(c, 30*time.Second, func(ctx context.Context) (bool, error) {
+					if applyErr := webhookhandler.ApplyPodWebhookSettings(ctx, mgr.GetAPIReader(), mgr.GetClient(), mutatingWebhookConfiguration, podWebhook); applyErr != nil {
+						setupLog.Error(applyErr, "failed to apply the pod webhook settings, retrying", "configuration", mutatingWebhookConfiguration)
+						return false, nil
+					}
+					return true, nil
+				})
+				return nil
+			}))
+			if err != nil {
+				setupLog.Error(err, "failed to apply the pod webhook settings")
+				os.Exit(1)
+			}
+		}
 
 		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
 			Handler: webhookhandler.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), mgr.GetClient(),
-				[]webhookhandler.PodMutator{
-					sidecar.NewMutator(logger, cfg, mgr.GetClient()),
-					instrumentation.NewMutator(logger, mgr.GetClient()),
-				}),
+				podMutators(logger, cfg, mgr.GetClient())),
 		})
 	}
 	// +kubebuilder:scaffold:builder
@@ -249,14 +544,165 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := mgr.AddMetricsExtraHandler("/status", statusHandler(v)); err != nil {
+		setupLog.Error(err, "unable to set up the status endpoint")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "failed to flush the operator traces")
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
-func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v version.Version) error {
+// relatedImage returns the image of the RELATED_IMAGE_ env var with the given suffix when it's set, the given default
+// image otherwise. The env vars let the disconnected installs mirror all the default images, as with the related images
+// of OLM.
+func relatedImage(name, image string) string {
+	if related := os.Getenv("RELATED_IMAGE_" + name); related != "" {
+		return related
+	}
+	return image
+}
+
+const (
+	fipsModeEnabled  = "enabled"
+	fipsModeDisabled = "disabled"
+	fipsModeAuto     = "auto"
+)
+
+// fipsEnabled returns whether the operator runs in the given FIPS mode.
+func fipsEnabled(mode string) (bool, error) {
+	switch mode {
+	case fipsModeEnabled:
+		return true, nil
+	case fipsModeDisabled:
+		return false, nil
+	case fipsModeAuto:
+		return config.FIPSEnabledOnHost(), nil
+	}
+	return false, fmt.Errorf("the FIPS mode must be %s, %s or %s, got %s", fipsModeEnabled, fipsModeDisabled, fipsModeAuto, mode)
+}
+
+// renderCollectors writes the resources of the collectors of the files to stdout, the - file being stdin.
+func renderCollectors(cfg config.Config, files []string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("the files to render are required, set them with -f")
+	}
+
+	var inputs []io.Reader
+	for _, f := range files {
+		if f == "-" {
+			inputs = append(inputs, os.Stdin)
+		} else {
+			file, err := os.Open(f)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			inputs = append(inputs, file)
+		}
+		// the files don't necessarily end with a document separator
+		inputs = append(inputs, strings.NewReader("\n---\n"))
+	}
+	return render.Render(context.Background(), scheme, cfg, ctrl.Log.WithName("render"), io.MultiReader(inputs...), os.Stdout)
+}
+
+// checkFIPSImages returns an error when the operator runs in FIPS mode without the FIPS-validated image of one of the
+// components it deploys, rather than deploying their non-validated images.
+func checkFIPSImages(fips bool, images map[string]string) error {
+	if !fips {
+		return nil
+	}
+	if missing := config.MissingFIPSImages(images); len(missing) > 0 {
+		return fmt.Errorf("the operator runs in FIPS mode without FIPS-validated images for %s, set them in the FIPS image mapping or the %s env vars",
+			strings.Join(missing, ", "), config.FIPSImageEnvVar("<component>"))
+	}
+	return nil
+}
+
+// loadFIPSImages returns the FIPS-validated images of the mapping ConfigMap, given as namespace/name, and of the env vars.
+func loadFIPSImages(ctx context.Context, restConfig *rest.Config, mapping string) (map[string]string, error) {
+	var data map[string]string
+	if mapping != "" {
+		namespace, name, ok := strings.Cut(mapping, "/")
+		if !ok {
+			return nil, fmt.Errorf("the FIPS image mapping %s must be given as namespace/name", mapping)
+		}
+		cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return nil, err
+		}
+		cm := &corev1.ConfigMap{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get the FIPS image mapping %s: %w", mapping, err)
+		}
+		data = cm.Data
+	}
+	return config.FIPSImages(data, os.Getenv), nil
+}
+
+// podWebhookSettings returns the settings of the pod webhook given with the flags of the operator.
+func podWebhookSettings(failurePolicy, reinvocationPolicy string, timeoutSeconds int32, namespaceSelector, objectSelector string) (webhookhandler.PodWebhookSettings, error) {
+	settings := webhookhandler.PodWebhookSettings{}
+	if failurePolicy != "" {
+		policy := admissionregistrationv1.FailurePolicyType(failurePolicy)
+		settings.FailurePolicy = &policy
+	}
+	if reinvocationPolicy != "" {
+		policy := admissionregistrationv1.ReinvocationPolicyType(reinvocationPolicy)
+		settings.ReinvocationPolicy = &policy
+	}
+	if timeoutSeconds != 0 {
+		settings.TimeoutSeconds = &timeoutSeconds
+	}
+	if namespaceSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(namespaceSelector)
+		if err != nil {
+			return settings, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+		settings.NamespaceSelector = selector
+	}
+	if objectSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(objectSelector)
+		if err != nil {
+			return settings, fmt.Errorf("invalid object selector: %w", err)
+		}
+		settings.ObjectSelector = selector
+	}
+	return settings, settings.Validate()
+}
+
+// newCache returns the cache of the manager, restricted to the watched namespaces. The custom resources of the
+// operator are filtered by the given selector, so that the operator instances of several tenants don't reconcile
+// the same custom resources.
+func newCache(namespaces []string, selector labels.Selector) cache.NewCacheFunc {
+	newCacheFunc := cache.New
+	if len(namespaces) > 1 {
+		newCacheFunc = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+	if selector.Empty() {
+		return newCacheFunc
+	}
+
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		opts.SelectorsByObject = cache.SelectorsByObject{
+			&otelv1alpha1.OpenTelemetryCollector{}: {Label: selector},
+			&otelv1alpha1.Instrumentation{}:        {Label: selector},
+			&otelv1alpha1.TargetAllocator{}:        {Label: selector},
+			&otelv1alpha1.OpAMPBridge{}:            {Label: selector},
+			&otelv1alpha1.CollectorSmokeTest{}:     {Label: selector},
+		}
+		return newCacheFunc(config, opts)
+	}
+}
+
+func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v version.Version, enableCollectorUpgrades bool) error {
 	// run the auto-detect mechanism for the configuration
 	err := mgr.Add(manager.RunnableFunc(func(_ context.Context) error {
 		return cfg.StartAutoDetect()
@@ -265,28 +711,35 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 		return fmt.Errorf("failed to start the auto-detect mechanism: %w", err)
 	}
 	// adds the upgrade mechanism to be executed once the manager is ready
-	err = mgr.Add(manager.RunnableFunc(func(c context.Context) error {
-		up := &collectorupgrade.VersionUpgrade{
-			Log:      ctrl.Log.WithName("collector-upgrade"),
-			Version:  v,
-			Client:   mgr.GetClient(),
-			Recorder: record.NewFakeRecorder(collectorupgrade.RecordBufferSize),
+	if enableCollectorUpgrades {
+		err = mgr.Add(manager.RunnableFunc(func(c context.Context) error {
+			up := &collectorupgrade.VersionUpgrade{
+				Log:      ctrl.Log.WithName("collector-upgrade"),
+				Version:  v,
+				Client:   mgr.GetClient(),
+				Recorder: record.NewFakeRecorder(collectorupgrade.RecordBufferSize),
+			}
+			return up.ManagedInstances(c)
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to upgrade OpenTelemetryCollector instances: %w", err)
 		}
-		return up.ManagedInstances(c)
-	}))
-	if err != nil {
-		return fmt.Errorf("failed to upgrade OpenTelemetryCollector instances: %w", err)
+	} else {
+		ctrl.Log.WithName("collector-upgrade").Info("the upgrade of the OpenTelemetryCollector instances is disabled")
 	}
 
 	// adds the upgrade mechanism to be executed once the manager is ready
 	err = mgr.Add(manager.RunnableFunc(func(c context.Context) error {
 		u := &instrumentationupgrade.InstrumentationUpgrade{
-			Logger:                ctrl.Log.WithName("instrumentation-upgrade"),
-			DefaultAutoInstJava:   cfg.AutoInstrumentationJavaImage(),
-			DefaultAutoInstNodeJS: cfg.AutoInstrumentationNodeJSImage(),
-			DefaultAutoInstPython: cfg.AutoInstrumentationPythonImage(),
-			DefaultAutoInstDotNet: cfg.AutoInstrumentationDotNetImage(),
-			Client:                mgr.GetClient(),
+			Logger:                     ctrl.Log.WithName("instrumentation-upgrade"),
+			DefaultAutoInstJava:        cfg.AutoInstrumentationJavaImage(),
+			DefaultAutoInstNodeJS:      cfg.AutoInstrumentationNodeJSImage(),
+			DefaultAutoInstPython:      cfg.AutoInstrumentationPythonImage(),
+			DefaultAutoInstDotNet:      cfg.AutoInstrumentationDotNetImage(),
+			DefaultAutoInstGo:          cfg.AutoInstrumentationGoImage(),
+			DefaultAutoInstApacheHttpd: cfg.AutoInstrumentationApacheHttpdImage(),
+			DefaultAutoInstNginx:       cfg.AutoInstrumentationNginxImage(),
+			Client:                     mgr.GetClient(),
 		}
 		return u.ManagedInstances(c)
 	}))
@@ -296,6 +749,84 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 	return nil
 }
 
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=list
+
+// nodeArchitectures returns the function listing the distinct kubernetes.io/arch labels of the cluster nodes. The
+// nodes are read from the API server, the operator doesn't need to cache them.
+func nodeArchitectures(reader client.Reader) func(context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		nodes := &metav1.PartialObjectMetadataList{}
+		nodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+		if err := reader.List(ctx, nodes); err != nil {
+			return nil, fmt.Errorf("failed to list the nodes: %w", err)
+		}
+		found := map[string]bool{}
+		var architectures []string
+		for _, node := range nodes.Items {
+			arch := node.Labels[corev1.LabelArchStable]
+			if arch != "" && !found[arch] {
+				found[arch] = true
+				architectures = append(architectures, arch)
+			}
+		}
+		return architectures, nil
+	}
+}
+
+// envFromKeys returns the keys of the ConfigMap or Secret of an envFrom source.
+func envFromKeys(reader client.Reader) func(context.Context, string, corev1.EnvFromSource) ([]string, error) {
+	return func(ctx context.Context, namespace string, source corev1.EnvFromSource) ([]string, error) {
+		var keys []string
+		switch {
+		case source.ConfigMapRef != nil:
+			cm := &corev1.ConfigMap{}
+			if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.ConfigMapRef.Name}, cm); err != nil {
+				return nil, fmt.Errorf("failed to get the config map: %w", err)
+			}
+			for key := range cm.Data {
+				keys = append(keys, key)
+			}
+			for key := range cm.BinaryData {
+				keys = append(keys, key)
+			}
+		case source.SecretRef != nil:
+			secret := &corev1.Secret{}
+			if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: source.SecretRef.Name}, secret); err != nil {
+				return nil, fmt.Errorf("failed to get the secret: %w", err)
+			}
+			for key := range secret.Data {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+}
+
+// podMutators returns the mutators of the pod webhook, skipping the ones disabled by the feature gates.
+func podMutators(logger logr.Logger, cfg config.Config, cl client.Client) []webhookhandler.PodMutator {
+	var mutators []webhookhandler.PodMutator
+	if featuregate.Gates.Enabled(featuregate.SidecarInjection) {
+		mutators = append(mutators, sidecar.NewMutator(logger, cfg, cl))
+	}
+	return append(mutators, instrumentation.NewMutator(logger, cfg, cl))
+}
+
+// operatorStatus is the document served by the status endpoint.
+type operatorStatus struct {
+	Version      version.Version     `json:"version"`
+	FeatureGates []featuregate.State `json:"featureGates"`
+}
+
+// statusHandler serves the version of the operator and the state of its feature gates.
+func statusHandler(v version.Version) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(operatorStatus{Version: v, FeatureGates: featuregate.States()}); err != nil {
+			setupLog.Error(err, "failed to write the status")
+		}
+	})
+}
+
 // This function get the option from command argument (tlsConfig), check the validity through k8sapiflag
 // and set the config for webhook server.
 // refer to https://pkg.go.dev/k8s.io/component-base/cli/flag
@@ -16,9 +16,17 @@
 package autodetect
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
@@ -31,6 +39,9 @@ var _ AutoDetect = (*autoDetect)(nil)
 type AutoDetect interface {
 	Platform() (platform.Platform, error)
 	HPAVersion() (AutoscalingVersion, error)
+	PodDisruptionBudgets() (bool, error)
+	DualStack() (bool, error)
+	GRPCProbes() (bool, error)
 }
 
 type autoDetect struct {
@@ -105,6 +116,90 @@ func (a *autoDetect) HPAVersion() (AutoscalingVersion, error) {
 	return AutoscalingVersionUnknown, errors.New("Failed to find apiGroup autoscaling")
 }
 
+// PodDisruptionBudgets returns whether the cluster serves the policy/v1 PodDisruptionBudgets, which is the case from
+// Kubernetes 1.21 onwards.
+func (a *autoDetect) PodDisruptionBudgets() (bool, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return false, err
+	}
+
+	for _, apiGroup := range apiList.Groups {
+		if apiGroup.Name != "policy" {
+			continue
+		}
+		for _, version := range apiGroup.Versions {
+			if version.Version == "v1" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// DualStack returns whether the cluster supports dual-stack Services, that is whether its service CIDRs cover both IP
+// families. It creates a dual-stack Service in dry-run mode, which the API server only allocates both families to
+// when it's configured with an IPv4 and an IPv6 service CIDR.
+func (a *autoDetect) DualStack() (bool, error) {
+	policy := corev1.IPFamilyPolicyRequireDualStack
+	probe := corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "opentelemetry-operator-dual-stack-"},
+		Spec: corev1.ServiceSpec{
+			IPFamilyPolicy: &policy,
+			Ports:          []corev1.ServicePort{{Port: 80}},
+		},
+	}
+	body, err := json.Marshal(probe)
+	if err != nil {
+		return false, err
+	}
+
+	raw, err := a.dcl.RESTClient().Post().
+		AbsPath("/api/v1/namespaces", metav1.NamespaceDefault, "services").
+		Param("dryRun", metav1.DryRunAll).
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		Do(context.Background()).
+		Raw()
+	if apierrors.IsInvalid(err) {
+		// the dual-stack Services are rejected by the single-stack clusters
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// the clusters without the dual-stack feature drop the policy and allocate a single family
+	created := corev1.Service{}
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return false, fmt.Errorf("failed to decode the dry-run service: %w", err)
+	}
+	return len(created.Spec.IPFamilies) == 2, nil
+}
+
+// GRPCProbes returns whether the cluster supports gRPC container probes, which is the case from Kubernetes 1.24 onwards.
+func (a *autoDetect) GRPCProbes() (bool, error) {
+	return a.serverVersionAtLeast(1, 24)
+}
+
+func (a *autoDetect) serverVersionAtLeast(major, minor int) (bool, error) {
+	info, err := a.dcl.ServerVersion()
+	if err != nil {
+		return false, err
+	}
+
+	// managed clusters usually report versions such as "1.23+"
+	serverMajor, err := strconv.Atoi(strings.TrimSuffix(info.Major, "+"))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the server major version %q: %w", info.Major, err)
+	}
+	serverMinor, err := strconv.Atoi(strings.TrimSuffix(info.Minor, "+"))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the server minor version %q: %w", info.Minor, err)
+	}
+	return serverMajor > major || (serverMajor == major && serverMinor >= minor), nil
+}
+
 func (v AutoscalingVersion) String() string {
 	switch v {
 	case AutoscalingVersionV2:
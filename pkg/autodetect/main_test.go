@@ -22,7 +22,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
@@ -89,6 +91,130 @@ func TestUnknownPlatformOnError(t *testing.T) {
 	assert.Equal(t, platform.Unknown, plt)
 }
 
+func TestDetectPodDisruptionBudgetsBasedOnAvailableAPIGroups(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		versions  []metav1.GroupVersionForDiscovery
+		available bool
+	}{
+		{
+			name:      "policy v1",
+			versions:  []metav1.GroupVersionForDiscovery{{GroupVersion: "policy/v1", Version: "v1"}, {GroupVersion: "policy/v1beta1", Version: "v1beta1"}},
+			available: true,
+		},
+		{
+			name:     "policy v1beta1 only",
+			versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "policy/v1beta1", Version: "v1beta1"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				output, err := json.Marshal(&metav1.APIGroupList{Groups: []metav1.APIGroup{{Name: "policy", Versions: tt.versions}}})
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			available, err := autoDetect.PodDisruptionBudgets()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.available, available)
+		})
+	}
+}
+
+func TestDetectDualStackBasedOnServiceIPFamilies(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		status   int
+		families []corev1.IPFamily
+		expected bool
+	}{
+		{"dual-stack", http.StatusCreated, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}, true},
+		{"policy dropped", http.StatusCreated, []corev1.IPFamily{corev1.IPv4Protocol}, false},
+		{"single-stack", http.StatusUnprocessableEntity, nil, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/api/v1/namespaces/default/services", req.URL.Path)
+				assert.Equal(t, "All", req.URL.Query().Get("dryRun"))
+
+				var output []byte
+				var err error
+				if tt.status == http.StatusUnprocessableEntity {
+					output, err = json.Marshal(metav1.Status{
+						TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+						Status:   metav1.StatusFailure,
+						Reason:   metav1.StatusReasonInvalid,
+						Code:     http.StatusUnprocessableEntity,
+					})
+				} else {
+					output, err = json.Marshal(corev1.Service{Spec: corev1.ServiceSpec{IPFamilies: tt.families}})
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			// test
+			dualStack, err := autoDetect.DualStack()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, dualStack)
+		})
+	}
+}
+
+func TestDetectGRPCProbesBasedOnServerVersion(t *testing.T) {
+	for _, tt := range []struct {
+		major, minor string
+		expected     bool
+	}{
+		{"1", "23", false},
+		{"1", "24", true},
+		{"1", "25+", true},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(version.Info{Major: tt.major, Minor: tt.minor})
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		// test
+		grpcProbes, err := autoDetect.GRPCProbes()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, grpcProbes)
+	}
+}
+
 func TestAutoscalingVersionToString(t *testing.T) {
 	assert.Equal(t, "v2", autodetect.AutoscalingVersionV2.String())
 	assert.Equal(t, "v2beta2", autodetect.AutoscalingVersionV2Beta2.String())
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+// ConfigMerge merges the override config into the base config: the maps present in both are merged recursively, the
+// other values of the override replace the ones of the base. The base is modified and returned.
+func ConfigMerge(base, override map[interface{}]interface{}) map[interface{}]interface{} {
+	if base == nil {
+		base = map[interface{}]interface{}{}
+	}
+	for k, v := range override {
+		baseMap, baseIsMap := base[k].(map[interface{}]interface{})
+		overrideMap, overrideIsMap := v.(map[interface{}]interface{})
+		if baseIsMap && overrideIsMap {
+			base[k] = ConfigMerge(baseMap, overrideMap)
+			continue
+		}
+		base[k] = v
+	}
+	return base
+}
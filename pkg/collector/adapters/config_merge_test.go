@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigMerge(t *testing.T) {
+	// prepare
+	base, err := ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    headers:
+      tenant: a
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`)
+	require.NoError(t, err)
+	override, err := ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:14317
+exporters:
+  otlp:
+    headers:
+      tenant: b
+  logging:
+service:
+  pipelines:
+    traces:
+      exporters: [otlp, logging]
+`)
+	require.NoError(t, err)
+	expected, err := ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:14317
+      http:
+exporters:
+  otlp:
+    endpoint: backend:4317
+    headers:
+      tenant: b
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp, logging]
+`)
+	require.NoError(t, err)
+
+	// test
+	actual := ConfigMerge(base, override)
+
+	// verify
+	assert.Equal(t, expected, actual)
+}
+
+func TestConfigMergeNilBase(t *testing.T) {
+	override := map[interface{}]interface{}{"receivers": map[interface{}]interface{}{"otlp": nil}}
+	assert.Equal(t, override, ConfigMerge(nil, override))
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+// envReference matches the ${ENV} and ${provider:value} references expanded by the collector itself.
+var envReference = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*|[A-Za-z][A-Za-z0-9+.-]*:.*)$`)
+
+// ConfigFromTemplate evaluates the CEL expressions of the configuration, written as ${expression}. The expressions
+// can use the spec and metadata of the given instance as the cr variable, e.g. ${cr.metadata.name}, along with the
+// CEL string extensions. The collector's own ${ENV} and ${env:ENV} references are left untouched.
+func ConfigFromTemplate(configStr string, cr interface{}) (string, error) {
+	if !strings.Contains(configStr, "${") {
+		return configStr, nil
+	}
+
+	vars, err := templateVariables(cr)
+	if err != nil {
+		return "", err
+	}
+	env, err := cel.NewEnv(cel.Variable("cr", cel.DynType), ext.Strings())
+	if err != nil {
+		return "", fmt.Errorf("failed to create the CEL environment: %w", err)
+	}
+
+	var result strings.Builder
+	rest := configStr
+	for {
+		start := strings.Index(rest, "${")
+		if start == -1 {
+			break
+		}
+		end := expressionEnd(rest, start+2)
+		escaped := start > 0 && rest[start-1] == '$'
+		if end == -1 || escaped {
+			result.WriteString(rest[:start+2])
+			rest = rest[start+2:]
+			continue
+		}
+
+		expression := rest[start+2 : end]
+		if envReference.MatchString(strings.TrimSpace(expression)) {
+			result.WriteString(rest[:end+1])
+			rest = rest[end+1:]
+			continue
+		}
+
+		value, err := evaluate(env, expression, vars)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate the expression %q: %w", expression, err)
+		}
+		result.WriteString(rest[:start])
+		result.WriteString(value)
+		rest = rest[end+1:]
+	}
+	result.WriteString(rest)
+
+	return result.String(), nil
+}
+
+// templateVariables returns the spec and metadata of the instance, as they're exposed to the expressions.
+func templateVariables(cr interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the instance: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to convert the instance: %w", err)
+	}
+	return map[string]interface{}{
+		"cr": map[string]interface{}{
+			"metadata": fields["metadata"],
+			"spec":     fields["spec"],
+		},
+	}, nil
+}
+
+// expressionEnd returns the index of the brace closing the expression starting at the given index, skipping the
+// braces of the CEL maps and string literals, or -1 when the expression isn't closed.
+func expressionEnd(s string, from int) int {
+	depth := 0
+	var quote byte
+	for i := from; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case c == '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+func evaluate(env *cel.Env, expression string, vars map[string]interface{}) (string, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return "", issues.Err()
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return "", err
+	}
+	out, _, err := program.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+
+	switch out.Type() {
+	case types.StringType, types.IntType, types.UintType, types.DoubleType, types.BoolType:
+		return fmt.Sprint(out.Value()), nil
+	}
+	return "", fmt.Errorf("the expression evaluates to a %s, not a scalar value", out.Type().TypeName())
+}
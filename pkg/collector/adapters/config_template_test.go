@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type templateInstance struct {
+	Metadata map[string]interface{} `json:"metadata"`
+	Spec     map[string]interface{} `json:"spec"`
+}
+
+func TestConfigFromTemplate(t *testing.T) {
+	cr := templateInstance{
+		Metadata: map[string]interface{}{"name": "my-instance", "namespace": "observability"},
+		Spec:     map[string]interface{}{"mode": "deployment", "tls": map[string]interface{}{"enforceSecure": true}, "replicas": 2},
+	}
+
+	tests := []struct {
+		desc     string
+		config   string
+		expected string
+		err      string
+	}{
+		{
+			desc:     "NoExpressions",
+			config:   "exporters:\n  otlp:\n    endpoint: collector:4317\n",
+			expected: "exporters:\n  otlp:\n    endpoint: collector:4317\n",
+		},
+		{
+			desc:     "Expressions",
+			config:   `endpoint: "${has(cr.spec.tls) ? 'https' : 'http'}://${cr.metadata.name}.${cr.metadata.namespace.upperAscii()}:4317"`,
+			expected: `endpoint: "https://my-instance.OBSERVABILITY:4317"`,
+		},
+		{
+			desc:     "ScalarResult",
+			config:   "replicas: ${int(cr.spec.replicas) * 2}",
+			expected: "replicas: 4",
+		},
+		{
+			desc:     "EnvReferencesAreKept",
+			config:   "key: ${API_KEY}\nother: ${env:OTHER_KEY}\nescaped: $${cr.metadata.name}",
+			expected: "key: ${API_KEY}\nother: ${env:OTHER_KEY}\nescaped: $${cr.metadata.name}",
+		},
+		{
+			desc:   "SyntaxError",
+			config: "endpoint: ${cr.metadata.name +}",
+			err:    `failed to evaluate the expression "cr.metadata.name +"`,
+		},
+		{
+			desc:   "MissingField",
+			config: "endpoint: ${cr.spec.hostname}",
+			err:    "no such key: hostname",
+		},
+		{
+			desc:   "NotAScalar",
+			config: "endpoint: ${cr.metadata}",
+			err:    "not a scalar value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := ConfigFromTemplate(tt.config, cr)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
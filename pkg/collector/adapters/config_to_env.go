@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"regexp"
+	"sort"
+)
+
+// envVarReference matches the ${VAR} and ${env:VAR} references the collector expands with its environment variables,
+// along with the dollar signs escaping them. The references with a default value, like ${env:VAR:-default}, don't
+// match.
+var envVarReference = regexp.MustCompile(`(\$*)\$\{(?:env:)?([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ConfigToEnvReferences returns the sorted names of the environment variables referenced by the values of the config.
+// The escaped references, written as $${VAR}, aren't part of the result.
+func ConfigToEnvReferences(config map[interface{}]interface{}) []string {
+	found := map[string]bool{}
+	collectEnvReferences(config, found)
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectEnvReferences(value interface{}, found map[string]bool) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for _, item := range v {
+			collectEnvReferences(item, found)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectEnvReferences(item, found)
+		}
+	case string:
+		for _, match := range envVarReference.FindAllStringSubmatch(v, -1) {
+			// each pair of dollar signs is an escaped one
+			if len(match[1])%2 == 0 {
+				found[match[2]] = true
+			}
+		}
+	}
+}
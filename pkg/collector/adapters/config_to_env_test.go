@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToEnvReferences(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "NoReferences",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317`,
+			expected: []string{},
+		}, {
+			desc: "References",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: ${env:MY_POD_IP}:4317
+exporters:
+  otlp:
+    endpoint: ${BACKEND_ENDPOINT}
+    headers:
+      api-key: Bearer ${env:API_KEY}
+processors:
+  resource:
+    attributes:
+      - key: pod
+        value: ${env:MY_POD_IP}`,
+			expected: []string{"API_KEY", "BACKEND_ENDPOINT", "MY_POD_IP"},
+		}, {
+			desc: "EscapedAndDefaultedReferences",
+			config: `exporters:
+  otlp:
+    endpoint: ${env:BACKEND_ENDPOINT:-collector:4317}
+    headers:
+      literal: $${env:LITERAL}
+      expanded: $$${env:EXPANDED}
+      provider: ${file:/etc/token}`,
+			expected: []string{"EXPANDED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			actual := ConfigToEnvReferences(config)
+
+			// verify
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
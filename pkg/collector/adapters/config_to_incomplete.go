@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"sort"
+)
+
+// pipelineComponentKinds are the component kinds referenced by the service pipelines, with their top-level key.
+var pipelineComponentKinds = []struct {
+	key  string
+	kind string
+}{
+	{key: "receivers", kind: "receiver"},
+	{key: "processors", kind: "processor"},
+	{key: "exporters", kind: "exporter"},
+}
+
+// ConfigToIncompleteComponents returns the inconsistencies between the component definitions of the config and the
+// service referencing them: components used by a pipeline or the service without a definition, and defined components
+// which aren't used anywhere. The result is sorted, and empty when the config is self-consistent.
+func ConfigToIncompleteComponents(config map[interface{}]interface{}) []string {
+	var problems []string
+
+	service, _ := config["service"].(map[interface{}]interface{})
+	pipelines, _ := service["pipelines"].(map[interface{}]interface{})
+
+	for _, c := range pipelineComponentKinds {
+		defined := componentIDs(config[c.key])
+		used := map[string]bool{}
+		for pipelineID, pipelineCfg := range pipelines {
+			pipeline, _ := pipelineCfg.(map[interface{}]interface{})
+			refs, _ := pipeline[c.key].([]interface{})
+			for _, ref := range refs {
+				id := fmt.Sprint(ref)
+				used[id] = true
+				if !defined[id] {
+					problems = append(problems, fmt.Sprintf("pipeline '%v' references the undefined %s '%s'", pipelineID, c.kind, id))
+				}
+			}
+		}
+		for id := range defined {
+			if !used[id] {
+				problems = append(problems, fmt.Sprintf("%s '%s' is defined but not used in any pipeline", c.kind, id))
+			}
+		}
+	}
+
+	defined := componentIDs(config["extensions"])
+	enabled := map[string]bool{}
+	refs, _ := service["extensions"].([]interface{})
+	for _, ref := range refs {
+		id := fmt.Sprint(ref)
+		enabled[id] = true
+		if !defined[id] {
+			problems = append(problems, fmt.Sprintf("service references the undefined extension '%s'", id))
+		}
+	}
+	for id := range defined {
+		if !enabled[id] {
+			problems = append(problems, fmt.Sprintf("extension '%s' is defined but not enabled in the service", id))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+func componentIDs(section interface{}) map[string]bool {
+	ids := map[string]bool{}
+	components, _ := section.(map[interface{}]interface{})
+	for id := range components {
+		ids[fmt.Sprint(id)] = true
+	}
+	return ids
+}
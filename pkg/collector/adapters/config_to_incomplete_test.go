@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToIncompleteComponents(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "CompleteConfig",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+exporters:
+  logging:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]`,
+		}, {
+			desc: "UndefinedComponents",
+			config: `receivers:
+  otlp:
+exporters:
+  logging:
+service:
+  extensions: [pprof]
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      processors: [batch]
+      exporters: [logging]`,
+			expected: []string{
+				"pipeline 'traces' references the undefined processor 'batch'",
+				"pipeline 'traces' references the undefined receiver 'jaeger'",
+				"service references the undefined extension 'pprof'",
+			},
+		}, {
+			desc: "UnusedComponents",
+			config: `receivers:
+  otlp:
+  otlp/2:
+exporters:
+  logging:
+  otlp:
+extensions:
+  zpages:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]`,
+			expected: []string{
+				"exporter 'otlp' is defined but not used in any pipeline",
+				"extension 'zpages' is defined but not enabled in the service",
+				"receiver 'otlp/2' is defined but not used in any pipeline",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			actual := ConfigToIncompleteComponents(config)
+
+			// verify
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// componentTypeRegex matches the type part of the component IDs, as accepted by the collector.
+var componentTypeRegex = regexp.MustCompile(`^[a-zA-Z][0-9a-zA-Z_]*$`)
+
+// pipelineTypes are the data types of the service pipelines.
+var pipelineTypes = map[string]bool{"traces": true, "metrics": true, "logs": true}
+
+// ConfigToInvalidPipelines returns the problems making the collector refuse to start with the given config: a service
+// without pipelines, pipelines of an unknown data type or without receivers or exporters, invalid component IDs and
+// references to undefined components. The result is sorted, and empty when the pipelines are valid.
+func ConfigToInvalidPipelines(config map[interface{}]interface{}) []string {
+	var problems []string
+
+	for _, c := range pipelineComponentKinds {
+		problems = append(problems, invalidComponentIDs(config, c.key, c.kind)...)
+	}
+	problems = append(problems, invalidComponentIDs(config, "extensions", "extension")...)
+
+	service, _ := config["service"].(map[interface{}]interface{})
+	pipelines, _ := service["pipelines"].(map[interface{}]interface{})
+	if len(pipelines) == 0 {
+		problems = append(problems, "the service has no pipelines")
+	}
+	for pipelineID, pipelineCfg := range pipelines {
+		id := fmt.Sprint(pipelineID)
+		if dataType := strings.SplitN(id, "/", 2)[0]; !pipelineTypes[dataType] {
+			problems = append(problems, fmt.Sprintf("pipeline '%s' has the unknown data type '%s', it must be one of logs, metrics or traces", id, dataType))
+		}
+		pipeline, _ := pipelineCfg.(map[interface{}]interface{})
+		for _, c := range pipelineComponentKinds {
+			defined := componentIDs(config[c.key])
+			refs, _ := pipeline[c.key].([]interface{})
+			if len(refs) == 0 && c.key != "processors" {
+				problems = append(problems, fmt.Sprintf("pipeline '%s' has no %s", id, c.key))
+			}
+			for _, ref := range refs {
+				if !defined[fmt.Sprint(ref)] {
+					problems = append(problems, fmt.Sprintf("pipeline '%s' references the undefined %s '%v'", id, c.kind, ref))
+				}
+			}
+		}
+	}
+
+	defined := componentIDs(config["extensions"])
+	refs, _ := service["extensions"].([]interface{})
+	for _, ref := range refs {
+		if !defined[fmt.Sprint(ref)] {
+			problems = append(problems, fmt.Sprintf("service references the undefined extension '%v'", ref))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+func invalidComponentIDs(config map[interface{}]interface{}, key, kind string) []string {
+	var problems []string
+	for id := range componentIDs(config[key]) {
+		if !validComponentID(id) {
+			problems = append(problems, fmt.Sprintf("the %s ID '%s' is invalid, it must be a type optionally followed by /name", kind, id))
+		}
+	}
+	return problems
+}
+
+func validComponentID(id string) bool {
+	parts := strings.SplitN(id, "/", 2)
+	if !componentTypeRegex.MatchString(parts[0]) {
+		return false
+	}
+	return len(parts) == 1 || strings.TrimSpace(parts[1]) != ""
+}
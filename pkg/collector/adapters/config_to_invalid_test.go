@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToInvalidPipelines(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "ValidPipelines",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+exporters:
+  logging:
+  otlp/backend:
+extensions:
+  health_check:
+  zpages:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging, otlp/backend]
+    metrics/backend:
+      receivers: [otlp]
+      exporters: [otlp/backend]`,
+		}, {
+			desc: "NoService",
+			config: `receivers:
+  otlp:`,
+			expected: []string{
+				"the service has no pipelines",
+			},
+		}, {
+			desc: "InvalidPipelines",
+			config: `receivers:
+  otlp:
+exporters:
+  logging:
+service:
+  extensions: [pprof]
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      processors: [batch]
+      exporters: [logging]
+    spans:
+      receivers: [otlp]
+    logs:
+      exporters: [logging]`,
+			expected: []string{
+				"pipeline 'logs' has no receivers",
+				"pipeline 'spans' has no exporters",
+				"pipeline 'spans' has the unknown data type 'spans', it must be one of logs, metrics or traces",
+				"pipeline 'traces' references the undefined processor 'batch'",
+				"pipeline 'traces' references the undefined receiver 'jaeger'",
+				"service references the undefined extension 'pprof'",
+			},
+		}, {
+			desc: "InvalidComponentIDs",
+			config: `receivers:
+  otlp:
+  1otlp:
+exporters:
+  logging/:
+extensions:
+  health-check:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging/]`,
+			expected: []string{
+				"the exporter ID 'logging/' is invalid, it must be a type optionally followed by /name",
+				"the extension ID 'health-check' is invalid, it must be a type optionally followed by /name",
+				"the receiver ID '1otlp' is invalid, it must be a type optionally followed by /name",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			actual := ConfigToInvalidPipelines(config)
+
+			// verify
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
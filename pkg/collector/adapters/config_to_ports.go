@@ -34,6 +34,26 @@ var (
 
 // ConfigToReceiverPorts converts the incoming configuration object into a set of service ports required by the receivers.
 func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+	portsByReceiver, err := ConfigToReceiverPortsByReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := []corev1.ServicePort{}
+	for _, rcvrPorts := range portsByReceiver {
+		ports = append(ports, rcvrPorts...)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports, nil
+}
+
+// ConfigToReceiverPortsByReceiver converts the incoming configuration object into the service ports required by each
+// of the enabled receivers, keyed by the receiver name.
+func ConfigToReceiverPortsByReceiver(logger logr.Logger, config map[interface{}]interface{}) (map[string][]corev1.ServicePort, error) {
 	// now, we gather which ports we might need to open
 	// for that, we get all the receivers and check their `endpoint` properties,
 	// extracting the port from it. The port name has to be a "DNS_LABEL", so, we try to make it follow the pattern:
@@ -61,7 +81,7 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 		return nil, ErrReceiversNotAMap
 	}
 
-	ports := []corev1.ServicePort{}
+	ports := map[string][]corev1.ServicePort{}
 	for key, val := range receivers {
 		// This check will pass only the enabled receivers,
 		// then only the related ports will be opened.
@@ -87,13 +107,9 @@ func ConfigToReceiverPorts(logger logr.Logger, config map[interface{}]interface{
 		}
 
 		if len(rcvrPorts) > 0 {
-			ports = append(ports, rcvrPorts...)
+			ports[rcvrName] = rcvrPorts
 		}
 	}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
-
 	return ports, nil
 }
@@ -16,6 +16,10 @@ package adapters
 
 import (
 	"errors"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -35,8 +39,13 @@ var (
 
 	errServiceExtensionsNotSlice     = errors.New("service extensions property in the configuration does not contain valid extensions")
 	errNoServiceExtensionHealthCheck = errors.New("no healthcheck extension available in service extension configuration")
+
+	errNoOTLPGRPCReceiver = errors.New("receivers property in the configuration does not contain an OTLP receiver with the grpc protocol")
 )
 
+// envVarPattern matches the environment variables expanded by the collector in its config.
+var envVarPattern = regexp.MustCompile(`\$\{[^}]*\}`)
+
 type probeConfiguration struct {
 	path string
 	port intstr.IntOrString
@@ -45,6 +54,7 @@ type probeConfiguration struct {
 const (
 	defaultHealthCheckPath = "/"
 	defaultHealthCheckPort = 13133
+	defaultOTLPGRPCPort    = 4317
 )
 
 // ConfigToContainerProbe converts the incoming configuration object into a container probe or returns an error.
@@ -98,6 +108,69 @@ func ConfigToContainerProbe(config map[interface{}]interface{}) (*corev1.Probe,
 	return nil, errNoExtensionHealthCheck
 }
 
+// ConfigToGRPCContainerProbe converts the incoming configuration object into a container probe using the gRPC health
+// checking protocol on the port of the OTLP gRPC receiver, or returns an error.
+func ConfigToGRPCContainerProbe(config map[interface{}]interface{}) (*corev1.Probe, error) {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return nil, errNoOTLPGRPCReceiver
+	}
+
+	// in the event of multiple OTLP receivers, the names are sorted to always probe the same one
+	names := make([]string, 0, len(receivers))
+	for k := range receivers {
+		name, ok := k.(string)
+		if ok && (name == "otlp" || strings.HasPrefix(name, "otlp/")) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		receiver, ok := receivers[name].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		protocols, ok := receiver["protocols"].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		grpc, ok := protocols["grpc"]
+		if !ok {
+			continue
+		}
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				GRPC: &corev1.GRPCAction{
+					Port: extractGRPCPort(grpc),
+				},
+			},
+		}, nil
+	}
+
+	return nil, errNoOTLPGRPCReceiver
+}
+
+func extractGRPCPort(protocol interface{}) int32 {
+	cfg, ok := protocol.(map[interface{}]interface{})
+	if !ok {
+		return defaultOTLPGRPCPort
+	}
+	endpoint, ok := cfg["endpoint"].(string)
+	if !ok {
+		return defaultOTLPGRPCPort
+	}
+	i := strings.LastIndex(endpoint, ":")
+	if i < 0 {
+		return defaultOTLPGRPCPort
+	}
+	port, err := strconv.ParseInt(endpoint[i+1:], 10, 32)
+	if err != nil {
+		return defaultOTLPGRPCPort
+	}
+	return int32(port)
+}
+
 func createProbeFromExtension(extension interface{}) (*corev1.Probe, error) {
 	probeCfg := extractProbeConfigurationFromExtension(extension)
 	return &corev1.Probe{
@@ -146,11 +219,12 @@ func extractPortFromExtensionConfig(cfg map[interface{}]interface{}) intstr.IntO
 	if !ok {
 		return defaultHealthCheckEndpoint()
 	}
-	endpointComponents := strings.Split(parsedEndpoint, ":")
-	if len(endpointComponents) != 2 {
+	// the host can be an IPv6 address, or an environment variable like ${env:MY_POD_IP}
+	_, port, err := net.SplitHostPort(envVarPattern.ReplaceAllString(parsedEndpoint, "host"))
+	if err != nil || port == "" {
 		return defaultHealthCheckEndpoint()
 	}
-	return intstr.Parse(endpointComponents[1])
+	return intstr.Parse(port)
 }
 
 func defaultHealthCheckEndpoint() intstr.IntOrString {
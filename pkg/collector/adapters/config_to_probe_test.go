@@ -62,6 +62,24 @@ service:
 			config: `extensions:
   health_check:
     endpoint: :1234
+service:
+  extensions: [health_check]`,
+		}, {
+			desc:         "CustomEndpointWithIPv6Host",
+			expectedPort: int32(1234),
+			expectedPath: "/",
+			config: `extensions:
+  health_check:
+    endpoint: "[::]:1234"
+service:
+  extensions: [health_check]`,
+		}, {
+			desc:         "CustomEndpointWithEnvironmentVariableHost",
+			expectedPort: int32(1234),
+			expectedPath: "/",
+			config: `extensions:
+  health_check:
+    endpoint: ${env:MY_POD_IP}:1234
 service:
   extensions: [health_check]`,
 		}, {
@@ -185,3 +203,77 @@ service: [hi]`,
 		assert.Equal(t, test.expectedErr, err, test.desc)
 	}
 }
+
+func TestConfigToGRPCProbe(t *testing.T) {
+	tests := []struct {
+		desc         string
+		config       string
+		expectedPort int32
+	}{
+		{
+			desc:         "DefaultEndpoint",
+			expectedPort: int32(4317),
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:`,
+		}, {
+			desc:         "CustomEndpoint",
+			expectedPort: int32(14317),
+			config: `receivers:
+  otlp/custom:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:14317`,
+		}, {
+			desc:         "FirstReceiverWithGRPC",
+			expectedPort: int32(24317),
+			config: `receivers:
+  otlp/a:
+    protocols:
+      http:
+  otlp/b:
+    protocols:
+      grpc:
+        endpoint: :24317`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(test.config)
+			require.NoError(t, err, test.desc)
+
+			// test
+			actualProbe, err := ConfigToGRPCContainerProbe(config)
+
+			// verify
+			require.NoError(t, err)
+			require.NotNil(t, actualProbe.GRPC)
+			assert.Equal(t, test.expectedPort, actualProbe.GRPC.Port)
+		})
+	}
+}
+
+func TestConfigToGRPCProbeShouldErrorWithoutOTLPGRPCReceiver(t *testing.T) {
+	for _, config := range []string{
+		`exporters:
+  logging:`,
+		`receivers:
+  otlp:
+    protocols:
+      http:`,
+		`receivers:
+  jaeger:
+    protocols:
+      grpc:`,
+	} {
+		parsed, err := ConfigFromString(config)
+		require.NoError(t, err)
+
+		_, err = ConfigToGRPCContainerProbe(parsed)
+		assert.ErrorIs(t, err, errNoOTLPGRPCReceiver)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// insecureTLSFields are the TLS settings disabling the transport security or the certificate verification.
+var insecureTLSFields = map[string]bool{
+	"insecure":             true,
+	"insecure_skip_verify": true,
+}
+
+// ConfigToInsecureTLSSettings returns a description of each receiver and exporter TLS setting that is insecure,
+// like "exporter 'otlp' sets 'tls.insecure: true'".
+func ConfigToInsecureTLSSettings(config map[interface{}]interface{}) []string {
+	var settings []string
+	for section, kind := range map[string]string{"receivers": "receiver", "exporters": "exporter"} {
+		components, ok := config[section].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for name, component := range components {
+			for _, field := range insecureFields(component, "") {
+				settings = append(settings, fmt.Sprintf("%s '%v' sets '%s: true'", kind, name, field))
+			}
+		}
+	}
+	sort.Strings(settings)
+	return settings
+}
+
+func insecureFields(value interface{}, path string) []string {
+	var fields []string
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for k, item := range v {
+			key := fmt.Sprintf("%v", k)
+			fieldPath := key
+			if path != "" {
+				fieldPath = strings.Join([]string{path, key}, ".")
+			}
+			if enabled, ok := item.(bool); ok && enabled && insecureTLSFields[key] {
+				fields = append(fields, fieldPath)
+				continue
+			}
+			fields = append(fields, insecureFields(item, fieldPath)...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			fields = append(fields, insecureFields(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return fields
+}
+
+// ReceiverCertFile is a certificate file a receiver serves TLS with.
+type ReceiverCertFile struct {
+	Receiver string
+	Path     string
+}
+
+// ConfigToReceiverCertFiles returns the "cert_file" TLS settings of the receivers, sorted by receiver and path.
+func ConfigToReceiverCertFiles(config map[interface{}]interface{}) []ReceiverCertFile {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+
+	var files []ReceiverCertFile
+	for name, receiver := range receivers {
+		for _, path := range certFiles(receiver) {
+			files = append(files, ReceiverCertFile{Receiver: fmt.Sprintf("%v", name), Path: path})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Receiver != files[j].Receiver {
+			return files[i].Receiver < files[j].Receiver
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+func certFiles(value interface{}) []string {
+	var files []string
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for k, item := range v {
+			if path, ok := item.(string); ok && k == "cert_file" && path != "" {
+				files = append(files, path)
+				continue
+			}
+			files = append(files, certFiles(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			files = append(files, certFiles(item)...)
+		}
+	}
+	return files
+}
+
+// ReceiverTLSFiles are the certificate and key files of a TLS setting of a receiver.
+type ReceiverTLSFiles struct {
+	Receiver string
+	CertFile string
+	KeyFile  string
+}
+
+// ReceiverTLSDir is a directory holding the certificate and key files of a receiver.
+type ReceiverTLSDir struct {
+	Receiver string
+	Dir      string
+	// CertFiles and KeyFiles are the names of the files in the directory.
+	CertFiles []string
+	KeyFiles  []string
+}
+
+// ConfigToReceiverTLSFiles returns the TLS settings of the receivers with both a "cert_file" and a "key_file", sorted
+// by receiver and certificate file.
+func ConfigToReceiverTLSFiles(config map[interface{}]interface{}) []ReceiverTLSFiles {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+
+	var files []ReceiverTLSFiles
+	for name, receiver := range receivers {
+		for _, f := range tlsFiles(receiver) {
+			f.Receiver = fmt.Sprintf("%v", name)
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Receiver != files[j].Receiver {
+			return files[i].Receiver < files[j].Receiver
+		}
+		return files[i].CertFile < files[j].CertFile
+	})
+	return files
+}
+
+func tlsFiles(value interface{}) []ReceiverTLSFiles {
+	var files []ReceiverTLSFiles
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		certFile, _ := v["cert_file"].(string)
+		keyFile, _ := v["key_file"].(string)
+		if certFile != "" && keyFile != "" {
+			files = append(files, ReceiverTLSFiles{CertFile: certFile, KeyFile: keyFile})
+		}
+		for _, item := range v {
+			files = append(files, tlsFiles(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			files = append(files, tlsFiles(item)...)
+		}
+	}
+	return files
+}
+
+// ReceiverTLSDirs groups the TLS files by directory, sorted by directory. The files within one of the mounted
+// directories are skipped. The certificate and key files of a TLS setting need to be in the same directory, which
+// can't be shared by several receivers.
+func ReceiverTLSDirs(files []ReceiverTLSFiles, mounted []string) ([]ReceiverTLSDir, error) {
+	dirs := map[string]*ReceiverTLSDir{}
+	for _, f := range files {
+		certFile, keyFile := path.Clean(f.CertFile), path.Clean(f.KeyFile)
+		if isMounted(certFile, mounted) && isMounted(keyFile, mounted) {
+			continue
+		}
+		dir := path.Dir(certFile)
+		if path.Dir(keyFile) != dir {
+			return nil, fmt.Errorf("the cert_file %s and key_file %s of receiver '%s' aren't in the same directory", f.CertFile, f.KeyFile, f.Receiver)
+		}
+		d, ok := dirs[dir]
+		if !ok {
+			d = &ReceiverTLSDir{Receiver: f.Receiver, Dir: dir}
+			dirs[dir] = d
+		}
+		if d.Receiver != f.Receiver {
+			return nil, fmt.Errorf("the TLS files of receivers '%s' and '%s' are both in the directory %s", d.Receiver, f.Receiver, dir)
+		}
+		d.CertFiles = appendUnique(d.CertFiles, path.Base(certFile))
+		d.KeyFiles = appendUnique(d.KeyFiles, path.Base(keyFile))
+	}
+
+	result := make([]ReceiverTLSDir, 0, len(dirs))
+	for _, d := range dirs {
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dir < result[j].Dir
+	})
+	return result, nil
+}
+
+func isMounted(file string, mounted []string) bool {
+	for _, m := range mounted {
+		m = path.Clean(m)
+		if file == m || strings.HasPrefix(file, m+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigToInsecureTLSSettings(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		expected []string
+	}{
+		{
+			desc: "SecureConfig",
+			config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/tls.crt
+exporters:
+  otlp:
+    endpoint: collector:4317
+    tls:
+      insecure: false`,
+		}, {
+			desc: "InsecureExporter",
+			config: `exporters:
+  otlp:
+    endpoint: collector:4317
+    tls:
+      insecure: true
+  otlphttp:
+    tls:
+      insecure_skip_verify: true`,
+			expected: []string{
+				"exporter 'otlp' sets 'tls.insecure: true'",
+				"exporter 'otlphttp' sets 'tls.insecure_skip_verify: true'",
+			},
+		}, {
+			desc: "InsecureReceiver",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel
+        tls_config:
+          insecure_skip_verify: true`,
+			expected: []string{
+				"receiver 'prometheus' sets 'config.scrape_configs[0].tls_config.insecure_skip_verify: true'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			config, err := ConfigFromString(tt.config)
+			require.NoError(t, err)
+
+			// test
+			actual := ConfigToInsecureTLSSettings(config)
+
+			// verify
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestConfigToReceiverCertFiles(t *testing.T) {
+	// prepare
+	config, err := ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/grpc/tls.crt
+          key_file: /certs/grpc/tls.key
+      http:
+        tls:
+          cert_file: /certs/http/tls.crt
+  jaeger:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/jaeger/tls.crt
+  zipkin: {}
+exporters:
+  otlp:
+    tls:
+      cert_file: /certs/client/tls.crt`)
+	require.NoError(t, err)
+
+	// test
+	actual := ConfigToReceiverCertFiles(config)
+
+	// verify
+	assert.Equal(t, []ReceiverCertFile{
+		{Receiver: "jaeger", Path: "/certs/jaeger/tls.crt"},
+		{Receiver: "otlp", Path: "/certs/grpc/tls.crt"},
+		{Receiver: "otlp", Path: "/certs/http/tls.crt"},
+	}, actual)
+}
+
+func TestReceiverTLSDirs(t *testing.T) {
+	config, err := ConfigFromString(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/otlp/tls.crt
+          key_file: /certs/otlp/tls.key
+      http:
+        tls:
+          cert_file: /certs/otlp/http.crt
+          key_file: /certs/otlp/http.key
+  jaeger:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /mounted/tls.crt
+          key_file: /mounted/tls.key
+  zipkin:
+    tls:
+      cert_file: /certs/zipkin/tls.crt`)
+	require.NoError(t, err)
+
+	// test
+	files := ConfigToReceiverTLSFiles(config)
+	dirs, err := ReceiverTLSDirs(files, []string{"/mounted"})
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Equal(t, []ReceiverTLSDir{{
+		Receiver:  "otlp",
+		Dir:       "/certs/otlp",
+		CertFiles: []string{"http.crt", "tls.crt"},
+		KeyFiles:  []string{"http.key", "tls.key"},
+	}}, dirs)
+}
+
+func TestReceiverTLSDirsConflicts(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		files       []ReceiverTLSFiles
+		expectedErr string
+	}{
+		{
+			desc:        "different directories",
+			files:       []ReceiverTLSFiles{{Receiver: "otlp", CertFile: "/certs/tls.crt", KeyFile: "/keys/tls.key"}},
+			expectedErr: "aren't in the same directory",
+		},
+		{
+			desc: "shared directory",
+			files: []ReceiverTLSFiles{
+				{Receiver: "jaeger", CertFile: "/certs/jaeger.crt", KeyFile: "/certs/jaeger.key"},
+				{Receiver: "otlp", CertFile: "/certs/otlp.crt", KeyFile: "/certs/otlp.key"},
+			},
+			expectedErr: "are both in the directory /certs",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := ReceiverTLSDirs(tt.files, nil)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -109,3 +109,26 @@ func GetEnabledReceivers(_ logr.Logger, config map[interface{}]interface{}) map[
 	}
 	return availableReceivers
 }
+
+// GetEnabledProcessors returns the processors referenced by at least one pipeline, with their configuration.
+func GetEnabledProcessors(_ logr.Logger, config map[interface{}]interface{}) map[string]interface{} {
+	processors, _ := config["processors"].(map[interface{}]interface{})
+	cfgService, _ := config["service"].(map[interface{}]interface{})
+	pipelines, _ := cfgService["pipelines"].(map[interface{}]interface{})
+
+	enabled := map[string]interface{}{}
+	for _, pipelineCfg := range pipelines {
+		pipelineDesc, _ := pipelineCfg.(map[interface{}]interface{})
+		names, _ := pipelineDesc["processors"].([]interface{})
+		for _, name := range names {
+			processorName, ok := name.(string)
+			if !ok {
+				continue
+			}
+			if processorCfg, ok := processors[processorName]; ok {
+				enabled[processorName] = processorCfg
+			}
+		}
+	}
+	return enabled
+}
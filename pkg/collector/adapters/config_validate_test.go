@@ -105,3 +105,39 @@ service:
 	check := GetEnabledReceivers(logger, config)
 	require.Empty(t, check)
 }
+
+func TestEnabledProcessors(t *testing.T) {
+	// prepare
+	configStr := `
+receivers:
+  otlp:
+processors:
+  batch:
+  k8sattributes/pods:
+    passthrough: true
+  memory_limiter:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [k8sattributes/pods, batch]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp]
+      processors: [batch, undefined]
+      exporters: [logging]
+`
+	config, err := ConfigFromString(configStr)
+	require.NoError(t, err)
+
+	// test
+	processors := GetEnabledProcessors(logger, config)
+
+	// verify
+	require.Equal(t, map[string]interface{}{
+		"batch":              nil,
+		"k8sattributes/pods": map[interface{}]interface{}{"passthrough": true},
+	}, processors)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// Affinity returns the affinity of the collector pods, adding the anti-affinity with the pods of
+// spec.antiAffinityTarget to spec.affinity.
+func Affinity(otelcol v1alpha1.OpenTelemetryCollector) *corev1.Affinity {
+	target := otelcol.Spec.AntiAffinityTarget
+	if target == nil || target.PodLabelSelector == nil {
+		return otelcol.Spec.Affinity
+	}
+
+	affinity := &corev1.Affinity{}
+	if otelcol.Spec.Affinity != nil {
+		affinity = otelcol.Spec.Affinity.DeepCopy()
+	}
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			LabelSelector: target.PodLabelSelector.DeepCopy(),
+			Namespaces:    target.Namespaces,
+			TopologyKey:   corev1.LabelHostname,
+		},
+	)
+	return affinity
+}
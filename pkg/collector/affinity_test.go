@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestAffinityWithoutAntiAffinityTarget(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Affinity: testAffinityValue,
+		},
+	}
+
+	assert.Same(t, testAffinityValue, Affinity(otelcol))
+	assert.Nil(t, Affinity(v1alpha1.OpenTelemetryCollector{}))
+}
+
+func TestAffinityWithAntiAffinityTarget(t *testing.T) {
+	// prepare
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "latency-sensitive"}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Affinity: testAffinityValue,
+			AntiAffinityTarget: &v1alpha1.AntiAffinityTargetSpec{
+				PodLabelSelector: selector,
+				Namespaces:       []string{"apps"},
+			},
+		},
+	}
+
+	// test
+	affinity := Affinity(otelcol)
+
+	// verify
+	assert.Equal(t, testAffinityValue.NodeAffinity, affinity.NodeAffinity)
+	assert.Equal(t, []corev1.PodAffinityTerm{{
+		LabelSelector: selector,
+		Namespaces:    []string{"apps"},
+		TopologyKey:   "kubernetes.io/hostname",
+	}}, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	assert.Nil(t, testAffinityValue.PodAntiAffinity, "the spec affinity must not be modified")
+}
@@ -18,11 +18,13 @@ import (
 	"crypto/sha256"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
 // Annotations return the annotations for OpenTelemetryCollector pod.
-func Annotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+func Annotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	annotations := map[string]string{}
 
@@ -32,10 +34,8 @@ func Annotations(instance v1alpha1.OpenTelemetryCollector) map[string]string {
 	annotations["prometheus.io/path"] = "/metrics"
 
 	// allow override of prometheus annotations
-	if nil != instance.Annotations {
-		for k, v := range instance.Annotations {
-			annotations[k] = v
-		}
+	for k, v := range PropagatedAnnotations(instance, filterAnnotations) {
+		annotations[k] = v
 	}
 	// make sure sha256 for configMap is always calculated
 	annotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
@@ -48,17 +48,41 @@ func PodAnnotations(instance v1alpha1.OpenTelemetryCollector) map[string]string
 	// new map every time, so that we don't touch the instance's annotations
 	podAnnotations := map[string]string{}
 
+	for k, v := range instance.Spec.AdditionalAnnotations {
+		podAnnotations[k] = v
+	}
 	// allow override of pod annotations
 	for k, v := range instance.Spec.PodAnnotations {
 		podAnnotations[k] = v
 	}
 
-	// make sure sha256 for configMap is always calculated
-	podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	// the config changes roll out the pods, unless the running collectors reload it
+	if !ConfigReloads(instance) {
+		podAnnotations["opentelemetry-operator-config/sha256"] = getConfigMapSHA(instance.Spec.Config)
+	}
 
 	return podAnnotations
 }
 
+// PropagatedAnnotations returns the annotations of the instance propagated to the objects generated for it, along with
+// its additional annotations. The filtered annotations and the copy of the instance kept by kubectl aren't propagated.
+func PropagatedAnnotations(instance v1alpha1.OpenTelemetryCollector, filterAnnotations []string) map[string]string {
+	if len(instance.Annotations) == 0 && len(instance.Spec.AdditionalAnnotations) == 0 {
+		return nil
+	}
+	// new map every time, so that we don't touch the instance's annotations
+	annotations := map[string]string{}
+	for k, v := range instance.Annotations {
+		if k != corev1.LastAppliedConfigAnnotation && !isFilteredLabel(k, filterAnnotations) {
+			annotations[k] = v
+		}
+	}
+	for k, v := range instance.Spec.AdditionalAnnotations {
+		annotations[k] = v
+	}
+	return annotations
+}
+
 func getConfigMapSHA(config string) string {
 	h := sha256.Sum256([]byte(config))
 	return fmt.Sprintf("%x", h)
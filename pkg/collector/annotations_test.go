@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -36,7 +37,7 @@ func TestDefaultAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, []string{})
 	podAnnotations := PodAnnotations(otelcol)
 
 	//verify
@@ -65,7 +66,7 @@ func TestUserAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, []string{})
 	podAnnotations := PodAnnotations(otelcol)
 
 	//verify
@@ -88,7 +89,7 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	}
 
 	// test
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, []string{})
 	podAnnotations := PodAnnotations(otelcol)
 
 	// verify
@@ -96,3 +97,71 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", annotations["myapp"])
 	assert.Equal(t, "pod_annotation_value", podAnnotations["pod_annotation"])
 }
+
+func TestPodAnnotationsConfigReload(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:               "test",
+			ConfigReloadStrategy: v1alpha1.ConfigReloadStrategyReload,
+		},
+	}
+
+	// test
+	annotations := Annotations(otelcol, []string{})
+	podAnnotations := PodAnnotations(otelcol)
+
+	// verify
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", annotations["opentelemetry-operator-config/sha256"])
+	assert.NotContains(t, podAnnotations, "opentelemetry-operator-config/sha256")
+}
+
+func TestAdditionalAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"myapp": "mycomponent", "team": "instance"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			AdditionalAnnotations: map[string]string{"team": "additional", "owner": "additional"},
+			PodAnnotations:        map[string]string{"owner": "pod"},
+		},
+	}
+
+	// test
+	annotations := Annotations(otelcol, []string{})
+	podAnnotations := PodAnnotations(otelcol)
+
+	// verify
+	assert.Equal(t, "mycomponent", annotations["myapp"])
+	assert.Equal(t, "additional", annotations["team"])
+	assert.Equal(t, "additional", annotations["owner"])
+	assert.Equal(t, "additional", podAnnotations["team"])
+	assert.Equal(t, "pod", podAnnotations["owner"])
+}
+
+func TestPropagatedAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"myapp":                            "mycomponent",
+				"argocd.argoproj.io/sync-wave":     "1",
+				corev1.LastAppliedConfigAnnotation: "{}",
+			},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			AdditionalAnnotations: map[string]string{"argocd.argoproj.io/managed": "false"},
+		},
+	}
+
+	// test
+	annotations := PropagatedAnnotations(otelcol, []string{"argocd.argoproj.io/*"})
+
+	// verify
+	assert.Equal(t, map[string]string{
+		"myapp":                      "mycomponent",
+		"argocd.argoproj.io/managed": "false",
+	}, annotations)
+	assert.Len(t, otelcol.Annotations, 3)
+}
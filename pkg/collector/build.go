@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// BuildRevisionAnnotation holds the revision of the custom distribution the build Job builds.
+const BuildRevisionAnnotation = "opentelemetry.io/build-revision"
+
+const (
+	// buildWorkspace is the directory shared by the containers of the build Job.
+	buildWorkspace = "/workspace"
+	// buildBinary is the name of the collector binary of the custom distributions.
+	buildBinary = "otelcol"
+	// buildScript writes the manifest and the Dockerfile of the distribution to the workspace, then builds it with
+	// the OCB version matching its collector version.
+	buildScript = `set -e
+printf '%s' "$MANIFEST" > ` + buildWorkspace + `/manifest.yaml
+printf 'FROM %s\nCOPY dist/` + buildBinary + ` /` + buildBinary + `\nENTRYPOINT ["/` + buildBinary + `"]\n' "$BASE_IMAGE" > ` + buildWorkspace + `/Dockerfile
+go install go.opentelemetry.io/collector/cmd/builder@v"$OTELCOL_VERSION"
+builder --config=` + buildWorkspace + `/manifest.yaml`
+)
+
+// BuildManifest returns the OCB manifest of the custom distribution of the instance, with the dist section set by
+// the operator, along with the collector version it's built with.
+func BuildManifest(otelcol v1alpha1.OpenTelemetryCollector) (string, string, error) {
+	manifest := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(otelcol.Spec.Build.Manifest), &manifest); err != nil {
+		return "", "", fmt.Errorf("failed to parse the build manifest: %w", err)
+	}
+
+	dist := map[interface{}]interface{}{}
+	if existing, ok := manifest["dist"].(map[interface{}]interface{}); ok {
+		dist = existing
+	}
+	dist["name"] = buildBinary
+	dist["output_path"] = buildWorkspace + "/dist"
+	otelcolVersion, _ := dist["otelcol_version"].(string)
+	if otelcolVersion == "" {
+		otelcolVersion = version.OpenTelemetryCollector()
+		dist["otelcol_version"] = otelcolVersion
+	}
+	manifest["dist"] = dist
+
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", "", err
+	}
+	return string(out), strings.TrimPrefix(otelcolVersion, "v"), nil
+}
+
+// BuildRevision returns a short hash of the manifest of the custom distribution, tagging its image. The manifest
+// set by the operator is hashed, so that the distribution is rebuilt when its default collector version changes.
+func BuildRevision(otelcol v1alpha1.OpenTelemetryCollector) string {
+	manifest, _, err := BuildManifest(otelcol)
+	if err != nil {
+		// the invalid manifests fail to build anyway
+		manifest = otelcol.Spec.Build.Manifest
+	}
+	h := sha256.Sum256([]byte(manifest))
+	return fmt.Sprintf("%x", h)[:10]
+}
+
+// BuildImage returns the image of the custom distribution of the instance.
+func BuildImage(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return fmt.Sprintf("%s:%s", otelcol.Spec.Build.Image, BuildRevision(otelcol))
+}
+
+// BuildJob builds the Job building the custom distribution of the instance with OCB, and pushing its image with kaniko.
+func BuildJob(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) (batchv1.Job, error) {
+	manifest, otelcolVersion, err := BuildManifest(otelcol)
+	if err != nil {
+		return batchv1.Job{}, err
+	}
+
+	name := naming.CollectorBuild(otelcol)
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = name
+	labels["app.kubernetes.io/component"] = "opentelemetry-build"
+
+	volumes := []corev1.Volume{{
+		Name:         "workspace",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	workspaceMount := corev1.VolumeMount{Name: "workspace", MountPath: buildWorkspace}
+	kanikoMounts := []corev1.VolumeMount{workspaceMount}
+	if otelcol.Spec.Build.PushSecret != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "push-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: otelcol.Spec.Build.PushSecret.Name,
+					Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+				},
+			},
+		})
+		kanikoMounts = append(kanikoMounts, corev1.VolumeMount{Name: "push-secret", MountPath: "/kaniko/.docker", ReadOnly: true})
+	}
+
+	annotations := PropagatedAnnotations(otelcol, cfg.AnnotationsFilter())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[BuildRevisionAnnotation] = BuildRevision(otelcol)
+
+	backoffLimit := int32(0)
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: ServiceAccountName(otelcol),
+					RestartPolicy:      corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{{
+						Name:    "builder",
+						Image:   buildImage(cfg, config.FIPSImageCollectorBuilder, cfg.CollectorBuilderImage()),
+						Command: []string{"sh", "-c", buildScript},
+						Env: []corev1.EnvVar{
+							{Name: "MANIFEST", Value: manifest},
+							{Name: "OTELCOL_VERSION", Value: otelcolVersion},
+							{Name: "BASE_IMAGE", Value: buildImage(cfg, config.FIPSImageCollectorBuildBase, cfg.CollectorBuildBaseImage())},
+						},
+						VolumeMounts: []corev1.VolumeMount{workspaceMount},
+						Resources:    otelcol.Spec.Build.Resources,
+					}},
+					Containers: []corev1.Container{{
+						Name:  "kaniko",
+						Image: buildImage(cfg, config.FIPSImageImageBuilder, cfg.ImageBuilderImage()),
+						Args: []string{
+							"--dockerfile=" + buildWorkspace + "/Dockerfile",
+							"--context=dir://" + buildWorkspace,
+							"--destination=" + BuildImage(otelcol),
+						},
+						VolumeMounts: kanikoMounts,
+					}},
+					Volumes:      volumes,
+					Tolerations:  otelcol.Spec.Tolerations,
+					NodeSelector: otelcol.Spec.NodeSelector,
+				},
+			},
+		},
+	}, nil
+}
+
+// buildImage returns the given image of the build Job, or its FIPS-validated image in FIPS mode.
+func buildImage(cfg config.Config, component, image string) string {
+	if fipsImage, ok := cfg.FIPSImage(component); ok && cfg.FIPS() {
+		return fipsImage
+	}
+	return image
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+const buildManifest = `dist:
+  name: my-distribution
+  output_path: /tmp/dist
+receivers:
+  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.74.0
+`
+
+func TestBuildManifest(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		manifest        string
+		expectedVersion string
+	}{
+		{
+			name:            "default collector version",
+			manifest:        buildManifest,
+			expectedVersion: version.OpenTelemetryCollector(),
+		},
+		{
+			name:            "custom collector version",
+			manifest:        "dist:\n  otelcol_version: v0.74.0\nreceivers:\n  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.74.0\n",
+			expectedVersion: "0.74.0",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Build: &v1alpha1.BuildSpec{Manifest: tt.manifest},
+				},
+			}
+
+			// test
+			manifest, otelcolVersion, err := BuildManifest(otelcol)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, otelcolVersion)
+			parsed := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(manifest), &parsed))
+			dist := parsed["dist"].(map[interface{}]interface{})
+			assert.Equal(t, "otelcol", dist["name"])
+			assert.Equal(t, "/workspace/dist", dist["output_path"])
+			assert.Len(t, parsed["receivers"], 1)
+		})
+	}
+}
+
+func TestBuildManifestInvalid(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Build: &v1alpha1.BuildSpec{Manifest: "receivers: ["},
+		},
+	}
+
+	// test
+	_, _, err := BuildManifest(otelcol)
+
+	// verify
+	assert.ErrorContains(t, err, "failed to parse the build manifest")
+}
+
+func TestBuildRevision(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: {}",
+			Build: &v1alpha1.BuildSpec{
+				Manifest: buildManifest,
+				Image:    "registry.example.com/otelcol",
+			},
+		},
+	}
+
+	// test
+	revision := BuildRevision(otelcol)
+	otelcol.Spec.Config = "receivers: {otlp: {}}"
+	changedConfig := BuildRevision(otelcol)
+	otelcol.Spec.Build.Manifest = buildManifest + "exporters:\n  - gomod: go.opentelemetry.io/collector/exporter/otlpexporter v0.74.0\n"
+	changedManifest := BuildRevision(otelcol)
+
+	// verify
+	assert.Len(t, revision, 10)
+	assert.Equal(t, revision, changedConfig)
+	assert.NotEqual(t, revision, changedManifest)
+	assert.Equal(t, "registry.example.com/otelcol:"+changedManifest, BuildImage(otelcol))
+}
+
+func TestBuildJob(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Build: &v1alpha1.BuildSpec{
+				Manifest: buildManifest,
+				Image:    "registry.example.com/otelcol",
+			},
+		},
+	}
+	cfg := config.New(
+		config.WithCollectorBuilderImage("golang:1.19"),
+		config.WithImageBuilderImage("kaniko:v1.9.1"),
+		config.WithCollectorBuildBaseImage("alpine:3.17"),
+	)
+
+	// test
+	job, err := BuildJob(cfg, logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "my-instance-build", job.Name)
+	assert.Equal(t, BuildRevision(otelcol), job.Annotations[BuildRevisionAnnotation])
+	assert.Equal(t, "my-ns", job.Namespace)
+	assert.Equal(t, "opentelemetry-build", job.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	assert.Equal(t, "my-instance-collector", podSpec.ServiceAccountName)
+	require.Len(t, podSpec.InitContainers, 1)
+	assert.Equal(t, "golang:1.19", podSpec.InitContainers[0].Image)
+	assert.Contains(t, podSpec.InitContainers[0].Env, corev1.EnvVar{Name: "BASE_IMAGE", Value: "alpine:3.17"})
+	require.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, "kaniko:v1.9.1", podSpec.Containers[0].Image)
+	assert.Contains(t, podSpec.Containers[0].Args, "--destination="+BuildImage(otelcol))
+	assert.Len(t, podSpec.Volumes, 1)
+}
+
+func TestBuildJobFIPSImages(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Build: &v1alpha1.BuildSpec{
+				Manifest: buildManifest,
+				Image:    "registry.example.com/otelcol",
+			},
+		},
+	}
+	cfg := config.New(
+		config.WithCollectorBuilderImage("golang:1.19"),
+		config.WithImageBuilderImage("kaniko:v1.9.1"),
+		config.WithCollectorBuildBaseImage("alpine:3.17"),
+		config.WithFIPS(true),
+		config.WithFIPSImages(map[string]string{
+			config.FIPSImageCollectorBuilder:   "golang-fips",
+			config.FIPSImageImageBuilder:       "kaniko-fips",
+			config.FIPSImageCollectorBuildBase: "base-fips",
+		}),
+	)
+
+	// test
+	job, err := BuildJob(cfg, logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, "golang-fips", podSpec.InitContainers[0].Image)
+	assert.Contains(t, podSpec.InitContainers[0].Env, corev1.EnvVar{Name: "BASE_IMAGE", Value: "base-fips"})
+	assert.Equal(t, "kaniko-fips", podSpec.Containers[0].Image)
+}
+
+func TestBuildJobPushSecret(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Build: &v1alpha1.BuildSpec{
+				Manifest:   buildManifest,
+				Image:      "registry.example.com/otelcol",
+				PushSecret: &corev1.LocalObjectReference{Name: "registry-credentials"},
+			},
+		},
+	}
+
+	// test
+	job, err := BuildJob(config.New(), logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	podSpec := job.Spec.Template.Spec
+	require.Len(t, podSpec.Volumes, 2)
+	assert.Equal(t, "registry-credentials", podSpec.Volumes[1].Secret.SecretName)
+	assert.Equal(t, corev1.DockerConfigJsonKey, podSpec.Volumes[1].Secret.Items[0].Key)
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "push-secret", MountPath: "/kaniko/.docker", ReadOnly: true})
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// CanaryLabel marks the canary Deployment and ConfigMap of the collector rollouts, as well as the canary pods.
+	CanaryLabel = "opentelemetry.io/canary"
+
+	// canaryComponent is the component label of the canary pods, distinct from the collector one so that the
+	// collector Deployment, Service, PodDisruptionBudget and HorizontalPodAutoscaler don't select them.
+	canaryComponent = "opentelemetry-collector-canary"
+
+	// CanaryRevisionAnnotation holds the revision of the collector config and image running in the canary Deployment.
+	CanaryRevisionAnnotation = "opentelemetry.io/canary-revision"
+
+	defaultCanaryReplicas                = int32(1)
+	defaultCanaryBakeDuration            = 10 * time.Minute
+	defaultCanaryMaxExportFailurePercent = int32(5)
+	defaultMetricsPort                   = int32(8888)
+
+	exporterSentMetricPrefix   = "otelcol_exporter_sent_"
+	exporterFailedMetricPrefix = "otelcol_exporter_send_failed_"
+)
+
+// exportedSignals are the suffixes of the exporter metrics counting the spans, metric points and log records.
+var exportedSignals = []string{"spans", "metric_points", "log_records"}
+
+// UsesCanaryRollout returns whether the config and image changes of the collector are rolled out through a canary Deployment.
+func UsesCanaryRollout(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Canary != nil && otelcol.Spec.Mode == v1alpha1.ModeDeployment
+}
+
+// CanaryRevision returns a short hash identifying the collector image and config rolled out by the canary.
+func CanaryRevision(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	h := sha256.Sum256([]byte(Image(cfg, otelcol) + "\n" + getConfigMapSHA(otelcol.Spec.Config)))
+	return fmt.Sprintf("%x", h)[:10]
+}
+
+// CanaryBakeDuration returns how long the canary must stay healthy before the changes are promoted.
+func CanaryBakeDuration(otelcol v1alpha1.OpenTelemetryCollector) time.Duration {
+	if otelcol.Spec.Canary == nil || otelcol.Spec.Canary.BakeDuration == nil {
+		return defaultCanaryBakeDuration
+	}
+	return otelcol.Spec.Canary.BakeDuration.Duration
+}
+
+// CanaryMaxExportFailurePercent returns the percentage of failed exports above which the canary fails.
+func CanaryMaxExportFailurePercent(otelcol v1alpha1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Canary == nil || otelcol.Spec.Canary.MaxExportFailurePercent == nil {
+		return defaultCanaryMaxExportFailurePercent
+	}
+	return *otelcol.Spec.Canary.MaxExportFailurePercent
+}
+
+// CanaryDeployment builds the canary Deployment running the desired collector config and image. Its pods have their
+// own component label, so that the workloads and the Service of the collector don't select them, and mount the canary
+// ConfigMaps instead of the collector ones.
+func CanaryDeployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, revision string) appsv1.Deployment {
+	deployment := Deployment(cfg, logger, otelcol)
+	name := naming.CanaryCollector(otelcol)
+
+	labels := map[string]string{}
+	for k, v := range deployment.Labels {
+		labels[k] = v
+	}
+	labels["app.kubernetes.io/name"] = name
+	labels[CanaryLabel] = "true"
+	podLabels := map[string]string{}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+	podLabels["app.kubernetes.io/component"] = canaryComponent
+	selector := SelectorLabels(otelcol)
+	selector["app.kubernetes.io/component"] = canaryComponent
+	selector[CanaryLabel] = "true"
+
+	replicas := defaultCanaryReplicas
+	if otelcol.Spec.Canary != nil && otelcol.Spec.Canary.Replicas != nil {
+		replicas = *otelcol.Spec.Canary.Replicas
+	}
+
+	deployment.Name = name
+	deployment.Labels = labels
+	deployment.Annotations[CanaryRevisionAnnotation] = revision
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	deployment.Spec.Template.Labels = podLabels
+	for i, v := range deployment.Spec.Template.Spec.Volumes {
+		if v.Name != naming.ConfigMapVolume() {
+			continue
+		}
+		if v.ConfigMap != nil {
+			deployment.Spec.Template.Spec.Volumes[i].ConfigMap.Name = name
+		}
+		if v.Projected != nil {
+			for j := range v.Projected.Sources {
+				v.Projected.Sources[j].ConfigMap.Name = naming.ConfigMapPart(name, j)
+			}
+		}
+	}
+	return deployment
+}
+
+// MetricsURL returns the URL of the telemetry metrics endpoint of the collector pod.
+func MetricsURL(pod corev1.Pod) string {
+	port := defaultMetricsPort
+	for _, c := range pod.Spec.Containers {
+		if c.Name != naming.Container() {
+			continue
+		}
+		for _, p := range c.Ports {
+			if p.Name == "metrics" {
+				port = p.ContainerPort
+			}
+		}
+	}
+	return fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+}
+
+// ExportCounts reads the number of spans, metric points and log records the collector sent and failed to send from
+// the otelcol_exporter_sent_* and otelcol_exporter_send_failed_* metrics of its telemetry endpoint.
+func ExportCounts(ctx context.Context, httpClient *http.Client, metricsURL string) (float64, float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the collector metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("failed to get the collector metrics, status code %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse the collector metrics: %w", err)
+	}
+
+	var sent, failed float64
+	for name, family := range families {
+		// the counters have a _total suffix in the recent collector versions
+		name = strings.TrimSuffix(name, "_total")
+		for _, signal := range exportedSignals {
+			var total *float64
+			switch name {
+			case exporterSentMetricPrefix + signal:
+				total = &sent
+			case exporterFailedMetricPrefix + signal:
+				total = &failed
+			default:
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				if m.GetCounter() != nil {
+					*total += m.GetCounter().GetValue()
+				} else {
+					*total += m.GetUntyped().GetValue()
+				}
+			}
+		}
+	}
+	return sent, failed, nil
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestCanaryRevision(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithCollectorImage("default-image"))
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: {}",
+		},
+	}
+
+	// test
+	revision := CanaryRevision(cfg, otelcol)
+	otelcol.Spec.Config = "receivers: {otlp: {}}"
+	changedConfig := CanaryRevision(cfg, otelcol)
+	otelcol.Spec.Image = "custom-image"
+	changedImage := CanaryRevision(cfg, otelcol)
+
+	// verify
+	assert.Len(t, revision, 10)
+	assert.NotEqual(t, revision, changedConfig)
+	assert.NotEqual(t, changedConfig, changedImage)
+	assert.Equal(t, changedImage, CanaryRevision(cfg, otelcol))
+}
+
+func TestCanaryDeployment(t *testing.T) {
+	// prepare
+	two := int32(2)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Canary: &v1alpha1.CanarySpec{Replicas: &two},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := CanaryDeployment(cfg, logger, otelcol, "abcdef0123")
+
+	// verify
+	assert.Equal(t, "my-instance-collector-canary", d.Name)
+	assert.Equal(t, "abcdef0123", d.Annotations[CanaryRevisionAnnotation])
+	assert.Equal(t, &two, d.Spec.Replicas)
+	assert.Equal(t, "true", d.Spec.Selector.MatchLabels[CanaryLabel])
+	assert.Equal(t, "true", d.Spec.Template.Labels[CanaryLabel])
+	assert.Equal(t, "my-instance-collector-canary", d.Spec.Template.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "opentelemetry-collector-canary", d.Spec.Template.Labels["app.kubernetes.io/component"])
+	for k, v := range d.Spec.Selector.MatchLabels {
+		assert.Equal(t, v, d.Spec.Template.Labels[k])
+	}
+	// the collector workloads and Service don't select the canary pods
+	assert.False(t, labels.SelectorFromSet(SelectorLabels(otelcol)).Matches(labels.Set(d.Spec.Template.Labels)))
+	require.NotEmpty(t, d.Spec.Template.Spec.Volumes)
+	assert.Equal(t, "my-instance-collector-canary", d.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	// the collector Deployment is left untouched
+	collectorDeployment := Deployment(cfg, logger, otelcol)
+	assert.Empty(t, collectorDeployment.Labels[CanaryLabel])
+	assert.Equal(t, "my-instance-collector", collectorDeployment.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+}
+
+func TestCanaryDefaults(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Canary: &v1alpha1.CanarySpec{},
+		},
+	}
+	assert.True(t, UsesCanaryRollout(otelcol))
+	assert.Equal(t, "10m0s", CanaryBakeDuration(otelcol).String())
+	assert.Equal(t, int32(5), CanaryMaxExportFailurePercent(otelcol))
+
+	otelcol.Spec.Mode = v1alpha1.ModeDaemonSet
+	assert.False(t, UsesCanaryRollout(otelcol))
+}
+
+func TestMetricsURL(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "otc-container",
+				Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 9999}},
+			}},
+		},
+		Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	assert.Equal(t, "http://10.0.0.1:9999/metrics", MetricsURL(pod))
+
+	pod.Spec.Containers[0].Ports = nil
+	pod.Status.PodIP = "fd00::1"
+	assert.Equal(t, "http://[fd00::1]:8888/metrics", MetricsURL(pod))
+}
+
+func TestExportCounts(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		metrics        string
+		expectedSent   float64
+		expectedFailed float64
+	}{
+		{
+			desc: "counters without suffix",
+			metrics: `# TYPE otelcol_exporter_sent_spans counter
+otelcol_exporter_sent_spans{exporter="otlp"} 90
+otelcol_exporter_sent_spans{exporter="logging"} 5
+# TYPE otelcol_exporter_send_failed_spans counter
+otelcol_exporter_send_failed_spans{exporter="otlp"} 10
+# TYPE otelcol_exporter_sent_metric_points counter
+otelcol_exporter_sent_metric_points{exporter="otlp"} 5
+# TYPE otelcol_exporter_queue_size gauge
+otelcol_exporter_queue_size{exporter="otlp"} 100
+`,
+			expectedSent:   100,
+			expectedFailed: 10,
+		},
+		{
+			desc: "counters with the total suffix",
+			metrics: `# TYPE otelcol_exporter_sent_log_records_total counter
+otelcol_exporter_sent_log_records_total{exporter="otlp"} 20
+# TYPE otelcol_exporter_send_failed_log_records_total counter
+otelcol_exporter_send_failed_log_records_total{exporter="otlp"} 2
+`,
+			expectedSent:   20,
+			expectedFailed: 2,
+		},
+		{
+			desc:    "no exporter metrics",
+			metrics: "# TYPE otelcol_process_uptime counter\notelcol_process_uptime 10\n",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.metrics)
+			}))
+			defer server.Close()
+
+			// test
+			sent, failed, err := ExportCounts(context.Background(), server.Client(), server.URL)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSent, sent)
+			assert.Equal(t, tt.expectedFailed, failed)
+		})
+	}
+}
+
+func TestExportCountsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, _, err := ExportCounts(context.Background(), server.Client(), server.URL)
+	assert.ErrorContains(t, err, "status code 503")
+}
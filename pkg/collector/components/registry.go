@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package components checks the components of the collector configs against the components of the collector
+// distributions.
+package components
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// the registry bundled with the operator, so that the check works offline.
+//
+//go:embed registry.yaml
+var bundled []byte
+
+// kinds are the config sections of the components, with the kind of their components.
+var kinds = []struct {
+	key  string
+	kind string
+}{
+	{key: "receivers", kind: "receiver"},
+	{key: "processors", kind: "processor"},
+	{key: "exporters", kind: "exporter"},
+	{key: "extensions", kind: "extension"},
+	{key: "connectors", kind: "connector"},
+}
+
+// Registry lists the components of the collector distributions, per version.
+type Registry struct {
+	Distributions []Distribution `yaml:"distributions"`
+}
+
+// Distribution lists the components of a collector distribution, per version.
+type Distribution struct {
+	// Name of the distribution, e.g. core or contrib.
+	Name string `yaml:"name"`
+	// Images are the repositories of the images of the distribution, without tag.
+	Images []string `yaml:"images"`
+	// Versions maps the versions of the distribution, without the v prefix, to their components.
+	Versions map[string]Components `yaml:"versions"`
+}
+
+// Components lists the component types of a distribution version by kind.
+type Components struct {
+	Receivers  []string `yaml:"receivers,omitempty"`
+	Processors []string `yaml:"processors,omitempty"`
+	Exporters  []string `yaml:"exporters,omitempty"`
+	Extensions []string `yaml:"extensions,omitempty"`
+	Connectors []string `yaml:"connectors,omitempty"`
+}
+
+// Default returns the registry bundled with the operator.
+func Default() Registry {
+	r, err := Parse(bundled)
+	if err != nil {
+		// the bundled registry is checked by the tests
+		panic(err)
+	}
+	return r
+}
+
+// Load reads the registry of the given file, and completes it with the bundled registry. The distributions of the
+// file take precedence over the bundled ones.
+func Load(path string) (Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Registry{}, fmt.Errorf("failed to read the component registry: %w", err)
+	}
+	r, err := Parse(data)
+	if err != nil {
+		return Registry{}, err
+	}
+	r.Distributions = append(r.Distributions, Default().Distributions...)
+	return r, nil
+}
+
+// Parse parses a YAML registry.
+func Parse(data []byte) (Registry, error) {
+	r := Registry{}
+	if err := yaml.UnmarshalStrict(data, &r); err != nil {
+		return Registry{}, fmt.Errorf("failed to parse the component registry: %w", err)
+	}
+	for _, d := range r.Distributions {
+		if d.Name == "" || len(d.Images) == 0 {
+			return Registry{}, fmt.Errorf("the distributions of the component registry need a name and images")
+		}
+	}
+	return r, nil
+}
+
+// Lookup returns the distribution and version of the image, with their components. It returns false when the
+// distribution or its version isn't part of the registry.
+func (r Registry) Lookup(image string) (Distribution, string, Components, bool) {
+	repository, version := splitImage(image)
+	for _, d := range r.Distributions {
+		for _, i := range d.Images {
+			if normalizeRepository(i) != repository {
+				continue
+			}
+			if c, ok := d.Versions[version]; ok {
+				return d, version, c, true
+			}
+		}
+	}
+	return Distribution{}, "", Components{}, false
+}
+
+// Missing returns the components defined in the config which aren't part of the given components, sorted.
+func (c Components) Missing(config map[interface{}]interface{}) []string {
+	available := map[string][]string{
+		"receivers":  c.Receivers,
+		"processors": c.Processors,
+		"exporters":  c.Exporters,
+		"extensions": c.Extensions,
+		"connectors": c.Connectors,
+	}
+
+	var missing []string
+	for _, k := range kinds {
+		components, _ := config[k.key].(map[interface{}]interface{})
+		for id := range components {
+			// the component IDs are either type or type/name
+			componentType := strings.SplitN(fmt.Sprint(id), "/", 2)[0]
+			if !contains(available[k.key], componentType) {
+				missing = append(missing, fmt.Sprintf("%s '%v'", k.kind, id))
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// splitImage returns the normalized repository and the version of the tag of the image.
+func splitImage(image string) (string, string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	var tag string
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, tag = image[:i], image[i+1:]
+	}
+	return normalizeRepository(image), strings.TrimPrefix(tag, "v")
+}
+
+func normalizeRepository(repository string) string {
+	for _, prefix := range []string{"docker.io/", "index.docker.io/"} {
+		repository = strings.TrimPrefix(repository, prefix)
+	}
+	return repository
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const contribRegistry = `distributions:
+  - name: contrib
+    images:
+      - otel/opentelemetry-collector-contrib
+    versions:
+      "0.66.0":
+        receivers: [otlp, filelog]
+        exporters: [otlp]
+`
+
+func TestDefault(t *testing.T) {
+	// test
+	r := Default()
+
+	// verify
+	distribution, version, components, ok := r.Lookup("otel/opentelemetry-collector:0.66.0")
+	require.True(t, ok)
+	assert.Equal(t, "core", distribution.Name)
+	assert.Equal(t, "0.66.0", version)
+	assert.Contains(t, components.Receivers, "otlp")
+}
+
+func TestLookup(t *testing.T) {
+	r := Default()
+	for _, tt := range []struct {
+		image    string
+		expected bool
+	}{
+		{image: "otel/opentelemetry-collector:0.66.0", expected: true},
+		{image: "docker.io/otel/opentelemetry-collector:v0.66.0", expected: true},
+		{image: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.66.0@sha256:abc", expected: true},
+		{image: "otel/opentelemetry-collector:0.1.0", expected: false},
+		{image: "otel/opentelemetry-collector", expected: false},
+		{image: "registry.example.com/otelcol:0.66.0", expected: false},
+	} {
+		t.Run(tt.image, func(t *testing.T) {
+			// test
+			_, _, _, ok := r.Lookup(tt.image)
+
+			// verify
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	// prepare
+	components := Components{
+		Receivers: []string{"otlp"},
+		Exporters: []string{"otlp", "logging"},
+	}
+	config := map[interface{}]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(`receivers:
+  otlp:
+  filelog/app:
+exporters:
+  otlp/gateway:
+  logging:
+extensions:
+  zpages:
+`), &config))
+
+	// test
+	missing := components.Missing(config)
+
+	// verify
+	assert.Equal(t, []string{"extension 'zpages'", "receiver 'filelog/app'"}, missing)
+}
+
+func TestLoad(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contribRegistry), 0600))
+
+	// test
+	r, err := Load(path)
+
+	// verify
+	require.NoError(t, err)
+	distribution, _, _, ok := r.Lookup("otel/opentelemetry-collector-contrib:0.66.0")
+	assert.True(t, ok)
+	assert.Equal(t, "contrib", distribution.Name)
+	_, _, _, ok = r.Lookup("otel/opentelemetry-collector:0.66.0")
+	assert.True(t, ok, "the bundled distributions should still be available")
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		registry    string
+		expectedErr string
+	}{
+		{
+			desc:        "unknown field",
+			registry:    "distributions:\n  - name: core\n    image: otel/opentelemetry-collector\n",
+			expectedErr: "failed to parse the component registry",
+		},
+		{
+			desc:        "missing images",
+			registry:    "distributions:\n  - name: core\n",
+			expectedErr: "need a name and images",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := Parse([]byte(tt.registry))
+
+			// verify
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// configReloaderScript sends a SIGHUP to the collector, found by its config argument, when the config file, or one of
+// the other parts of a split config, changes. The argument is only expanded by the shell, so that the script doesn't
+// match itself.
+const configReloaderScript = `last=$(sha256sum "$CONFIG_FILE" $CONFIG_PARTS)
+while sleep 5; do
+  current=$(sha256sum "$CONFIG_FILE" $CONFIG_PARTS)
+  if [ "$current" != "$last" ] && pkill -HUP -f -- "--config=$CONFIG_FILE"; then
+    echo "the config changed, the collector was sent a SIGHUP"
+    last="$current"
+  fi
+done`
+
+// ConfigReloads returns whether the running collectors of the instance reload their config when it changes. The
+// collectors running on Windows nodes are always restarted, as the reloader is a Linux container.
+func ConfigReloads(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.ConfigReloadStrategy == v1alpha1.ConfigReloadStrategyReload && otelcol.Spec.Mode != v1alpha1.ModeSidecar &&
+		!isWindows(otelcol)
+}
+
+// podContainers returns the collector container, followed by the container reloading its config when needed and the
+// additional containers of the instance.
+func podContainers(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, container corev1.Container) []corev1.Container {
+	containers := []corev1.Container{container}
+	if ConfigReloads(otelcol) {
+		containers = append(containers, ConfigReloaderContainer(cfg, otelcol))
+	}
+	return append(containers, otelcol.Spec.AdditionalContainers...)
+}
+
+// shareProcessNamespace returns whether the containers of the collector pods share their process namespace, either
+// as set by the instance or so that the config reloader can signal the collector.
+func shareProcessNamespace(otelcol v1alpha1.OpenTelemetryCollector) *bool {
+	if otelcol.Spec.ShareProcessNamespace != nil {
+		return otelcol.Spec.ShareProcessNamespace
+	}
+	if !ConfigReloads(otelcol) {
+		return nil
+	}
+	share := true
+	return &share
+}
+
+// ConfigReloaderContainer builds the container reloading the config of the collector, which needs to share the
+// process namespace of the collector container.
+func ConfigReloaderContainer(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
+	env := []corev1.EnvVar{{
+		Name:  "CONFIG_FILE",
+		Value: fmt.Sprintf("/conf/%s", cfg.CollectorConfigMapEntry()),
+	}}
+	if parts := ConfigParts(otelcol); parts > 1 {
+		var files []string
+		for i := 1; i < parts; i++ {
+			files = append(files, fmt.Sprintf("/conf/%s", ConfigPartEntry(cfg, i)))
+		}
+		env = append(env, corev1.EnvVar{Name: "CONFIG_PARTS", Value: strings.Join(files, " ")})
+	}
+
+	image := cfg.ConfigReloaderImage()
+	if fipsImage, ok := cfg.FIPSImage(config.FIPSImageConfigReloader); ok && cfg.FIPS() {
+		image = fipsImage
+	}
+
+	return corev1.Container{
+		Name:    naming.ConfigReloaderContainer(),
+		Image:   image,
+		Command: []string{"sh", "-c", configReloaderScript},
+		Env:     env,
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.ConfigMapVolume(),
+			MountPath: "/conf",
+			ReadOnly:  true,
+		}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("8Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+		// signaling the collector requires running as the same user
+		SecurityContext: configReloaderSecurityContext(otelcol),
+	}
+}
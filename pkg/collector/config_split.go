@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	// configSplitThreshold is the size of the config over which it's split across several ConfigMaps. It's below the
+	// 1MiB limit of the ConfigMaps, leaving room for their metadata and for the rewriting of the config, e.g. for the
+	// target allocator.
+	configSplitThreshold = 768 * 1024
+
+	// maxConfigPartSize is the largest part of the config a ConfigMap can hold along with its metadata.
+	maxConfigPartSize = 1024*1024 - 32*1024
+)
+
+// splitSections are the sections of the config split by component, the other top-level keys, like the service, are
+// kept whole.
+var splitSections = map[string]bool{
+	"receivers":  true,
+	"processors": true,
+	"exporters":  true,
+	"extensions": true,
+	"connectors": true,
+}
+
+// configFragment is a component of a split section of the config, or a whole top-level key.
+type configFragment struct {
+	key     string
+	section interface{}
+	id      interface{}
+	value   interface{}
+}
+
+// ConfigParts returns the number of ConfigMaps the config of the instance is split across, 1 unless the config is
+// larger than the threshold. The sidecars get their config from an environment variable, and it's never split.
+func ConfigParts(otelcol v1alpha1.OpenTelemetryCollector) int {
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar || len(otelcol.Spec.Config) <= configSplitThreshold {
+		return 1
+	}
+	_, parts := configPartition(otelcol.Spec.Config)
+	return parts
+}
+
+// ConfigPartEntry returns the file the given part of the config is mounted as, next to the collector config.
+func ConfigPartEntry(cfg config.Config, part int) string {
+	entry := cfg.CollectorConfigMapEntry()
+	if part == 0 {
+		return entry
+	}
+	if i := strings.LastIndex(entry, "."); i > 0 {
+		return fmt.Sprintf("%s-%d%s", entry[:i], part, entry[i:])
+	}
+	return fmt.Sprintf("%s-%d", entry, part)
+}
+
+// SplitConfig splits the rendered config of the instance into ConfigParts parts, which the collector merges back
+// from its --config flags. The components are assigned to the parts from the config of the instance, so that the
+// rewriting of the config doesn't change the number of parts, and the new ones are added to the first part.
+func SplitConfig(otelcol v1alpha1.OpenTelemetryCollector, rendered string) ([]string, error) {
+	parts := ConfigParts(otelcol)
+	if parts == 1 {
+		return []string{rendered}, nil
+	}
+	assignment, _ := configPartition(otelcol.Spec.Config)
+
+	cfg, err := adapters.ConfigFromString(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("the config can't be split: %w", err)
+	}
+	partConfigs := make([]map[interface{}]interface{}, parts)
+	for i := range partConfigs {
+		partConfigs[i] = map[interface{}]interface{}{}
+	}
+	for _, fragment := range configFragments(cfg) {
+		part := partConfigs[assignment[fragment.key]]
+		if fragment.id == nil {
+			part[fragment.section] = fragment.value
+			continue
+		}
+		section, ok := part[fragment.section].(map[interface{}]interface{})
+		if !ok {
+			section = map[interface{}]interface{}{}
+			part[fragment.section] = section
+		}
+		section[fragment.id] = fragment.value
+	}
+
+	split := make([]string, 0, parts)
+	for i, partConfig := range partConfigs {
+		out, err := yaml.Marshal(partConfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > maxConfigPartSize {
+			return nil, fmt.Errorf("the part %d of the config is %d bytes, which is over the %d bytes a ConfigMap can hold, one of its components is too large", i, len(out), maxConfigPartSize)
+		}
+		split = append(split, string(out))
+	}
+	return split, nil
+}
+
+// MergeConfigParts merges the parts of a split config back, like the collector does with its --config flags.
+func MergeConfigParts(parts []string) (string, error) {
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	merged := map[interface{}]interface{}{}
+	for _, part := range parts {
+		cfg, err := adapters.ConfigFromString(part)
+		if err != nil {
+			return "", err
+		}
+		for key, value := range cfg {
+			existing, isMap := merged[key].(map[interface{}]interface{})
+			section, ok := value.(map[interface{}]interface{})
+			if !isMap || !ok {
+				merged[key] = value
+				continue
+			}
+			for id, component := range section {
+				existing[id] = component
+			}
+		}
+	}
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// configPartition assigns the fragments of the config to the parts, filling each part up to the threshold in the
+// order of the fragments. A config that can't be parsed isn't split.
+func configPartition(rendered string) (map[string]int, int) {
+	cfg, err := adapters.ConfigFromString(rendered)
+	if err != nil {
+		return map[string]int{}, 1
+	}
+
+	assignment := map[string]int{}
+	part, size := 0, 0
+	for _, fragment := range configFragments(cfg) {
+		entry := map[interface{}]interface{}{fragment.section: fragment.value}
+		if fragment.id != nil {
+			entry = map[interface{}]interface{}{fragment.section: map[interface{}]interface{}{fragment.id: fragment.value}}
+		}
+		out, err := yaml.Marshal(entry)
+		if err != nil {
+			return map[string]int{}, 1
+		}
+		if size > 0 && size+len(out) > configSplitThreshold {
+			part, size = part+1, 0
+		}
+		assignment[fragment.key] = part
+		size += len(out)
+	}
+	return assignment, part + 1
+}
+
+// configFragments returns the fragments of the config, ordered by their key.
+func configFragments(cfg map[interface{}]interface{}) []configFragment {
+	var fragments []configFragment
+	for section, value := range cfg {
+		components, ok := value.(map[interface{}]interface{})
+		if !ok || !splitSections[fmt.Sprint(section)] {
+			fragments = append(fragments, configFragment{key: fmt.Sprint(section), section: section, value: value})
+			continue
+		}
+		for id, component := range components {
+			fragments = append(fragments, configFragment{key: fmt.Sprintf("%v/%v", section, id), section: section, id: id, value: component})
+		}
+	}
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].key < fragments[j].key })
+	return fragments
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// largeConfig returns a config with the given number of exporters of about 1KiB each.
+func largeConfig(exporters int) string {
+	var b strings.Builder
+	b.WriteString("receivers:\n  otlp:\n    protocols:\n      grpc: {}\nexporters:\n")
+	var ids []string
+	for i := 0; i < exporters; i++ {
+		id := fmt.Sprintf("otlp/tenant-%04d", i)
+		ids = append(ids, id)
+		fmt.Fprintf(&b, "  %s:\n    endpoint: tenant-%04d:4317\n    headers:\n      x-tenant: %s\n", id, i, strings.Repeat("x", 1000))
+	}
+	fmt.Fprintf(&b, "service:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [%s]\n", strings.Join(ids, ", "))
+	return b.String()
+}
+
+func largeInstance(exporters int) v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Config: largeConfig(exporters),
+		},
+	}
+}
+
+func parseConfig(t *testing.T, cfg string) map[interface{}]interface{} {
+	parsed := map[interface{}]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(cfg), &parsed))
+	return parsed
+}
+
+func TestConfigParts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		otelcol  v1alpha1.OpenTelemetryCollector
+		expected int
+	}{
+		{
+			desc:     "small config",
+			otelcol:  largeInstance(10),
+			expected: 1,
+		},
+		{
+			desc:     "config over the threshold",
+			otelcol:  largeInstance(2000),
+			expected: 3,
+		},
+		{
+			desc: "sidecar",
+			otelcol: func() v1alpha1.OpenTelemetryCollector {
+				otelcol := largeInstance(2000)
+				otelcol.Spec.Mode = v1alpha1.ModeSidecar
+				return otelcol
+			}(),
+			expected: 1,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ConfigParts(tt.otelcol))
+		})
+	}
+}
+
+func TestSplitConfig(t *testing.T) {
+	t.Run("should keep a small config unchanged", func(t *testing.T) {
+		// prepare
+		otelcol := largeInstance(10)
+
+		// test
+		parts, err := SplitConfig(otelcol, otelcol.Spec.Config)
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{otelcol.Spec.Config}, parts)
+	})
+
+	t.Run("should split a large config into parts merging back to the config", func(t *testing.T) {
+		// prepare
+		otelcol := largeInstance(2000)
+
+		// test
+		parts, err := SplitConfig(otelcol, otelcol.Spec.Config)
+
+		// verify
+		require.NoError(t, err)
+		require.Len(t, parts, 3)
+		for _, part := range parts {
+			assert.Less(t, len(part), 1024*1024)
+		}
+		merged, err := MergeConfigParts(parts)
+		require.NoError(t, err)
+		assert.Equal(t, parseConfig(t, otelcol.Spec.Config), parseConfig(t, merged))
+	})
+
+	t.Run("should add the components of the rewritten config to the first part", func(t *testing.T) {
+		// prepare
+		otelcol := largeInstance(2000)
+		rendered := strings.Replace(otelcol.Spec.Config, "exporters:\n", "exporters:\n  logging: {}\n", 1)
+
+		// test
+		parts, err := SplitConfig(otelcol, rendered)
+
+		// verify
+		require.NoError(t, err)
+		require.Len(t, parts, 3)
+		assert.Contains(t, parseConfig(t, parts[0])["exporters"], "logging")
+		merged, err := MergeConfigParts(parts)
+		require.NoError(t, err)
+		assert.Equal(t, parseConfig(t, rendered), parseConfig(t, merged))
+	})
+
+	t.Run("should fail when a component doesn't fit in a ConfigMap", func(t *testing.T) {
+		// prepare
+		otelcol := largeInstance(2000)
+		rendered := strings.Replace(otelcol.Spec.Config, "exporters:\n", fmt.Sprintf("exporters:\n  logging:\n    verbosity: %s\n", strings.Repeat("x", 1024*1024)), 1)
+
+		// test
+		_, err := SplitConfig(otelcol, rendered)
+
+		// verify
+		assert.ErrorContains(t, err, "one of its components is too large")
+	})
+}
+
+func TestSplitConfigMounts(t *testing.T) {
+	// prepare
+	otelcol := largeInstance(2000)
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+	container := Container(cfg, logger, otelcol)
+	reloader := ConfigReloaderContainer(cfg, otelcol)
+
+	// verify
+	require.NotNil(t, volumes[0].Projected)
+	assert.Equal(t, naming.ConfigMapVolume(), volumes[0].Name)
+	require.Len(t, volumes[0].Projected.Sources, 3)
+	for i, source := range volumes[0].Projected.Sources {
+		assert.Equal(t, naming.ConfigMapPart("my-instance-collector", i), source.ConfigMap.Name)
+		assert.Equal(t, ConfigPartEntry(cfg, i), source.ConfigMap.Items[0].Path)
+	}
+	assert.Equal(t, "my-instance-collector-2", volumes[0].Projected.Sources[2].ConfigMap.Name)
+	assert.Equal(t, "collector-2.yaml", volumes[0].Projected.Sources[2].ConfigMap.Items[0].Key)
+
+	assert.Contains(t, container.Args, "--config=/conf/collector.yaml")
+	assert.Contains(t, container.Args, "--config=/conf/collector-1.yaml")
+	assert.Contains(t, container.Args, "--config=/conf/collector-2.yaml")
+
+	assert.Contains(t, reloader.Env, corev1.EnvVar{Name: "CONFIG_PARTS", Value: "/conf/collector-1.yaml /conf/collector-2.yaml"})
+}
+
+func TestCanaryDeploymentSplitConfig(t *testing.T) {
+	// prepare
+	otelcol := largeInstance(2000)
+
+	// test
+	deployment := CanaryDeployment(config.New(), logger, otelcol, "1")
+
+	// verify
+	volume := deployment.Spec.Template.Spec.Volumes[0]
+	require.NotNil(t, volume.Projected)
+	assert.Equal(t, "my-instance-collector-canary", volume.Projected.Sources[0].ConfigMap.Name)
+	assert.Equal(t, "my-instance-collector-canary-1", volume.Projected.Sources[1].ConfigMap.Name)
+}
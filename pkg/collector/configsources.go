@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// configSourceEnvVars returns the environment variables holding the Secret config sources of the instance. The
+// collector reads them with its env config provider, so that their values aren't written to the collector's ConfigMap.
+func configSourceEnvVars(otelcol v1alpha1.OpenTelemetryCollector) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for i, source := range otelcol.Spec.ConfigSources {
+		if source.SecretKeyRef == nil {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:      configSourceEnvVar(i),
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: source.SecretKeyRef.DeepCopy()},
+		})
+	}
+	return envVars
+}
+
+// configSourceArgs returns the config flags of the Secret config sources. They precede the flag of the collector's
+// ConfigMap, as the collector merges its configs in the order of the flags.
+func configSourceArgs(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	var args []string
+	for i, source := range otelcol.Spec.ConfigSources {
+		if source.SecretKeyRef != nil {
+			args = append(args, fmt.Sprintf("--config=env:%s", configSourceEnvVar(i)))
+		}
+	}
+	return args
+}
+
+func configSourceEnvVar(index int) string {
+	return fmt.Sprintf("OTEL_CONFIG_SOURCE_%d", index)
+}
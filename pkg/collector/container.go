@@ -17,8 +17,10 @@ package collector
 import (
 	"fmt"
 	"net"
+	"path"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/mitchellh/mapstructure"
@@ -35,12 +37,38 @@ import (
 // https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
 const maxPortLen = 15
 
+// VolumeClaimMountDir is the directory the volume claim templates are mounted in when the instance doesn't mount them.
+const VolumeClaimMountDir = "/var/lib/otelcol"
+
+// windowsConfigMountPath is the directory the config is mounted in for the collectors running on Windows nodes.
+const windowsConfigMountPath = `C:\conf`
+
+// Image returns the collector image of the instance, the image of its custom distribution when it's built by the
+// operator. Without an image in the spec, the pinned version replaces the tag of the default collector image.
+func Image(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	// in FIPS mode, the FIPS-validated image replaces the one of the instance
+	if image, ok := cfg.FIPSImage(config.FIPSImageCollector); ok && cfg.FIPS() {
+		return image
+	}
+	if otelcol.Spec.Build != nil {
+		return BuildImage(otelcol)
+	}
+	if len(otelcol.Spec.Image) > 0 {
+		return otelcol.Spec.Image
+	}
+	image := cfg.CollectorImage()
+	if otelcol.Spec.Version == "" {
+		return image
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return fmt.Sprintf("%s:%s", image, otelcol.Spec.Version)
+}
+
 // Container builds a container for the given collector.
 func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
-	image := otelcol.Spec.Image
-	if len(image) == 0 {
-		image = cfg.CollectorImage()
-	}
+	image := Image(cfg, otelcol)
 
 	// build container ports from service ports
 	ports := getConfigContainerPorts(logger, otelcol.Spec.Config)
@@ -63,20 +91,47 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 
 	// this effectively overrides any 'config' entry that might exist in the CR
 	argsMap["config"] = fmt.Sprintf("/conf/%s", cfg.CollectorConfigMapEntry())
+	if isWindows(otelcol) {
+		argsMap["config"] = fmt.Sprintf(`%s\%s`, windowsConfigMountPath, cfg.CollectorConfigMapEntry())
+	}
 
-	var args []string
+	args := configSourceArgs(otelcol)
 	for k, v := range argsMap {
 		args = append(args, fmt.Sprintf("--%s=%s", k, v))
 	}
+	// the collector merges the parts of a split config, in the order of the flags
+	for i := 1; i < ConfigParts(otelcol); i++ {
+		if isWindows(otelcol) {
+			args = append(args, fmt.Sprintf(`--config=%s\%s`, windowsConfigMountPath, ConfigPartEntry(cfg, i)))
+		} else {
+			args = append(args, fmt.Sprintf("--config=/conf/%s", ConfigPartEntry(cfg, i)))
+		}
+	}
 
 	volumeMounts := []corev1.VolumeMount{{
 		Name:      naming.ConfigMapVolume(),
 		MountPath: "/conf",
 	}}
+	if isWindows(otelcol) {
+		volumeMounts[0].MountPath = windowsConfigMountPath
+	}
 
 	if len(otelcol.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
+	volumeMounts = append(volumeMounts, volumeClaimMounts(otelcol)...)
+	if usesTargetAllocatorMTLS(otelcol) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.TATLSVolume(),
+			MountPath: TargetAllocatorTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+	tlsMounts, err := receiverTLSVolumeMounts(otelcol)
+	if err != nil {
+		logger.Error(err, "couldn't mount the receiver certificates")
+	}
+	volumeMounts = append(volumeMounts, tlsMounts...)
 
 	var envVars = otelcol.Spec.Env
 	if otelcol.Spec.Env == nil {
@@ -92,7 +147,13 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		},
 	})
 
-	if otelcol.Spec.TargetAllocator.Enabled {
+	envVars = append(envVars, configSourceEnvVars(otelcol)...)
+
+	if env, ok := goMemLimit(otelcol); ok {
+		envVars = append(envVars, env)
+	}
+
+	if otelcol.Spec.TargetAllocator.Enabled || otelcol.Spec.TargetAllocatorRef != "" {
 		// We need to add a SHARD here so the collector is able to keep targets after the hashmod operation which is
 		// added by default by the Prometheus operator's config generator.
 		// All collector instances use SHARD == 0 as they only receive targets
@@ -104,10 +165,30 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		})
 	}
 
-	var livenessProbe *corev1.Probe
+	var livenessProbe, readinessProbe, startupProbe *corev1.Probe
 	if config, err := adapters.ConfigFromString(otelcol.Spec.Config); err == nil {
-		if probe, err := adapters.ConfigToContainerProbe(config); err == nil {
-			livenessProbe = probe
+		var handler *corev1.Probe
+		if otelcol.Spec.LivenessProbe != nil && otelcol.Spec.LivenessProbe.Protocol == v1alpha1.ProbeProtocolGRPC {
+			if probe, err := adapters.ConfigToGRPCContainerProbe(config); err == nil {
+				handler = probe
+			}
+		}
+		if handler == nil {
+			if probe, err := adapters.ConfigToContainerProbe(config); err == nil {
+				handler = probe
+			}
+		}
+		if handler != nil {
+			livenessProbe = handler
+			if otelcol.Spec.LivenessProbe != nil {
+				livenessProbe = tunedProbe(handler, otelcol.Spec.LivenessProbe.ProbeSpec)
+			}
+			if otelcol.Spec.ReadinessProbe != nil {
+				readinessProbe = tunedProbe(handler, *otelcol.Spec.ReadinessProbe)
+			}
+			if otelcol.Spec.StartupProbe != nil {
+				startupProbe = tunedProbe(handler, *otelcol.Spec.StartupProbe)
+			}
 		}
 	}
 
@@ -121,9 +202,33 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 		Env:             envVars,
 		EnvFrom:         otelcol.Spec.EnvFrom,
 		Resources:       otelcol.Spec.Resources,
-		SecurityContext: otelcol.Spec.SecurityContext,
+		SecurityContext: ContainerSecurityContext(otelcol),
 		LivenessProbe:   livenessProbe,
+		ReadinessProbe:  readinessProbe,
+		StartupProbe:    startupProbe,
+		Lifecycle:       otelcol.Spec.Lifecycle,
+	}
+}
+
+// tunedProbe returns a copy of the probe with the settings of the spec.
+func tunedProbe(probe *corev1.Probe, spec v1alpha1.ProbeSpec) *corev1.Probe {
+	tuned := probe.DeepCopy()
+	if spec.InitialDelaySeconds != nil {
+		tuned.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		tuned.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.PeriodSeconds != nil {
+		tuned.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.SuccessThreshold != nil {
+		tuned.SuccessThreshold = *spec.SuccessThreshold
+	}
+	if spec.FailureThreshold != nil {
+		tuned.FailureThreshold = *spec.FailureThreshold
 	}
+	return tuned
 }
 
 func getConfigContainerPorts(logger logr.Logger, cfg string) map[string]corev1.ContainerPort {
@@ -215,3 +320,33 @@ func portMapToList(portMap map[string]corev1.ContainerPort) []corev1.ContainerPo
 	})
 	return ports
 }
+
+// volumeClaimMounts mounts the volume claim templates of the statefulset mode that aren't mounted by the instance
+// already, e.g. for the directory of the file_storage extension.
+func volumeClaimMounts(otelcol v1alpha1.OpenTelemetryCollector) []corev1.VolumeMount {
+	if otelcol.Spec.Mode != v1alpha1.ModeStatefulSet {
+		return nil
+	}
+
+	mounted := map[string]bool{}
+	for _, mount := range otelcol.Spec.VolumeMounts {
+		mounted[mount.Name] = true
+	}
+
+	var volumeMounts []corev1.VolumeMount
+	for _, claim := range otelcol.Spec.VolumeClaimTemplates {
+		if mounted[claim.Name] || mounted[naming.VolumeClaim(claim.Name)] {
+			continue
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.VolumeClaim(claim.Name),
+			MountPath: path.Join(VolumeClaimMountDir, claim.Name),
+		})
+	}
+	return volumeMounts
+}
+
+// isWindows returns whether the collector runs on Windows nodes, i.e. its node selector requires the Windows OS.
+func isWindows(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.NodeSelector[corev1.LabelOSStable] == string(corev1.Windows)
+}
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -64,6 +66,88 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerWithFIPSImage(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Image: "overridden-image",
+		},
+	}
+	images := map[string]string{config.FIPSImageCollector: "fips-image"}
+
+	// test
+	fips := Container(config.New(config.WithFIPS(true), config.WithFIPSImages(images)), logger, otelcol)
+	nonFIPS := Container(config.New(config.WithFIPSImages(images)), logger, otelcol)
+	missing := Container(config.New(config.WithFIPS(true)), logger, otelcol)
+
+	// verify
+	assert.Equal(t, "fips-image", fips.Image)
+	assert.Equal(t, "overridden-image", nonFIPS.Image)
+	assert.Equal(t, "overridden-image", missing.Image)
+}
+
+func TestContainerWithBuild(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Image: "overridden-image",
+			Build: &v1alpha1.BuildSpec{
+				Manifest: "receivers:\n  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.74.0\n",
+				Image:    "registry.example.com/otelcol",
+			},
+		},
+	}
+
+	// test
+	c := Container(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, BuildImage(otelcol), c.Image)
+}
+
+func TestContainerWithPinnedVersion(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		image        string
+		defaultImage string
+		expected     string
+	}{
+		{
+			desc:         "replaces the tag of the default image",
+			defaultImage: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.80.0",
+			expected:     "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.61.0",
+		},
+		{
+			desc:         "adds a tag to the default image",
+			defaultImage: "localhost:5000/otelcol",
+			expected:     "localhost:5000/otelcol:0.61.0",
+		},
+		{
+			desc:         "keeps the image of the spec",
+			image:        "overridden-image",
+			defaultImage: "default-image:0.80.0",
+			expected:     "overridden-image",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Image:   tt.image,
+					Version: "0.61.0",
+				},
+			}
+			cfg := config.New(config.WithCollectorImage(tt.defaultImage))
+
+			// test
+			c := Container(cfg, logger, otelcol)
+
+			// verify
+			assert.Equal(t, tt.expected, c.Image)
+		})
+	}
+}
+
 func TestContainerPorts(t *testing.T) {
 	var goodConfig = `receivers:
   examplereceiver:
@@ -239,6 +323,68 @@ func TestContainerCustomVolumes(t *testing.T) {
 	assert.Equal(t, "custom-volume-mount", c.VolumeMounts[1].Name)
 }
 
+func TestContainerVolumeClaimMounts(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeStatefulSet,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "file_storage"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "custom-mounted"}},
+			},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "custom-mounted",
+				MountPath: "/data",
+			}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, c.VolumeMounts, 3)
+	assert.Equal(t, corev1.VolumeMount{Name: "custom-mounted", MountPath: "/data"}, c.VolumeMounts[1])
+	assert.Equal(t, corev1.VolumeMount{Name: "file-storage", MountPath: "/var/lib/otelcol/file_storage"}, c.VolumeMounts[2])
+}
+
+func TestContainerVolumeClaimMountsIgnoredOutsideStatefulSet(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDeployment,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "file_storage"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, c.VolumeMounts, 1)
+}
+
+func TestContainerWindows(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, `C:\conf`, c.VolumeMounts[0].MountPath)
+	assert.Contains(t, c.Args, `--config=C:\conf\collector.yaml`)
+}
+
 func TestContainerCustomSecurityContext(t *testing.T) {
 	// default config without security context
 	c1 := Container(config.New(), logger, v1alpha1.OpenTelemetryCollector{Spec: v1alpha1.OpenTelemetryCollectorSpec{}})
@@ -366,6 +512,41 @@ func TestContainerArgs(t *testing.T) {
 	assert.Contains(t, c.Args, "--log-level=debug")
 }
 
+func TestContainerSecretConfigSources(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ConfigSources: []v1alpha1.ConfigSource{
+				{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "shared-receivers"},
+						Key:                  "receivers.yaml",
+					},
+				},
+				{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "shared-exporters"},
+						Key:                  "exporters.yaml",
+					},
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, "--config=env:OTEL_CONFIG_SOURCE_1", c.Args[0])
+	assert.Contains(t, c.Args, "--config=/conf/collector.yaml")
+	assert.Contains(t, c.Env, corev1.EnvVar{
+		Name:      "OTEL_CONFIG_SOURCE_1",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: otelcol.Spec.ConfigSources[1].SecretKeyRef},
+	})
+	assert.NotContains(t, c.Args, "--config=env:OTEL_CONFIG_SOURCE_0")
+}
+
 func TestContainerImagePullPolicy(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -436,3 +617,109 @@ service:
 	assert.Equal(t, int32(13133), c.LivenessProbe.HTTPGet.Port.IntVal)
 	assert.Equal(t, "", c.LivenessProbe.HTTPGet.Host)
 }
+
+func TestContainerProbesTuned(t *testing.T) {
+	// prepare
+	initialDelay := int32(60)
+	failureThreshold := int32(10)
+	successThreshold := int32(2)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `extensions:
+  health_check:
+    endpoint: 0.0.0.0:8080
+    path: /health
+service:
+  extensions: [health_check]`,
+			LivenessProbe: &v1alpha1.LivenessProbeSpec{
+				Protocol:  v1alpha1.ProbeProtocolHTTP,
+				ProbeSpec: v1alpha1.ProbeSpec{InitialDelaySeconds: &initialDelay},
+			},
+			ReadinessProbe: &v1alpha1.ProbeSpec{SuccessThreshold: &successThreshold},
+			StartupProbe:   &v1alpha1.ProbeSpec{FailureThreshold: &failureThreshold},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+		require.NotNil(t, probe)
+		assert.Equal(t, "/health", probe.HTTPGet.Path)
+		assert.Equal(t, int32(8080), probe.HTTPGet.Port.IntVal)
+	}
+	assert.Equal(t, int32(60), c.LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(0), c.ReadinessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(2), c.ReadinessProbe.SuccessThreshold)
+	assert.Equal(t, int32(10), c.StartupProbe.FailureThreshold)
+	assert.Equal(t, int32(0), c.LivenessProbe.FailureThreshold)
+}
+
+func TestContainerWithoutReadinessAndStartupProbes(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `extensions:
+  health_check:
+service:
+  extensions: [health_check]`,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.NotNil(t, c.LivenessProbe)
+	assert.Nil(t, c.ReadinessProbe)
+	assert.Nil(t, c.StartupProbe)
+}
+
+func TestContainerGRPCProbe(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]`,
+			LivenessProbe: &v1alpha1.LivenessProbeSpec{Protocol: v1alpha1.ProbeProtocolGRPC},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Nil(t, c.LivenessProbe.HTTPGet)
+	assert.Equal(t, int32(4317), c.LivenessProbe.GRPC.Port)
+}
+
+func TestContainerGRPCProbeFallsBackToHTTP(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `extensions:
+  health_check:
+service:
+  extensions: [health_check]`,
+			LivenessProbe: &v1alpha1.LivenessProbeSpec{Protocol: v1alpha1.ProbeProtocolGRPC},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Nil(t, c.LivenessProbe.GRPC)
+	assert.Equal(t, int32(13133), c.LivenessProbe.HTTPGet.Port.IntVal)
+}
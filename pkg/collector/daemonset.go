@@ -30,7 +30,7 @@ func DaemonSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	labels := Labels(otelcol, cfg.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
 
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, cfg.AnnotationsFilter())
 	podAnnotations := PodAnnotations(otelcol)
 	return appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -43,24 +43,32 @@ func DaemonSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabels(otelcol),
 			},
-			Template: corev1.PodTemplateSpec{
+			UpdateStrategy: otelcol.Spec.DaemonSetUpdateStrategy,
+			Template: PodTemplateOverrides(logger, otelcol, corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol)},
-					Volumes:            Volumes(cfg, otelcol),
-					Tolerations:        otelcol.Spec.Tolerations,
-					NodeSelector:       otelcol.Spec.NodeSelector,
-					HostNetwork:        otelcol.Spec.HostNetwork,
-					DNSPolicy:          getDNSPolicy(otelcol),
-					SecurityContext:    otelcol.Spec.PodSecurityContext,
-					PriorityClassName:  otelcol.Spec.PriorityClassName,
-					Affinity:           otelcol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(otelcol),
+					InitContainers:                otelcol.Spec.InitContainers,
+					Containers:                    podContainers(cfg, otelcol, Container(cfg, logger, otelcol)),
+					ShareProcessNamespace:         shareProcessNamespace(otelcol),
+					Volumes:                       Volumes(cfg, otelcol),
+					Tolerations:                   otelcol.Spec.Tolerations,
+					NodeSelector:                  otelcol.Spec.NodeSelector,
+					HostNetwork:                   otelcol.Spec.HostNetwork,
+					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.DNSConfig,
+					SecurityContext:               PodSecurityContext(otelcol),
+					PriorityClassName:             otelcol.Spec.PriorityClassName,
+					Affinity:                      Affinity(otelcol),
+					TopologySpreadConstraints:     otelcol.Spec.TopologySpreadConstraints,
+					RuntimeClassName:              otelcol.Spec.RuntimeClassName,
+					Overhead:                      otelcol.Spec.Overhead,
+					TerminationGracePeriodSeconds: TerminationGracePeriodSeconds(logger, otelcol),
 				},
-			},
+			}),
 		},
 	}
 }
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -264,3 +266,137 @@ func TestDaemonSetAffinity(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.Affinity)
 	assert.Equal(t, *testAffinityValue, *d2.Spec.Template.Spec.Affinity)
 }
+
+func TestDaemonSetRuntimeClassAndOverhead(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := DaemonSet(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.RuntimeClassName)
+	assert.Nil(t, d1.Spec.Template.Spec.Overhead)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-kata",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			RuntimeClassName: &testRuntimeClassName,
+			Overhead:         testOverheadValue,
+		},
+	}
+
+	d2 := DaemonSet(cfg, logger, otelcol2)
+	assert.Equal(t, &testRuntimeClassName, d2.Spec.Template.Spec.RuntimeClassName)
+	assert.Equal(t, testOverheadValue, d2.Spec.Template.Spec.Overhead)
+}
+
+func TestDaemonSetTopologySpreadConstraints(t *testing.T) {
+	// prepare
+	constraints := []v1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       v1.LabelTopologyZone,
+		WhenUnsatisfiable: v1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/instance": "default.my-instance"},
+		},
+	}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TopologySpreadConstraints: constraints,
+		},
+	}
+
+	// test
+	d := DaemonSet(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, constraints, d.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestDaemonSetUpdateStrategy(t *testing.T) {
+	// prepare
+	maxSurge := intstr.FromString("25%")
+	maxUnavailable := intstr.FromInt(0)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			DaemonSetUpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := DaemonSet(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, otelcol.Spec.DaemonSetUpdateStrategy, d.Spec.UpdateStrategy)
+}
+
+func TestDaemonSetLifecycleAndTerminationGracePeriod(t *testing.T) {
+	// prepare
+	gracePeriod := int64(60)
+	lifecycle := &v1.Lifecycle{
+		PreStop: &v1.LifecycleHandler{
+			Exec: &v1.ExecAction{Command: []string{"/drain"}},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Lifecycle:                     lifecycle,
+			TerminationGracePeriodSeconds: &gracePeriod,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := DaemonSet(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, &gracePeriod, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, lifecycle, d.Spec.Template.Spec.Containers[0].Lifecycle)
+}
+
+func TestDaemonSetDNSPolicyAndConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			HostNetwork: true,
+			DNSPolicy:   v1.DNSNone,
+			DNSConfig: &v1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"svc.cluster.local"},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := DaemonSet(cfg, logger, otelcol)
+
+	// verify
+	assert.True(t, d.Spec.Template.Spec.HostNetwork)
+	assert.Equal(t, v1.DNSNone, d.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, otelcol.Spec.DNSConfig, d.Spec.Template.Spec.DNSConfig)
+}
@@ -30,9 +30,15 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 	labels := Labels(otelcol, cfg.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
 
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, cfg.AnnotationsFilter())
 	podAnnotations := PodAnnotations(otelcol)
 
+	container := Container(cfg, logger, otelcol)
+	gracePeriod := TerminationGracePeriodSeconds(logger, otelcol)
+	if otelcol.Spec.ShutdownTimeout != "" && otelcol.Spec.ShutdownPreStopSleep && container.Lifecycle == nil {
+		container.Lifecycle = PreStopLifecycle()
+	}
+
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Collector(otelcol),
@@ -41,28 +47,37 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: otelcol.Spec.Replicas,
+			Replicas:             otelcol.Spec.Replicas,
+			RevisionHistoryLimit: otelcol.Spec.RevisionHistoryLimit,
+			Strategy:             otelcol.Spec.DeploymentUpdateStrategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabels(otelcol),
 			},
-			Template: corev1.PodTemplateSpec{
+			Template: PodTemplateOverrides(logger, otelcol, corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol)},
-					Volumes:            Volumes(cfg, otelcol),
-					DNSPolicy:          getDNSPolicy(otelcol),
-					HostNetwork:        otelcol.Spec.HostNetwork,
-					Tolerations:        otelcol.Spec.Tolerations,
-					NodeSelector:       otelcol.Spec.NodeSelector,
-					SecurityContext:    otelcol.Spec.PodSecurityContext,
-					PriorityClassName:  otelcol.Spec.PriorityClassName,
-					Affinity:           otelcol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(otelcol),
+					InitContainers:                otelcol.Spec.InitContainers,
+					Containers:                    podContainers(cfg, otelcol, container),
+					ShareProcessNamespace:         shareProcessNamespace(otelcol),
+					Volumes:                       Volumes(cfg, otelcol),
+					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.DNSConfig,
+					HostNetwork:                   otelcol.Spec.HostNetwork,
+					Tolerations:                   otelcol.Spec.Tolerations,
+					NodeSelector:                  otelcol.Spec.NodeSelector,
+					SecurityContext:               PodSecurityContext(otelcol),
+					PriorityClassName:             otelcol.Spec.PriorityClassName,
+					Affinity:                      Affinity(otelcol),
+					TopologySpreadConstraints:     otelcol.Spec.TopologySpreadConstraints,
+					RuntimeClassName:              otelcol.Spec.RuntimeClassName,
+					Overhead:                      otelcol.Spec.Overhead,
+					TerminationGracePeriodSeconds: gracePeriod,
 				},
-			},
+			}),
 		},
 	}
 }
@@ -18,7 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -52,6 +55,13 @@ var testAffinityValue = &v1.Affinity{
 	},
 }
 
+var testRuntimeClassName = "kata"
+
+var testOverheadValue = v1.ResourceList{
+	v1.ResourceCPU:    resource.MustParse("250m"),
+	v1.ResourceMemory: resource.MustParse("120Mi"),
+}
+
 func TestDeploymentNewDefault(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -304,3 +314,252 @@ func TestDeploymentAffinity(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.Affinity)
 	assert.Equal(t, *testAffinityValue, *d2.Spec.Template.Spec.Affinity)
 }
+
+func TestDeploymentShutdownTimeout(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := Deployment(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Nil(t, d1.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-shutdown-timeout",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ShutdownTimeout: "30s",
+		},
+	}
+
+	d2 := Deployment(cfg, logger, otelcol2)
+	require.NotNil(t, d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, int64(40), *d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Nil(t, d2.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	otelcol3 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-shutdown-prestop-sleep",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ShutdownTimeout:      "30s",
+			ShutdownPreStopSleep: true,
+		},
+	}
+
+	d3 := Deployment(cfg, logger, otelcol3)
+	require.NotNil(t, d3.Spec.Template.Spec.Containers[0].Lifecycle)
+	assert.Equal(t, []string{"sleep", "5"}, d3.Spec.Template.Spec.Containers[0].Lifecycle.PreStop.Exec.Command)
+}
+
+func TestDeploymentLifecycleAndTerminationGracePeriod(t *testing.T) {
+	gracePeriod := int64(120)
+	lifecycle := &v1.Lifecycle{
+		PreStop: &v1.LifecycleHandler{
+			HTTPGet: &v1.HTTPGetAction{Path: "/drain"},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ShutdownTimeout:               "30s",
+			Lifecycle:                     lifecycle,
+			TerminationGracePeriodSeconds: &gracePeriod,
+		},
+	}
+
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+	assert.Equal(t, &gracePeriod, d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, lifecycle, d.Spec.Template.Spec.Containers[0].Lifecycle)
+}
+
+func TestDeploymentRuntimeClassAndOverhead(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := Deployment(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.RuntimeClassName)
+	assert.Nil(t, d1.Spec.Template.Spec.Overhead)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-kata",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			RuntimeClassName: &testRuntimeClassName,
+			Overhead:         testOverheadValue,
+		},
+	}
+
+	d2 := Deployment(cfg, logger, otelcol2)
+	assert.Equal(t, &testRuntimeClassName, d2.Spec.Template.Spec.RuntimeClassName)
+	assert.Equal(t, testOverheadValue, d2.Spec.Template.Spec.Overhead)
+}
+
+func TestDeploymentRevisionHistoryLimit(t *testing.T) {
+	one := int32(1)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			RevisionHistoryLimit: &one,
+		},
+	}
+
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+	assert.Equal(t, &one, d.Spec.RevisionHistoryLimit)
+}
+
+func TestDeploymentAntiAffinityTarget(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			AntiAffinityTarget: &v1alpha1.AntiAffinityTargetSpec{
+				PodLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "latency-sensitive"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+
+	assert.NotNil(t, d.Spec.Template.Spec.Affinity)
+	assert.Len(t, d.Spec.Template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+}
+
+func TestDeploymentTopologySpreadConstraints(t *testing.T) {
+	// prepare
+	constraints := []v1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       v1.LabelTopologyZone,
+		WhenUnsatisfiable: v1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/instance": "default.my-instance"},
+		},
+	}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TopologySpreadConstraints: constraints,
+		},
+	}
+
+	// test
+	d := Deployment(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, constraints, d.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestDeploymentConfigReload(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:               "receivers: {}",
+			ConfigReloadStrategy: v1alpha1.ConfigReloadStrategyReload,
+		},
+	}
+	cfg := config.New(config.WithConfigReloaderImage("busybox:1.36"))
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	require.Len(t, d.Spec.Template.Spec.Containers, 2)
+	reloader := d.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "otc-config-reloader", reloader.Name)
+	assert.Equal(t, "busybox:1.36", reloader.Image)
+	assert.Equal(t, []v1.EnvVar{{Name: "CONFIG_FILE", Value: "/conf/collector.yaml"}}, reloader.Env)
+
+	// in FIPS mode, the reloader runs its FIPS-validated image
+	fipsCfg := config.New(
+		config.WithConfigReloaderImage("busybox:1.36"),
+		config.WithFIPS(true),
+		config.WithFIPSImages(map[string]string{config.FIPSImageConfigReloader: "reloader-fips"}),
+	)
+	assert.Equal(t, "reloader-fips", Deployment(fipsCfg, logger, otelcol).Spec.Template.Spec.Containers[1].Image)
+	require.NotNil(t, d.Spec.Template.Spec.ShareProcessNamespace)
+	assert.True(t, *d.Spec.Template.Spec.ShareProcessNamespace)
+	assert.NotContains(t, d.Spec.Template.Annotations, "opentelemetry-operator-config/sha256")
+	assert.Contains(t, d.Annotations, "opentelemetry-operator-config/sha256")
+
+	// the default strategy rolls out the pods
+	otelcol.Spec.ConfigReloadStrategy = v1alpha1.ConfigReloadStrategyRestart
+	d = Deployment(cfg, logger, otelcol)
+	assert.Len(t, d.Spec.Template.Spec.Containers, 1)
+	assert.Nil(t, d.Spec.Template.Spec.ShareProcessNamespace)
+
+	// the process namespace can be shared without the reloader
+	share := true
+	otelcol.Spec.ShareProcessNamespace = &share
+	d = Deployment(cfg, logger, otelcol)
+	assert.Equal(t, &share, d.Spec.Template.Spec.ShareProcessNamespace)
+}
+
+func TestDeploymentInitAndAdditionalContainers(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:               "receivers: {}",
+			ConfigReloadStrategy: v1alpha1.ConfigReloadStrategyReload,
+			InitContainers:       []v1.Container{{Name: "geoip", Image: "geoipupdate"}},
+			AdditionalContainers: []v1.Container{{Name: "proxy", Image: "envoy"}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, otelcol.Spec.InitContainers, d.Spec.Template.Spec.InitContainers)
+	require.Len(t, d.Spec.Template.Spec.Containers, 3)
+	assert.Equal(t, "otc-container", d.Spec.Template.Spec.Containers[0].Name)
+	assert.Equal(t, "otc-config-reloader", d.Spec.Template.Spec.Containers[1].Name)
+	assert.Equal(t, "proxy", d.Spec.Template.Spec.Containers[2].Name)
+}
+
+func TestDeploymentUpdateStrategy(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			DeploymentUpdateStrategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+
+	cfg := config.New()
+
+	d := Deployment(cfg, logger, otelcol)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, d.Spec.Strategy.Type)
+}
@@ -171,6 +171,18 @@ func (m *mockAutoDetect) HPAVersion() (autodetect.AutoscalingVersion, error) {
 	return m.HPAVersionFunc()
 }
 
+func (m *mockAutoDetect) PodDisruptionBudgets() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) DualStack() (bool, error) {
+	return true, nil
+}
+
+func (m *mockAutoDetect) GRPCProbes() (bool, error) {
+	return true, nil
+}
+
 func (m *mockAutoDetect) Platform() (platform.Platform, error) {
 	if m.PlatformFunc != nil {
 		return m.PlatformFunc()
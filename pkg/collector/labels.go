@@ -22,10 +22,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
+// TargetAllocatorLabel is set to the name of the TargetAllocator referenced by the instance on its collector pods,
+// which the TargetAllocator allocates the targets to.
+const TargetAllocatorLabel = "opentelemetry.io/target-allocator"
+
 func isFilteredLabel(label string, filterLabels []string) bool {
 	for _, pattern := range filterLabels {
-		match, _ := regexp.MatchString(pattern, label)
-		return match
+		if match, _ := regexp.MatchString(pattern, label); match {
+			return true
+		}
 	}
 
 	return false
@@ -43,6 +48,10 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, filterLabels []string) map
 		}
 	}
 
+	for k, v := range instance.Spec.AdditionalLabels {
+		base[k] = v
+	}
+
 	for k, v := range SelectorLabels(instance) {
 		base[k] = v
 	}
@@ -54,6 +63,10 @@ func Labels(instance v1alpha1.OpenTelemetryCollector, filterLabels []string) map
 		base["app.kubernetes.io/version"] = "latest"
 	}
 
+	if instance.Spec.TargetAllocatorRef != "" {
+		base[TargetAllocatorLabel] = instance.Spec.TargetAllocatorRef
+	}
+
 	return base
 }
 
@@ -82,6 +82,26 @@ func TestLabelsPropagateDown(t *testing.T) {
 	assert.Equal(t, "mycomponent", labels["myapp"])
 }
 
+func TestLabelsTargetAllocatorRef(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocatorRef: "shared",
+		},
+	}
+
+	// test
+	labels := Labels(otelcol, []string{})
+
+	// verify
+	assert.Equal(t, "shared", labels[TargetAllocatorLabel])
+	assert.NotContains(t, SelectorLabels(otelcol), TargetAllocatorLabel)
+}
+
 func TestLabelsFilter(t *testing.T) {
 	otelcol := v1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
@@ -98,6 +118,45 @@ func TestLabelsFilter(t *testing.T) {
 	assert.Equal(t, "bar", labels["test.foo.io"])
 }
 
+func TestLabelsFilterPatterns(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"test.bar.io": "foo", "test.foo.io": "bar", "team": "otel"},
+		},
+	}
+
+	// test
+	labels := Labels(otelcol, []string{".*.bar.io", ".*.foo.io"})
+
+	// verify
+	assert.NotContains(t, labels, "test.bar.io")
+	assert.NotContains(t, labels, "test.foo.io")
+	assert.Equal(t, "otel", labels["team"])
+}
+
+func TestAdditionalLabels(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "my-instance",
+			Labels: map[string]string{"team": "instance"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			AdditionalLabels: map[string]string{
+				"team":                         "additional",
+				"app.kubernetes.io/managed-by": "helm",
+			},
+		},
+	}
+
+	// test
+	labels := Labels(otelcol, []string{})
+
+	// verify
+	assert.Equal(t, "additional", labels["team"])
+	assert.Equal(t, "opentelemetry-operator", labels["app.kubernetes.io/managed-by"])
+	assert.NotContains(t, SelectorLabels(otelcol), "team")
+}
+
 func TestSelectorLabels(t *testing.T) {
 	// prepare
 	expected := map[string]string{
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+const (
+	goMemLimitEnvVar = "GOMEMLIMIT"
+
+	mebibyte = 1024 * 1024
+)
+
+// memoryLimit returns the memory limit of the collector container in bytes, or 0 when it has none.
+func memoryLimit(otelcol v1alpha1.OpenTelemetryCollector) int64 {
+	limit, ok := otelcol.Spec.Resources.Limits[corev1.ResourceMemory]
+	if !ok {
+		return 0
+	}
+	return limit.Value()
+}
+
+// goMemLimit returns the GOMEMLIMIT environment variable of the collector container, which makes the Go runtime
+// collect the garbage more aggressively before the container is OOMKilled. It's only set when the instance opts in
+// with spec.memoryTuning.goMemLimitPercentage, as it restarts the collector pods.
+func goMemLimit(otelcol v1alpha1.OpenTelemetryCollector) (corev1.EnvVar, bool) {
+	limit := memoryLimit(otelcol)
+	if limit == 0 || otelcol.Spec.MemoryTuning.GoMemLimitPercentage == nil {
+		return corev1.EnvVar{}, false
+	}
+	percentage := int64(*otelcol.Spec.MemoryTuning.GoMemLimitPercentage)
+	for _, env := range otelcol.Spec.Env {
+		if env.Name == goMemLimitEnvVar {
+			return corev1.EnvVar{}, false
+		}
+	}
+	return corev1.EnvVar{Name: goMemLimitEnvVar, Value: fmt.Sprintf("%dMiB", limit*percentage/100/mebibyte)}, true
+}
+
+// MemoryLimiterConfig sets the limit_mib and spike_limit_mib of the memory_limiter processors of the config from
+// the memory limit of the collector container, as percentages of spec.memoryTuning. The config is returned unchanged
+// without memory limit, memory_limiter processor, or percentages.
+func MemoryLimiterConfig(otelcol v1alpha1.OpenTelemetryCollector, config string) (string, error) {
+	tuning := otelcol.Spec.MemoryTuning
+	limit := memoryLimit(otelcol)
+	if tuning.MemoryLimiterPercentage == nil || limit == 0 || !strings.Contains(config, "memory_limiter") {
+		return config, nil
+	}
+
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return "", err
+	}
+	processors, ok := cfg["processors"].(map[interface{}]interface{})
+	if !ok {
+		return config, nil
+	}
+
+	tuned := false
+	for id, settings := range processors {
+		name, ok := id.(string)
+		if !ok || (name != "memory_limiter" && !strings.HasPrefix(name, "memory_limiter/")) {
+			continue
+		}
+		processor, ok := settings.(map[interface{}]interface{})
+		if !ok {
+			processor = map[interface{}]interface{}{}
+		}
+		// the absolute limits replace the relative ones
+		delete(processor, "limit_percentage")
+		delete(processor, "spike_limit_percentage")
+		processor["limit_mib"] = limit * int64(*tuning.MemoryLimiterPercentage) / 100 / mebibyte
+		if tuning.MemoryLimiterSpikePercentage != nil {
+			processor["spike_limit_mib"] = limit * int64(*tuning.MemoryLimiterSpikePercentage) / 100 / mebibyte
+		}
+		processors[id] = processor
+		tuned = true
+	}
+	if !tuned {
+		return config, nil
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+func TestContainerGoMemLimit(t *testing.T) {
+	eighty := int32(80)
+	fifty := int32(50)
+	limits := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+	tests := []struct {
+		name       string
+		limits     corev1.ResourceList
+		percentage *int32
+		env        []corev1.EnvVar
+		expected   string
+	}{
+		{name: "no memory limit"},
+		{name: "no memory limit", percentage: &eighty},
+		{name: "not opted in", limits: limits},
+		{name: "percentage", limits: limits, percentage: &eighty, expected: "819MiB"},
+		{name: "custom percentage", limits: limits, percentage: &fifty, expected: "512MiB"},
+		{name: "set by env", limits: limits, percentage: &eighty, env: []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "900MiB"}}, expected: "900MiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Resources:    corev1.ResourceRequirements{Limits: tt.limits},
+					MemoryTuning: v1alpha1.MemoryTuningSpec{GoMemLimitPercentage: tt.percentage},
+					Env:          tt.env,
+				},
+			}
+
+			// test
+			c := Container(config.New(), logger, otelcol)
+
+			// verify
+			var values []string
+			for _, env := range c.Env {
+				if env.Name == "GOMEMLIMIT" {
+					values = append(values, env.Value)
+				}
+			}
+			if tt.expected == "" {
+				assert.Empty(t, values)
+				return
+			}
+			assert.Equal(t, []string{tt.expected}, values)
+		})
+	}
+}
+
+func TestMemoryLimiterConfig(t *testing.T) {
+	eighty := int32(80)
+	twenty := int32(20)
+	config := `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+  memory_limiter:
+    check_interval: 1s
+    limit_percentage: 75
+    spike_limit_percentage: 15
+  memory_limiter/gateway:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp]
+      processors: [memory_limiter/gateway]
+      exporters: [logging]
+`
+
+	t.Run("should set the limits from the memory limit", func(t *testing.T) {
+		// prepare
+		otelcol := v1alpha1.OpenTelemetryCollector{
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Resources:    corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}},
+				MemoryTuning: v1alpha1.MemoryTuningSpec{MemoryLimiterPercentage: &eighty, MemoryLimiterSpikePercentage: &twenty},
+			},
+		}
+
+		// test
+		actual, err := MemoryLimiterConfig(otelcol, config)
+
+		// verify
+		require.NoError(t, err)
+		cfg, err := adapters.ConfigFromString(actual)
+		require.NoError(t, err)
+		processors := cfg["processors"].(map[interface{}]interface{})
+		assert.Equal(t, map[interface{}]interface{}{"check_interval": "1s", "limit_mib": 1638, "spike_limit_mib": 409}, processors["memory_limiter"])
+		assert.Equal(t, map[interface{}]interface{}{"limit_mib": 1638, "spike_limit_mib": 409}, processors["memory_limiter/gateway"])
+		assert.Nil(t, processors["batch"])
+	})
+
+	t.Run("should keep the config without memory limit", func(t *testing.T) {
+		// prepare
+		otelcol := v1alpha1.OpenTelemetryCollector{
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				MemoryTuning: v1alpha1.MemoryTuningSpec{MemoryLimiterPercentage: &eighty},
+			},
+		}
+
+		// test
+		actual, err := MemoryLimiterConfig(otelcol, config)
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, config, actual)
+	})
+
+	t.Run("should keep the config without memory limiter percentage", func(t *testing.T) {
+		// prepare
+		otelcol := v1alpha1.OpenTelemetryCollector{
+			Spec: v1alpha1.OpenTelemetryCollectorSpec{
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}},
+			},
+		}
+
+		// test
+		actual, err := MemoryLimiterConfig(otelcol, config)
+
+		// verify
+		require.NoError(t, err)
+		assert.Equal(t, config, actual)
+	})
+}
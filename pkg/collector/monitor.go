@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+var (
+	// ServiceMonitorGVK is the kind of the Prometheus operator ServiceMonitors. The Prometheus operator API isn't a
+	// dependency of the operator, the monitors are handled as unstructured objects.
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+	// PodMonitorGVK is the kind of the Prometheus operator PodMonitors.
+	PodMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+)
+
+// sidecarInjectedLabel is set by the sidecar injection on the pods running a sidecar of an instance.
+const sidecarInjectedLabel = "sidecar.opentelemetry.io/injected"
+
+type serviceMonitorSpec struct {
+	Selector  metav1.LabelSelector `json:"selector"`
+	Endpoints []monitorEndpoint    `json:"endpoints"`
+}
+
+type podMonitorSpec struct {
+	Selector            metav1.LabelSelector `json:"selector"`
+	PodMetricsEndpoints []monitorEndpoint    `json:"podMetricsEndpoints"`
+}
+
+type monitorEndpoint struct {
+	Port string `json:"port"`
+}
+
+// UsesPodMonitor returns whether the metrics of the instance are scraped from its pods, as the daemonset and sidecar
+// modes don't have a Service per collector pod.
+func UsesPodMonitor(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Mode == v1alpha1.ModeDaemonSet || otelcol.Spec.Mode == v1alpha1.ModeSidecar
+}
+
+// Monitor returns the ServiceMonitor or PodMonitor scraping the internal metrics of the collector.
+func Monitor(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) (*unstructured.Unstructured, error) {
+	// the labels of the spec can't override the ones the operator uses to find the monitors of the instance
+	labels := map[string]string{}
+	for k, v := range otelcol.Spec.Observability.Metrics.Labels {
+		labels[k] = v
+	}
+	for k, v := range Labels(otelcol, cfg.LabelsFilter()) {
+		labels[k] = v
+	}
+
+	var spec interface{}
+	monitor := &unstructured.Unstructured{}
+	if UsesPodMonitor(otelcol) {
+		selector := SelectorLabels(otelcol)
+		if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+			selector = map[string]string{sidecarInjectedLabel: fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name)}
+		}
+		spec = &podMonitorSpec{
+			Selector:            metav1.LabelSelector{MatchLabels: selector},
+			PodMetricsEndpoints: []monitorEndpoint{{Port: "metrics"}},
+		}
+		labels["app.kubernetes.io/name"] = naming.PodMonitor(otelcol)
+		monitor.SetGroupVersionKind(PodMonitorGVK)
+		monitor.SetName(naming.PodMonitor(otelcol))
+	} else {
+		selector := SelectorLabels(otelcol)
+		selector["app.kubernetes.io/name"] = naming.MonitoringService(otelcol)
+		spec = &serviceMonitorSpec{
+			Selector:  metav1.LabelSelector{MatchLabels: selector},
+			Endpoints: []monitorEndpoint{{Port: "monitoring"}},
+		}
+		labels["app.kubernetes.io/name"] = naming.ServiceMonitor(otelcol)
+		monitor.SetGroupVersionKind(ServiceMonitorGVK)
+		monitor.SetName(naming.ServiceMonitor(otelcol))
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the %s spec: %w", monitor.GetKind(), err)
+	}
+	monitor.SetNamespace(otelcol.Namespace)
+	monitor.SetLabels(labels)
+	monitor.Object["spec"] = content
+	return monitor, nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestMonitor(t *testing.T) {
+	for _, tt := range []struct {
+		mode              v1alpha1.Mode
+		expectedGVK       schema.GroupVersionKind
+		expectedSelector  map[string]string
+		expectedEndpoints []string
+	}{
+		{
+			mode:        v1alpha1.ModeDeployment,
+			expectedGVK: ServiceMonitorGVK,
+			expectedSelector: map[string]string{
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				"app.kubernetes.io/instance":   "observability.my-instance",
+				"app.kubernetes.io/part-of":    "opentelemetry",
+				"app.kubernetes.io/component":  "opentelemetry-collector",
+				"app.kubernetes.io/name":       "my-instance-collector-monitoring",
+			},
+			expectedEndpoints: []string{"spec", "endpoints"},
+		},
+		{
+			mode:        v1alpha1.ModeDaemonSet,
+			expectedGVK: PodMonitorGVK,
+			expectedSelector: map[string]string{
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				"app.kubernetes.io/instance":   "observability.my-instance",
+				"app.kubernetes.io/part-of":    "opentelemetry",
+				"app.kubernetes.io/component":  "opentelemetry-collector",
+			},
+			expectedEndpoints: []string{"spec", "podMetricsEndpoints"},
+		},
+		{
+			mode:        v1alpha1.ModeSidecar,
+			expectedGVK: PodMonitorGVK,
+			expectedSelector: map[string]string{
+				"sidecar.opentelemetry.io/injected": "observability.my-instance",
+			},
+			expectedEndpoints: []string{"spec", "podMetricsEndpoints"},
+		},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "observability",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode: tt.mode,
+					Observability: v1alpha1.ObservabilitySpec{
+						Metrics: v1alpha1.MetricsConfigSpec{
+							EnableMetrics: true,
+							Labels: map[string]string{
+								"release":                      "prometheus",
+								"app.kubernetes.io/managed-by": "someone-else",
+							},
+						},
+					},
+				},
+			}
+
+			// test
+			monitor, err := Monitor(config.New(), otelcol)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedGVK, monitor.GroupVersionKind())
+			assert.Equal(t, "my-instance-collector", monitor.GetName())
+			assert.Equal(t, "observability", monitor.GetNamespace())
+			assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+			assert.Equal(t, "opentelemetry-operator", monitor.GetLabels()["app.kubernetes.io/managed-by"])
+
+			selector, _, _ := unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+			assert.Equal(t, tt.expectedSelector, selector)
+			endpoints, _, _ := unstructured.NestedSlice(monitor.Object, tt.expectedEndpoints...)
+			require.Len(t, endpoints, 1)
+		})
+	}
+}
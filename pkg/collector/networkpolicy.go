@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// NetworkPolicy builds the NetworkPolicy of the collector pods of the given instance, allowing the ingress traffic
+// on the ports of the collector container only.
+func NetworkPolicy(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) networkingv1.NetworkPolicy {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.NetworkPolicy(otelcol)
+
+	container := Container(cfg, logger, otelcol)
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(container.Ports))
+	for _, p := range container.Ports {
+		ports = append(ports, NetworkPolicyPort(p.ContainerPort, p.Protocol))
+	}
+
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.NetworkPolicy(otelcol),
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: Annotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabels(otelcol),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: ports,
+				From:  otelcol.Spec.NetworkPolicy.From,
+			}},
+		},
+	}
+}
+
+// NetworkPolicyPort returns the NetworkPolicy port matching the given port number and protocol.
+func NetworkPolicyPort(port int32, protocol corev1.Protocol) networkingv1.NetworkPolicyPort {
+	number := intstr.FromInt(int(port))
+	result := networkingv1.NetworkPolicyPort{Port: &number}
+	if protocol != "" {
+		result.Protocol = &protocol
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	// prepare
+	from := []networkingv1.NetworkPolicyPeer{{
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+	}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+  statsd:
+    endpoint: 0.0.0.0:8125
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp, statsd]
+      exporters: [logging]
+`,
+			Ports: []corev1.ServicePort{{Name: "web", Port: 80}},
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{
+				From: from,
+			},
+		},
+	}
+
+	// test
+	policy := NetworkPolicy(config.New(), logger, otelcol)
+
+	// verify
+	tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+	port := func(number int) *intstr.IntOrString {
+		p := intstr.FromInt(number)
+		return &p
+	}
+	assert.Equal(t, "my-instance-collector", policy.Name)
+	assert.Equal(t, "observability", policy.Namespace)
+	assert.Equal(t, SelectorLabels(otelcol), policy.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	require.Len(t, policy.Spec.Ingress, 1)
+	assert.Equal(t, from, policy.Spec.Ingress[0].From)
+	assert.ElementsMatch(t, []networkingv1.NetworkPolicyPort{
+		{Port: port(8888), Protocol: &tcp},
+		{Port: port(4317), Protocol: &tcp},
+		{Port: port(8125), Protocol: &udp},
+		{Port: port(80)},
+	}, policy.Spec.Ingress[0].Ports)
+}
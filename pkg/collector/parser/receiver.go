@@ -30,6 +30,9 @@ import (
 var (
 	// DNS_LABEL constraints: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-label-names
 	dnsLabelValidation = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$")
+
+	// endpointPortValidation matches the port of an endpoint, optionally followed by a path.
+	endpointPortValidation = regexp.MustCompile(`:([0-9]+)(/[^:]*)?$`)
 )
 
 // ReceiverParser is an interface that should be implemented by all receiver parsers.
@@ -79,47 +82,43 @@ var (
 	listenAddressKey = "listen_address"
 )
 
-func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[interface{}]interface{}) *v1.ServicePort {
-	var endpoint interface{}
-	switch {
-	// syslog receiver contains the endpoint
-	// that needs to be exposed one level down inside config
-	// i.e. either in tcp or udp section with field key
-	// as `listen_address`
-	case name == "syslog":
-		var c map[interface{}]interface{}
-		if udp, isUDP := config["udp"]; isUDP && udp != nil {
-			c = udp.(map[interface{}]interface{})
-			endpoint = getAddressFromConfig(logger, name, listenAddressKey, c)
-		} else if tcp, isTCP := config["tcp"]; isTCP && tcp != nil {
-			c = tcp.(map[interface{}]interface{})
-			endpoint = getAddressFromConfig(logger, name, listenAddressKey, c)
-		}
-
-	// tcplog and udplog receivers hold the endpoint
-	// value in `listen_address` field
-	case name == "tcplog" || name == "udplog":
-		endpoint = getAddressFromConfig(logger, name, listenAddressKey, config)
-
-	// ignore kubeletstats receiver as it holds the field key endpoint, and it
-	// is a scraper, we only expose endpoint through k8s service objects for
-	// receivers that aren't scrapers.
-	case name == "kubeletstats":
-		return nil
+// scraperReceivers holds the receivers which scrape an endpoint instead of listening on it, so their endpoint
+// must not be exposed by the collector's service.
+var scraperReceivers = map[string]struct{}{
+	"apache":        {},
+	"couchdb":       {},
+	"elasticsearch": {},
+	"flinkmetrics":  {},
+	"haproxy":       {},
+	"httpcheck":     {},
+	"kubeletstats":  {},
+	"memcached":     {},
+	"mongodb":       {},
+	"mysql":         {},
+	"nginx":         {},
+	"postgresql":    {},
+	"prometheus":    {},
+	"rabbitmq":      {},
+	"redis":         {},
+	"riak":          {},
+	"zookeeper":     {},
+}
 
-	// ignore prometheus receiver as it has no listening endpoint
-	case name == "prometheus":
+func singlePortFromConfigEndpoint(logger logr.Logger, name string, config map[interface{}]interface{}) *v1.ServicePort {
+	// we only expose endpoint through k8s service objects for receivers that aren't scrapers.
+	if _, ok := scraperReceivers[receiverType(name)]; ok {
 		return nil
-
-	default:
-		endpoint = getAddressFromConfig(logger, name, endpointKey, config)
 	}
 
-	switch endpoint := endpoint.(type) {
+	return singlePortFromConfigKey(logger, name, endpointKey, config)
+}
+
+func singlePortFromConfigKey(logger logr.Logger, name, key string, config map[interface{}]interface{}) *v1.ServicePort {
+	switch endpoint := getAddressFromConfig(logger, name, key, config).(type) {
 	case string:
 		port, err := portFromEndpoint(endpoint)
 		if err != nil {
-			logger.WithValues(endpointKey, endpoint).Info("couldn't parse the endpoint's port")
+			logger.WithValues(key, endpoint).Info("couldn't parse the endpoint's port")
 			return nil
 		}
 
@@ -163,10 +162,10 @@ func portFromEndpoint(endpoint string) (int32, error) {
 	var err error
 	var port int64
 
-	r := regexp.MustCompile(":[0-9]+")
-
-	if r.MatchString(endpoint) {
-		port, err = strconv.ParseInt(strings.Replace(r.FindString(endpoint), ":", "", -1), 10, 32)
+	// the port is the last one of the endpoint, so that the IPv6 addresses like "[::1]:4317" and the
+	// environment variables like "${env:MY_POD_IP}:4317" aren't mistaken for it
+	if match := endpointPortValidation.FindStringSubmatch(endpoint); match != nil {
+		port, err = strconv.ParseInt(match[1], 10, 32)
 
 		if err != nil {
 			return 0, err
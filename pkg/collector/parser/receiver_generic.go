@@ -29,6 +29,7 @@ type GenericReceiver struct {
 	defaultAppProtocol *string
 	logger             logr.Logger
 	name               string
+	endpointKey        string
 	defaultProtocol    corev1.Protocol
 	parserName         string
 	defaultPort        int32
@@ -49,7 +50,12 @@ func NewGenericReceiverParser(logger logr.Logger, name string, config map[interf
 
 // Ports returns all the service ports for all protocols in this parser.
 func (g *GenericReceiver) Ports() ([]corev1.ServicePort, error) {
-	port := singlePortFromConfigEndpoint(g.logger, g.name, g.config)
+	var port *corev1.ServicePort
+	if g.endpointKey != "" {
+		port = singlePortFromConfigKey(g.logger, g.name, g.endpointKey, g.config)
+	} else {
+		port = singlePortFromConfigEndpoint(g.logger, g.name, g.config)
+	}
 	if port != nil {
 		port.Protocol = g.defaultProtocol
 		port.AppProtocol = g.defaultAppProtocol
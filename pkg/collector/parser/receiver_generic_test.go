@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/parser"
@@ -122,3 +123,57 @@ func TestDownstreamParsers(t *testing.T) {
 		})
 	}
 }
+
+func TestUDPParsers(t *testing.T) {
+	for _, tt := range []struct {
+		builder      func(logr.Logger, string, map[interface{}]interface{}) parser.ReceiverParser
+		receiverName string
+	}{
+		{parser.NewStatsdReceiverParser, "statsd"},
+		{parser.NewAWSXrayReceiverParser, "awsxray"},
+	} {
+		t.Run(tt.receiverName, func(t *testing.T) {
+			// prepare
+			builder := tt.builder(logger, tt.receiverName, map[interface{}]interface{}{})
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.Equal(t, corev1.ProtocolUDP, ports[0].Protocol)
+		})
+	}
+}
+
+func TestAppProtocols(t *testing.T) {
+	for _, tt := range []struct {
+		builder      func(logr.Logger, string, map[interface{}]interface{}) parser.ReceiverParser
+		receiverName string
+		appProtocol  string
+	}{
+		{parser.NewOpenCensusReceiverParser, "opencensus", "grpc"},
+		{parser.NewCollectdReceiverParser, "collectd", "http"},
+		{parser.NewSAPMReceiverParser, "sapm", "http"},
+		{parser.NewSignalFxReceiverParser, "signalfx", "http"},
+		{parser.NewInfluxdbReceiverParser, "influxdb", "http"},
+		{parser.NewSplunkHecReceiverParser, "splunk_hec", "http"},
+	} {
+		t.Run(tt.receiverName, func(t *testing.T) {
+			// prepare
+			builder := tt.builder(logger, tt.receiverName, map[interface{}]interface{}{})
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.Equal(t, corev1.ProtocolTCP, ports[0].Protocol)
+			if assert.NotNil(t, ports[0].AppProtocol) {
+				assert.Equal(t, tt.appProtocol, *ports[0].AppProtocol)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLokiSelfRegisters(t *testing.T) {
+	// verify
+	assert.True(t, IsRegistered("loki"))
+}
+
+func TestLokiPortsOverridden(t *testing.T) {
+	// prepare
+	builder := NewLokiReceiverParser(logger, "loki/custom", map[interface{}]interface{}{
+		"protocols": map[interface{}]interface{}{
+			"grpc": map[interface{}]interface{}{
+				"endpoint": "0.0.0.0:1234",
+			},
+			"http": map[interface{}]interface{}{
+				"endpoint": "${env:MY_POD_IP}:1235",
+			},
+		},
+	})
+
+	// test
+	ports, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, ports, 2)
+	assert.Equal(t, "loki-custom-grpc", ports[0].Name)
+	assert.EqualValues(t, 1234, ports[0].Port)
+	assert.Equal(t, "loki-custom-http", ports[1].Name)
+	assert.EqualValues(t, 1235, ports[1].Port)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ ReceiverParser = &MultiProtocolReceiver{}
+
+// MultiProtocolReceiver is a parser for the receivers listening on a port for each of the protocols configured in
+// their "protocols" section. Like for the OTLP receivers, only the configured protocols get a port.
+type MultiProtocolReceiver struct {
+	config     map[interface{}]interface{}
+	logger     logr.Logger
+	name       string
+	parserName string
+	protocols  []receiverProtocol
+}
+
+// receiverProtocol describes a protocol of a multi-protocol receiver.
+type receiverProtocol struct {
+	name        string
+	defaultPort int32
+	appProtocol *string
+}
+
+func newMultiProtocolReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}, parserName string, protocols []receiverProtocol) ReceiverParser {
+	settings, ok := config["protocols"].(map[interface{}]interface{})
+	if !ok {
+		settings = map[interface{}]interface{}{}
+	}
+
+	return &MultiProtocolReceiver{
+		logger:     logger,
+		name:       name,
+		config:     settings,
+		parserName: parserName,
+		protocols:  protocols,
+	}
+}
+
+// Ports returns all the service ports for all protocols in this parser.
+func (m *MultiProtocolReceiver) Ports() ([]corev1.ServicePort, error) {
+	ports := []corev1.ServicePort{}
+
+	for _, protocol := range m.protocols {
+		// do we have the protocol specified at all?
+		protocolConfig, ok := m.config[protocol.name]
+		if !ok {
+			continue
+		}
+
+		nameWithProtocol := fmt.Sprintf("%s-%s", m.name, protocol.name)
+		var port *corev1.ServicePort
+		if settings, ok := protocolConfig.(map[interface{}]interface{}); ok {
+			port = singlePortFromConfigEndpoint(m.logger, nameWithProtocol, settings)
+		}
+		if port == nil {
+			port = &corev1.ServicePort{
+				Name: portName(nameWithProtocol, protocol.defaultPort),
+				Port: protocol.defaultPort,
+			}
+		}
+		port.Protocol = corev1.ProtocolTCP
+		port.AppProtocol = protocol.appProtocol
+		ports = append(ports, *port)
+	}
+
+	return ports, nil
+}
+
+// ParserName returns the name of this parser.
+func (m *MultiProtocolReceiver) ParserName() string {
+	return m.parserName
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSkywalkingSelfRegisters(t *testing.T) {
+	// verify
+	assert.True(t, IsRegistered("skywalking"))
+}
+
+func TestSkywalkingIsFoundByName(t *testing.T) {
+	// test
+	p := For(logger, "skywalking/custom", map[interface{}]interface{}{})
+
+	// verify
+	assert.Equal(t, "__skywalking", p.ParserName())
+}
+
+func TestSkywalkingExposeDefaultPorts(t *testing.T) {
+	// prepare
+	builder := NewSkywalkingReceiverParser(logger, "skywalking", map[interface{}]interface{}{
+		"protocols": map[interface{}]interface{}{
+			"grpc": nil,
+			"http": map[interface{}]interface{}{},
+		},
+	})
+
+	// test
+	ports, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, ports, 2)
+	assert.Equal(t, "skywalking-grpc", ports[0].Name)
+	assert.EqualValues(t, 11800, ports[0].Port)
+	assert.Equal(t, "grpc", *ports[0].AppProtocol)
+	assert.Equal(t, "skywalking-http", ports[1].Name)
+	assert.EqualValues(t, 12800, ports[1].Port)
+	assert.Equal(t, "http", *ports[1].AppProtocol)
+	for _, port := range ports {
+		assert.EqualValues(t, corev1.ProtocolTCP, port.Protocol)
+	}
+}
+
+func TestSkywalkingNoProtocols(t *testing.T) {
+	// prepare
+	builder := NewSkywalkingReceiverParser(logger, "skywalking", map[interface{}]interface{}{})
+
+	// test
+	ports, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, ports, 0)
+}
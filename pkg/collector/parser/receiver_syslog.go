@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ ReceiverParser = &SyslogReceiverParser{}
+
+const parserNameSyslog = "__syslog"
+
+// SyslogReceiverParser parses the configuration for syslog receivers, from the contrib repository.
+type SyslogReceiverParser struct {
+	config map[interface{}]interface{}
+	logger logr.Logger
+	name   string
+}
+
+// NewSyslogReceiverParser builds a new parser for syslog receivers.
+func NewSyslogReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) ReceiverParser {
+	return &SyslogReceiverParser{
+		logger: logger,
+		name:   name,
+		config: config,
+	}
+}
+
+// Ports returns the port of the tcp or udp section of the receiver, without default port, since the
+// listen address is required.
+func (s *SyslogReceiverParser) Ports() ([]corev1.ServicePort, error) {
+	for _, transport := range []struct {
+		key      string
+		protocol corev1.Protocol
+	}{
+		{key: "udp", protocol: corev1.ProtocolUDP},
+		{key: "tcp", protocol: corev1.ProtocolTCP},
+	} {
+		settings, ok := s.config[transport.key].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if port := singlePortFromConfigKey(s.logger, s.name, listenAddressKey, settings); port != nil {
+			port.Protocol = transport.protocol
+			return []corev1.ServicePort{*port}, nil
+		}
+	}
+
+	return []corev1.ServicePort{}, nil
+}
+
+// ParserName returns the name of this parser.
+func (s *SyslogReceiverParser) ParserName() string {
+	return parserNameSyslog
+}
+
+func init() {
+	Register("syslog", NewSyslogReceiverParser)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSyslogSelfRegisters(t *testing.T) {
+	// verify
+	assert.True(t, IsRegistered("syslog"))
+}
+
+func TestSyslogPorts(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   map[interface{}]interface{}
+		protocol corev1.Protocol
+	}{
+		{
+			desc: "tcp",
+			config: map[interface{}]interface{}{
+				"tcp": map[interface{}]interface{}{"listen_address": "0.0.0.0:5140"},
+			},
+			protocol: corev1.ProtocolTCP,
+		},
+		{
+			desc: "udp",
+			config: map[interface{}]interface{}{
+				"udp": map[interface{}]interface{}{"listen_address": "0.0.0.0:5140"},
+			},
+			protocol: corev1.ProtocolUDP,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := For(logger, "syslog/custom", tt.config)
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 1)
+			assert.Equal(t, "syslog-custom", ports[0].Name)
+			assert.EqualValues(t, 5140, ports[0].Port)
+			assert.Equal(t, tt.protocol, ports[0].Protocol)
+		})
+	}
+}
+
+func TestSyslogWithoutListenAddress(t *testing.T) {
+	// prepare
+	builder := NewSyslogReceiverParser(logger, "syslog", map[interface{}]interface{}{
+		"tcp": map[interface{}]interface{}{},
+	})
+
+	// test
+	ports, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, ports, 0)
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTCPLogSelfRegisters(t *testing.T) {
+	// verify
+	assert.True(t, IsRegistered("tcplog"))
+}
+
+func TestTCPLogPorts(t *testing.T) {
+	// prepare
+	builder := For(logger, "tcplog", map[interface{}]interface{}{
+		"listen_address": "0.0.0.0:54525",
+	})
+
+	// test
+	ports, err := builder.Ports()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, ports, 1)
+	assert.Equal(t, "tcplog", ports[0].Name)
+	assert.EqualValues(t, 54525, ports[0].Port)
+	assert.Equal(t, corev1.ProtocolTCP, ports[0].Protocol)
+}
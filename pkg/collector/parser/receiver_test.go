@@ -70,6 +70,8 @@ func TestReceiverParsePortFromEndpoint(t *testing.T) {
 		{"absolute with path", "http://localhost:1234/server-status?auto", 1234, false},
 		{"no protocol", "0.0.0.0:1234", 1234, false},
 		{"just port", ":1234", 1234, false},
+		{"ipv6", "[::1]:1234", 1234, false},
+		{"environment variable", "${env:MY_POD_IP}:1234", 1234, false},
 		{"no port at all", "http://localhost", 0, true},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -114,6 +116,24 @@ func TestIgnorekubeletstatsEndpoint(t *testing.T) {
 	assert.Len(t, ports, 0)
 }
 
+func TestIgnoreScraperEndpoints(t *testing.T) {
+	for _, name := range []string{"prometheus", "nginx", "redis/primary"} {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			builder := For(logger, name, map[interface{}]interface{}{
+				"endpoint": "localhost:9000",
+			})
+
+			// test
+			ports, err := builder.Ports()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Len(t, ports, 0)
+		})
+	}
+}
+
 func TestReceiverFallbackWhenNotRegistered(t *testing.T) {
 	// test
 	p := For(logger, "myreceiver", map[interface{}]interface{}{})
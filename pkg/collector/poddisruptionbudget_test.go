@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	// prepare
+	minAvailable := intstr.FromString("50%")
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PodDisruptionBudget: &v1alpha1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+			},
+		},
+	}
+
+	// test
+	pdb := PodDisruptionBudget(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-collector", pdb.Name)
+	assert.Equal(t, "observability", pdb.Namespace)
+	assert.Equal(t, "my-instance-collector", pdb.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+	assert.Equal(t, SelectorLabels(otelcol), pdb.Spec.Selector.MatchLabels)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// PodTemplateOverrides applies the instance's podTemplateOverrides strategic merge patch to the pod template. The
+// selector labels are set back after the patch, so that the workload still selects its pods. The template is returned
+// unchanged when the patch can't be applied.
+func PodTemplateOverrides(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	if otelcol.Spec.PodTemplateOverrides == nil || len(otelcol.Spec.PodTemplateOverrides.Raw) == 0 {
+		return template
+	}
+
+	patched, err := patchPodTemplate(template, otelcol.Spec.PodTemplateOverrides.Raw)
+	if err != nil {
+		logger.Error(err, "failed to apply the pod template overrides, ignoring them")
+		return template
+	}
+
+	if patched.Labels == nil {
+		patched.Labels = map[string]string{}
+	}
+	for k, v := range SelectorLabels(otelcol) {
+		patched.Labels[k] = v
+	}
+	return patched
+}
+
+func patchPodTemplate(template corev1.PodTemplateSpec, patch []byte) (corev1.PodTemplateSpec, error) {
+	original, err := json.Marshal(template)
+	if err != nil {
+		return template, err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+	if err != nil {
+		return template, err
+	}
+	patched := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return template, err
+	}
+	return patched, nil
+}
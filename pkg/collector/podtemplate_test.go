@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPodTemplateOverrides(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		patch  string
+		verify func(t *testing.T, template corev1.PodTemplateSpec)
+	}{
+		{
+			name:  "new fields",
+			patch: `{"spec":{"hostAliases":[{"ip":"10.0.0.1","hostnames":["backend"]}]}}`,
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"backend"}}}, template.Spec.HostAliases)
+				assert.Len(t, template.Spec.Containers, 1)
+			},
+		},
+		{
+			name:  "containers merged by name",
+			patch: `{"spec":{"containers":[{"name":"otc-container","stdin":true}]}}`,
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				require.Len(t, template.Spec.Containers, 1)
+				assert.True(t, template.Spec.Containers[0].Stdin)
+				assert.NotEmpty(t, template.Spec.Containers[0].Args)
+			},
+		},
+		{
+			name:  "selector labels kept",
+			patch: `{"metadata":{"labels":{"app.kubernetes.io/instance":"other","team":"observability"}}}`,
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				assert.Equal(t, "default.my-instance", template.Labels["app.kubernetes.io/instance"])
+				assert.Equal(t, "observability", template.Labels["team"])
+			},
+		},
+		{
+			name:  "invalid patch ignored",
+			patch: `{"spec":{"containers":"otc-container"}}`,
+			verify: func(t *testing.T, template corev1.PodTemplateSpec) {
+				require.Len(t, template.Spec.Containers, 1)
+				assert.Equal(t, "otc-container", template.Spec.Containers[0].Name)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					PodTemplateOverrides: &runtime.RawExtension{Raw: []byte(tt.patch)},
+				},
+			}
+
+			// test
+			d := Deployment(config.New(), logger, otelcol)
+
+			// verify
+			tt.verify(t, d.Spec.Template)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// PreDeployCheckRevision returns a short hash identifying the collector image and config to verify, along with the check itself.
+func PreDeployCheckRevision(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) string {
+	parts := []string{Image(cfg, otelcol), getConfigMapSHA(otelcol.Spec.Config)}
+	if otelcol.Spec.PreDeployCheck != nil {
+		parts = append(parts, otelcol.Spec.PreDeployCheck.Image)
+		parts = append(parts, otelcol.Spec.PreDeployCheck.Command...)
+	}
+	h := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return fmt.Sprintf("%x", h)[:10]
+}
+
+// PreDeployCheckJob builds the Job verifying the given revision of the instance before it's deployed.
+func PreDeployCheckJob(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, revision string) batchv1.Job {
+	name := naming.PreDeployCheck(otelcol, revision)
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = name
+	labels["app.kubernetes.io/component"] = "opentelemetry-precheck"
+
+	backoffLimit := int32(0)
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: ServiceAccountName(otelcol),
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "precheck",
+						Image:   otelcol.Spec.PreDeployCheck.Image,
+						Command: otelcol.Spec.PreDeployCheck.Command,
+					}},
+					Tolerations:  otelcol.Spec.Tolerations,
+					NodeSelector: otelcol.Spec.NodeSelector,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPreDeployCheckRevision(t *testing.T) {
+	// prepare
+	cfg := config.New(config.WithCollectorImage("default-image"))
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: {}",
+			PreDeployCheck: &v1alpha1.PreDeployCheckSpec{
+				Image: "busybox",
+			},
+		},
+	}
+
+	// test
+	revision := PreDeployCheckRevision(cfg, otelcol)
+	otelcol.Spec.Config = "receivers: {otlp: {}}"
+	changedConfig := PreDeployCheckRevision(cfg, otelcol)
+	otelcol.Spec.Image = "custom-image"
+	changedImage := PreDeployCheckRevision(cfg, otelcol)
+
+	// verify
+	assert.Len(t, revision, 10)
+	assert.NotEqual(t, revision, changedConfig)
+	assert.NotEqual(t, changedConfig, changedImage)
+	assert.Equal(t, changedImage, PreDeployCheckRevision(cfg, otelcol))
+}
+
+func TestPreDeployCheckJob(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PreDeployCheck: &v1alpha1.PreDeployCheckSpec{
+				Image:   "busybox",
+				Command: []string{"/check.sh"},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	job := PreDeployCheckJob(cfg, logger, otelcol, "abcdef0123")
+
+	// verify
+	assert.Equal(t, "my-instance-precheck-abcdef0123", job.Name)
+	assert.Equal(t, "my-ns", job.Namespace)
+	assert.Equal(t, "opentelemetry-precheck", job.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, "my-instance-collector", job.Spec.Template.Spec.ServiceAccountName)
+	assert.Len(t, job.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "busybox", job.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"/check.sh"}, job.Spec.Template.Spec.Containers[0].Command)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// certificateGVK is the kind of the cert-manager Certificates, handled as unstructured objects.
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+type receiverCertificateSpec struct {
+	SecretName string                        `json:"secretName"`
+	CommonName string                        `json:"commonName"`
+	DNSNames   []string                      `json:"dnsNames"`
+	Usages     []string                      `json:"usages"`
+	IssuerRef  v1alpha1.CertificateIssuerRef `json:"issuerRef"`
+	PrivateKey receiverCertificateKey        `json:"privateKey"`
+}
+
+type receiverCertificateKey struct {
+	Algorithm      string `json:"algorithm"`
+	RotationPolicy string `json:"rotationPolicy"`
+}
+
+// UsesReceiverTLS returns whether the operator issues the certificates of the receivers of the instance.
+func UsesReceiverTLS(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.TLS.IssuerRef != nil && otelcol.Spec.Mode != v1alpha1.ModeSidecar
+}
+
+// ReceiverTLSDirs returns the directories of the collector container holding the receiver certificates issued by
+// the operator, i.e. the ones of the TLS files not mounted by the volumeMounts of the instance.
+func ReceiverTLSDirs(otelcol v1alpha1.OpenTelemetryCollector) ([]adapters.ReceiverTLSDir, error) {
+	if !UsesReceiverTLS(otelcol) {
+		return nil, nil
+	}
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	mounted := make([]string, 0, len(otelcol.Spec.VolumeMounts))
+	for _, mount := range otelcol.Spec.VolumeMounts {
+		mounted = append(mounted, mount.MountPath)
+	}
+	return adapters.ReceiverTLSDirs(adapters.ConfigToReceiverTLSFiles(cfg), mounted)
+}
+
+// ReceiverCertificateNames returns the names of the receiver Certificates, and Secrets, issued by the operator.
+func ReceiverCertificateNames(otelcol v1alpha1.OpenTelemetryCollector) ([]string, error) {
+	dirs, err := ReceiverTLSDirs(otelcol)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		name := naming.ReceiverCertificate(otelcol, dir.Receiver)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ReceiverCertificates returns a cert-manager Certificate for each receiver serving TLS, valid for the names of the
+// collector Services. cert-manager renews them, along with their private key, before they expire.
+func ReceiverCertificates(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) ([]unstructured.Unstructured, error) {
+	names, err := ReceiverCertificateNames(otelcol)
+	if err != nil {
+		return nil, err
+	}
+
+	var dnsNames []string
+	for _, service := range []string{naming.Service(otelcol), naming.HeadlessService(otelcol)} {
+		dnsNames = append(dnsNames,
+			service,
+			fmt.Sprintf("%s.%s", service, otelcol.Namespace),
+			fmt.Sprintf("%s.%s.svc", service, otelcol.Namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", service, otelcol.Namespace),
+		)
+	}
+	dnsNames = append(dnsNames, otelcol.Spec.TLS.DNSNames...)
+
+	certificates := make([]unstructured.Unstructured, 0, len(names))
+	for _, name := range names {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&receiverCertificateSpec{
+			SecretName: name,
+			CommonName: naming.Service(otelcol),
+			DNSNames:   dnsNames,
+			Usages:     []string{"digital signature", "key encipherment", "server auth"},
+			IssuerRef:  *otelcol.Spec.TLS.IssuerRef,
+			PrivateKey: receiverCertificateKey{Algorithm: "ECDSA", RotationPolicy: "Always"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the Certificate spec: %w", err)
+		}
+
+		labels := Labels(otelcol, cfg.LabelsFilter())
+		labels["app.kubernetes.io/name"] = name
+
+		cert := unstructured.Unstructured{}
+		cert.SetGroupVersionKind(certificateGVK)
+		cert.SetName(name)
+		cert.SetNamespace(otelcol.Namespace)
+		cert.SetLabels(labels)
+		cert.Object["spec"] = content
+		certificates = append(certificates, cert)
+	}
+	return certificates, nil
+}
+
+// receiverTLSVolumes returns the volumes of the receiver certificates, one for each directory holding their files.
+func receiverTLSVolumes(otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
+	dirs, err := ReceiverTLSDirs(otelcol)
+	if err != nil {
+		// the webhook rejects these configs, and the container builder logs the error
+		return nil
+	}
+
+	volumes := make([]corev1.Volume, 0, len(dirs))
+	for i, dir := range dirs {
+		var items []corev1.KeyToPath
+		for _, file := range dir.CertFiles {
+			items = append(items, corev1.KeyToPath{Key: corev1.TLSCertKey, Path: file})
+		}
+		for _, file := range dir.KeyFiles {
+			items = append(items, corev1.KeyToPath{Key: corev1.TLSPrivateKeyKey, Path: file})
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.ReceiverTLSVolume(i),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: naming.ReceiverCertificate(otelcol, dir.Receiver),
+					Items:      items,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// receiverTLSVolumeMounts returns the mounts of the receiver certificate volumes. The whole directories are mounted,
+// as the files mounted with a subPath aren't updated when the certificates are renewed.
+func receiverTLSVolumeMounts(otelcol v1alpha1.OpenTelemetryCollector) ([]corev1.VolumeMount, error) {
+	dirs, err := ReceiverTLSDirs(otelcol)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make([]corev1.VolumeMount, 0, len(dirs))
+	for i, dir := range dirs {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      naming.ReceiverTLSVolume(i),
+			MountPath: path.Clean(dir.Dir),
+			ReadOnly:  true,
+		})
+	}
+	return mounts, nil
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const receiverTLSConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /certs/otlp/tls.crt
+          key_file: /certs/otlp/tls.key
+  jaeger:
+    protocols:
+      grpc:
+        tls:
+          cert_file: /mounted/tls.crt
+          key_file: /mounted/tls.key
+`
+
+func receiverTLSInstance() v1alpha1.OpenTelemetryCollector {
+	return v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: receiverTLSConfig,
+			TLS: v1alpha1.TLSSpec{
+				IssuerRef: &v1alpha1.CertificateIssuerRef{Name: "ca-issuer", Kind: "ClusterIssuer"},
+				DNSNames:  []string{"otlp.example.com"},
+			},
+			VolumeMounts: []corev1.VolumeMount{{Name: "mounted", MountPath: "/mounted"}},
+		},
+	}
+}
+
+func TestReceiverCertificates(t *testing.T) {
+	// prepare
+	otelcol := receiverTLSInstance()
+	otelcol.Labels = map[string]string{"foo": "1", "bar": "2"}
+	cfg := config.New(config.WithLabelFilters([]string{"foo"}))
+
+	// test
+	certificates, err := ReceiverCertificates(cfg, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, certificates, 1)
+	cert := certificates[0]
+	assert.Equal(t, "Certificate", cert.GetKind())
+	assert.Equal(t, naming.ReceiverCertificate(otelcol, "otlp"), cert.GetName())
+	assert.Equal(t, "my-ns", cert.GetNamespace())
+	assert.Equal(t, "opentelemetry-collector", cert.GetLabels()["app.kubernetes.io/component"])
+	assert.Equal(t, "2", cert.GetLabels()["bar"])
+	assert.NotContains(t, cert.GetLabels(), "foo")
+
+	spec := cert.Object["spec"].(map[string]interface{})
+	assert.Equal(t, cert.GetName(), spec["secretName"])
+	assert.Equal(t, map[string]interface{}{"name": "ca-issuer", "kind": "ClusterIssuer"}, spec["issuerRef"])
+	assert.Contains(t, spec["dnsNames"], "my-instance-collector.my-ns.svc")
+	assert.Contains(t, spec["dnsNames"], "my-instance-collector-headless.my-ns.svc.cluster.local")
+	assert.Contains(t, spec["dnsNames"], "otlp.example.com")
+}
+
+func TestReceiverCertificateNamesUnique(t *testing.T) {
+	// prepare
+	first := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "my-instance"}}
+	second := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "my"}}
+
+	// test
+	firstName := naming.ReceiverCertificate(first, "otlp")
+	secondName := naming.ReceiverCertificate(second, "instance-otlp")
+
+	// verify
+	assert.NotEqual(t, firstName, secondName)
+	assert.Regexp(t, "^my-instance-otlp-[0-9a-f]{8}-tls$", firstName)
+}
+
+func TestReceiverCertificatesSidecar(t *testing.T) {
+	// prepare
+	otelcol := receiverTLSInstance()
+	otelcol.Spec.Mode = v1alpha1.ModeSidecar
+
+	// test
+	certificates, err := ReceiverCertificates(config.New(), otelcol)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Empty(t, certificates)
+}
+
+func TestReceiverTLSVolumes(t *testing.T) {
+	// prepare
+	otelcol := receiverTLSInstance()
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+	container := Container(cfg, logger, otelcol)
+
+	// verify
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "receiver-tls-0", volumes[1].Name)
+	require.NotNil(t, volumes[1].Secret)
+	assert.Equal(t, naming.ReceiverCertificate(otelcol, "otlp"), volumes[1].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: corev1.TLSCertKey, Path: "tls.crt"},
+		{Key: corev1.TLSPrivateKeyKey, Path: "tls.key"},
+	}, volumes[1].Secret.Items)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "receiver-tls-0",
+		MountPath: "/certs/otlp",
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the field manager of the objects applied by the operator. It doesn't depend on the name of the
+// operator binary, so that the fields owned by the operator can be told apart from the ones of the GitOps tools.
+const FieldManager = "opentelemetry-operator"
+
+// apply creates or updates the desired object with a server-side apply. The operator owns the fields it sets, which it
+// takes over from the other field managers, and the fields it stops setting are removed from the object, while the
+// fields set by the API server and the other field managers are kept.
+func apply(ctx context.Context, params Params, desired client.Object) error {
+	// the typed objects built for the instance leave the type meta empty, which the apply patch needs
+	gvk, err := apiutil.GVKForObject(desired, params.Scheme)
+	if err != nil {
+		return fmt.Errorf("failed to get the kind of the object: %w", err)
+	}
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	desired.SetManagedFields(nil)
+	desired.SetResourceVersion("")
+
+	return params.Client.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
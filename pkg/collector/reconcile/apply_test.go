@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestApply(t *testing.T) {
+	// prepare
+	nns := types.NamespacedName{Namespace: "default", Name: "test-apply"}
+	desired := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nns.Name,
+			Namespace: nns.Namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "opentelemetry-operator", "team": "otel"},
+		},
+		Data: map[string]string{"collector.yaml": "receivers: {}", "removed.yaml": "exporters: {}"},
+	}
+	require.NoError(t, apply(context.Background(), params(), desired.DeepCopy()))
+
+	// another field manager labels the object
+	existing := &v1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), nns, existing))
+	existing.Labels["argocd.argoproj.io/instance"] = "collectors"
+	require.NoError(t, k8sClient.Update(context.Background(), existing))
+
+	// test
+	delete(desired.Labels, "team")
+	delete(desired.Data, "removed.yaml")
+	err := apply(context.Background(), params(), desired.DeepCopy())
+
+	// verify
+	assert.NoError(t, err)
+	actual := &v1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), nns, actual))
+	assert.Equal(t, map[string]string{"collector.yaml": "receivers: {}"}, actual.Data)
+	assert.NotContains(t, actual.Labels, "team")
+	assert.Equal(t, "collectors", actual.Labels["argocd.argoproj.io/instance"])
+
+	managers := map[string]metav1.ManagedFieldsOperationType{}
+	for _, entry := range actual.ManagedFields {
+		managers[entry.Manager] = entry.Operation
+	}
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, managers[FieldManager])
+
+	require.NoError(t, k8sClient.Delete(context.Background(), actual))
+}
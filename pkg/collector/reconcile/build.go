@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// CollectorBuild reconciles the Job building the custom distribution of the instance. The Job of a previous revision
+// is deleted, its deletion triggering the reconciliation creating the Job of the new revision.
+func CollectorBuild(ctx context.Context, params Params) error {
+	existing := &batchv1.Job{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.CollectorBuild(params.Instance)}
+	err := params.Client.Get(ctx, nns, existing)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	found := err == nil
+
+	if params.Instance.Spec.Build == nil {
+		if found {
+			return deleteBuild(ctx, params, existing)
+		}
+		return nil
+	}
+
+	desired, err := collector.BuildJob(params.Config, params.Log, params.Instance)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := params.Client.Create(ctx, &desired); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "job.name", desired.Name, "job.namespace", desired.Namespace)
+		return nil
+	}
+	if existing.DeletionTimestamp != nil {
+		return nil
+	}
+	if existing.Annotations[collector.BuildRevisionAnnotation] != desired.Annotations[collector.BuildRevisionAnnotation] {
+		// the manifest changed, the build of the previous revision is stale
+		return deleteBuild(ctx, params, existing)
+	}
+
+	switch {
+	case existing.Status.Succeeded > 0:
+		return setJobCondition(ctx, params, v1alpha1.ConditionTypeBuildFailed, metav1.ConditionFalse, "JobSucceeded",
+			fmt.Sprintf("the build job %s succeeded", existing.Name))
+	case existing.Status.Failed > 0:
+		params.Log.Info("custom distribution build failed, holding the collector update", "job.name", existing.Name)
+		return setJobCondition(ctx, params, v1alpha1.ConditionTypeBuildFailed, metav1.ConditionTrue, "JobFailed",
+			fmt.Sprintf("the build job %s failed, delete it to build the distribution again", existing.Name))
+	}
+	return nil
+}
+
+// collectorBuilt returns whether the custom distribution of the instance, if any, is built and pushed.
+func collectorBuilt(ctx context.Context, params Params) (bool, error) {
+	if params.Instance.Spec.Build == nil {
+		return true, nil
+	}
+
+	existing := &batchv1.Job{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.CollectorBuild(params.Instance)}
+	if err := params.Client.Get(ctx, nns, existing); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get: %w", err)
+	}
+	return existing.Annotations[collector.BuildRevisionAnnotation] == collector.BuildRevision(params.Instance) &&
+		existing.Status.Succeeded > 0, nil
+}
+
+// deleteBuild deletes the build Job along with its pods.
+func deleteBuild(ctx context.Context, params Params, existing *batchv1.Job) error {
+	if err := params.Client.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	params.Log.V(2).Info("deleted", "job.name", existing.Name, "job.namespace", existing.Namespace)
+	return nil
+}
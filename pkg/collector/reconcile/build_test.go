@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestCollectorBuild(t *testing.T) {
+	param := params()
+	param.Instance.Name = "build"
+	param.Instance.Spec.Build = &v1alpha1.BuildSpec{
+		Image: "registry.example.com/otelcol",
+		Manifest: `receivers:
+  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.69.0
+`,
+	}
+	nns := types.NamespacedName{Namespace: "default", Name: "build-build"}
+
+	t.Run("should create the build job", func(t *testing.T) {
+		require.NoError(t, CollectorBuild(context.Background(), param))
+
+		job := &batchv1.Job{}
+		exists, err := populateObjectIfExists(t, job, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, collector.BuildRevision(param.Instance), job.Annotations[collector.BuildRevisionAnnotation])
+
+		built, err := collectorBuilt(context.Background(), param)
+		require.NoError(t, err)
+		assert.False(t, built)
+	})
+
+	t.Run("should recreate the build job when the manifest changes", func(t *testing.T) {
+		param.Instance.Spec.Build.Manifest += `exporters:
+  - gomod: go.opentelemetry.io/collector/exporter/otlpexporter v0.69.0
+`
+
+		// the stale job is deleted first
+		require.NoError(t, CollectorBuild(context.Background(), param))
+		exists, err := populateObjectIfExists(t, &batchv1.Job{}, nns)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, CollectorBuild(context.Background(), param))
+		job := &batchv1.Job{}
+		exists, err = populateObjectIfExists(t, job, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, collector.BuildRevision(param.Instance), job.Annotations[collector.BuildRevisionAnnotation])
+	})
+
+	t.Run("should delete the build job without build", func(t *testing.T) {
+		param.Instance.Spec.Build = nil
+
+		require.NoError(t, CollectorBuild(context.Background(), param))
+		exists, err := populateObjectIfExists(t, &batchv1.Job{}, nns)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
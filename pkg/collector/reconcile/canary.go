@@ -0,0 +1,390 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// canaryBakingSinceAnnotation holds the time the replicas of the canary Deployment became ready at.
+	canaryBakingSinceAnnotation = "opentelemetry.io/canary-baking-since"
+
+	// canaryResultAnnotation holds the outcome of the canary Deployment for its revision, promoted or failed.
+	canaryResultAnnotation = "opentelemetry.io/canary-result"
+
+	canaryResultPromoted = "promoted"
+	canaryResultFailed   = "failed"
+)
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Canaries rolls out the config and image changes of the collector Deployment to its canary Deployment first. The
+// changes are promoted, and applied by the ConfigMaps and Deployments tasks, once the canary replicas stayed healthy
+// for the bake duration. A failed canary is scaled down, and its changes aren't rolled out until they change again.
+func Canaries(ctx context.Context, params Params) error {
+	if !collector.UsesCanaryRollout(params.Instance) {
+		if err := deleteCanary(ctx, params); err != nil {
+			return err
+		}
+		return updateCanaryCondition(ctx, params, nil)
+	}
+
+	pending, err := collectorUpdatePending(ctx, params)
+	if err != nil || !pending {
+		// nothing to roll out, or the changes were promoted already
+		if err == nil {
+			err = deleteCanary(ctx, params)
+		}
+		return err
+	}
+
+	// hold the update until the custom distribution is built
+	if built, err := collectorBuilt(ctx, params); err != nil || !built {
+		return err
+	}
+
+	// hold the update until the image has the components of the config
+	if !componentsSupported(params) {
+		return nil
+	}
+
+	// the canary only runs the changes that passed the pre-deploy check
+	if passed, err := preDeployCheckPassed(ctx, params); err != nil || !passed {
+		return err
+	}
+
+	revision := collector.CanaryRevision(params.Config, params.Instance)
+	var existing *appsv1.Deployment
+	current := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.CanaryCollector(params.Instance)}
+	if err := params.Client.Get(ctx, nns, current); err == nil {
+		existing = current
+	} else if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	sameRevision := existing != nil && existing.Annotations[collector.CanaryRevisionAnnotation] == revision
+	if sameRevision && existing.Annotations[canaryResultAnnotation] != "" {
+		// the promoted changes are applied by the other tasks, and the failed ones are held until they change
+		return nil
+	}
+
+	desired := collector.CanaryDeployment(params.Config, params.Log, params.Instance, revision)
+	// Selector is an immutable field, the canary with a previous selector is created again in the next reconcile cycle.
+	if existing != nil && !apiequality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		params.Log.V(2).Info("Spec.Selector change detected, trying to delete, the new canary deployment will be created in the next reconcile cycle ", "deployment.name", existing.Name, "deployment.namespace", existing.Namespace)
+		if err := params.Client.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete deployment: %w", err)
+		}
+		return nil
+	}
+	if err := expectedCanary(ctx, params, desired, existing, sameRevision); err != nil {
+		return fmt.Errorf("failed to reconcile the expected canary: %w", err)
+	}
+	if !sameRevision {
+		return updateCanaryCondition(ctx, params, &metav1.Condition{
+			Status:  metav1.ConditionTrue,
+			Reason:  "RollingOut",
+			Message: fmt.Sprintf("the canary of revision %s is rolling out", revision),
+		})
+	}
+	if !deploymentRolledOut(*existing) || existing.Status.ReadyReplicas < *desired.Spec.Replicas {
+		// the rollout status change triggers a new reconciliation
+		return nil
+	}
+
+	since, err := time.Parse(time.RFC3339, existing.Annotations[canaryBakingSinceAnnotation])
+	if err != nil {
+		// the canary replicas just became ready
+		if err := annotateCanary(ctx, params, existing, canaryBakingSinceAnnotation, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+		return updateCanaryCondition(ctx, params, &metav1.Condition{
+			Status:  metav1.ConditionTrue,
+			Reason:  "Baking",
+			Message: fmt.Sprintf("the canary of revision %s is baking for %s", revision, collector.CanaryBakeDuration(params.Instance)),
+		})
+	}
+
+	failure, ready, err := canaryFailure(ctx, params, desired.Spec.Selector.MatchLabels)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		message := fmt.Sprintf("the canary of revision %s failed, the collector update is on hold: %s", revision, failure)
+		params.Log.Info("canary failed, holding the collector update", "revision", revision, "reason", failure)
+		if err := annotateCanary(ctx, params, existing, canaryResultAnnotation, canaryResultFailed); err != nil {
+			return err
+		}
+		if params.Recorder != nil {
+			params.Recorder.Event(&params.Instance, corev1.EventTypeWarning, "CanaryFailed", message)
+		}
+		return updateCanaryCondition(ctx, params, &metav1.Condition{
+			Status:  metav1.ConditionFalse,
+			Reason:  "CanaryFailed",
+			Message: message,
+		})
+	}
+
+	if !ready || time.Since(since) < collector.CanaryBakeDuration(params.Instance) {
+		// the instances using the canary rollout are reconciled periodically
+		return nil
+	}
+	if err := annotateCanary(ctx, params, existing, canaryResultAnnotation, canaryResultPromoted); err != nil {
+		return err
+	}
+	return updateCanaryCondition(ctx, params, &metav1.Condition{
+		Status:  metav1.ConditionFalse,
+		Reason:  "Promoted",
+		Message: fmt.Sprintf("the canary of revision %s was healthy for %s, the changes are promoted", revision, collector.CanaryBakeDuration(params.Instance)),
+	})
+}
+
+// canaryPromoted returns whether the collector update can be applied. When the instance uses the canary rollout and
+// the running collector differs from the desired one, the update holds until the canary of the desired one is promoted.
+func canaryPromoted(ctx context.Context, params Params) (bool, error) {
+	if !collector.UsesCanaryRollout(params.Instance) {
+		return true, nil
+	}
+
+	pending, err := collectorUpdatePending(ctx, params)
+	if err != nil || !pending {
+		return !pending, err
+	}
+
+	existing := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.CanaryCollector(params.Instance)}
+	if err := params.Client.Get(ctx, nns, existing); k8serrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get: %w", err)
+	}
+	return existing.Annotations[collector.CanaryRevisionAnnotation] == collector.CanaryRevision(params.Config, params.Instance) &&
+		existing.Annotations[canaryResultAnnotation] == canaryResultPromoted, nil
+}
+
+// expectedCanary creates or updates the canary ConfigMap and Deployment. The canary annotations of the previous
+// revision are removed when the revision changes.
+func expectedCanary(ctx context.Context, params Params, desired appsv1.Deployment, existing *appsv1.Deployment, sameRevision bool) error {
+	cm, err := collectorConfigMap(ctx, params)
+	if err != nil {
+		return err
+	}
+	cm.Name = desired.Name
+	cm.Labels["app.kubernetes.io/name"] = desired.Name
+	cm.Labels[collector.CanaryLabel] = "true"
+	cms, err := splitConfigMap(params, cm)
+	if err != nil {
+		return err
+	}
+	if err := expectedConfigMaps(ctx, params, cms); err != nil {
+		return err
+	}
+	// the parts of a larger config are left over when the config shrinks
+	if err := deleteCanaryConfigMaps(ctx, params, cms); err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if existing == nil {
+		if err := params.Client.Create(ctx, &desired); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "deployment.name", desired.Name, "deployment.namespace", desired.Namespace)
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	if !sameRevision {
+		delete(updated.Annotations, canaryBakingSinceAnnotation)
+		delete(updated.Annotations, canaryResultAnnotation)
+	}
+	for k, v := range desired.Annotations {
+		updated.Annotations[k] = v
+	}
+	updated.Labels = desired.Labels
+	updated.Spec = desired.Spec
+	updated.OwnerReferences = desired.OwnerReferences
+
+	if err := params.Client.Patch(ctx, updated, client.MergeFrom(existing)); err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+	params.Log.V(2).Info("applied", "deployment.name", desired.Name, "deployment.namespace", desired.Namespace)
+	return nil
+}
+
+// annotateCanary records the state of the canary on its Deployment. The failed canaries are scaled down.
+func annotateCanary(ctx context.Context, params Params, existing *appsv1.Deployment, key, value string) error {
+	updated := existing.DeepCopy()
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[key] = value
+	if key == canaryResultAnnotation && value == canaryResultFailed {
+		zero := int32(0)
+		updated.Spec.Replicas = &zero
+	}
+	if err := params.Client.Patch(ctx, updated, client.MergeFrom(existing)); err != nil {
+		return fmt.Errorf("failed to annotate the canary: %w", err)
+	}
+	return nil
+}
+
+// canaryFailure returns why the canary pods are unhealthy, if they are: one of their containers restarted, or they
+// failed to export more than the allowed percentage of their spans, metric points and log records. It also returns
+// whether all the canary pods are ready, the canary being promoted only while it has ready pods and none unready.
+func canaryFailure(ctx context.Context, params Params, selector map[string]string) (string, bool, error) {
+	pods := &corev1.PodList{}
+	if err := params.Client.List(ctx, pods, client.InNamespace(params.Instance.Namespace), client.MatchingLabels(selector)); err != nil {
+		return "", false, fmt.Errorf("failed to list the canary pods: %w", err)
+	}
+
+	ready := len(pods.Items) > 0
+	var sent, failed float64
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > 0 {
+				return fmt.Sprintf("the container %s of the pod %s restarted", status.Name, pod.Name), false, nil
+			}
+		}
+		if !podReady(pod) {
+			ready = false
+		}
+		if params.HTTPClient == nil || pod.Status.PodIP == "" {
+			continue
+		}
+		podSent, podFailed, err := collector.ExportCounts(ctx, params.HTTPClient, collector.MetricsURL(pod))
+		if err != nil {
+			// the operator might not be allowed to reach the pods, which shouldn't hold the rollouts
+			params.Log.V(2).Info("ignoring the export failures of the canary pod", "pod", pod.Name, "reason", err.Error())
+			continue
+		}
+		sent += podSent
+		failed += podFailed
+	}
+
+	maxPercent := float64(collector.CanaryMaxExportFailurePercent(params.Instance))
+	if failed > 0 && failed*100 > maxPercent*(sent+failed) {
+		return fmt.Sprintf("%.1f%% of the exports failed, more than the allowed %.0f%%", failed*100/(sent+failed), maxPercent), false, nil
+	}
+	return "", ready, nil
+}
+
+// podReady returns whether the pod has the Ready condition.
+func podReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// deleteCanary deletes the canary Deployment and ConfigMaps, if they exist.
+func deleteCanary(ctx context.Context, params Params) error {
+	existing := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.CanaryCollector(params.Instance)}
+	if err := params.Client.Get(ctx, nns, existing); err == nil {
+		if err := params.Client.Delete(ctx, existing); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "canary.name", nns.Name, "canary.namespace", nns.Namespace)
+	} else if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	return deleteCanaryConfigMaps(ctx, params, nil)
+}
+
+// deleteCanaryConfigMaps deletes the canary ConfigMaps of the instance, other than the expected ones. They're
+// selected by their labels, to find the parts of the previous configs too.
+func deleteCanaryConfigMaps(ctx context.Context, params Params, expected []corev1.ConfigMap) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			collector.CanaryLabel:          "true",
+		}),
+	}
+	list := &corev1.ConfigMapList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "configmap.name", existing.Name, "configmap.namespace", existing.Namespace)
+		}
+	}
+	return nil
+}
+
+// updateCanaryCondition sets the CanaryRollout condition of the instance, or removes it when nil.
+func updateCanaryCondition(ctx context.Context, params Params, condition *metav1.Condition) error {
+	existing := meta.FindStatusCondition(params.Instance.Status.Conditions, v1alpha1.ConditionTypeCanaryRollout)
+	if condition == nil && existing == nil {
+		return nil
+	}
+	if condition != nil && existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+
+	changed := params.Instance.DeepCopy()
+	if condition == nil {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeCanaryRollout)
+	} else {
+		condition.Type = v1alpha1.ConditionTypeCanaryRollout
+		meta.SetStatusCondition(&changed.Status.Conditions, *condition)
+	}
+
+	statusPatch := client.MergeFrom(&params.Instance)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+	}
+	return nil
+}
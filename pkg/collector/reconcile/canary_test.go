@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// canaryParams returns the params of a persisted instance using the canary rollout, with a collector Deployment
+// running a previous image.
+func canaryParams(t *testing.T, name string) Params {
+	param := params()
+	param.Instance.Name = name
+	param.Instance.UID = ""
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.Canary = &v1alpha1.CanarySpec{BakeDuration: &metav1.Duration{}}
+	require.NoError(t, k8sClient.Create(context.Background(), &param.Instance))
+
+	deploy := collector.Deployment(param.Config, logger, param.Instance)
+	deploy.Spec.Template.Spec.Containers[0].Image = "previous-image"
+	require.NoError(t, k8sClient.Create(context.Background(), &deploy))
+	t.Cleanup(func() {
+		assert.NoError(t, deleteCanary(context.Background(), param))
+		assert.NoError(t, k8sClient.Delete(context.Background(), &deploy))
+		assert.NoError(t, k8sClient.Delete(context.Background(), &param.Instance))
+	})
+	return param
+}
+
+// rollOutCanary sets the status of the canary Deployment the deployment controller would set once it rolled out.
+func rollOutCanary(t *testing.T, nns types.NamespacedName) {
+	canary := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), nns, canary))
+	canary.Annotations[collector.DeploymentRevisionAnnotation] = "1"
+	require.NoError(t, k8sClient.Update(context.Background(), canary))
+	canary.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: canary.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		ReadyReplicas:      1,
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentProgressing,
+			Status: corev1.ConditionTrue,
+			Reason: "NewReplicaSetAvailable",
+		}},
+	}
+	require.NoError(t, k8sClient.Status().Update(context.Background(), canary))
+}
+
+// createCanaryPod creates a pod of the canary Deployment with the given status.
+func createCanaryPod(t *testing.T, nns types.NamespacedName, name string, status corev1.PodStatus) {
+	canary := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), nns, canary))
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: nns.Namespace,
+			Labels:    canary.Spec.Template.Labels,
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "otc-container", Image: "otel"}}},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &pod))
+	t.Cleanup(func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &pod))
+	})
+	pod.Status = status
+	require.NoError(t, k8sClient.Status().Update(context.Background(), &pod))
+}
+
+func getInstance(t *testing.T, param Params) *v1alpha1.OpenTelemetryCollector {
+	instance := &v1alpha1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: param.Instance.Namespace, Name: param.Instance.Name}, instance))
+	return instance
+}
+
+func getCanaryCondition(t *testing.T, param Params) *metav1.Condition {
+	return meta.FindStatusCondition(getInstance(t, param).Status.Conditions, v1alpha1.ConditionTypeCanaryRollout)
+}
+
+func TestCanaries(t *testing.T) {
+	param := canaryParams(t, "canary")
+	nns := types.NamespacedName{Namespace: "default", Name: naming.CanaryCollector(param.Instance)}
+
+	t.Run("should create the canary and hold the update", func(t *testing.T) {
+		require.NoError(t, Canaries(context.Background(), param))
+
+		canary := &appsv1.Deployment{}
+		exists, err := populateObjectIfExists(t, canary, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, collector.CanaryRevision(param.Config, param.Instance), canary.Annotations[collector.CanaryRevisionAnnotation])
+		exists, err = populateObjectIfExists(t, &corev1.ConfigMap{}, nns)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		promoted, err := canaryPromoted(context.Background(), param)
+		require.NoError(t, err)
+		assert.False(t, promoted)
+
+		condition := getCanaryCondition(t, param)
+		require.NotNil(t, condition)
+		assert.Equal(t, "RollingOut", condition.Reason)
+	})
+
+	t.Run("should promote the healthy canary after its bake duration", func(t *testing.T) {
+		rollOutCanary(t, nns)
+		param.Instance = *getInstance(t, param)
+
+		// the canary starts baking once ready
+		require.NoError(t, Canaries(context.Background(), param))
+		param.Instance = *getInstance(t, param)
+
+		// the canary isn't promoted until its pods are observed ready
+		require.NoError(t, Canaries(context.Background(), param))
+		promoted, err := canaryPromoted(context.Background(), param)
+		require.NoError(t, err)
+		assert.False(t, promoted)
+		createCanaryPod(t, nns, "canary-pod", corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		})
+		require.NoError(t, Canaries(context.Background(), param))
+
+		promoted, err = canaryPromoted(context.Background(), param)
+		require.NoError(t, err)
+		assert.True(t, promoted)
+
+		condition := getCanaryCondition(t, param)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "Promoted", condition.Reason)
+	})
+
+	t.Run("should delete the canary once the update is applied", func(t *testing.T) {
+		collectorDeployment := &appsv1.Deployment{}
+		require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: naming.Collector(param.Instance)}, collectorDeployment))
+		collectorDeployment.Spec.Template.Spec.Containers[0].Image = collector.Image(param.Config, param.Instance)
+		require.NoError(t, k8sClient.Update(context.Background(), collectorDeployment))
+
+		// a part of a previous config, split across more ConfigMaps
+		stalePart := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.ConfigMapPart(nns.Name, 3),
+				Namespace: nns.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "default.canary",
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					collector.CanaryLabel:          "true",
+				},
+			},
+		}
+		require.NoError(t, k8sClient.Create(context.Background(), &stalePart))
+
+		require.NoError(t, Canaries(context.Background(), param))
+
+		exists, err := populateObjectIfExists(t, &appsv1.Deployment{}, nns)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = populateObjectIfExists(t, &corev1.ConfigMap{}, nns)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = populateObjectIfExists(t, &corev1.ConfigMap{}, client.ObjectKeyFromObject(&stalePart))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestCanariesFailure(t *testing.T) {
+	param := canaryParams(t, "canary-failure")
+	nns := types.NamespacedName{Namespace: "default", Name: naming.CanaryCollector(param.Instance)}
+	require.NoError(t, Canaries(context.Background(), param))
+	rollOutCanary(t, nns)
+	require.NoError(t, Canaries(context.Background(), param))
+
+	createCanaryPod(t, nns, "canary-failure-pod", corev1.PodStatus{
+		ContainerStatuses: []corev1.ContainerStatus{{Name: "otc-container", RestartCount: 1}},
+	})
+
+	// test
+	param.Instance = *getInstance(t, param)
+	require.NoError(t, Canaries(context.Background(), param))
+
+	// verify
+	canary := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(context.Background(), nns, canary))
+	assert.Equal(t, int32(0), *canary.Spec.Replicas)
+	promoted, err := canaryPromoted(context.Background(), param)
+	require.NoError(t, err)
+	assert.False(t, promoted)
+
+	condition := getCanaryCondition(t, param)
+	require.NotNil(t, condition)
+	assert.Equal(t, "CanaryFailed", condition.Reason)
+	assert.Contains(t, condition.Message, "the container otc-container of the pod canary-failure-pod restarted")
+
+	// the failed changes aren't rolled out again
+	require.NoError(t, Canaries(context.Background(), param))
+	require.NoError(t, k8sClient.Get(context.Background(), nns, canary))
+	assert.Equal(t, int32(0), *canary.Spec.Replicas)
+}
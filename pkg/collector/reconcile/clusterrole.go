@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+
+// ClusterRoles reconciles the cluster role required by the components of the instance reading from the Kubernetes API.
+func ClusterRoles(ctx context.Context, params Params) error {
+	desired := desiredClusterRoles(params)
+
+	// first, handle the create/update parts
+	if err := expectedClusterRoles(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected cluster roles: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteClusterRoles(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the cluster roles to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// ClusterRoleBindings reconciles the cluster role binding of the collector's cluster role to its service account.
+func ClusterRoleBindings(ctx context.Context, params Params) error {
+	desired := desiredClusterRoleBindings(params)
+
+	// first, handle the create/update parts
+	if err := expectedClusterRoleBindings(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected cluster role bindings: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteClusterRoleBindings(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the cluster role bindings to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteClusterRBAC deletes the cluster role and cluster role binding of the instance. Being cluster scoped, they
+// can't be owned by the instance and aren't garbage collected along with it.
+func DeleteClusterRBAC(ctx context.Context, params Params) error {
+	if err := deleteClusterRoleBindings(ctx, params, nil); err != nil {
+		return fmt.Errorf("failed to delete the cluster role bindings: %w", err)
+	}
+	if err := deleteClusterRoles(ctx, params, nil); err != nil {
+		return fmt.Errorf("failed to delete the cluster roles: %w", err)
+	}
+	return nil
+}
+
+func desiredClusterRoles(params Params) []rbacv1.ClusterRole {
+	if !params.Instance.Spec.RBAC.ClusterRole || params.Instance.Spec.Mode == v1alpha1.ModeSidecar ||
+		!featuregate.Gates.Enabled(featuregate.CollectorClusterRoles) {
+		return []rbacv1.ClusterRole{}
+	}
+	role := collector.ClusterRole(params.Config, params.Log, params.Instance)
+	if len(role.Rules) == 0 {
+		return []rbacv1.ClusterRole{}
+	}
+	return []rbacv1.ClusterRole{role}
+}
+
+func desiredClusterRoleBindings(params Params) []rbacv1.ClusterRoleBinding {
+	if len(desiredClusterRoles(params)) == 0 {
+		return []rbacv1.ClusterRoleBinding{}
+	}
+	return []rbacv1.ClusterRoleBinding{collector.ClusterRoleBinding(params.Config, params.Instance)}
+}
+
+func expectedClusterRoles(ctx context.Context, params Params, expected []rbacv1.ClusterRole) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "clusterrole.name", desired.Name)
+	}
+
+	return nil
+}
+
+func expectedClusterRoleBindings(ctx context.Context, params Params, expected []rbacv1.ClusterRoleBinding) error {
+	for _, obj := range expected {
+		desired := obj
+
+		existing := &rbacv1.ClusterRoleBinding{}
+		err := params.Client.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := apply(ctx, params, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "clusterrolebinding.name", desired.Name)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		if existing.RoleRef != desired.RoleRef {
+			// the role reference of a binding is immutable, so it has to be recreated
+			if err := params.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			if err := apply(ctx, params, &desired); err != nil {
+				return fmt.Errorf("failed to create: %w", err)
+			}
+			params.Log.V(2).Info("recreated", "clusterrolebinding.name", desired.Name)
+			continue
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "clusterrolebinding.name", desired.Name)
+	}
+
+	return nil
+}
+
+// clusterListOptions selects the cluster scoped resources created for the instance.
+func clusterListOptions(params Params) []client.ListOption {
+	return []client.ListOption{
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+}
+
+func deleteClusterRoles(ctx context.Context, params Params, expected []rbacv1.ClusterRole) error {
+	list := &rbacv1.ClusterRoleList{}
+	if err := params.Client.List(ctx, list, clusterListOptions(params)...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "clusterrole.name", existing.Name)
+		}
+	}
+
+	return nil
+}
+
+func deleteClusterRoleBindings(ctx context.Context, params Params, expected []rbacv1.ClusterRoleBinding) error {
+	list := &rbacv1.ClusterRoleBindingList{}
+	if err := params.Client.List(ctx, list, clusterListOptions(params)...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "clusterrolebinding.name", existing.Name)
+		}
+	}
+
+	return nil
+}
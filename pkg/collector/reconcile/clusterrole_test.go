@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const kubeletStatsConfig = `receivers:
+  kubeletstats:
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats]
+      exporters: [logging]
+`
+
+func enableClusterRoles(t *testing.T) {
+	require.NoError(t, featuregate.Gates.Set("CollectorClusterRoles=true"))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.Gates.Set("CollectorClusterRoles=false"))
+	})
+}
+
+func TestDesiredClusterRoles(t *testing.T) {
+	enableClusterRoles(t)
+
+	t.Run("should not create a cluster role unless enabled", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Config = kubeletStatsConfig
+
+		assert.Empty(t, desiredClusterRoles(p))
+		assert.Empty(t, desiredClusterRoleBindings(p))
+	})
+
+	t.Run("should not create a cluster role without kubernetes components", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.RBAC.ClusterRole = true
+
+		assert.Empty(t, desiredClusterRoles(p))
+		assert.Empty(t, desiredClusterRoleBindings(p))
+	})
+
+	t.Run("should not create a cluster role for sidecars", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Config = kubeletStatsConfig
+		p.Instance.Spec.Mode = v1alpha1.ModeSidecar
+		p.Instance.Spec.RBAC.ClusterRole = true
+
+		assert.Empty(t, desiredClusterRoles(p))
+	})
+
+	t.Run("should create a cluster role for the kubernetes components", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Config = kubeletStatsConfig
+		p.Instance.Spec.RBAC.ClusterRole = true
+
+		roles := desiredClusterRoles(p)
+		require.Len(t, roles, 1)
+		assert.Equal(t, collector.ClusterRoleRules(p.Log, p.Instance), roles[0].Rules)
+		assert.Len(t, desiredClusterRoleBindings(p), 1)
+	})
+
+	t.Run("should not create a cluster role with the feature gate disabled", func(t *testing.T) {
+		require.NoError(t, featuregate.Gates.Set("CollectorClusterRoles=false"))
+		defer func() {
+			require.NoError(t, featuregate.Gates.Set("CollectorClusterRoles=true"))
+		}()
+		p := params()
+		p.Instance.Spec.Config = kubeletStatsConfig
+		p.Instance.Spec.RBAC.ClusterRole = true
+
+		assert.Empty(t, desiredClusterRoles(p))
+	})
+}
+
+func TestClusterRoles(t *testing.T) {
+	enableClusterRoles(t)
+	p := params()
+	p.Instance.Spec.Config = kubeletStatsConfig
+	p.Instance.Spec.RBAC.ClusterRole = true
+	nns := types.NamespacedName{Name: naming.ClusterRole(p.Instance)}
+
+	t.Run("should create the cluster role and its binding", func(t *testing.T) {
+		require.NoError(t, ClusterRoles(context.Background(), p))
+		require.NoError(t, ClusterRoleBindings(context.Background(), p))
+
+		actual := rbacv1.ClusterRole{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Empty(t, actual.OwnerReferences)
+
+		binding := rbacv1.ClusterRoleBinding{}
+		exists, err = populateObjectIfExists(t, &binding, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, collector.ServiceAccountName(p.Instance), binding.Subjects[0].Name)
+	})
+
+	t.Run("should delete the cluster role and its binding", func(t *testing.T) {
+		require.NoError(t, DeleteClusterRBAC(context.Background(), p))
+
+		exists, err := populateObjectIfExists(t, &rbacv1.ClusterRole{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = populateObjectIfExists(t, &rbacv1.ClusterRoleBinding{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// ConfigComponents checks the components of the config against the distribution of the collector image, and reports
+// the components the image misses with the UnsupportedComponents condition. The rollout of the collector workload is
+// held by the other tasks meanwhile, so that the collector isn't rolled out to crash on its config.
+func ConfigComponents(ctx context.Context, params Params) error {
+	missing, message := missingComponents(params)
+
+	existing := meta.FindStatusCondition(params.Instance.Status.Conditions, v1alpha1.ConditionTypeUnsupportedComponents)
+	if len(missing) == 0 && existing == nil {
+		return nil
+	}
+	if existing != nil && existing.Message == message {
+		return nil
+	}
+
+	changed := params.Instance.DeepCopy()
+	if len(missing) == 0 {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeUnsupportedComponents)
+	} else {
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ConditionTypeUnsupportedComponents,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ComponentsMissing",
+			Message: message,
+		})
+	}
+	statusPatch := client.MergeFrom(&params.Instance)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+	}
+	return nil
+}
+
+// componentsSupported returns whether the distribution of the collector image has the components of the config, the
+// rollout of the collector being held until it does.
+func componentsSupported(params Params) bool {
+	missing, _ := missingComponents(params)
+	return len(missing) == 0
+}
+
+// missingComponents returns the components of the config missing from the distribution of the collector image, with
+// the message reporting them. Nothing is missing when the distribution of the image isn't in the registry.
+func missingComponents(params Params) ([]string, string) {
+	image := collector.Image(params.Config, params.Instance)
+	distribution, version, components, ok := params.Config.ComponentRegistry().Lookup(image)
+	if !ok {
+		params.Log.V(2).Info("the distribution of the image isn't in the component registry, skipping the components check", "image", image)
+		return nil, ""
+	}
+
+	cfg, err := adapters.ConfigFromString(params.Instance.Spec.Config)
+	if err != nil {
+		params.Log.V(2).Info("failed to parse the config, skipping the components check", "error", err)
+		return nil, ""
+	}
+	missing := components.Missing(cfg)
+	if len(missing) == 0 {
+		return nil, ""
+	}
+	return missing, fmt.Sprintf("the %s distribution %s of the image %s doesn't have the %s", distribution.Name, version, image, strings.Join(missing, ", "))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/components"
+)
+
+func TestConfigComponents(t *testing.T) {
+	instance := params().Instance
+	instance.ObjectMeta = metav1.ObjectMeta{Name: "test-components", Namespace: "default"}
+	instance.Spec.Image = "otel/opentelemetry-collector:0.66.0"
+	require.NoError(t, k8sClient.Create(context.Background(), &instance))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &instance))
+	}()
+	nsn := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	cfg := config.New(config.WithComponentRegistry(components.Default()))
+
+	t.Run("should report the components missing from the image", func(t *testing.T) {
+		p := params()
+		p.Config = cfg
+		p.Instance = instance
+		p.Instance.Spec.Config = `receivers:
+  filelog:
+exporters:
+  otlp:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [otlp]
+`
+		assert.NoError(t, ConfigComponents(context.Background(), p))
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &actual))
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeUnsupportedComponents)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "the core distribution 0.66.0 of the image otel/opentelemetry-collector:0.66.0 doesn't have the receiver 'filelog'", condition.Message)
+
+		// only the rollout of the collector is held
+		assert.False(t, componentsSupported(p))
+	})
+
+	t.Run("should remove the condition once the components are supported", func(t *testing.T) {
+		p := params()
+		p.Config = cfg
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &p.Instance))
+		p.Instance.Spec.Config = `receivers:
+  otlp:
+exporters:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+		assert.NoError(t, ConfigComponents(context.Background(), p))
+		assert.True(t, componentsSupported(p))
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &actual))
+		assert.Nil(t, meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeUnsupportedComponents))
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// configChecksumAnnotation is the pod template annotation holding the checksum of the collector's ConfigMap, so that
+// any change of the ConfigMap rolls out the collector pods.
+const configChecksumAnnotation = "checksum/config"
+
+// setConfigChecksum stamps the checksum of the collector's ConfigMap on the pod template, unless the running
+// collectors reload their config. The ConfigMap is reconciled before the workloads, and its updates trigger a new
+// reconciliation, so the checksum catches up with the ConfigMap.
+func setConfigChecksum(ctx context.Context, params Params, template *corev1.PodTemplateSpec) error {
+	if collector.ConfigReloads(params.Instance) {
+		return nil
+	}
+
+	// the config is split across several ConfigMaps when it's too large for a single one
+	var cms []corev1.ConfigMap
+	for i := 0; i < collector.ConfigParts(params.Instance); i++ {
+		cm := corev1.ConfigMap{}
+		nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.ConfigMapPart(naming.ConfigMap(params.Instance), i)}
+		if err := params.Client.Get(ctx, nns, &cm); k8serrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get the config map: %w", err)
+		}
+		cms = append(cms, cm)
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[configChecksumAnnotation] = configMapChecksum(cms...)
+	return nil
+}
+
+// configMapChecksum returns the SHA-256 checksum of the data of the ConfigMaps, in the order of their keys.
+func configMapChecksum(cms ...corev1.ConfigMap) string {
+	h := sha256.New()
+	for _, cm := range cms {
+		keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+		for k := range cm.Data {
+			keys = append(keys, k)
+		}
+		for k := range cm.BinaryData {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write([]byte{0})
+			if v, ok := cm.Data[k]; ok {
+				h.Write([]byte(v))
+			} else {
+				h.Write(cm.BinaryData[k])
+			}
+			h.Write([]byte{0})
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+func TestSetConfigChecksum(t *testing.T) {
+	param := params()
+	param.Instance.Name = "checksum"
+
+	t.Run("should skip the checksum without a config map", func(t *testing.T) {
+		template := corev1.PodTemplateSpec{}
+		require.NoError(t, setConfigChecksum(context.Background(), param, &template))
+		assert.NotContains(t, template.Annotations, configChecksumAnnotation)
+	})
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ConfigMap(param.Instance),
+			Namespace: param.Instance.Namespace,
+		},
+		Data: map[string]string{"collector.yaml": "receivers: {}\n"},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), cm))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), cm))
+	}()
+
+	var checksum string
+	t.Run("should stamp the checksum of the config map", func(t *testing.T) {
+		template := corev1.PodTemplateSpec{}
+		require.NoError(t, setConfigChecksum(context.Background(), param, &template))
+		checksum = template.Annotations[configChecksumAnnotation]
+		assert.Equal(t, configMapChecksum(*cm), checksum)
+	})
+
+	t.Run("should change the checksum with the config map", func(t *testing.T) {
+		cm.Data["collector.yaml"] = "receivers: {otlp: {}}\n"
+		require.NoError(t, k8sClient.Update(context.Background(), cm))
+
+		template := corev1.PodTemplateSpec{}
+		require.NoError(t, setConfigChecksum(context.Background(), param, &template))
+		assert.NotEmpty(t, template.Annotations[configChecksumAnnotation])
+		assert.NotEqual(t, checksum, template.Annotations[configChecksumAnnotation])
+	})
+
+	t.Run("should skip the checksum when the config is reloaded", func(t *testing.T) {
+		reload := param
+		reload.Instance.Spec.ConfigReloadStrategy = v1alpha1.ConfigReloadStrategyReload
+
+		template := corev1.PodTemplateSpec{}
+		require.NoError(t, setConfigChecksum(context.Background(), reload, &template))
+		assert.NotContains(t, template.Annotations, configChecksumAnnotation)
+	})
+}
+
+func TestConfigMapChecksum(t *testing.T) {
+	a := corev1.ConfigMap{Data: map[string]string{"a": "1", "b": "2"}}
+	b := corev1.ConfigMap{Data: map[string]string{"b": "2", "a": "1"}}
+	c := corev1.ConfigMap{Data: map[string]string{"a": "12"}}
+	d := corev1.ConfigMap{Data: map[string]string{"a": "1"}, BinaryData: map[string][]byte{"b": []byte("2")}}
+
+	assert.Equal(t, configMapChecksum(a), configMapChecksum(b))
+	assert.NotEqual(t, configMapChecksum(a), configMapChecksum(c))
+	assert.Equal(t, configMapChecksum(a), configMapChecksum(d))
+
+	// the parts of a split config
+	e := corev1.ConfigMap{Data: map[string]string{"c": "3"}}
+	assert.NotEqual(t, configMapChecksum(a), configMapChecksum(a, e))
+	assert.Equal(t, configMapChecksum(a, e), configMapChecksum(b, e))
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EncryptedValuePrefix marks the config values holding a base64 encoded AES-256-GCM ciphertext.
+const EncryptedValuePrefix = "enc:"
+
+// encryptionKeySize is the size of an AES-256 key.
+const encryptionKeySize = 32
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// encryptionKey reads the AES-256 key referenced by the instance. The Secret value can either be the raw
+// 32 bytes key or its base64 encoding.
+func encryptionKey(ctx context.Context, params Params) ([]byte, error) {
+	ref := params.Instance.Spec.EncryptionKeyRef
+	secret := &corev1.Secret{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: ref.Name}
+	if err := params.Client.Get(ctx, nns, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the encryption key secret: %w", err)
+	}
+
+	key, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("the encryption key secret %s has no key %s", ref.Name, ref.Key)
+	}
+	if len(key) == encryptionKeySize {
+		return key, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(key)))
+	if err != nil || len(decoded) != encryptionKeySize {
+		return nil, fmt.Errorf("the encryption key in secret %s must be %d bytes long", ref.Name, encryptionKeySize)
+	}
+	return decoded, nil
+}
+
+// DecryptConfig replaces the values of the config prefixed with "enc:" by their plaintext.
+func DecryptConfig(config string, key []byte) (string, error) {
+	if !strings.Contains(config, EncryptedValuePrefix) {
+		return config, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the cipher: %w", err)
+	}
+
+	var cfg interface{}
+	if err := yaml.Unmarshal([]byte(config), &cfg); err != nil {
+		return "", fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	decrypted, err := decryptValues(gcm, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(decrypted)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func decryptValues(gcm cipher.AEAD, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for k, item := range v {
+			decrypted, err := decryptValues(gcm, item)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			v[k] = decrypted
+		}
+	case []interface{}:
+		for i, item := range v {
+			decrypted, err := decryptValues(gcm, item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = decrypted
+		}
+	case string:
+		if !strings.HasPrefix(v, EncryptedValuePrefix) {
+			return v, nil
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(v, EncryptedValuePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the encrypted value: %w", err)
+		}
+		if len(data) < gcm.NonceSize() {
+			return nil, fmt.Errorf("the encrypted value is too short")
+		}
+		plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the value: %w", err)
+		}
+		return string(plaintext), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func encrypt(t *testing.T, key []byte, plaintext string) string {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	return EncryptedValuePrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil))
+}
+
+func TestDecryptConfig(t *testing.T) {
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	t.Run("should return the config unchanged without encrypted values", func(t *testing.T) {
+		config := "receivers:\n  otlp: {}\n"
+		actual, err := DecryptConfig(config, key)
+		assert.NoError(t, err)
+		assert.Equal(t, config, actual)
+	})
+
+	t.Run("should decrypt the encrypted values", func(t *testing.T) {
+		config := `exporters:
+  otlp:
+    headers:
+      api-key: ` + encrypt(t, key, "my-secret-key") + `
+    endpoints:
+      - ` + encrypt(t, key, "collector:4317") + `
+`
+		actual, err := DecryptConfig(config, key)
+		require.NoError(t, err)
+
+		var cfg map[string]map[string]map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(actual), &cfg))
+		assert.Equal(t, map[interface{}]interface{}{"api-key": "my-secret-key"}, cfg["exporters"]["otlp"]["headers"])
+		assert.Equal(t, []interface{}{"collector:4317"}, cfg["exporters"]["otlp"]["endpoints"])
+	})
+
+	t.Run("should fail with the wrong key", func(t *testing.T) {
+		otherKey := make([]byte, encryptionKeySize)
+		config := "exporters:\n  otlp:\n    api-key: " + encrypt(t, otherKey, "my-secret-key") + "\n"
+		_, err := DecryptConfig(config, key)
+		assert.ErrorContains(t, err, "failed to decrypt the value")
+	})
+}
+
+func TestEncryptionKey(t *testing.T) {
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "encryption-key",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"raw":     key,
+			"encoded": []byte(base64.StdEncoding.EncodeToString(key)),
+			"short":   []byte("too-short"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), secret))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), secret))
+	}()
+
+	for _, tt := range []struct {
+		key         string
+		expectedErr string
+	}{
+		{key: "raw"},
+		{key: "encoded"},
+		{key: "short", expectedErr: "must be 32 bytes long"},
+		{key: "missing", expectedErr: "has no key missing"},
+	} {
+		t.Run(tt.key, func(t *testing.T) {
+			p := params()
+			p.Instance.Spec.EncryptionKeyRef = &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secret.Name},
+				Key:                  tt.key,
+			}
+
+			actual, err := encryptionKey(context.Background(), p)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, key, actual)
+		})
+	}
+}
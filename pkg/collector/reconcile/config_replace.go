@@ -17,16 +17,16 @@ package reconcile
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
-	"github.com/mitchellh/mapstructure"
 	promconfig "github.com/prometheus/prometheus/config"
-	"github.com/prometheus/prometheus/discovery"
-	"github.com/prometheus/prometheus/discovery/http"
-	_ "github.com/prometheus/prometheus/discovery/install" // Package install has the side-effect of registering all builtin.
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -34,49 +34,77 @@ type Config struct {
 	PromConfig *promconfig.Config `yaml:"config"`
 }
 
+// ReplaceConfig returns the config of the instance with the scrape configs of its prometheus receiver discovering the
+// targets served by its target allocator, either the embedded one or the referenced TargetAllocator.
 func ReplaceConfig(params Params) (string, error) {
+	if params.TargetAllocator != nil {
+		promCfgMap, err := adapters.ConfigFromString(params.TargetAllocator.Spec.Config)
+		if err != nil {
+			return "", fmt.Errorf("the config of the target allocator %s is incorrect: %w", params.TargetAllocator.Name, err)
+		}
+		// the config of the target allocator is a plain Prometheus config, whose dollar signs the collector would expand
+		return replaceScrapeConfigs(params.Instance.Spec.Config, ta.EscapeDollarSigns(promCfgMap), naming.StandaloneTAService(params.TargetAllocator.Name), false)
+	}
 	if !params.Instance.Spec.TargetAllocator.Enabled {
 		return params.Instance.Spec.Config, nil
 	}
-	config, getStringErr := adapters.ConfigFromString(params.Instance.Spec.Config)
-	if getStringErr != nil {
-		return "", getStringErr
-	}
 
 	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(params.Instance.Spec.Config)
 	if getCfgPromErr != nil {
 		return "", getCfgPromErr
 	}
+	return replaceScrapeConfigs(params.Instance.Spec.Config, promCfgMap, naming.TAService(params.Instance), targetallocator.UsesMTLS(params.Instance))
+}
 
-	// yaml marshaling/unsmarshaling is preferred because of the problems associated with the conversion of map to a struct using mapstructure
-	promCfg, marshalErr := yaml.Marshal(map[string]interface{}{
-		"config": promCfgMap,
-	})
-	if marshalErr != nil {
-		return "", marshalErr
+// replaceScrapeConfigs sets the given Prometheus config as the one of the prometheus receiver, with the service
+// discovery of each job querying the targets assigned by the target allocator behind the service. The other settings
+// are kept as written, and the global settings of the receiver, e.g. its external_labels, take precedence over the ones
+// of the given config.
+func replaceScrapeConfigs(collectorConfig string, promCfgMap map[interface{}]interface{}, service string, mtls bool) (string, error) {
+	config, getStringErr := adapters.ConfigFromString(collectorConfig)
+	if getStringErr != nil {
+		return "", getStringErr
 	}
-
-	var cfg Config
-	if marshalErr = yaml.UnmarshalStrict(promCfg, &cfg); marshalErr != nil {
-		return "", fmt.Errorf("error unmarshaling YAML: %w", marshalErr)
+	prometheus, err := prometheusReceiver(config)
+	if err != nil {
+		return "", err
+	}
+	if err := ta.ValidatePromConfig(promCfgMap); err != nil {
+		return "", err
 	}
 
-	for i := range cfg.PromConfig.ScrapeConfigs {
-		escapedJob := url.QueryEscape(cfg.PromConfig.ScrapeConfigs[i].JobName)
-		cfg.PromConfig.ScrapeConfigs[i].ServiceDiscoveryConfigs = discovery.Configs{
-			&http.SDConfig{
-				URL: fmt.Sprintf("http://%s:80/jobs/%s/targets?collector_id=$POD_NAME", naming.TAService(params.Instance), escapedJob),
-			},
+	updated := make(map[interface{}]interface{}, len(promCfgMap))
+	for k, v := range promCfgMap {
+		updated[k] = v
+	}
+	if current, ok := prometheus["config"].(map[interface{}]interface{}); ok {
+		if global := mergeGlobal(promCfgMap["global"], current["global"]); global != nil {
+			updated["global"] = global
 		}
 	}
 
-	updPromCfgMap := make(map[string]interface{})
-	if err := mapstructure.Decode(cfg, &updPromCfgMap); err != nil {
-		return "", err
+	scrapeConfigs, _ := promCfgMap["scrape_configs"].([]interface{})
+	replaced := make([]interface{}, 0, len(scrapeConfigs))
+	for _, sc := range scrapeConfigs {
+		scrapeConfig, ok := sc.(map[interface{}]interface{})
+		if !ok {
+			return "", fmt.Errorf("the scrape config %v of the prometheus config isn't a map", sc)
+		}
+		rewritten := make(map[interface{}]interface{}, len(scrapeConfig))
+		for k, v := range scrapeConfig {
+			// the targets are discovered by the target allocator
+			if key, ok := k.(string); ok && (key == "static_configs" || strings.HasSuffix(key, "_sd_configs")) {
+				continue
+			}
+			rewritten[k] = v
+		}
+		// the target allocator reads the job names without the escaping of their dollar signs
+		jobName, _ := scrapeConfig["job_name"].(string)
+		rewritten["http_sd_configs"] = []interface{}{httpSDConfig(service, strings.ReplaceAll(jobName, "$$", "$"), mtls)}
+		replaced = append(replaced, rewritten)
 	}
-
-	// type coercion checks are handled in the ConfigToPromConfig method above
-	config["receivers"].(map[interface{}]interface{})["prometheus"].(map[interface{}]interface{})["config"] = updPromCfgMap["PromConfig"]
+	updated["scrape_configs"] = replaced
+	prometheus["config"] = updated
 
 	out, err := yaml.Marshal(config)
 	if err != nil {
@@ -84,3 +112,65 @@ func ReplaceConfig(params Params) (string, error) {
 	}
 	return string(out), nil
 }
+
+// httpSDConfig returns the HTTP service discovery config of a job, querying the targets the target allocator behind
+// the service assigns to the collector pod.
+func httpSDConfig(service, jobName string, mtls bool) map[interface{}]interface{} {
+	escapedJob := url.QueryEscape(jobName)
+	if !mtls {
+		return map[interface{}]interface{}{
+			"url": fmt.Sprintf("http://%s:80/jobs/%s/targets?collector_id=$POD_NAME", service, escapedJob),
+		}
+	}
+	return map[interface{}]interface{}{
+		"url": fmt.Sprintf("https://%s:443/jobs/%s/targets?collector_id=$POD_NAME", service, escapedJob),
+		"tls_config": map[interface{}]interface{}{
+			"ca_file":   fmt.Sprintf("%s/%s", collector.TargetAllocatorTLSMountPath, targetallocator.TLSCAKey),
+			"cert_file": fmt.Sprintf("%s/%s", collector.TargetAllocatorTLSMountPath, corev1.TLSCertKey),
+			"key_file":  fmt.Sprintf("%s/%s", collector.TargetAllocatorTLSMountPath, corev1.TLSPrivateKeyKey),
+		},
+	}
+}
+
+// mergeGlobal returns the global settings of the given Prometheus config, overridden by the ones of the receiver.
+func mergeGlobal(global, receiverGlobal interface{}) interface{} {
+	override, ok := receiverGlobal.(map[interface{}]interface{})
+	if !ok {
+		return global
+	}
+	base, ok := global.(map[interface{}]interface{})
+	if !ok {
+		return override
+	}
+	merged := make(map[interface{}]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// prometheusReceiver returns the prometheus receiver of the collector config, which is added to the receivers map when
+// it has no settings.
+func prometheusReceiver(config map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no receivers available as part of the configuration")
+	}
+	property, ok := receivers["prometheus"]
+	if !ok {
+		return nil, fmt.Errorf("no prometheus available as part of the configuration")
+	}
+	if property == nil {
+		prometheus := map[interface{}]interface{}{}
+		receivers["prometheus"] = prometheus
+		return prometheus, nil
+	}
+	prometheus, ok := property.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("prometheus property in the configuration doesn't contain valid prometheus")
+	}
+	return prometheus, nil
+}
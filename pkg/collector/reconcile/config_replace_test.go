@@ -15,12 +15,17 @@
 package reconcile
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/prometheus/prometheus/discovery/http"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
 )
 
@@ -61,6 +66,36 @@ func TestPrometheusParser(t *testing.T) {
 		}
 	})
 
+	t.Run("should use the target allocator certificates with mTLS", func(t *testing.T) {
+		param.Instance.Spec.TargetAllocator.MTLS = &v1alpha1.TargetAllocatorMTLS{}
+		defer func() { param.Instance.Spec.TargetAllocator.MTLS = nil }()
+		actualConfig, err := ReplaceConfig(param)
+		assert.NoError(t, err)
+
+		// prepare
+		var cfg Config
+		promCfgMap, err := ta.ConfigToPromConfig(actualConfig)
+		assert.NoError(t, err)
+
+		promCfg, err := yaml.Marshal(map[string]interface{}{
+			"config": promCfgMap,
+		})
+		assert.NoError(t, err)
+
+		err = yaml.UnmarshalStrict(promCfg, &cfg)
+		assert.NoError(t, err)
+
+		// test
+		assert.NotEmpty(t, cfg.PromConfig.ScrapeConfigs)
+		for _, scrapeConfig := range cfg.PromConfig.ScrapeConfigs {
+			sdConfig := scrapeConfig.ServiceDiscoveryConfigs[0].(*http.SDConfig)
+			assert.Equal(t, "https://test-targetallocator:443/jobs/"+scrapeConfig.JobName+"/targets?collector_id=$POD_NAME", sdConfig.URL)
+			assert.Equal(t, "/etc/targetallocator-tls/ca.crt", sdConfig.HTTPClientConfig.TLSConfig.CAFile)
+			assert.Equal(t, "/etc/targetallocator-tls/tls.crt", sdConfig.HTTPClientConfig.TLSConfig.CertFile)
+			assert.Equal(t, "/etc/targetallocator-tls/tls.key", sdConfig.HTTPClientConfig.TLSConfig.KeyFile)
+		}
+	})
+
 	t.Run("should not update config with http_sd_config", func(t *testing.T) {
 		param.Instance.Spec.TargetAllocator.Enabled = false
 		actualConfig, err := ReplaceConfig(param)
@@ -96,3 +131,132 @@ func TestPrometheusParser(t *testing.T) {
 	})
 
 }
+
+func TestReplaceConfigKeepsPrometheusSettings(t *testing.T) {
+	config := `receivers:
+  prometheus:
+    config:
+      global:
+        scrape_interval: 30s
+        external_labels:
+          cluster: prod
+      scrape_configs:
+      - job_name: app
+        basic_auth:
+          username: user
+          password: secret
+        kubernetes_sd_configs:
+        - role: pod
+        relabel_configs:
+        - source_labels: [__meta_kubernetes_pod_label_app]
+          regex: (.+)
+          target_label: app
+          replacement: $$1
+        metric_relabel_configs:
+        - source_labels: [__name__]
+          regex: go_.*
+          action: drop
+exporters:
+  logging:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [logging]
+`
+
+	t.Run("should keep the global settings and the relabel configs", func(t *testing.T) {
+		// prepare
+		param := params()
+		param.Instance.Spec.Config = config
+		param.Instance.Spec.TargetAllocator.Enabled = true
+
+		// test
+		actual, err := ReplaceConfig(param)
+		require.NoError(t, err)
+
+		// verify
+		promCfg, err := ta.ConfigToPromConfig(actual)
+		require.NoError(t, err)
+		assert.Equal(t, map[interface{}]interface{}{
+			"scrape_interval": "30s",
+			"external_labels": map[interface{}]interface{}{"cluster": "prod"},
+		}, promCfg["global"])
+		scrapeConfig := promCfg["scrape_configs"].([]interface{})[0].(map[interface{}]interface{})
+		assert.Equal(t, map[interface{}]interface{}{"username": "user", "password": "secret"}, scrapeConfig["basic_auth"])
+		assert.Equal(t, "$$1", scrapeConfig["relabel_configs"].([]interface{})[0].(map[interface{}]interface{})["replacement"])
+		assert.Len(t, scrapeConfig["metric_relabel_configs"], 1)
+		assert.NotContains(t, scrapeConfig, "kubernetes_sd_configs")
+		assert.Equal(t, []interface{}{map[interface{}]interface{}{
+			"url": "http://test-targetallocator:80/jobs/app/targets?collector_id=$POD_NAME",
+		}}, scrapeConfig["http_sd_configs"])
+	})
+
+	t.Run("should reject the capture groups the collector would expand", func(t *testing.T) {
+		// prepare
+		param := params()
+		param.Instance.Spec.Config = strings.Replace(config, "replacement: $$1", "replacement: $1", 1)
+		param.Instance.Spec.TargetAllocator.Enabled = true
+
+		// test
+		_, err := ReplaceConfig(param)
+
+		// verify
+		assert.ErrorContains(t, err, "the relabel_configs 0 of the job app references $1 in its replacement")
+	})
+
+	t.Run("should reject the invalid relabel configs", func(t *testing.T) {
+		// prepare
+		param := params()
+		param.Instance.Spec.Config = strings.Replace(config, "regex: go_.*", "regex: (go_", 1)
+		param.Instance.Spec.TargetAllocator.Enabled = true
+
+		// test
+		_, err := ReplaceConfig(param)
+
+		// verify
+		assert.ErrorContains(t, err, "the prometheus config is incorrect")
+	})
+
+	t.Run("should escape the config of the referenced target allocator", func(t *testing.T) {
+		// prepare
+		param := params()
+		param.Instance.Spec.Config = config
+		param.TargetAllocator = &v1alpha1.TargetAllocator{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"},
+			Spec: v1alpha1.TargetAllocatorSpec{
+				Config: `global:
+  scrape_interval: 1m
+  scrape_timeout: 20s
+scrape_configs:
+- job_name: shared
+  relabel_configs:
+  - source_labels: [__address__]
+    regex: (.+):\d+
+    target_label: host
+    replacement: ${1}
+`,
+			},
+		}
+
+		// test
+		actual, err := ReplaceConfig(param)
+		require.NoError(t, err)
+
+		// verify
+		promCfg, err := ta.ConfigToPromConfig(actual)
+		require.NoError(t, err)
+		assert.Equal(t, map[interface{}]interface{}{
+			"scrape_interval": "30s",
+			"scrape_timeout":  "20s",
+			"external_labels": map[interface{}]interface{}{"cluster": "prod"},
+		}, promCfg["global"])
+		scrapeConfigs := promCfg["scrape_configs"].([]interface{})
+		require.Len(t, scrapeConfigs, 1)
+		scrapeConfig := scrapeConfigs[0].(map[interface{}]interface{})
+		assert.Equal(t, "$${1}", scrapeConfig["relabel_configs"].([]interface{})[0].(map[interface{}]interface{})["replacement"])
+		assert.Equal(t, []interface{}{map[interface{}]interface{}{
+			"url": "http://" + naming.StandaloneTAService("shared") + ":80/jobs/shared/targets?collector_id=$POD_NAME",
+		}}, scrapeConfig["http_sd_configs"])
+	})
+}
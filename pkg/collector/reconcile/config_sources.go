@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+)
+
+// ConfigSourceLabel marks the ConfigMaps and Secrets used as config sources, the operator only watches the labeled ones.
+const ConfigSourceLabel = "opentelemetry.io/config-source"
+
+// configSourcesChecksumAnnotation restarts the collector pods when their Secret config sources change.
+const configSourcesChecksumAnnotation = "checksum/config-sources"
+
+// ConfigWithSources returns the inline config of the instance merged over the configs of its ConfigMap sources. The
+// Secret sources are only verified, the collector reads them from its environment. The sources are read with the given
+// client, from the cluster of the instance even when it's deployed to a remote cluster. The sources which aren't labeled
+// are rejected when requireLabel is set, as the operator wouldn't watch their changes.
+func ConfigWithSources(ctx context.Context, c client.Client, instance v1alpha1.OpenTelemetryCollector, requireLabel bool) (string, error) {
+	var merged map[interface{}]interface{}
+	merge := false
+	for _, source := range instance.Spec.ConfigSources {
+		name, config, err := configSource(ctx, c, instance.Namespace, source, requireLabel)
+		if err != nil {
+			return "", err
+		}
+		if source.ConfigMapKeyRef == nil {
+			continue
+		}
+		cfg, err := adapters.ConfigFromString(config)
+		if err != nil {
+			return "", fmt.Errorf("the config source %s is incorrect: %w", name, err)
+		}
+		merged = adapters.ConfigMerge(merged, cfg)
+		merge = true
+	}
+	if !merge {
+		return instance.Spec.Config, nil
+	}
+
+	cfg, err := adapters.ConfigFromString(instance.Spec.Config)
+	if err != nil {
+		return "", err
+	}
+	merged = adapters.ConfigMerge(merged, cfg)
+
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// configSource returns a description of the source, and the config it holds. The missing optional sources hold an
+// empty config, and the sources which aren't labeled are rejected when requireLabel is set.
+func configSource(ctx context.Context, c client.Client, namespace string, source v1alpha1.ConfigSource, requireLabel bool) (string, string, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		name := fmt.Sprintf("configmap %s/%s", ref.Name, ref.Key)
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, cm)
+		if err != nil && !(k8serrors.IsNotFound(err) && isOptional(ref.Optional)) {
+			return name, "", fmt.Errorf("failed to get the config source %s: %w", name, err)
+		}
+		if err == nil && requireLabel && cm.Labels[ConfigSourceLabel] != "true" {
+			return name, "", fmt.Errorf("the config source %s isn't labeled %s=true", name, ConfigSourceLabel)
+		}
+		config, ok := cm.Data[ref.Key]
+		if !ok && err == nil && !isOptional(ref.Optional) {
+			return name, "", fmt.Errorf("the config source %s doesn't exist", name)
+		}
+		return name, config, nil
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		name := fmt.Sprintf("secret %s/%s", ref.Name, ref.Key)
+		secret := &corev1.Secret{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret)
+		if err != nil && !(k8serrors.IsNotFound(err) && isOptional(ref.Optional)) {
+			return name, "", fmt.Errorf("failed to get the config source %s: %w", name, err)
+		}
+		if err == nil && requireLabel && secret.Labels[ConfigSourceLabel] != "true" {
+			return name, "", fmt.Errorf("the config source %s isn't labeled %s=true", name, ConfigSourceLabel)
+		}
+		config, ok := secret.Data[ref.Key]
+		if !ok && err == nil && !isOptional(ref.Optional) {
+			return name, "", fmt.Errorf("the config source %s doesn't exist", name)
+		}
+		return name, string(config), nil
+	}
+	return "", "", fmt.Errorf("the config source references neither a configmap nor a secret")
+}
+
+// setConfigSourcesChecksum stamps the checksum of the Secret config sources on the pod template, so that the collector
+// pods are restarted with their new environment when the Secrets change.
+func setConfigSourcesChecksum(ctx context.Context, params Params, template *corev1.PodTemplateSpec) error {
+	h := sha256.New()
+	secrets := false
+	for _, source := range params.Instance.Spec.ConfigSources {
+		if source.SecretKeyRef == nil {
+			continue
+		}
+		_, config, err := configSource(ctx, params.Client, params.Instance.Namespace, source, !params.UnwatchedConfigSources)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(source.SecretKeyRef.Name))
+		h.Write([]byte{0})
+		h.Write([]byte(source.SecretKeyRef.Key))
+		h.Write([]byte{0})
+		h.Write([]byte(config))
+		h.Write([]byte{0})
+		secrets = true
+	}
+	if !secrets {
+		return nil
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[configSourcesChecksumAnnotation] = fmt.Sprintf("%x", h.Sum(nil))
+	return nil
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// ConfigSourceReferenced returns whether the config sources of the instance reference the given ConfigMap or Secret.
+func ConfigSourceReferenced(instance v1alpha1.OpenTelemetryCollector, obj client.Object) bool {
+	if obj.GetNamespace() != instance.Namespace {
+		return false
+	}
+	for _, source := range instance.Spec.ConfigSources {
+		switch obj.(type) {
+		case *corev1.ConfigMap:
+			if source.ConfigMapKeyRef != nil && source.ConfigMapKeyRef.Name == obj.GetName() {
+				return true
+			}
+		case *corev1.Secret:
+			if source.SecretKeyRef != nil && source.SecretKeyRef.Name == obj.GetName() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestConfigWithSources(t *testing.T) {
+	// prepare
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-config",
+			Namespace: "default",
+			Labels:    map[string]string{ConfigSourceLabel: "true"},
+		},
+		Data: map[string]string{
+			"receivers.yaml": "receivers:\n  otlp:\n    protocols:\n      grpc:\n",
+		},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-exporters",
+			Namespace: "default",
+			Labels:    map[string]string{ConfigSourceLabel: "true"},
+		},
+		Data: map[string][]byte{
+			"exporters.yaml": []byte("exporters:\n  otlp:\n    endpoint: backend:4317\n    headers:\n      api-key: secret\n"),
+		},
+	}
+	unlabeled := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unlabeled-config",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"receivers.yaml": "receivers:\n  otlp:\n",
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), cm))
+	require.NoError(t, k8sClient.Create(context.Background(), secret))
+	require.NoError(t, k8sClient.Create(context.Background(), unlabeled))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), cm))
+		assert.NoError(t, k8sClient.Delete(context.Background(), secret))
+		assert.NoError(t, k8sClient.Delete(context.Background(), unlabeled))
+	}()
+
+	optional := true
+	sources := []v1alpha1.ConfigSource{
+		{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: cm.Name},
+				Key:                  "receivers.yaml",
+			},
+		},
+		{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secret.Name},
+				Key:                  "exporters.yaml",
+			},
+		},
+		{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "missing"},
+				Key:                  "config.yaml",
+				Optional:             &optional,
+			},
+		},
+	}
+
+	t.Run("should return the inline config without sources", func(t *testing.T) {
+		p := params()
+		actual, err := ConfigWithSources(context.Background(), k8sClient, p.Instance, true)
+		assert.NoError(t, err)
+		assert.Equal(t, p.Instance.Spec.Config, actual)
+	})
+
+	t.Run("should merge the inline config over the configmap sources", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.ConfigSources = sources
+		p.Instance.Spec.Config = `exporters:
+  otlp:
+    endpoint: other-backend:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+
+		actual, err := ConfigWithSources(context.Background(), k8sClient, p.Instance, true)
+		require.NoError(t, err)
+
+		var cfg map[string]map[string]map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(actual), &cfg))
+		assert.Contains(t, cfg["receivers"], "otlp")
+		assert.Equal(t, "other-backend:4317", cfg["exporters"]["otlp"]["endpoint"])
+		assert.NotContains(t, cfg["exporters"]["otlp"], "headers")
+		assert.Contains(t, cfg["service"], "pipelines")
+	})
+
+	t.Run("should fail with a missing source", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.ConfigSources = []v1alpha1.ConfigSource{
+			{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: secret.Name},
+					Key:                  "missing.yaml",
+				},
+			},
+		}
+		_, err := ConfigWithSources(context.Background(), k8sClient, p.Instance, true)
+		assert.ErrorContains(t, err, "the config source secret shared-exporters/missing.yaml doesn't exist")
+	})
+
+	t.Run("should fail with a source which isn't labeled", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.ConfigSources = []v1alpha1.ConfigSource{
+			{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: unlabeled.Name},
+					Key:                  "receivers.yaml",
+				},
+			},
+		}
+		_, err := ConfigWithSources(context.Background(), k8sClient, p.Instance, true)
+		assert.ErrorContains(t, err, "the config source configmap unlabeled-config/receivers.yaml isn't labeled opentelemetry.io/config-source=true")
+	})
+
+	t.Run("should merge a source which isn't labeled when the label isn't required", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.ConfigSources = []v1alpha1.ConfigSource{
+			{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: unlabeled.Name},
+					Key:                  "receivers.yaml",
+				},
+			},
+		}
+		actual, err := ConfigWithSources(context.Background(), k8sClient, p.Instance, false)
+		require.NoError(t, err)
+		assert.NotEqual(t, p.Instance.Spec.Config, actual)
+	})
+
+	t.Run("should stamp the checksum of the secret sources", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.ConfigSources = sources
+		template := &v1.PodTemplateSpec{}
+		require.NoError(t, setConfigSourcesChecksum(context.Background(), p, template))
+		first := template.Annotations[configSourcesChecksumAnnotation]
+		assert.NotEmpty(t, first)
+
+		secret.Data["exporters.yaml"] = []byte("exporters:\n  otlp:\n    endpoint: backend:4317\n")
+		require.NoError(t, k8sClient.Update(context.Background(), secret))
+		require.NoError(t, setConfigSourcesChecksum(context.Background(), p, template))
+		assert.NotEqual(t, first, template.Annotations[configSourcesChecksumAnnotation])
+	})
+}
+
+func TestConfigSourceReferenced(t *testing.T) {
+	instance := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ConfigSources: []v1alpha1.ConfigSource{
+				{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "shared"}}},
+			},
+		},
+	}
+
+	assert.True(t, ConfigSourceReferenced(instance, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}))
+	assert.False(t, ConfigSourceReferenced(instance, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}))
+	assert.False(t, ConfigSourceReferenced(instance, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "other"}}))
+}
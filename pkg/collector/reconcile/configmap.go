@@ -23,6 +23,7 @@ import (
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
 	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
@@ -39,8 +41,29 @@ import (
 
 // ConfigMaps reconciles the config map(s) required for the instance in the current context.
 func ConfigMaps(ctx context.Context, params Params) error {
-	desired := []corev1.ConfigMap{
-		desiredConfigMap(ctx, params),
+	// hold the update until the custom distribution is built
+	if built, err := collectorBuilt(ctx, params); err != nil || !built {
+		return err
+	}
+
+	// hold the update until the image has the components of the config
+	if !componentsSupported(params) {
+		return nil
+	}
+
+	// hold the update until the pre-deploy check passes
+	if passed, err := preDeployCheckPassed(ctx, params); err != nil || !passed {
+		return err
+	}
+
+	// hold the config until its canary is promoted
+	if promoted, err := canaryPromoted(ctx, params); err != nil || !promoted {
+		return err
+	}
+
+	desired, err := collectorConfigMaps(ctx, params)
+	if err != nil {
+		return err
 	}
 
 	if params.Instance.Spec.TargetAllocator.Enabled {
@@ -52,7 +75,7 @@ func ConfigMaps(ctx context.Context, params Params) error {
 	}
 
 	// first, handle the create/update parts
-	if err := expectedConfigMaps(ctx, params, desired, true); err != nil {
+	if err := expectedConfigMaps(ctx, params, desired); err != nil {
 		return fmt.Errorf("failed to reconcile the expected configmaps: %w", err)
 	}
 
@@ -64,19 +87,123 @@ func ConfigMaps(ctx context.Context, params Params) error {
 	return nil
 }
 
-func desiredConfigMap(_ context.Context, params Params) corev1.ConfigMap {
+// collectorConfigMap builds the collector ConfigMap with the decrypted config, once the config is complete.
+func collectorConfigMap(ctx context.Context, params Params) (corev1.ConfigMap, error) {
+	cm, err := desiredConfigMap(ctx, params)
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+
+	if params.Instance.Spec.EncryptionKeyRef != nil {
+		key, err := encryptionKey(ctx, params)
+		if err != nil {
+			return corev1.ConfigMap{}, err
+		}
+		config, err := DecryptConfig(cm.Data["collector.yaml"], key)
+		if err != nil {
+			return corev1.ConfigMap{}, fmt.Errorf("failed to decrypt config: %w", err)
+		}
+		cm.Data["collector.yaml"] = config
+	}
+
+	// don't write a config the collector would fail to start with
+	if err := checkConfigComplete(ctx, params, cm.Data["collector.yaml"]); err != nil {
+		return corev1.ConfigMap{}, err
+	}
+	return cm, nil
+}
+
+// collectorConfigMaps builds the collector ConfigMap, or the ConfigMaps holding the parts of its config when it's too
+// large for a single one.
+func collectorConfigMaps(ctx context.Context, params Params) ([]corev1.ConfigMap, error) {
+	cm, err := collectorConfigMap(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return splitConfigMap(params, cm)
+}
+
+// splitConfigMap splits the config of the collector ConfigMap across the ConfigMaps named after it.
+func splitConfigMap(params Params, cm corev1.ConfigMap) ([]corev1.ConfigMap, error) {
+	parts, err := collector.SplitConfig(params.Instance, cm.Data["collector.yaml"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to split the config across several config maps: %w", err)
+	}
+	if len(parts) == 1 {
+		return []corev1.ConfigMap{cm}, nil
+	}
+
+	cms := make([]corev1.ConfigMap, 0, len(parts))
+	for i, part := range parts {
+		partCM := *cm.DeepCopy()
+		partCM.Name = naming.ConfigMapPart(cm.Name, i)
+		partCM.Labels["app.kubernetes.io/name"] = partCM.Name
+		partCM.Data = map[string]string{collector.ConfigPartEntry(params.Config, i): part}
+		cms = append(cms, partCM)
+	}
+	return cms, nil
+}
+
+// checkConfigComplete reports the inconsistencies of the generated config with the IncompleteConfig condition, and
+// returns an error when there are any.
+func checkConfigComplete(ctx context.Context, params Params, config string) error {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		params.Log.V(2).Info("failed to parse the config, skipping the completeness check", "error", err)
+		return nil
+	}
+	problems := adapters.ConfigToIncompleteComponents(cfg)
+
+	existing := meta.FindStatusCondition(params.Instance.Status.Conditions, v1alpha1.ConditionTypeIncompleteConfig)
+	if len(problems) == 0 && existing == nil {
+		return nil
+	}
+	message := strings.Join(problems, ", ")
+	if existing != nil && existing.Message == message {
+		return fmt.Errorf("the config is incomplete: %s", message)
+	}
+
+	changed := params.Instance.DeepCopy()
+	if len(problems) == 0 {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeIncompleteConfig)
+	} else {
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ConditionTypeIncompleteConfig,
+			Status:  metav1.ConditionTrue,
+			Reason:  "InconsistentComponents",
+			Message: message,
+		})
+	}
+	statusPatch := client.MergeFrom(&params.Instance)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the config is incomplete: %s", message)
+	}
+	return nil
+}
+
+func desiredConfigMap(_ context.Context, params Params) (corev1.ConfigMap, error) {
 	name := naming.ConfigMap(params.Instance)
 	version := strings.Split(params.Instance.Spec.Image, ":")
-	labels := collector.Labels(params.Instance, []string{})
+	labels := collector.Labels(params.Instance, params.Config.LabelsFilter())
 	labels["app.kubernetes.io/name"] = name
 	if len(version) > 1 {
 		labels["app.kubernetes.io/version"] = version[len(version)-1]
 	} else {
 		labels["app.kubernetes.io/version"] = "latest"
 	}
+	// the collector would scrape all the targets, or none, without the replaced scrape configs
 	config, err := ReplaceConfig(params)
 	if err != nil {
-		params.Log.V(2).Info("failed to update prometheus config to use sharded targets: ", err)
+		return corev1.ConfigMap{}, fmt.Errorf("failed to replace the scrape configs of the prometheus receiver with the ones of the target allocator: %w", err)
+	}
+	if tuned, err := collector.MemoryLimiterConfig(params.Instance, config); err != nil {
+		params.Log.V(2).Info("failed to set the memory_limiter limits from the memory limit", "error", err)
+	} else {
+		config = tuned
 	}
 
 	return corev1.ConfigMap{
@@ -84,12 +211,12 @@ func desiredConfigMap(_ context.Context, params Params) corev1.ConfigMap {
 			Name:        name,
 			Namespace:   params.Instance.Namespace,
 			Labels:      labels,
-			Annotations: params.Instance.Annotations,
+			Annotations: collector.PropagatedAnnotations(params.Instance, params.Config.AnnotationsFilter()),
 		},
 		Data: map[string]string{
 			"collector.yaml": config,
 		},
-	}
+	}, nil
 }
 
 func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
@@ -114,7 +241,8 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 		"app.kubernetes.io/managed-by": "opentelemetry-operator",
 		"app.kubernetes.io/component":  "opentelemetry-collector",
 	}
-	taConfig["config"] = promConfig
+	// the target allocator reads the relabel configs as Prometheus does, without the escaping of the collector config
+	taConfig["config"] = ta.UnescapeDollarSigns(promConfig)
 	if len(params.Instance.Spec.TargetAllocator.AllocationStrategy) > 0 {
 		taConfig["allocation_strategy"] = params.Instance.Spec.TargetAllocator.AllocationStrategy
 	} else {
@@ -125,6 +253,10 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 		taConfig["filter_strategy"] = params.Instance.Spec.TargetAllocator.FilterStrategy
 	}
 
+	if len(params.Instance.Spec.TargetAllocator.AllocationRules) > 0 {
+		taConfig["allocation_rules"] = targetallocator.AllocationRules(params.Instance.Spec.TargetAllocator.AllocationRules)
+	}
+
 	if params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector != nil {
 		taConfig["service_monitor_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector
 	}
@@ -133,6 +265,14 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 		taConfig["pod_monitor_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector
 	}
 
+	if params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorNamespaceSelector != nil {
+		taConfig["service_monitor_namespace_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorNamespaceSelector
+	}
+
+	if params.Instance.Spec.TargetAllocator.PrometheusCR.PodMonitorNamespaceSelector != nil {
+		taConfig["pod_monitor_namespace_selector"] = &params.Instance.Spec.TargetAllocator.PrometheusCR.PodMonitorNamespaceSelector
+	}
+
 	taConfigYAML, err := yaml.Marshal(taConfig)
 	if err != nil {
 		return corev1.ConfigMap{}, err
@@ -143,7 +283,7 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 			Name:        name,
 			Namespace:   params.Instance.Namespace,
 			Labels:      labels,
-			Annotations: params.Instance.Annotations,
+			Annotations: collector.PropagatedAnnotations(params.Instance, params.Config.AnnotationsFilter()),
 		},
 		Data: map[string]string{
 			"targetallocator.yaml": string(taConfigYAML),
@@ -151,7 +291,7 @@ func desiredTAConfigMap(params Params) (corev1.ConfigMap, error) {
 	}, nil
 }
 
-func expectedConfigMaps(ctx context.Context, params Params, expected []corev1.ConfigMap, retry bool) error {
+func expectedConfigMaps(ctx context.Context, params Params, expected []corev1.ConfigMap) error {
 	for _, obj := range expected {
 		desired := obj
 
@@ -163,18 +303,8 @@ func expectedConfigMaps(ctx context.Context, params Params, expected []corev1.Co
 		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
 		clientGetErr := params.Client.Get(ctx, nns, existing)
 		if clientGetErr != nil && errors.IsNotFound(clientGetErr) {
-			if clientCreateErr := params.Client.Create(ctx, &desired); clientCreateErr != nil {
-				if errors.IsAlreadyExists(clientCreateErr) && retry {
-					// let's try again? we probably had multiple updates at one, and now it exists already
-					if err := expectedConfigMaps(ctx, params, expected, false); err != nil {
-						// somethin else happened now...
-						return err
-					}
-
-					// we succeeded in the retry, exit this attempt
-					return nil
-				}
-				return fmt.Errorf("failed to create: %w", clientCreateErr)
+			if err := apply(ctx, params, &desired); err != nil {
+				return fmt.Errorf("failed to create: %w", err)
 			}
 			params.Log.V(2).Info("created", "configmap.name", desired.Name, "configmap.namespace", desired.Namespace)
 			continue
@@ -182,33 +312,11 @@ func expectedConfigMaps(ctx context.Context, params Params, expected []corev1.Co
 			return fmt.Errorf("failed to get: %w", clientGetErr)
 		}
 
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-
-		updated.Data = desired.Data
-		updated.BinaryData = desired.BinaryData
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 		if configMapChanged(&desired, existing) {
-			params.Recorder.Event(updated, "Normal", "ConfigUpdate ", fmt.Sprintf("OpenTelemetry Config changed - %s/%s", desired.Namespace, desired.Name))
+			params.Recorder.Event(&desired, "Normal", "ConfigUpdate ", fmt.Sprintf("OpenTelemetry Config changed - %s/%s", desired.Namespace, desired.Name))
 		}
 
 		params.Log.V(2).Info("applied", "configmap.name", desired.Name, "configmap.namespace", desired.Namespace)
@@ -232,6 +340,10 @@ func deleteConfigMaps(ctx context.Context, params Params, expected []corev1.Conf
 
 	for i := range list.Items {
 		existing := list.Items[i]
+		if existing.Labels[collector.CanaryLabel] == "true" {
+			// the canary ConfigMap is reconciled by the Canaries task
+			continue
+		}
 		del := true
 		for _, keep := range expected {
 			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
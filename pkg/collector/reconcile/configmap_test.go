@@ -16,11 +16,15 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -61,7 +65,11 @@ receivers:
 exporters:
   logging:
 
+extensions:
+  health_check:
+
 service:
+  extensions: [health_check]
   pipelines:
     metrics:
       receivers: [prometheus, jaeger]
@@ -69,7 +77,8 @@ service:
       exporters: [logging]`,
 		}
 
-		actual := desiredConfigMap(context.Background(), params())
+		actual, err := desiredConfigMap(context.Background(), params())
+		assert.NoError(t, err)
 
 		assert.Equal(t, "test-collector", actual.Name)
 		assert.Equal(t, expectedLables, actual.Labels)
@@ -84,6 +93,8 @@ service:
 		expectedData := map[string]string{
 			"collector.yaml": `exporters:
   logging: null
+extensions:
+  health_check: null
 processors: null
 receivers:
   jaeger:
@@ -91,22 +102,14 @@ receivers:
       grpc: null
   prometheus:
     config:
-      global:
-        scrape_interval: 1m
-        scrape_timeout: 10s
-        evaluation_interval: 1m
       scrape_configs:
-      - job_name: otel-collector
-        honor_timestamps: true
+      - http_sd_configs:
+        - url: http://test-targetallocator:80/jobs/otel-collector/targets?collector_id=$POD_NAME
+        job_name: otel-collector
         scrape_interval: 10s
-        scrape_timeout: 10s
-        metrics_path: /metrics
-        scheme: http
-        follow_redirects: true
-        http_sd_configs:
-        - follow_redirects: false
-          url: http://test-targetallocator:80/jobs/otel-collector/targets?collector_id=$POD_NAME
 service:
+  extensions:
+  - health_check
   pipelines:
     metrics:
       exporters:
@@ -120,7 +123,8 @@ service:
 
 		param := params()
 		param.Instance.Spec.TargetAllocator.Enabled = true
-		actual := desiredConfigMap(context.Background(), param)
+		actual, err := desiredConfigMap(context.Background(), param)
+		assert.NoError(t, err)
 
 		assert.Equal(t, "test-collector", actual.Name)
 		assert.Equal(t, expectedLables, actual.Labels)
@@ -140,21 +144,10 @@ processors: null
 receivers:
   prometheus:
     config:
-      global:
-        scrape_interval: 1m
-        scrape_timeout: 10s
-        evaluation_interval: 1m
       scrape_configs:
-      - job_name: serviceMonitor/test/test/0
-        honor_timestamps: true
-        scrape_interval: 1m
-        scrape_timeout: 10s
-        metrics_path: /metrics
-        scheme: http
-        follow_redirects: true
-        http_sd_configs:
-        - follow_redirects: false
-          url: http://test-targetallocator:80/jobs/serviceMonitor%2Ftest%2Ftest%2F0/targets?collector_id=$POD_NAME
+      - http_sd_configs:
+        - url: http://test-targetallocator:80/jobs/serviceMonitor%2Ftest%2Ftest%2F0/targets?collector_id=$POD_NAME
+        job_name: serviceMonitor/test/test/0
 service:
   pipelines:
     metrics:
@@ -169,7 +162,8 @@ service:
 		param, err := newParams("test/test-img", "../testdata/http_sd_config_servicemonitor_test.yaml")
 		assert.NoError(t, err)
 		param.Instance.Spec.TargetAllocator.Enabled = true
-		actual := desiredConfigMap(context.Background(), param)
+		actual, err := desiredConfigMap(context.Background(), param)
+		assert.NoError(t, err)
 
 		assert.Equal(t, "test-collector", actual.Name)
 		assert.Equal(t, expectedLables, actual.Labels)
@@ -227,8 +221,12 @@ label_selector:
   app.kubernetes.io/component: opentelemetry-collector
   app.kubernetes.io/instance: default.test
   app.kubernetes.io/managed-by: opentelemetry-operator
+pod_monitor_namespace_selector:
+  team: test
 pod_monitor_selector:
   release: test
+service_monitor_namespace_selector:
+  team: test
 service_monitor_selector:
   release: test
 `,
@@ -240,6 +238,12 @@ service_monitor_selector:
 		p.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector = map[string]string{
 			"release": "test",
 		}
+		p.Instance.Spec.TargetAllocator.PrometheusCR.PodMonitorNamespaceSelector = map[string]string{
+			"team": "test",
+		}
+		p.Instance.Spec.TargetAllocator.PrometheusCR.ServiceMonitorNamespaceSelector = map[string]string{
+			"team": "test",
+		}
 		actual, err := desiredTAConfigMap(p)
 		assert.NoError(t, err)
 
@@ -248,6 +252,66 @@ service_monitor_selector:
 		assert.Equal(t, expectedData, actual.Data)
 
 	})
+	t.Run("should return expected target allocator config map with allocation rules", func(t *testing.T) {
+		expectedData := map[string]string{
+			"targetallocator.yaml": `allocation_rules:
+- allocation_strategy: consistent-hashing
+  collectors: test-collector-[01]
+  jobs:
+  - federate-.*
+  name: federation
+- action: drop
+  jobs:
+  - blackbox
+  name: probes
+allocation_strategy: least-weighted
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+label_selector:
+  app.kubernetes.io/component: opentelemetry-collector
+  app.kubernetes.io/instance: default.test
+  app.kubernetes.io/managed-by: opentelemetry-operator
+`,
+		}
+		p := params()
+		p.Instance.Spec.TargetAllocator.AllocationRules = []v1alpha1.TargetAllocatorAllocationRule{
+			{
+				Name:               "federation",
+				Jobs:               []string{"federate-.*"},
+				AllocationStrategy: v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+				Collectors:         "test-collector-[01]",
+			},
+			{Name: "probes", Jobs: []string{"blackbox"}, Action: v1alpha1.TargetAllocatorAllocationRuleActionDrop},
+		}
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
+	t.Run("should unescape the dollar signs of the target allocator config", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Config = `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: otel-collector
+        relabel_configs:
+        - source_labels: [__address__]
+          regex: (.+):\d+
+          target_label: host
+          replacement: $$1
+`
+		actual, err := desiredTAConfigMap(p)
+		assert.NoError(t, err)
+
+		assert.Contains(t, actual.Data["targetallocator.yaml"], "replacement: $1\n")
+	})
 
 }
 
@@ -255,7 +319,9 @@ func TestExpectedConfigMap(t *testing.T) {
 	t.Run("should create collector and target allocator config maps", func(t *testing.T) {
 		configMap, err := desiredTAConfigMap(params())
 		assert.NoError(t, err)
-		err = expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{desiredConfigMap(context.Background(), params()), configMap}, true)
+		cm, err := desiredConfigMap(context.Background(), params())
+		assert.NoError(t, err)
+		err = expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{cm, configMap})
 		assert.NoError(t, err)
 
 		exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: "test-collector"})
@@ -289,10 +355,13 @@ func TestExpectedConfigMap(t *testing.T) {
 			Log:      logger,
 			Recorder: record.NewFakeRecorder(10),
 		}
-		cm := desiredConfigMap(context.Background(), param)
+		cm, err := desiredConfigMap(context.Background(), param)
+		assert.NoError(t, err)
 		createObjectIfNotExists(t, "test-collector", &cm)
 
-		err := expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{desiredConfigMap(context.Background(), params())}, true)
+		cm, err = desiredConfigMap(context.Background(), params())
+		assert.NoError(t, err)
+		err = expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{cm})
 		assert.NoError(t, err)
 
 		actual := v1.ConfigMap{}
@@ -344,7 +413,7 @@ func TestExpectedConfigMap(t *testing.T) {
 
 		configMap, err := desiredTAConfigMap(params())
 		assert.NoError(t, err)
-		err = expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{configMap}, true)
+		err = expectedConfigMaps(context.Background(), params(), []v1.ConfigMap{configMap})
 		assert.NoError(t, err)
 
 		actual := v1.ConfigMap{}
@@ -387,10 +456,101 @@ func TestExpectedConfigMap(t *testing.T) {
 		exists, _ := populateObjectIfExists(t, &v1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
 		assert.True(t, exists)
 
-		err := deleteConfigMaps(context.Background(), params(), []v1.ConfigMap{desiredConfigMap(context.Background(), params())})
+		cm, err := desiredConfigMap(context.Background(), params())
+		assert.NoError(t, err)
+		err = deleteConfigMaps(context.Background(), params(), []v1.ConfigMap{cm})
 		assert.NoError(t, err)
 
 		exists, _ = populateObjectIfExists(t, &v1.ConfigMap{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
 		assert.False(t, exists)
 	})
 }
+
+func TestCheckConfigComplete(t *testing.T) {
+	instance := params().Instance
+	instance.ObjectMeta = metav1.ObjectMeta{Name: "test-incomplete", Namespace: "default"}
+	require.NoError(t, k8sClient.Create(context.Background(), &instance))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &instance))
+	}()
+	nsn := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+
+	t.Run("should report the incomplete config", func(t *testing.T) {
+		p := params()
+		p.Instance = instance
+		config := `receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+		err := checkConfigComplete(context.Background(), p, config)
+		assert.ErrorContains(t, err, "pipeline 'traces' references the undefined exporter 'otlp'")
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &actual))
+		condition := meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeIncompleteConfig)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+
+	t.Run("should remove the condition once the config is complete", func(t *testing.T) {
+		p := params()
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &p.Instance))
+		config := `receivers:
+  otlp:
+exporters:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+		assert.NoError(t, checkConfigComplete(context.Background(), p, config))
+
+		actual := v1alpha1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &actual))
+		assert.Nil(t, meta.FindStatusCondition(actual.Status.Conditions, v1alpha1.ConditionTypeIncompleteConfig))
+	})
+}
+
+func TestSplitConfigMap(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("exporters:\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&b, "  otlp/%04d:\n    endpoint: %s:4317\n", i, strings.Repeat("x", 1000))
+	}
+	param := params()
+	param.Instance.Spec.Config = b.String()
+	param.Instance.Annotations = map[string]string{
+		"my-annotation":                "my-value",
+		v1.LastAppliedConfigAnnotation: "{}",
+	}
+
+	cm, err := desiredConfigMap(context.Background(), param)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"my-annotation": "my-value"}, cm.Annotations)
+
+	t.Run("should split the config across several config maps", func(t *testing.T) {
+		actual, err := splitConfigMap(param, cm)
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+		for i, name := range []string{"test-collector", "test-collector-1", "test-collector-2"} {
+			assert.Equal(t, name, actual[i].Name)
+			assert.Equal(t, name, actual[i].Labels["app.kubernetes.io/name"])
+			assert.Equal(t, map[string]string{"my-annotation": "my-value"}, actual[i].Annotations)
+		}
+		assert.Contains(t, actual[0].Data, "collector.yaml")
+		assert.Contains(t, actual[1].Data, "collector-1.yaml")
+		assert.Contains(t, actual[2].Data, "collector-2.yaml")
+	})
+
+	t.Run("should keep a small config in a single config map", func(t *testing.T) {
+		actual, err := splitConfigMap(params(), cm)
+		require.NoError(t, err)
+		assert.Equal(t, []v1.ConfigMap{cm}, actual)
+	})
+}
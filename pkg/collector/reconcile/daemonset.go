@@ -32,9 +32,34 @@ import (
 
 // DaemonSets reconciles the daemon set(s) required for the instance in the current context.
 func DaemonSets(ctx context.Context, params Params) error {
+	// hold the update until the custom distribution is built
+	if built, err := collectorBuilt(ctx, params); err != nil || !built {
+		return err
+	}
+
+	// hold the update until the image has the components of the config
+	if !componentsSupported(params) {
+		return nil
+	}
+
+	// hold the update until the pre-deploy check passes
+	if passed, err := preDeployCheckPassed(ctx, params); err != nil || !passed {
+		return err
+	}
+
 	desired := []appsv1.DaemonSet{}
 	if params.Instance.Spec.Mode == "daemonset" {
-		desired = append(desired, collector.DaemonSet(params.Config, params.Log, params.Instance))
+		workload := collector.DaemonSet(params.Config, params.Log, params.Instance)
+		if err := setConfigChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setReceiverTLSChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setConfigSourcesChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		desired = append(desired, workload)
 	}
 
 	// first, handle the create/update parts
@@ -62,7 +87,7 @@ func expectedDaemonSets(ctx context.Context, params Params, expected []appsv1.Da
 		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
 		err := params.Client.Get(ctx, nns, existing)
 		if err != nil && k8serrors.IsNotFound(err) {
-			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+			if clientErr := apply(ctx, params, &desired); clientErr != nil {
 				return fmt.Errorf("failed to create: %w", clientErr)
 			}
 			params.Log.V(2).Info("created", "daemonset.name", desired.Name, "daemonset.namespace", desired.Namespace)
@@ -81,27 +106,7 @@ func expectedDaemonSets(ctx context.Context, params Params, expected []appsv1.Da
 			continue
 		}
 
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-
-		updated.Spec = desired.Spec
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-
-		patch := client.MergeFrom(existing)
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
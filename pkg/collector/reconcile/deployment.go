@@ -34,9 +34,47 @@ import (
 
 // Deployments reconciles the deployment(s) required for the instance in the current context.
 func Deployments(ctx context.Context, params Params) error {
+	// hold the update until the custom distribution is built
+	if built, err := collectorBuilt(ctx, params); err != nil || !built {
+		return err
+	}
+
+	// hold the update until the image has the components of the config
+	if !componentsSupported(params) {
+		return nil
+	}
+
+	// hold the update until the pre-deploy check passes
+	if passed, err := preDeployCheckPassed(ctx, params); err != nil || !passed {
+		return err
+	}
+
+	// don't deploy again the config and image rolled back after a failed smoke test
+	if held, err := smokeTestRollbackHolds(ctx, params); err != nil || held {
+		if held {
+			params.Log.V(2).Info("holding the collector update, its smoke test failed and it was rolled back")
+		}
+		return err
+	}
+
+	// hold the update until its canary is promoted
+	if promoted, err := canaryPromoted(ctx, params); err != nil || !promoted {
+		return err
+	}
+
 	desired := []appsv1.Deployment{}
 	if params.Instance.Spec.Mode == "deployment" {
-		desired = append(desired, collector.Deployment(params.Config, params.Log, params.Instance))
+		workload := collector.Deployment(params.Config, params.Log, params.Instance)
+		if err := setConfigChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setReceiverTLSChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setConfigSourcesChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		desired = append(desired, workload)
 	}
 
 	if params.Instance.Spec.TargetAllocator.Enabled {
@@ -68,7 +106,7 @@ func expectedDeployments(ctx context.Context, params Params, expected []appsv1.D
 		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
 		err := params.Client.Get(ctx, nns, existing)
 		if err != nil && k8serrors.IsNotFound(err) {
-			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+			if clientErr := apply(ctx, params, &desired); clientErr != nil {
 				return fmt.Errorf("failed to create: %w", clientErr)
 			}
 			params.Log.V(2).Info("created", "deployment.name", desired.Name, "deployment.namespace", desired.Namespace)
@@ -87,28 +125,7 @@ func expectedDeployments(ctx context.Context, params Params, expected []appsv1.D
 			continue
 		}
 
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-
-		updated.Spec = desired.Spec
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
@@ -133,6 +150,10 @@ func deleteDeployments(ctx context.Context, params Params, expected []appsv1.Dep
 
 	for i := range list.Items {
 		existing := list.Items[i]
+		if existing.Labels[collector.CanaryLabel] == "true" {
+			// the canary Deployment is reconciled by the Canaries task
+			continue
+		}
 		del := true
 		for _, keep := range expected {
 			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// The Gateway API isn't a dependency of the operator, the routes are handled as unstructured objects.
+var (
+	httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1beta1", Kind: "HTTPRoute"}
+	grpcRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "GRPCRoute"}
+)
+
+type gatewayParentRef struct {
+	Name        string  `json:"name"`
+	Namespace   *string `json:"namespace,omitempty"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+type gatewayBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+type httpRouteSpec struct {
+	ParentRefs []gatewayParentRef `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []httpRouteRule    `json:"rules"`
+}
+
+type httpRouteRule struct {
+	Matches     []httpRouteMatch    `json:"matches,omitempty"`
+	Filters     []httpRouteFilter   `json:"filters,omitempty"`
+	BackendRefs []gatewayBackendRef `json:"backendRefs"`
+}
+
+type httpRouteMatch struct {
+	Path httpPathMatch `json:"path"`
+}
+
+type httpPathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type httpRouteFilter struct {
+	Type       string         `json:"type"`
+	URLRewrite httpURLRewrite `json:"urlRewrite"`
+}
+
+type httpURLRewrite struct {
+	Path httpPathModifier `json:"path"`
+}
+
+type httpPathModifier struct {
+	Type               string `json:"type"`
+	ReplacePrefixMatch string `json:"replacePrefixMatch"`
+}
+
+type grpcRouteSpec struct {
+	ParentRefs []gatewayParentRef `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []grpcRouteRule    `json:"rules"`
+}
+
+type grpcRouteRule struct {
+	BackendRefs []gatewayBackendRef `json:"backendRefs"`
+}
+
+func desiredGatewayRoutes(_ context.Context, params Params) ([]unstructured.Unstructured, error) {
+	ports := servicePortsFromCfg(params)
+
+	// if we have no ports, we don't need a route entry
+	if len(ports) == 0 {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping gateway routes",
+			"instance.name", params.Instance.Name,
+			"instance.namespace", params.Instance.Namespace,
+		)
+		return nil, nil
+	}
+
+	ingress := params.Instance.Spec.Ingress
+	parentRef := gatewayParentRef{Name: ingress.Gateway.ParentRef.Name}
+	if ingress.Gateway.ParentRef.Namespace != "" {
+		parentRef.Namespace = &ingress.Gateway.ParentRef.Namespace
+	}
+	if ingress.Gateway.ParentRef.SectionName != "" {
+		parentRef.SectionName = &ingress.Gateway.ParentRef.SectionName
+	}
+
+	var routes []unstructured.Unstructured
+	for _, p := range ports {
+		var hostnames []string
+		switch {
+		case ingress.RuleType == v1alpha1.IngressRuleTypeSubdomain:
+			hostnames = []string{p.Name + "." + ingress.Hostname}
+		case ingress.Hostname != "":
+			hostnames = []string{ingress.Hostname}
+		}
+		backendRefs := []gatewayBackendRef{{Name: naming.Service(params.Instance), Port: p.Port}}
+
+		var gvk schema.GroupVersionKind
+		var name string
+		var spec interface{}
+		switch appProtocol(p) {
+		case "grpc":
+			// gRPC requests can't be matched on a path prefix, the GRPCRoutes match all the requests to their hosts
+			gvk, name = grpcRouteGVK, naming.GRPCRoute(params.Instance, p.Name)
+			spec = &grpcRouteSpec{
+				ParentRefs: []gatewayParentRef{parentRef},
+				Hostnames:  hostnames,
+				Rules:      []grpcRouteRule{{BackendRefs: backendRefs}},
+			}
+		case "http":
+			gvk, name = httpRouteGVK, naming.HTTPRoute(params.Instance, p.Name)
+			rule := httpRouteRule{BackendRefs: backendRefs}
+			if ingress.RuleType != v1alpha1.IngressRuleTypeSubdomain {
+				// the receivers serve their endpoints from the root path, so the port prefix is stripped
+				rule.Matches = []httpRouteMatch{{Path: httpPathMatch{Type: "PathPrefix", Value: "/" + p.Name}}}
+				rule.Filters = []httpRouteFilter{{
+					Type:       "URLRewrite",
+					URLRewrite: httpURLRewrite{Path: httpPathModifier{Type: "ReplacePrefixMatch", ReplacePrefixMatch: "/"}},
+				}}
+			}
+			spec = &httpRouteSpec{
+				ParentRefs: []gatewayParentRef{parentRef},
+				Hostnames:  hostnames,
+				Rules:      []httpRouteRule{rule},
+			}
+		default:
+			// the gateway routes are only created for the gRPC and HTTP receivers
+			continue
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the %s spec: %w", gvk.Kind, err)
+		}
+
+		route := unstructured.Unstructured{}
+		route.SetGroupVersionKind(gvk)
+		route.SetName(name)
+		route.SetNamespace(params.Instance.Namespace)
+		route.SetAnnotations(ingress.Annotations)
+		route.SetLabels(map[string]string{
+			"app.kubernetes.io/name":       name,
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		})
+		route.Object["spec"] = content
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func appProtocol(port corev1.ServicePort) string {
+	if port.AppProtocol == nil {
+		return ""
+	}
+	return *port.AppProtocol
+}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+
+// GatewayRoutes reconciles the Gateway API HTTPRoutes and GRPCRoutes required for the instance in the current context.
+func GatewayRoutes(ctx context.Context, params Params) error {
+	var desired []unstructured.Unstructured
+	if params.Instance.Spec.Ingress.Type == v1alpha1.IngressTypeGateway && params.Instance.Spec.Mode != v1alpha1.ModeSidecar {
+		routes, err := desiredGatewayRoutes(ctx, params)
+		if err != nil {
+			return err
+		}
+		desired = routes
+	}
+
+	// first, handle the create/update parts
+	if err := expectedGatewayRoutes(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected gateway routes: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteGatewayRoutes(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the gateway routes to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedGatewayRoutes(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		err := apply(ctx, params, &desired)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("the %s kind isn't available, the Gateway API needs to be installed to expose the collector with the gateway type: %w", desired.GetKind(), err)
+		} else if err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "kind", desired.GetKind(), "name", desired.GetName(), "namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deleteGatewayRoutes(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	for _, list := range gatewayRouteLists() {
+		if err := params.Client.List(ctx, list, opts...); meta.IsNoMatchError(err) {
+			// the Gateway API isn't installed, so there's nothing to delete
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+
+		for i := range list.Items {
+			existing := list.Items[i]
+			del := true
+			for _, keep := range expected {
+				if keep.GetKind() == existing.GetKind() && keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+					del = false
+					break
+				}
+			}
+
+			if del {
+				if err := params.Client.Delete(ctx, &existing); err != nil {
+					return fmt.Errorf("failed to delete: %w", err)
+				}
+				params.Log.V(2).Info("deleted", "kind", existing.GetKind(), "name", existing.GetName(), "namespace", existing.GetNamespace())
+			}
+		}
+	}
+
+	return nil
+}
+
+func gatewayRouteLists() []*unstructured.UnstructuredList {
+	var lists []*unstructured.UnstructuredList
+	for _, gvk := range []schema.GroupVersionKind{httpRouteGVK, grpcRouteGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		lists = append(lists, list)
+	}
+	return lists
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const testFileGatewayRoutes = "../testdata/gateway_routes_testdata.yaml"
+
+func TestDesiredGatewayRoutes(t *testing.T) {
+	t.Run("should expose the gRPC and HTTP ports on paths of the hostname", func(t *testing.T) {
+		// prepare
+		params, err := newParams("something:tag", testFileGatewayRoutes)
+		require.NoError(t, err)
+		params.Instance.Spec.Ports = nil
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:     v1alpha1.IngressTypeGateway,
+			Hostname: "example.com",
+			RuleType: v1alpha1.IngressRuleTypePath,
+			Gateway: v1alpha1.GatewayRoute{
+				ParentRef: v1alpha1.GatewayParentReference{Name: "public", Namespace: "gateways"},
+			},
+		}
+
+		// test
+		routes, err := desiredGatewayRoutes(context.Background(), params)
+
+		// verify
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		grpcRoute := routes[0]
+		assert.Equal(t, grpcRouteGVK, grpcRoute.GroupVersionKind())
+		assert.Equal(t, naming.GRPCRoute(params.Instance, "otlp-grpc"), grpcRoute.GetName())
+		assert.Equal(t, "default", grpcRoute.GetNamespace())
+		parentRefs, _, _ := unstructured.NestedSlice(grpcRoute.Object, "spec", "parentRefs")
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "public", "namespace": "gateways"}}, parentRefs)
+		hostnames, _, _ := unstructured.NestedStringSlice(grpcRoute.Object, "spec", "hostnames")
+		assert.Equal(t, []string{"example.com"}, hostnames)
+		rules, _, _ := unstructured.NestedSlice(grpcRoute.Object, "spec", "rules")
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"backendRefs": []interface{}{map[string]interface{}{"name": "test-collector", "port": int64(4317)}},
+		}}, rules)
+
+		httpRoute := routes[1]
+		assert.Equal(t, httpRouteGVK, httpRoute.GroupVersionKind())
+		assert.Equal(t, naming.HTTPRoute(params.Instance, "otlp-http"), httpRoute.GetName())
+		rules, _, _ = unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+		require.Len(t, rules, 1)
+		rule := rules[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"path": map[string]interface{}{"type": "PathPrefix", "value": "/otlp-http"},
+		}}, rule["matches"])
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"type":       "URLRewrite",
+			"urlRewrite": map[string]interface{}{"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": "/"}},
+		}}, rule["filters"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "test-collector", "port": int64(4318)}}, rule["backendRefs"])
+	})
+
+	t.Run("should expose each port on its own subdomain", func(t *testing.T) {
+		// prepare
+		params, err := newParams("something:tag", testFileGatewayRoutes)
+		require.NoError(t, err)
+		params.Instance.Spec.Ports = nil
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:     v1alpha1.IngressTypeGateway,
+			Hostname: "example.com",
+			RuleType: v1alpha1.IngressRuleTypeSubdomain,
+			Gateway: v1alpha1.GatewayRoute{
+				ParentRef: v1alpha1.GatewayParentReference{Name: "public", SectionName: "https"},
+			},
+		}
+
+		// test
+		routes, err := desiredGatewayRoutes(context.Background(), params)
+
+		// verify
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		for _, route := range routes {
+			parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+			assert.Equal(t, []interface{}{map[string]interface{}{"name": "public", "sectionName": "https"}}, parentRefs)
+		}
+		hostnames, _, _ := unstructured.NestedStringSlice(routes[0].Object, "spec", "hostnames")
+		assert.Equal(t, []string{"otlp-grpc.example.com"}, hostnames)
+		hostnames, _, _ = unstructured.NestedStringSlice(routes[1].Object, "spec", "hostnames")
+		assert.Equal(t, []string{"otlp-http.example.com"}, hostnames)
+		_, found, _ := unstructured.NestedFieldNoCopy(routes[1].Object, "spec", "rules", "matches")
+		assert.False(t, found)
+	})
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	t.Run("should do nothing without the gateway type", func(t *testing.T) {
+		// the Gateway API isn't installed in the test environment
+		err := GatewayRoutes(context.Background(), params())
+		assert.NoError(t, err)
+	})
+}
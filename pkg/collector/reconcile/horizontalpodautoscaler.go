@@ -20,10 +20,7 @@ import (
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
-	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -37,8 +34,9 @@ import (
 func HorizontalPodAutoscalers(ctx context.Context, params Params) error {
 	desired := []client.Object{}
 
-	// check if autoscale mode is on, e.g MaxReplicas is not nil
-	if params.Instance.Spec.MaxReplicas != nil {
+	// check if autoscale mode is on, e.g MaxReplicas is not nil, the instances scaled with KEDA get their HPA from KEDA
+	// and the instances scaled on their targets are scaled by the operator
+	if params.Instance.Spec.MaxReplicas != nil && !collector.UsesKeda(params.Instance) && !collector.UsesTargetScaling(params.Instance) {
 		desired = append(desired, collector.HorizontalPodAutoscaler(params.Config, params.Log, params.Instance))
 	}
 
@@ -56,14 +54,6 @@ func HorizontalPodAutoscalers(ctx context.Context, params Params) error {
 }
 
 func expectedHorizontalPodAutoscalers(ctx context.Context, params Params, expected []client.Object) error {
-	autoscalingVersion := params.Config.AutoscalingVersion()
-	var existing client.Object
-	if autoscalingVersion == autodetect.AutoscalingVersionV2Beta2 {
-		existing = &autoscalingv2beta2.HorizontalPodAutoscaler{}
-	} else {
-		existing = &autoscalingv2.HorizontalPodAutoscaler{}
-	}
-
 	for _, obj := range expected {
 		desired, _ := meta.Accessor(obj)
 
@@ -71,36 +61,7 @@ func expectedHorizontalPodAutoscalers(ctx context.Context, params Params, expect
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 
-		nns := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
-		err := params.Client.Get(ctx, nns, existing)
-		if k8serrors.IsNotFound(err) {
-			if clientErr := params.Client.Create(ctx, obj); clientErr != nil {
-				return fmt.Errorf("failed to create: %w", clientErr)
-			}
-			params.Log.V(2).Info("created", "hpa.name", desired.GetName(), "hpa.namespace", desired.GetNamespace())
-			continue
-		} else if err != nil {
-			return fmt.Errorf("failed to get %w", err)
-		}
-
-		updated := existing.DeepCopyObject().(client.Object)
-		updated.SetOwnerReferences(desired.GetOwnerReferences())
-		setAutoscalerSpec(params, autoscalingVersion, updated)
-
-		annotations := updated.GetAnnotations()
-		for k, v := range desired.GetAnnotations() {
-			annotations[k] = v
-		}
-		updated.SetAnnotations(annotations)
-		labels := updated.GetLabels()
-		for k, v := range desired.GetLabels() {
-			labels[k] = v
-		}
-		updated.SetLabels(labels)
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, obj); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
@@ -110,37 +71,6 @@ func expectedHorizontalPodAutoscalers(ctx context.Context, params Params, expect
 	return nil
 }
 
-func setAutoscalerSpec(params Params, autoscalingVersion autodetect.AutoscalingVersion, updated client.Object) {
-	one := int32(1)
-	if params.Instance.Spec.MaxReplicas != nil {
-		if autoscalingVersion == autodetect.AutoscalingVersionV2Beta2 {
-			updated.(*autoscalingv2beta2.HorizontalPodAutoscaler).Spec.MaxReplicas = *params.Instance.Spec.MaxReplicas
-			if params.Instance.Spec.MinReplicas != nil {
-				updated.(*autoscalingv2beta2.HorizontalPodAutoscaler).Spec.MinReplicas = params.Instance.Spec.MinReplicas
-			} else {
-				updated.(*autoscalingv2beta2.HorizontalPodAutoscaler).Spec.MinReplicas = &one
-			}
-			updated.(*autoscalingv2beta2.HorizontalPodAutoscaler).Spec.Metrics[0].Resource.Target.AverageUtilization = params.Instance.Spec.Autoscaler.TargetCPUUtilization
-		} else {
-			updated.(*autoscalingv2.HorizontalPodAutoscaler).Spec.MaxReplicas = *params.Instance.Spec.MaxReplicas
-			if params.Instance.Spec.MinReplicas != nil {
-				updated.(*autoscalingv2.HorizontalPodAutoscaler).Spec.MinReplicas = params.Instance.Spec.MinReplicas
-			} else {
-				updated.(*autoscalingv2.HorizontalPodAutoscaler).Spec.MinReplicas = &one
-			}
-
-			// This will update memory and CPU usage for now, and can be used to update other metrics in the future
-			for _, metric := range updated.(*autoscalingv2.HorizontalPodAutoscaler).Spec.Metrics {
-				if metric.Resource.Name == corev1.ResourceCPU {
-					metric.Resource.Target.AverageUtilization = params.Instance.Spec.Autoscaler.TargetCPUUtilization
-				} else if metric.Resource.Name == corev1.ResourceMemory {
-					metric.Resource.Target.AverageUtilization = params.Instance.Spec.Autoscaler.TargetMemoryUtilization
-				}
-			}
-		}
-	}
-}
-
 func deleteHorizontalPodAutoscalers(ctx context.Context, params Params, expected []client.Object) error {
 	autoscalingVersion := params.Config.AutoscalingVersion()
 
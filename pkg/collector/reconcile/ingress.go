@@ -20,7 +20,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,22 +47,12 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 		return nil
 	}
 
-	pathType := networkingv1.PathTypePrefix
-	paths := make([]networkingv1.HTTPIngressPath, len(ports))
-	for i, p := range ports {
-		paths[i] = networkingv1.HTTPIngressPath{
-			Path:     "/" + p.Name,
-			PathType: &pathType,
-			Backend: networkingv1.IngressBackend{
-				Service: &networkingv1.IngressServiceBackend{
-					Name: naming.Service(params.Instance),
-					Port: networkingv1.ServiceBackendPort{
-						// Valid names must be non-empty and no more than 15 characters long.
-						Name: naming.Truncate(p.Name, 15),
-					},
-				},
-			},
-		}
+	var rules []networkingv1.IngressRule
+	switch params.Instance.Spec.Ingress.RuleType {
+	case v1alpha1.IngressRuleTypeSubdomain:
+		rules = createSubdomainIngressRules(naming.Service(params.Instance), params.Instance.Spec.Ingress.Hostname, ports)
+	default:
+		rules = []networkingv1.IngressRule{createPathIngressRules(naming.Service(params.Instance), params.Instance.Spec.Ingress.Hostname, ports)}
 	}
 
 	return &networkingv1.Ingress{
@@ -78,18 +67,65 @@ func desiredIngresses(_ context.Context, params Params) *networkingv1.Ingress {
 			},
 		},
 		Spec: networkingv1.IngressSpec{
-			TLS: params.Instance.Spec.Ingress.TLS,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: params.Instance.Spec.Ingress.Hostname,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: paths,
+			TLS:              params.Instance.Spec.Ingress.TLS,
+			Rules:            rules,
+			IngressClassName: params.Instance.Spec.Ingress.IngressClassName,
+		},
+	}
+}
+
+// createPathIngressRules exposes every port on its own path of the hostname.
+func createPathIngressRules(serviceName string, hostname string, ports []corev1.ServicePort) networkingv1.IngressRule {
+	pathType := networkingv1.PathTypePrefix
+	paths := make([]networkingv1.HTTPIngressPath, len(ports))
+	for i, p := range ports {
+		paths[i] = networkingv1.HTTPIngressPath{
+			Path:     "/" + p.Name,
+			PathType: &pathType,
+			Backend:  ingressBackend(serviceName, p),
+		}
+	}
+	return networkingv1.IngressRule{
+		Host: hostname,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		},
+	}
+}
+
+// createSubdomainIngressRules exposes every port on its own subdomain of the hostname, e.g. otlp-grpc.example.com.
+func createSubdomainIngressRules(serviceName string, hostname string, ports []corev1.ServicePort) []networkingv1.IngressRule {
+	pathType := networkingv1.PathTypePrefix
+	rules := make([]networkingv1.IngressRule, len(ports))
+	for i, p := range ports {
+		rules[i] = networkingv1.IngressRule{
+			Host: p.Name + "." + hostname,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     "/",
+							PathType: &pathType,
+							Backend:  ingressBackend(serviceName, p),
 						},
 					},
 				},
 			},
-			IngressClassName: params.Instance.Spec.Ingress.IngressClassName,
+		}
+	}
+	return rules
+}
+
+func ingressBackend(serviceName string, port corev1.ServicePort) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: serviceName,
+			Port: networkingv1.ServiceBackendPort{
+				// Valid names must be non-empty and no more than 15 characters long.
+				Name: naming.Truncate(port.Name, 15),
+			},
 		},
 	}
 }
@@ -134,43 +170,7 @@ func expectedIngresses(ctx context.Context, params Params, expected []networking
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 
-		existing := &networkingv1.Ingress{}
-		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
-		clientGetErr := params.Client.Get(ctx, nns, existing)
-		if clientGetErr != nil && k8serrors.IsNotFound(clientGetErr) {
-			if err := params.Client.Create(ctx, &desired); err != nil {
-				return fmt.Errorf("failed to create: %w", err)
-			}
-			params.Log.V(2).Info("created", "ingress.name", desired.Name, "ingress.namespace", desired.Namespace)
-			return nil
-		} else if clientGetErr != nil {
-			return fmt.Errorf("failed to get: %w", clientGetErr)
-		}
-
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-		updated.Spec.Rules = desired.Spec.Rules
-		updated.Spec.TLS = desired.Spec.TLS
-		updated.Spec.DefaultBackend = desired.Spec.DefaultBackend
-		updated.Spec.IngressClassName = desired.Spec.IngressClassName
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
@@ -233,6 +233,7 @@ func servicePortsFromCfg(params Params) []corev1.ServicePort {
 		//
 		// in the first case, we remove the port we inferred from the list
 		// in the second case, we rename our inferred port to something like "port-%d"
+		// the CR ports without an app protocol inherit the one inferred for the same port number
 		portNumbers, portNames := extractPortNumbersAndNames(params.Instance.Spec.Ports)
 		resultingInferredPorts := []corev1.ServicePort{}
 		for _, inferred := range ports {
@@ -241,7 +242,7 @@ func servicePortsFromCfg(params Params) []corev1.ServicePort {
 			}
 		}
 
-		ports = append(params.Instance.Spec.Ports, resultingInferredPorts...)
+		ports = append(withInferredAppProtocols(params.Instance.Spec.Ports, ports), resultingInferredPorts...)
 	}
 	return ports
 }
@@ -177,6 +177,36 @@ func TestDesiredIngresses(t *testing.T) {
 		}, got)
 	})
 
+	t.Run("should return one rule per port with the subdomain rule type", func(t *testing.T) {
+		// prepare
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		params.Instance.Spec.Ingress = v1alpha1.Ingress{
+			Type:     v1alpha1.IngressTypeNginx,
+			Hostname: "example.com",
+			RuleType: v1alpha1.IngressRuleTypeSubdomain,
+		}
+
+		// test
+		got := desiredIngresses(context.Background(), params)
+
+		// verify
+		if !assert.NotNil(t, got) {
+			return
+		}
+		var hosts []string
+		for _, rule := range got.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+			if assert.Len(t, rule.HTTP.Paths, 1) {
+				assert.Equal(t, "/", rule.HTTP.Paths[0].Path)
+				assert.Equal(t, naming.Service(params.Instance), rule.HTTP.Paths[0].Backend.Service.Name)
+				assert.Equal(t, rule.Host, rule.HTTP.Paths[0].Backend.Service.Port.Name+".example.com")
+			}
+		}
+		assert.ElementsMatch(t, []string{"web.example.com", "otlp-grpc.example.com", "otlp-test-grpc.example.com"}, hosts)
+	})
 }
 
 func TestExpectedIngresses(t *testing.T) {
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
+
+// Monitors reconciles the Prometheus operator ServiceMonitors and PodMonitors scraping the collector metrics.
+func Monitors(ctx context.Context, params Params) error {
+	desired := []unstructured.Unstructured{}
+
+	if params.Instance.Spec.Observability.Metrics.EnableMetrics {
+		monitor, err := collector.Monitor(params.Config, params.Instance)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, *monitor)
+	}
+
+	// first, handle the create/update parts
+	if err := expectedMonitors(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected monitors: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteMonitors(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the monitors to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedMonitors(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		err := apply(ctx, params, &desired)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("the %s kind isn't available, the Prometheus operator needs to be installed to scrape the collector metrics: %w", desired.GetKind(), err)
+		} else if err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "kind", desired.GetKind(), "monitor.name", desired.GetName(), "monitor.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deleteMonitors(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	for _, list := range monitorLists() {
+		if err := params.Client.List(ctx, list, opts...); meta.IsNoMatchError(err) {
+			// the Prometheus operator isn't installed, so there's nothing to delete
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+
+		for i := range list.Items {
+			existing := list.Items[i]
+			del := true
+			for _, keep := range expected {
+				if keep.GetKind() == existing.GetKind() && keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+					del = false
+					break
+				}
+			}
+
+			if del {
+				if err := params.Client.Delete(ctx, &existing); err != nil {
+					return fmt.Errorf("failed to delete: %w", err)
+				}
+				params.Log.V(2).Info("deleted", "kind", existing.GetKind(), "monitor.name", existing.GetName(), "monitor.namespace", existing.GetNamespace())
+			}
+		}
+	}
+
+	return nil
+}
+
+func monitorLists() []*unstructured.UnstructuredList {
+	var lists []*unstructured.UnstructuredList
+	for _, gvk := range []schema.GroupVersionKind{collector.ServiceMonitorGVK, collector.PodMonitorGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		lists = append(lists, list)
+	}
+	return lists
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// NetworkPolicies reconciles the NetworkPolicies of the collector and target allocator pods.
+func NetworkPolicies(ctx context.Context, params Params) error {
+	desired := []networkingv1.NetworkPolicy{}
+	if params.Instance.Spec.NetworkPolicy != nil && params.Instance.Spec.Mode != v1alpha1.ModeSidecar {
+		desired = append(desired, collector.NetworkPolicy(params.Config, params.Log, params.Instance))
+		if params.Instance.Spec.TargetAllocator.Enabled {
+			desired = append(desired, targetallocator.NetworkPolicy(params.Instance))
+		}
+	}
+
+	// first, handle the create/update parts
+	if err := expectedNetworkPolicies(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected network policies: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteNetworkPolicies(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the network policies to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedNetworkPolicies(ctx context.Context, params Params, expected []networkingv1.NetworkPolicy) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "networkpolicy.name", desired.Name, "networkpolicy.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deleteNetworkPolicies(ctx context.Context, params Params, expected []networkingv1.NetworkPolicy) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &networkingv1.NetworkPolicyList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "networkpolicy.name", existing.Name, "networkpolicy.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestNetworkPolicies(t *testing.T) {
+	nns := types.NamespacedName{Namespace: "default", Name: "test-collector"}
+	taNns := types.NamespacedName{Namespace: "default", Name: "test-targetallocator"}
+	param := params()
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.NetworkPolicy = &v1alpha1.NetworkPolicySpec{}
+
+	t.Run("should create the network policy", func(t *testing.T) {
+		err := NetworkPolicies(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := networkingv1.NetworkPolicy{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Len(t, actual.Spec.Ingress, 1)
+		assert.Empty(t, actual.Spec.Ingress[0].From)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+
+		exists, err = populateObjectIfExists(t, &networkingv1.NetworkPolicy{}, taNns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should update the network policy and create the target allocator one", func(t *testing.T) {
+		from := []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "observability"}},
+		}}
+		param.Instance.Spec.NetworkPolicy = &v1alpha1.NetworkPolicySpec{From: from}
+		param.Instance.Spec.TargetAllocator.Enabled = true
+
+		err := NetworkPolicies(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := networkingv1.NetworkPolicy{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, from, actual.Spec.Ingress[0].From)
+
+		exists, err = populateObjectIfExists(t, &networkingv1.NetworkPolicy{}, taNns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should delete the network policies", func(t *testing.T) {
+		param.Instance.Spec.NetworkPolicy = nil
+
+		err := NetworkPolicies(context.Background(), param)
+		assert.NoError(t, err)
+
+		for _, name := range []types.NamespacedName{nns, taNns} {
+			exists, err := populateObjectIfExists(t, &networkingv1.NetworkPolicy{}, name)
+			assert.NoError(t, err)
+			assert.False(t, exists)
+		}
+	})
+}
@@ -18,14 +18,19 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
@@ -41,12 +46,27 @@ func Self(ctx context.Context, params Params) error {
 	if params.Instance.Status.Version == "" {
 		// a version is not set, otherwise let the upgrade mechanism take care of it!
 		changed.Status.Version = version.OpenTelemetryCollector()
+		if params.Instance.Spec.Version != "" {
+			changed.Status.Version = params.Instance.Spec.Version
+		}
 	}
 
-	if err := updateScaleSubResourceStatus(ctx, params.Client, &changed); err != nil {
+	if err := updateScaleSubResourceStatus(ctx, params.Client, params.Config, &changed); err != nil {
 		return fmt.Errorf("failed to update the scale subresource status for the OpenTelemetry CR: %w", err)
 	}
 
+	// the conditions might have been updated by other tasks during this reconciliation
+	current := v1alpha1.OpenTelemetryCollector{}
+	if err := params.Client.Get(ctx, client.ObjectKeyFromObject(&params.Instance), &current); err != nil {
+		return fmt.Errorf("failed to get the OpenTelemetry CR: %w", err)
+	}
+	changed.Status.Conditions = current.Status.Conditions
+	updateInsecureTLSCondition(params.Log, &changed)
+	updateUnsupportedArchitectureCondition(&changed)
+	if err := updateWorkloadReadiness(ctx, params.Client, &changed); err != nil {
+		return fmt.Errorf("failed to update the workload readiness for the OpenTelemetry CR: %w", err)
+	}
+
 	statusPatch := client.MergeFrom(&params.Instance)
 	if err := params.Client.Status().Patch(ctx, &changed, statusPatch); err != nil {
 		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
@@ -55,7 +75,104 @@ func Self(ctx context.Context, params Params) error {
 	return nil
 }
 
-func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+// updateInsecureTLSCondition reports the insecure TLS settings of the config, which are only rejected by the webhook
+// when the instance enforces secure settings.
+func updateInsecureTLSCondition(logger logr.Logger, changed *v1alpha1.OpenTelemetryCollector) {
+	// don't modify the conditions shared with params.Instance, or the status patch would miss the change
+	changed.Status.Conditions = append([]metav1.Condition{}, changed.Status.Conditions...)
+
+	cfg, err := adapters.ConfigFromString(changed.Spec.Config)
+	if err != nil {
+		logger.V(2).Info("failed to parse the config, skipping the TLS settings check", "error", err)
+		return
+	}
+
+	insecure := adapters.ConfigToInsecureTLSSettings(cfg)
+	if len(insecure) == 0 {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeInsecureTLSConfig)
+		return
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.ConditionTypeInsecureTLSConfig,
+		Status:  metav1.ConditionTrue,
+		Reason:  "InsecureTLSSettings",
+		Message: strings.Join(insecure, ", "),
+	})
+}
+
+// updateUnsupportedArchitectureCondition reports the node architectures the image is missing, as found by the webhook.
+func updateUnsupportedArchitectureCondition(changed *v1alpha1.OpenTelemetryCollector) {
+	missing := changed.Annotations[v1alpha1.UnsupportedArchitecturesAnnotation]
+	if missing == "" {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeUnsupportedArchitecture)
+		return
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.ConditionTypeUnsupportedArchitecture,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ImageArchitectureMissing",
+		Message: fmt.Sprintf("the image %s isn't available for the node architectures %s", changed.Spec.Image, missing),
+	})
+}
+
+// updateWorkloadReadiness reports the ready replicas over the desired replicas of the collector workload, and whether
+// all of them are ready with the WorkloadReady condition.
+func updateWorkloadReadiness(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollector) error {
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.Collector(*changed),
+	}
+
+	var desired, ready int32
+	switch changed.Spec.Mode {
+	case v1alpha1.ModeDeployment:
+		obj := &appsv1.Deployment{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return fmt.Errorf("failed to get deployment: %w", err)
+		}
+		desired, ready = replicasOrDefault(obj.Spec.Replicas), obj.Status.ReadyReplicas
+	case v1alpha1.ModeStatefulSet:
+		obj := &appsv1.StatefulSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return fmt.Errorf("failed to get statefulSet: %w", err)
+		}
+		desired, ready = replicasOrDefault(obj.Spec.Replicas), obj.Status.ReadyReplicas
+	case v1alpha1.ModeDaemonSet:
+		obj := &appsv1.DaemonSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return fmt.Errorf("failed to get daemonSet: %w", err)
+		}
+		desired, ready = obj.Status.DesiredNumberScheduled, obj.Status.NumberReady
+	default:
+		// the sidecars are part of the pods of the applications
+		changed.Status.Scale.StatusReplicas = ""
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1alpha1.ConditionTypeWorkloadReady)
+		return nil
+	}
+	changed.Status.Scale.StatusReplicas = fmt.Sprintf("%d/%d", ready, desired)
+
+	condition := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeWorkloadReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReplicasReady",
+		Message: fmt.Sprintf("%d/%d replicas are ready", ready, desired),
+	}
+	if ready < desired {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReplicasNotReady"
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	return nil
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, cfg config.Config, changed *v1alpha1.OpenTelemetryCollector) error {
 	mode := changed.Spec.Mode
 	if mode != v1alpha1.ModeDeployment && mode != v1alpha1.ModeStatefulSet {
 		changed.Status.Scale.Replicas = 0
@@ -67,7 +184,7 @@ func updateScaleSubResourceStatus(ctx context.Context, cli client.Client, change
 	name := naming.Collector(*changed)
 
 	// Set the scale selector
-	labels := collector.Labels(*changed, []string{})
+	labels := collector.Labels(*changed, cfg.LabelsFilter())
 	labels["app.kubernetes.io/name"] = name
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
 	if err != nil {
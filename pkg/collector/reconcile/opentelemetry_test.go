@@ -19,9 +19,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 func TestSelf(t *testing.T) {
@@ -40,3 +46,111 @@ func TestSelf(t *testing.T) {
 
 	})
 }
+
+func TestUpdateInsecureTLSCondition(t *testing.T) {
+	t.Run("should report insecure tls settings", func(t *testing.T) {
+		instance := params().Instance
+		instance.Spec.Config = `exporters:
+  otlp:
+    tls:
+      insecure: true
+`
+		updateInsecureTLSCondition(logger, &instance)
+
+		condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeInsecureTLSConfig)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "exporter 'otlp' sets 'tls.insecure: true'", condition.Message)
+	})
+
+	t.Run("should remove the condition once the settings are secure", func(t *testing.T) {
+		instance := params().Instance
+		instance.Status.Conditions = []metav1.Condition{{
+			Type:   v1alpha1.ConditionTypeInsecureTLSConfig,
+			Status: metav1.ConditionTrue,
+		}}
+		original := instance.Status.Conditions
+
+		updateInsecureTLSCondition(logger, &instance)
+
+		assert.Empty(t, instance.Status.Conditions)
+		assert.Len(t, original, 1)
+	})
+}
+
+func TestUpdateUnsupportedArchitectureCondition(t *testing.T) {
+	t.Run("should report the missing architectures", func(t *testing.T) {
+		instance := params().Instance
+		instance.Spec.Image = "my-registry/collector:1.0"
+		instance.Annotations = map[string]string{v1alpha1.UnsupportedArchitecturesAnnotation: "arm64"}
+
+		updateUnsupportedArchitectureCondition(&instance)
+
+		condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeUnsupportedArchitecture)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "the image my-registry/collector:1.0 isn't available for the node architectures arm64", condition.Message)
+	})
+
+	t.Run("should remove the condition once the image supports all the architectures", func(t *testing.T) {
+		instance := params().Instance
+		instance.Status.Conditions = []metav1.Condition{{
+			Type:   v1alpha1.ConditionTypeUnsupportedArchitecture,
+			Status: metav1.ConditionTrue,
+		}}
+
+		updateUnsupportedArchitectureCondition(&instance)
+
+		assert.Empty(t, instance.Status.Conditions)
+	})
+}
+
+func TestUpdateWorkloadReadiness(t *testing.T) {
+	t.Run("should report the replicas which aren't ready", func(t *testing.T) {
+		instance := params().Instance
+		instance.Name = "workload-ready"
+		instance.Spec.Mode = v1alpha1.ModeDaemonSet
+		ds := appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.Collector(instance),
+				Namespace: instance.Namespace,
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "workload-ready"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "workload-ready"}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "otc-container", Image: "otel"}}},
+				},
+			},
+		}
+		createObjectIfNotExists(t, ds.Name, &ds)
+		ds.Status = appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2}
+		require.NoError(t, k8sClient.Status().Update(context.Background(), &ds))
+
+		err := updateWorkloadReadiness(context.Background(), k8sClient, &instance)
+		require.NoError(t, err)
+
+		condition := meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeWorkloadReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "ReplicasNotReady", condition.Reason)
+		assert.Equal(t, "2/3 replicas are ready", condition.Message)
+		assert.Equal(t, "2/3", instance.Status.Scale.StatusReplicas)
+	})
+
+	t.Run("should remove the condition for sidecars", func(t *testing.T) {
+		instance := params().Instance
+		instance.Spec.Mode = v1alpha1.ModeSidecar
+		instance.Status.Scale.StatusReplicas = "1/1"
+		instance.Status.Conditions = []metav1.Condition{{
+			Type:   v1alpha1.ConditionTypeWorkloadReady,
+			Status: metav1.ConditionTrue,
+		}}
+
+		err := updateWorkloadReadiness(context.Background(), k8sClient, &instance)
+		require.NoError(t, err)
+
+		assert.Empty(t, instance.Status.Conditions)
+		assert.Empty(t, instance.Status.Scale.StatusReplicas)
+	})
+}
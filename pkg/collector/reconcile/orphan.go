@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/platform"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// Orphan removes the owner references to the instance from the resources created for it, so that they are
+// not garbage collected once the instance is deleted.
+func Orphan(ctx context.Context, params Params) error {
+	return forEachInstanceObject(ctx, params, func(existing client.Object) error {
+		var refs []metav1.OwnerReference
+		for _, ref := range existing.GetOwnerReferences() {
+			if ref.UID != params.Instance.UID {
+				refs = append(refs, ref)
+			}
+		}
+		if len(refs) == len(existing.GetOwnerReferences()) {
+			return nil
+		}
+
+		updated := existing.DeepCopyObject().(client.Object)
+		updated.SetOwnerReferences(refs)
+
+		patch := client.MergeFrom(existing)
+		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+			return fmt.Errorf("failed to remove the owner reference: %w", err)
+		}
+		params.Log.V(2).Info("orphaned", "kind", fmt.Sprintf("%T", existing), "name", existing.GetName(), "namespace", existing.GetNamespace())
+		return nil
+	})
+}
+
+// DeleteOwned deletes the resources owned by the instance, for the instances using the Foreground GC policy, and
+// returns whether some of them are still being deleted. The instance is only let go once all of them are gone.
+func DeleteOwned(ctx context.Context, params Params) (bool, error) {
+	remaining := false
+	err := forEachInstanceObject(ctx, params, func(existing client.Object) error {
+		owned := false
+		for _, ref := range existing.GetOwnerReferences() {
+			if ref.UID == params.Instance.UID {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			return nil
+		}
+
+		remaining = true
+		if existing.GetDeletionTimestamp() != nil {
+			return nil
+		}
+		// the dependents of the resources, like the pods of the workloads, are garbage collected in the background
+		if err := params.Client.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "kind", fmt.Sprintf("%T", existing), "name", existing.GetName(), "namespace", existing.GetNamespace())
+		return nil
+	})
+	return remaining, err
+}
+
+// Delete deletes the resources created for the instance. It's used for the resources of remote clusters, which aren't
+// garbage collected along with the instance.
+func Delete(ctx context.Context, params Params) error {
+	err := forEachInstanceObject(ctx, params, func(existing client.Object) error {
+		// the pods of the Jobs would be orphaned otherwise
+		if err := params.Client.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "kind", fmt.Sprintf("%T", existing), "name", existing.GetName(), "namespace", existing.GetNamespace())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return DeleteClusterRBAC(ctx, params)
+}
+
+// forEachInstanceObject calls fn with each of the resources created for the instance.
+func forEachInstanceObject(ctx context.Context, params Params, fn func(client.Object) error) error {
+	opts := instanceListOptions(params)
+	for _, list := range instanceLists(params) {
+		if err := params.Client.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("failed to extract list items: %w", err)
+		}
+
+		for _, item := range items {
+			existing, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			if err := fn(existing); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func instanceListOptions(params Params) []client.ListOption {
+	return []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+}
+
+// instanceLists returns the lists of the kinds of resources created for the instances.
+func instanceLists(params Params) []client.ObjectList {
+	lists := []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceAccountList{},
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+		&corev1.ServiceList{},
+		&appsv1.DeploymentList{},
+		&appsv1.DaemonSetList{},
+		&appsv1.StatefulSetList{},
+		&networkingv1.IngressList{},
+		&networkingv1.NetworkPolicyList{},
+		&batchv1.JobList{},
+		&v1alpha1.CollectorSmokeTestList{},
+	}
+	if params.Config.PodDisruptionBudgets() {
+		lists = append(lists, &policyv1.PodDisruptionBudgetList{})
+	}
+	if params.Config.AutoscalingVersion() == autodetect.AutoscalingVersionV2Beta2 {
+		lists = append(lists, &autoscalingv2beta2.HorizontalPodAutoscalerList{})
+	} else {
+		lists = append(lists, &autoscalingv2.HorizontalPodAutoscalerList{})
+	}
+	if collector.UsesVPA(params.Instance) {
+		// the VerticalPodAutoscaler kind only exists when the VerticalPodAutoscaler is installed
+		lists = append(lists, verticalPodAutoscalerList())
+	}
+	if collector.UsesKeda(params.Instance) {
+		// the ScaledObject kind only exists when KEDA is installed
+		lists = append(lists, scaledObjectList())
+	}
+	if (targetallocator.UsesMTLS(params.Instance) && params.Instance.Spec.TargetAllocator.MTLS.IssuerRef != nil) ||
+		collector.UsesReceiverTLS(params.Instance) {
+		// the Certificate kind only exists when cert-manager is installed
+		lists = append(lists, certificateList())
+	}
+	if params.Instance.Spec.Observability.Metrics.EnableMetrics {
+		// the monitor kinds only exist when the Prometheus operator is installed
+		for _, list := range monitorLists() {
+			lists = append(lists, list)
+		}
+	}
+	if params.Instance.Spec.Ingress.Type == v1alpha1.IngressTypeGateway {
+		// the route kinds only exist when the Gateway API is installed
+		for _, list := range gatewayRouteLists() {
+			lists = append(lists, list)
+		}
+	}
+	if params.Config.Platform() == platform.OpenShift {
+		lists = append(lists, &routev1.RouteList{})
+	}
+	return lists
+}
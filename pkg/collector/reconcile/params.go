@@ -15,6 +15,8 @@
 package reconcile
 
 import (
+	"net/http"
+
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -32,4 +34,11 @@ type Params struct {
 	Log      logr.Logger
 	Instance v1alpha1.OpenTelemetryCollector
 	Config   config.Config
+	// TargetAllocator is the TargetAllocator referenced by the instance, if any.
+	TargetAllocator *v1alpha1.TargetAllocator
+	// HTTPClient reads the telemetry metrics of the canary collector pods.
+	HTTPClient *http.Client
+	// UnwatchedConfigSources is set when the changes of the config sources aren't watched, like when rendering the
+	// manifests offline, so that the sources don't need to be labeled.
+	UnwatchedConfigSources bool
 }
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// PodDisruptionBudgets reconciles the PodDisruptionBudgets of the collector pods and of the replicated TargetAllocator pods.
+func PodDisruptionBudgets(ctx context.Context, params Params) error {
+	if !params.Config.PodDisruptionBudgets() {
+		params.Log.V(2).Info("skipping the pod disruption budgets, the cluster doesn't serve policy/v1")
+		return nil
+	}
+	desired := []policyv1.PodDisruptionBudget{}
+	if params.Instance.Spec.PodDisruptionBudget != nil && (params.Instance.Spec.Mode == v1alpha1.ModeDeployment || params.Instance.Spec.Mode == v1alpha1.ModeStatefulSet) {
+		desired = append(desired, collector.PodDisruptionBudget(params.Config, params.Log, params.Instance))
+	}
+	if params.Instance.Spec.TargetAllocator.Enabled && targetallocator.HighlyAvailable(params.Instance) {
+		desired = append(desired, targetallocator.PodDisruptionBudget(params.Instance))
+	}
+
+	// first, handle the create/update parts
+	if err := expectedPodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected pod disruption budgets: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deletePodDisruptionBudgets(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the pod disruption budgets to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedPodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "pdb.name", desired.Name, "pdb.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deletePodDisruptionBudgets(ctx context.Context, params Params, expected []policyv1.PodDisruptionBudget) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &policyv1.PodDisruptionBudgetList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "pdb.name", existing.Name, "pdb.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestPodDisruptionBudgets(t *testing.T) {
+	nns := types.NamespacedName{Namespace: "default", Name: "test-collector"}
+	maxUnavailable := intstr.FromInt(1)
+	param := params()
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+
+	t.Run("should create the pod disruption budget", func(t *testing.T) {
+		err := PodDisruptionBudgets(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, &maxUnavailable, actual.Spec.MaxUnavailable)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+	})
+
+	t.Run("should update the pod disruption budget", func(t *testing.T) {
+		minAvailable := intstr.FromString("50%")
+		param.Instance.Spec.PodDisruptionBudget = &v1alpha1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable}
+
+		err := PodDisruptionBudgets(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, &minAvailable, actual.Spec.MinAvailable)
+		assert.Nil(t, actual.Spec.MaxUnavailable)
+	})
+
+	t.Run("should delete the pod disruption budget", func(t *testing.T) {
+		param.Instance.Spec.PodDisruptionBudget = nil
+
+		err := PodDisruptionBudgets(context.Background(), param)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestTargetAllocatorPodDisruptionBudget(t *testing.T) {
+	nns := types.NamespacedName{Namespace: "default", Name: "test-targetallocator"}
+	two := int32(2)
+	param := params()
+	param.Instance.Spec.TargetAllocator.Enabled = true
+	param.Instance.Spec.TargetAllocator.Replicas = &two
+
+	t.Run("should create the pod disruption budget of the replicated target allocator", func(t *testing.T) {
+		err := PodDisruptionBudgets(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := policyv1.PodDisruptionBudget{}
+		exists, err := populateObjectIfExists(t, &actual, nns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, intstr.FromInt(1), *actual.Spec.MaxUnavailable)
+	})
+
+	t.Run("should delete the pod disruption budget of a single target allocator", func(t *testing.T) {
+		one := int32(1)
+		param.Instance.Spec.TargetAllocator.Replicas = &one
+
+		err := PodDisruptionBudgets(context.Background(), param)
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &policyv1.PodDisruptionBudget{}, nns)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const configHashAnnotation = "opentelemetry-operator-config/sha256"
+
+// preDeployCheckComponent is the component label of the pre-deploy check Jobs.
+const preDeployCheckComponent = "opentelemetry-precheck"
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// preDeployCheckPassed returns whether the collector update can be applied. When the instance has a pre-deploy check
+// and the running collector differs from the desired one, the check Job is started and the update holds until it succeeds.
+func preDeployCheckPassed(ctx context.Context, params Params) (bool, error) {
+	if params.Instance.Spec.PreDeployCheck == nil {
+		return true, nil
+	}
+
+	pending, err := collectorUpdatePending(ctx, params)
+	if err != nil || !pending {
+		return !pending, err
+	}
+
+	revision := collector.PreDeployCheckRevision(params.Config, params.Instance)
+	desired := collector.PreDeployCheckJob(params.Config, params.Log, params.Instance, revision)
+
+	existing := &batchv1.Job{}
+	nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	err = params.Client.Get(ctx, nns, existing)
+	if err != nil && k8serrors.IsNotFound(err) {
+		if err := deleteJobs(ctx, params, preDeployCheckComponent, desired.Name); err != nil {
+			return false, err
+		}
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return false, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := params.Client.Create(ctx, &desired); err != nil {
+			return false, fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "job.name", desired.Name, "job.namespace", desired.Namespace)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get: %w", err)
+	}
+
+	switch {
+	case existing.Status.Succeeded > 0:
+		return true, setJobCondition(ctx, params, v1alpha1.ConditionTypePreDeployCheckFailed, metav1.ConditionFalse, "JobSucceeded",
+			fmt.Sprintf("the pre-deploy check job %s succeeded", existing.Name))
+	case existing.Status.Failed > 0:
+		params.Log.Info("pre-deploy check failed, holding the collector update", "job.name", existing.Name)
+		return false, setJobCondition(ctx, params, v1alpha1.ConditionTypePreDeployCheckFailed, metav1.ConditionTrue, "JobFailed",
+			fmt.Sprintf("the pre-deploy check job %s failed, the collector update is on hold", existing.Name))
+	}
+
+	// the check is still running, the job status change triggers a new reconciliation
+	return false, nil
+}
+
+// collectorUpdatePending returns whether the running collector workload has a different image or config than the desired one.
+func collectorUpdatePending(ctx context.Context, params Params) (bool, error) {
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.Collector(params.Instance)}
+
+	// the config hash is only set on the pod template when the config changes roll out the pods
+	var annotations map[string]string
+	var template corev1.PodTemplateSpec
+	var err error
+	switch params.Instance.Spec.Mode {
+	case v1alpha1.ModeDeployment:
+		existing := &appsv1.Deployment{}
+		err = params.Client.Get(ctx, nns, existing)
+		annotations, template = existing.Annotations, existing.Spec.Template
+	case v1alpha1.ModeDaemonSet:
+		existing := &appsv1.DaemonSet{}
+		err = params.Client.Get(ctx, nns, existing)
+		annotations, template = existing.Annotations, existing.Spec.Template
+	case v1alpha1.ModeStatefulSet:
+		existing := &appsv1.StatefulSet{}
+		err = params.Client.Get(ctx, nns, existing)
+		annotations, template = existing.Annotations, existing.Spec.Template
+	default:
+		return false, nil
+	}
+	if k8serrors.IsNotFound(err) {
+		// nothing is running yet, so there's nothing to protect
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get: %w", err)
+	}
+
+	desired := collector.Container(params.Config, params.Log, params.Instance)
+	if annotations[configHashAnnotation] != collector.Annotations(params.Instance, params.Config.AnnotationsFilter())[configHashAnnotation] {
+		return true, nil
+	}
+	for _, c := range template.Spec.Containers {
+		if c.Name == desired.Name && c.Image != desired.Image {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteJobs deletes the Jobs of the given component of the instance, except the one to keep.
+func deleteJobs(ctx context.Context, params Params, component, keep string) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  component,
+		}),
+	}
+	list := &batchv1.JobList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		if existing.Name == keep {
+			continue
+		}
+		if err := params.Client.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "job.name", existing.Name, "job.namespace", existing.Namespace)
+	}
+
+	return nil
+}
+
+// setJobCondition sets the failure condition of the Job of a pre-deploy check or a build, the condition is only
+// added once the Job fails.
+func setJobCondition(ctx context.Context, params Params, conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	existing := meta.FindStatusCondition(params.Instance.Status.Conditions, conditionType)
+	if existing == nil && status == metav1.ConditionFalse {
+		// no need to report a check that never failed
+		return nil
+	}
+	if existing != nil && existing.Status == status && existing.Reason == reason && existing.Message == message {
+		return nil
+	}
+
+	changed := params.Instance.DeepCopy()
+	meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+
+	statusPatch := client.MergeFrom(&params.Instance)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestPreDeployCheckPassed(t *testing.T) {
+	param := params()
+	param.Instance.Name = "precheck"
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.PreDeployCheck = &v1alpha1.PreDeployCheckSpec{
+		Image:   "busybox",
+		Command: []string{"/check.sh"},
+	}
+
+	t.Run("should pass without a running collector", func(t *testing.T) {
+		passed, err := preDeployCheckPassed(context.Background(), param)
+		assert.NoError(t, err)
+		assert.True(t, passed)
+	})
+
+	deploy := collector.Deployment(param.Config, logger, param.Instance)
+	deploy.Spec.Template.Spec.Containers[0].Image = "previous-image"
+	require.NoError(t, k8sClient.Create(context.Background(), &deploy))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &deploy))
+	}()
+
+	revision := collector.PreDeployCheckRevision(param.Config, param.Instance)
+	nns := types.NamespacedName{Namespace: "default", Name: "precheck-precheck-" + revision}
+
+	t.Run("should start the check job and hold the update", func(t *testing.T) {
+		passed, err := preDeployCheckPassed(context.Background(), param)
+		assert.NoError(t, err)
+		assert.False(t, passed)
+
+		exists, err := populateObjectIfExists(t, &batchv1.Job{}, nns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should pass once the check job succeeded", func(t *testing.T) {
+		job := &batchv1.Job{}
+		require.NoError(t, k8sClient.Get(context.Background(), nns, job))
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		require.NoError(t, k8sClient.Status().Update(context.Background(), job))
+
+		passed, err := preDeployCheckPassed(context.Background(), param)
+		assert.NoError(t, err)
+		assert.True(t, passed)
+	})
+}
+
+func TestCollectorUpdatePendingConfigReload(t *testing.T) {
+	param := params()
+	param.Instance.Name = "precheck-reload"
+	param.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	param.Instance.Spec.ConfigReloadStrategy = v1alpha1.ConfigReloadStrategyReload
+
+	deploy := collector.Deployment(param.Config, logger, param.Instance)
+	require.NoError(t, k8sClient.Create(context.Background(), &deploy))
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &deploy))
+	}()
+
+	pending, err := collectorUpdatePending(context.Background(), param)
+	assert.NoError(t, err)
+	assert.False(t, pending)
+
+	// the config changes don't show up in the pod template, but still need checking
+	param.Instance.Spec.Config += "\n# changed\n"
+	pending, err = collectorUpdatePending(context.Background(), param)
+	assert.NoError(t, err)
+	assert.True(t, pending)
+}
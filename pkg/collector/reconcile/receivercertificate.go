@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// receiverTLSChecksumAnnotation restarts the collector pods when the receiver certificates are renewed.
+const receiverTLSChecksumAnnotation = "checksum/receiver-tls"
+
+// ReceiverCertificates reconciles the cert-manager Certificates of the receivers serving TLS.
+func ReceiverCertificates(ctx context.Context, params Params) error {
+	desired := []unstructured.Unstructured{}
+	if collector.UsesReceiverTLS(params.Instance) {
+		certificates, err := collector.ReceiverCertificates(params.Config, params.Instance)
+		if err != nil {
+			return fmt.Errorf("failed to build the receiver certificates: %w", err)
+		}
+		desired = append(desired, certificates...)
+	}
+
+	// first, handle the create/update parts
+	if err := expectedCertificates(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected certificates: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteCertificates(ctx, params, "opentelemetry-collector", desired); err != nil {
+		return fmt.Errorf("failed to reconcile the certificates to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// setReceiverTLSChecksum stamps the checksum of the receiver certificates on the pod template, so that the collector
+// pods are restarted with the renewed certificates. The secrets not issued yet are skipped, their creation triggers
+// a new reconciliation.
+func setReceiverTLSChecksum(ctx context.Context, params Params, template *corev1.PodTemplateSpec) error {
+	names, err := collector.ReceiverCertificateNames(params.Instance)
+	if err != nil || len(names) == 0 {
+		// the config errors are reported by the collector status
+		return nil
+	}
+
+	h := sha256.New()
+	for _, name := range names {
+		secret := &corev1.Secret{}
+		nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: name}
+		if err := params.Client.Get(ctx, nns, secret); k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get the receiver certificate secret: %w", err)
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(secret.Data[corev1.TLSCertKey])
+		h.Write([]byte{0})
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[receiverTLSChecksumAnnotation] = fmt.Sprintf("%x", h.Sum(nil))
+	return nil
+}
+
+// ReceiverCertificateReferenced returns whether the object is the secret of one of the receiver certificates of the
+// instance.
+func ReceiverCertificateReferenced(instance v1alpha1.OpenTelemetryCollector, obj client.Object) bool {
+	if _, ok := obj.(*corev1.Secret); !ok || obj.GetNamespace() != instance.Namespace {
+		return false
+	}
+	names, err := collector.ReceiverCertificateNames(instance)
+	if err != nil {
+		return false
+	}
+	for _, name := range names {
+		if name == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// the operator can only grant the permissions it holds itself, the other resources are already managed by it
+// +kubebuilder:rbac:groups="",resources=endpoints;events;namespaces;nodes;nodes/metrics;nodes/proxy;nodes/stats;pods;replicationcontrollers;resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch
+
+// Roles reconciles the role required by the Kubernetes receivers of the instance.
+func Roles(ctx context.Context, params Params) error {
+	desired := desiredRoles(params)
+
+	// first, handle the create/update parts
+	if err := expectedRoles(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected roles: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteRoles(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the roles to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// RoleBindings reconciles the role binding of the collector's role to its service account.
+func RoleBindings(ctx context.Context, params Params) error {
+	desired := desiredRoleBindings(params)
+
+	// first, handle the create/update parts
+	if err := expectedRoleBindings(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected role bindings: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteRoleBindings(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the role bindings to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func desiredRoles(params Params) []rbacv1.Role {
+	if params.Instance.Spec.Mode == v1alpha1.ModeSidecar {
+		return []rbacv1.Role{}
+	}
+	role := collector.Role(params.Config, params.Log, params.Instance)
+	if len(role.Rules) == 0 {
+		return []rbacv1.Role{}
+	}
+	return []rbacv1.Role{role}
+}
+
+func desiredRoleBindings(params Params) []rbacv1.RoleBinding {
+	if len(desiredRoles(params)) == 0 {
+		return []rbacv1.RoleBinding{}
+	}
+	return []rbacv1.RoleBinding{collector.RoleBinding(params.Config, params.Instance)}
+}
+
+func expectedRoles(ctx context.Context, params Params, expected []rbacv1.Role) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "role.name", desired.Name, "role.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func expectedRoleBindings(ctx context.Context, params Params, expected []rbacv1.RoleBinding) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &rbacv1.RoleBinding{}
+		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if clientErr := apply(ctx, params, &desired); clientErr != nil {
+				return fmt.Errorf("failed to create: %w", clientErr)
+			}
+			params.Log.V(2).Info("created", "rolebinding.name", desired.Name, "rolebinding.namespace", desired.Namespace)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		if existing.RoleRef != desired.RoleRef {
+			// the role reference of a binding is immutable, so it has to be recreated
+			if err := params.Client.Delete(ctx, existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			if err := apply(ctx, params, &desired); err != nil {
+				return fmt.Errorf("failed to create: %w", err)
+			}
+			params.Log.V(2).Info("recreated", "rolebinding.name", desired.Name, "rolebinding.namespace", desired.Namespace)
+			continue
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "rolebinding.name", desired.Name, "rolebinding.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deleteRoles(ctx context.Context, params Params, expected []rbacv1.Role) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &rbacv1.RoleList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "role.name", existing.Name, "role.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
+
+func deleteRoleBindings(ctx context.Context, params Params, expected []rbacv1.RoleBinding) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := &rbacv1.RoleBindingList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "rolebinding.name", existing.Name, "rolebinding.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
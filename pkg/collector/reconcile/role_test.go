@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+const k8sEventsConfig = `receivers:
+  k8s_events:
+exporters:
+  logging:
+service:
+  pipelines:
+    logs:
+      receivers: [k8s_events]
+      exporters: [logging]
+`
+
+func TestDesiredRoles(t *testing.T) {
+	t.Run("should not create a role without kubernetes receivers", func(t *testing.T) {
+		assert.Empty(t, desiredRoles(params()))
+		assert.Empty(t, desiredRoleBindings(params()))
+	})
+
+	t.Run("should create a role for the kubernetes receivers", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Config = k8sEventsConfig
+
+		roles := desiredRoles(p)
+		assert.Len(t, roles, 1)
+		assert.NotEmpty(t, roles[0].Rules)
+		assert.Len(t, desiredRoleBindings(p), 1)
+	})
+}
+
+func TestExpectedRoles(t *testing.T) {
+	p := params()
+	p.Instance.Spec.Config = k8sEventsConfig
+
+	t.Run("should create the role and its binding", func(t *testing.T) {
+		err := expectedRoles(context.Background(), p, desiredRoles(p))
+		assert.NoError(t, err)
+		err = expectedRoleBindings(context.Background(), p, desiredRoleBindings(p))
+		assert.NoError(t, err)
+
+		actual := rbacv1.Role{}
+		exists, err := populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, instanceUID, actual.OwnerReferences[0].UID)
+
+		binding := rbacv1.RoleBinding{}
+		exists, err = populateObjectIfExists(t, &binding, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, collector.ServiceAccountName(p.Instance), binding.Subjects[0].Name)
+	})
+
+	t.Run("should update the rules of the existing role", func(t *testing.T) {
+		existing := rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-collector",
+				Namespace: "default",
+			},
+		}
+		createObjectIfNotExists(t, "test-collector", &existing)
+
+		err := expectedRoles(context.Background(), p, desiredRoles(p))
+		assert.NoError(t, err)
+
+		actual := rbacv1.Role{}
+		_, err = populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: "test-collector"})
+		assert.NoError(t, err)
+		assert.Equal(t, collector.RoleRules(p.Log, p.Instance), actual.Rules)
+	})
+}
+
+func TestDeleteRoles(t *testing.T) {
+	t.Run("should delete the role and binding no longer needed", func(t *testing.T) {
+		labels := map[string]string{
+			"app.kubernetes.io/instance":   "default.test",
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}
+		role := rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-delete-collector",
+				Namespace: "default",
+				Labels:    labels,
+			},
+		}
+		createObjectIfNotExists(t, "test-delete-collector", &role)
+		binding := rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-delete-collector",
+				Namespace: "default",
+				Labels:    labels,
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "test-delete-collector"},
+		}
+		createObjectIfNotExists(t, "test-delete-collector", &binding)
+
+		err := deleteRoles(context.Background(), params(), desiredRoles(params()))
+		assert.NoError(t, err)
+		err = deleteRoleBindings(context.Background(), params(), desiredRoleBindings(params()))
+		assert.NoError(t, err)
+
+		exists, err := populateObjectIfExists(t, &rbacv1.Role{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = populateObjectIfExists(t, &rbacv1.RoleBinding{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -17,11 +17,10 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	routev1 "github.com/openshift/api/route/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -30,6 +29,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
+const defaultRouteHostnameTemplate = "{port}.{hostname}"
+
 func desiredRoutes(_ context.Context, params Params) []routev1.Route {
 	var tlsCfg *routev1.TLSConfig
 	switch params.Instance.Spec.Ingress.Route.Termination {
@@ -71,7 +72,7 @@ func desiredRoutes(_ context.Context, params Params) []routev1.Route {
 				},
 			},
 			Spec: routev1.RouteSpec{
-				Host: p.Name + "." + params.Instance.Spec.Ingress.Hostname,
+				Host: routeHost(params.Instance, p.Name),
 				Path: "/" + p.Name,
 				To: routev1.RouteTargetReference{
 					Kind: "Service",
@@ -89,6 +90,24 @@ func desiredRoutes(_ context.Context, params Params) []routev1.Route {
 	return routes
 }
 
+// routeHost renders the host of the route exposing the given port. Without a template nor an ingress hostname,
+// the host is left to the router, which generates one.
+func routeHost(otelcol v1alpha1.OpenTelemetryCollector, port string) string {
+	template := otelcol.Spec.Ingress.Route.HostnameTemplate
+	if template == "" {
+		if otelcol.Spec.Ingress.Hostname == "" {
+			return ""
+		}
+		template = defaultRouteHostnameTemplate
+	}
+	return strings.NewReplacer(
+		"{port}", port,
+		"{hostname}", otelcol.Spec.Ingress.Hostname,
+		"{name}", otelcol.Name,
+		"{namespace}", otelcol.Namespace,
+	).Replace(template)
+}
+
 // Routes reconciles the route(s) required for the instance in the current context.
 func Routes(ctx context.Context, params Params) error {
 	if params.Instance.Spec.Ingress.Type != v1alpha1.IngressTypeRoute {
@@ -129,43 +148,7 @@ func expectedRoutes(ctx context.Context, params Params, expected []routev1.Route
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 
-		existing := &routev1.Route{}
-		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
-		err := params.Client.Get(ctx, nns, existing)
-		if err != nil && k8serrors.IsNotFound(err) {
-			if err = params.Client.Create(ctx, &desired); err != nil {
-				return fmt.Errorf("failed to create: %w", err)
-			}
-			params.Log.V(2).Info("created", "route.name", desired.Name, "route.namespace", desired.Namespace)
-			continue
-		} else if err != nil {
-			return fmt.Errorf("failed to get: %w", err)
-		}
-
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-		updated.Spec.To = desired.Spec.To
-		updated.Spec.TLS = desired.Spec.TLS
-		updated.Spec.Port = desired.Spec.Port
-		updated.Spec.WildcardPolicy = desired.Spec.WildcardPolicy
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
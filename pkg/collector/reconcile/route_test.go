@@ -144,6 +144,54 @@ func TestDesiredRoutes(t *testing.T) {
 	})
 }
 
+func TestRouteHost(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: "observability",
+		},
+	}
+
+	for _, tt := range []struct {
+		desc     string
+		hostname string
+		template string
+		expected string
+	}{
+		{
+			desc:     "no hostname",
+			expected: "",
+		},
+		{
+			desc:     "default template",
+			hostname: "example.com",
+			expected: "otlp-grpc.example.com",
+		},
+		{
+			desc:     "custom template",
+			hostname: "apps.example.com",
+			template: "{port}-{name}-{namespace}.{hostname}",
+			expected: "otlp-grpc-gateway-observability.apps.example.com",
+		},
+		{
+			desc:     "custom template without hostname",
+			template: "{name}.example.com",
+			expected: "gateway.example.com",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol.Spec.Ingress = v1alpha1.Ingress{
+				Type:     v1alpha1.IngressTypeRoute,
+				Hostname: tt.hostname,
+				Route: v1alpha1.OpenShiftRoute{
+					HostnameTemplate: tt.template,
+				},
+			}
+			assert.Equal(t, tt.expected, routeHost(otelcol, "otlp-grpc"))
+		})
+	}
+}
+
 func TestExpectedRoutes(t *testing.T) {
 	t.Run("should create and update route entry", func(t *testing.T) {
 		ctx := context.Background()
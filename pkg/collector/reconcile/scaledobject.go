@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+
+// ScaledObjects reconciles the KEDA ScaledObjects of the instances scaled with KEDA.
+func ScaledObjects(ctx context.Context, params Params) error {
+	desired := []unstructured.Unstructured{}
+
+	if collector.UsesKeda(params.Instance) {
+		scaledObject, err := collector.ScaledObject(params.Config, params.Log, params.Instance)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, *scaledObject)
+	}
+
+	// first, handle the create/update parts
+	if err := expectedScaledObjects(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected scaled objects: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteScaledObjects(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the scaled objects to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedScaledObjects(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		err := apply(ctx, params, &desired)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("the ScaledObject kind isn't available, KEDA needs to be installed to scale the collector on its triggers: %w", err)
+		} else if err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "scaledobject.name", desired.GetName(), "scaledobject.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deleteScaledObjects(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := scaledObjectList()
+	if err := params.Client.List(ctx, list, opts...); meta.IsNoMatchError(err) {
+		// KEDA isn't installed, so there's nothing to delete
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "scaledobject.name", existing.GetName(), "scaledobject.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+func scaledObjectList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(collector.ScaledObjectGVK.GroupVersion().WithKind(collector.ScaledObjectGVK.Kind + "List"))
+	return list
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/autodetect"
+)
+
+func TestScaledObjectsWithoutKeda(t *testing.T) {
+	t.Run("should skip the deletion when KEDA isn't installed", func(t *testing.T) {
+		err := ScaledObjects(context.Background(), params())
+		assert.NoError(t, err)
+	})
+
+	t.Run("should report that KEDA is required", func(t *testing.T) {
+		p := paramsWithHPA(autodetect.AutoscalingVersionV2)
+		p.Instance.Spec.Autoscaler.Keda = &v1alpha1.KedaSpec{
+			Triggers: []v1alpha1.KedaTrigger{{Type: "kafka", Metadata: map[string]string{"topic": "otlp_spans"}}},
+		}
+
+		err := ScaledObjects(context.Background(), p)
+		assert.ErrorContains(t, err, "KEDA needs to be installed")
+	})
+}
@@ -17,13 +17,11 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -54,10 +52,11 @@ func Services(ctx context.Context, params Params) error {
 				desired = append(desired, *svc)
 			}
 		}
+		desired = append(desired, splitServices(params)...)
 	}
 
 	if params.Instance.Spec.TargetAllocator.Enabled {
-		desired = append(desired, desiredTAService(params))
+		desired = append(desired, targetallocator.Service(params.Instance))
 	}
 
 	// first, handle the create/update parts
@@ -74,7 +73,7 @@ func Services(ctx context.Context, params Params) error {
 }
 
 func desiredService(ctx context.Context, params Params) *corev1.Service {
-	labels := collector.Labels(params.Instance, []string{})
+	labels := collector.Labels(params.Instance, params.Config.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.Service(params.Instance)
 
 	config, err := adapters.ConfigFromString(params.Instance.Spec.Config)
@@ -97,6 +96,7 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 		//
 		// in the first case, we remove the port we inferred from the list
 		// in the second case, we rename our inferred port to something like "port-%d"
+		// the CR ports without an app protocol inherit the one inferred for the same port number
 		portNumbers, portNames := extractPortNumbersAndNames(params.Instance.Spec.Ports)
 		resultingInferredPorts := []corev1.ServicePort{}
 		for _, inferred := range ports {
@@ -105,7 +105,7 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 			}
 		}
 
-		ports = append(params.Instance.Spec.Ports, resultingInferredPorts...)
+		ports = append(withInferredAppProtocols(params.Instance.Spec.Ports, ports), resultingInferredPorts...)
 	}
 
 	// if we have no ports, we don't need a service
@@ -114,43 +114,42 @@ func desiredService(ctx context.Context, params Params) *corev1.Service {
 		return nil
 	}
 
-	return &corev1.Service{
+	svc := params.Instance.Spec.Service
+	if len(svc.NodePorts) > 0 && (svc.Type == corev1.ServiceTypeNodePort || svc.Type == corev1.ServiceTypeLoadBalancer) {
+		// copy to avoid modifying params.Instance.Spec.Ports
+		withNodePorts := make([]corev1.ServicePort, 0, len(ports))
+		for _, p := range ports {
+			if nodePort, ok := svc.NodePorts[p.Name]; ok {
+				p.NodePort = nodePort
+			}
+			withNodePorts = append(withNodePorts, p)
+		}
+		ports = withNodePorts
+	}
+
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Service(params.Instance),
 			Namespace:   params.Instance.Namespace,
 			Labels:      labels,
-			Annotations: params.Instance.Annotations,
+			Annotations: collector.PropagatedAnnotations(params.Instance, params.Config.AnnotationsFilter()),
 		},
 		Spec: corev1.ServiceSpec{
-			Selector:  collector.SelectorLabels(params.Instance),
-			ClusterIP: "",
-			Ports:     ports,
+			Type:           svc.Type,
+			Selector:       collector.SelectorLabels(params.Instance),
+			ClusterIP:      "",
+			Ports:          ports,
+			IPFamilyPolicy: svc.IPFamilyPolicy,
+			IPFamilies:     svc.IPFamilies,
 		},
 	}
-}
-
-func desiredTAService(params Params) corev1.Service {
-	labels := targetallocator.Labels(params.Instance)
-	labels["app.kubernetes.io/name"] = naming.TAService(params.Instance)
 
-	selector := targetallocator.Labels(params.Instance)
-	selector["app.kubernetes.io/name"] = naming.TargetAllocator(params.Instance)
-
-	return corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      naming.TAService(params.Instance),
-			Namespace: params.Instance.Namespace,
-			Labels:    labels,
-		},
-		Spec: corev1.ServiceSpec{
-			Selector: selector,
-			Ports: []corev1.ServicePort{{
-				Name:       "targetallocation",
-				Port:       80,
-				TargetPort: intstr.FromInt(8080),
-			}},
-		},
+	if svc.Type == corev1.ServiceTypeLoadBalancer {
+		service.Spec.LoadBalancerIP = svc.LoadBalancerIP
+		service.Spec.LoadBalancerSourceRanges = svc.LoadBalancerSourceRanges
 	}
+
+	return service
 }
 
 func headless(ctx context.Context, params Params) *corev1.Service {
@@ -171,12 +170,119 @@ func headless(ctx context.Context, params Params) *corev1.Service {
 	}
 	h.Annotations = annotations
 
+	// a headless service can't be exposed outside of the cluster
+	h.Spec.Type = corev1.ServiceTypeClusterIP
+	h.Spec.LoadBalancerIP = ""
+	h.Spec.LoadBalancerSourceRanges = nil
+	ports := make([]corev1.ServicePort, 0, len(h.Spec.Ports))
+	for _, p := range h.Spec.Ports {
+		p.NodePort = 0
+		ports = append(ports, p)
+	}
+	h.Spec.Ports = ports
+
 	h.Spec.ClusterIP = "None"
 	return h
 }
 
+// splitServices returns the Services of each receiver, or receiver port, when the instance splits its Service.
+func splitServices(params Params) []corev1.Service {
+	split := params.Instance.Spec.Service.Split
+	if split == "" {
+		return nil
+	}
+
+	config, err := adapters.ConfigFromString(params.Instance.Spec.Config)
+	if err != nil {
+		params.Log.Error(err, "couldn't extract the configuration from the context")
+		return nil
+	}
+
+	portsByReceiver, err := adapters.ConfigToReceiverPortsByReceiver(params.Log, config)
+	if err != nil {
+		params.Log.Error(err, "couldn't build the split services for this instance")
+		return nil
+	}
+
+	// the ports of the CR take precedence over the inferred ones, like for the collector service
+	portNumbers, portNames := extractPortNumbersAndNames(params.Instance.Spec.Ports)
+	groups := map[string][]corev1.ServicePort{}
+	for receiver, ports := range portsByReceiver {
+		for _, inferred := range ports {
+			filtered := filterPort(params.Log, inferred, portNumbers, portNames)
+			if filtered == nil {
+				continue
+			}
+			key := receiver
+			if split == v1alpha1.ServiceSplitProtocol {
+				key = filtered.Name
+			}
+			groups[key] = append(groups[key], *filtered)
+		}
+	}
+	var inferred []corev1.ServicePort
+	for _, ports := range portsByReceiver {
+		inferred = append(inferred, ports...)
+	}
+	for _, p := range withInferredAppProtocols(params.Instance.Spec.Ports, inferred) {
+		groups[p.Name] = append(groups[p.Name], p)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	services := make([]corev1.Service, 0, len(keys))
+	for _, key := range keys {
+		ports := groups[key]
+		sort.Slice(ports, func(i, j int) bool {
+			return ports[i].Name < ports[j].Name
+		})
+
+		settings := params.Instance.Spec.Service.SplitServices[key]
+		name := naming.SplitService(params.Instance, key)
+		clusterIP := ""
+		if settings.Headless {
+			// the cluster IP can't be removed from an existing service, so the headless services get their own name
+			name = naming.SplitService(params.Instance, key+"-headless")
+			clusterIP = corev1.ClusterIPNone
+		}
+
+		labels := collector.Labels(params.Instance, params.Config.LabelsFilter())
+		labels["app.kubernetes.io/name"] = name
+
+		annotations := map[string]string{}
+		for k, v := range collector.PropagatedAnnotations(params.Instance, params.Config.AnnotationsFilter()) {
+			annotations[k] = v
+		}
+		for k, v := range settings.Annotations {
+			annotations[k] = v
+		}
+
+		services = append(services, corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   params.Instance.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Type:           corev1.ServiceTypeClusterIP,
+				Selector:       collector.SelectorLabels(params.Instance),
+				ClusterIP:      clusterIP,
+				Ports:          ports,
+				IPFamilyPolicy: params.Instance.Spec.Service.IPFamilyPolicy,
+				IPFamilies:     params.Instance.Spec.Service.IPFamilies,
+			},
+		})
+	}
+	return services
+}
+
 func monitoringService(ctx context.Context, params Params) *corev1.Service {
-	labels := collector.Labels(params.Instance, []string{})
+	labels := collector.Labels(params.Instance, params.Config.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.MonitoringService(params.Instance)
 
 	return &corev1.Service{
@@ -184,7 +290,7 @@ func monitoringService(ctx context.Context, params Params) *corev1.Service {
 			Name:        naming.MonitoringService(params.Instance),
 			Namespace:   params.Instance.Namespace,
 			Labels:      labels,
-			Annotations: params.Instance.Annotations,
+			Annotations: collector.PropagatedAnnotations(params.Instance, params.Config.AnnotationsFilter()),
 		},
 		Spec: corev1.ServiceSpec{
 			Selector:  collector.SelectorLabels(params.Instance),
@@ -193,6 +299,8 @@ func monitoringService(ctx context.Context, params Params) *corev1.Service {
 				Name: "monitoring",
 				Port: 8888,
 			}},
+			IPFamilyPolicy: params.Instance.Spec.Service.IPFamilyPolicy,
+			IPFamilies:     params.Instance.Spec.Service.IPFamilies,
 		},
 	}
 }
@@ -205,41 +313,7 @@ func expectedServices(ctx context.Context, params Params, expected []corev1.Serv
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 
-		existing := &corev1.Service{}
-		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
-		err := params.Client.Get(ctx, nns, existing)
-		if err != nil && k8serrors.IsNotFound(err) {
-			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
-				return fmt.Errorf("failed to create: %w", clientErr)
-			}
-			params.Log.V(2).Info("created", "service.name", desired.Name, "service.namespace", desired.Namespace)
-			continue
-		} else if err != nil {
-			return fmt.Errorf("failed to get: %w", err)
-		}
-
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
-		}
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
-
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
-		}
-		updated.Spec.Ports = desired.Spec.Ports
-		updated.Spec.Selector = desired.Spec.Selector
-
-		patch := client.MergeFrom(existing)
-
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
@@ -309,6 +383,29 @@ func filterPort(logger logr.Logger, candidate corev1.ServicePort, portNumbers ma
 	return &candidate
 }
 
+// withInferredAppProtocols returns a copy of the CR ports where the ports without an app protocol get the one
+// inferred from the receiver exposing the same port number.
+func withInferredAppProtocols(ports []corev1.ServicePort, inferred []corev1.ServicePort) []corev1.ServicePort {
+	appProtocols := map[int32]*string{}
+	for _, p := range inferred {
+		if p.AppProtocol != nil {
+			appProtocols[p.Port] = p.AppProtocol
+		}
+	}
+
+	result := make([]corev1.ServicePort, 0, len(ports))
+	for _, p := range ports {
+		if p.AppProtocol == nil {
+			if appProtocol, ok := appProtocols[p.Port]; ok {
+				value := *appProtocol
+				p.AppProtocol = &value
+			}
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
 func extractPortNumbersAndNames(ports []corev1.ServicePort) (map[int32]bool, map[string]bool) {
 	numbers := map[int32]bool{}
 	names := map[string]bool{}
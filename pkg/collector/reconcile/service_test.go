@@ -95,6 +95,28 @@ func TestFilterPort(t *testing.T) {
 	}
 }
 
+func TestWithInferredAppProtocols(t *testing.T) {
+	grpc, http, h2c := "grpc", "http", "kubernetes.io/h2c"
+	inferred := []v1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317, AppProtocol: &grpc},
+		{Name: "otlp-http", Port: 4318, AppProtocol: &http},
+	}
+	ports := []v1.ServicePort{
+		{Name: "grpc", Port: 4317},
+		{Name: "http", Port: 4318, AppProtocol: &h2c},
+		{Name: "web", Port: 80},
+	}
+
+	actual := withInferredAppProtocols(ports, inferred)
+
+	assert.Equal(t, []v1.ServicePort{
+		{Name: "grpc", Port: 4317, AppProtocol: &grpc},
+		{Name: "http", Port: 4318, AppProtocol: &h2c},
+		{Name: "web", Port: 80},
+	}, actual)
+	assert.Nil(t, ports[0].AppProtocol, "the CR ports should not be modified")
+}
+
 func TestDesiredService(t *testing.T) {
 	t.Run("should return nil service for unknown receiver and protocol", func(t *testing.T) {
 		params := Params{
@@ -129,6 +151,55 @@ func TestDesiredService(t *testing.T) {
 		assert.Equal(t, expected, *actual)
 
 	})
+	t.Run("should inherit the inferred app protocol for the ports of the CR", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Ports = []v1.ServicePort{{Name: "jaeger", Protocol: "TCP", Port: 14250}}
+
+		actual := desiredService(context.Background(), p)
+
+		grpc := "grpc"
+		assert.Equal(t, []v1.ServicePort{{Name: "jaeger", Protocol: "TCP", Port: 14250, AppProtocol: &grpc}}, actual.Spec.Ports)
+		assert.Nil(t, p.Instance.Spec.Ports[0].AppProtocol)
+	})
+	t.Run("should return service with node ports and load balancer settings", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Service = v1alpha1.ServiceSpec{
+			Type:                     v1.ServiceTypeLoadBalancer,
+			NodePorts:                map[string]int32{"web": 30080},
+			LoadBalancerIP:           "10.0.0.1",
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+		}
+
+		actual := desiredService(context.Background(), p)
+
+		assert.Equal(t, v1.ServiceTypeLoadBalancer, actual.Spec.Type)
+		assert.Equal(t, "10.0.0.1", actual.Spec.LoadBalancerIP)
+		assert.Equal(t, []string{"10.0.0.0/8"}, actual.Spec.LoadBalancerSourceRanges)
+		assert.Equal(t, int32(30080), actual.Spec.Ports[0].NodePort)
+		assert.Zero(t, p.Instance.Spec.Ports[0].NodePort)
+
+		h := headless(context.Background(), p)
+		assert.Equal(t, v1.ServiceTypeClusterIP, h.Spec.Type)
+		assert.Empty(t, h.Spec.LoadBalancerIP)
+		assert.Zero(t, h.Spec.Ports[0].NodePort)
+	})
+	t.Run("should return dual-stack service", func(t *testing.T) {
+		p := params()
+		policy := v1.IPFamilyPolicyRequireDualStack
+		p.Instance.Spec.Service = v1alpha1.ServiceSpec{
+			IPFamilyPolicy: &policy,
+			IPFamilies:     []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol},
+		}
+
+		actual := desiredService(context.Background(), p)
+
+		assert.Equal(t, &policy, actual.Spec.IPFamilyPolicy)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, actual.Spec.IPFamilies)
+
+		h := headless(context.Background(), p)
+		assert.Equal(t, &policy, h.Spec.IPFamilyPolicy)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, h.Spec.IPFamilies)
+	})
 
 }
 
@@ -217,6 +288,69 @@ func TestHeadlessService(t *testing.T) {
 	})
 }
 
+func TestSplitServices(t *testing.T) {
+	t.Run("should return no service without split", func(t *testing.T) {
+		assert.Empty(t, splitServices(params()))
+	})
+
+	t.Run("should return a service for each receiver", func(t *testing.T) {
+		// prepare
+		p, err := newParams("", testFileGatewayRoutes)
+		assert.NoError(t, err)
+		p.Instance.Spec.Service.Split = v1alpha1.ServiceSplitReceiver
+
+		// test
+		actual := splitServices(p)
+
+		// verify
+		assert.Len(t, actual, 3)
+		assert.Equal(t, "test-collector-otlp", actual[0].Name)
+		assert.Equal(t, []string{"otlp-grpc", "otlp-http"}, portNames(actual[0].Spec.Ports))
+		assert.Equal(t, "test-collector-statsd", actual[1].Name)
+		assert.Equal(t, []string{"statsd"}, portNames(actual[1].Spec.Ports))
+		assert.Equal(t, "test-collector-web", actual[2].Name)
+		assert.Equal(t, []string{"web"}, portNames(actual[2].Spec.Ports))
+		for _, svc := range actual {
+			assert.Equal(t, v1.ServiceTypeClusterIP, svc.Spec.Type)
+			assert.Equal(t, "", svc.Spec.ClusterIP)
+			assert.Equal(t, collector.SelectorLabels(p.Instance), svc.Spec.Selector)
+		}
+	})
+
+	t.Run("should return a service for each protocol", func(t *testing.T) {
+		// prepare
+		p, err := newParams("", testFileGatewayRoutes)
+		assert.NoError(t, err)
+		p.Instance.Annotations = map[string]string{"team": "observability"}
+		p.Instance.Spec.Service.Split = v1alpha1.ServiceSplitProtocol
+		p.Instance.Spec.Service.SplitServices = map[string]v1alpha1.SplitServiceSpec{
+			"otlp-grpc": {Headless: true},
+			"web":       {Annotations: map[string]string{"prometheus.io/scrape": "true"}},
+		}
+
+		// test
+		actual := splitServices(p)
+
+		// verify
+		assert.Len(t, actual, 4)
+		assert.Equal(t, "test-collector-otlp-grpc-headless", actual[0].Name)
+		assert.Equal(t, "None", actual[0].Spec.ClusterIP)
+		assert.Equal(t, map[string]string{"team": "observability"}, actual[0].Annotations)
+		assert.Equal(t, "test-collector-otlp-http", actual[1].Name)
+		assert.Equal(t, "test-collector-statsd", actual[2].Name)
+		assert.Equal(t, "test-collector-web", actual[3].Name)
+		assert.Equal(t, map[string]string{"team": "observability", "prometheus.io/scrape": "true"}, actual[3].Annotations)
+	})
+}
+
+func portNames(ports []v1.ServicePort) []string {
+	var names []string
+	for _, p := range ports {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
 func TestMonitoringService(t *testing.T) {
 	t.Run("returned service should expose monitoring port", func(t *testing.T) {
 		expected := []v1.ServicePort{{
@@ -227,6 +361,20 @@ func TestMonitoringService(t *testing.T) {
 		assert.Equal(t, expected, actual.Spec.Ports)
 
 	})
+
+	t.Run("should return dual-stack service", func(t *testing.T) {
+		p := params()
+		policy := v1.IPFamilyPolicyPreferDualStack
+		p.Instance.Spec.Service = v1alpha1.ServiceSpec{
+			IPFamilyPolicy: &policy,
+			IPFamilies:     []v1.IPFamily{v1.IPv6Protocol},
+		}
+
+		actual := monitoringService(context.Background(), p)
+
+		assert.Equal(t, &policy, actual.Spec.IPFamilyPolicy)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol}, actual.Spec.IPFamilies)
+	})
 }
 
 func service(name string, ports []v1.ServicePort) v1.Service {
@@ -29,8 +29,8 @@ import (
 
 func TestExpectedServiceAccounts(t *testing.T) {
 	t.Run("should create multiple service accounts", func(t *testing.T) {
-		desired := collector.ServiceAccount(params().Instance)
-		allocatorDesired := targetallocator.ServiceAccount(params().Instance)
+		desired := collector.ServiceAccount(params().Config, params().Instance)
+		allocatorDesired := targetallocator.ServiceAccount(params().Config, params().Instance)
 		err := expectedServiceAccounts(context.Background(), params(), []v1.ServiceAccount{desired, allocatorDesired})
 		assert.NoError(t, err)
 
@@ -56,7 +56,7 @@ func TestExpectedServiceAccounts(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, exists)
 
-		err = expectedServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Instance)})
+		err = expectedServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Config, params().Instance)})
 		assert.NoError(t, err)
 
 		actual := v1.ServiceAccount{}
@@ -83,7 +83,7 @@ func TestDeleteServiceAccounts(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, exists)
 
-		err = deleteServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Instance)})
+		err = deleteServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Config, params().Instance)})
 		assert.NoError(t, err)
 
 		exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
@@ -107,7 +107,7 @@ func TestDeleteServiceAccounts(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, exists)
 
-		err = deleteServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Instance)})
+		err = deleteServiceAccounts(context.Background(), params(), []v1.ServiceAccount{collector.ServiceAccount(params().Config, params().Instance)})
 		assert.NoError(t, err)
 
 		exists, err = populateObjectIfExists(t, &v1.ServiceAccount{}, types.NamespacedName{Namespace: "default", Name: "test-delete-collector"})
@@ -132,7 +132,7 @@ func TestDesiredServiceAccounts(t *testing.T) {
 		params := params()
 		desired := desiredServiceAccounts(params)
 		assert.Len(t, desired, 1)
-		assert.Equal(t, collector.ServiceAccount(params.Instance), desired[0])
+		assert.Equal(t, collector.ServiceAccount(params.Config, params.Instance), desired[0])
 	})
 
 	t.Run("should create targetallocator service account", func(t *testing.T) {
@@ -141,6 +141,6 @@ func TestDesiredServiceAccounts(t *testing.T) {
 		params.Instance.Spec.TargetAllocator.Enabled = true
 		desired := desiredServiceAccounts(params)
 		assert.Len(t, desired, 1)
-		assert.Equal(t, targetallocator.ServiceAccount(params.Instance), desired[0])
+		assert.Equal(t, targetallocator.ServiceAccount(params.Config, params.Instance), desired[0])
 	})
 }
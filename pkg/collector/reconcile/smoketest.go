@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=collectorsmoketests,verbs=get;list;watch;create;update;patch;delete
+
+// SmokeTests creates the smoke test of the collector Deployment once its current revision is rolled out.
+func SmokeTests(ctx context.Context, params Params) error {
+	if params.Instance.Spec.SmokeTest == nil || params.Instance.Spec.Mode != v1alpha1.ModeDeployment {
+		return deleteSmokeTests(ctx, params, nil)
+	}
+
+	existing := &appsv1.Deployment{}
+	nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: naming.Collector(params.Instance)}
+	if err := params.Client.Get(ctx, nns, existing); k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	if !deploymentRolledOut(*existing) {
+		// the rollout status change triggers a new reconciliation
+		return nil
+	}
+
+	desired := collector.SmokeTest(params.Config, params.Instance, *existing)
+
+	// first, handle the create parts, the smoke tests are never updated
+	if err := expectedSmokeTest(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected smoke tests: %w", err)
+	}
+
+	// then, delete the smoke tests of the previous revisions
+	if err := deleteSmokeTests(ctx, params, &desired); err != nil {
+		return fmt.Errorf("failed to reconcile the smoke tests to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// deploymentRolledOut returns whether all the replicas of the Deployment run its current revision.
+func deploymentRolledOut(deployment appsv1.Deployment) bool {
+	if deployment.Annotations[collector.DeploymentRevisionAnnotation] == "" || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing {
+			return c.Reason == "NewReplicaSetAvailable" && deployment.Status.UpdatedReplicas == deployment.Status.Replicas
+		}
+	}
+	return false
+}
+
+func expectedSmokeTest(ctx context.Context, params Params, desired v1alpha1.CollectorSmokeTest) error {
+	if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	existing := &v1alpha1.CollectorSmokeTest{}
+	nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	err := params.Client.Get(ctx, nns, existing)
+	if err != nil && k8serrors.IsNotFound(err) {
+		if err := params.Client.Create(ctx, &desired); err != nil {
+			return fmt.Errorf("failed to create: %w", err)
+		}
+		params.Log.V(2).Info("created", "collectorsmoketest.name", desired.Name, "collectorsmoketest.namespace", desired.Namespace)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get: %w", err)
+	}
+	return nil
+}
+
+// deleteSmokeTests deletes the smoke tests other than the kept one, except those holding the deployment of a
+// config and image rolled back after a failure.
+func deleteSmokeTests(ctx context.Context, params Params, keep *v1alpha1.CollectorSmokeTest) error {
+	list, err := listSmokeTests(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		if keep != nil && existing.Name == keep.Name {
+			continue
+		}
+		if keep != nil && holdsDeployment(params, existing) {
+			continue
+		}
+		if err := params.Client.Delete(ctx, &existing); err != nil {
+			return fmt.Errorf("failed to delete: %w", err)
+		}
+		params.Log.V(2).Info("deleted", "collectorsmoketest.name", existing.Name, "collectorsmoketest.namespace", existing.Namespace)
+	}
+
+	return nil
+}
+
+// smokeTestRollbackHolds returns whether the desired collector config and image were rolled back after a failed
+// smoke test, in which case they aren't deployed again.
+func smokeTestRollbackHolds(ctx context.Context, params Params) (bool, error) {
+	if params.Instance.Spec.SmokeTest == nil || !params.Instance.Spec.SmokeTest.Rollback {
+		return false, nil
+	}
+
+	list, err := listSmokeTests(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range list.Items {
+		if holdsDeployment(params, existing) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func holdsDeployment(params Params, smokeTest v1alpha1.CollectorSmokeTest) bool {
+	if !smokeTest.Status.RolledBack {
+		return false
+	}
+	desired := collector.Container(params.Config, params.Log, params.Instance)
+	return smokeTest.Spec.ConfigHash == collector.PodAnnotations(params.Instance)[configHashAnnotation] &&
+		smokeTest.Spec.Image == desired.Image
+}
+
+func listSmokeTests(ctx context.Context, params Params) (*v1alpha1.CollectorSmokeTestList, error) {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  "opentelemetry-smoketest",
+		}),
+	}
+	list := &v1alpha1.CollectorSmokeTestList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return list, nil
+}
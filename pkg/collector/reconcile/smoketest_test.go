@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestDeploymentRolledOut(t *testing.T) {
+	rolledOut := func() appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Generation:  2,
+				Annotations: map[string]string{collector.DeploymentRevisionAnnotation: "2"},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Reason: "NewReplicaSetAvailable",
+				}},
+			},
+		}
+	}
+
+	t.Run("should detect the completed rollout", func(t *testing.T) {
+		assert.True(t, deploymentRolledOut(rolledOut()))
+	})
+
+	t.Run("should wait for the new spec to be observed", func(t *testing.T) {
+		deployment := rolledOut()
+		deployment.Generation = 3
+		assert.False(t, deploymentRolledOut(deployment))
+	})
+
+	t.Run("should wait for the old replicas to terminate", func(t *testing.T) {
+		deployment := rolledOut()
+		deployment.Status.Replicas = 3
+		assert.False(t, deploymentRolledOut(deployment))
+	})
+
+	t.Run("should wait for the new replica set", func(t *testing.T) {
+		deployment := rolledOut()
+		deployment.Status.Conditions[0].Reason = "ReplicaSetUpdated"
+		assert.False(t, deploymentRolledOut(deployment))
+	})
+}
+
+func TestSmokeTestRollbackHolds(t *testing.T) {
+	p := params()
+	p.Instance.Spec.Mode = v1alpha1.ModeDeployment
+	p.Instance.Spec.SmokeTest = &v1alpha1.SmokeTestSpec{Rollback: true}
+
+	smokeTest := v1alpha1.CollectorSmokeTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-smoketest-1",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":   "default.test",
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				"app.kubernetes.io/component":  "opentelemetry-smoketest",
+			},
+		},
+		Spec: v1alpha1.CollectorSmokeTestSpec{
+			Collector:  "test",
+			Revision:   "1",
+			ConfigHash: collector.PodAnnotations(p.Instance)[configHashAnnotation],
+			Image:      p.Instance.Spec.Image,
+		},
+	}
+	createObjectIfNotExists(t, "test-smoketest-1", &smokeTest)
+	defer func() {
+		assert.NoError(t, k8sClient.Delete(context.Background(), &smokeTest))
+	}()
+
+	t.Run("should not hold a collector that wasn't rolled back", func(t *testing.T) {
+		held, err := smokeTestRollbackHolds(context.Background(), p)
+		assert.NoError(t, err)
+		assert.False(t, held)
+	})
+
+	smokeTest.Status.Phase = v1alpha1.SmokeTestPhaseFailed
+	smokeTest.Status.RolledBack = true
+	require.NoError(t, k8sClient.Status().Update(context.Background(), &smokeTest))
+
+	t.Run("should hold the config and image rolled back", func(t *testing.T) {
+		held, err := smokeTestRollbackHolds(context.Background(), p)
+		assert.NoError(t, err)
+		assert.True(t, held)
+	})
+
+	t.Run("should release the hold once the config changes", func(t *testing.T) {
+		changed := p
+		changed.Instance.Spec.Config = "receivers: {}"
+		held, err := smokeTestRollbackHolds(context.Background(), changed)
+		assert.NoError(t, err)
+		assert.False(t, held)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -32,10 +33,34 @@ import (
 
 // StatefulSets reconciles the stateful set(s) required for the instance in the current context.
 func StatefulSets(ctx context.Context, params Params) error {
+	// hold the update until the custom distribution is built
+	if built, err := collectorBuilt(ctx, params); err != nil || !built {
+		return err
+	}
+
+	// hold the update until the image has the components of the config
+	if !componentsSupported(params) {
+		return nil
+	}
+
+	// hold the update until the pre-deploy check passes
+	if passed, err := preDeployCheckPassed(ctx, params); err != nil || !passed {
+		return err
+	}
 
 	desired := []appsv1.StatefulSet{}
 	if params.Instance.Spec.Mode == "statefulset" {
-		desired = append(desired, collector.StatefulSet(params.Config, params.Log, params.Instance))
+		workload := collector.StatefulSet(params.Config, params.Log, params.Instance)
+		if err := setConfigChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setReceiverTLSChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		if err := setConfigSourcesChecksum(ctx, params, &workload.Spec.Template); err != nil {
+			return err
+		}
+		desired = append(desired, workload)
 	}
 
 	// first, handle the create/update parts
@@ -63,7 +88,7 @@ func expectedStatefulSets(ctx context.Context, params Params, expected []appsv1.
 		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
 		err := params.Client.Get(ctx, nns, existing)
 		if err != nil && k8serrors.IsNotFound(err) {
-			if clientErr := params.Client.Create(ctx, &desired); clientErr != nil {
+			if clientErr := apply(ctx, params, &desired); clientErr != nil {
 				return fmt.Errorf("failed to create: %w", clientErr)
 			}
 			params.Log.V(2).Info("created", "statefulset.name", desired.Name, "statefulset.namespace", desired.Namespace)
@@ -82,27 +107,23 @@ func expectedStatefulSets(ctx context.Context, params Params, expected []appsv1.
 			continue
 		}
 
-		// it exists already, merge the two if the end result isn't identical to the existing one
-		updated := existing.DeepCopy()
-		if updated.Annotations == nil {
-			updated.Annotations = map[string]string{}
-		}
-		if updated.Labels == nil {
-			updated.Labels = map[string]string{}
+		// the orphaned pods are adopted by the new statefulset, once the previous one is gone
+		if existing.DeletionTimestamp != nil {
+			continue
 		}
 
-		updated.Spec = desired.Spec
-		updated.ObjectMeta.OwnerReferences = desired.ObjectMeta.OwnerReferences
+		// PodManagementPolicy is immutable as well, the pods and their volumes are kept while the statefulset is
+		// created again
+		if desired.Spec.PodManagementPolicy != existing.Spec.PodManagementPolicy {
+			params.Log.V(2).Info("Spec.PodManagementPolicy change detected, trying to delete, the new collector statefulset will be created in the next reconcile cycle", "statefulset.name", existing.Name, "statefulset.namespace", existing.Namespace)
 
-		for k, v := range desired.ObjectMeta.Annotations {
-			updated.ObjectMeta.Annotations[k] = v
-		}
-		for k, v := range desired.ObjectMeta.Labels {
-			updated.ObjectMeta.Labels[k] = v
+			if err := params.Client.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
+				return fmt.Errorf("failed to delete statefulset: %w", err)
+			}
+			continue
 		}
 
-		patch := client.MergeFrom(existing)
-		if err := params.Client.Patch(ctx, updated, patch); err != nil {
+		if err := apply(ctx, params, &desired); err != nil {
 			return fmt.Errorf("failed to apply changes: %w", err)
 		}
 
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -162,4 +163,41 @@ func TestExpectedStatefulsets(t *testing.T) {
 		assert.True(t, exists)
 		assert.Equal(t, newSs.Spec.Selector.MatchLabels, actual.Spec.Selector.MatchLabels)
 	})
+
+	t.Run("change Spec.PodManagementPolicy should recreate statefulset", func(t *testing.T) {
+		oldSs := collector.StatefulSet(param.Config, logger, param.Instance)
+		oldSs.Name = "update-pod-management-policy"
+
+		err := expectedStatefulSets(context.Background(), param, []v1.StatefulSet{oldSs})
+		assert.NoError(t, err)
+
+		newSs := collector.StatefulSet(param.Config, logger, param.Instance)
+		newSs.Name = oldSs.Name
+		newSs.Spec.PodManagementPolicy = v1.OrderedReadyPodManagement
+		err = expectedStatefulSets(context.Background(), param, []v1.StatefulSet{newSs})
+		assert.NoError(t, err)
+
+		// the pods are orphaned, which the garbage collector completes by removing the orphan finalizer
+		deleted := v1.StatefulSet{}
+		exists, err := populateObjectIfExists(t, &deleted, types.NamespacedName{Namespace: "default", Name: oldSs.Name})
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.NotNil(t, deleted.DeletionTimestamp)
+		assert.Contains(t, deleted.Finalizers, metav1.FinalizerOrphanDependents)
+		err = expectedStatefulSets(context.Background(), param, []v1.StatefulSet{newSs})
+		assert.NoError(t, err)
+		deleted.Finalizers = nil
+		require.NoError(t, k8sClient.Update(context.Background(), &deleted))
+		exists, err = populateObjectIfExists(t, &v1.StatefulSet{}, types.NamespacedName{Namespace: "default", Name: oldSs.Name})
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		err = expectedStatefulSets(context.Background(), param, []v1.StatefulSet{newSs})
+		assert.NoError(t, err)
+		actual := v1.StatefulSet{}
+		exists, err = populateObjectIfExists(t, &actual, types.NamespacedName{Namespace: "default", Name: oldSs.Name})
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, v1.OrderedReadyPodManagement, actual.Spec.PodManagementPolicy)
+	})
 }
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+// certManagerSecretAnnotation is set by cert-manager on the secrets of its Certificates.
+const certManagerSecretAnnotation = "cert-manager.io/certificate-name"
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;patch;delete
+
+// TargetAllocatorCertificates reconciles the certificates securing the TargetAllocator with mutual TLS. They're either
+// cert-manager Certificates, or secrets issued from a self-signed CA when the instance has no issuer.
+func TargetAllocatorCertificates(ctx context.Context, params Params) error {
+	desiredCertificates := []unstructured.Unstructured{}
+	desiredSecrets := []corev1.Secret{}
+
+	if targetallocator.UsesMTLS(params.Instance) {
+		if params.Instance.Spec.TargetAllocator.MTLS.IssuerRef != nil {
+			certificates, err := targetallocator.Certificates(params.Instance)
+			if err != nil {
+				return err
+			}
+			desiredCertificates = append(desiredCertificates, certificates...)
+		} else {
+			secrets, err := desiredTLSSecrets(ctx, params, time.Now())
+			if err != nil {
+				return err
+			}
+			desiredSecrets = append(desiredSecrets, secrets...)
+		}
+	}
+
+	// first, handle the create/update parts
+	if err := expectedCertificates(ctx, params, desiredCertificates); err != nil {
+		return fmt.Errorf("failed to reconcile the expected certificates: %w", err)
+	}
+	if err := expectedTLSSecrets(ctx, params, desiredSecrets); err != nil {
+		return fmt.Errorf("failed to reconcile the expected certificate secrets: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteCertificates(ctx, params, "opentelemetry-targetallocator", desiredCertificates); err != nil {
+		return fmt.Errorf("failed to reconcile the certificates to be deleted: %w", err)
+	}
+	if err := deleteTLSSecrets(ctx, params, desiredSecrets); err != nil {
+		return fmt.Errorf("failed to reconcile the certificate secrets to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+// desiredTLSSecrets returns the self-signed CA, and the server and client certificates it issued. The existing
+// certificates are kept until they need to be renewed.
+func desiredTLSSecrets(ctx context.Context, params Params, now time.Time) ([]corev1.Secret, error) {
+	existing := map[string]map[string][]byte{}
+	for _, name := range []string{
+		naming.TACertificateAuthority(params.Instance),
+		naming.TAServerCertificate(params.Instance),
+		naming.TAClientCertificate(params.Instance),
+	} {
+		secret := &corev1.Secret{}
+		nns := types.NamespacedName{Namespace: params.Instance.Namespace, Name: name}
+		if err := params.Client.Get(ctx, nns, secret); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get: %w", err)
+		}
+		existing[name] = secret.Data
+	}
+
+	ca := existing[naming.TACertificateAuthority(params.Instance)]
+	if targetallocator.CANeedsRenewal(ca[corev1.TLSCertKey], now) {
+		cert, key, err := targetallocator.NewCertificateAuthority(naming.TACertificateAuthority(params.Instance), now)
+		if err != nil {
+			return nil, err
+		}
+		ca = map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key}
+		params.Log.Info("generated the self-signed CA of the target allocator", "secret.name", naming.TACertificateAuthority(params.Instance))
+	}
+
+	issue := func(name, commonName string, dnsNames []string, usage x509.ExtKeyUsage) (map[string][]byte, error) {
+		data := existing[name]
+		if targetallocator.CertificateNeedsRenewal(data[corev1.TLSCertKey], ca[corev1.TLSCertKey], dnsNames, now) {
+			cert, key, err := targetallocator.IssueCertificate(ca[corev1.TLSCertKey], ca[corev1.TLSPrivateKeyKey], commonName, dnsNames, usage, now)
+			if err != nil {
+				return nil, err
+			}
+			params.Log.Info("issued the target allocator certificate", "secret.name", name)
+			data = map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key}
+		}
+		return map[string][]byte{
+			corev1.TLSCertKey:        data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey:  data[corev1.TLSPrivateKeyKey],
+			targetallocator.TLSCAKey: ca[corev1.TLSCertKey],
+		}, nil
+	}
+	serverData, err := issue(naming.TAServerCertificate(params.Instance), naming.TAService(params.Instance),
+		targetallocator.ServerDNSNames(params.Instance), x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return nil, err
+	}
+	clientData, err := issue(naming.TAClientCertificate(params.Instance), naming.Collector(params.Instance),
+		nil, x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	return []corev1.Secret{
+		tlsSecret(params, naming.TACertificateAuthority(params.Instance), ca),
+		tlsSecret(params, naming.TAServerCertificate(params.Instance), serverData),
+		tlsSecret(params, naming.TAClientCertificate(params.Instance), clientData),
+	}, nil
+}
+
+func tlsSecret(params Params, name string, data map[string][]byte) corev1.Secret {
+	labels := targetallocator.Labels(params.Instance)
+	labels["app.kubernetes.io/name"] = name
+
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.Instance.Namespace,
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
+
+func expectedTLSSecrets(ctx context.Context, params Params, expected []corev1.Secret) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		existing := &corev1.Secret{}
+		nns := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+		err := params.Client.Get(ctx, nns, existing)
+		if err != nil && k8serrors.IsNotFound(err) {
+			if err := apply(ctx, params, &desired); err != nil {
+				return fmt.Errorf("failed to create: %w", err)
+			}
+			params.Log.V(2).Info("created", "secret.name", desired.Name, "secret.namespace", desired.Namespace)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get: %w", err)
+		}
+
+		if reflect.DeepEqual(existing.Data, desired.Data) && reflect.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) {
+			continue
+		}
+
+		if err := apply(ctx, params, &desired); err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "secret.name", desired.Name, "secret.namespace", desired.Namespace)
+	}
+
+	return nil
+}
+
+func deleteTLSSecrets(ctx context.Context, params Params, expected []corev1.Secret) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  "opentelemetry-targetallocator",
+		}),
+	}
+	list := &corev1.SecretList{}
+	if err := params.Client.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		if _, ok := existing.Annotations[certManagerSecretAnnotation]; ok {
+			// cert-manager took over the secret when the instance switched to an issuer
+			continue
+		}
+		del := true
+		for _, keep := range expected {
+			if keep.Name == existing.Name && keep.Namespace == existing.Namespace {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "secret.name", existing.Name, "secret.namespace", existing.Namespace)
+		}
+	}
+
+	return nil
+}
+
+func expectedCertificates(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		err := apply(ctx, params, &desired)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("the Certificate kind isn't available, cert-manager needs to be installed to issue the certificates: %w", err)
+		} else if err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "certificate.name", desired.GetName(), "certificate.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+// deleteCertificates deletes the Certificates of the component of the instance which aren't expected.
+func deleteCertificates(ctx context.Context, params Params, component string, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  component,
+		}),
+	}
+	list := certificateList()
+	if err := params.Client.List(ctx, list, opts...); meta.IsNoMatchError(err) {
+		// cert-manager isn't installed, so there's nothing to delete
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "certificate.name", existing.GetName(), "certificate.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+func certificateList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(targetallocator.CertificateGVK.GroupVersion().WithKind(targetallocator.CertificateGVK.Kind + "List"))
+	return list
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator"
+)
+
+func TestTargetAllocatorCertificates(t *testing.T) {
+	caNns := types.NamespacedName{Namespace: "default", Name: "test-targetallocator-ca"}
+	serverNns := types.NamespacedName{Namespace: "default", Name: "test-targetallocator-server"}
+	clientNns := types.NamespacedName{Namespace: "default", Name: "test-targetallocator-client"}
+	param := params()
+	param.Instance.Spec.TargetAllocator.Enabled = true
+	param.Instance.Spec.TargetAllocator.MTLS = &v1alpha1.TargetAllocatorMTLS{}
+
+	var issued v1.Secret
+	t.Run("should issue the certificates from a self-signed CA", func(t *testing.T) {
+		err := TargetAllocatorCertificates(context.Background(), param)
+		assert.NoError(t, err)
+
+		ca := v1.Secret{}
+		exists, err := populateObjectIfExists(t, &ca, caNns)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		exists, err = populateObjectIfExists(t, &issued, serverNns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, v1.SecretTypeTLS, issued.Type)
+		assert.Equal(t, ca.Data[v1.TLSCertKey], issued.Data[targetallocator.TLSCAKey])
+		assert.False(t, targetallocator.CertificateNeedsRenewal(issued.Data[v1.TLSCertKey], ca.Data[v1.TLSCertKey],
+			targetallocator.ServerDNSNames(param.Instance), time.Now()))
+		assert.Equal(t, instanceUID, issued.OwnerReferences[0].UID)
+
+		exists, err = populateObjectIfExists(t, &v1.Secret{}, clientNns)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("should keep the certificates until they need to be renewed", func(t *testing.T) {
+		err := TargetAllocatorCertificates(context.Background(), param)
+		assert.NoError(t, err)
+
+		actual := v1.Secret{}
+		exists, err := populateObjectIfExists(t, &actual, serverNns)
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, issued.Data, actual.Data)
+	})
+
+	t.Run("should require cert-manager for the issuer", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.TargetAllocator.Enabled = true
+		p.Instance.Spec.TargetAllocator.MTLS = &v1alpha1.TargetAllocatorMTLS{
+			IssuerRef: &v1alpha1.CertificateIssuerRef{Name: "my-issuer"},
+		}
+
+		err := TargetAllocatorCertificates(context.Background(), p)
+		assert.ErrorContains(t, err, "cert-manager needs to be installed")
+	})
+
+	t.Run("should delete the certificates", func(t *testing.T) {
+		param.Instance.Spec.TargetAllocator.MTLS = nil
+
+		err := TargetAllocatorCertificates(context.Background(), param)
+		assert.NoError(t, err)
+
+		for _, nns := range []types.NamespacedName{caNns, serverNns, clientNns} {
+			exists, err := populateObjectIfExists(t, &v1.Secret{}, nns)
+			assert.NoError(t, err)
+			assert.False(t, exists, nns.Name)
+		}
+	})
+}
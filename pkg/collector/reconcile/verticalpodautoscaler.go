@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// VerticalPodAutoscalers reconciles the VerticalPodAutoscalers of the daemonset collectors.
+func VerticalPodAutoscalers(ctx context.Context, params Params) error {
+	desired := []unstructured.Unstructured{}
+
+	if collector.UsesVPA(params.Instance) {
+		verticalPodAutoscaler, err := collector.VerticalPodAutoscaler(params.Config, params.Log, params.Instance)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, *verticalPodAutoscaler)
+	}
+
+	// first, handle the create/update parts
+	if err := expectedVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the expected vertical pod autoscalers: %w", err)
+	}
+
+	// then, delete the extra objects
+	if err := deleteVerticalPodAutoscalers(ctx, params, desired); err != nil {
+		return fmt.Errorf("failed to reconcile the vertical pod autoscalers to be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func expectedVerticalPodAutoscalers(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	for _, obj := range expected {
+		desired := obj
+
+		if err := controllerutil.SetControllerReference(&params.Instance, &desired, params.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		err := apply(ctx, params, &desired)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("the VerticalPodAutoscaler kind isn't available, the VerticalPodAutoscaler needs to be installed to adjust the resources of the collector: %w", err)
+		} else if err != nil {
+			return fmt.Errorf("failed to apply changes: %w", err)
+		}
+
+		params.Log.V(2).Info("applied", "verticalpodautoscaler.name", desired.GetName(), "verticalpodautoscaler.namespace", desired.GetNamespace())
+	}
+
+	return nil
+}
+
+func deleteVerticalPodAutoscalers(ctx context.Context, params Params, expected []unstructured.Unstructured) error {
+	opts := []client.ListOption{
+		client.InNamespace(params.Instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.Instance.Namespace, params.Instance.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}),
+	}
+	list := verticalPodAutoscalerList()
+	if err := params.Client.List(ctx, list, opts...); meta.IsNoMatchError(err) {
+		// the VerticalPodAutoscaler isn't installed, so there's nothing to delete
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range list.Items {
+		existing := list.Items[i]
+		del := true
+		for _, keep := range expected {
+			if keep.GetName() == existing.GetName() && keep.GetNamespace() == existing.GetNamespace() {
+				del = false
+				break
+			}
+		}
+
+		if del {
+			if err := params.Client.Delete(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to delete: %w", err)
+			}
+			params.Log.V(2).Info("deleted", "verticalpodautoscaler.name", existing.GetName(), "verticalpodautoscaler.namespace", existing.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+func verticalPodAutoscalerList() *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(collector.VerticalPodAutoscalerGVK.GroupVersion().WithKind(collector.VerticalPodAutoscalerGVK.Kind + "List"))
+	return list
+}
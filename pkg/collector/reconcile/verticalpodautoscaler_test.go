@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestVerticalPodAutoscalersWithoutVPA(t *testing.T) {
+	t.Run("should skip the deletion when the VerticalPodAutoscaler isn't installed", func(t *testing.T) {
+		err := VerticalPodAutoscalers(context.Background(), params())
+		assert.NoError(t, err)
+	})
+
+	t.Run("should report that the VerticalPodAutoscaler is required", func(t *testing.T) {
+		p := params()
+		p.Instance.Spec.Mode = v1alpha1.ModeDaemonSet
+		p.Instance.Spec.Autoscaler = &v1alpha1.AutoscalerSpec{
+			VPA: &v1alpha1.VPASpec{UpdateMode: v1alpha1.VPAUpdateModeInitial},
+		}
+
+		err := VerticalPodAutoscalers(context.Background(), p)
+		assert.ErrorContains(t, err, "the VerticalPodAutoscaler needs to be installed")
+	})
+}
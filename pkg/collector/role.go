@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// receiverRules lists the permissions required by the receivers reading from the Kubernetes API.
+var receiverRules = map[string][]rbacv1.PolicyRule{
+	"k8s_events": {
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: readVerbs},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: readVerbs},
+	},
+	"k8sobjects": {
+		{APIGroups: []string{""}, Resources: []string{"events", "pods"}, Verbs: readVerbs},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: readVerbs},
+	},
+	"k8s_cluster": {
+		{APIGroups: []string{""}, Resources: []string{"events", "namespaces", "nodes", "pods", "replicationcontrollers", "resourcequotas", "services"}, Verbs: readVerbs},
+		{APIGroups: []string{"apps"}, Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"}, Verbs: readVerbs},
+		{APIGroups: []string{"autoscaling"}, Resources: []string{"horizontalpodautoscalers"}, Verbs: readVerbs},
+		{APIGroups: []string{"batch"}, Resources: []string{"cronjobs", "jobs"}, Verbs: readVerbs},
+	},
+	"kubeletstats": {
+		{APIGroups: []string{""}, Resources: []string{"nodes/proxy", "nodes/stats"}, Verbs: readVerbs},
+	},
+}
+
+// prometheusKubernetesSDRules lists the permissions required by the prometheus receivers discovering their targets
+// with kubernetes_sd_configs.
+var prometheusKubernetesSDRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"endpoints", "nodes", "nodes/metrics", "pods", "services"}, Verbs: readVerbs},
+	{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: readVerbs},
+	{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: readVerbs},
+}
+
+// processorRules lists the permissions required by the processors reading from the Kubernetes API.
+var processorRules = map[string][]rbacv1.PolicyRule{
+	"k8sattributes": {
+		{APIGroups: []string{""}, Resources: []string{"namespaces", "pods"}, Verbs: readVerbs},
+		{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: readVerbs},
+	},
+}
+
+// clusterScopedResources lists the resources of the rules above that can't be granted by a Role.
+var clusterScopedResources = map[string]bool{
+	"namespaces":    true,
+	"nodes":         true,
+	"nodes/metrics": true,
+	"nodes/proxy":   true,
+	"nodes/stats":   true,
+}
+
+// RoleRules returns the namespaced rules required by the enabled components of the instance, merged by API group.
+func RoleRules(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []rbacv1.PolicyRule {
+	return componentRules(logger, otelcol, false)
+}
+
+// ClusterRoleRules returns the rules required by the enabled components of the instance, including the cluster
+// scoped resources, merged by API group.
+func ClusterRoleRules(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) []rbacv1.PolicyRule {
+	return componentRules(logger, otelcol, true)
+}
+
+func componentRules(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, withClusterScoped bool) []rbacv1.PolicyRule {
+	cfg, err := adapters.ConfigFromString(otelcol.Spec.Config)
+	if err != nil {
+		logger.Error(err, "couldn't extract the configuration from the context")
+		return nil
+	}
+
+	var required []rbacv1.PolicyRule
+	receivers, _ := cfg["receivers"].(map[interface{}]interface{})
+	for receiver, enabled := range adapters.GetEnabledReceivers(logger, cfg) {
+		name, ok := receiver.(string)
+		if !ok || !enabled {
+			continue
+		}
+		// components can be named, like k8s_events/my-namespace
+		receiverType := strings.SplitN(name, "/", 2)[0]
+		required = append(required, receiverRules[receiverType]...)
+		if receiverType == "prometheus" && usesKubernetesSD(receivers[name]) {
+			required = append(required, prometheusKubernetesSDRules...)
+		}
+	}
+	for name := range adapters.GetEnabledProcessors(logger, cfg) {
+		required = append(required, processorRules[strings.SplitN(name, "/", 2)[0]]...)
+	}
+
+	resourcesByGroup := map[string]map[string]bool{}
+	for _, rule := range required {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				if clusterScopedResources[resource] && !withClusterScoped {
+					continue
+				}
+				if resourcesByGroup[group] == nil {
+					resourcesByGroup[group] = map[string]bool{}
+				}
+				resourcesByGroup[group][resource] = true
+			}
+		}
+	}
+
+	groups := make([]string, 0, len(resourcesByGroup))
+	for group := range resourcesByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var rules []rbacv1.PolicyRule
+	for _, group := range groups {
+		resources := make([]string, 0, len(resourcesByGroup[group]))
+		for resource := range resourcesByGroup[group] {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources,
+			Verbs:     readVerbs,
+		})
+	}
+	return rules
+}
+
+// usesKubernetesSD returns whether one of the scrape configs of a prometheus receiver discovers its targets with
+// kubernetes_sd_configs.
+func usesKubernetesSD(receiver interface{}) bool {
+	receiverCfg, _ := receiver.(map[interface{}]interface{})
+	promCfg, _ := receiverCfg["config"].(map[interface{}]interface{})
+	scrapeConfigs, _ := promCfg["scrape_configs"].([]interface{})
+	for _, scrapeConfig := range scrapeConfigs {
+		scrapeCfg, _ := scrapeConfig.(map[interface{}]interface{})
+		if sdConfigs, _ := scrapeCfg["kubernetes_sd_configs"].([]interface{}); len(sdConfigs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Role builds the Role granting the collector the permissions required by its receivers.
+func Role(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) rbacv1.Role {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.Role(otelcol)
+
+	return rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Role(otelcol),
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Rules: RoleRules(logger, otelcol),
+	}
+}
+
+// RoleBinding builds the RoleBinding of the collector's Role to its service account.
+func RoleBinding(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) rbacv1.RoleBinding {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.RoleBinding(otelcol)
+
+	return rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.RoleBinding(otelcol),
+			Namespace:   otelcol.Namespace,
+			Labels:      labels,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ServiceAccountName(otelcol),
+			Namespace: otelcol.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     naming.Role(otelcol),
+		},
+	}
+}
+
+// ClusterRole builds the ClusterRole granting the collector the cluster wide permissions required by its components.
+func ClusterRole(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) rbacv1.ClusterRole {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.ClusterRole(otelcol)
+
+	return rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ClusterRole(otelcol),
+			Labels:      labels,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Rules: ClusterRoleRules(logger, otelcol),
+	}
+}
+
+// ClusterRoleBinding builds the ClusterRoleBinding of the collector's ClusterRole to its service account.
+func ClusterRoleBinding(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) rbacv1.ClusterRoleBinding {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.ClusterRoleBinding(otelcol)
+
+	return rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ClusterRoleBinding(otelcol),
+			Labels:      labels,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      ServiceAccountName(otelcol),
+			Namespace: otelcol.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     naming.ClusterRole(otelcol),
+		},
+	}
+}
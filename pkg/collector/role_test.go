@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestRoleRules(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []rbacv1.PolicyRule
+	}{
+		{
+			desc: "no kubernetes receivers",
+			config: `receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`,
+		},
+		{
+			desc: "k8s_events receiver",
+			config: `receivers:
+  k8s_events:
+service:
+  pipelines:
+    logs:
+      receivers: [k8s_events]
+`,
+			expected: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			desc: "named receivers are merged",
+			config: `receivers:
+  k8s_events/ns1:
+  k8sobjects:
+service:
+  pipelines:
+    logs:
+      receivers: [k8s_events/ns1, k8sobjects]
+`,
+			expected: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"events", "pods"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			desc: "cluster scoped resources are left out",
+			config: `receivers:
+  kubeletstats:
+  k8s_cluster:
+processors:
+  k8sattributes:
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats, k8s_cluster]
+      processors: [k8sattributes]
+`,
+			expected: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"events", "pods", "replicationcontrollers", "resourcequotas", "services"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"apps"}, Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"autoscaling"}, Resources: []string{"horizontalpodautoscalers"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"batch"}, Resources: []string{"cronjobs", "jobs"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			desc: "receivers not in a pipeline are ignored",
+			config: `receivers:
+  k8s_cluster:
+  otlp:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp]
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Config: tt.config,
+				},
+			}
+
+			assert.Equal(t, tt.expected, RoleRules(logger, otelcol))
+		})
+	}
+}
+
+func TestClusterRoleRules(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected []rbacv1.PolicyRule
+	}{
+		{
+			desc: "k8sattributes processor and kubeletstats receiver",
+			config: `receivers:
+  kubeletstats:
+processors:
+  k8sattributes/pods:
+service:
+  pipelines:
+    metrics:
+      receivers: [kubeletstats]
+      processors: [k8sattributes/pods]
+`,
+			expected: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"namespaces", "nodes/proxy", "nodes/stats", "pods"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			desc: "prometheus receiver with kubernetes_sd_configs",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: pods
+        kubernetes_sd_configs:
+        - role: pod
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+`,
+			expected: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"endpoints", "nodes", "nodes/metrics", "pods", "services"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+		{
+			desc: "prometheus receiver with static targets",
+			config: `receivers:
+  prometheus:
+    config:
+      scrape_configs:
+      - job_name: self
+        static_configs:
+        - targets: [localhost:8888]
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Config: tt.config,
+				},
+			}
+
+			assert.Equal(t, tt.expected, ClusterRoleRules(logger, otelcol))
+		})
+	}
+}
+
+func TestClusterRoleBinding(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+	}
+
+	// test
+	binding := ClusterRoleBinding(config.New(), otelcol)
+
+	// verify
+	assert.Equal(t, "observability-my-instance-d322b93a-collector", binding.Name)
+	assert.Empty(t, binding.Namespace)
+	assert.Equal(t, "observability-my-instance-d322b93a-collector", binding.RoleRef.Name)
+	assert.Equal(t, "ClusterRole", binding.RoleRef.Kind)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "my-instance-collector", Namespace: "observability"}}, binding.Subjects)
+}
+
+func TestRoleBinding(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			ServiceAccount: "my-special-sa",
+		},
+	}
+
+	// test
+	binding := RoleBinding(config.New(), otelcol)
+
+	// verify
+	assert.Equal(t, "my-instance-collector", binding.Name)
+	assert.Equal(t, "my-instance-collector", binding.RoleRef.Name)
+	assert.Equal(t, "Role", binding.RoleRef.Kind)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "my-special-sa", Namespace: "observability"}}, binding.Subjects)
+}
+
+func TestRoleFilteredAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+			Annotations: map[string]string{
+				"foo":                              "1",
+				"kubectl.example.com/last-applied": "2",
+			},
+		},
+	}
+	cfg := config.New(config.WithAnnotationFilters([]string{"kubectl.example.com/*"}))
+
+	// test
+	annotations := []map[string]string{
+		Role(cfg, logger, otelcol).Annotations,
+		RoleBinding(cfg, otelcol).Annotations,
+		ClusterRole(cfg, logger, otelcol).Annotations,
+		ClusterRoleBinding(cfg, otelcol).Annotations,
+	}
+
+	// verify
+	for _, a := range annotations {
+		assert.Equal(t, map[string]string{"foo": "1"}, a)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ScaledObjectGVK is the kind of the KEDA ScaledObjects. The KEDA API isn't a dependency of the operator, the
+// ScaledObjects are handled as unstructured objects.
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+type scaledObjectSpec struct {
+	ScaleTargetRef  autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+	MinReplicaCount *int32                                    `json:"minReplicaCount,omitempty"`
+	MaxReplicaCount *int32                                    `json:"maxReplicaCount,omitempty"`
+	PollingInterval *int32                                    `json:"pollingInterval,omitempty"`
+	CooldownPeriod  *int32                                    `json:"cooldownPeriod,omitempty"`
+	Advanced        *scaledObjectAdvanced                     `json:"advanced,omitempty"`
+	Triggers        []v1alpha1.KedaTrigger                    `json:"triggers"`
+}
+
+type scaledObjectAdvanced struct {
+	HorizontalPodAutoscalerConfig scaledObjectHPAConfig `json:"horizontalPodAutoscalerConfig"`
+}
+
+type scaledObjectHPAConfig struct {
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// UsesKeda returns whether the instance is scaled by a KEDA ScaledObject instead of a HorizontalPodAutoscaler.
+func UsesKeda(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.MaxReplicas != nil && otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.Keda != nil
+}
+
+// ScaledObject returns the KEDA ScaledObject scaling the collector on the triggers of its autoscaler. Like the
+// HorizontalPodAutoscaler, it targets the scale subresource of the OpenTelemetryCollector.
+func ScaledObject(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) (*unstructured.Unstructured, error) {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
+
+	keda := otelcol.Spec.Autoscaler.Keda
+	spec := scaledObjectSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "OpenTelemetryCollector",
+			Name:       naming.OpenTelemetryCollector(otelcol),
+		},
+		MinReplicaCount: otelcol.Spec.MinReplicas,
+		MaxReplicaCount: otelcol.Spec.MaxReplicas,
+		PollingInterval: keda.PollingInterval,
+		CooldownPeriod:  keda.CooldownPeriod,
+		Triggers:        keda.Triggers,
+	}
+	if spec.MinReplicaCount == nil {
+		spec.MinReplicaCount = otelcol.Spec.Replicas
+	}
+	if otelcol.Spec.Autoscaler.Behavior != nil {
+		spec.Advanced = &scaledObjectAdvanced{
+			HorizontalPodAutoscalerConfig: scaledObjectHPAConfig{Behavior: otelcol.Spec.Autoscaler.Behavior},
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the ScaledObject spec: %w", err)
+	}
+
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName(naming.ScaledObject(otelcol))
+	scaledObject.SetNamespace(otelcol.Namespace)
+	scaledObject.SetLabels(labels)
+	scaledObject.SetAnnotations(Annotations(otelcol, cfg.AnnotationsFilter()))
+	scaledObject.Object["spec"] = content
+	return scaledObject, nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestScaledObject(t *testing.T) {
+	// prepare
+	replicas := int32(1)
+	minReplicas := int32(2)
+	maxReplicas := int32(10)
+	cooldown := int32(60)
+	stabilization := int32(120)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Replicas:    &replicas,
+			MinReplicas: &minReplicas,
+			MaxReplicas: &maxReplicas,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+					ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &stabilization},
+				},
+				Keda: &v1alpha1.KedaSpec{
+					CooldownPeriod: &cooldown,
+					Triggers: []v1alpha1.KedaTrigger{{
+						Type: "kafka",
+						Metadata: map[string]string{
+							"bootstrapServers": "kafka:9092",
+							"consumerGroup":    "otel-gateway",
+							"topic":            "otlp_spans",
+							"lagThreshold":     "1000",
+						},
+						AuthenticationRef: &v1alpha1.KedaAuthenticationRef{Name: "kafka-credentials"},
+					}},
+				},
+			},
+		},
+	}
+
+	// test
+	scaledObject, err := ScaledObject(config.New(), logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.True(t, UsesKeda(otelcol))
+	assert.Equal(t, ScaledObjectGVK, scaledObject.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector", scaledObject.GetName())
+	assert.Equal(t, "observability", scaledObject.GetNamespace())
+	assert.Equal(t, "my-instance-collector", scaledObject.GetLabels()["app.kubernetes.io/name"])
+
+	target, _, _ := unstructured.NestedStringMap(scaledObject.Object, "spec", "scaleTargetRef")
+	assert.Equal(t, map[string]string{
+		"apiVersion": "opentelemetry.io/v1alpha1",
+		"kind":       "OpenTelemetryCollector",
+		"name":       "my-instance",
+	}, target)
+	minCount, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+	assert.Equal(t, int64(2), minCount)
+	maxCount, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "maxReplicaCount")
+	assert.Equal(t, int64(10), maxCount)
+	cooldownPeriod, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "cooldownPeriod")
+	assert.Equal(t, int64(60), cooldownPeriod)
+	_, found, _ := unstructured.NestedFieldNoCopy(scaledObject.Object, "spec", "pollingInterval")
+	assert.False(t, found)
+	window, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "advanced", "horizontalPodAutoscalerConfig", "behavior", "scaleDown", "stabilizationWindowSeconds")
+	assert.Equal(t, int64(120), window)
+
+	triggers, _, _ := unstructured.NestedSlice(scaledObject.Object, "spec", "triggers")
+	require.Len(t, triggers, 1)
+	trigger := triggers[0].(map[string]interface{})
+	assert.Equal(t, "kafka", trigger["type"])
+	assert.Equal(t, "otlp_spans", trigger["metadata"].(map[string]interface{})["topic"])
+	assert.Equal(t, map[string]interface{}{"name": "kafka-credentials"}, trigger["authenticationRef"])
+}
+
+func TestScaledObjectDefaultsToReplicas(t *testing.T) {
+	// prepare
+	replicas := int32(3)
+	maxReplicas := int32(5)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Replicas:    &replicas,
+			MaxReplicas: &maxReplicas,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				Keda: &v1alpha1.KedaSpec{
+					Triggers: []v1alpha1.KedaTrigger{{Type: "prometheus", Metadata: map[string]string{"threshold": "100"}}},
+				},
+			},
+		},
+	}
+
+	// test
+	scaledObject, err := ScaledObject(config.New(), logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	minCount, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+	assert.Equal(t, int64(3), minCount)
+	_, found, _ := unstructured.NestedFieldNoCopy(scaledObject.Object, "spec", "advanced")
+	assert.False(t, found)
+}
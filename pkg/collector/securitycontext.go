@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// collectorImageUser is the user the collector images run as. The config reloader runs as the same user to be
+// allowed to signal the collector.
+const collectorImageUser = int64(10001)
+
+// restrictedDefaults returns whether the security contexts of the collector are defaulted to the restricted Pod
+// Security Standard. It's enabled by the RestrictedPodSecurity feature gate, and skipped for the collectors running on
+// Windows nodes, as the defaulted fields are Linux specific, and for the agents running as root, in the host network
+// or mounting host paths, which likely need the privileges the defaults remove.
+func restrictedDefaults(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if !featuregate.Gates.Enabled(featuregate.RestrictedPodSecurity) || isWindows(otelcol) || otelcol.Spec.HostNetwork {
+		return false
+	}
+	if pod := otelcol.Spec.PodSecurityContext; pod != nil && pod.RunAsUser != nil && *pod.RunAsUser == 0 {
+		return false
+	}
+	if container := otelcol.Spec.SecurityContext; container != nil && container.RunAsUser != nil && *container.RunAsUser == 0 {
+		return false
+	}
+	for _, volume := range otelcol.Spec.Volumes {
+		if volume.HostPath != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// PodSecurityContext returns the security context of the collector pods: the instance's podSecurityContext, with
+// the fields required by the restricted Pod Security Standard defaulted when they aren't set and restrictedDefaults
+// applies.
+func PodSecurityContext(otelcol v1alpha1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	if !restrictedDefaults(otelcol) {
+		return otelcol.Spec.PodSecurityContext
+	}
+
+	sc := &corev1.PodSecurityContext{}
+	if otelcol.Spec.PodSecurityContext != nil {
+		sc = otelcol.Spec.PodSecurityContext.DeepCopy()
+	}
+	if sc.RunAsNonRoot == nil {
+		runAsNonRoot := true
+		sc.RunAsNonRoot = &runAsNonRoot
+	}
+	if sc.SeccompProfile == nil {
+		sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	return sc
+}
+
+// ContainerSecurityContext returns the security context of the collector container: the instance's securityContext,
+// with privilege escalation disabled and all the capabilities dropped unless it says otherwise, when restrictedDefaults
+// applies. The sidecars don't control the security context of the pod they're injected into, so they also default to
+// running as the non-root user of the collector images with the runtime default seccomp profile.
+func ContainerSecurityContext(otelcol v1alpha1.OpenTelemetryCollector) *corev1.SecurityContext {
+	if !restrictedDefaults(otelcol) {
+		return otelcol.Spec.SecurityContext
+	}
+
+	sc := &corev1.SecurityContext{}
+	if otelcol.Spec.SecurityContext != nil {
+		sc = otelcol.Spec.SecurityContext.DeepCopy()
+	}
+	// privileged containers always allow the privilege escalation
+	if sc.AllowPrivilegeEscalation == nil && (sc.Privileged == nil || !*sc.Privileged) {
+		allowPrivilegeEscalation := false
+		sc.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	if otelcol.Spec.Mode == v1alpha1.ModeSidecar {
+		if sc.RunAsNonRoot == nil {
+			runAsNonRoot := true
+			sc.RunAsNonRoot = &runAsNonRoot
+			// the pod, which might run as root, doesn't select the user of the sidecar
+			if sc.RunAsUser == nil {
+				runAsUser := collectorImageUser
+				sc.RunAsUser = &runAsUser
+			}
+		}
+		if sc.SeccompProfile == nil {
+			sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		}
+	}
+	return sc
+}
+
+// configReloaderSecurityContext returns the security context of the config reloader, the one of the collector
+// container. With the restricted defaults, it runs as the collector images user when no user is set, as the reloader
+// image may run as root.
+func configReloaderSecurityContext(otelcol v1alpha1.OpenTelemetryCollector) *corev1.SecurityContext {
+	if !restrictedDefaults(otelcol) {
+		return otelcol.Spec.SecurityContext
+	}
+	sc := ContainerSecurityContext(otelcol)
+	podUserSet := otelcol.Spec.PodSecurityContext != nil && otelcol.Spec.PodSecurityContext.RunAsUser != nil
+	if sc != nil && sc.RunAsUser == nil && !podUserSet {
+		runAsUser := collectorImageUser
+		sc.RunAsUser = &runAsUser
+	}
+	return sc
+}
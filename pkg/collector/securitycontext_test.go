@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func enableRestrictedPodSecurity(t *testing.T) {
+	require.NoError(t, featuregate.Gates.Set("RestrictedPodSecurity=true"))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.Gates.Set("RestrictedPodSecurity=false"))
+	})
+}
+
+func TestPodSecurityContextDisabled(t *testing.T) {
+	// prepare
+	uid := int64(1234)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: &uid},
+		},
+	}
+
+	// test and verify
+	assert.Equal(t, otelcol.Spec.PodSecurityContext, PodSecurityContext(otelcol))
+	assert.Nil(t, ContainerSecurityContext(otelcol))
+	assert.Nil(t, configReloaderSecurityContext(otelcol))
+}
+
+func TestPodSecurityContext(t *testing.T) {
+	enableRestrictedPodSecurity(t)
+	runAsNonRoot, runAsRoot := true, false
+	root := int64(0)
+	localhost := &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost}
+
+	for _, tt := range []struct {
+		name     string
+		otelcol  v1alpha1.OpenTelemetryCollector
+		expected *corev1.PodSecurityContext
+	}{
+		{
+			name: "restricted defaults",
+			expected: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &runAsNonRoot,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+		{
+			name: "instance values are kept",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					PodSecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot:   &runAsRoot,
+						SeccompProfile: localhost,
+					},
+				},
+			},
+			expected: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &runAsRoot,
+				SeccompProfile: localhost,
+			},
+		},
+		{
+			name: "no defaults on windows",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					NodeSelector: map[string]string{corev1.LabelOSStable: string(corev1.Windows)},
+				},
+			},
+		},
+		{
+			name: "no defaults for root agents",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: &root},
+				},
+			},
+			expected: &corev1.PodSecurityContext{RunAsUser: &root},
+		},
+		{
+			name: "no defaults in the host network",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					HostNetwork: true,
+				},
+			},
+		},
+		{
+			name: "no defaults with host paths",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Volumes: []corev1.Volume{{
+						Name:         "varlog",
+						VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}},
+					}},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PodSecurityContext(tt.otelcol))
+		})
+	}
+}
+
+func TestContainerSecurityContext(t *testing.T) {
+	enableRestrictedPodSecurity(t)
+
+	// prepare
+	sidecar := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeSidecar,
+		},
+	}
+
+	// test
+	sc := ContainerSecurityContext(sidecar)
+
+	// verify
+	require.NotNil(t, sc)
+	assert.False(t, *sc.AllowPrivilegeEscalation)
+	assert.Equal(t, []corev1.Capability{"ALL"}, sc.Capabilities.Drop)
+	assert.True(t, *sc.RunAsNonRoot)
+	assert.Equal(t, collectorImageUser, *sc.RunAsUser, "the sidecar doesn't run as the user of the pod")
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, sc.SeccompProfile.Type)
+
+	// the filelog agents reading the logs of the node as root keep their capabilities
+	root := int64(0)
+	agent := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:            v1alpha1.ModeDaemonSet,
+			SecurityContext: &corev1.SecurityContext{RunAsUser: &root},
+		},
+	}
+	assert.Equal(t, agent.Spec.SecurityContext, ContainerSecurityContext(agent))
+}
+
+func TestConfigReloaderSecurityContext(t *testing.T) {
+	enableRestrictedPodSecurity(t)
+	uid := int64(1234)
+	for _, tt := range []struct {
+		name     string
+		otelcol  v1alpha1.OpenTelemetryCollector
+		expected int64
+	}{
+		{
+			name:     "collector images user",
+			expected: collectorImageUser,
+		},
+		{
+			name: "container user",
+			otelcol: v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					SecurityContext: &corev1.SecurityContext{RunAsUser: &uid},
+				},
+			},
+			expected: uid,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := configReloaderSecurityContext(tt.otelcol)
+			require.NotNil(t, sc.RunAsUser)
+			assert.Equal(t, tt.expected, *sc.RunAsUser)
+		})
+	}
+
+	// the pod user applies to the reloader
+	sc := configReloaderSecurityContext(v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: &uid},
+		},
+	})
+	assert.Nil(t, sc.RunAsUser)
+}
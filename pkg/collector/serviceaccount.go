@@ -19,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
@@ -32,8 +33,8 @@ func ServiceAccountName(instance v1alpha1.OpenTelemetryCollector) string {
 }
 
 // ServiceAccount returns the service account for the given instance.
-func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccount {
-	labels := Labels(otelcol, []string{})
+func ServiceAccount(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccount {
+	labels := Labels(otelcol, cfg.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.ServiceAccount(otelcol)
 
 	return corev1.ServiceAccount{
@@ -41,7 +42,7 @@ func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccou
 			Name:        naming.ServiceAccount(otelcol),
 			Namespace:   otelcol.Namespace,
 			Labels:      labels,
-			Annotations: otelcol.Annotations,
+			Annotations: PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
 		},
 	}
 }
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	// shutdownGracePeriodOverhead is added to the shutdown timeout to cover the preStop hook and the process exit.
+	shutdownGracePeriodOverhead = 10 * time.Second
+
+	// preStopSleepSeconds gives the load balancers the time to deregister the terminating pods.
+	preStopSleepSeconds = "5"
+)
+
+// TerminationGracePeriodSeconds returns the termination grace period of the collector pods, either the instance's
+// terminationGracePeriodSeconds or one based on its shutdown timeout. It returns nil when the instance has neither.
+func TerminationGracePeriodSeconds(logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) *int64 {
+	if otelcol.Spec.TerminationGracePeriodSeconds != nil {
+		return otelcol.Spec.TerminationGracePeriodSeconds
+	}
+	if otelcol.Spec.ShutdownTimeout == "" {
+		return nil
+	}
+	timeout, err := time.ParseDuration(otelcol.Spec.ShutdownTimeout)
+	if err != nil || timeout < 0 {
+		logger.Info("invalid shutdown timeout, using the default termination grace period", "shutdownTimeout", otelcol.Spec.ShutdownTimeout)
+		return nil
+	}
+	seconds := int64(math.Ceil((timeout + shutdownGracePeriodOverhead).Seconds()))
+	return &seconds
+}
+
+// PreStopLifecycle returns the lifecycle of the collector container delaying its SIGTERM, so that the connections can
+// be drained once the pod is removed from the load balancers.
+func PreStopLifecycle() *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"sleep", preStopSleepSeconds},
+			},
+		},
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const (
+	// DeploymentRevisionAnnotation holds the revision of a Deployment and of its ReplicaSets.
+	DeploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+	defaultSmokeTestImage = "curlimages/curl:7.86.0"
+
+	// smokeTestDeadlineSeconds bounds the duration of the smoke test Job.
+	smokeTestDeadlineSeconds = int64(120)
+)
+
+// smokeTestScript sends 10 spans to a single collector pod and checks that its zpages list received traces.
+const smokeTestScript = `set -e
+host=$(getent hosts "$COLLECTOR_HOST" | awk '{ print $1; exit }')
+if [ -z "$host" ]; then
+  echo "no collector pod found behind $COLLECTOR_HOST"
+  exit 1
+fi
+case "$host" in *:*) host="[$host]" ;; esac
+
+for i in $(seq 1 10); do
+  span_id=$(printf '%016x' "$i")
+  curl -sSf -o /dev/null -X POST -H 'Content-Type: application/json' "http://$host:4318/v1/traces" --data "{
+    \"resourceSpans\": [{
+      \"resource\": {\"attributes\": [{\"key\": \"service.name\", \"value\": {\"stringValue\": \"opentelemetry-operator-smoke-test\"}}]},
+      \"scopeSpans\": [{\"spans\": [{
+        \"traceId\": \"4f70656e54656c656d65747279536d6b\",
+        \"spanId\": \"$span_id\",
+        \"name\": \"smoke-test\",
+        \"kind\": 1,
+        \"startTimeUnixNano\": \"$(date +%s)000000000\",
+        \"endTimeUnixNano\": \"$(date +%s)000000000\"
+      }]}]
+    }]
+  }"
+done
+
+if ! curl -sSf "http://$host:55679/debug/tracez" | grep -q TraceDataReceived; then
+  echo "the spans sent to $host don't appear in /debug/tracez"
+  exit 1
+fi
+echo "the spans sent to $host were received"
+`
+
+// SmokeTest builds the smoke test of the current revision of the collector Deployment.
+func SmokeTest(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector, deployment appsv1.Deployment) v1alpha1.CollectorSmokeTest {
+	revision := deployment.Annotations[DeploymentRevisionAnnotation]
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.SmokeTest(otelcol, revision)
+	labels["app.kubernetes.io/component"] = "opentelemetry-smoketest"
+
+	smokeTest := v1alpha1.CollectorSmokeTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.SmokeTest(otelcol, revision),
+			Namespace: otelcol.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.CollectorSmokeTestSpec{
+			Collector:  otelcol.Name,
+			Revision:   revision,
+			ConfigHash: deployment.Spec.Template.Annotations["opentelemetry-operator-config/sha256"],
+		},
+	}
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Name == naming.Container() {
+			smokeTest.Spec.Image = c.Image
+		}
+	}
+	return smokeTest
+}
+
+// SmokeTestJob builds the Job sending spans to the collector for the given smoke test.
+func SmokeTestJob(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, smokeTest v1alpha1.CollectorSmokeTest) batchv1.Job {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = smokeTest.Name
+	labels["app.kubernetes.io/component"] = "opentelemetry-smoketest"
+
+	image := defaultSmokeTestImage
+	if otelcol.Spec.SmokeTest != nil && otelcol.Spec.SmokeTest.Image != "" {
+		image = otelcol.Spec.SmokeTest.Image
+	}
+
+	backoffLimit := int32(0)
+	deadline := smokeTestDeadlineSeconds
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      smokeTest.Name,
+			Namespace: smokeTest.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "smoketest",
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", smokeTestScript},
+						Env: []corev1.EnvVar{{
+							Name:  "COLLECTOR_HOST",
+							Value: fmt.Sprintf("%s.%s.svc", naming.HeadlessService(otelcol), otelcol.Namespace),
+						}},
+					}},
+					Tolerations:  otelcol.Spec.Tolerations,
+					NodeSelector: otelcol.Spec.NodeSelector,
+				},
+			},
+		},
+	}
+}
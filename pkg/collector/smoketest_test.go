@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestSmokeTest(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+	}
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{DeploymentRevisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"opentelemetry-operator-config/sha256": "abc"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "otc-container", Image: "otelcol:0.66.0"}},
+				},
+			},
+		},
+	}
+
+	// test
+	smokeTest := SmokeTest(config.New(), otelcol, deployment)
+
+	// verify
+	assert.Equal(t, "my-instance-smoketest-3", smokeTest.Name)
+	assert.Equal(t, "my-ns", smokeTest.Namespace)
+	assert.Equal(t, "opentelemetry-smoketest", smokeTest.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, v1alpha1.CollectorSmokeTestSpec{
+		Collector:  "my-instance",
+		Revision:   "3",
+		ConfigHash: "abc",
+		Image:      "otelcol:0.66.0",
+	}, smokeTest.Spec)
+}
+
+func TestSmokeTestJob(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			SmokeTest: &v1alpha1.SmokeTestSpec{},
+		},
+	}
+	smokeTest := v1alpha1.CollectorSmokeTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance-smoketest-3",
+			Namespace: "my-ns",
+		},
+	}
+
+	// test
+	job := SmokeTestJob(config.New(), logger, otelcol, smokeTest)
+
+	// verify
+	assert.Equal(t, "my-instance-smoketest-3", job.Name)
+	assert.Equal(t, "my-ns", job.Namespace)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Len(t, job.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "curlimages/curl:7.86.0", job.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "COLLECTOR_HOST", Value: "my-instance-collector-headless.my-ns.svc"}}, job.Spec.Template.Spec.Containers[0].Env)
+
+	// the image can be overridden
+	otelcol.Spec.SmokeTest.Image = "my-curl:1.0"
+	job = SmokeTestJob(config.New(), logger, otelcol, smokeTest)
+	assert.Equal(t, "my-curl:1.0", job.Spec.Template.Spec.Containers[0].Image)
+}
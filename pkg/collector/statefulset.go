@@ -30,7 +30,7 @@ func StatefulSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTel
 	labels := Labels(otelcol, cfg.LabelsFilter())
 	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
 
-	annotations := Annotations(otelcol)
+	annotations := Annotations(otelcol, cfg.AnnotationsFilter())
 	podAnnotations := PodAnnotations(otelcol)
 
 	return appsv1.StatefulSet{
@@ -45,27 +45,43 @@ func StatefulSet(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTel
 			Selector: &metav1.LabelSelector{
 				MatchLabels: SelectorLabels(otelcol),
 			},
-			Template: corev1.PodTemplateSpec{
+			Template: PodTemplateOverrides(logger, otelcol, corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName(otelcol),
-					Containers:         []corev1.Container{Container(cfg, logger, otelcol)},
-					Volumes:            Volumes(cfg, otelcol),
-					DNSPolicy:          getDNSPolicy(otelcol),
-					HostNetwork:        otelcol.Spec.HostNetwork,
-					Tolerations:        otelcol.Spec.Tolerations,
-					NodeSelector:       otelcol.Spec.NodeSelector,
-					SecurityContext:    otelcol.Spec.PodSecurityContext,
-					PriorityClassName:  otelcol.Spec.PriorityClassName,
-					Affinity:           otelcol.Spec.Affinity,
+					ServiceAccountName:            ServiceAccountName(otelcol),
+					InitContainers:                otelcol.Spec.InitContainers,
+					Containers:                    podContainers(cfg, otelcol, Container(cfg, logger, otelcol)),
+					ShareProcessNamespace:         shareProcessNamespace(otelcol),
+					Volumes:                       Volumes(cfg, otelcol),
+					DNSPolicy:                     getDNSPolicy(otelcol),
+					DNSConfig:                     otelcol.Spec.DNSConfig,
+					HostNetwork:                   otelcol.Spec.HostNetwork,
+					Tolerations:                   otelcol.Spec.Tolerations,
+					NodeSelector:                  otelcol.Spec.NodeSelector,
+					SecurityContext:               PodSecurityContext(otelcol),
+					PriorityClassName:             otelcol.Spec.PriorityClassName,
+					Affinity:                      Affinity(otelcol),
+					TopologySpreadConstraints:     otelcol.Spec.TopologySpreadConstraints,
+					RuntimeClassName:              otelcol.Spec.RuntimeClassName,
+					Overhead:                      otelcol.Spec.Overhead,
+					TerminationGracePeriodSeconds: TerminationGracePeriodSeconds(logger, otelcol),
 				},
-			},
-			Replicas:             otelcol.Spec.Replicas,
-			PodManagementPolicy:  "Parallel",
-			VolumeClaimTemplates: VolumeClaimTemplates(cfg, otelcol),
+			}),
+			Replicas:                             otelcol.Spec.Replicas,
+			PodManagementPolicy:                  podManagementPolicy(otelcol),
+			UpdateStrategy:                       otelcol.Spec.StatefulSetUpdateStrategy,
+			VolumeClaimTemplates:                 VolumeClaimTemplates(cfg, otelcol),
+			PersistentVolumeClaimRetentionPolicy: otelcol.Spec.PersistentVolumeClaimRetentionPolicy,
 		},
 	}
 }
+
+func podManagementPolicy(otelcol v1alpha1.OpenTelemetryCollector) appsv1.PodManagementPolicyType {
+	if otelcol.Spec.PodManagementPolicy == "" {
+		return appsv1.ParallelPodManagement
+	}
+	return otelcol.Spec.PodManagementPolicy
+}
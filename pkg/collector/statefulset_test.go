@@ -149,6 +149,29 @@ func TestStatefulSetVolumeClaimTemplates(t *testing.T) {
 	assert.Equal(t, resource.MustParse("1Gi"), ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests["storage"])
 }
 
+func TestStatefulSetPersistentVolumeClaimRetentionPolicy(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: "statefulset",
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	ss := StatefulSet(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, otelcol.Spec.PersistentVolumeClaimRetentionPolicy, ss.Spec.PersistentVolumeClaimRetentionPolicy)
+}
+
 func TestStatefulSetPodAnnotations(t *testing.T) {
 	// prepare
 	testPodAnnotationValues := map[string]string{"annotation-key": "annotation-value"}
@@ -345,3 +368,127 @@ func TestStatefulSetAffinity(t *testing.T) {
 	assert.NotNil(t, sts2.Spec.Template.Spec.Affinity)
 	assert.Equal(t, *testAffinityValue, *sts2.Spec.Template.Spec.Affinity)
 }
+
+func TestStatefulSetRuntimeClassAndOverhead(t *testing.T) {
+	otelcol1 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+	}
+
+	cfg := config.New()
+
+	d1 := StatefulSet(cfg, logger, otelcol1)
+	assert.Nil(t, d1.Spec.Template.Spec.RuntimeClassName)
+	assert.Nil(t, d1.Spec.Template.Spec.Overhead)
+
+	otelcol2 := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-kata",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			RuntimeClassName: &testRuntimeClassName,
+			Overhead:         testOverheadValue,
+		},
+	}
+
+	d2 := StatefulSet(cfg, logger, otelcol2)
+	assert.Equal(t, &testRuntimeClassName, d2.Spec.Template.Spec.RuntimeClassName)
+	assert.Equal(t, testOverheadValue, d2.Spec.Template.Spec.Overhead)
+}
+
+func TestStatefulSetTopologySpreadConstraints(t *testing.T) {
+	// prepare
+	constraints := []v1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       v1.LabelTopologyZone,
+		WhenUnsatisfiable: v1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/instance": "default.my-instance"},
+		},
+	}}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TopologySpreadConstraints: constraints,
+		},
+	}
+
+	// test
+	ss := StatefulSet(config.New(), logger, otelcol)
+
+	// verify
+	assert.Equal(t, constraints, ss.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestStatefulSetUpdateStrategyAndPodManagementPolicy(t *testing.T) {
+	partition := int32(1)
+	for _, tt := range []struct {
+		name                string
+		podManagementPolicy appsv1.PodManagementPolicyType
+		expectedPolicy      appsv1.PodManagementPolicyType
+	}{
+		{
+			name:           "default",
+			expectedPolicy: appsv1.ParallelPodManagement,
+		},
+		{
+			name:                "ordered ready",
+			podManagementPolicy: appsv1.OrderedReadyPodManagement,
+			expectedPolicy:      appsv1.OrderedReadyPodManagement,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-instance",
+				},
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:                "statefulset",
+					PodManagementPolicy: tt.podManagementPolicy,
+					StatefulSetUpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						Type: appsv1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+							Partition: &partition,
+						},
+					},
+				},
+			}
+			cfg := config.New()
+
+			// test
+			ss := StatefulSet(cfg, logger, otelcol)
+
+			// verify
+			assert.Equal(t, tt.expectedPolicy, ss.Spec.PodManagementPolicy)
+			assert.Equal(t, otelcol.Spec.StatefulSetUpdateStrategy, ss.Spec.UpdateStrategy)
+		})
+	}
+}
+
+func TestStatefulSetDNSPolicyAndConfig(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:      "statefulset",
+			DNSPolicy: v1.DNSDefault,
+			DNSConfig: &v1.PodDNSConfig{
+				Options: []v1.PodDNSConfigOption{{Name: "ndots"}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	ss := StatefulSet(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, v1.DNSDefault, ss.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, otelcol.Spec.DNSConfig, ss.Spec.Template.Spec.DNSConfig)
+}
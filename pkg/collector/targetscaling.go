@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// UsesTargetScaling returns whether the instance is scaled on the number of targets of its target allocator instead
+// of a HorizontalPodAutoscaler.
+func UsesTargetScaling(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.MaxReplicas != nil && otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.TargetsPerReplica != nil
+}
+
+// TargetScaledReplicas returns the replicas required to scrape the targets with at most targetsPerReplica targets
+// per replica, between the minReplicas and maxReplicas of the instance.
+func TargetScaledReplicas(otelcol v1alpha1.OpenTelemetryCollector, targets int) int32 {
+	perReplica := int(*otelcol.Spec.Autoscaler.TargetsPerReplica)
+	replicas := int32((targets + perReplica - 1) / perReplica)
+
+	minReplicas := int32(1)
+	if otelcol.Spec.MinReplicas != nil {
+		minReplicas = *otelcol.Spec.MinReplicas
+	}
+	if replicas < minReplicas {
+		return minReplicas
+	}
+	if replicas > *otelcol.Spec.MaxReplicas {
+		return *otelcol.Spec.MaxReplicas
+	}
+	return replicas
+}
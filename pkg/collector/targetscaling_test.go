@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestTargetScaledReplicas(t *testing.T) {
+	two, ten, hundred := int32(2), int32(10), int32(100)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			MinReplicas: &two,
+			MaxReplicas: &ten,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				TargetsPerReplica: &hundred,
+			},
+		},
+	}
+	assert.True(t, UsesTargetScaling(otelcol))
+
+	for _, tt := range []struct {
+		targets  int
+		expected int32
+	}{
+		{targets: 0, expected: 2},
+		{targets: 150, expected: 2},
+		{targets: 201, expected: 3},
+		{targets: 500, expected: 5},
+		{targets: 5000, expected: 10},
+	} {
+		assert.Equal(t, tt.expected, TargetScaledReplicas(otelcol, tt.targets), "targets: %d", tt.targets)
+	}
+}
+
+func TestUsesTargetScalingWithoutTargetsPerReplica(t *testing.T) {
+	ten := int32(10)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			MaxReplicas: &ten,
+			Autoscaler:  &v1alpha1.AutoscalerSpec{},
+		},
+	}
+	assert.False(t, UsesTargetScaling(otelcol))
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
 )
 
 type VersionUpgrade struct {
@@ -61,6 +62,7 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 		}
 		upgraded, err := u.ManagedInstance(ctx, original)
 		if err != nil {
+			metrics.RecordUpgrade("OpenTelemetryCollector", err)
 			const msg = "automated update not possible. Configuration must be corrected manually and CR instance must be re-created."
 			itemLogger.Info(msg)
 			u.Recorder.Event(&original, "Error", "Upgrade", msg)
@@ -72,6 +74,7 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 			st := upgraded.Status
 			patch := client.MergeFrom(&original)
 			if err := u.Client.Patch(ctx, &upgraded, patch); err != nil {
+				metrics.RecordUpgrade("OpenTelemetryCollector", err)
 				itemLogger.Error(err, "failed to apply changes to instance")
 				continue
 			}
@@ -79,10 +82,12 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 			// the status object requires its own update
 			upgraded.Status = st
 			if err := u.Client.Status().Patch(ctx, &upgraded, patch); err != nil {
+				metrics.RecordUpgrade("OpenTelemetryCollector", err)
 				itemLogger.Error(err, "failed to apply changes to instance's status object")
 				continue
 			}
 
+			metrics.RecordUpgrade("OpenTelemetryCollector", nil)
 			itemLogger.Info("instance upgraded", "version", upgraded.Status.Version)
 		}
 	}
@@ -112,8 +117,24 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 		return otelcol, nil
 	}
 
+	// the pinned version replaces the version shipped with the operator as the target of the upgrade
+	target := u.Version.OpenTelemetryCollector
+	var pinnedV *semver.Version
+	if otelcol.Spec.Version != "" {
+		pinnedV, err = semver.NewVersion(otelcol.Spec.Version)
+		if err != nil {
+			u.Log.Error(err, "failed to parse the pinned version for OpenTelemetry Collector instance", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Spec.Version)
+			return otelcol, err
+		}
+		if !pinnedV.GreaterThan(instanceV) {
+			u.Log.V(1).Info("skipping upgrade for OpenTelemetry Collector instance, as it's pinned to its version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version, "pinned", otelcol.Spec.Version)
+			return otelcol, nil
+		}
+		target = otelcol.Spec.Version
+	}
+
 	for _, available := range versions {
-		if available.GreaterThan(instanceV) {
+		if available.GreaterThan(instanceV) && (pinnedV == nil || !available.GreaterThan(pinnedV)) {
 			upgraded, err := available.upgrade(u, &otelcol) //available.upgrade(params., &otelcol)
 
 			if err != nil {
@@ -127,8 +148,9 @@ func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1alpha1.Op
 		}
 	}
 
-	// at the end of the process, we are up to date with the latest known version, which is what we have from versions.txt
-	otelcol.Status.Version = u.Version.OpenTelemetryCollector
+	// at the end of the process, we are up to date with the latest known version, which is what we have from versions.txt,
+	// or with the pinned version
+	otelcol.Status.Version = target
 
 	u.Log.V(1).Info("final version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
 	return otelcol, nil
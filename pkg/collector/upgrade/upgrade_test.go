@@ -105,6 +105,42 @@ func TestUpgradeUpToLatestKnownVersion(t *testing.T) {
 	assert.Equal(t, "0.10.0", res.Status.Version)
 }
 
+func TestUpgradeUpToPinnedVersion(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		pinned    string
+		expectedV string
+	}{
+		{"older-than-the-operator", "0.9.0", "0.9.0"},
+		{"same-as-the-instance", "0.8.0", "0.8.0"},
+		{"older-than-the-instance", "0.2.0", "0.8.0"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+			existing := makeOtelcol(nsn)
+			existing.Spec.Version = tt.pinned
+			existing.Status.Version = "0.8.0"
+
+			currentV := version.Get()
+			currentV.OpenTelemetryCollector = upgrade.Latest.String()
+			up := &upgrade.VersionUpgrade{
+				Log:      logger,
+				Version:  currentV,
+				Client:   k8sClient,
+				Recorder: record.NewFakeRecorder(upgrade.RecordBufferSize),
+			}
+
+			// test
+			res, err := up.ManagedInstance(context.Background(), existing)
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedV, res.Status.Version)
+		})
+	}
+}
+
 func TestVersionsShouldNotBeChanged(t *testing.T) {
 	for _, tt := range []struct {
 		desc            string
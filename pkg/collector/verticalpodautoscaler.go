@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscalers. The VerticalPodAutoscaler API isn't a dependency
+// of the operator, the VerticalPodAutoscalers are handled as unstructured objects.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+type verticalPodAutoscalerSpec struct {
+	TargetRef      autoscalingv1.CrossVersionObjectReference `json:"targetRef"`
+	UpdatePolicy   *vpaUpdatePolicy                          `json:"updatePolicy,omitempty"`
+	ResourcePolicy vpaResourcePolicy                         `json:"resourcePolicy"`
+}
+
+type vpaUpdatePolicy struct {
+	UpdateMode v1alpha1.VPAUpdateMode `json:"updateMode"`
+}
+
+type vpaResourcePolicy struct {
+	ContainerPolicies []vpaContainerPolicy `json:"containerPolicies"`
+}
+
+type vpaContainerPolicy struct {
+	ContainerName       string                       `json:"containerName"`
+	Mode                string                       `json:"mode,omitempty"`
+	MinAllowed          corev1.ResourceList          `json:"minAllowed,omitempty"`
+	MaxAllowed          corev1.ResourceList          `json:"maxAllowed,omitempty"`
+	ControlledResources []corev1.ResourceName        `json:"controlledResources,omitempty"`
+	ControlledValues    v1alpha1.VPAControlledValues `json:"controlledValues,omitempty"`
+}
+
+// UsesVPA returns whether the daemonset collector has a VerticalPodAutoscaler.
+func UsesVPA(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.Mode == v1alpha1.ModeDaemonSet && otelcol.Spec.Autoscaler != nil && otelcol.Spec.Autoscaler.VPA != nil
+}
+
+// VerticalPodAutoscaler returns the VerticalPodAutoscaler of the collector DaemonSet, only adjusting the resources of
+// the collector container.
+func VerticalPodAutoscaler(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) (*unstructured.Unstructured, error) {
+	labels := Labels(otelcol, cfg.LabelsFilter())
+	labels["app.kubernetes.io/name"] = naming.Collector(otelcol)
+
+	vpa := otelcol.Spec.Autoscaler.VPA
+	spec := verticalPodAutoscalerSpec{
+		TargetRef: autoscalingv1.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+			Name:       naming.Collector(otelcol),
+		},
+		ResourcePolicy: vpaResourcePolicy{
+			ContainerPolicies: []vpaContainerPolicy{
+				{
+					ContainerName:       naming.Container(),
+					MinAllowed:          vpa.MinAllowed,
+					MaxAllowed:          vpa.MaxAllowed,
+					ControlledResources: vpa.ControlledResources,
+					ControlledValues:    vpa.ControlledValues,
+				},
+				{
+					// the other containers, like the config reloader, keep their resources
+					ContainerName: "*",
+					Mode:          "Off",
+				},
+			},
+		},
+	}
+	if vpa.UpdateMode != "" {
+		spec.UpdatePolicy = &vpaUpdatePolicy{UpdateMode: vpa.UpdateMode}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the VerticalPodAutoscaler spec: %w", err)
+	}
+
+	verticalPodAutoscaler := &unstructured.Unstructured{}
+	verticalPodAutoscaler.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	verticalPodAutoscaler.SetName(naming.VerticalPodAutoscaler(otelcol))
+	verticalPodAutoscaler.SetNamespace(otelcol.Namespace)
+	verticalPodAutoscaler.SetLabels(labels)
+	verticalPodAutoscaler.SetAnnotations(Annotations(otelcol, cfg.AnnotationsFilter()))
+	verticalPodAutoscaler.Object["spec"] = content
+	return verticalPodAutoscaler, nil
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	. "github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestVerticalPodAutoscaler(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: v1alpha1.ModeDaemonSet,
+			Autoscaler: &v1alpha1.AutoscalerSpec{
+				VPA: &v1alpha1.VPASpec{
+					UpdateMode:          v1alpha1.VPAUpdateModeRecreate,
+					MinAllowed:          corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+					MaxAllowed:          corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+					ControlledResources: []corev1.ResourceName{corev1.ResourceMemory},
+					ControlledValues:    v1alpha1.VPAControlledValuesRequestsOnly,
+				},
+			},
+		},
+	}
+
+	// test
+	vpa, err := VerticalPodAutoscaler(config.New(), logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	assert.True(t, UsesVPA(otelcol))
+	assert.Equal(t, VerticalPodAutoscalerGVK, vpa.GroupVersionKind())
+	assert.Equal(t, "my-instance-collector", vpa.GetName())
+	assert.Equal(t, "observability", vpa.GetNamespace())
+	assert.Equal(t, "my-instance-collector", vpa.GetLabels()["app.kubernetes.io/name"])
+
+	target, _, _ := unstructured.NestedStringMap(vpa.Object, "spec", "targetRef")
+	assert.Equal(t, map[string]string{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"name":       "my-instance-collector",
+	}, target)
+	updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, "Recreate", updateMode)
+
+	policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	require.Len(t, policies, 2)
+	assert.Equal(t, map[string]interface{}{
+		"containerName":       "otc-container",
+		"minAllowed":          map[string]interface{}{"memory": "128Mi"},
+		"maxAllowed":          map[string]interface{}{"cpu": "2", "memory": "2Gi"},
+		"controlledResources": []interface{}{"memory"},
+		"controlledValues":    "RequestsOnly",
+	}, policies[0])
+	assert.Equal(t, map[string]interface{}{"containerName": "*", "mode": "Off"}, policies[1])
+}
+
+func TestVerticalPodAutoscalerDefaults(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:       v1alpha1.ModeDaemonSet,
+			Autoscaler: &v1alpha1.AutoscalerSpec{VPA: &v1alpha1.VPASpec{}},
+		},
+	}
+
+	// test
+	vpa, err := VerticalPodAutoscaler(config.New(), logger, otelcol)
+
+	// verify
+	require.NoError(t, err)
+	_, found, _ := unstructured.NestedFieldNoCopy(vpa.Object, "spec", "updatePolicy")
+	assert.False(t, found)
+	policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	assert.Equal(t, map[string]interface{}{"containerName": "otc-container"}, policies[0])
+}
+
+func TestUsesVPA(t *testing.T) {
+	for _, tt := range []struct {
+		mode     v1alpha1.Mode
+		expected bool
+	}{
+		{mode: v1alpha1.ModeDaemonSet, expected: true},
+		{mode: v1alpha1.ModeDeployment},
+		{mode: v1alpha1.ModeSidecar},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			otelcol := v1alpha1.OpenTelemetryCollector{
+				Spec: v1alpha1.OpenTelemetryCollectorSpec{
+					Mode:       tt.mode,
+					Autoscaler: &v1alpha1.AutoscalerSpec{VPA: &v1alpha1.VPASpec{}},
+				},
+			}
+			assert.Equal(t, tt.expected, UsesVPA(otelcol))
+		})
+	}
+}
@@ -16,6 +16,9 @@
 package collector
 
 import (
+	"path"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -38,9 +41,80 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		},
 	}}
 
+	if parts := ConfigParts(otelcol); parts > 1 {
+		volumes[0].VolumeSource = corev1.VolumeSource{Projected: configPartsVolumeSource(cfg, naming.ConfigMap(otelcol), parts)}
+	}
+
 	if len(otelcol.Spec.Volumes) > 0 {
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
 
+	if usesTargetAllocatorMTLS(otelcol) {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.TATLSVolume(),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: naming.TAClientCertificate(otelcol)},
+			},
+		})
+	}
+	volumes = append(volumes, receiverTLSVolumes(otelcol)...)
+
 	return volumes
 }
+
+// configPartsVolumeSource projects the ConfigMaps holding the parts of a split config in the config volume, each part
+// as its own file.
+func configPartsVolumeSource(cfg config.Config, name string, parts int) *corev1.ProjectedVolumeSource {
+	projected := &corev1.ProjectedVolumeSource{}
+	for i := 0; i < parts; i++ {
+		key := ConfigPartEntry(cfg, i)
+		projected.Sources = append(projected.Sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: naming.ConfigMapPart(name, i)},
+				Items:                []corev1.KeyToPath{{Key: key, Path: key}},
+			},
+		})
+	}
+	return projected
+}
+
+// TargetAllocatorTLSMountPath is the directory the client certificate of the TargetAllocator is mounted to.
+const TargetAllocatorTLSMountPath = "/etc/targetallocator-tls"
+
+func usesTargetAllocatorMTLS(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.TargetAllocator.Enabled && otelcol.Spec.TargetAllocator.MTLS != nil
+}
+
+// SecretKeyForFile returns the Secret and the key the file at the given path of the collector container is read from.
+// The file must be mounted by one of the spec.volumeMounts from a Secret volume of spec.volumes.
+func SecretKeyForFile(otelcol v1alpha1.OpenTelemetryCollector, file string) (string, string, bool) {
+	file = path.Clean(file)
+	for _, mount := range otelcol.Spec.VolumeMounts {
+		mountPath := path.Clean(mount.MountPath)
+		var rel string
+		switch {
+		case file == mountPath && mount.SubPath != "":
+			rel = mount.SubPath
+		case strings.HasPrefix(file, mountPath+"/"):
+			rel = path.Join(mount.SubPath, strings.TrimPrefix(file, mountPath+"/"))
+		default:
+			continue
+		}
+
+		for _, volume := range otelcol.Spec.Volumes {
+			if volume.Name != mount.Name || volume.Secret == nil {
+				continue
+			}
+			if len(volume.Secret.Items) == 0 {
+				// without items, each key of the Secret is a file at the root of the volume
+				return volume.Secret.SecretName, rel, !strings.Contains(rel, "/")
+			}
+			for _, item := range volume.Secret.Items {
+				if path.Clean(item.Path) == rel {
+					return volume.Secret.SecretName, item.Key, true
+				}
+			}
+		}
+	}
+	return "", "", false
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -61,3 +62,82 @@ func TestVolumeAllowsMoreToBeAdded(t *testing.T) {
 	// check that it's the otc-internal volume, with the config map
 	assert.Equal(t, "my-volume", volumes[1].Name)
 }
+
+func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+				MTLS:    &v1alpha1.TargetAllocatorMTLS{},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, naming.TATLSVolume(), volumes[1].Name)
+	assert.Equal(t, "my-instance-targetallocator-client", volumes[1].Secret.SecretName)
+	assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{
+		Name:      naming.TATLSVolume(),
+		MountPath: TargetAllocatorTLSMountPath,
+		ReadOnly:  true,
+	})
+}
+
+func TestSecretKeyForFile(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "certs", MountPath: "/certs"},
+				{Name: "mapped", MountPath: "/mapped/"},
+				{Name: "single", MountPath: "/single/server.crt", SubPath: "server.crt"},
+				{Name: "config", MountPath: "/config"},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "receiver-tls"}}},
+				{Name: "mapped", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+					SecretName: "mapped-tls",
+					Items:      []corev1.KeyToPath{{Key: "tls.crt", Path: "server/cert.pem"}},
+				}}},
+				{Name: "single", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+					SecretName: "single-tls",
+					Items:      []corev1.KeyToPath{{Key: "tls.crt", Path: "server.crt"}},
+				}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		file   string
+		secret string
+		key    string
+		found  bool
+	}{
+		{file: "/certs/tls.crt", secret: "receiver-tls", key: "tls.crt", found: true},
+		{file: "/mapped/server/cert.pem", secret: "mapped-tls", key: "tls.crt", found: true},
+		{file: "/single/server.crt", secret: "single-tls", key: "tls.crt", found: true},
+		{file: "/certs/nested/tls.crt"},
+		{file: "/mapped/unknown.pem"},
+		{file: "/config/tls.crt"},
+		{file: "/etc/tls.crt"},
+	} {
+		t.Run(tt.file, func(t *testing.T) {
+			secret, key, found := SecretKeyForFile(otelcol, tt.file)
+			assert.Equal(t, tt.found, found)
+			if tt.found {
+				assert.Equal(t, tt.secret, secret)
+				assert.Equal(t, tt.key, key)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
 // VolumeClaimTemplates builds the volumeClaimTemplates for the given instance,
@@ -32,9 +33,12 @@ func VolumeClaimTemplates(cfg config.Config, otelcol v1alpha1.OpenTelemetryColle
 		return volumeClaimTemplates
 	}
 
-	// Add all user specified claims or use default.
-	volumeClaimTemplates = append(volumeClaimTemplates,
-		otelcol.Spec.VolumeClaimTemplates...)
+	// Add all user specified claims or use default, named after valid volume names, e.g. file-storage for file_storage.
+	for _, claim := range otelcol.Spec.VolumeClaimTemplates {
+		claim := *claim.DeepCopy()
+		claim.Name = naming.VolumeClaim(claim.Name)
+		volumeClaimTemplates = append(volumeClaimTemplates, claim)
+	}
 
 	return volumeClaimTemplates
 }
@@ -63,6 +63,29 @@ func TestVolumeClaimAllowsUserToAdd(t *testing.T) {
 	assert.Equal(t, resource.MustParse("1Gi"), volumeClaims[0].Spec.Resources.Requests["storage"])
 }
 
+func TestVolumeClaimNames(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode: "statefulset",
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "file_storage",
+				},
+			}},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumeClaims := VolumeClaimTemplates(cfg, otelcol)
+
+	// verify
+	assert.Len(t, volumeClaims, 1)
+	assert.Equal(t, "file-storage", volumeClaims[0].Name)
+	assert.Equal(t, "file_storage", otelcol.Spec.VolumeClaimTemplates[0].Name)
+}
+
 func TestVolumeClaimChecksForStatefulset(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -17,6 +17,9 @@ package constants
 const (
 	EnvOTELServiceName          = "OTEL_SERVICE_NAME"
 	EnvOTELExporterOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTELExporterCertificate  = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	EnvOTELExporterClientCert   = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	EnvOTELExporterClientKey    = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
 	EnvOTELResourceAttrs        = "OTEL_RESOURCE_ATTRIBUTES"
 	EnvOTELPropagators          = "OTEL_PROPAGATORS"
 	EnvOTELTracesSampler        = "OTEL_TRACES_SAMPLER"
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventexport publishes the state transitions of OpenTelemetryCollector instances to an external event bus.
+package eventexport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// DefaultTopic is the NATS subject or Kafka topic used when the instance doesn't specify one.
+const DefaultTopic = "opentelemetry-operator.events"
+
+const publishTimeout = 5 * time.Second
+
+// queueSize is the number of events waiting to be published, the next ones are dropped.
+const queueSize = 100
+
+// EventType represents the state transition an event was published for.
+type EventType string
+
+const (
+	// EventCreated is published when a new instance is reconciled for the first time.
+	EventCreated EventType = "Created"
+
+	// EventConfigChanged is published when the collector configuration of an instance changes.
+	EventConfigChanged EventType = "ConfigChanged"
+
+	// EventScaled is published when the replicas of an instance change.
+	EventScaled EventType = "Scaled"
+
+	// EventError is published when the reconciliation of an instance fails.
+	EventError EventType = "Error"
+)
+
+// Event is the JSON document published to the event bus.
+type Event struct {
+	Type      EventType `json:"type"`
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// PublishFunc sends the payload to the event bus described by the spec.
+type PublishFunc func(ctx context.Context, spec v1alpha1.EventExportSpec, topic string, payload []byte) error
+
+// Exporter keeps track of the last observed state of the instances and publishes the transitions between them. The
+// events are queued by the reconciliation, and published once it's done, when the exporter is started.
+type Exporter struct {
+	log     logr.Logger
+	publish PublishFunc
+	queue   chan publication
+
+	mu       sync.Mutex
+	observed map[types.NamespacedName]observedState
+}
+
+type publication struct {
+	spec    v1alpha1.EventExportSpec
+	topic   string
+	event   EventType
+	payload []byte
+}
+
+type observedState struct {
+	configHash string
+	replicas   int32
+}
+
+// New returns an exporter publishing to NATS or Kafka.
+func New(logger logr.Logger) *Exporter {
+	return NewWithPublisher(logger, Publish)
+}
+
+// NewWithPublisher returns an exporter publishing events with the given function.
+func NewWithPublisher(logger logr.Logger, publish PublishFunc) *Exporter {
+	return &Exporter{
+		log:      logger,
+		publish:  publish,
+		queue:    make(chan publication, queueSize),
+		observed: map[types.NamespacedName]observedState{},
+	}
+}
+
+// Observe compares the instance with its last observed state and queues an event for each transition, without waiting
+// for the event bus. The reconcileErr is the error returned by the reconciliation of the instance, if any.
+func (e *Exporter) Observe(otelcol v1alpha1.OpenTelemetryCollector, reconcileErr error) {
+	nsn := types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}
+	current := observedState{
+		configHash: fmt.Sprintf("%x", sha256.Sum256([]byte(otelcol.Spec.Config))),
+	}
+	if otelcol.Spec.Replicas != nil {
+		current.replicas = *otelcol.Spec.Replicas
+	}
+
+	e.mu.Lock()
+	previous, seen := e.observed[nsn]
+	e.observed[nsn] = current
+	e.mu.Unlock()
+
+	if !otelcol.Spec.EventExport.Enabled {
+		return
+	}
+
+	var events []Event
+	now := time.Now().UTC()
+	switch {
+	case !seen && otelcol.Status.Version == "":
+		events = append(events, Event{Type: EventCreated, Time: now})
+	case seen:
+		if previous.configHash != current.configHash {
+			events = append(events, Event{Type: EventConfigChanged, Time: now})
+		}
+		if previous.replicas != current.replicas {
+			events = append(events, Event{
+				Type:    EventScaled,
+				Time:    now,
+				Message: fmt.Sprintf("replicas changed from %d to %d", previous.replicas, current.replicas),
+			})
+		}
+	}
+	if reconcileErr != nil {
+		events = append(events, Event{Type: EventError, Time: now, Message: reconcileErr.Error()})
+	}
+
+	topic := otelcol.Spec.EventExport.Topic
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	for _, event := range events {
+		event.Namespace = otelcol.Namespace
+		event.Name = otelcol.Name
+		payload, err := json.Marshal(event)
+		if err != nil {
+			e.log.Error(err, "failed to marshal event", "type", event.Type)
+			continue
+		}
+
+		select {
+		case e.queue <- publication{spec: otelcol.Spec.EventExport, topic: topic, event: event.Type, payload: payload}:
+		default:
+			e.log.Info("dropping the event, too many events are waiting to be published", "type", event.Type, "topic", topic)
+		}
+	}
+}
+
+// Start publishes the queued events until the context is done, each one within the publish timeout.
+func (e *Exporter) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case p := <-e.queue:
+			publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+			if err := e.publish(publishCtx, p.spec, p.topic, p.payload); err != nil {
+				e.log.Error(err, "failed to publish event", "type", p.event, "topic", p.topic)
+			}
+			cancel()
+		}
+	}
+}
+
+// Forget drops the observed state of a deleted instance.
+func (e *Exporter) Forget(nsn types.NamespacedName) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.observed, nsn)
+}
+
+// Publish sends the payload to the NATS servers or Kafka brokers described by the spec.
+func Publish(ctx context.Context, spec v1alpha1.EventExportSpec, topic string, payload []byte) error {
+	if len(spec.Brokers) == 0 {
+		return fmt.Errorf("no brokers to publish to")
+	}
+
+	switch spec.Type {
+	case v1alpha1.EventExportTypeNATS:
+		nc, err := nats.Connect(strings.Join(spec.Brokers, ","), nats.Timeout(publishTimeout))
+		if err != nil {
+			return fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+		defer nc.Close()
+		if err := nc.Publish(topic, payload); err != nil {
+			return fmt.Errorf("failed to publish to NATS: %w", err)
+		}
+		return nc.FlushWithContext(ctx)
+	case v1alpha1.EventExportTypeKafka:
+		w := kafka.NewWriter(kafka.WriterConfig{
+			Brokers: spec.Brokers,
+			Topic:   topic,
+		})
+		defer w.Close()
+		if err := w.WriteMessages(ctx, kafka.Message{Value: payload}); err != nil {
+			return fmt.Errorf("failed to publish to Kafka: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported event export type %q", spec.Type)
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+var logger = logf.Log.WithName("unit-tests")
+
+func TestObserve(t *testing.T) {
+	// prepare
+	var published []Event
+	var topics []string
+	exporter := NewWithPublisher(logger, func(_ context.Context, _ v1alpha1.EventExportSpec, topic string, payload []byte) error {
+		var event Event
+		require.NoError(t, json.Unmarshal(payload, &event))
+		published = append(published, event)
+		topics = append(topics, topic)
+		return nil
+	})
+	one, two := int32(1), int32(2)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config:   "receivers: {}",
+			Replicas: &one,
+			EventExport: v1alpha1.EventExportSpec{
+				Enabled: true,
+				Type:    v1alpha1.EventExportTypeNATS,
+				Brokers: []string{"nats://nats:4222"},
+			},
+		},
+	}
+
+	// test
+	exporter.Observe(otelcol, nil)
+	otelcol.Status.Version = "0.0.1"
+	exporter.Observe(otelcol, nil)
+	otelcol.Spec.Config = "receivers: {otlp: {}}"
+	otelcol.Spec.Replicas = &two
+	exporter.Observe(otelcol, errors.New("failed to reconcile"))
+	require.Empty(t, published)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, exporter.Start(ctx))
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return len(exporter.queue) == 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// verify
+	require.Len(t, published, 4)
+	assert.Equal(t, EventCreated, published[0].Type)
+	assert.Equal(t, "my-instance", published[0].Name)
+	assert.Equal(t, "default", published[0].Namespace)
+	assert.Equal(t, EventConfigChanged, published[1].Type)
+	assert.Equal(t, EventScaled, published[2].Type)
+	assert.Equal(t, "replicas changed from 1 to 2", published[2].Message)
+	assert.Equal(t, EventError, published[3].Type)
+	assert.Equal(t, "failed to reconcile", published[3].Message)
+	assert.Equal(t, DefaultTopic, topics[0])
+}
+
+func TestObserveDisabled(t *testing.T) {
+	// prepare
+	exporter := NewWithPublisher(logger, func(context.Context, v1alpha1.EventExportSpec, string, []byte) error {
+		assert.Fail(t, "should not have been called")
+		return nil
+	})
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+	}
+
+	// test
+	exporter.Observe(otelcol, errors.New("failed to reconcile"))
+	exporter.Forget(types.NamespacedName{Name: "my-instance", Namespace: "default"})
+
+	// verify
+	assert.Empty(t, exporter.observed)
+	assert.Empty(t, exporter.queue)
+}
+
+func TestObserveFullQueue(t *testing.T) {
+	// prepare
+	exporter := NewWithPublisher(logger, func(context.Context, v1alpha1.EventExportSpec, string, []byte) error {
+		return nil
+	})
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			EventExport: v1alpha1.EventExportSpec{Enabled: true, Type: v1alpha1.EventExportTypeNATS},
+		},
+	}
+
+	// test
+	for i := 0; i < queueSize+1; i++ {
+		exporter.Observe(otelcol, errors.New("failed to reconcile"))
+	}
+
+	// verify
+	assert.Len(t, exporter.queue, queueSize)
+}
+
+func TestPublishUnsupportedType(t *testing.T) {
+	err := Publish(context.Background(), v1alpha1.EventExportSpec{Brokers: []string{"localhost:4222"}}, DefaultTopic, []byte("{}"))
+	assert.ErrorContains(t, err, "unsupported event export type")
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featuregate holds the feature gates of the operator, which are distinct from the feature gates of the collectors
+// it manages.
+package featuregate
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// SidecarInjection enables the injection of collector sidecars into the pods annotated with
+	// sidecar.opentelemetry.io/inject.
+	SidecarInjection featuregate.Feature = "SidecarInjection"
+
+	// TargetAllocator enables the deployment of target allocators for the collectors in statefulset mode.
+	TargetAllocator featuregate.Feature = "TargetAllocator"
+
+	// MultiClusterFederation enables the deployment of collectors to the remote clusters set in their federationRef.
+	MultiClusterFederation featuregate.Feature = "MultiClusterFederation"
+
+	// OpAMPBridge enables the deployment of OpAMP bridges managing the collectors on behalf of an OpAMP server.
+	OpAMPBridge featuregate.Feature = "OpAMPBridge"
+
+	// GoAutoInstrumentation enables the injection of the Go eBPF auto-instrumentation agent, which runs as a privileged
+	// sidecar, into the pods annotated with instrumentation.opentelemetry.io/inject-go.
+	GoAutoInstrumentation featuregate.Feature = "GoAutoInstrumentation"
+
+	// CollectorClusterRoles enables the ClusterRoles granting the collectors with rbac.clusterRole the cluster wide
+	// permissions of their components. The authors of the collectors then read the cluster wide resources, like the
+	// nodes, with the permissions of the operator.
+	CollectorClusterRoles featuregate.Feature = "CollectorClusterRoles"
+
+	// RestrictedPodSecurity defaults the security contexts of the collector pods to the restricted Pod Security
+	// Standard, which the collectors running as root or needing capabilities have to opt out of.
+	RestrictedPodSecurity featuregate.Feature = "RestrictedPodSecurity"
+)
+
+// Gates are the feature gates of the operator, set with the --feature-gates flag.
+var Gates featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+var defaultGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	SidecarInjection:       {Default: true, PreRelease: featuregate.GA, LockToDefault: true},
+	TargetAllocator:        {Default: true, PreRelease: featuregate.Beta},
+	MultiClusterFederation: {Default: false, PreRelease: featuregate.Alpha},
+	OpAMPBridge:            {Default: false, PreRelease: featuregate.Alpha},
+	GoAutoInstrumentation:  {Default: false, PreRelease: featuregate.Alpha},
+	CollectorClusterRoles:  {Default: false, PreRelease: featuregate.Alpha},
+	RestrictedPodSecurity:  {Default: false, PreRelease: featuregate.Alpha},
+}
+
+func init() {
+	runtime.Must(Gates.Add(defaultGates))
+}
+
+// State is the state of a feature gate, as reported by the status endpoint of the operator.
+type State struct {
+	Name    string `json:"name"`
+	Stage   string `json:"stage"`
+	Enabled bool   `json:"enabled"`
+}
+
+// States returns the state of the feature gates of the operator, sorted by name.
+func States() []State {
+	var states []State
+	for feature, spec := range defaultGates {
+		states = append(states, State{
+			Name:    string(feature),
+			Stage:   stage(spec),
+			Enabled: Gates.Enabled(feature),
+		})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+func stage(spec featuregate.FeatureSpec) string {
+	switch spec.PreRelease {
+	case featuregate.Alpha:
+		return "alpha"
+	case featuregate.Beta:
+		return "beta"
+	}
+	return "stable"
+}
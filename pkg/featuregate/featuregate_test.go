@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultStates(t *testing.T) {
+	assert.Equal(t, []State{
+		{Name: "CollectorClusterRoles", Stage: "alpha", Enabled: false},
+		{Name: "GoAutoInstrumentation", Stage: "alpha", Enabled: false},
+		{Name: "MultiClusterFederation", Stage: "alpha", Enabled: false},
+		{Name: "OpAMPBridge", Stage: "alpha", Enabled: false},
+		{Name: "RestrictedPodSecurity", Stage: "alpha", Enabled: false},
+		{Name: "SidecarInjection", Stage: "stable", Enabled: true},
+		{Name: "TargetAllocator", Stage: "beta", Enabled: true},
+	}, States())
+}
+
+func TestSetGates(t *testing.T) {
+	// prepare
+	defer func() {
+		require.NoError(t, Gates.Set("MultiClusterFederation=false,TargetAllocator=true"))
+	}()
+
+	// test
+	err := Gates.Set("MultiClusterFederation=true,TargetAllocator=false")
+
+	// verify
+	require.NoError(t, err)
+	assert.True(t, Gates.Enabled(MultiClusterFederation))
+	assert.False(t, Gates.Enabled(TargetAllocator))
+}
+
+func TestStableGateCannotBeDisabled(t *testing.T) {
+	err := Gates.Set("SidecarInjection=false")
+	assert.ErrorContains(t, err, "cannot set feature gate SidecarInjection to false")
+	assert.True(t, Gates.Enabled(SidecarInjection))
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package federation connects the operator to the remote clusters collectors are deployed to.
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// kubeconfigKey is the key of the kubeconfig in the Secrets created by Cluster API.
+const kubeconfigKey = "value"
+
+// Clients keeps a client for each remote cluster, built from its Cluster API kubeconfig Secret.
+type Clients struct {
+	scheme    *runtime.Scheme
+	newClient func(*rest.Config, client.Options) (client.Client, error)
+
+	mu      sync.Mutex
+	clients map[types.NamespacedName]remoteClient
+}
+
+type remoteClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+// NewClients returns the remote clients registry, using the given scheme for the remote objects.
+func NewClients(scheme *runtime.Scheme) *Clients {
+	return &Clients{
+		scheme:    scheme,
+		newClient: client.New,
+		clients:   map[types.NamespacedName]remoteClient{},
+	}
+}
+
+// KubeconfigSecret returns the name of the Secret holding the kubeconfig of the referenced cluster. It's always read
+// from the namespace of the instance, so that the operator doesn't hand the clusters of other namespaces to the
+// authors of the collectors.
+func KubeconfigSecret(otelcol v1alpha1.OpenTelemetryCollector) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: otelcol.Namespace,
+		Name:      fmt.Sprintf("%s-kubeconfig", otelcol.Spec.FederationRef.ClusterName),
+	}
+}
+
+// ClientFor returns the client reconciling the instance: the resources of the collector are read from and written
+// to the referenced cluster, while the OpenTelemetry resources stay on the local cluster.
+func (c *Clients) ClientFor(ctx context.Context, local client.Client, otelcol v1alpha1.OpenTelemetryCollector) (client.Client, error) {
+	nsn := KubeconfigSecret(otelcol)
+	secret := &corev1.Secret{}
+	if err := local.Get(ctx, nsn, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig secret of the cluster %s: %w", otelcol.Spec.FederationRef.ClusterName, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.clients[nsn]
+	if !ok || cached.resourceVersion != secret.ResourceVersion {
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[kubeconfigKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the kubeconfig of the cluster %s: %w", otelcol.Spec.FederationRef.ClusterName, err)
+		}
+		remote, err := c.newClient(restConfig, client.Options{Scheme: c.scheme})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the client of the cluster %s: %w", otelcol.Spec.FederationRef.ClusterName, err)
+		}
+		cached = remoteClient{resourceVersion: secret.ResourceVersion, client: remote}
+		c.clients[nsn] = cached
+	}
+
+	return &routingClient{Client: cached.client, local: local, owner: otelcol.UID}, nil
+}
+
+// routingClient sends the requests for the OpenTelemetry resources to the local cluster and the others to the remote
+// one. The owner references to the instance are removed from the remote objects, as the garbage collector of the remote
+// cluster would delete them otherwise.
+type routingClient struct {
+	client.Client
+	local client.Client
+	owner types.UID
+}
+
+var _ client.Client = (*routingClient)(nil)
+
+func (c *routingClient) target(obj runtime.Object) client.Client {
+	gvk, err := apiutil.GVKForObject(obj, c.local.Scheme())
+	if err == nil && gvk.Group == v1alpha1.GroupVersion.Group {
+		return c.local
+	}
+	return c.Client
+}
+
+func (c *routingClient) withoutOwner(obj client.Object) client.Object {
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != c.owner {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+	return obj
+}
+
+// Get implements client.Client.
+func (c *routingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.target(obj).Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Client.
+func (c *routingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.target(list).List(ctx, list, opts...)
+}
+
+// Create implements client.Client.
+func (c *routingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	target := c.target(obj)
+	if target == c.Client {
+		obj = c.withoutOwner(obj)
+	}
+	return target.Create(ctx, obj, opts...)
+}
+
+// Delete implements client.Client.
+func (c *routingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.target(obj).Delete(ctx, obj, opts...)
+}
+
+// Update implements client.Client.
+func (c *routingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	target := c.target(obj)
+	if target == c.Client {
+		obj = c.withoutOwner(obj)
+	}
+	return target.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.Client. The patch is computed from the object when it's sent, so it doesn't restore
+// the removed owner references.
+func (c *routingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	target := c.target(obj)
+	if target == c.Client {
+		obj = c.withoutOwner(obj)
+	}
+	return target.Patch(ctx, obj, patch, opts...)
+}
+
+// DeleteAllOf implements client.Client.
+func (c *routingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.target(obj).DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status implements client.Client.
+func (c *routingClient) Status() client.StatusWriter {
+	return &routingStatusWriter{c: c}
+}
+
+type routingStatusWriter struct {
+	c *routingClient
+}
+
+// Update implements client.StatusWriter.
+func (w *routingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return w.c.target(obj).Status().Update(ctx, obj, opts...)
+}
+
+// Patch implements client.StatusWriter.
+func (w *routingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.c.target(obj).Status().Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: https://workload:6443
+users:
+- name: admin
+  user:
+    token: abc
+contexts:
+- name: admin@workload
+  context:
+    cluster: workload
+    user: admin
+current-context: admin@workload
+`
+
+func TestClientFor(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+			UID:       "instance-uid",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			FederationRef: &v1alpha1.FederationRef{
+				ClusterName: "workload",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload-kubeconfig",
+			Namespace: "observability",
+		},
+		Data: map[string][]byte{"value": []byte(kubeconfig)},
+	}
+	local := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol.DeepCopy(), secret).Build()
+	remote := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	var servers []string
+	clients := NewClients(scheme)
+	clients.newClient = func(cfg *rest.Config, _ client.Options) (client.Client, error) {
+		servers = append(servers, cfg.Host)
+		return remote, nil
+	}
+
+	// test
+	cl, err := clients.ClientFor(context.Background(), local, otelcol)
+	require.NoError(t, err)
+	_, err = clients.ClientFor(context.Background(), local, otelcol)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []string{"https://workload:6443"}, servers, "the remote client should be reused")
+
+	t.Run("should create the collector resources in the remote cluster", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance-collector",
+				Namespace: "observability",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "opentelemetry.io/v1alpha1",
+					Kind:       "OpenTelemetryCollector",
+					Name:       "my-instance",
+					UID:        "instance-uid",
+				}},
+			},
+		}
+		require.NoError(t, cl.Create(context.Background(), deployment))
+
+		nsn := types.NamespacedName{Name: "my-instance-collector", Namespace: "observability"}
+		actual := &appsv1.Deployment{}
+		require.NoError(t, remote.Get(context.Background(), nsn, actual))
+		assert.Empty(t, actual.OwnerReferences)
+		assert.True(t, apierrors.IsNotFound(local.Get(context.Background(), nsn, &appsv1.Deployment{})))
+	})
+
+	t.Run("should keep the OpenTelemetry resources in the local cluster", func(t *testing.T) {
+		actual := &v1alpha1.OpenTelemetryCollector{}
+		assert.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(&otelcol), actual))
+	})
+}
+
+func TestClientForMissingKubeconfig(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	local := fake.NewClientBuilder().WithScheme(scheme).Build()
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			FederationRef: &v1alpha1.FederationRef{ClusterName: "workload"},
+		},
+	}
+
+	// test
+	_, err := NewClients(scheme).ClientFor(context.Background(), local, otelcol)
+
+	// verify
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Equal(t, types.NamespacedName{Name: "workload-kubeconfig", Namespace: "observability"}, KubeconfigSecret(otelcol))
+}
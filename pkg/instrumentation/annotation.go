@@ -15,9 +15,12 @@
 package instrumentation
 
 import (
+	"fmt"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
 const (
@@ -27,8 +30,59 @@ const (
 	annotationInjectNodeJS        = "instrumentation.opentelemetry.io/inject-nodejs"
 	annotationInjectPython        = "instrumentation.opentelemetry.io/inject-python"
 	annotationInjectDotNet        = "instrumentation.opentelemetry.io/inject-dotnet"
+	annotationInjectGo            = "instrumentation.opentelemetry.io/inject-go"
+	annotationInjectApacheHttpd   = "instrumentation.opentelemetry.io/inject-apache-httpd"
+	annotationInjectNginx         = "instrumentation.opentelemetry.io/inject-nginx"
 	annotationInjectSdk           = "instrumentation.opentelemetry.io/inject-sdk"
 	annotationInjectContainerName = "instrumentation.opentelemetry.io/container-names"
+
+	// annotationDefaultInject lists the instrumentations injected into the pods of the annotated namespace without
+	// inject annotation, e.g. "java,nodejs". The pods opt out of all of them with "false".
+	annotationDefaultInject = "instrumentation.opentelemetry.io/default-inject"
+	annotationInjectPrefix  = "instrumentation.opentelemetry.io/inject-"
+
+	// annotationGoExecPath is the path of the executable instrumented by the Go agent, in the container running it.
+	annotationGoExecPath = "instrumentation.opentelemetry.io/otel-go-auto-target-exe"
+
+	// annotationDotNetRuntime and annotationPythonPlatform select the libc the auto-instrumentation is built for,
+	// glibc by default.
+	annotationDotNetRuntime  = "instrumentation.opentelemetry.io/otel-dotnet-auto-runtime"
+	annotationPythonPlatform = "instrumentation.opentelemetry.io/otel-python-platform"
+
+	// annotationNginxConfigFile is the path of the main Nginx configuration file, in the instrumented container.
+	annotationNginxConfigFile = "instrumentation.opentelemetry.io/nginx-config-file"
+
+	// annotationSampler, annotationSamplerArg and annotationPropagators override the sampler and propagators of the
+	// Instrumentation for the annotated pod.
+	annotationSampler     = "instrumentation.opentelemetry.io/sampler"
+	annotationSamplerArg  = "instrumentation.opentelemetry.io/sampler-arg"
+	annotationPropagators = "instrumentation.opentelemetry.io/propagators"
+
+	// AnnotationInjectedGenerations holds the generations of the Instrumentations the pod is injected from.
+	AnnotationInjectedGenerations = "instrumentation.opentelemetry.io/injected-generations"
+)
+
+var (
+	samplerTypes = map[v1alpha1.SamplerType]bool{
+		v1alpha1.AlwaysOn:                true,
+		v1alpha1.AlwaysOff:               true,
+		v1alpha1.TraceIDRatio:            true,
+		v1alpha1.ParentBasedAlwaysOn:     true,
+		v1alpha1.ParentBasedAlwaysOff:    true,
+		v1alpha1.ParentBasedTraceIDRatio: true,
+		v1alpha1.JaegerRemote:            true,
+		v1alpha1.XRaySampler:             true,
+	}
+	propagators = map[v1alpha1.Propagator]bool{
+		v1alpha1.TraceContext: true,
+		v1alpha1.Baggage:      true,
+		v1alpha1.B3:           true,
+		v1alpha1.B3Multi:      true,
+		v1alpha1.Jaeger:       true,
+		v1alpha1.XRay:         true,
+		v1alpha1.OTTrace:      true,
+		v1alpha1.None:         true,
+	}
 )
 
 // annotationValue returns the effective annotationInjectJava value, based on the annotations from the pod and namespace.
@@ -40,6 +94,9 @@ func annotationValue(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation str
 
 	// if the namespace value is empty, the pod annotation should be used, whatever it is
 	if len(nsAnnValue) == 0 {
+		if len(podAnnValue) == 0 && injectedByDefault(ns, pod, annotation) {
+			return "true"
+		}
 		return podAnnValue
 	}
 
@@ -62,3 +119,65 @@ func annotationValue(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation str
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// injectedByDefault returns whether the default-inject annotation of the namespace selects the instrumentation of the
+// inject annotation, unless the pod opts out. The pods injecting some instrumentations with their own inject
+// annotations only get those, and the pods of the operator, like the collectors, aren't instrumented by default.
+func injectedByDefault(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation string) bool {
+	if !strings.HasPrefix(annotation, annotationInjectPrefix) || strings.EqualFold(pod.Annotations[annotationDefaultInject], "false") {
+		return false
+	}
+	if pod.Labels["app.kubernetes.io/managed-by"] == "opentelemetry-operator" {
+		return false
+	}
+	for k, v := range pod.Annotations {
+		if strings.HasPrefix(k, annotationInjectPrefix) && !strings.EqualFold(v, "false") {
+			return false
+		}
+	}
+	language := strings.TrimPrefix(annotation, annotationInjectPrefix)
+	for _, defaultLanguage := range strings.Split(ns.Annotations[annotationDefaultInject], ",") {
+		if strings.EqualFold(strings.TrimSpace(defaultLanguage), language) {
+			return true
+		}
+	}
+	return false
+}
+
+// withPodOverrides returns the instrumentation with the sampler and propagators overridden by the pod annotations.
+// The instrumentation is returned unchanged when an annotation holds an unknown value.
+func withPodOverrides(otelinst v1alpha1.Instrumentation, pod metav1.ObjectMeta) (v1alpha1.Instrumentation, error) {
+	overridden := *otelinst.DeepCopy()
+
+	if samplerType, ok := pod.Annotations[annotationSampler]; ok {
+		if !samplerTypes[v1alpha1.SamplerType(samplerType)] {
+			return otelinst, fmt.Errorf("unknown sampler %q in annotation %s", samplerType, annotationSampler)
+		}
+		if overridden.Spec.Sampler.Type != v1alpha1.SamplerType(samplerType) {
+			// the argument of the instrumentation's sampler doesn't apply to another sampler
+			overridden.Spec.Sampler.Argument = ""
+		}
+		overridden.Spec.Sampler.Type = v1alpha1.SamplerType(samplerType)
+	}
+	if argument, ok := pod.Annotations[annotationSamplerArg]; ok {
+		overridden.Spec.Sampler.Argument = argument
+	}
+
+	if value, ok := pod.Annotations[annotationPropagators]; ok {
+		overridden.Spec.Propagators = nil
+		for _, name := range strings.Split(value, ",") {
+			propagator := v1alpha1.Propagator(strings.TrimSpace(name))
+			if !propagators[propagator] {
+				return otelinst, fmt.Errorf("unknown propagator %q in annotation %s", propagator, annotationPropagators)
+			}
+			overridden.Spec.Propagators = append(overridden.Spec.Propagators, propagator)
+		}
+	}
+
+	return overridden, nil
+}
+
+// annotationLanguage returns the language of an inject annotation, e.g. java for the inject-java annotation.
+func annotationLanguage(annotation string) string {
+	return strings.TrimPrefix(annotation, annotationInjectPrefix)
+}
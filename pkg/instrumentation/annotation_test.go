@@ -20,6 +20,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
 func TestEffectiveAnnotationValue(t *testing.T) {
@@ -130,6 +132,127 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 			},
 			corev1.Namespace{},
 		},
+
+		{
+			"ns-default-inject",
+			"true",
+			corev1.Pod{},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "nodejs, java",
+					},
+				},
+			},
+		},
+
+		{
+			"ns-default-inject-other-language",
+			"",
+			corev1.Pod{},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "python",
+					},
+				},
+			},
+		},
+
+		{
+			"pod-opts-out-of-default-inject",
+			"",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "false",
+					},
+				},
+			},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "java",
+					},
+				},
+			},
+		},
+
+		{
+			"pod-opts-out-of-default-inject-language",
+			"false",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectJava: "false",
+					},
+				},
+			},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "java",
+					},
+				},
+			},
+		},
+
+		{
+			"pod-inject-annotation-wins-over-default-inject",
+			"",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectPython: "true",
+					},
+				},
+			},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "java",
+					},
+				},
+			},
+		},
+
+		{
+			"pod-opts-out-of-other-language-keeps-default-inject",
+			"true",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectPython: "false",
+					},
+				},
+			},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "java,python",
+					},
+				},
+			},
+		},
+
+		{
+			"operator-pod-not-default-injected",
+			"",
+			corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					},
+				},
+			},
+			corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationDefaultInject: "java",
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			// test
@@ -140,3 +263,77 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestWithPodOverrides(t *testing.T) {
+	otelinst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Propagators: []v1alpha1.Propagator{v1alpha1.TraceContext, v1alpha1.Baggage},
+			Sampler: v1alpha1.Sampler{
+				Type:     v1alpha1.ParentBasedTraceIDRatio,
+				Argument: "0.25",
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		expected    v1alpha1.InstrumentationSpec
+		err         string
+	}{
+		{
+			desc:     "no-overrides",
+			expected: otelinst.Spec,
+		},
+		{
+			desc: "sampler-arg-override",
+			annotations: map[string]string{
+				annotationSamplerArg: "0.5",
+			},
+			expected: v1alpha1.InstrumentationSpec{
+				Propagators: []v1alpha1.Propagator{v1alpha1.TraceContext, v1alpha1.Baggage},
+				Sampler:     v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.5"},
+			},
+		},
+		{
+			desc: "sampler-and-propagators-override",
+			annotations: map[string]string{
+				annotationSampler:     "always_on",
+				annotationPropagators: "b3multi, baggage",
+			},
+			expected: v1alpha1.InstrumentationSpec{
+				Propagators: []v1alpha1.Propagator{v1alpha1.B3Multi, v1alpha1.Baggage},
+				Sampler:     v1alpha1.Sampler{Type: v1alpha1.AlwaysOn},
+			},
+		},
+		{
+			desc: "unknown-propagator",
+			annotations: map[string]string{
+				annotationPropagators: "w3c",
+			},
+			expected: otelinst.Spec,
+			err:      `unknown propagator "w3c"`,
+		},
+		{
+			desc: "unknown-sampler",
+			annotations: map[string]string{
+				annotationSampler: "sometimes",
+			},
+			expected: otelinst.Spec,
+			err:      `unknown sampler "sometimes"`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			actual, err := withPodOverrides(otelinst, metav1.ObjectMeta{Annotations: tt.annotations})
+
+			// verify
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, actual.Spec)
+		})
+	}
+}
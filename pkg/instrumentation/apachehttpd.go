@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	apacheDefaultConfigDirectory = "/usr/local/apache2/conf"
+	apacheConfigFile             = "httpd.conf"
+)
+
+// injectApacheHttpdagent loads the otel-webserver-module into the Apache HTTPD server of the container, by including
+// the agent config at the end of httpd.conf.
+func injectApacheHttpdagent(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int, serviceName, serviceNamespace string) (corev1.Pod, error) {
+	apacheSpec := otelinst.Spec.ApacheHttpd
+	configDirectory := apacheDefaultConfigDirectory
+	if apacheSpec.ConfigPath != "" {
+		configDirectory = strings.TrimSuffix(apacheSpec.ConfigPath, "/")
+	}
+
+	return injectWebServerAgent(webServer{
+		name:            "Apache HTTPD",
+		key:             "apache-httpd",
+		image:           apacheSpec.Image,
+		env:             apacheSpec.Env,
+		resources:       apacheSpec.Resources,
+		configDirectory: configDirectory,
+		setupCommands: []string{
+			fmt.Sprintf("echo 'Include %s/%s' >> %s/%s", configDirectory, webServerAgentConfigFile, webServerAgentConfigDirectory, apacheConfigFile),
+		},
+		agentConfig: apacheAgentConfig(otelinst, serviceName, serviceNamespace),
+	}, pod, index)
+}
+
+// apacheAgentConfig renders the agent config loading the module, and its directives.
+func apacheAgentConfig(otelinst v1alpha1.Instrumentation, serviceName, serviceNamespace string) string {
+	// the module of Apache 2.2 has a version suffix
+	moduleSuffix := ""
+	if otelinst.Spec.ApacheHttpd.Version == "2.2" {
+		moduleSuffix = "22"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Load the otel-webserver-module SDK\n")
+	for _, lib := range []string{
+		"libopentelemetry_common.so",
+		"libopentelemetry_resources.so",
+		"libopentelemetry_trace.so",
+		"libopentelemetry_otlp_recordable.so",
+		"libopentelemetry_exporter_ostream_span.so",
+		"libopentelemetry_exporter_otlp_grpc.so",
+		"libopentelemetry_webserver_sdk.so",
+	} {
+		fmt.Fprintf(&sb, "LoadFile %s/sdk_lib/lib/%s\n", webServerAgentDirectory, lib)
+	}
+	fmt.Fprintf(&sb, "LoadModule otel_apache_module %s/WebServerModule/Apache/libmod_apache_otel%s.so\n", webServerAgentDirectory, moduleSuffix)
+
+	sb.WriteString("# Agent configuration\n")
+	for _, directive := range webServerAgentDirectives(otelinst, "ApacheModule", serviceName, serviceNamespace, otelinst.Spec.ApacheHttpd.Attrs) {
+		sb.WriteString(directive + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectApacheHttpdagent(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			ApacheHttpd: v1alpha1.ApacheHttpd{
+				Image: "foo/bar:1",
+				Env:   []corev1.EnvVar{{Name: "OTEL_APACHE_FOO", Value: "bar"}},
+			},
+		},
+	}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "httpd",
+					Image: "httpd:2.4",
+					Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/"}},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "httpd-config", MountPath: apacheDefaultConfigDirectory},
+						{Name: "html", MountPath: "/usr/local/apache2/htdocs"},
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	actual, err := injectApacheHttpdagent(inst, pod, 0, "my-service", "my-ns")
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, actual.Spec.InitContainers, 2)
+
+	clone := actual.Spec.InitContainers[0]
+	assert.Equal(t, "otel-apache-httpd-source-conf-clone", clone.Name)
+	assert.Equal(t, "httpd:2.4", clone.Image)
+	assert.Equal(t, []string{"cp -rL /usr/local/apache2/conf/* /opt/opentelemetry-webserver/source-conf"}, clone.Args)
+	assert.Nil(t, clone.ReadinessProbe)
+	assert.Empty(t, clone.Ports)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "httpd-config", MountPath: apacheDefaultConfigDirectory},
+		{Name: "html", MountPath: "/usr/local/apache2/htdocs"},
+		{Name: "otel-apache-httpd-conf-dir", MountPath: webServerAgentConfigDirectory},
+	}, clone.VolumeMounts)
+
+	agent := actual.Spec.InitContainers[1]
+	assert.Equal(t, "opentelemetry-auto-instrumentation-apache-httpd", agent.Name)
+	assert.Equal(t, "foo/bar:1", agent.Image)
+	assert.Contains(t, agent.Args[0], "echo 'Include /usr/local/apache2/conf/opentelemetry_agent.conf' >> /opt/opentelemetry-webserver/source-conf/httpd.conf")
+
+	container := actual.Spec.Containers[0]
+	assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_APACHE_FOO", Value: "bar"}}, container.Env)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "html", MountPath: "/usr/local/apache2/htdocs"},
+		{Name: "otel-apache-httpd-agent", MountPath: webServerAgentDirectory},
+		{Name: "otel-apache-httpd-conf-dir", MountPath: apacheDefaultConfigDirectory},
+	}, container.VolumeMounts)
+	assert.Len(t, actual.Spec.Volumes, 2)
+	assert.True(t, isAutoInstrumentationInjected(actual))
+
+	// the agent isn't injected twice
+	_, err = injectApacheHttpdagent(inst, actual, 0, "my-service", "my-ns")
+	assert.Equal(t, errors.New("the Apache HTTPD agent is already injected"), err)
+}
+
+func TestInjectApacheHttpdagentWindows(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			OS:         &corev1.PodOS{Name: corev1.Windows},
+			Containers: []corev1.Container{{Name: "httpd"}},
+		},
+	}
+
+	actual, err := injectApacheHttpdagent(v1alpha1.Instrumentation{}, pod, 0, "my-service", "my-ns")
+
+	assert.Equal(t, errors.New("the Apache HTTPD instrumentation isn't supported on Windows"), err)
+	assert.Equal(t, pod, actual)
+}
+
+func TestApacheAgentConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		inst     v1alpha1.Instrumentation
+		expected string
+	}{
+		{
+			name: "default",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+				},
+			},
+			expected: `# Load the otel-webserver-module SDK
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_common.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_resources.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_trace.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_otlp_recordable.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_exporter_ostream_span.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_exporter_otlp_grpc.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_webserver_sdk.so
+LoadModule otel_apache_module /opt/opentelemetry-webserver/agent/WebServerModule/Apache/libmod_apache_otel.so
+# Agent configuration
+ApacheModuleEnabled ON
+ApacheModuleOtelExporterEndpoint http://collector:4317
+ApacheModuleOtelSpanExporter otlp
+ApacheModuleResolveBackends ON
+ApacheModuleServiceInstanceId <<SID-PLACEHOLDER>>
+ApacheModuleServiceName my-service
+ApacheModuleServiceNamespace my-ns
+ApacheModuleTraceAsError ON
+`,
+		},
+		{
+			name: "apache 2.2, sampled out, overridden attributes",
+			inst: v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Sampler: v1alpha1.Sampler{Type: v1alpha1.ParentBasedAlwaysOff},
+					ApacheHttpd: v1alpha1.ApacheHttpd{
+						Version: "2.2",
+						Attrs: []corev1.EnvVar{
+							{Name: "ApacheModuleTraceAsError", Value: "OFF"},
+							{Name: "ApacheModuleOtelMaxQueueSize", Value: "4096"},
+						},
+					},
+				},
+			},
+			expected: `# Load the otel-webserver-module SDK
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_common.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_resources.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_trace.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_otlp_recordable.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_exporter_ostream_span.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_exporter_otlp_grpc.so
+LoadFile /opt/opentelemetry-webserver/agent/sdk_lib/lib/libopentelemetry_webserver_sdk.so
+LoadModule otel_apache_module /opt/opentelemetry-webserver/agent/WebServerModule/Apache/libmod_apache_otel22.so
+# Agent configuration
+ApacheModuleEnabled OFF
+ApacheModuleOtelMaxQueueSize 4096
+ApacheModuleOtelSpanExporter otlp
+ApacheModuleResolveBackends ON
+ApacheModuleServiceInstanceId <<SID-PLACEHOLDER>>
+ApacheModuleServiceName my-service
+ApacheModuleServiceNamespace my-ns
+ApacheModuleTraceAsError OFF
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, apacheAgentConfig(test.inst, "my-service", "my-ns"))
+		})
+	}
+}
@@ -38,6 +38,40 @@ const (
 	dotNetOTelAutoHomePath              = "/otel-auto-instrumentation"
 	dotNetSharedStorePath               = "/otel-auto-instrumentation/store"
 	dotNetStartupHookPath               = "/otel-auto-instrumentation/net/OpenTelemetry.AutoInstrumentation.StartupHook.dll"
+	dotNetCoreClrProfilerPathWin        = `C:\otel-auto-instrumentation\win-x64\OpenTelemetry.AutoInstrumentation.Native.dll`
+	dotNetAdditionalDepsPathWin         = `C:\otel-auto-instrumentation\AdditionalDeps`
+	dotNetSharedStorePathWin            = `C:\otel-auto-instrumentation\store`
+	dotNetStartupHookPathWin            = `C:\otel-auto-instrumentation\net\OpenTelemetry.AutoInstrumentation.StartupHook.dll`
+)
+
+// dotNetPaths are the paths of the auto-instrumentation files in the container, and the separator of the env vars
+// taking multiple paths.
+type dotNetPaths struct {
+	coreClrProfiler string
+	additionalDeps  string
+	otelAutoHome    string
+	sharedStore     string
+	startupHook     string
+	separator       string
+}
+
+var (
+	dotNetLinuxPaths = dotNetPaths{
+		coreClrProfiler: dotNetCoreClrProfilerPath,
+		additionalDeps:  dotNetAdditionalDepsPath,
+		otelAutoHome:    dotNetOTelAutoHomePath,
+		sharedStore:     dotNetSharedStorePath,
+		startupHook:     dotNetStartupHookPath,
+		separator:       ":",
+	}
+	dotNetWindowsPaths = dotNetPaths{
+		coreClrProfiler: dotNetCoreClrProfilerPathWin,
+		additionalDeps:  dotNetAdditionalDepsPathWin,
+		otelAutoHome:    windowsMountPath,
+		sharedStore:     dotNetSharedStorePathWin,
+		startupHook:     dotNetStartupHookPathWin,
+		separator:       ";",
+	}
 )
 
 func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int) (corev1.Pod, error) {
@@ -62,6 +96,19 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int) (cor
 		return pod, errors.New("OTEL_DOTNET_AUTO_HOME environment variable is already set in the .NET instrumentation spec")
 	}
 
+	windows := IsWindowsPod(pod)
+	if windows && dotNetSpec.WindowsImage == "" {
+		return pod, errors.New("the pod runs on Windows and the .NET instrumentation has no Windows image")
+	}
+	paths, image := dotNetLinuxPaths, dotNetSpec.Image
+	command := []string{"cp", "-a", "/autoinstrumentation/.", "/otel-auto-instrumentation/"}
+	if windows {
+		paths, image = dotNetWindowsPaths, dotNetSpec.WindowsImage
+		command = []string{"CMD", "/c", "xcopy", "/e", "/y", `C:\autoinstrumentation\*`, `C:\otel-auto-instrumentation\`}
+	} else if image, err = dotNetRuntimes.image(pod, dotNetSpec.Image, dotNetSpec.MuslImage); err != nil {
+		return pod, err
+	}
+
 	// inject .NET instrumentation spec env vars.
 	for _, env := range dotNetSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -75,23 +122,23 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int) (cor
 		concatEnvValues      = true
 	)
 
-	setDotNetEnvVar(container, envDotNetCoreClrEnableProfiling, dotNetCoreClrEnableProfilingEnabled, doNotConcatEnvValues)
+	setDotNetEnvVar(container, envDotNetCoreClrEnableProfiling, dotNetCoreClrEnableProfilingEnabled, doNotConcatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetCoreClrProfiler, dotNetCoreClrProfilerID, doNotConcatEnvValues)
+	setDotNetEnvVar(container, envDotNetCoreClrProfiler, dotNetCoreClrProfilerID, doNotConcatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetCoreClrProfilerPath, dotNetCoreClrProfilerPath, doNotConcatEnvValues)
+	setDotNetEnvVar(container, envDotNetCoreClrProfilerPath, paths.coreClrProfiler, doNotConcatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetStartupHook, dotNetStartupHookPath, concatEnvValues)
+	setDotNetEnvVar(container, envDotNetStartupHook, paths.startupHook, concatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetAdditionalDeps, dotNetAdditionalDepsPath, concatEnvValues)
+	setDotNetEnvVar(container, envDotNetAdditionalDeps, paths.additionalDeps, concatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetOTelAutoHome, dotNetOTelAutoHomePath, doNotConcatEnvValues)
+	setDotNetEnvVar(container, envDotNetOTelAutoHome, paths.otelAutoHome, doNotConcatEnvValues, paths.separator)
 
-	setDotNetEnvVar(container, envDotNetSharedStore, dotNetSharedStorePath, concatEnvValues)
+	setDotNetEnvVar(container, envDotNetSharedStore, paths.sharedStore, concatEnvValues, paths.separator)
 
 	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 		Name:      volumeName,
-		MountPath: "/otel-auto-instrumentation",
+		MountPath: paths.otelAutoHome,
 	})
 
 	// We just inject Volumes and init containers for the first processed container.
@@ -104,11 +151,11 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int) (cor
 
 		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
 			Name:    initContainerName,
-			Image:   dotNetSpec.Image,
-			Command: []string{"cp", "-a", "/autoinstrumentation/.", "/otel-auto-instrumentation/"},
+			Image:   image,
+			Command: command,
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      volumeName,
-				MountPath: "/otel-auto-instrumentation",
+				MountPath: paths.otelAutoHome,
 			}},
 		})
 	}
@@ -116,9 +163,9 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int) (cor
 }
 
 // setDotNetEnvVar function sets env var to the container if not exist already.
-// value of concatValues should be set to true if the env var supports multiple values separated by :.
+// value of concatValues should be set to true if the env var supports multiple values separated by the separator.
 // If it is set to false, the original container's env var value has priority.
-func setDotNetEnvVar(container *corev1.Container, envVarName string, envVarValue string, concatValues bool) {
+func setDotNetEnvVar(container *corev1.Container, envVarName string, envVarValue string, concatValues bool, separator string) {
 	idx := getIndexOfEnv(container.Env, envVarName)
 	if idx < 0 {
 		container.Env = append(container.Env, corev1.EnvVar{
@@ -128,6 +175,6 @@ func setDotNetEnvVar(container *corev1.Container, envVarName string, envVarValue
 		return
 	}
 	if concatValues {
-		container.Env[idx].Value = fmt.Sprintf("%s:%s", container.Env[idx].Value, envVarValue)
+		container.Env[idx].Value = fmt.Sprintf("%s%s%s", container.Env[idx].Value, separator, envVarValue)
 	}
 }
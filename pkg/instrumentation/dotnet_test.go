@@ -360,6 +360,111 @@ func TestInjectDotNetSDK(t *testing.T) {
 			},
 			err: fmt.Errorf("OTEL_DOTNET_AUTO_HOME environment variable is already set in the .NET instrumentation spec"),
 		},
+		{
+			name:   "Windows pod",
+			DotNet: v1alpha1.DotNet{Image: "foo/bar:1", WindowsImage: "foo/bar:1-windows"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{
+									Name:  envDotNetStartupHook,
+									Value: `C:\hooks\MyHook.dll`,
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+					Volumes: []corev1.Volume{
+						{
+							Name: volumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    initContainerName,
+							Image:   "foo/bar:1-windows",
+							Command: []string{"CMD", "/c", "xcopy", "/e", "/y", `C:\autoinstrumentation\*`, `C:\otel-auto-instrumentation\`},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      volumeName,
+								MountPath: `C:\otel-auto-instrumentation`,
+							}},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      volumeName,
+									MountPath: `C:\otel-auto-instrumentation`,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  envDotNetStartupHook,
+									Value: `C:\hooks\MyHook.dll;` + dotNetStartupHookPathWin,
+								},
+								{
+									Name:  envDotNetCoreClrEnableProfiling,
+									Value: dotNetCoreClrEnableProfilingEnabled,
+								},
+								{
+									Name:  envDotNetCoreClrProfiler,
+									Value: dotNetCoreClrProfilerID,
+								},
+								{
+									Name:  envDotNetCoreClrProfilerPath,
+									Value: dotNetCoreClrProfilerPathWin,
+								},
+								{
+									Name:  envDotNetAdditionalDeps,
+									Value: dotNetAdditionalDepsPathWin,
+								},
+								{
+									Name:  envDotNetOTelAutoHome,
+									Value: `C:\otel-auto-instrumentation`,
+								},
+								{
+									Name:  envDotNetSharedStore,
+									Value: dotNetSharedStorePathWin,
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "Windows pod without Windows image",
+			DotNet: v1alpha1.DotNet{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					OS: &corev1.PodOS{Name: corev1.Windows},
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					OS: &corev1.PodOS{Name: corev1.Windows},
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			err: fmt.Errorf("the pod runs on Windows and the .NET instrumentation has no Windows image"),
+		},
 	}
 
 	for _, test := range tests {
@@ -370,3 +475,74 @@ func TestInjectDotNetSDK(t *testing.T) {
 		})
 	}
 }
+
+func TestInjectDotNetSDKMusl(t *testing.T) {
+	dotNetSpec := v1alpha1.DotNet{Image: "foo/bar:1", MuslImage: "foo/bar:1-musl", WindowsImage: "foo/bar:1-windows"}
+	tests := []struct {
+		name          string
+		runtime       string
+		windows       bool
+		dotNetSpec    v1alpha1.DotNet
+		expectedImage string
+		err           error
+	}{
+		{
+			name:          "default runtime",
+			dotNetSpec:    dotNetSpec,
+			expectedImage: "foo/bar:1",
+		},
+		{
+			name:          "linux-x64",
+			runtime:       "linux-x64",
+			dotNetSpec:    dotNetSpec,
+			expectedImage: "foo/bar:1",
+		},
+		{
+			name:          "linux-musl-x64",
+			runtime:       "linux-musl-x64",
+			dotNetSpec:    dotNetSpec,
+			expectedImage: "foo/bar:1-musl",
+		},
+		{
+			name:          "Windows pod ignores the runtime",
+			runtime:       "linux-musl-x64",
+			windows:       true,
+			dotNetSpec:    dotNetSpec,
+			expectedImage: "foo/bar:1-windows",
+		},
+		{
+			name:       "linux-musl-x64 without musl image",
+			runtime:    "linux-musl-x64",
+			dotNetSpec: v1alpha1.DotNet{Image: "foo/bar:1"},
+			err:        fmt.Errorf("the pod selects linux-musl-x64 with the instrumentation.opentelemetry.io/otel-dotnet-auto-runtime annotation and the instrumentation has no musl image"),
+		},
+		{
+			name:       "unknown runtime",
+			runtime:    "linux-arm64",
+			dotNetSpec: dotNetSpec,
+			err:        fmt.Errorf("unknown value \"linux-arm64\" in annotation instrumentation.opentelemetry.io/otel-dotnet-auto-runtime, should be linux-x64 or linux-musl-x64"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{}},
+				},
+			}
+			if test.runtime != "" {
+				pod.Annotations = map[string]string{annotationDotNetRuntime: test.runtime}
+			}
+			if test.windows {
+				pod.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+			}
+
+			pod, err := injectDotNetSDK(test.dotNetSpec, pod, 0)
+
+			assert.Equal(t, test.err, err)
+			if test.err == nil {
+				assert.Equal(t, test.expectedImage, pod.Spec.InitContainers[0].Image)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// exporterTLSMountPath is the directory the Secret with the certificates of the exporter is mounted at.
+const exporterTLSMountPath = "/otel-auto-instrumentation-exporter-tls"
+
+// injectExporterTLS mounts the Secret with the certificates of the exporter into the container at index and points
+// the exporter at them. The env vars already set on the container take precedence.
+func injectExporterTLS(tls *v1alpha1.TLS, pod corev1.Pod, index int) corev1.Pod {
+	if tls == nil || tls.SecretName == "" {
+		return pod
+	}
+
+	volume := naming.DNSName(naming.Truncate("otel-exporter-tls-%s", 63, tls.SecretName))
+	if !hasVolume(pod, volume) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: volume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tls.SecretName,
+				},
+			},
+		})
+	}
+
+	container := &pod.Spec.Containers[index]
+	mounted := false
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volume {
+			mounted = true
+			break
+		}
+	}
+	if !mounted {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volume,
+			MountPath: exporterTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	for _, env := range []struct {
+		name, key string
+	}{
+		{name: constants.EnvOTELExporterCertificate, key: tls.CA},
+		{name: constants.EnvOTELExporterClientCert, key: tls.Cert},
+		{name: constants.EnvOTELExporterClientKey, key: tls.Key},
+	} {
+		if env.key != "" && getIndexOfEnv(container.Env, env.name) == -1 {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  env.name,
+				Value: path.Join(exporterTLSMountPath, env.key),
+			})
+		}
+	}
+	return pod
+}
+
+func hasVolume(pod corev1.Pod, name string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
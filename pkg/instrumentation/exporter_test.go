@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectExporterTLS(t *testing.T) {
+	tests := []struct {
+		name     string
+		tls      *v1alpha1.TLS
+		pod      corev1.Pod
+		expected corev1.Pod
+	}{
+		{
+			name: "no TLS",
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+		},
+		{
+			name: "mTLS",
+			tls:  &v1alpha1.TLS{SecretName: "otel-certs", CA: "ca.crt", Cert: "tls.crt", Key: "tls.key"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_EXPORTER_OTLP_CERTIFICATE",
+									Value: "/etc/ssl/ca.crt",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-exporter-tls-otel-certs",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "otel-certs",
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "otel-exporter-tls-otel-certs",
+									MountPath: "/otel-auto-instrumentation-exporter-tls",
+									ReadOnly:  true,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_EXPORTER_OTLP_CERTIFICATE",
+									Value: "/etc/ssl/ca.crt",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE",
+									Value: "/otel-auto-instrumentation-exporter-tls/tls.crt",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_CLIENT_KEY",
+									Value: "/otel-auto-instrumentation-exporter-tls/tls.key",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "secret name with dots",
+			tls:  &v1alpha1.TLS{SecretName: "otel.example.com"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-exporter-tls-otel-example-com",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "otel.example.com",
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "otel-exporter-tls-otel-example-com",
+									MountPath: "/otel-auto-instrumentation-exporter-tls",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "already mounted",
+			tls:  &v1alpha1.TLS{SecretName: "otel-certs", CA: "ca.crt"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-exporter-tls-otel-certs",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "otel-certs",
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "otel-exporter-tls-otel-certs",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "otel-certs",
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "otel-exporter-tls-otel-certs",
+									MountPath: "/otel-auto-instrumentation-exporter-tls",
+									ReadOnly:  true,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_EXPORTER_OTLP_CERTIFICATE",
+									Value: "/otel-auto-instrumentation-exporter-tls/ca.crt",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := injectExporterTLS(test.tls, test.pod, 0)
+			assert.Equal(t, test.expected, pod)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
+)
+
+var errNoFIPSImage = errors.New("the operator runs in FIPS mode and has no FIPS-validated image for the language")
+
+// withFIPSImages substitutes the FIPS-validated images of the operator to the images of the instrumentations when it
+// runs in FIPS mode. The languages without such an image aren't injected, their agents can't be assumed compliant.
+func withFIPSImages(cfg config.Config, logger logr.Logger, insts languageInstrumentations) languageInstrumentations {
+	if !cfg.FIPS() {
+		return insts
+	}
+
+	insts.Java = withFIPSImage(cfg, logger, insts.Java, config.FIPSImageJava, annotationInjectJava, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.Java.Image = image
+	})
+	insts.NodeJS = withFIPSImage(cfg, logger, insts.NodeJS, config.FIPSImageNodeJS, annotationInjectNodeJS, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.NodeJS.Image = image
+	})
+	// the musl images aren't substituted, so the pods selecting them aren't injected
+	insts.Python = withFIPSImage(cfg, logger, insts.Python, config.FIPSImagePython, annotationInjectPython, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.Python.Image, spec.Python.MuslImage = image, ""
+	})
+	insts.DotNet = withFIPSImage(cfg, logger, insts.DotNet, config.FIPSImageDotNet, annotationInjectDotNet, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.DotNet.Image, spec.DotNet.MuslImage = image, ""
+	})
+	insts.Go = withFIPSImage(cfg, logger, insts.Go, config.FIPSImageGo, annotationInjectGo, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.Go.Image = image
+	})
+	insts.ApacheHttpd = withFIPSImage(cfg, logger, insts.ApacheHttpd, config.FIPSImageApacheHttpd, annotationInjectApacheHttpd, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.ApacheHttpd.Image = image
+	})
+	insts.Nginx = withFIPSImage(cfg, logger, insts.Nginx, config.FIPSImageNginx, annotationInjectNginx, func(spec *v1alpha1.InstrumentationSpec, image string) {
+		spec.Nginx.Image = image
+	})
+	return insts
+}
+
+func withFIPSImage(cfg config.Config, logger logr.Logger, inst *v1alpha1.Instrumentation, component, annotation string,
+	setImage func(spec *v1alpha1.InstrumentationSpec, image string)) *v1alpha1.Instrumentation {
+	if inst == nil {
+		return nil
+	}
+	image, ok := cfg.FIPSImage(component)
+	if !ok {
+		logger.Info("Skipping the instrumentation injection", "reason", errNoFIPSImage.Error(), "language", annotationLanguage(annotation))
+		metrics.RecordInjection(annotationLanguage(annotation), errNoFIPSImage)
+		return nil
+	}
+	// the instrumentation can be shared by several languages
+	fips := inst.DeepCopy()
+	setImage(&fips.Spec, image)
+	return fips
+}
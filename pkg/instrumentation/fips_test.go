@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestWithFIPSImages(t *testing.T) {
+	// prepare
+	inst := &v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Java:   v1alpha1.Java{Image: "java"},
+			NodeJS: v1alpha1.NodeJS{Image: "nodejs"},
+			Python: v1alpha1.Python{Image: "python", MuslImage: "python-musl"},
+		},
+	}
+	insts := languageInstrumentations{Java: inst, NodeJS: inst, Python: inst}
+	images := map[string]string{
+		config.FIPSImageJava:   "java-fips",
+		config.FIPSImagePython: "python-fips",
+	}
+
+	t.Run("should keep the images without the FIPS mode", func(t *testing.T) {
+		// test
+		actual := withFIPSImages(config.New(config.WithFIPSImages(images)), logf.Log, insts)
+
+		// verify
+		assert.Equal(t, insts, actual)
+	})
+
+	t.Run("should substitute the FIPS-validated images", func(t *testing.T) {
+		// test
+		actual := withFIPSImages(config.New(config.WithFIPS(true), config.WithFIPSImages(images)), logf.Log, insts)
+
+		// verify
+		require.NotNil(t, actual.Java)
+		require.NotNil(t, actual.Python)
+		assert.Equal(t, "java-fips", actual.Java.Spec.Java.Image)
+		assert.Equal(t, "python-fips", actual.Python.Spec.Python.Image)
+		assert.Empty(t, actual.Python.Spec.Python.MuslImage)
+		// the language without a FIPS-validated image isn't injected
+		assert.Nil(t, actual.NodeJS)
+		// the shared instrumentation is left unchanged
+		assert.Equal(t, "java", inst.Spec.Java.Image)
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	envOtelTargetExe      = "OTEL_GO_AUTO_TARGET_EXE"
+	kernelDebugVolumeName = "kernel-debug"
+	kernelDebugVolumePath = "/sys/kernel/debug"
+	goAgentContainerName  = "opentelemetry-auto-instrumentation"
+)
+
+// injectGoSDK adds the Go eBPF agent as a sidecar sharing the process namespace of the pod, which instruments the
+// executable set by the otel-go-auto-target-exe annotation. The agent needs to be privileged to load its eBPF
+// programs, and reads the kernel debug filesystem of the node.
+func injectGoSDK(goSpec v1alpha1.Go, pod corev1.Pod) (corev1.Pod, error) {
+	if pod.Spec.ShareProcessNamespace != nil && !*pod.Spec.ShareProcessNamespace {
+		return pod, errors.New("the pod explicitly disables the shared process namespace")
+	}
+	if IsWindowsPod(pod) {
+		return pod, errors.New("the Go instrumentation isn't supported on Windows")
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == goAgentContainerName {
+			return pod, errors.New("the Go agent is already injected")
+		}
+	}
+
+	privileged := true
+	zero := int64(0)
+	agent := corev1.Container{
+		Name:      goAgentContainerName,
+		Image:     goSpec.Image,
+		Resources: goSpec.Resources,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  &zero,
+			Privileged: &privileged,
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      kernelDebugVolumeName,
+			MountPath: kernelDebugVolumePath,
+		}},
+	}
+
+	// the annotation takes precedence over the env var of the Go instrumentation spec
+	if execPath, ok := pod.Annotations[annotationGoExecPath]; ok && execPath != "" {
+		agent.Env = append(agent.Env, corev1.EnvVar{
+			Name:  envOtelTargetExe,
+			Value: execPath,
+		})
+	}
+	for _, env := range goSpec.Env {
+		idx := getIndexOfEnv(agent.Env, env.Name)
+		if idx == -1 {
+			agent.Env = append(agent.Env, env)
+		}
+	}
+	if getIndexOfEnv(agent.Env, envOtelTargetExe) == -1 {
+		return pod, errors.New("the executable to instrument isn't set, the pod needs the instrumentation.opentelemetry.io/otel-go-auto-target-exe annotation")
+	}
+
+	pod.Spec.ShareProcessNamespace = &privileged
+	pod.Spec.Containers = append(pod.Spec.Containers, agent)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: kernelDebugVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: kernelDebugVolumePath,
+			},
+		},
+	})
+	return pod, nil
+}
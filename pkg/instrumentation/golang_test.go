@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectGoSDK(t *testing.T) {
+	falsee := false
+	truee := true
+	zero := int64(0)
+
+	tests := []struct {
+		name string
+		v1alpha1.Go
+		pod      corev1.Pod
+		expected corev1.Pod
+		err      error
+	}{
+		{
+			name: "shared process namespace disabled",
+			Go:   v1alpha1.Go{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					ShareProcessNamespace: &falsee,
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					ShareProcessNamespace: &falsee,
+				},
+			},
+			err: errors.New("the pod explicitly disables the shared process namespace"),
+		},
+		{
+			name: "target executable not set",
+			Go:   v1alpha1.Go{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			err: errors.New("the executable to instrument isn't set, the pod needs the instrumentation.opentelemetry.io/otel-go-auto-target-exe annotation"),
+		},
+		{
+			name: "target executable set by the annotation",
+			Go: v1alpha1.Go{
+				Image: "foo/bar:1",
+				Env: []corev1.EnvVar{
+					{Name: envOtelTargetExe, Value: "/app/other"},
+					{Name: "OTEL_GO_AUTO_SHOW_VERIFIER_LOG", Value: "true"},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationGoExecPath: "/app/server"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationGoExecPath: "/app/server"},
+				},
+				Spec: corev1.PodSpec{
+					ShareProcessNamespace: &truee,
+					Containers: []corev1.Container{
+						{Name: "app"},
+						{
+							Name:  goAgentContainerName,
+							Image: "foo/bar:1",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser:  &zero,
+								Privileged: &truee,
+							},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      kernelDebugVolumeName,
+								MountPath: kernelDebugVolumePath,
+							}},
+							Env: []corev1.EnvVar{
+								{Name: envOtelTargetExe, Value: "/app/server"},
+								{Name: "OTEL_GO_AUTO_SHOW_VERIFIER_LOG", Value: "true"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{{
+						Name: kernelDebugVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							HostPath: &corev1.HostPathVolumeSource{
+								Path: kernelDebugVolumePath,
+							},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "windows pod",
+			Go:   v1alpha1.Go{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationGoExecPath: "/app/server"},
+				},
+				Spec: corev1.PodSpec{
+					OS:         &corev1.PodOS{Name: corev1.Windows},
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotationGoExecPath: "/app/server"},
+				},
+				Spec: corev1.PodSpec{
+					OS:         &corev1.PodOS{Name: corev1.Windows},
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			err: errors.New("the Go instrumentation isn't supported on Windows"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod, err := injectGoSDK(test.Go, test.pod)
+			assert.Equal(t, test.expected, pod)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -14,7 +14,42 @@
 
 package instrumentation
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// windowsMountPath is the directory the auto-instrumentation is copied to in the containers running on Windows.
+const windowsMountPath = `C:\otel-auto-instrumentation`
+
+// libcVariants are the values of the annotation selecting the auto-instrumentation built for glibc or musl.
+type libcVariants struct {
+	annotation string
+	glibc      string
+	musl       string
+}
+
+var (
+	dotNetRuntimes  = libcVariants{annotation: annotationDotNetRuntime, glibc: "linux-x64", musl: "linux-musl-x64"}
+	pythonPlatforms = libcVariants{annotation: annotationPythonPlatform, glibc: "glibc", musl: "musl"}
+)
+
+// image returns the image built for the libc selected by the annotation of the pod.
+func (v libcVariants) image(pod corev1.Pod, image, muslImage string) (string, error) {
+	switch value := pod.Annotations[v.annotation]; value {
+	case "", v.glibc:
+		return image, nil
+	case v.musl:
+		if muslImage == "" {
+			return "", fmt.Errorf("the pod selects %s with the %s annotation and the instrumentation has no musl image", value, v.annotation)
+		}
+		return muslImage, nil
+	default:
+		return "", fmt.Errorf("unknown value %q in annotation %s, should be %s or %s", value, v.annotation, v.glibc, v.musl)
+	}
+}
 
 // Calculate if we already inject InitContainers.
 func isInitContainerMissing(pod corev1.Pod) bool {
@@ -26,12 +61,84 @@ func isInitContainerMissing(pod corev1.Pod) bool {
 	return true
 }
 
-// Checks if Pod is already instrumented by checking Instrumentation InitContainer presence.
+// Checks if Pod is already instrumented by checking Instrumentation InitContainer presence, or the Go agent sidecar.
+// The init containers of the web server agents are suffixed by their server.
 func isAutoInstrumentationInjected(pod corev1.Pod) bool {
 	for _, cont := range pod.Spec.InitContainers {
-		if cont.Name == initContainerName {
+		if cont.Name == initContainerName || strings.HasPrefix(cont.Name, initContainerName+"-") {
+			return true
+		}
+	}
+	for _, cont := range pod.Spec.Containers {
+		if cont.Name == goAgentContainerName {
 			return true
 		}
 	}
 	return false
 }
+
+// IsWindowsPod returns whether the pod runs on Windows nodes, based on its OS or its node selector.
+func IsWindowsPod(pod corev1.Pod) bool {
+	if pod.Spec.OS != nil {
+		return pod.Spec.OS.Name == corev1.Windows
+	}
+	return pod.Spec.NodeSelector[corev1.LabelOSStable] == string(corev1.Windows)
+}
+
+// allContainers is the value of the container-names annotation selecting all the containers of the pod.
+const allContainers = "*"
+
+// containersToInstrument returns the names of the containers selected by the value of the container-names annotation,
+// and the selected names the pod has no container for. The first container is selected when the value is empty.
+func containersToInstrument(pod corev1.Pod, containerNames string) ([]string, []string) {
+	if len(pod.Spec.Containers) == 0 {
+		return nil, nil
+	}
+	containerNames = strings.TrimSpace(containerNames)
+	if containerNames == "" {
+		return []string{pod.Spec.Containers[0].Name}, nil
+	}
+
+	exists := map[string]bool{}
+	var all []string
+	for _, container := range pod.Spec.Containers {
+		exists[container.Name] = true
+		all = append(all, container.Name)
+	}
+	if containerNames == allContainers {
+		return all, nil
+	}
+
+	var selected, unknown []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(containerNames, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if exists[name] {
+			selected = append(selected, name)
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	return selected, unknown
+}
+
+// addImagePullSecrets adds the image pull secrets of the Instrumentation the pod doesn't reference yet.
+func addImagePullSecrets(pod corev1.Pod, secrets []corev1.LocalObjectReference) corev1.Pod {
+	for _, secret := range secrets {
+		found := false
+		for _, existing := range pod.Spec.ImagePullSecrets {
+			if existing.Name == secret.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, secret)
+		}
+	}
+	return pod
+}
@@ -124,3 +124,106 @@ func TestAutoInstrumentationInjected(t *testing.T) {
 		})
 	}
 }
+
+func TestIsWindowsPod(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected bool
+	}{
+		{
+			name:     "Linux_By_Default",
+			spec:     corev1.PodSpec{},
+			expected: false,
+		},
+		{
+			name:     "Windows_OS",
+			spec:     corev1.PodSpec{OS: &corev1.PodOS{Name: corev1.Windows}},
+			expected: true,
+		},
+		{
+			name:     "Windows_Node_Selector",
+			spec:     corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "windows"}},
+			expected: true,
+		},
+		{
+			name: "OS_Takes_Precedence",
+			spec: corev1.PodSpec{
+				OS:           &corev1.PodOS{Name: corev1.Linux},
+				NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsWindowsPod(corev1.Pod{Spec: test.spec}))
+		})
+	}
+}
+
+func TestContainersToInstrument(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}, {Name: "istio-proxy"}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		pod             corev1.Pod
+		containerNames  string
+		expected        []string
+		expectedUnknown []string
+	}{
+		{
+			name:     "first container by default",
+			pod:      pod,
+			expected: []string{"app"},
+		},
+		{
+			name:           "named containers",
+			pod:            pod,
+			containerNames: "worker, app",
+			expected:       []string{"worker", "app"},
+		},
+		{
+			name:           "all containers",
+			pod:            pod,
+			containerNames: "*",
+			expected:       []string{"app", "worker", "istio-proxy"},
+		},
+		{
+			name:            "unknown and duplicated containers",
+			pod:             pod,
+			containerNames:  "app,consumer,app,",
+			expected:        []string{"app"},
+			expectedUnknown: []string{"consumer"},
+		},
+		{
+			name:           "no containers",
+			containerNames: "*",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, unknown := containersToInstrument(test.pod, test.containerNames)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedUnknown, unknown)
+		})
+	}
+}
+
+func TestAddImagePullSecrets(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "app-registry"}},
+		},
+	}
+
+	pod = addImagePullSecrets(pod, []corev1.LocalObjectReference{{Name: "otel-registry"}, {Name: "app-registry"}})
+
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "app-registry"}, {Name: "otel-registry"}}, pod.Spec.ImagePullSecrets)
+}
@@ -15,6 +15,8 @@
 package instrumentation
 
 import (
+	"errors"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -23,6 +25,7 @@ import (
 const (
 	envJavaToolsOptions = "JAVA_TOOL_OPTIONS"
 	javaJVMArgument     = " -javaagent:/otel-auto-instrumentation/javaagent.jar"
+	javaJVMArgumentWin  = ` -javaagent:C:\otel-auto-instrumentation\javaagent.jar`
 )
 
 func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.Pod, error) {
@@ -34,6 +37,17 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 		return pod, err
 	}
 
+	windows := IsWindowsPod(pod)
+	if windows && javaSpec.WindowsImage == "" {
+		return pod, errors.New("the pod runs on Windows and the Java instrumentation has no Windows image")
+	}
+	jvmArgument, mountPath := javaJVMArgument, "/otel-auto-instrumentation"
+	image, command := javaSpec.Image, []string{"cp", "/javaagent.jar", "/otel-auto-instrumentation/javaagent.jar"}
+	if windows {
+		jvmArgument, mountPath = javaJVMArgumentWin, windowsMountPath
+		image, command = javaSpec.WindowsImage, []string{"CMD", "/c", "copy", `C:\javaagent.jar`, `C:\otel-auto-instrumentation\javaagent.jar`}
+	}
+
 	// inject Java instrumentation spec env vars.
 	for _, env := range javaSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -46,15 +60,15 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 	if idx == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  envJavaToolsOptions,
-			Value: javaJVMArgument,
+			Value: jvmArgument,
 		})
 	} else {
-		container.Env[idx].Value = container.Env[idx].Value + javaJVMArgument
+		container.Env[idx].Value = container.Env[idx].Value + jvmArgument
 	}
 
 	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 		Name:      volumeName,
-		MountPath: "/otel-auto-instrumentation",
+		MountPath: mountPath,
 	})
 
 	// We just inject Volumes and init containers for the first processed container.
@@ -67,11 +81,11 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int) (corev1.
 
 		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
 			Name:    initContainerName,
-			Image:   javaSpec.Image,
-			Command: []string{"cp", "/javaagent.jar", "/otel-auto-instrumentation/javaagent.jar"},
+			Image:   image,
+			Command: command,
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      volumeName,
-				MountPath: "/otel-auto-instrumentation",
+				MountPath: mountPath,
 			}},
 		})
 	}
@@ -174,6 +174,80 @@ func TestInjectJavaagent(t *testing.T) {
 			},
 			err: fmt.Errorf("the container defines env var value via ValueFrom, envVar: %s", envJavaToolsOptions),
 		},
+		{
+			name: "Windows pod",
+			Java: v1alpha1.Java{Image: "foo/bar:1", WindowsImage: "foo/bar:1-windows"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					OS: &corev1.PodOS{Name: corev1.Windows},
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					OS: &corev1.PodOS{Name: corev1.Windows},
+					Volumes: []corev1.Volume{
+						{
+							Name: volumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    initContainerName,
+							Image:   "foo/bar:1-windows",
+							Command: []string{"CMD", "/c", "copy", `C:\javaagent.jar`, `C:\otel-auto-instrumentation\javaagent.jar`},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      volumeName,
+								MountPath: `C:\otel-auto-instrumentation`,
+							}},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      volumeName,
+									MountPath: `C:\otel-auto-instrumentation`,
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "JAVA_TOOL_OPTIONS",
+									Value: javaJVMArgumentWin,
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "Windows pod without Windows image",
+			Java: v1alpha1.Java{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "windows"},
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			err: fmt.Errorf("the pod runs on Windows and the Java instrumentation has no Windows image"),
+		},
 	}
 
 	for _, test := range tests {
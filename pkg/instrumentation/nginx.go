@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	nginxDefaultConfigFile = "/etc/nginx/nginx.conf"
+	nginxVersionFile       = "version.txt"
+	envLdLibraryPath       = "LD_LIBRARY_PATH"
+)
+
+// injectNginxagent loads the otel-webserver-module into the Nginx server of the container. The module is built for
+// each Nginx version, so the clone of the container detects the version of its Nginx. The main config then loads
+// the module on its first line, and includes the agent config at the start of its http block.
+func injectNginxagent(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int, serviceName, serviceNamespace string) (corev1.Pod, error) {
+	nginxSpec := otelinst.Spec.Nginx
+	configFile := nginxSpec.ConfigFile
+	if file, ok := pod.Annotations[annotationNginxConfigFile]; ok && file != "" {
+		configFile = file
+	}
+	if configFile == "" {
+		configFile = nginxDefaultConfigFile
+	}
+	if !path.IsAbs(configFile) {
+		return pod, fmt.Errorf("the Nginx config file should be an absolute path: %s", configFile)
+	}
+	configDirectory, configFileName := path.Split(path.Clean(configFile))
+	configDirectory = strings.TrimSuffix(configDirectory, "/")
+
+	copiedConfigFile := fmt.Sprintf("%s/%s", webServerAgentConfigDirectory, configFileName)
+	versionFile := fmt.Sprintf("%s/%s", webServerAgentConfigDirectory, nginxVersionFile)
+	pod, err := injectWebServerAgent(webServer{
+		name:            "Nginx",
+		key:             "nginx",
+		image:           nginxSpec.Image,
+		env:             nginxSpec.Env,
+		resources:       nginxSpec.Resources,
+		configDirectory: configDirectory,
+		cloneCommands: []string{
+			// nginx -v prints e.g. "nginx version: nginx/1.23.1" on stderr
+			fmt.Sprintf("nginx -v 2>&1 | sed 's,.*/,,' > %s", versionFile),
+		},
+		setupCommands: []string{
+			fmt.Sprintf("sed -i \"1i load_module %s/WebServerModule/Nginx/$(cat %s)/ngx_http_opentelemetry_module.so;\" %s", webServerAgentDirectory, versionFile, copiedConfigFile),
+			fmt.Sprintf("sed -i \"s,^\\([[:space:]]*http[[:space:]]*{\\),\\1 include %s/%s;,\" %s", configDirectory, webServerAgentConfigFile, copiedConfigFile),
+		},
+		agentConfig: nginxAgentConfig(otelinst, serviceName, serviceNamespace),
+	}, pod, index)
+	if err != nil {
+		return pod, err
+	}
+
+	// the module loads the libraries of the SDK
+	container := &pod.Spec.Containers[index]
+	libraries := fmt.Sprintf("%s/sdk_lib/lib", webServerAgentDirectory)
+	if idx := getIndexOfEnv(container.Env, envLdLibraryPath); idx > -1 {
+		container.Env[idx].Value = fmt.Sprintf("%s:%s", container.Env[idx].Value, libraries)
+	} else {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envLdLibraryPath,
+			Value: libraries,
+		})
+	}
+	return pod, nil
+}
+
+// nginxAgentConfig renders the directives of the agent config, included in the http block.
+func nginxAgentConfig(otelinst v1alpha1.Instrumentation, serviceName, serviceNamespace string) string {
+	var sb strings.Builder
+	for _, directive := range webServerAgentDirectives(otelinst, "NginxModule", serviceName, serviceNamespace, otelinst.Spec.Nginx.Attrs) {
+		sb.WriteString(directive + ";\n")
+	}
+	return sb.String()
+}
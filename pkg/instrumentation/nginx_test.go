@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectNginxagent(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			Nginx: v1alpha1.Nginx{
+				Image: "foo/bar:1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		nginx             v1alpha1.Nginx
+		annotations       map[string]string
+		env               []corev1.EnvVar
+		expectedDirectory string
+		expectedFile      string
+		expectedEnv       []corev1.EnvVar
+	}{
+		{
+			name:              "default config file",
+			expectedDirectory: "/etc/nginx",
+			expectedFile:      "nginx.conf",
+			expectedEnv:       []corev1.EnvVar{{Name: envLdLibraryPath, Value: "/opt/opentelemetry-webserver/agent/sdk_lib/lib"}},
+		},
+		{
+			name:              "config file of the instrumentation",
+			nginx:             v1alpha1.Nginx{ConfigFile: "/usr/local/nginx/conf/nginx.conf"},
+			env:               []corev1.EnvVar{{Name: envLdLibraryPath, Value: "/usr/lib"}},
+			expectedDirectory: "/usr/local/nginx/conf",
+			expectedFile:      "nginx.conf",
+			expectedEnv:       []corev1.EnvVar{{Name: envLdLibraryPath, Value: "/usr/lib:/opt/opentelemetry-webserver/agent/sdk_lib/lib"}},
+		},
+		{
+			name:              "config file of the annotation",
+			nginx:             v1alpha1.Nginx{ConfigFile: "/usr/local/nginx/conf/nginx.conf"},
+			annotations:       map[string]string{annotationNginxConfigFile: "/opt/nginx/main.conf"},
+			expectedDirectory: "/opt/nginx",
+			expectedFile:      "main.conf",
+			expectedEnv:       []corev1.EnvVar{{Name: envLdLibraryPath, Value: "/opt/opentelemetry-webserver/agent/sdk_lib/lib"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otelinst := *inst.DeepCopy()
+			otelinst.Spec.Nginx.ConfigFile = test.nginx.ConfigFile
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.23.1", Env: test.env}},
+				},
+			}
+
+			// test
+			actual, err := injectNginxagent(otelinst, pod, 0, "my-service", "my-ns")
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, actual.Spec.InitContainers, 2)
+			assert.Equal(t, []string{"cp -rL " + test.expectedDirectory + "/* /opt/opentelemetry-webserver/source-conf && " +
+				"nginx -v 2>&1 | sed 's,.*/,,' > /opt/opentelemetry-webserver/source-conf/version.txt"}, actual.Spec.InitContainers[0].Args)
+			assert.Equal(t, []string{"cp -ar /opt/opentelemetry/* /opt/opentelemetry-webserver/agent && " +
+				"echo \"$OTEL_WEBSERVER_AGENT_CONF\" > /opt/opentelemetry-webserver/source-conf/opentelemetry_agent.conf && " +
+				"sed -i \"s/<<SID-PLACEHOLDER>>/${OTEL_WEBSERVER_SERVICE_INSTANCE_ID}/g\" /opt/opentelemetry-webserver/source-conf/opentelemetry_agent.conf && " +
+				"sed -i \"1i load_module /opt/opentelemetry-webserver/agent/WebServerModule/Nginx/$(cat /opt/opentelemetry-webserver/source-conf/version.txt)/ngx_http_opentelemetry_module.so;\" /opt/opentelemetry-webserver/source-conf/" + test.expectedFile + " && " +
+				"sed -i \"s,^\\([[:space:]]*http[[:space:]]*{\\),\\1 include " + test.expectedDirectory + "/opentelemetry_agent.conf;,\" /opt/opentelemetry-webserver/source-conf/" + test.expectedFile,
+			}, actual.Spec.InitContainers[1].Args)
+			assert.Equal(t, []corev1.VolumeMount{
+				{Name: "otel-nginx-agent", MountPath: webServerAgentDirectory},
+				{Name: "otel-nginx-conf-dir", MountPath: test.expectedDirectory},
+			}, actual.Spec.Containers[0].VolumeMounts)
+			assert.Equal(t, test.expectedEnv, actual.Spec.Containers[0].Env)
+		})
+	}
+}
+
+func TestInjectNginxagentRelativeConfigFile(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotationNginxConfigFile: "nginx.conf"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "nginx"}},
+		},
+	}
+
+	actual, err := injectNginxagent(v1alpha1.Instrumentation{}, pod, 0, "my-service", "my-ns")
+
+	assert.Equal(t, errors.New("the Nginx config file should be an absolute path: nginx.conf"), err)
+	assert.Equal(t, pod, actual)
+}
+
+func TestNginxAgentConfig(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			Sampler:  v1alpha1.Sampler{Type: v1alpha1.AlwaysOff},
+			Nginx: v1alpha1.Nginx{
+				Attrs: []corev1.EnvVar{{Name: "NginxModuleOtelMaxQueueSize", Value: "4096"}},
+			},
+		},
+	}
+
+	assert.Equal(t, `NginxModuleEnabled OFF;
+NginxModuleOtelExporterEndpoint http://collector:4317;
+NginxModuleOtelMaxQueueSize 4096;
+NginxModuleOtelSpanExporter otlp;
+NginxModuleResolveBackends ON;
+NginxModuleServiceInstanceId <<SID-PLACEHOLDER>>;
+NginxModuleServiceName my-service;
+NginxModuleServiceNamespace my-ns;
+NginxModuleTraceAsError ON;
+`, nginxAgentConfig(inst, "my-service", "my-ns"))
+}
@@ -25,7 +25,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
 )
 
 var (
@@ -37,25 +40,39 @@ type instPodMutator struct {
 	Client      client.Client
 	sdkInjector *sdkInjector
 	Logger      logr.Logger
+	config      config.Config
 }
 
 type languageInstrumentations struct {
-	Java   *v1alpha1.Instrumentation
-	NodeJS *v1alpha1.Instrumentation
-	Python *v1alpha1.Instrumentation
-	DotNet *v1alpha1.Instrumentation
-	Sdk    *v1alpha1.Instrumentation
+	Java        *v1alpha1.Instrumentation
+	NodeJS      *v1alpha1.Instrumentation
+	Python      *v1alpha1.Instrumentation
+	DotNet      *v1alpha1.Instrumentation
+	Go          *v1alpha1.Instrumentation
+	ApacheHttpd *v1alpha1.Instrumentation
+	Nginx       *v1alpha1.Instrumentation
+	Sdk         *v1alpha1.Instrumentation
 }
 
 var _ webhookhandler.PodMutator = (*instPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, client client.Client) *instPodMutator {
+// withSDKVersionImages resolves the images selected by the sdkVersion of the instrumentations of the languages having one.
+func withSDKVersionImages(insts languageInstrumentations) languageInstrumentations {
+	insts.Java = insts.Java.WithSDKVersionImages()
+	insts.NodeJS = insts.NodeJS.WithSDKVersionImages()
+	insts.Python = insts.Python.WithSDKVersionImages()
+	return insts
+}
+
+func NewMutator(logger logr.Logger, cfg config.Config, client client.Client) *instPodMutator {
 	return &instPodMutator{
 		Logger: logger,
 		Client: client,
+		config: cfg,
 		sdkInjector: &sdkInjector{
-			logger: logger,
-			client: client,
+			logger:  logger,
+			client:  client,
+			runtime: cfg.ContainerRuntime(),
 		},
 	}
 }
@@ -79,6 +96,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectJava), err)
 		return pod, err
 	}
 	insts.Java = inst
@@ -86,6 +104,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectNodeJS), err)
 		return pod, err
 	}
 	insts.NodeJS = inst
@@ -93,6 +112,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectPython), err)
 		return pod, err
 	}
 	insts.Python = inst
@@ -100,35 +120,106 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectDotNet), err)
 		return pod, err
 	}
 	insts.DotNet = inst
 
+	if featuregate.Gates.Enabled(featuregate.GoAutoInstrumentation) {
+		if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo); err != nil {
+			// we still allow the pod to be created, but we log a message to the operator's logs
+			logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+			metrics.RecordInjection(annotationLanguage(annotationInjectGo), err)
+			return pod, err
+		}
+		insts.Go = inst
+	} else if annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectGo) != "" {
+		logger.Info("Skipping Go instrumentation injection, the feature gate is disabled", "featureGate", featuregate.GoAutoInstrumentation)
+	}
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectApacheHttpd), err)
+		return pod, err
+	}
+	insts.ApacheHttpd = inst
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectNginx), err)
+		return pod, err
+	}
+	insts.Nginx = inst
+
 	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		metrics.RecordInjection(annotationLanguage(annotationInjectSdk), err)
 		return pod, err
 	}
 	insts.Sdk = inst
 
-	if insts.Java == nil && insts.NodeJS == nil && insts.Python == nil && insts.DotNet == nil && insts.Sdk == nil {
+	insts = withSDKVersionImages(insts)
+	insts = withFIPSImages(pm.config, logger, insts)
+	if insts.Java == nil && insts.NodeJS == nil && insts.Python == nil && insts.DotNet == nil && insts.Go == nil && insts.ApacheHttpd == nil && insts.Nginx == nil && insts.Sdk == nil {
 		logger.V(1).Info("annotation not present in deployment, skipping instrumentation injection")
 		return pod, nil
 	}
 
 	// We retrieve the annotation for podname
-	var targetContainers = annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName)
+	targetContainers, unknownContainers := containersToInstrument(pod, annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName))
+	if len(unknownContainers) > 0 {
+		logger.Info("Skipping the instrumentation of containers the pod doesn't have", "containers", unknownContainers)
+	}
 
 	// once it's been determined that instrumentation is desired, none exists yet, and we know which instance it should talk to,
 	// we should inject the instrumentation.
 	modifiedPod := pod
-	for _, currentContainer := range strings.Split(targetContainers, ",") {
-		modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, strings.TrimSpace(currentContainer))
+	for _, currentContainer := range targetContainers {
+		modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, currentContainer)
+	}
+	if len(targetContainers) > 0 {
+		modifiedPod = withInjectedGenerations(modifiedPod, insts)
 	}
 
 	return modifiedPod, nil
 }
 
+// InjectedInstrumentationsIndex is the field index of the pods by the Instrumentations they're injected from.
+const InjectedInstrumentationsIndex = "metadata.annotations.injectedInstrumentations"
+
+// InjectedInstrumentations returns the namespaced names of the Instrumentations the pod is injected from, which are
+// the keys of the InjectedInstrumentationsIndex.
+func InjectedInstrumentations(obj client.Object) []string {
+	generations := webhookhandler.ParseInjectedGenerations(obj.GetAnnotations()[AnnotationInjectedGenerations])
+	if len(generations) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(generations))
+	for nsn := range generations {
+		keys = append(keys, nsn.String())
+	}
+	return keys
+}
+
+// withInjectedGenerations records the generations of the Instrumentations the pod is injected from, so that the pod
+// can be restarted when they change.
+func withInjectedGenerations(pod corev1.Pod, insts languageInstrumentations) corev1.Pod {
+	generations := webhookhandler.InjectedGenerations{}
+	for _, inst := range []*v1alpha1.Instrumentation{insts.Java, insts.NodeJS, insts.Python, insts.DotNet, insts.Go, insts.ApacheHttpd, insts.Nginx, insts.Sdk} {
+		if inst != nil {
+			generations[types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}] = inst.Generation
+		}
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationInjectedGenerations] = generations.String()
+	return pod
+}
+
 func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string) (*v1alpha1.Instrumentation, error) {
 	instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, instAnnotation)
 
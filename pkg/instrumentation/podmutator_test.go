@@ -26,10 +26,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestMutatePod(t *testing.T) {
-	mutator := NewMutator(logr.Discard(), k8sClient)
+	mutator := NewMutator(logr.Discard(), config.New(), k8sClient)
 	require.NotNil(t, mutator)
 
 	tests := []struct {
@@ -117,7 +119,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectJava: "true",
+						annotationInjectJava:          "true",
+						AnnotationInjectedGenerations: "javaagent/example-inst=1",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -289,7 +292,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectNodeJS: "true",
+						annotationInjectNodeJS:        "true",
+						AnnotationInjectedGenerations: "nodejs/example-inst=1",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -458,7 +462,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectPython: "true",
+						annotationInjectPython:        "true",
+						AnnotationInjectedGenerations: "python/example-inst=1",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -623,7 +628,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectDotNet: "true",
+						annotationInjectDotNet:        "true",
+						AnnotationInjectedGenerations: "dotnet/example-inst=1",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -887,3 +893,90 @@ func TestMutatePod(t *testing.T) {
 		})
 	}
 }
+
+func TestMutatePodGo(t *testing.T) {
+	// prepare
+	require.NoError(t, featuregate.Gates.Set("GoAutoInstrumentation=true"))
+	defer func() {
+		require.NoError(t, featuregate.Gates.Set("GoAutoInstrumentation=false"))
+	}()
+	mutator := NewMutator(logr.Discard(), config.New(), k8sClient)
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "golang",
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &ns))
+	defer func() {
+		_ = k8sClient.Delete(context.Background(), &ns)
+	}()
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-inst",
+			Namespace: "golang",
+		},
+		Spec: v1alpha1.InstrumentationSpec{
+			Go: v1alpha1.Go{
+				Image: "otel/go:1",
+			},
+			Exporter: v1alpha1.Exporter{
+				Endpoint: "http://collector:4318",
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), &inst))
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				annotationInjectGo:   "true",
+				annotationGoExecPath: "/app/server",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+				},
+			},
+		},
+	}
+
+	// test
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, mutated.Spec.Containers, 2)
+	assert.Empty(t, mutated.Spec.Containers[0].Env)
+	agent := mutated.Spec.Containers[1]
+	assert.Equal(t, goAgentContainerName, agent.Name)
+	assert.Equal(t, "otel/go:1", agent.Image)
+	assert.Contains(t, agent.Env, corev1.EnvVar{Name: envOtelTargetExe, Value: "/app/server"})
+	assert.Contains(t, agent.Env, corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: "app"})
+	assert.Contains(t, agent.Env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector:4318"})
+	assert.Contains(t, agent.Env, corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.container.name=app,k8s.namespace.name=golang,k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME),k8s.pod.name=$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME)"})
+	assert.True(t, *mutated.Spec.ShareProcessNamespace)
+	assert.Equal(t, "golang/example-inst=1", mutated.Annotations[AnnotationInjectedGenerations])
+
+	// the agent isn't injected while the feature gate is disabled
+	require.NoError(t, featuregate.Gates.Set("GoAutoInstrumentation=false"))
+	mutated, err = mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+	assert.Len(t, mutated.Spec.Containers, 1)
+}
+
+func TestInjectedInstrumentations(t *testing.T) {
+	// prepare
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationInjectedGenerations: "default/java=2,observability/python=1"},
+		},
+	}
+
+	// test
+	keys := InjectedInstrumentations(pod)
+
+	// verify
+	assert.ElementsMatch(t, []string{"default/java", "observability/python"}, keys)
+	assert.Empty(t, InjectedInstrumentations(&corev1.Pod{}))
+}
@@ -15,6 +15,7 @@
 package instrumentation
 
 import (
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -39,6 +40,14 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 		return pod, err
 	}
 
+	if IsWindowsPod(pod) {
+		return pod, errors.New("the Python instrumentation doesn't support the pods running on Windows")
+	}
+	image, err := pythonPlatforms.image(pod, pythonSpec.Image, pythonSpec.MuslImage)
+	if err != nil {
+		return pod, err
+	}
+
 	// inject Python instrumentation spec env vars.
 	for _, env := range pythonSpec.Env {
 		idx := getIndexOfEnv(container.Env, env.Name)
@@ -93,7 +102,7 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 
 		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
 			Name:    initContainerName,
-			Image:   pythonSpec.Image,
+			Image:   image,
 			Command: []string{"cp", "-a", "/autoinstrumentation/.", "/otel-auto-instrumentation/"},
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      volumeName,
@@ -332,3 +332,63 @@ func TestInjectPythonSDK(t *testing.T) {
 		})
 	}
 }
+
+func TestInjectPythonSDKMusl(t *testing.T) {
+	pythonSpec := v1alpha1.Python{Image: "foo/bar:1", MuslImage: "foo/bar:1-musl"}
+	tests := []struct {
+		name          string
+		platform      string
+		pythonSpec    v1alpha1.Python
+		expectedImage string
+		err           error
+	}{
+		{
+			name:          "default platform",
+			pythonSpec:    pythonSpec,
+			expectedImage: "foo/bar:1",
+		},
+		{
+			name:          "glibc",
+			platform:      "glibc",
+			pythonSpec:    pythonSpec,
+			expectedImage: "foo/bar:1",
+		},
+		{
+			name:          "musl",
+			platform:      "musl",
+			pythonSpec:    pythonSpec,
+			expectedImage: "foo/bar:1-musl",
+		},
+		{
+			name:       "musl without musl image",
+			platform:   "musl",
+			pythonSpec: v1alpha1.Python{Image: "foo/bar:1"},
+			err:        fmt.Errorf("the pod selects musl with the instrumentation.opentelemetry.io/otel-python-platform annotation and the instrumentation has no musl image"),
+		},
+		{
+			name:       "unknown platform",
+			platform:   "alpine",
+			pythonSpec: pythonSpec,
+			err:        fmt.Errorf("unknown value \"alpine\" in annotation instrumentation.opentelemetry.io/otel-python-platform, should be glibc or musl"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{}},
+				},
+			}
+			if test.platform != "" {
+				pod.Annotations = map[string]string{annotationPythonPlatform: test.platform}
+			}
+
+			pod, err := injectPythonSDK(test.pythonSpec, pod, 0)
+
+			assert.Equal(t, test.err, err)
+			if test.err == nil {
+				assert.Equal(t, test.expectedImage, pod.Spec.InitContainers[0].Image)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry checks the availability and the architectures of images in OCI registries.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultRegistry = "docker.io"
+	dockerHubHost   = "registry-1.docker.io"
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Checker looks up image manifests with the OCI distribution API, using anonymous tokens when the registry requires them.
+type Checker struct {
+	Client *http.Client
+}
+
+// New returns a checker using the default HTTP client.
+func New() *Checker {
+	return &Checker{Client: http.DefaultClient}
+}
+
+// Exists returns whether the tag of the image exists in its registry.
+func (c *Checker) Exists(ctx context.Context, image string) (bool, error) {
+	host, repository, tag, err := parse(image)
+	if err != nil {
+		return false, err
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, tag)
+
+	resp, err := c.send(ctx, http.MethodHead, manifestURL, "")
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := c.token(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return false, err
+		}
+		if resp, err = c.send(ctx, http.MethodHead, manifestURL, token); err != nil {
+			return false, err
+		}
+		resp.Body.Close()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected status %d looking up the image %s", resp.StatusCode, image)
+}
+
+// Architectures returns the architectures of the linux images in the manifest list of the image, or the architecture
+// of the image itself when it's a single image manifest.
+func (c *Checker) Architectures(ctx context.Context, image string) ([]string, error) {
+	host, repository, tag, err := parse(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Manifests []struct {
+			Platform struct {
+				Architecture string `json:"architecture"`
+				OS           string `json:"os"`
+			} `json:"platform"`
+		} `json:"manifests"`
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, tag), &manifest); err != nil {
+		return nil, err
+	}
+
+	if len(manifest.Manifests) > 0 {
+		found := map[string]bool{}
+		var architectures []string
+		for _, m := range manifest.Manifests {
+			// the attestation manifests have an unknown platform
+			arch := m.Platform.Architecture
+			if m.Platform.OS != "linux" || arch == "" || arch == "unknown" || found[arch] {
+				continue
+			}
+			found[arch] = true
+			architectures = append(architectures, arch)
+		}
+		sort.Strings(architectures)
+		return architectures, nil
+	}
+
+	if manifest.Config.Digest == "" {
+		return nil, fmt.Errorf("the manifest of the image %s has no config", image)
+	}
+	var config struct {
+		Architecture string `json:"architecture"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("https://%s/v2/%s/blobs/%s", host, repository, manifest.Config.Digest), &config); err != nil {
+		return nil, err
+	}
+	return []string{config.Architecture}, nil
+}
+
+// getJSON decodes the registry document at the URL.
+func (c *Checker) getJSON(ctx context.Context, documentURL string, v interface{}) error {
+	resp, err := c.send(ctx, http.MethodGet, documentURL, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, err := c.token(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return err
+		}
+		if resp, err = c.send(ctx, http.MethodGet, documentURL, token); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d getting %s", resp.StatusCode, documentURL)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", documentURL, err)
+	}
+	return nil
+}
+
+// send requests the URL with the accepted manifest media types. The caller closes the response body.
+func (c *Checker) send(ctx context.Context, method, documentURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ","))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the image manifest: %w", err)
+	}
+	return resp, nil
+}
+
+// token requests an anonymous pull token from the realm of the Bearer challenge.
+func (c *Checker) token(ctx context.Context, challenge string) (string, error) {
+	params := parseChallenge(challenge)
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if v, ok := params[key]; ok {
+			query.Set(key, v)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d getting a registry token", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode the registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallenge returns the parameters of a WWW-Authenticate Bearer challenge.
+func parseChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	scheme, rest, found := strings.Cut(challenge, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return params
+	}
+	for _, part := range strings.Split(rest, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if found {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	return params
+}
+
+// parse splits the image reference into the registry host, the repository and the tag.
+func parse(image string) (string, string, string, error) {
+	name, tag := image, "latest"
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	if name == "" || tag == "" || strings.Contains(name, "@") {
+		return "", "", "", fmt.Errorf("unsupported image reference %q", image)
+	}
+
+	host, repository := defaultRegistry, name
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		host, repository = first, rest
+	}
+	if host == defaultRegistry {
+		host = dockerHubHost
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	return host, repository, tag, nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExists(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:org/agent:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:org/agent:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/org/agent/manifests/1.0.0":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{Client: server.Client()}
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	exists, err := checker.Exists(context.Background(), host+"/org/agent:1.0.0")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = checker.Exists(context.Background(), host+"/org/agent:0.0.1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestArchitectures(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/org/collector/manifests/multi":
+			fmt.Fprint(w, `{"manifests": [
+				{"platform": {"architecture": "arm64", "os": "linux"}},
+				{"platform": {"architecture": "amd64", "os": "linux"}},
+				{"platform": {"architecture": "amd64", "os": "windows"}},
+				{"platform": {"architecture": "unknown", "os": "unknown"}}
+			]}`)
+		case "/v2/org/collector/manifests/single":
+			fmt.Fprint(w, `{"config": {"digest": "sha256:abc"}}`)
+		case "/v2/org/collector/blobs/sha256:abc":
+			fmt.Fprint(w, `{"architecture": "amd64", "os": "linux"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{Client: server.Client()}
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	architectures, err := checker.Architectures(context.Background(), host+"/org/collector:multi")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amd64", "arm64"}, architectures)
+
+	architectures, err = checker.Architectures(context.Background(), host+"/org/collector:single")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amd64"}, architectures)
+
+	_, err = checker.Architectures(context.Background(), host+"/org/collector:missing")
+	assert.ErrorContains(t, err, "unexpected status 404")
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		image      string
+		host       string
+		repository string
+		tag        string
+	}{
+		{"ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:1.20.2", "ghcr.io", "open-telemetry/opentelemetry-operator/autoinstrumentation-java", "1.20.2"},
+		{"localhost:5000/agent:1.0", "localhost:5000", "agent", "1.0"},
+		{"org/agent", "registry-1.docker.io", "org/agent", "latest"},
+		{"agent:1.0", "registry-1.docker.io", "library/agent", "1.0"},
+	} {
+		t.Run(tt.image, func(t *testing.T) {
+			host, repository, tag, err := parse(tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.host, host)
+			assert.Equal(t, tt.repository, repository)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
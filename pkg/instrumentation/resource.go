@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// resourceValueEscaper percent-encodes the characters separating the attributes in OTEL_RESOURCE_ATTRIBUTES,
+// which the annotation values may have.
+var resourceValueEscaper = strings.NewReplacer("%", "%25", ",", "%2C", "=", "%3D")
+
+// mappedResourceAttributes returns the resource attributes mapped from the labels and annotations of the pod, or
+// of its namespace when the pod doesn't have them. The annotations take precedence over the labels.
+func mappedResourceAttributes(resource v1alpha1.Resource, ns metav1.ObjectMeta, pod metav1.ObjectMeta) map[string]string {
+	res := map[string]string{}
+	for _, mapping := range []struct {
+		attributes map[string]string
+		ns, pod    map[string]string
+	}{
+		{attributes: resource.FromLabels, ns: ns.Labels, pod: pod.Labels},
+		{attributes: resource.FromAnnotations, ns: ns.Annotations, pod: pod.Annotations},
+	} {
+		for key, attribute := range mapping.attributes {
+			value, ok := mapping.pod[key]
+			if !ok {
+				value, ok = mapping.ns[key]
+			}
+			if ok && value != "" && attribute != "" {
+				res[attribute] = resourceValueEscaper.Replace(value)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestMappedResourceAttributes(t *testing.T) {
+	resource := v1alpha1.Resource{
+		FromLabels: map[string]string{
+			"app.kubernetes.io/version": "service.version",
+			"app.kubernetes.io/part-of": "service.namespace",
+			"team":                      "team.name",
+			"missing":                   "missing",
+		},
+		FromAnnotations: map[string]string{
+			"example.com/owner":   "owner",
+			"example.com/release": "service.version",
+		},
+	}
+	ns := metav1.ObjectMeta{
+		Labels: map[string]string{
+			"team":                      "payments",
+			"app.kubernetes.io/version": "0.0.1",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pod      metav1.ObjectMeta
+		expected map[string]string
+	}{
+		{
+			name: "pod labels take precedence over the namespace labels",
+			pod: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"app.kubernetes.io/version": "1.2.3",
+					"app.kubernetes.io/part-of": "shop",
+				},
+			},
+			expected: map[string]string{
+				"service.version":   "1.2.3",
+				"service.namespace": "shop",
+				"team.name":         "payments",
+			},
+		},
+		{
+			name: "annotations take precedence over the labels and are escaped",
+			pod: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"app.kubernetes.io/version": "1.2.3",
+				},
+				Annotations: map[string]string{
+					"example.com/owner":   "jane,john=100%",
+					"example.com/release": "1.2.4",
+				},
+			},
+			expected: map[string]string{
+				"service.version": "1.2.4",
+				"team.name":       "payments",
+				"owner":           "jane%2Cjohn%3D100%25",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, mappedResourceAttributes(resource, ns, test.pod))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RuntimeContainerd is the value of the --runtime flag for clusters running containerd.
+	RuntimeContainerd = "containerd"
+
+	containerdAnnotationPrefix = "io.containerd."
+
+	// annotationContainerdRuntimeHandler pins the containerd runtime handler of the pod sandbox.
+	annotationContainerdRuntimeHandler = "io.containerd.cri.runtime-handler"
+)
+
+// hasContainerdAnnotations returns whether the pod carries annotations interpreted by containerd or its NRI plugins,
+// like io.containerd.runc.v2.group.
+func hasContainerdAnnotations(pod corev1.Pod) bool {
+	for k := range pod.Annotations {
+		if strings.HasPrefix(k, containerdAnnotationPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// adjustForRuntime makes the injected init container compatible with the runtime-specific annotations of the pod.
+// With containerd, the init container shares the security context of the instrumented container, so the runtime hooks
+// grouping the pod containers apply to it as well, and a pod pinning a runtime handler gets the runtime class of the
+// same name, as the runtime class is set for the whole pod, including the injected init container.
+func adjustForRuntime(runtime string, pod corev1.Pod, index int) corev1.Pod {
+	if runtime != RuntimeContainerd || !hasContainerdAnnotations(pod) {
+		return pod
+	}
+
+	if handler, ok := pod.Annotations[annotationContainerdRuntimeHandler]; ok && handler != "" && pod.Spec.RuntimeClassName == nil {
+		pod.Spec.RuntimeClassName = &handler
+	}
+
+	securityContext := pod.Spec.Containers[index].SecurityContext
+	if securityContext == nil {
+		return pod
+	}
+	for i := range pod.Spec.InitContainers {
+		initContainer := &pod.Spec.InitContainers[i]
+		if initContainer.Name == initContainerName && initContainer.SecurityContext == nil {
+			initContainer.SecurityContext = securityContext.DeepCopy()
+		}
+	}
+	return pod
+}
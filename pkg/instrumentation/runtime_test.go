@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdjustForRuntime(t *testing.T) {
+	runAsUser := int64(1000)
+	runtimeClass := "kata"
+
+	tests := []struct {
+		name     string
+		runtime  string
+		pod      corev1.Pod
+		expected corev1.Pod
+	}{
+		{
+			name:     "unknown runtime",
+			runtime:  "",
+			pod:      containerdPod(map[string]string{"io.containerd.runc.v2.group": "app"}, &runAsUser),
+			expected: containerdPod(map[string]string{"io.containerd.runc.v2.group": "app"}, &runAsUser),
+		},
+		{
+			name:     "no containerd annotations",
+			runtime:  RuntimeContainerd,
+			pod:      containerdPod(map[string]string{"foo": "bar"}, &runAsUser),
+			expected: containerdPod(map[string]string{"foo": "bar"}, &runAsUser),
+		},
+		{
+			name:    "security context copied to the init container",
+			runtime: RuntimeContainerd,
+			pod:     containerdPod(map[string]string{"io.containerd.runc.v2.group": "app"}, &runAsUser),
+			expected: func() corev1.Pod {
+				pod := containerdPod(map[string]string{"io.containerd.runc.v2.group": "app"}, &runAsUser)
+				pod.Spec.InitContainers[0].SecurityContext = &corev1.SecurityContext{RunAsUser: &runAsUser}
+				return pod
+			}(),
+		},
+		{
+			name:    "runtime class set from the runtime handler",
+			runtime: RuntimeContainerd,
+			pod:     containerdPod(map[string]string{annotationContainerdRuntimeHandler: "kata"}, nil),
+			expected: func() corev1.Pod {
+				pod := containerdPod(map[string]string{annotationContainerdRuntimeHandler: "kata"}, nil)
+				pod.Spec.RuntimeClassName = &runtimeClass
+				return pod
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := adjustForRuntime(test.runtime, test.pod, 0)
+			assert.Equal(t, test.expected, pod)
+		})
+	}
+}
+
+func containerdPod(annotations map[string]string, runAsUser *int64) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: initContainerName},
+			},
+			Containers: []corev1.Container{
+				{Name: "app"},
+			},
+		},
+	}
+	if runAsUser != nil {
+		pod.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{RunAsUser: runAsUser}
+	}
+	return pod
+}
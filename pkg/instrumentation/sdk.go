@@ -36,6 +36,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
 )
 
 const (
@@ -46,8 +47,9 @@ const (
 // inject a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 
 type sdkInjector struct {
-	client client.Client
-	logger logr.Logger
+	client  client.Client
+	logger  logr.Logger
+	runtime string
 }
 
 func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations, ns corev1.Namespace, pod corev1.Pod, containerName string) corev1.Pod {
@@ -69,11 +71,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		var err error
 		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
+		metrics.RecordInjection(annotationLanguage(annotationInjectJava), err)
 		if err != nil {
 			i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 		} else {
+			pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 		}
 	}
 	if insts.NodeJS != nil {
@@ -81,11 +85,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		var err error
 		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
+		metrics.RecordInjection(annotationLanguage(annotationInjectNodeJS), err)
 		if err != nil {
 			i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 		} else {
+			pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 		}
 	}
 	if insts.Python != nil {
@@ -93,11 +99,13 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		var err error
 		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index)
+		metrics.RecordInjection(annotationLanguage(annotationInjectPython), err)
 		if err != nil {
 			i.logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 		} else {
+			pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 		}
 	}
 	if insts.DotNet != nil {
@@ -105,20 +113,68 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		var err error
 		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 		pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index)
+		metrics.RecordInjection(annotationLanguage(annotationInjectDotNet), err)
 		if err != nil {
 			i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
 		} else {
+			pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 		}
 	}
+	if insts.Go != nil {
+		otelinst := *insts.Go
+		var err error
+		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		pod, err = injectGoSDK(otelinst.Spec.Go, pod)
+		metrics.RecordInjection(annotationLanguage(annotationInjectGo), err)
+		if err != nil {
+			i.logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		} else {
+			// the agent exports the telemetry of the instrumented container
+			agentIndex := len(pod.Spec.Containers) - 1
+			pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
+			pod = i.injectCommonEnvVar(otelinst, pod, agentIndex)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, agentIndex, index)
+		}
+	}
+	if insts.ApacheHttpd != nil {
+		otelinst := *insts.ApacheHttpd
+		i.logger.V(1).Info("injecting Apache HTTPD instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		pod = i.injectWebServerAgent(ctx, otelinst, ns, pod, index, annotationInjectApacheHttpd, "Apache HTTPD", injectApacheHttpdagent)
+	}
+	if insts.Nginx != nil {
+		otelinst := *insts.Nginx
+		i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		pod = i.injectWebServerAgent(ctx, otelinst, ns, pod, index, annotationInjectNginx, "Nginx", injectNginxagent)
+	}
 	if insts.Sdk != nil {
 		otelinst := *insts.Sdk
 		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+		metrics.RecordInjection(annotationLanguage(annotationInjectSdk), nil)
 		pod = i.injectCommonEnvVar(otelinst, pod, index)
-		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index)
+		pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 	}
-	return pod
+	return adjustForRuntime(i.runtime, pod, index)
+}
+
+// injectWebServerAgent injects the otel-webserver-module with the given function. The agent is configured by a config
+// file rather than env vars, so the config is rendered from the Instrumentation with the overrides of the pod.
+// The injection is recorded under the language of the inject annotation.
+func (i *sdkInjector) injectWebServerAgent(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int, annotation, server string,
+	inject func(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int, serviceName, serviceNamespace string) (corev1.Pod, error)) corev1.Pod {
+	// the invalid overrides are logged with the common SDK config
+	overridden, _ := withPodOverrides(otelinst, pod.ObjectMeta)
+	resourceMap := i.createResourceMap(ctx, otelinst, ns, pod, index, index)
+	pod, err := inject(overridden, pod, index, chooseServiceName(pod, resourceMap, index), ns.Name)
+	metrics.RecordInjection(annotationLanguage(annotation), err)
+	if err != nil {
+		i.logger.Info(fmt.Sprintf("Skipping %s agent injection", server), "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+		return pod
+	}
+	pod = addImagePullSecrets(pod, otelinst.Spec.ImagePullSecrets)
+	pod = i.injectCommonEnvVar(otelinst, pod, index)
+	return i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 }
 
 func (i *sdkInjector) injectCommonEnvVar(otelinst v1alpha1.Instrumentation, pod corev1.Pod, index int) corev1.Pod {
@@ -132,14 +188,21 @@ func (i *sdkInjector) injectCommonEnvVar(otelinst v1alpha1.Instrumentation, pod
 	return pod
 }
 
-func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int) corev1.Pod {
-	container := &pod.Spec.Containers[index]
-	resourceMap := i.createResourceMap(ctx, otelinst, ns, pod, index)
+// injectCommonSDKConfig sets the SDK env vars of the container at agentIndex, describing the instrumented container at
+// appIndex. They're the same container, except for the Go agent sidecar.
+func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, agentIndex, appIndex int) corev1.Pod {
+	otelinst, err := withPodOverrides(otelinst, pod.ObjectMeta)
+	if err != nil {
+		i.logger.Info("Ignoring the instrumentation overrides of the pod", "reason", err.Error())
+	}
+
+	container := &pod.Spec.Containers[agentIndex]
+	resourceMap := i.createResourceMap(ctx, otelinst, ns, pod, agentIndex, appIndex)
 	idx := getIndexOfEnv(container.Env, constants.EnvOTELServiceName)
 	if idx == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  constants.EnvOTELServiceName,
-			Value: chooseServiceName(pod, resourceMap, index),
+			Value: chooseServiceName(pod, resourceMap, appIndex),
 		})
 	}
 	if otelinst.Spec.Exporter.Endpoint != "" {
@@ -151,9 +214,12 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			})
 		}
 	}
+	pod = injectExporterTLS(otelinst.Spec.Exporter.TLS, pod, agentIndex)
+	container = &pod.Spec.Containers[agentIndex]
 
-	// Some attributes might be empty, we should get them via k8s downward API
-	if resourceMap[string(semconv.K8SPodNameKey)] == "" {
+	// Some attributes might be empty, we should get them via k8s downward API, unless a previous injection into the
+	// container already did
+	if resourceMap[string(semconv.K8SPodNameKey)] == "" && getIndexOfEnv(container.Env, constants.EnvPodName) == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name: constants.EnvPodName,
 			ValueFrom: &corev1.EnvVarSource{
@@ -165,7 +231,7 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 		resourceMap[string(semconv.K8SPodNameKey)] = fmt.Sprintf("$(%s)", constants.EnvPodName)
 	}
 	if otelinst.Spec.Resource.AddK8sUIDAttributes {
-		if resourceMap[string(semconv.K8SPodUIDKey)] == "" {
+		if resourceMap[string(semconv.K8SPodUIDKey)] == "" && getIndexOfEnv(container.Env, constants.EnvPodUID) == -1 {
 			container.Env = append(container.Env, corev1.EnvVar{
 				Name: constants.EnvPodUID,
 				ValueFrom: &corev1.EnvVarSource{
@@ -177,7 +243,7 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			resourceMap[string(semconv.K8SPodUIDKey)] = fmt.Sprintf("$(%s)", constants.EnvPodUID)
 		}
 	}
-	if resourceMap[string(semconv.K8SNodeNameKey)] == "" {
+	if resourceMap[string(semconv.K8SNodeNameKey)] == "" && getIndexOfEnv(container.Env, constants.EnvNodeName) == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name: constants.EnvNodeName,
 			ValueFrom: &corev1.EnvVarSource{
@@ -196,7 +262,7 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			Name:  constants.EnvOTELResourceAttrs,
 			Value: resStr,
 		})
-	} else {
+	} else if resStr != "" {
 		if !strings.HasSuffix(container.Env[idx].Value, ",") {
 			resStr = "," + resStr
 		}
@@ -222,9 +288,16 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 				Value: string(otelinst.Spec.Sampler.Type),
 			})
 			if otelinst.Spec.Sampler.Argument != "" {
+				argument := otelinst.Spec.Sampler.Argument
+				if otelinst.Spec.Sampler.Type == v1alpha1.JaegerRemote {
+					// the argument is validated by the webhook, a bare endpoint gets translated to the key-value format
+					if normalized, err := v1alpha1.NormalizeJaegerRemoteArgument(argument); err == nil {
+						argument = normalized
+					}
+				}
 				container.Env = append(container.Env, corev1.EnvVar{
 					Name:  constants.EnvOTELTracesSamplerArg,
-					Value: otelinst.Spec.Sampler.Argument,
+					Value: argument,
 				})
 			}
 		}
@@ -243,6 +316,9 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 }
 
 func chooseServiceName(pod corev1.Pod, resources map[string]string, index int) string {
+	if name := resources[string(semconv.ServiceNameKey)]; name != "" {
+		return name
+	}
 	if name := resources[string(semconv.K8SDeploymentNameKey)]; name != "" {
 		return name
 	}
@@ -263,12 +339,12 @@ func chooseServiceName(pod corev1.Pod, resources map[string]string, index int) s
 
 // createResourceMap creates resource attribute map.
 // User defined attributes (in explicitly set env var) have higher precedence.
-func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, index int) map[string]string {
+func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, agentIndex, appIndex int) map[string]string {
 	// get existing resources env var and parse it into a map
 	existingRes := map[string]bool{}
-	existingResourceEnvIdx := getIndexOfEnv(pod.Spec.Containers[index].Env, constants.EnvOTELResourceAttrs)
+	existingResourceEnvIdx := getIndexOfEnv(pod.Spec.Containers[agentIndex].Env, constants.EnvOTELResourceAttrs)
 	if existingResourceEnvIdx > -1 {
-		existingResArr := strings.Split(pod.Spec.Containers[index].Env[existingResourceEnvIdx].Value, ",")
+		existingResArr := strings.Split(pod.Spec.Containers[agentIndex].Env[existingResourceEnvIdx].Value, ",")
 		for _, kv := range existingResArr {
 			keyValueArr := strings.Split(strings.TrimSpace(kv), "=")
 			if len(keyValueArr) != 2 {
@@ -284,10 +360,15 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 			res[k] = v
 		}
 	}
+	for k, v := range mappedResourceAttributes(otelinst.Spec.Resource, ns.ObjectMeta, pod.ObjectMeta) {
+		if !existingRes[k] {
+			res[k] = v
+		}
+	}
 
 	k8sResources := map[attribute.Key]string{}
 	k8sResources[semconv.K8SNamespaceNameKey] = ns.Name
-	k8sResources[semconv.K8SContainerNameKey] = pod.Spec.Containers[index].Name
+	k8sResources[semconv.K8SContainerNameKey] = pod.Spec.Containers[appIndex].Name
 	// Some fields might be empty - node name, pod name
 	// The pod name might be empty if the pod is created form deployment template
 	k8sResources[semconv.K8SPodNameKey] = pod.Name
@@ -364,7 +364,7 @@ func TestSDKInjection(t *testing.T) {
 			inj := sdkInjector{
 				client: k8sClient,
 			}
-			pod := inj.injectCommonSDKConfig(context.Background(), test.inst, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: test.pod.Namespace}}, test.pod, 0)
+			pod := inj.injectCommonSDKConfig(context.Background(), test.inst, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: test.pod.Namespace}}, test.pod, 0, 0)
 			_, err = json.MarshalIndent(pod, "", "  ")
 			assert.NoError(t, err)
 			assert.Equal(t, test.expected, pod)
@@ -863,3 +863,36 @@ func TestInjectSdkOnly(t *testing.T) {
 		},
 	}, pod)
 }
+
+func TestInjectSdkOnlyWithLanguage(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			Exporter: v1alpha1.Exporter{
+				Endpoint: "https://collector:4318",
+			},
+			Java: v1alpha1.Java{
+				Image: "img:1",
+			},
+			Resource: v1alpha1.Resource{
+				AddK8sUIDAttributes: true,
+			},
+		},
+	}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+				},
+			},
+		},
+	}
+	inj := sdkInjector{
+		logger: logr.Discard(),
+	}
+
+	expected := inj.inject(context.Background(), languageInstrumentations{Java: &inst}, corev1.Namespace{}, *pod.DeepCopy(), "")
+	actual := inj.inject(context.Background(), languageInstrumentations{Java: &inst, Sdk: &inst}, corev1.Namespace{}, *pod.DeepCopy(), "")
+
+	assert.Equal(t, expected, actual)
+}
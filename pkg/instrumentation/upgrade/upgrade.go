@@ -23,15 +23,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/metrics"
 )
 
 type InstrumentationUpgrade struct {
-	Client                client.Client
-	Logger                logr.Logger
-	DefaultAutoInstJava   string
-	DefaultAutoInstNodeJS string
-	DefaultAutoInstPython string
-	DefaultAutoInstDotNet string
+	Client                     client.Client
+	Logger                     logr.Logger
+	DefaultAutoInstJava        string
+	DefaultAutoInstNodeJS      string
+	DefaultAutoInstPython      string
+	DefaultAutoInstDotNet      string
+	DefaultAutoInstGo          string
+	DefaultAutoInstApacheHttpd string
+	DefaultAutoInstNginx       string
 }
 
 //+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch;update;patch
@@ -55,7 +59,9 @@ func (u *InstrumentationUpgrade) ManagedInstances(ctx context.Context) error {
 		upgraded := u.upgrade(ctx, toUpgrade)
 		if !reflect.DeepEqual(upgraded, toUpgrade) {
 			// use update instead of patch because the patch does not upgrade annotations
-			if err := u.Client.Update(ctx, &upgraded); err != nil {
+			err := u.Client.Update(ctx, &upgraded)
+			metrics.RecordUpgrade("Instrumentation", err)
+			if err != nil {
 				u.Logger.Error(err, "failed to apply changes to instance", "name", upgraded.Name, "namespace", upgraded.Namespace)
 				continue
 			}
@@ -101,5 +107,29 @@ func (u *InstrumentationUpgrade) upgrade(_ context.Context, inst v1alpha1.Instru
 			inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationDotNet] = u.DefaultAutoInstDotNet
 		}
 	}
+	autoInstGo := inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationGo]
+	if autoInstGo != "" {
+		// upgrade the image only if the image matches the annotation
+		if inst.Spec.Go.Image == autoInstGo {
+			inst.Spec.Go.Image = u.DefaultAutoInstGo
+			inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationGo] = u.DefaultAutoInstGo
+		}
+	}
+	autoInstApacheHttpd := inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationApacheHttpd]
+	if autoInstApacheHttpd != "" {
+		// upgrade the image only if the image matches the annotation
+		if inst.Spec.ApacheHttpd.Image == autoInstApacheHttpd {
+			inst.Spec.ApacheHttpd.Image = u.DefaultAutoInstApacheHttpd
+			inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationApacheHttpd] = u.DefaultAutoInstApacheHttpd
+		}
+	}
+	autoInstNginx := inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationNginx]
+	if autoInstNginx != "" {
+		// upgrade the image only if the image matches the annotation
+		if inst.Spec.Nginx.Image == autoInstNginx {
+			inst.Spec.Nginx.Image = u.DefaultAutoInstNginx
+			inst.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationNginx] = u.DefaultAutoInstNginx
+		}
+	}
 	return inst
 }
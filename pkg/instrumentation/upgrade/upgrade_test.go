@@ -43,10 +43,13 @@ func TestUpgrade(t *testing.T) {
 			Name:      "my-inst",
 			Namespace: nsName,
 			Annotations: map[string]string{
-				v1alpha1.AnnotationDefaultAutoInstrumentationJava:   "java:1",
-				v1alpha1.AnnotationDefaultAutoInstrumentationNodeJS: "nodejs:1",
-				v1alpha1.AnnotationDefaultAutoInstrumentationPython: "python:1",
-				v1alpha1.AnnotationDefaultAutoInstrumentationDotNet: "dotnet:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationJava:        "java:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationNodeJS:      "nodejs:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationPython:      "python:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationDotNet:      "dotnet:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationGo:          "go:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationApacheHttpd: "apache-httpd:1",
+				v1alpha1.AnnotationDefaultAutoInstrumentationNginx:       "nginx:1",
 			},
 		},
 		Spec: v1alpha1.InstrumentationSpec{
@@ -60,16 +63,22 @@ func TestUpgrade(t *testing.T) {
 	assert.Equal(t, "nodejs:1", inst.Spec.NodeJS.Image)
 	assert.Equal(t, "python:1", inst.Spec.Python.Image)
 	assert.Equal(t, "dotnet:1", inst.Spec.DotNet.Image)
+	assert.Equal(t, "go:1", inst.Spec.Go.Image)
+	assert.Equal(t, "apache-httpd:1", inst.Spec.ApacheHttpd.Image)
+	assert.Equal(t, "nginx:1", inst.Spec.Nginx.Image)
 	err = k8sClient.Create(context.Background(), inst)
 	require.NoError(t, err)
 
 	up := &InstrumentationUpgrade{
-		Logger:                logr.Discard(),
-		DefaultAutoInstJava:   "java:2",
-		DefaultAutoInstNodeJS: "nodejs:2",
-		DefaultAutoInstPython: "python:2",
-		DefaultAutoInstDotNet: "dotnet:2",
-		Client:                k8sClient,
+		Logger:                     logr.Discard(),
+		DefaultAutoInstJava:        "java:2",
+		DefaultAutoInstNodeJS:      "nodejs:2",
+		DefaultAutoInstPython:      "python:2",
+		DefaultAutoInstDotNet:      "dotnet:2",
+		DefaultAutoInstGo:          "go:2",
+		DefaultAutoInstApacheHttpd: "apache-httpd:2",
+		DefaultAutoInstNginx:       "nginx:2",
+		Client:                     k8sClient,
 	}
 	err = up.ManagedInstances(context.Background())
 	require.NoError(t, err)
@@ -88,4 +97,10 @@ func TestUpgrade(t *testing.T) {
 	assert.Equal(t, "python:2", updated.Spec.Python.Image)
 	assert.Equal(t, "dotnet:2", updated.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationDotNet])
 	assert.Equal(t, "dotnet:2", updated.Spec.DotNet.Image)
+	assert.Equal(t, "go:2", updated.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationGo])
+	assert.Equal(t, "go:2", updated.Spec.Go.Image)
+	assert.Equal(t, "apache-httpd:2", updated.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationApacheHttpd])
+	assert.Equal(t, "apache-httpd:2", updated.Spec.ApacheHttpd.Image)
+	assert.Equal(t, "nginx:2", updated.Annotations[v1alpha1.AnnotationDefaultAutoInstrumentationNginx])
+	assert.Equal(t, "nginx:2", updated.Spec.Nginx.Image)
 }
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	webServerAgentConfigFile         = "opentelemetry_agent.conf"
+	webServerAgentImageDirectory     = "/opt/opentelemetry"
+	webServerAgentDirectory          = "/opt/opentelemetry-webserver/agent"
+	webServerAgentConfigDirectory    = "/opt/opentelemetry-webserver/source-conf"
+	webServerAgentConfigEnvVar       = "OTEL_WEBSERVER_AGENT_CONF"
+	webServerServiceInstanceID       = "<<SID-PLACEHOLDER>>"
+	webServerServiceInstanceIDEnvVar = "OTEL_WEBSERVER_SERVICE_INSTANCE_ID"
+)
+
+// webServer describes how the otel-webserver-module is loaded into a web server, which isn't configured by env vars
+// but by its config files. The agent is injected in steps:
+//  1. an init container cloned from the instrumented container copies the original config directory to a volume,
+//  2. the agent init container copies the module, writes the agent config and amends the copied config to load it,
+//  3. the volume with the amended config is mounted over the config directory of the instrumented container.
+type webServer struct {
+	// name is the name of the server in the errors, and key the suffix of the injected containers and volumes.
+	name string
+	key  string
+
+	image     string
+	env       []corev1.EnvVar
+	resources corev1.ResourceRequirements
+
+	// configDirectory is the config directory of the instrumented container.
+	configDirectory string
+
+	// cloneCommands run in the clone of the instrumented container, after its config is copied.
+	cloneCommands []string
+
+	// setupCommands run in the agent init container to load the agent in the copied config, after the agent config
+	// is written.
+	setupCommands []string
+
+	// agentConfig is the content of the agent config file, included by the copied config.
+	agentConfig string
+}
+
+func (w webServer) agentInitContainerName() string {
+	return fmt.Sprintf("%s-%s", initContainerName, w.key)
+}
+
+func (w webServer) cloneContainerName() string {
+	return fmt.Sprintf("otel-%s-source-conf-clone", w.key)
+}
+
+func (w webServer) configVolume() string {
+	return fmt.Sprintf("otel-%s-conf-dir", w.key)
+}
+
+func (w webServer) agentVolume() string {
+	return fmt.Sprintf("otel-%s-agent", w.key)
+}
+
+func injectWebServerAgent(w webServer, pod corev1.Pod, index int) (corev1.Pod, error) {
+	if IsWindowsPod(pod) {
+		return pod, fmt.Errorf("the %s instrumentation isn't supported on Windows", w.name)
+	}
+	for _, initContainer := range pod.Spec.InitContainers {
+		if initContainer.Name == w.agentInitContainerName() {
+			return pod, fmt.Errorf("the %s agent is already injected", w.name)
+		}
+	}
+
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	for _, env := range w.env {
+		idx := getIndexOfEnv(container.Env, env.Name)
+		if idx == -1 {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	// the clone runs the image of the instrumented container to get its config, but mustn't start the server.
+	// The symbolic links of the config mounted from a ConfigMap are followed.
+	clone := container.DeepCopy()
+	clone.Name = w.cloneContainerName()
+	clone.Command = []string{"/bin/sh", "-c"}
+	clone.Args = []string{strings.Join(append([]string{
+		fmt.Sprintf("cp -rL %s/* %s", w.configDirectory, webServerAgentConfigDirectory),
+	}, w.cloneCommands...), " && ")}
+	clone.Resources = w.resources
+	clone.LivenessProbe = nil
+	clone.ReadinessProbe = nil
+	clone.StartupProbe = nil
+	clone.Lifecycle = nil
+	clone.Ports = nil
+	clone.VolumeMounts = append(clone.VolumeMounts, corev1.VolumeMount{
+		Name:      w.configVolume(),
+		MountPath: webServerAgentConfigDirectory,
+	})
+
+	agentConfigPath := fmt.Sprintf("%s/%s", webServerAgentConfigDirectory, webServerAgentConfigFile)
+	agent := corev1.Container{
+		Name:    w.agentInitContainerName(),
+		Image:   w.image,
+		Command: []string{"/bin/sh", "-c"},
+		Args: []string{strings.Join(append([]string{
+			fmt.Sprintf("cp -ar %s/* %s", webServerAgentImageDirectory, webServerAgentDirectory),
+			fmt.Sprintf("echo \"$%s\" > %s", webServerAgentConfigEnvVar, agentConfigPath),
+			fmt.Sprintf("sed -i \"s/%s/${%s}/g\" %s", webServerServiceInstanceID, webServerServiceInstanceIDEnvVar, agentConfigPath),
+		}, w.setupCommands...), " && ")},
+		Env: []corev1.EnvVar{
+			{
+				Name:  webServerAgentConfigEnvVar,
+				Value: w.agentConfig,
+			},
+			{
+				Name: webServerServiceInstanceIDEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
+		},
+		Resources: w.resources,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      w.agentVolume(),
+				MountPath: webServerAgentDirectory,
+			},
+			{
+				Name:      w.configVolume(),
+				MountPath: webServerAgentConfigDirectory,
+			},
+		},
+	}
+
+	// a volume mounted on the config directory would hide the amended config, it's only used by the clone
+	var volumeMounts []corev1.VolumeMount
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.MountPath != w.configDirectory {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+	container.VolumeMounts = append(volumeMounts,
+		corev1.VolumeMount{
+			Name:      w.agentVolume(),
+			MountPath: webServerAgentDirectory,
+		},
+		corev1.VolumeMount{
+			Name:      w.configVolume(),
+			MountPath: w.configDirectory,
+		},
+	)
+
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *clone, agent)
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		corev1.Volume{
+			Name: w.configVolume(),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		corev1.Volume{
+			Name: w.agentVolume(),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	)
+	return pod, nil
+}
+
+// webServerAgentDirectives returns the directives of the agent config with the exporter and sampler of the
+// Instrumentation, prefixed with the module name of the server, e.g. ApacheModuleEnabled. The module only samples all
+// or none of the requests, and propagates the W3C trace context. The attrs take precedence over the rendered directives.
+func webServerAgentDirectives(otelinst v1alpha1.Instrumentation, prefix, serviceName, serviceNamespace string, attrs []corev1.EnvVar) []string {
+	enabled := "ON"
+	switch otelinst.Spec.Sampler.Type {
+	case v1alpha1.AlwaysOff, v1alpha1.ParentBasedAlwaysOff:
+		enabled = "OFF"
+	}
+	directives := map[string]string{
+		prefix + "Enabled":           enabled,
+		prefix + "OtelSpanExporter":  "otlp",
+		prefix + "ServiceName":       serviceName,
+		prefix + "ServiceNamespace":  serviceNamespace,
+		prefix + "ServiceInstanceId": webServerServiceInstanceID,
+		prefix + "ResolveBackends":   "ON",
+		prefix + "TraceAsError":      "ON",
+	}
+	if otelinst.Spec.Exporter.Endpoint != "" {
+		directives[prefix+"OtelExporterEndpoint"] = otelinst.Spec.Exporter.Endpoint
+	}
+	for _, attr := range attrs {
+		directives[attr.Name] = attr.Value
+	}
+
+	keys := make([]string, 0, len(directives))
+	for k := range directives {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s %s", k, directives[k]))
+	}
+	return lines
+}
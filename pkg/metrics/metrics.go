@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics records the Prometheus metrics of the operator, served by the manager along with the
+// controller-runtime ones.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const namespace = "opentelemetry_operator"
+
+const (
+	// ResultSuccess labels the injections and upgrades that succeeded.
+	ResultSuccess = "success"
+
+	// ResultFailure labels the injections and upgrades that failed.
+	ResultFailure = "failure"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliations of the OpenTelemetryCollector instances.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"namespace", "name"})
+
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed reconciliations of the OpenTelemetryCollector instances.",
+	}, []string{"namespace", "name"})
+
+	managedCollectors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "managed_collectors",
+		Help:      "Number of OpenTelemetryCollector instances managed by the operator, by mode and version.",
+	}, []string{"mode", "version"})
+
+	injections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "instrumentation_injections_total",
+		Help:      "Number of auto-instrumentation injections into pods, by language and result.",
+	}, []string{"language", "result"})
+
+	upgrades = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upgrades_total",
+		Help:      "Number of automatic upgrades of the managed instances, by kind and result.",
+	}, []string{"kind", "result"})
+)
+
+// collectors keeps the mode and version of the instances, the managed collectors gauge is recomputed from it.
+var collectors = struct {
+	sync.Mutex
+	instances map[types.NamespacedName]collectorState
+}{instances: map[types.NamespacedName]collectorState{}}
+
+type collectorState struct {
+	mode    string
+	version string
+}
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileErrors, managedCollectors, injections, upgrades)
+}
+
+// ObserveReconcile records the duration and outcome of a reconciliation of the instance.
+func ObserveReconcile(nsn types.NamespacedName, duration time.Duration, err error) {
+	reconcileDuration.WithLabelValues(nsn.Namespace, nsn.Name).Observe(duration.Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(nsn.Namespace, nsn.Name).Inc()
+	}
+}
+
+// ObserveCollector records the mode and version of a managed instance.
+func ObserveCollector(otelcol v1alpha1.OpenTelemetryCollector) {
+	collectors.Lock()
+	defer collectors.Unlock()
+
+	collectors.instances[types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}] = collectorState{
+		mode:    string(otelcol.Spec.Mode),
+		version: otelcol.Status.Version,
+	}
+	updateManagedCollectors()
+}
+
+// ForgetCollector drops a deleted instance from the managed collectors, along with its reconcile metrics.
+func ForgetCollector(nsn types.NamespacedName) {
+	collectors.Lock()
+	defer collectors.Unlock()
+
+	delete(collectors.instances, nsn)
+	updateManagedCollectors()
+	reconcileDuration.DeleteLabelValues(nsn.Namespace, nsn.Name)
+	reconcileErrors.DeleteLabelValues(nsn.Namespace, nsn.Name)
+}
+
+func updateManagedCollectors() {
+	counts := map[collectorState]int{}
+	for _, state := range collectors.instances {
+		counts[state]++
+	}
+	managedCollectors.Reset()
+	for state, count := range counts {
+		managedCollectors.WithLabelValues(state.mode, state.version).Set(float64(count))
+	}
+}
+
+// RecordInjection records the outcome of the injection of the instrumentation of a language into a pod.
+func RecordInjection(language string, err error) {
+	injections.WithLabelValues(language, result(err)).Inc()
+}
+
+// RecordUpgrade records the outcome of the automatic upgrade of an instance of the kind.
+func RecordUpgrade(kind string, err error) {
+	upgrades.WithLabelValues(kind, result(err)).Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
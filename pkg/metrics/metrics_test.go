@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	// prepare
+	nsn := types.NamespacedName{Namespace: "default", Name: "my-instance"}
+
+	// test
+	ObserveReconcile(nsn, time.Second, nil)
+	ObserveReconcile(nsn, time.Second, errors.New("failed to reconcile"))
+
+	// verify
+	assert.Equal(t, 1, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileErrors.WithLabelValues("default", "my-instance")))
+
+	ForgetCollector(nsn)
+	assert.Equal(t, 0, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, 0, testutil.CollectAndCount(reconcileErrors))
+}
+
+func TestManagedCollectors(t *testing.T) {
+	// prepare
+	collector := func(name string, mode v1alpha1.Mode, version string) v1alpha1.OpenTelemetryCollector {
+		return v1alpha1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.OpenTelemetryCollectorSpec{Mode: mode},
+			Status:     v1alpha1.OpenTelemetryCollectorStatus{Version: version},
+		}
+	}
+
+	// test
+	ObserveCollector(collector("gateway", v1alpha1.ModeDeployment, "0.61.0"))
+	ObserveCollector(collector("edge", v1alpha1.ModeDeployment, "0.61.0"))
+	ObserveCollector(collector("agent", v1alpha1.ModeDaemonSet, "0.61.0"))
+	ObserveCollector(collector("agent", v1alpha1.ModeDaemonSet, "0.62.0"))
+
+	// verify
+	assert.Equal(t, float64(2), testutil.ToFloat64(managedCollectors.WithLabelValues("deployment", "0.61.0")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(managedCollectors.WithLabelValues("daemonset", "0.62.0")))
+	assert.Equal(t, 2, testutil.CollectAndCount(managedCollectors))
+
+	ForgetCollector(types.NamespacedName{Namespace: "default", Name: "gateway"})
+	ForgetCollector(types.NamespacedName{Namespace: "default", Name: "edge"})
+	ForgetCollector(types.NamespacedName{Namespace: "default", Name: "agent"})
+	assert.Equal(t, 0, testutil.CollectAndCount(managedCollectors))
+}
+
+func TestRecordInjectionAndUpgrade(t *testing.T) {
+	// test
+	RecordInjection("java", nil)
+	RecordInjection("java", errors.New("no instrumentation"))
+	RecordInjection("java", nil)
+	RecordUpgrade("OpenTelemetryCollector", errors.New("failed to patch"))
+
+	// verify
+	assert.Equal(t, float64(2), testutil.ToFloat64(injections.WithLabelValues("java", ResultSuccess)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(injections.WithLabelValues("java", ResultFailure)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(upgrades.WithLabelValues("OpenTelemetryCollector", ResultFailure)))
+}
@@ -29,6 +29,15 @@ func TAConfigMap(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
 }
 
+// ConfigMapPart builds the name of the config map holding the given part of a config split across several config
+// maps, the first part being held by the config map of the given name.
+func ConfigMapPart(name string, part int) string {
+	if part == 0 {
+		return name
+	}
+	return DNSName(Truncate("%s-%d", 63, name, part))
+}
+
 // ConfigMapVolume returns the name to use for the config map's volume in the pod.
 func ConfigMapVolume() string {
 	return "otc-internal"
@@ -44,6 +53,11 @@ func Container() string {
 	return "otc-container"
 }
 
+// ConfigReloaderContainer returns the name to use for the container reloading the config in the collector pod.
+func ConfigReloaderContainer() string {
+	return "otc-config-reloader"
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -54,11 +68,56 @@ func Collector(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
 }
 
+// CanaryCollector builds the name of the canary Deployment and ConfigMap of the collector rollouts.
+func CanaryCollector(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol.Name))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
 }
 
+// PodDisruptionBudget builds the PodDisruptionBudget name based on the instance.
+func PodDisruptionBudget(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// NetworkPolicy builds the NetworkPolicy name of the collector pods based on the instance.
+func NetworkPolicy(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// TAPodDisruptionBudget returns the name to use for the PodDisruptionBudget of the TargetAllocator pods.
+func TAPodDisruptionBudget(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+}
+
+// TANetworkPolicy returns the name to use for the NetworkPolicy of the TargetAllocator pods.
+func TANetworkPolicy(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
+}
+
+// ScaledObject builds the KEDA ScaledObject name based on the instance.
+func ScaledObject(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// VerticalPodAutoscaler builds the name of the VerticalPodAutoscaler of the collector based on the instance.
+func VerticalPodAutoscaler(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// ServiceMonitor builds the name of the ServiceMonitor scraping the collector metrics based on the instance.
+func ServiceMonitor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// PodMonitor builds the name of the PodMonitor scraping the collector metrics based on the instance.
+func PodMonitor(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
 // HorizontalPodAutoscaler builds the collector (deployment/daemonset) name based on the instance.
 func OpenTelemetryCollector(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s", 63, otelcol.Name))
@@ -69,6 +128,41 @@ func OpenTelemetryCollectorName(otelcolName string) string {
 	return DNSName(Truncate("%s", 63, otelcolName))
 }
 
+// Role builds the name of the Role granting the collector the permissions required by its receivers.
+func Role(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// RoleBinding builds the name of the RoleBinding of the collector's Role.
+func RoleBinding(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
+}
+
+// ClusterRole builds the name of the collector's ClusterRole, which has to be unique across the namespaces.
+func ClusterRole(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(TruncateUnique("collector", 63, otelcol.Namespace, otelcol.Name))
+}
+
+// ClusterRoleBinding builds the name of the ClusterRoleBinding of the collector's ClusterRole.
+func ClusterRoleBinding(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(TruncateUnique("collector", 63, otelcol.Namespace, otelcol.Name))
+}
+
+// PreDeployCheck builds the name of the pre-deploy check Job for the given revision of the instance.
+func PreDeployCheck(otelcol v1alpha1.OpenTelemetryCollector, revision string) string {
+	return DNSName(Truncate("%s-precheck-%s", 63, otelcol.Name, revision))
+}
+
+// CollectorBuild builds the name of the Job building the custom collector distribution.
+func CollectorBuild(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-build", 63, otelcol.Name))
+}
+
+// SmokeTest builds the name of the smoke test of the given revision of the collector Deployment.
+func SmokeTest(otelcol v1alpha1.OpenTelemetryCollector, revision string) string {
+	return DNSName(Truncate("%s-smoketest-%s", 63, otelcol.Name, revision))
+}
+
 // TargetAllocator returns the TargetAllocator deployment resource name.
 func TargetAllocator(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
@@ -84,6 +178,11 @@ func MonitoringService(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-monitoring", 63, Service(otelcol)))
 }
 
+// SplitService builds the name of the service of a receiver, or a receiver port, based on the instance.
+func SplitService(otelcol v1alpha1.OpenTelemetryCollector, name string) string {
+	return DNSName(Truncate("%s-collector-%s", 63, otelcol.Name, name))
+}
+
 // Service builds the service name based on the instance.
 func Service(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
@@ -99,11 +198,59 @@ func Route(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
 	return DNSName(Truncate("%s-%s-route", 63, prefix, otelcol.Name))
 }
 
+// HTTPRoute builds the Gateway API HTTPRoute name based on the instance.
+func HTTPRoute(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
+	return DNSName(Truncate("%s-%s-httproute", 63, prefix, otelcol.Name))
+}
+
+// GRPCRoute builds the Gateway API GRPCRoute name based on the instance.
+func GRPCRoute(otelcol v1alpha1.OpenTelemetryCollector, prefix string) string {
+	return DNSName(Truncate("%s-%s-grpcroute", 63, prefix, otelcol.Name))
+}
+
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
 }
 
+// TAServerCertificate returns the name of the Certificate, and Secret, of the TargetAllocator server.
+func TAServerCertificate(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator-server", 63, otelcol.Name))
+}
+
+// TAClientCertificate returns the name of the Certificate, and Secret, of the collector client of the TargetAllocator.
+func TAClientCertificate(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator-client", 63, otelcol.Name))
+}
+
+// TACertificateAuthority returns the name of the Secret of the self-signed CA issuing the TargetAllocator certificates.
+func TACertificateAuthority(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return DNSName(Truncate("%s-targetallocator-ca", 63, otelcol.Name))
+}
+
+// ReceiverCertificate returns the name of the Certificate, and Secret, of the receiver of the collector. The receiver
+// names can contain dashes and slashes, like otlp/my-receiver, so the name is made unique by a hash.
+func ReceiverCertificate(otelcol v1alpha1.OpenTelemetryCollector, receiver string) string {
+	return DNSName(TruncateUnique("tls", 63, otelcol.Name, receiver))
+}
+
+// VolumeClaim returns the name of the volume claim template, and of its volume, with the given name made a valid DNS
+// label.
+func VolumeClaim(name string) string {
+	return DNSName(Truncate("%s", 63, name))
+}
+
+// ReceiverTLSVolume returns the name of the volume of the receiver certificate mounted at the index-th directory.
+func ReceiverTLSVolume(index int) string {
+	return Truncate("receiver-tls-%d", 63, index)
+}
+
+// TATLSVolume returns the name to use for the volume of the TargetAllocator certificate in both the TargetAllocator
+// and collector pods.
+func TATLSVolume() string {
+	return "ta-tls"
+}
+
 // ServiceAccount builds the service account name based on the instance.
 func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol.Name))
@@ -113,3 +260,29 @@ func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) string {
 func TargetAllocatorServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol.Name))
 }
+
+// StandaloneTargetAllocator returns the name of the Deployment, ConfigMap and ServiceAccount of a TargetAllocator.
+// It doesn't collide with the names of the TargetAllocator embedded in a collector of the same name.
+func StandaloneTargetAllocator(taName string) string {
+	return DNSName(Truncate("%s-standalone-ta", 63, taName))
+}
+
+// StandaloneTAService returns the name of the Service of a TargetAllocator, queried by the collectors referencing it.
+func StandaloneTAService(taName string) string {
+	return DNSName(Truncate("%s-standalone-ta", 63, taName))
+}
+
+// OpAMPBridge returns the name of the Deployment, ConfigMap, ServiceAccount, Role and RoleBinding of an OpAMPBridge.
+func OpAMPBridge(bridgeName string) string {
+	return DNSName(Truncate("%s-opamp-bridge", 63, bridgeName))
+}
+
+// OpAMPBridgeContainer returns the name of the container of the OpAMP bridge pods.
+func OpAMPBridgeContainer() string {
+	return "opamp-bridge"
+}
+
+// OpAMPBridgeConfigVolume returns the name of the volume holding the config of the OpAMP bridge.
+func OpAMPBridgeConfigVolume() string {
+	return "opamp-bridge-internal"
+}
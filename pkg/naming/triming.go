@@ -18,8 +18,10 @@
 package naming
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var regexpEndReplace, regexpBeginReplace *regexp.Regexp
@@ -72,3 +74,16 @@ func trimNonAlphaNumeric(text string) string {
 	newText := regexpEndReplace.ReplaceAllString(text, "")
 	return regexpBeginReplace.ReplaceAllString(newText, "")
 }
+
+// TruncateUnique joins the values, which can contain dashes themselves, like a namespace and a name, and the suffix into
+// a name of at most max chars. A hash of the values tells apart the values joined into the same name, like the a-b/c and
+// a/b-c pairs, and the values shortened into the same name.
+func TruncateUnique(suffix string, max int, values ...string) string {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(values, "/"))))[:8]
+	format := strings.Repeat("%s-", len(values)) + "%s-%s"
+	args := make([]interface{}, 0, len(values)+2)
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return Truncate(format, max, append(args, hash, suffix)...)
+}
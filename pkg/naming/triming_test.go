@@ -18,6 +18,7 @@
 package naming
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -111,3 +112,17 @@ func TestTrimNonAlphaNumeric(t *testing.T) {
 		assert.Equal(t, test.expected, output)
 	}
 }
+
+func TestTruncateUnique(t *testing.T) {
+	// the pairs joining into the same name get different names
+	assert.NotEqual(t, TruncateUnique("collector", 63, "a-b", "c"), TruncateUnique("collector", 63, "a", "b-c"))
+	assert.Equal(t, TruncateUnique("collector", 63, "a-b", "c"), TruncateUnique("collector", 63, "a-b", "c"))
+	assert.Regexp(t, "^a-b-c-[0-9a-f]{8}-collector$", TruncateUnique("collector", 63, "a-b", "c"))
+
+	// the long values are shortened, keeping the hash and the suffix
+	long := strings.Repeat("n", 63)
+	name := TruncateUnique("collector", 63, long, "my-instance")
+	assert.Len(t, name, 63)
+	assert.Regexp(t, "-my-instance-[0-9a-f]{8}-collector$", name)
+	assert.NotEqual(t, name, TruncateUnique("collector", 63, long+"x", "my-instance"))
+}
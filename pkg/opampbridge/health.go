@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// ComponentHealth is the health of a managed instance, reported as the ComponentHealth of the OpAMP status. The
+// health of each of its pods is reported as a sub-component.
+type ComponentHealth struct {
+	Healthy   bool
+	StartTime time.Time
+	Status    string
+	LastError string
+	// Components holds the health of the pods, by pod name.
+	Components map[string]ComponentHealth
+}
+
+// CollectorReport is what the bridge reports to the OpAMP server for a managed instance.
+type CollectorReport struct {
+	Name   string
+	Health *ComponentHealth
+}
+
+// Reports returns the reports of all the instances managed by the bridge, according to its capabilities.
+func Reports(ctx context.Context, c client.Client, httpClient *http.Client, bridge v1alpha1.OpAMPBridge) ([]CollectorReport, error) {
+	list := &v1alpha1.OpenTelemetryCollectorList{}
+	if err := c.List(ctx, list, client.InNamespace(bridge.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+
+	var reports []CollectorReport
+	for _, instance := range list.Items {
+		if !Manages(bridge, instance) {
+			continue
+		}
+		report := CollectorReport{Name: instance.Name}
+		if bridge.Spec.Capabilities[v1alpha1.OpAMPBridgeCapabilityReportsHealth] {
+			health, err := CollectorHealth(ctx, c, httpClient, instance)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the health of the collector %s: %w", instance.Name, err)
+			}
+			report.Health = &health
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+	return reports, nil
+}
+
+// CollectorHealth returns the health of the pods of the instance, checked with their health_check extension when the
+// config enables one, and the rollout status of its workload.
+func CollectorHealth(ctx context.Context, c client.Client, httpClient *http.Client, instance v1alpha1.OpenTelemetryCollector) (ComponentHealth, error) {
+	health := ComponentHealth{Healthy: true, Components: map[string]ComponentHealth{}}
+	if instance.Spec.Mode != v1alpha1.ModeSidecar {
+		status, rolledOut, err := rolloutStatus(ctx, c, instance)
+		if err != nil {
+			return health, err
+		}
+		health.Status = status
+		health.Healthy = rolledOut
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(collector.SelectorLabels(instance))); err != nil {
+		return health, fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+	healthCheck := healthCheckProbe(instance)
+	for _, pod := range pods.Items {
+		podHealth := podHealth(ctx, httpClient, pod, healthCheck)
+		health.Components[pod.Name] = podHealth
+		if !podHealth.Healthy {
+			health.Healthy = false
+			if health.LastError == "" {
+				health.LastError = fmt.Sprintf("%s: %s", pod.Name, podHealth.LastError)
+			}
+		}
+		if health.StartTime.IsZero() || (!podHealth.StartTime.IsZero() && podHealth.StartTime.Before(health.StartTime)) {
+			health.StartTime = podHealth.StartTime
+		}
+	}
+	if len(pods.Items) == 0 {
+		health.Healthy = false
+	}
+	return health, nil
+}
+
+// rolloutStatus describes the rollout of the collector workload, and returns whether all its pods run the latest spec.
+func rolloutStatus(ctx context.Context, c client.Client, instance v1alpha1.OpenTelemetryCollector) (string, bool, error) {
+	nns := types.NamespacedName{Namespace: instance.Namespace, Name: naming.Collector(instance)}
+	var desired, updated, available int32
+	var err error
+	switch instance.Spec.Mode {
+	case v1alpha1.ModeDaemonSet:
+		workload := &appsv1.DaemonSet{}
+		err = c.Get(ctx, nns, workload)
+		desired, updated, available = workload.Status.DesiredNumberScheduled, workload.Status.UpdatedNumberScheduled, workload.Status.NumberAvailable
+	case v1alpha1.ModeStatefulSet:
+		workload := &appsv1.StatefulSet{}
+		err = c.Get(ctx, nns, workload)
+		desired, updated, available = workload.Status.Replicas, workload.Status.UpdatedReplicas, workload.Status.AvailableReplicas
+		if workload.Spec.Replicas != nil {
+			desired = *workload.Spec.Replicas
+		}
+	default:
+		workload := &appsv1.Deployment{}
+		err = c.Get(ctx, nns, workload)
+		desired, updated, available = workload.Status.Replicas, workload.Status.UpdatedReplicas, workload.Status.AvailableReplicas
+		if workload.Spec.Replicas != nil {
+			desired = *workload.Spec.Replicas
+		}
+	}
+	if apierrors.IsNotFound(err) {
+		return "not deployed", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("failed to get: %w", err)
+	}
+
+	if updated < desired || available < desired {
+		return fmt.Sprintf("rolling out, %d/%d updated, %d/%d available", updated, desired, available, desired), false, nil
+	}
+	return fmt.Sprintf("rolled out, %d/%d available", available, desired), true, nil
+}
+
+// healthCheckProbe returns the endpoint of the health_check extension of the instance pods, or nil when the config
+// doesn't enable one.
+func healthCheckProbe(instance v1alpha1.OpenTelemetryCollector) *corev1.HTTPGetAction {
+	config, err := adapters.ConfigFromString(instance.Spec.Config)
+	if err != nil {
+		return nil
+	}
+	probe, err := adapters.ConfigToContainerProbe(config)
+	if err != nil {
+		return nil
+	}
+	return probe.HTTPGet
+}
+
+// podHealth returns the health of a collector pod from its status, and from its health_check extension when set.
+func podHealth(ctx context.Context, httpClient *http.Client, pod corev1.Pod, healthCheck *corev1.HTTPGetAction) ComponentHealth {
+	health := ComponentHealth{Status: string(pod.Status.Phase)}
+	if pod.Status.StartTime != nil {
+		health.StartTime = pod.Status.StartTime.Time
+	}
+
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" {
+			health.LastError = fmt.Sprintf("the container %s is waiting: %s", status.Name, status.State.Waiting.Reason)
+		}
+		if terminated := status.LastTerminationState.Terminated; terminated != nil && health.LastError == "" && terminated.ExitCode != 0 {
+			health.LastError = fmt.Sprintf("the container %s exited with code %d: %s", status.Name, terminated.ExitCode, terminated.Reason)
+		}
+	}
+	if restarts > 0 {
+		health.Status = fmt.Sprintf("%s, %d restarts", health.Status, restarts)
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			health.Healthy = true
+		}
+	}
+	if !health.Healthy {
+		if health.LastError == "" {
+			health.LastError = "the pod isn't ready"
+		}
+		return health
+	}
+
+	if healthCheck == nil || pod.Status.PodIP == "" || httpClient == nil {
+		return health
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, healthCheck.Port.String()), healthCheck.Path)
+	req, err := http.NewRequestWithContext(checkCtx, http.MethodGet, url, nil)
+	if err != nil {
+		health.Healthy, health.LastError = false, err.Error()
+		return health
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		health.Healthy, health.LastError = false, fmt.Sprintf("the health check failed: %s", err)
+		return health
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		health.Healthy, health.LastError = false, fmt.Sprintf("the health check returned %s", resp.Status)
+	}
+	return health
+}
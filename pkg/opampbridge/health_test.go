@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func managedInstance() *v1alpha1.OpenTelemetryCollector {
+	return &v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "default",
+			Labels:    map[string]string{"opamp": "managed"},
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Mode:   v1alpha1.ModeDeployment,
+			Config: "receivers: {}",
+		},
+	}
+}
+
+func fakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func collectorPod(instance v1alpha1.OpenTelemetryCollector, ready bool, waitingReason string) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-instance-collector-0",
+			Namespace:   instance.Namespace,
+			Labels:      collector.SelectorLabels(instance),
+			Annotations: collector.PodAnnotations(instance),
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "otc-container",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+		}}
+	}
+	return pod
+}
+
+func rolledOutDeployment(updated int32) *appsv1.Deployment {
+	one := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance-collector",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &one,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   updated,
+			AvailableReplicas: 1,
+		},
+	}
+}
+
+func TestCollectorHealth(t *testing.T) {
+	for _, tt := range []struct {
+		desc            string
+		updated         int32
+		ready           bool
+		waitingReason   string
+		healthCheckCode int
+		expectedHealthy bool
+		expectedStatus  string
+		expectedErr     string
+	}{
+		{
+			desc:            "healthy",
+			updated:         1,
+			ready:           true,
+			healthCheckCode: http.StatusOK,
+			expectedHealthy: true,
+			expectedStatus:  "rolled out, 1/1 available",
+		},
+		{
+			desc:            "rolling out",
+			ready:           true,
+			healthCheckCode: http.StatusOK,
+			expectedStatus:  "rolling out, 0/1 updated, 1/1 available",
+		},
+		{
+			desc:            "failed health check",
+			updated:         1,
+			ready:           true,
+			healthCheckCode: http.StatusServiceUnavailable,
+			expectedStatus:  "rolled out, 1/1 available",
+			expectedErr:     "my-instance-collector-0: the health check returned 503 Service Unavailable",
+		},
+		{
+			desc:            "crash looping",
+			updated:         1,
+			waitingReason:   "CrashLoopBackOff",
+			healthCheckCode: http.StatusOK,
+			expectedStatus:  "rolled out, 1/1 available",
+			expectedErr:     "my-instance-collector-0: the container otc-container is waiting: CrashLoopBackOff",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.healthCheckCode)
+			}))
+			defer server.Close()
+			host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			require.NoError(t, err)
+
+			instance := managedInstance()
+			instance.Spec.Config = fmt.Sprintf(`extensions:
+  health_check:
+    endpoint: 0.0.0.0:%s
+service:
+  extensions: [health_check]
+`, port)
+			pod := collectorPod(*instance, tt.ready, tt.waitingReason)
+			pod.Status.PodIP = host
+			c := fakeClient(t, instance, pod, rolledOutDeployment(tt.updated))
+
+			// test
+			health, err := CollectorHealth(context.Background(), c, server.Client(), *instance)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHealthy, health.Healthy)
+			assert.Equal(t, tt.expectedStatus, health.Status)
+			assert.Equal(t, tt.expectedErr, health.LastError)
+			assert.Contains(t, health.Components, "my-instance-collector-0")
+		})
+	}
+}
+
+func TestReports(t *testing.T) {
+	// prepare
+	bridge := bridgeInstance()
+	unmanaged := managedInstance()
+	unmanaged.Name = "unmanaged"
+	unmanaged.Labels = nil
+	c := fakeClient(t, managedInstance(), unmanaged)
+
+	// test
+	reports, err := Reports(context.Background(), c, nil, bridge)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "my-instance", reports[0].Name)
+	assert.Nil(t, reports[0].Health)
+}
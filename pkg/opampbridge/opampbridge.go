@@ -0,0 +1,314 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opampbridge builds the objects deployed for an OpAMPBridge.
+package opampbridge
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// ConfigMapEntry is the key of the bridge config in its ConfigMap.
+const ConfigMapEntry = "remoteconfiguration.yaml"
+
+const configHashAnnotation = "opentelemetry-operator-config/sha256"
+
+// Labels returns the common labels of the objects created for an OpAMPBridge.
+func Labels(bridge v1alpha1.OpAMPBridge) map[string]string {
+	// new map every time, so that we don't touch the instance's label
+	base := map[string]string{}
+	for k, v := range bridge.Labels {
+		base[k] = v
+	}
+	for k, v := range selectorLabels(bridge) {
+		base[k] = v
+	}
+
+	return base
+}
+
+// selectorLabels returns the labels selecting the OpAMP bridge pod, which don't change for the lifetime of the
+// Deployment.
+func selectorLabels(bridge v1alpha1.OpAMPBridge) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, bridge.Namespace, bridge.Name),
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  "opentelemetry-opamp-bridge",
+		"app.kubernetes.io/name":       naming.OpAMPBridge(bridge.Name),
+	}
+}
+
+// headerEnvVar returns the environment variable holding the value of the header at the given index, read from its
+// Secret. The bridge expands the environment variables of its config.
+func headerEnvVar(index int) string {
+	return fmt.Sprintf("OPAMP_HEADER_%d", index)
+}
+
+// ConfigMap builds the config of an OpAMPBridge.
+func ConfigMap(bridge v1alpha1.OpAMPBridge) (corev1.ConfigMap, error) {
+	headers := map[string]string{}
+	for i, header := range bridge.Spec.Headers {
+		headers[header.Name] = fmt.Sprintf("${%s}", headerEnvVar(i))
+	}
+	capabilities := map[string]bool{string(v1alpha1.OpAMPBridgeCapabilityReportsStatus): true}
+	for capability, enabled := range bridge.Spec.Capabilities {
+		capabilities[string(capability)] = enabled
+	}
+
+	// the keys of the config file of the bridge
+	bridgeConfig := map[string]interface{}{
+		"endpoint":     bridge.Spec.Endpoint,
+		"capabilities": capabilities,
+	}
+	if len(headers) > 0 {
+		bridgeConfig["headers"] = headers
+	}
+	if len(bridge.Spec.ComponentsAllowed) > 0 {
+		bridgeConfig["componentsAllowed"] = bridge.Spec.ComponentsAllowed
+	}
+
+	bridgeConfigYAML, err := yaml.Marshal(bridgeConfig)
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+			Labels:    Labels(bridge),
+		},
+		Data: map[string]string{
+			ConfigMapEntry: string(bridgeConfigYAML),
+		},
+	}, nil
+}
+
+// Deployment builds the Deployment of an OpAMPBridge. The pod is rolled out when the config changes.
+func Deployment(cfg config.Config, bridge v1alpha1.OpAMPBridge, configMap corev1.ConfigMap) appsv1.Deployment {
+	labels := Labels(bridge)
+	one := int32(1)
+
+	annotations := map[string]string{}
+	for k, v := range bridge.Spec.PodAnnotations {
+		annotations[k] = v
+	}
+	annotations[configHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256([]byte(configMap.Data[ConfigMapEntry])))
+
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			// the bridge is a single agent for the OpAMP server
+			Replicas: &one,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels(bridge),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: ServiceAccountName(bridge),
+					Containers:         []corev1.Container{Container(cfg, bridge)},
+					Volumes: []corev1.Volume{{
+						Name: naming.OpAMPBridgeConfigVolume(),
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: naming.OpAMPBridge(bridge.Name)},
+								Items: []corev1.KeyToPath{{
+									Key:  ConfigMapEntry,
+									Path: ConfigMapEntry,
+								}},
+							},
+						},
+					}},
+					NodeSelector: bridge.Spec.NodeSelector,
+					Affinity:     bridge.Spec.Affinity,
+					Tolerations:  bridge.Spec.Tolerations,
+				},
+			},
+		},
+	}
+}
+
+// Container builds the container of the OpAMP bridge pod.
+func Container(cfg config.Config, bridge v1alpha1.OpAMPBridge) corev1.Container {
+	image := bridge.Spec.Image
+	if len(image) == 0 {
+		image = cfg.OpAMPBridgeImage()
+	}
+	// in FIPS mode, the FIPS-validated image replaces the one of the bridge
+	if fipsImage, ok := cfg.FIPSImage(config.FIPSImageOpAMPBridge); ok && cfg.FIPS() {
+		image = fipsImage
+	}
+
+	env := []corev1.EnvVar{{
+		Name: "OTELCOL_NAMESPACE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.namespace",
+			},
+		},
+	}}
+	for i := range bridge.Spec.Headers {
+		env = append(env, corev1.EnvVar{
+			Name: headerEnvVar(i),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &bridge.Spec.Headers[i].ValueFrom,
+			},
+		})
+	}
+	env = append(env, bridge.Spec.Env...)
+
+	return corev1.Container{
+		Name:            naming.OpAMPBridgeContainer(),
+		Image:           image,
+		ImagePullPolicy: bridge.Spec.ImagePullPolicy,
+		Resources:       bridge.Spec.Resources,
+		Env:             env,
+		Args:            []string{fmt.Sprintf("--config-file=/conf/%s", ConfigMapEntry)},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.OpAMPBridgeConfigVolume(),
+			MountPath: "/conf",
+		}},
+	}
+}
+
+// ServiceAccountName returns the name of the service account of the OpAMP bridge pod.
+func ServiceAccountName(bridge v1alpha1.OpAMPBridge) string {
+	if len(bridge.Spec.ServiceAccount) == 0 {
+		return naming.OpAMPBridge(bridge.Name)
+	}
+
+	return bridge.Spec.ServiceAccount
+}
+
+// ServiceAccount builds the service account of the OpAMPBridge, used when the spec doesn't set one.
+func ServiceAccount(bridge v1alpha1.OpAMPBridge) corev1.ServiceAccount {
+	return corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+			Labels:    Labels(bridge),
+		},
+	}
+}
+
+// Role builds the permissions of the OpAMP bridge on the collectors of its namespace: it reads them, and changes their
+// config with the AcceptsRemoteConfig capability, without creating or deleting any. It reads their pods and workloads
+// with the ReportsHealth capability. The effective config is the one of the collectors, the ConfigMaps holding the
+// resolved Secret values aren't readable.
+func Role(bridge v1alpha1.OpAMPBridge) rbacv1.Role {
+	capabilities := bridge.Spec.Capabilities
+	verbs := []string{"get", "list", "watch"}
+	if capabilities[v1alpha1.OpAMPBridgeCapabilityAcceptsRemoteConfig] {
+		verbs = append(verbs, "update", "patch")
+	}
+	rules := []rbacv1.PolicyRule{{
+		APIGroups: []string{v1alpha1.GroupVersion.Group},
+		Resources: []string{"opentelemetrycollectors"},
+		Verbs:     verbs,
+	}}
+	var coreResources []string
+	if capabilities[v1alpha1.OpAMPBridgeCapabilityReportsHealth] {
+		coreResources = append(coreResources, "pods")
+	}
+	if len(coreResources) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: coreResources,
+			Verbs:     []string{"get", "list", "watch"},
+		})
+	}
+	if capabilities[v1alpha1.OpAMPBridgeCapabilityReportsHealth] {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments", "statefulsets", "daemonsets"},
+			Verbs:     []string{"get", "list", "watch"},
+		})
+	}
+
+	return rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+			Labels:    Labels(bridge),
+		},
+		Rules: rules,
+	}
+}
+
+// RoleBinding grants the Role to the service account created for the OpAMP bridge.
+func RoleBinding(bridge v1alpha1.OpAMPBridge) rbacv1.RoleBinding {
+	return rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+			Labels:    Labels(bridge),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     naming.OpAMPBridge(bridge.Name),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      naming.OpAMPBridge(bridge.Name),
+			Namespace: bridge.Namespace,
+		}},
+	}
+}
+
+// Manages returns whether the bridge manages the instance.
+func Manages(bridge v1alpha1.OpAMPBridge, otelcol v1alpha1.OpenTelemetryCollector) bool {
+	if otelcol.Namespace != bridge.Namespace {
+		return false
+	}
+	for k, v := range bridge.Spec.Selector {
+		if otelcol.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ManagedCollectors returns the sorted names of the instances managed by the bridge.
+func ManagedCollectors(bridge v1alpha1.OpAMPBridge, instances []v1alpha1.OpenTelemetryCollector) []string {
+	var collectors []string
+	for _, instance := range instances {
+		if Manages(bridge, instance) {
+			collectors = append(collectors, instance.Name)
+		}
+	}
+	sort.Strings(collectors)
+	return collectors
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func bridgeInstance() v1alpha1.OpAMPBridge {
+	return v1alpha1.OpAMPBridge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-bridge",
+			Namespace: "default",
+			Labels:    map[string]string{"team": "observability"},
+		},
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			Endpoint: "wss://opamp.example.com/v1/opamp",
+			Headers: []v1alpha1.OpAMPBridgeHeader{{
+				Name: "Authorization",
+				ValueFrom: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "opamp-credentials"},
+					Key:                  "token",
+				},
+			}},
+			Capabilities: map[v1alpha1.OpAMPBridgeCapability]bool{
+				v1alpha1.OpAMPBridgeCapabilityAcceptsRemoteConfig: true,
+			},
+			ComponentsAllowed: map[string][]string{"receivers": {"otlp"}, "exporters": {"otlp"}},
+			Selector:          map[string]string{"opamp": "managed"},
+		},
+	}
+}
+
+func TestConfigMap(t *testing.T) {
+	// prepare
+	bridge := bridgeInstance()
+
+	// test
+	cm, err := ConfigMap(bridge)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "my-bridge-opamp-bridge", cm.Name)
+	assert.Equal(t, "observability", cm.Labels["team"])
+
+	bridgeConfig := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[ConfigMapEntry]), &bridgeConfig))
+	assert.Equal(t, "wss://opamp.example.com/v1/opamp", bridgeConfig["endpoint"])
+	assert.Equal(t, map[interface{}]interface{}{"Authorization": "${OPAMP_HEADER_0}"}, bridgeConfig["headers"])
+	assert.Equal(t, map[interface{}]interface{}{
+		"ReportsStatus":       true,
+		"AcceptsRemoteConfig": true,
+	}, bridgeConfig["capabilities"])
+	assert.Equal(t, map[interface{}]interface{}{
+		"receivers": []interface{}{"otlp"},
+		"exporters": []interface{}{"otlp"},
+	}, bridgeConfig["componentsAllowed"])
+	// only the keys of the config of the bridge are set
+	assert.Len(t, bridgeConfig, 4)
+}
+
+func TestDeployment(t *testing.T) {
+	// prepare
+	bridge := bridgeInstance()
+	cfg := config.New(config.WithOpAMPBridgeImage("opamp-bridge:0.0.1"))
+	cm, err := ConfigMap(bridge)
+	require.NoError(t, err)
+
+	// test
+	d := Deployment(cfg, bridge, cm)
+
+	// verify
+	assert.Equal(t, "my-bridge-opamp-bridge", d.Name)
+	assert.NotContains(t, d.Spec.Selector.MatchLabels, "team")
+	assert.Equal(t, "observability", d.Spec.Template.Labels["team"])
+	assert.NotEmpty(t, d.Spec.Template.Annotations[configHashAnnotation])
+	assert.Equal(t, "my-bridge-opamp-bridge", d.Spec.Template.Spec.ServiceAccountName)
+
+	require.Len(t, d.Spec.Template.Spec.Containers, 1)
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "opamp-bridge:0.0.1", container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:      "OPAMP_HEADER_0",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &bridge.Spec.Headers[0].ValueFrom},
+	})
+
+	// in FIPS mode, the bridge runs its FIPS-validated image
+	fipsCfg := config.New(
+		config.WithOpAMPBridgeImage("opamp-bridge:0.0.1"),
+		config.WithFIPS(true),
+		config.WithFIPSImages(map[string]string{config.FIPSImageOpAMPBridge: "opamp-bridge-fips"}),
+	)
+	assert.Equal(t, "opamp-bridge-fips", Deployment(fipsCfg, bridge, cm).Spec.Template.Spec.Containers[0].Image)
+
+	// a config change rolls out the pod
+	bridge.Spec.Endpoint = "wss://other.example.com/v1/opamp"
+	changed, err := ConfigMap(bridge)
+	require.NoError(t, err)
+	assert.NotEqual(t, d.Spec.Template.Annotations[configHashAnnotation], Deployment(cfg, bridge, changed).Spec.Template.Annotations[configHashAnnotation])
+}
+
+func TestServiceAccountName(t *testing.T) {
+	bridge := bridgeInstance()
+	assert.Equal(t, "my-bridge-opamp-bridge", ServiceAccountName(bridge))
+
+	bridge.Spec.ServiceAccount = "my-service-account"
+	assert.Equal(t, "my-service-account", ServiceAccountName(bridge))
+}
+
+func TestRole(t *testing.T) {
+	for _, tt := range []struct {
+		desc          string
+		capabilities  map[v1alpha1.OpAMPBridgeCapability]bool
+		expectedVerbs []string
+		expectedRules int
+	}{
+		{
+			desc:          "reports status only",
+			expectedVerbs: []string{"get", "list", "watch"},
+			expectedRules: 1,
+		},
+		{
+			desc:          "accepts remote config",
+			capabilities:  map[v1alpha1.OpAMPBridgeCapability]bool{v1alpha1.OpAMPBridgeCapabilityAcceptsRemoteConfig: true},
+			expectedVerbs: []string{"get", "list", "watch", "update", "patch"},
+			expectedRules: 1,
+		},
+		{
+			desc:          "reports health",
+			capabilities:  map[v1alpha1.OpAMPBridgeCapability]bool{v1alpha1.OpAMPBridgeCapabilityReportsHealth: true},
+			expectedVerbs: []string{"get", "list", "watch"},
+			expectedRules: 3,
+		},
+		{
+			desc:          "reports effective config",
+			capabilities:  map[v1alpha1.OpAMPBridgeCapability]bool{v1alpha1.OpAMPBridgeCapabilityReportsEffectiveConfig: true},
+			expectedVerbs: []string{"get", "list", "watch"},
+			expectedRules: 1,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			bridge := bridgeInstance()
+			bridge.Spec.Capabilities = tt.capabilities
+
+			// test
+			role := Role(bridge)
+
+			// verify
+			require.Len(t, role.Rules, tt.expectedRules)
+			assert.Equal(t, []string{"opentelemetrycollectors"}, role.Rules[0].Resources)
+			assert.Equal(t, tt.expectedVerbs, role.Rules[0].Verbs)
+		})
+	}
+}
+
+func TestRoleBinding(t *testing.T) {
+	binding := RoleBinding(bridgeInstance())
+	assert.Equal(t, "my-bridge-opamp-bridge", binding.RoleRef.Name)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "my-bridge-opamp-bridge", binding.Subjects[0].Name)
+}
+
+func TestManagedCollectors(t *testing.T) {
+	// prepare
+	bridge := bridgeInstance()
+	instances := []v1alpha1.OpenTelemetryCollector{
+		{ObjectMeta: metav1.ObjectMeta{Name: "managed-b", Namespace: "default", Labels: map[string]string{"opamp": "managed"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "managed-a", Namespace: "default", Labels: map[string]string{"opamp": "managed"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other", Labels: map[string]string{"opamp": "managed"}}},
+	}
+
+	// test
+	collectors := ManagedCollectors(bridge, instances)
+
+	// verify
+	assert.Equal(t, []string{"managed-a", "managed-b"}, collectors)
+}
@@ -15,6 +15,7 @@
 package sidecar
 
 import (
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +24,18 @@ import (
 const (
 	// Annotation contains the annotation name that pods contain, indicating whether a sidecar is desired.
 	Annotation = "sidecar.opentelemetry.io/inject"
+
+	// AnnotationCollectorConfig contains the annotation name holding the collector config of the pod's sidecar, in place
+	// of the config of the OpenTelemetry Collector instance.
+	AnnotationCollectorConfig = "sidecar.opentelemetry.io/collector-config"
+
+	// AnnotationNativeSidecar contains the annotation name telling whether the pod's sidecar is injected as a native
+	// sidecar, in place of the nativeSidecar of the OpenTelemetry Collector instance.
+	AnnotationNativeSidecar = "sidecar.opentelemetry.io/native"
+
+	// AnnotationInjectedGenerations contains the annotation name holding the generation of the OpenTelemetry Collector
+	// instance the pod's sidecar is injected from.
+	AnnotationInjectedGenerations = "sidecar.opentelemetry.io/injected-generations"
 )
 
 // annotationValue returns the effective annotation value, based on the annotations from the pod and namespace.
@@ -56,3 +69,12 @@ func annotationValue(ns corev1.Namespace, pod corev1.Pod) string {
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// nativeSidecar returns whether the pod's sidecar is injected as a native sidecar, the pod's AnnotationNativeSidecar
+// taking precedence over the instance.
+func nativeSidecar(pod corev1.Pod, instanceValue bool) bool {
+	if native, err := strconv.ParseBool(pod.Annotations[AnnotationNativeSidecar]); err == nil {
+		return native
+	}
+	return instanceValue
+}
@@ -140,3 +140,22 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestNativeSidecar(t *testing.T) {
+	for _, tt := range []struct {
+		desc          string
+		annotations   map[string]string
+		instanceValue bool
+		expected      bool
+	}{
+		{"instance-value-without-annotation", nil, true, true},
+		{"pod-true-overrides-instance", map[string]string{AnnotationNativeSidecar: "true"}, false, true},
+		{"pod-false-overrides-instance", map[string]string{AnnotationNativeSidecar: "false"}, true, false},
+		{"invalid-annotation-is-ignored", map[string]string{AnnotationNativeSidecar: "yes please"}, true, true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			assert.Equal(t, tt.expected, nativeSidecar(pod, tt.instanceValue))
+		})
+	}
+}
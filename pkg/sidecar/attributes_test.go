@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	appv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -146,6 +147,44 @@ func TestGetAttributesEnvWithPodReferences(t *testing.T) {
 	assert.Equal(t, expectedEnv, envs)
 }
 
+func TestGetAttributesEnvWithJobReferences(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-ns",
+		},
+	}
+	references := podReferences{
+		job: &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-job",
+				UID:  "uuid-job",
+			},
+		},
+		cronjob: &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cronjob",
+				UID:  "uuid-cronjob",
+			},
+		},
+	}
+	envs := getResourceAttributesEnv(ns, references)
+
+	expectedValue := fmt.Sprintf("%s=my-cronjob,%s=uuid-cronjob,%s=my-job,%s=uuid-job,%s=my-ns,%s=$(%s),%s=$(%s),%s=$(%s)",
+		semconv.K8SCronJobNameKey,
+		semconv.K8SCronJobUIDKey,
+		semconv.K8SJobNameKey,
+		semconv.K8SJobUIDKey,
+		semconv.K8SNamespaceNameKey,
+		semconv.K8SNodeNameKey,
+		constants.EnvNodeName,
+		semconv.K8SPodNameKey,
+		constants.EnvPodName,
+		semconv.K8SPodUIDKey,
+		constants.EnvPodUID,
+	)
+	assert.Equal(t, corev1.EnvVar{Name: resourceAttributesEnvName, Value: expectedValue}, envs[len(envs)-1])
+}
+
 func TestHasResourceAttributeEnvVar(t *testing.T) {
 	for _, tt := range []struct {
 		desc     string
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// envCollectorConfig is the environment variable holding the collector config overridden by the pod.
+const envCollectorConfig = "OTEL_COLLECTOR_CONFIG"
+
+var errInvalidConfigOverride = errors.New("the collector config override of the pod is invalid")
+
+// validateConfigOverride verifies that the config is a collector config whose pipelines only use defined components.
+func validateConfigOverride(config string) error {
+	cfg, err := adapters.ConfigFromString(config)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidConfigOverride, err)
+	}
+
+	service, ok := cfg["service"].(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("%w: no service section", errInvalidConfigOverride)
+	}
+	pipelines, ok := service["pipelines"].(map[interface{}]interface{})
+	if !ok || len(pipelines) == 0 {
+		return fmt.Errorf("%w: no pipelines", errInvalidConfigOverride)
+	}
+
+	for pipelineName, pipeline := range pipelines {
+		pipelineCfg, ok := pipeline.(map[interface{}]interface{})
+		if !ok {
+			return fmt.Errorf("%w: pipeline %v is not a map", errInvalidConfigOverride, pipelineName)
+		}
+		for _, kind := range []string{"receivers", "processors", "exporters"} {
+			components, _ := cfg[kind].(map[interface{}]interface{})
+			names, _ := pipelineCfg[kind].([]interface{})
+			if kind != "processors" && len(names) == 0 {
+				return fmt.Errorf("%w: pipeline %v has no %s", errInvalidConfigOverride, pipelineName, kind)
+			}
+			for _, name := range names {
+				if _, ok := components[name]; !ok {
+					return fmt.Errorf("%w: pipeline %v references the undefined %s %v", errInvalidConfigOverride, pipelineName, kind, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// withConfigOverride passes the config to the sidecar in an environment variable, in place of the instance's config map.
+func withConfigOverride(config string, container corev1.Container, volumes []corev1.Volume) (corev1.Container, []corev1.Volume) {
+	for i, arg := range container.Args {
+		if strings.HasPrefix(arg, "--config=") {
+			container.Args[i] = fmt.Sprintf("--config=env:%s", envCollectorConfig)
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  envCollectorConfig,
+		Value: config,
+	})
+
+	var mounts []corev1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if mount.Name != naming.ConfigMapVolume() {
+			mounts = append(mounts, mount)
+		}
+	}
+	container.VolumeMounts = mounts
+
+	var withoutConfigMap []corev1.Volume
+	for _, volume := range volumes {
+		if volume.Name != naming.ConfigMapVolume() {
+			withoutConfigMap = append(withoutConfigMap, volume)
+		}
+	}
+	return container, withoutConfigMap
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidecar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigOverride(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config string
+		err    string
+	}{
+		{
+			desc: "valid",
+			config: `receivers:
+  otlp:
+processors:
+  batch:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+`,
+		},
+		{
+			desc:   "not yaml",
+			config: "receivers: [",
+			err:    "the collector config override of the pod is invalid",
+		},
+		{
+			desc: "no pipelines",
+			config: `receivers:
+  otlp:
+service:
+  extensions: []
+`,
+			err: "no pipelines",
+		},
+		{
+			desc: "no exporters",
+			config: `receivers:
+  otlp:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`,
+			err: "pipeline traces has no exporters",
+		},
+		{
+			desc: "undefined processor",
+			config: `receivers:
+  otlp:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+`,
+			err: "pipeline traces references the undefined processors batch",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := validateConfigOverride(tt.config)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
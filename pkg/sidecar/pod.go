@@ -23,7 +23,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
@@ -32,20 +34,45 @@ const (
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
+// The collector config can be overridden by the pod's AnnotationCollectorConfig.
 func add(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
+	configOverride, overridden := pod.Annotations[AnnotationCollectorConfig]
+	if overridden {
+		otelcol.Spec.Config = configOverride
+	}
+
+	if instrumentation.IsWindowsPod(pod) {
+		// the collector of a Windows pod is built like the collectors running on Windows nodes
+		otelcol.Spec.NodeSelector = map[string]string{corev1.LabelOSStable: string(corev1.Windows)}
+	}
+
 	// add the container
 	volumes := collector.Volumes(cfg, otelcol)
 	container := collector.Container(cfg, logger, otelcol)
+	if overridden {
+		container, volumes = withConfigOverride(configOverride, container, volumes)
+	}
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
-	pod.Spec.Containers = append(pod.Spec.Containers, container)
+	if otelcol.Spec.NativeSidecar {
+		// the restart policy of the init container is set by the webhook, the core API of the operator doesn't have it
+		pod.Spec.InitContainers = append([]corev1.Container{container}, pod.Spec.InitContainers...)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, container)
+	}
 	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
 	}
 	pod.Labels[label] = fmt.Sprintf("%s.%s", otelcol.Namespace, otelcol.Name)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationInjectedGenerations] = webhookhandler.InjectedGenerations{
+		{Namespace: otelcol.Namespace, Name: otelcol.Name}: otelcol.Generation,
+	}.String()
 
 	return pod, nil
 }
@@ -56,19 +83,28 @@ func remove(pod corev1.Pod) (corev1.Pod, error) {
 		return pod, nil
 	}
 
-	var containers []corev1.Container
-	for _, container := range pod.Spec.Containers {
+	pod.Spec.Containers = withoutSidecar(pod.Spec.Containers)
+	pod.Spec.InitContainers = withoutSidecar(pod.Spec.InitContainers)
+	return pod, nil
+}
+
+func withoutSidecar(containers []corev1.Container) []corev1.Container {
+	var filtered []corev1.Container
+	for _, container := range containers {
 		if container.Name != naming.Container() {
-			containers = append(containers, container)
+			filtered = append(filtered, container)
 		}
 	}
-	pod.Spec.Containers = containers
-	return pod, nil
+	return filtered
 }
 
-// existsIn checks whether a sidecar container exists in the given pod.
+// existsIn checks whether a sidecar container, or a native sidecar init container, exists in the given pod.
 func existsIn(pod corev1.Pod) bool {
-	for _, container := range pod.Spec.Containers {
+	return hasSidecar(pod.Spec.Containers) || hasSidecar(pod.Spec.InitContainers)
+}
+
+func hasSidecar(containers []corev1.Container) bool {
+	for _, container := range containers {
 		if container.Name == naming.Container() {
 			return true
 		}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,8 +43,9 @@ func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 	}
 	otelcol := v1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "otelcol-sample",
-			Namespace: "some-app",
+			Name:       "otelcol-sample",
+			Namespace:  "some-app",
+			Generation: 2,
 		},
 	}
 	cfg := config.New(config.WithCollectorImage("some-default-image"))
@@ -56,6 +58,177 @@ func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 2)
 	assert.Len(t, changed.Spec.Volumes, 2)
 	assert.Equal(t, "some-app.otelcol-sample", changed.Labels["sidecar.opentelemetry.io/injected"])
+	assert.Equal(t, "some-app/otelcol-sample=2", changed.Annotations[AnnotationInjectedGenerations])
+}
+
+func TestAddSidecarWithTokenAndCSIVolumes(t *testing.T) {
+	// prepare
+	expiration := int64(3600)
+	readOnly := true
+	token := corev1.Volume{
+		Name: "vault-token",
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          "vault",
+				ExpirationSeconds: &expiration,
+				Path:              "token",
+			}}},
+		}},
+	}
+	certs := corev1.Volume{
+		Name: "vault-certs",
+		VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+			Driver:           "secrets-store.csi.k8s.io",
+			ReadOnly:         &readOnly,
+			VolumeAttributes: map[string]string{"secretProviderClass": "vault-certs"},
+		}},
+	}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Volumes: []corev1.Volume{token, certs},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "vault-token", MountPath: "/var/run/secrets/vault"},
+				{Name: "vault-certs", MountPath: "/etc/vault-certs", ReadOnly: true},
+			},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	require.NoError(t, err)
+	assert.Contains(t, changed.Spec.Volumes, token)
+	assert.Contains(t, changed.Spec.Volumes, certs)
+	require.Len(t, changed.Spec.Containers, 2)
+	assert.Contains(t, changed.Spec.Containers[1].VolumeMounts, corev1.VolumeMount{Name: "vault-token", MountPath: "/var/run/secrets/vault"})
+	assert.Contains(t, changed.Spec.Containers[1].VolumeMounts, corev1.VolumeMount{Name: "vault-certs", MountPath: "/etc/vault-certs", ReadOnly: true})
+}
+
+func TestAddNativeSidecar(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "my-init"},
+			},
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			NativeSidecar: true,
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 1)
+	require.Len(t, changed.Spec.InitContainers, 2)
+	assert.Equal(t, naming.Container(), changed.Spec.InitContainers[0].Name)
+	assert.Equal(t, "my-init", changed.Spec.InitContainers[1].Name)
+	assert.True(t, existsIn(changed))
+}
+
+func TestAddSidecarToWindowsPod(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			OS: &corev1.PodOS{Name: corev1.Windows},
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 2)
+	assert.Equal(t, `C:\conf`, changed.Spec.Containers[1].VolumeMounts[0].MountPath)
+}
+
+func TestAddSidecarWithConfigOverride(t *testing.T) {
+	// prepare
+	override := `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				AnnotationCollectorConfig: override,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			Config: "receivers: {jaeger: {}}",
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 2)
+	assert.Empty(t, changed.Spec.Volumes)
+
+	sidecar := changed.Spec.Containers[1]
+	assert.Contains(t, sidecar.Args, "--config=env:OTEL_COLLECTOR_CONFIG")
+	assert.Contains(t, sidecar.Env, corev1.EnvVar{Name: "OTEL_COLLECTOR_CONFIG", Value: override})
+	assert.Empty(t, sidecar.VolumeMounts)
+	assert.Contains(t, sidecar.Ports, corev1.ContainerPort{Name: "otlp-grpc", ContainerPort: 4317})
 }
 
 // this situation should never happen in the current code path, but it should not fail
@@ -101,6 +274,30 @@ func TestRemoveSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveNativeSidecar(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: naming.Container()},
+				{Name: "my-init"},
+			},
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+
+	// test
+	changed, err := remove(pod)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 1)
+	require.Len(t, changed.Spec.InitContainers, 1)
+	assert.Equal(t, "my-init", changed.Spec.InitContainers[0].Name)
+}
+
 func TestRemoveNonExistingSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,6 +30,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhookhandler"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
 )
 
 var (
@@ -89,10 +91,31 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 		return pod, err
 	}
 
+	config, err := adapters.ConfigFromTemplate(otelcol.Spec.Config, otelcol)
+	if err != nil {
+		logger.Error(err, "failed to evaluate the config template of the OpenTelemetry Collector instance for this pod's sidecar")
+		return pod, nil
+	}
+	otelcol.Spec.Config = config
+
+	if configOverride, ok := pod.Annotations[AnnotationCollectorConfig]; ok {
+		if err := validateConfigOverride(configOverride); err != nil {
+			// we still allow the pod to be created, but without a sidecar running an unexpected pipeline
+			logger.Error(err, "failed to inject the sidecar with the pod's collector config")
+			return pod, nil
+		}
+	}
+
 	// getting pod references, if any
 	references := p.podReferences(ctx, pod.OwnerReferences, ns)
 	attributes := getResourceAttributesEnv(ns, references)
 
+	otelcol.Spec.NativeSidecar = nativeSidecar(pod, otelcol.Spec.NativeSidecar)
+	if references.job != nil && !otelcol.Spec.NativeSidecar {
+		logger.Info("the pod is owned by a job and its sidecar isn't native, the sidecar will keep the job from completing",
+			"job", references.job.Name)
+	}
+
 	// once it's been determined that a sidecar is desired, none exists yet, and we know which instance it should talk to,
 	// we should add the sidecar.
 	logger.V(1).Info("injecting sidecar into pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
@@ -155,6 +178,14 @@ func (p *sidecarPodMutator) podReferences(ctx context.Context, ownerReferences [
 			references.deployment = deployment
 		}
 	}
+	job := p.getJobReference(ctx, ownerReferences, ns)
+	if job != nil {
+		references.job = job
+		cronjob := p.getCronJobReference(ctx, job)
+		if cronjob != nil {
+			references.cronjob = cronjob
+		}
+	}
 	return *references
 }
 
@@ -182,6 +213,30 @@ func (p *sidecarPodMutator) getDeploymentReference(ctx context.Context, replicaS
 	return nil
 }
 
+func (p *sidecarPodMutator) getJobReference(ctx context.Context, ownerReferences []metav1.OwnerReference, ns corev1.Namespace) *batchv1.Job {
+	jobName := findOwnerReferenceKind(ownerReferences, "Job")
+	if jobName != "" {
+		job := &batchv1.Job{}
+		err := p.client.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)
+		if err == nil {
+			return job
+		}
+	}
+	return nil
+}
+
+func (p *sidecarPodMutator) getCronJobReference(ctx context.Context, job *batchv1.Job) *batchv1.CronJob {
+	cronJobName := findOwnerReferenceKind(job.OwnerReferences, "CronJob")
+	if cronJobName != "" {
+		cronjob := &batchv1.CronJob{}
+		err := p.client.Get(ctx, types.NamespacedName{Name: cronJobName, Namespace: job.Namespace}, cronjob)
+		if err == nil {
+			return cronjob
+		}
+	}
+	return nil
+}
+
 func findOwnerReferenceKind(references []metav1.OwnerReference, kind string) string {
 	for _, reference := range references {
 		if reference.Kind == kind {
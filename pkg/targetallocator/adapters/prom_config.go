@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	promconfig "github.com/prometheus/prometheus/config"
+	_ "github.com/prometheus/prometheus/discovery/install" // Package install has the side-effect of registering all builtin.
+	"gopkg.in/yaml.v2"
+)
+
+// captureGroupPattern matches the references to the regex capture groups, along with the dollar signs before them.
+var captureGroupPattern = regexp.MustCompile(`(\$*)\$(\{[0-9]+\}|[0-9]+)`)
+
+// relabelFields are the fields of the relabel configs supporting the references to the regex capture groups, e.g. $1.
+var relabelFields = []string{"replacement", "target_label"}
+
+// ValidatePromConfig checks the Prometheus config of the prometheus receiver, as part of the collector config. The
+// relabel configs can't reference the regex capture groups with a single dollar sign, which the collector would expand
+// as an environment variable.
+func ValidatePromConfig(promCfg map[interface{}]interface{}) error {
+	scrapeConfigs, _ := promCfg["scrape_configs"].([]interface{})
+	for _, sc := range scrapeConfigs {
+		scrapeConfig, ok := sc.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"relabel_configs", "metric_relabel_configs"} {
+			relabelConfigs, _ := scrapeConfig[key].([]interface{})
+			for i, rc := range relabelConfigs {
+				relabelConfig, ok := rc.(map[interface{}]interface{})
+				if !ok {
+					continue
+				}
+				for _, field := range relabelFields {
+					value, _ := relabelConfig[field].(string)
+					if ref := unescapedCaptureGroup(value); ref != "" {
+						return fmt.Errorf("the %s %d of the job %v references %s in its %s, which the collector expands as an environment variable, use $%s instead",
+							key, i, scrapeConfig["job_name"], ref, field, ref)
+					}
+				}
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(map[string]interface{}{
+		"config": UnescapeDollarSigns(promCfg),
+	})
+	if err != nil {
+		return err
+	}
+	var cfg struct {
+		PromConfig *promconfig.Config `yaml:"config"`
+	}
+	if err := yaml.UnmarshalStrict(out, &cfg); err != nil {
+		return fmt.Errorf("the prometheus config is incorrect: %w", err)
+	}
+	return nil
+}
+
+// unescapedCaptureGroup returns the first reference to a regex capture group with a single dollar sign, e.g. $1 or
+// ${1}, which isn't escaped by the dollar signs before it.
+func unescapedCaptureGroup(value string) string {
+	for _, match := range captureGroupPattern.FindAllStringSubmatch(value, -1) {
+		if len(match[1])%2 == 0 {
+			return "$" + match[2]
+		}
+	}
+	return ""
+}
+
+// UnescapeDollarSigns returns a copy of the Prometheus config of the prometheus receiver with the dollar signs escaped
+// for the collector, e.g. $$1, replaced by single ones, as the target allocator reads them.
+func UnescapeDollarSigns(promCfg map[interface{}]interface{}) map[interface{}]interface{} {
+	return replaceInStrings(promCfg, "$$", "$").(map[interface{}]interface{})
+}
+
+// EscapeDollarSigns returns a copy of a plain Prometheus config with its dollar signs escaped, so that the collector
+// passes them to the prometheus receiver instead of expanding them as environment variables.
+func EscapeDollarSigns(promCfg map[interface{}]interface{}) map[interface{}]interface{} {
+	return replaceInStrings(promCfg, "$", "$$").(map[interface{}]interface{})
+}
+
+func replaceInStrings(value interface{}, old, new string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, old, new)
+	case map[interface{}]interface{}:
+		replaced := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			replaced[key] = replaceInStrings(item, old, new)
+		}
+		return replaced
+	case []interface{}:
+		replaced := make([]interface{}, len(v))
+		for i, item := range v {
+			replaced[i] = replaceInStrings(item, old, new)
+		}
+		return replaced
+	}
+	return value
+}
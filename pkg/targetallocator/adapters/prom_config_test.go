@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	ta "github.com/open-telemetry/opentelemetry-operator/pkg/targetallocator/adapters"
+)
+
+func TestValidatePromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name: "escaped capture groups",
+			config: `scrape_configs:
+- job_name: app
+  relabel_configs:
+  - source_labels: [__address__]
+    regex: (.+):(\d+)
+    target_label: host
+    replacement: $$1-$${2}
+`,
+		},
+		{
+			name: "capture group expanded by the collector",
+			config: `scrape_configs:
+- job_name: app
+  relabel_configs:
+  - source_labels: [__address__]
+    regex: (.+):\d+
+    target_label: host
+    replacement: $$$1
+`,
+			expectedErr: "the relabel_configs 0 of the job app references $1 in its replacement",
+		},
+		{
+			name: "braced capture group expanded by the collector",
+			config: `scrape_configs:
+- job_name: app
+  metric_relabel_configs:
+  - source_labels: [__name__]
+    regex: (.+)
+    target_label: ${1}_name
+`,
+			expectedErr: "the metric_relabel_configs 0 of the job app references ${1} in its target_label",
+		},
+		{
+			name: "invalid regex",
+			config: `scrape_configs:
+- job_name: app
+  relabel_configs:
+  - source_labels: [__address__]
+    regex: (.+
+    action: keep
+`,
+			expectedErr: "the prometheus config is incorrect",
+		},
+		{
+			name: "unknown field",
+			config: `scrape_config:
+  job_name: app
+`,
+			expectedErr: "field scrape_config not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			promCfg, err := adapters.ConfigFromString(tt.config)
+			assert.NoError(t, err)
+
+			// test
+			err = ta.ValidatePromConfig(promCfg)
+
+			// verify
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestEscapeDollarSigns(t *testing.T) {
+	// prepare
+	promCfg := map[interface{}]interface{}{
+		"scrape_configs": []interface{}{
+			map[interface{}]interface{}{
+				"job_name":        "app",
+				"scrape_interval": 10,
+				"relabel_configs": []interface{}{
+					map[interface{}]interface{}{"replacement": "${1}-$2"},
+				},
+			},
+		},
+	}
+
+	// test
+	escaped := ta.EscapeDollarSigns(promCfg)
+
+	// verify
+	assert.Equal(t, map[interface{}]interface{}{
+		"scrape_configs": []interface{}{
+			map[interface{}]interface{}{
+				"job_name":        "app",
+				"scrape_interval": 10,
+				"relabel_configs": []interface{}{
+					map[interface{}]interface{}{"replacement": "$${1}-$$2"},
+				},
+			},
+		},
+	}, escaped)
+	assert.Equal(t, promCfg, ta.UnescapeDollarSigns(escaped))
+	assert.Equal(t, "${1}-$2", promCfg["scrape_configs"].([]interface{})[0].(map[interface{}]interface{})["relabel_configs"].([]interface{})[0].(map[interface{}]interface{})["replacement"])
+}
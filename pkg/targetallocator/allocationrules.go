@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// AllocationRules returns the allocation_rules entry of the TargetAllocator config, nil when there are no rules.
+func AllocationRules(rules []v1alpha1.TargetAllocatorAllocationRule) []map[string]interface{} {
+	if len(rules) == 0 {
+		return nil
+	}
+	config := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		ruleConfig := map[string]interface{}{
+			"name": rule.Name,
+			"jobs": rule.Jobs,
+		}
+		if len(rule.Action) > 0 {
+			ruleConfig["action"] = rule.Action
+		}
+		if len(rule.AllocationStrategy) > 0 {
+			ruleConfig["allocation_strategy"] = rule.AllocationStrategy
+		}
+		if len(rule.Collectors) > 0 {
+			ruleConfig["collectors"] = rule.Collectors
+		}
+		config = append(config, ruleConfig)
+	}
+	return config
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestAllocationRules(t *testing.T) {
+	// prepare
+	rules := []v1alpha1.TargetAllocatorAllocationRule{
+		{
+			Name:               "federation",
+			Jobs:               []string{"federate-.*"},
+			AllocationStrategy: v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+			Collectors:         "my-instance-collector-[01]",
+		},
+		{Name: "probes", Jobs: []string{"blackbox"}, Action: v1alpha1.TargetAllocatorAllocationRuleActionDrop},
+	}
+
+	// test
+	config := AllocationRules(rules)
+
+	// verify
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"name":                "federation",
+			"jobs":                []string{"federate-.*"},
+			"allocation_strategy": v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+			"collectors":          "my-instance-collector-[01]",
+		},
+		{
+			"name":   "probes",
+			"jobs":   []string{"blackbox"},
+			"action": v1alpha1.TargetAllocatorAllocationRuleActionDrop,
+		},
+	}, config)
+}
+
+func TestAllocationRulesEmpty(t *testing.T) {
+	assert.Nil(t, AllocationRules(nil))
+}
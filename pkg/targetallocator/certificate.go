@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// CertificateGVK is the kind of the cert-manager Certificates. The cert-manager API isn't a dependency of the
+// operator, the Certificates are handled as unstructured objects.
+var CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// TLSMountPath is the directory the TargetAllocator server certificate, and the collector client certificate, are
+// mounted to.
+const TLSMountPath = "/etc/targetallocator-tls"
+
+// TLSCAKey is the key of the CA certificate in the TargetAllocator certificate secrets, like in the ones of cert-manager.
+const TLSCAKey = "ca.crt"
+
+type certificateSpec struct {
+	SecretName string                        `json:"secretName"`
+	CommonName string                        `json:"commonName"`
+	DNSNames   []string                      `json:"dnsNames,omitempty"`
+	Usages     []string                      `json:"usages"`
+	IssuerRef  v1alpha1.CertificateIssuerRef `json:"issuerRef"`
+	PrivateKey certificatePrivateKey         `json:"privateKey"`
+}
+
+type certificatePrivateKey struct {
+	Algorithm      string `json:"algorithm"`
+	RotationPolicy string `json:"rotationPolicy"`
+}
+
+// UsesMTLS returns whether the TargetAllocator of the instance serves the collector over mutual TLS.
+func UsesMTLS(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.TargetAllocator.Enabled && otelcol.Spec.TargetAllocator.MTLS != nil
+}
+
+// ServerDNSNames returns the names the collector can reach the TargetAllocator Service with.
+func ServerDNSNames(otelcol v1alpha1.OpenTelemetryCollector) []string {
+	service := naming.TAService(otelcol)
+	return []string{
+		service,
+		fmt.Sprintf("%s.%s", service, otelcol.Namespace),
+		fmt.Sprintf("%s.%s.svc", service, otelcol.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, otelcol.Namespace),
+	}
+}
+
+// Certificates returns the cert-manager Certificates of the TargetAllocator server and of the collector client, issued
+// by the issuer of the instance. cert-manager renews them, along with their private key, before they expire.
+func Certificates(otelcol v1alpha1.OpenTelemetryCollector) ([]unstructured.Unstructured, error) {
+	issuerRef := *otelcol.Spec.TargetAllocator.MTLS.IssuerRef
+	privateKey := certificatePrivateKey{Algorithm: "ECDSA", RotationPolicy: "Always"}
+
+	server, err := certificate(otelcol, naming.TAServerCertificate(otelcol), certificateSpec{
+		SecretName: naming.TAServerCertificate(otelcol),
+		CommonName: naming.TAService(otelcol),
+		DNSNames:   ServerDNSNames(otelcol),
+		Usages:     []string{"digital signature", "key encipherment", "server auth"},
+		IssuerRef:  issuerRef,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client, err := certificate(otelcol, naming.TAClientCertificate(otelcol), certificateSpec{
+		SecretName: naming.TAClientCertificate(otelcol),
+		CommonName: naming.Collector(otelcol),
+		Usages:     []string{"digital signature", "key encipherment", "client auth"},
+		IssuerRef:  issuerRef,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []unstructured.Unstructured{*server, *client}, nil
+}
+
+func certificate(otelcol v1alpha1.OpenTelemetryCollector, name string, spec certificateSpec) (*unstructured.Unstructured, error) {
+	labels := Labels(otelcol)
+	labels["app.kubernetes.io/name"] = name
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the Certificate spec: %w", err)
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertificateGVK)
+	cert.SetName(name)
+	cert.SetNamespace(otelcol.Namespace)
+	cert.SetLabels(labels)
+	cert.Object["spec"] = content
+	return cert, nil
+}
+
+// TLSArgs returns the arguments making the TargetAllocator serve over mutual TLS with its mounted certificate.
+func TLSArgs() []string {
+	return []string{
+		fmt.Sprintf("--tls-cert-file=%s/%s", TLSMountPath, corev1.TLSCertKey),
+		fmt.Sprintf("--tls-key-file=%s/%s", TLSMountPath, corev1.TLSPrivateKeyKey),
+		fmt.Sprintf("--tls-client-ca-file=%s/%s", TLSMountPath, TLSCAKey),
+	}
+}
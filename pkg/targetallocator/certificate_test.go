@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestCertificates(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+				MTLS: &v1alpha1.TargetAllocatorMTLS{
+					IssuerRef: &v1alpha1.CertificateIssuerRef{Name: "my-issuer", Kind: "ClusterIssuer"},
+				},
+			},
+		},
+	}
+
+	// test
+	certificates, err := Certificates(otelcol)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+
+	server := certificates[0]
+	assert.Equal(t, CertificateGVK, server.GroupVersionKind())
+	assert.Equal(t, "my-instance-targetallocator-server", server.GetName())
+	assert.Equal(t, "observability", server.GetNamespace())
+	assert.Equal(t, "my-instance-targetallocator-server", server.GetLabels()["app.kubernetes.io/name"])
+	assert.Equal(t, "my-instance-targetallocator-server", server.Object["spec"].(map[string]interface{})["secretName"])
+	assert.Equal(t, []interface{}{
+		"my-instance-targetallocator",
+		"my-instance-targetallocator.observability",
+		"my-instance-targetallocator.observability.svc",
+		"my-instance-targetallocator.observability.svc.cluster.local",
+	}, server.Object["spec"].(map[string]interface{})["dnsNames"])
+	assert.Contains(t, server.Object["spec"].(map[string]interface{})["usages"], "server auth")
+	assert.Equal(t, map[string]interface{}{"name": "my-issuer", "kind": "ClusterIssuer"}, server.Object["spec"].(map[string]interface{})["issuerRef"])
+
+	client := certificates[1]
+	assert.Equal(t, "my-instance-targetallocator-client", client.GetName())
+	assert.Equal(t, "my-instance-collector", client.Object["spec"].(map[string]interface{})["commonName"])
+	assert.NotContains(t, client.Object["spec"].(map[string]interface{}), "dnsNames")
+	assert.Contains(t, client.Object["spec"].(map[string]interface{})["usages"], "client auth")
+}
+
+func TestUsesMTLS(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	assert.False(t, UsesMTLS(otelcol))
+
+	otelcol.Spec.TargetAllocator.MTLS = &v1alpha1.TargetAllocatorMTLS{}
+	assert.False(t, UsesMTLS(otelcol))
+
+	otelcol.Spec.TargetAllocator.Enabled = true
+	assert.True(t, UsesMTLS(otelcol))
+}
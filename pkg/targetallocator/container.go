@@ -23,17 +23,33 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
-// Container builds a container for the given TargetAllocator.
-func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
-	image := otelcol.Spec.TargetAllocator.Image
+// Image returns the TargetAllocator image, the default one when the spec doesn't set it. In FIPS mode, the
+// FIPS-validated image replaces it.
+func Image(cfg config.Config, image string) string {
+	if fipsImage, ok := cfg.FIPSImage(config.FIPSImageTargetAllocator); ok && cfg.FIPS() {
+		return fipsImage
+	}
 	if len(image) == 0 {
-		image = cfg.TargetAllocatorImage()
+		return cfg.TargetAllocatorImage()
 	}
+	return image
+}
+
+// Container builds a container for the given TargetAllocator.
+func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) corev1.Container {
+	image := Image(cfg, otelcol.Spec.TargetAllocator.Image)
 
 	volumeMounts := []corev1.VolumeMount{{
 		Name:      naming.TAConfigMapVolume(),
 		MountPath: "/conf",
 	}}
+	if UsesMTLS(otelcol) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.TATLSVolume(),
+			MountPath: TLSMountPath,
+			ReadOnly:  true,
+		})
+	}
 
 	envVars := []corev1.EnvVar{}
 
@@ -50,6 +66,9 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelem
 	if otelcol.Spec.TargetAllocator.PrometheusCR.Enabled {
 		args = append(args, "--enable-prometheus-cr-watcher")
 	}
+	if UsesMTLS(otelcol) {
+		args = append(args, TLSArgs()...)
+	}
 	return corev1.Container{
 		Name:         naming.TAContainer(),
 		Image:        image,
@@ -58,6 +58,22 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerWithFIPSImage(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{}
+	cfg := config.New(
+		config.WithTargetAllocatorImage("default-image"),
+		config.WithFIPS(true),
+		config.WithFIPSImages(map[string]string{config.FIPSImageTargetAllocator: "fips-image"}),
+	)
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, "fips-image", c.Image)
+}
+
 func TestContainerVolumes(t *testing.T) {
 	// prepare
 	otelcol := v1alpha1.OpenTelemetryCollector{
@@ -77,3 +93,29 @@ func TestContainerVolumes(t *testing.T) {
 	assert.Len(t, c.VolumeMounts, 1)
 	assert.Equal(t, naming.TAConfigMapVolume(), c.VolumeMounts[0].Name)
 }
+
+func TestContainerWithMTLS(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+				MTLS:    &v1alpha1.TargetAllocatorMTLS{},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, otelcol)
+
+	// verify
+	assert.Len(t, c.VolumeMounts, 2)
+	assert.Equal(t, naming.TATLSVolume(), c.VolumeMounts[1].Name)
+	assert.Equal(t, TLSMountPath, c.VolumeMounts[1].MountPath)
+	assert.Equal(t, []string{
+		"--tls-cert-file=/etc/targetallocator-tls/tls.crt",
+		"--tls-key-file=/etc/targetallocator-tls/tls.key",
+		"--tls-client-ca-file=/etc/targetallocator-tls/ca.crt",
+	}, c.Args)
+}
@@ -29,6 +29,16 @@ import (
 func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTelemetryCollector) appsv1.Deployment {
 	labels := Labels(otelcol)
 	labels["app.kubernetes.io/name"] = naming.TargetAllocator(otelcol)
+	selector := SelectorLabels(otelcol)
+
+	// the pod annotations override the additional annotations of the instance
+	podAnnotations := map[string]string{}
+	for k, v := range otelcol.Spec.AdditionalAnnotations {
+		podAnnotations[k] = v
+	}
+	for k, v := range otelcol.Spec.PodAnnotations {
+		podAnnotations[k] = v
+	}
 
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -39,19 +49,47 @@ func Deployment(cfg config.Config, logger logr.Logger, otelcol v1alpha1.OpenTele
 		Spec: appsv1.DeploymentSpec{
 			Replicas: otelcol.Spec.TargetAllocator.Replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
+				MatchLabels: selector,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
-					Annotations: otelcol.Spec.PodAnnotations,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: ServiceAccountName(otelcol),
 					Containers:         []corev1.Container{Container(cfg, logger, otelcol)},
 					Volumes:            Volumes(cfg, otelcol),
+					Affinity:           affinity(otelcol.Spec.TargetAllocator.Replicas, selector),
 				},
 			},
 		},
 	}
 }
+
+// HighlyAvailable returns whether the TargetAllocator of the instance runs more than one replica.
+func HighlyAvailable(otelcol v1alpha1.OpenTelemetryCollector) bool {
+	return highlyAvailable(otelcol.Spec.TargetAllocator.Replicas)
+}
+
+func highlyAvailable(replicas *int32) bool {
+	return replicas != nil && *replicas > 1
+}
+
+// affinity spreads the replicas over the nodes, so that draining a node doesn't stop all of them.
+func affinity(replicas *int32, labels map[string]string) *corev1.Affinity {
+	if !highlyAvailable(replicas) {
+		return nil
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+					TopologyKey:   corev1.LabelHostname,
+				},
+			}},
+		},
+	}
+}
@@ -69,3 +69,77 @@ func TestDeploymentPodAnnotations(t *testing.T) {
 	assert.Equal(t, "my-instance-targetallocator", ds.Name)
 	assert.Equal(t, testPodAnnotationValues, ds.Spec.Template.Annotations)
 }
+
+func TestDeploymentAdditionalLabelsAndAnnotations(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			AdditionalLabels:      map[string]string{"team": "otel"},
+			AdditionalAnnotations: map[string]string{"owner": "additional", "team": "otel"},
+			PodAnnotations:        map[string]string{"owner": "pod"},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, "otel", d.Labels["team"])
+	assert.Equal(t, "otel", d.Spec.Template.Labels["team"])
+	assert.Equal(t, map[string]string{"owner": "pod", "team": "otel"}, d.Spec.Template.Annotations)
+
+	// the selector can't change, so it leaves out the additional labels
+	assert.NotContains(t, d.Spec.Selector.MatchLabels, "team")
+	assert.Equal(t, SelectorLabels(otelcol), d.Spec.Selector.MatchLabels)
+}
+
+func TestDeploymentHighlyAvailable(t *testing.T) {
+	// prepare
+	three := int32(3)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:  true,
+				Replicas: &three,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Equal(t, &three, d.Spec.Replicas)
+	terms := d.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 1)
+	assert.Equal(t, "kubernetes.io/hostname", terms[0].PodAffinityTerm.TopologyKey)
+	assert.Equal(t, d.Spec.Selector.MatchLabels, terms[0].PodAffinityTerm.LabelSelector.MatchLabels)
+}
+
+func TestDeploymentSingleReplica(t *testing.T) {
+	// prepare
+	one := int32(1)
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled:  true,
+				Replicas: &one,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	d := Deployment(cfg, logger, otelcol)
+
+	// verify
+	assert.Nil(t, d.Spec.Template.Spec.Affinity)
+}
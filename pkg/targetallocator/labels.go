@@ -28,6 +28,9 @@ func Labels(instance v1alpha1.OpenTelemetryCollector) map[string]string {
 			base[k] = v
 		}
 	}
+	for k, v := range instance.Spec.AdditionalLabels {
+		base[k] = v
+	}
 
 	base["app.kubernetes.io/managed-by"] = "opentelemetry-operator"
 	base["app.kubernetes.io/instance"] = naming.Truncate("%s.%s", 63, instance.Namespace, instance.Name)
@@ -36,3 +39,12 @@ func Labels(instance v1alpha1.OpenTelemetryCollector) map[string]string {
 
 	return base
 }
+
+// SelectorLabels return the labels selecting the TargetAllocator pods of the given instance. They leave out the
+// additional labels, which can change over time while the selector of the deployment can't.
+func SelectorLabels(instance v1alpha1.OpenTelemetryCollector) map[string]string {
+	instance.Spec.AdditionalLabels = nil
+	selector := Labels(instance)
+	selector["app.kubernetes.io/name"] = naming.TargetAllocator(instance)
+	return selector
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// allocatableTargetsMetric is the gauge of the TargetAllocator counting the targets it distributes among the collectors.
+const allocatableTargetsMetric = "opentelemetry_allocator_targets_allocatable"
+
+// MetricsURL returns the URL of the metrics endpoint of the TargetAllocator, behind its Service.
+func MetricsURL(otelcol v1alpha1.OpenTelemetryCollector) string {
+	return fmt.Sprintf("http://%s.%s.svc:80/metrics", naming.TAService(otelcol), otelcol.Namespace)
+}
+
+// AllocatableTargets reads the number of targets distributed by the TargetAllocator from its metrics endpoint.
+func AllocatableTargets(ctx context.Context, httpClient *http.Client, metricsURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the target allocator metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get the target allocator metrics, status code %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the target allocator metrics: %w", err)
+	}
+	family, ok := families[allocatableTargetsMetric]
+	if !ok || len(family.GetMetric()) == 0 {
+		return 0, fmt.Errorf("the target allocator metrics have no %s metric", allocatableTargetsMetric)
+	}
+	return int(family.GetMetric()[0].GetGauge().GetValue()), nil
+}
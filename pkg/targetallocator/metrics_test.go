@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestMetricsURL(t *testing.T) {
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+	}
+	assert.Equal(t, "http://my-instance-targetallocator.observability.svc:80/metrics", MetricsURL(otelcol))
+}
+
+func TestAllocatableTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`# HELP opentelemetry_allocator_targets_allocatable Number of targets the allocator distributes among the collectors.
+# TYPE opentelemetry_allocator_targets_allocatable gauge
+opentelemetry_allocator_targets_allocatable 1234
+# HELP opentelemetry_allocator_targets_per_collector The number of targets for each collector.
+# TYPE opentelemetry_allocator_targets_per_collector gauge
+opentelemetry_allocator_targets_per_collector{collector_name="my-instance-collector-0",strategy="consistent-hashing"} 617
+`))
+	}))
+	defer server.Close()
+
+	targets, err := AllocatableTargets(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, 1234, targets)
+}
+
+func TestAllocatableTargetsWithoutMetric(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# TYPE opentelemetry_allocator_targets gauge\nopentelemetry_allocator_targets{job_name=\"otel\"} 3\n"))
+	}))
+	defer server.Close()
+
+	_, err := AllocatableTargets(context.Background(), server.Client(), server.URL)
+	assert.ErrorContains(t, err, "have no opentelemetry_allocator_targets_allocatable metric")
+}
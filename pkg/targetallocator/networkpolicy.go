@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// NetworkPolicy builds the NetworkPolicy of the TargetAllocator pods of the given instance, allowing the ingress
+// traffic on the target ports of its Service only, from the collector pods and the sources of the instance.
+func NetworkPolicy(otelcol v1alpha1.OpenTelemetryCollector) networkingv1.NetworkPolicy {
+	labels := Labels(otelcol)
+	labels["app.kubernetes.io/name"] = naming.TANetworkPolicy(otelcol)
+
+	selector := Labels(otelcol)
+	selector["app.kubernetes.io/name"] = naming.TargetAllocator(otelcol)
+
+	var from []networkingv1.NetworkPolicyPeer
+	if len(otelcol.Spec.NetworkPolicy.From) > 0 {
+		from = append(from, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: collector.SelectorLabels(otelcol),
+			},
+		})
+		from = append(from, otelcol.Spec.NetworkPolicy.From...)
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, port := range Service(otelcol).Spec.Ports {
+		ports = append(ports, collector.NetworkPolicyPort(int32(port.TargetPort.IntValue()), corev1.ProtocolTCP))
+	}
+
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.TANetworkPolicy(otelcol),
+			Namespace: otelcol.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				Ports: ports,
+				From:  from,
+			}},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			NetworkPolicy: &v1alpha1.NetworkPolicySpec{},
+		},
+	}
+
+	t.Run("should allow any source", func(t *testing.T) {
+		// test
+		policy := NetworkPolicy(otelcol)
+
+		// verify
+		assert.Equal(t, "my-instance-targetallocator", policy.Name)
+		assert.Equal(t, "my-instance-targetallocator", policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/name"])
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Empty(t, policy.Spec.Ingress[0].From)
+		require.Len(t, policy.Spec.Ingress[0].Ports, 1)
+		assert.Equal(t, Service(otelcol).Spec.Ports[0].TargetPort, *policy.Spec.Ingress[0].Ports[0].Port)
+	})
+
+	t.Run("should allow the collector pods along with the sources", func(t *testing.T) {
+		// prepare
+		peer := networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "observability"}},
+		}
+		otelcol.Spec.NetworkPolicy.From = []networkingv1.NetworkPolicyPeer{peer}
+
+		// test
+		policy := NetworkPolicy(otelcol)
+
+		// verify
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: collector.SelectorLabels(otelcol)}},
+			peer,
+		}, policy.Spec.Ingress[0].From)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// allocationPort is the port the TargetAllocator serves the targets on.
+const allocationPort = 8080
+
+// Service builds the Service the collectors of the given instance query their targets from.
+func Service(otelcol v1alpha1.OpenTelemetryCollector) corev1.Service {
+	labels := Labels(otelcol)
+	labels["app.kubernetes.io/name"] = naming.TAService(otelcol)
+
+	port := int32(80)
+	if UsesMTLS(otelcol) {
+		port = 443
+	}
+
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.TAService(otelcol),
+			Namespace: otelcol.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: SelectorLabels(otelcol),
+			Ports: []corev1.ServicePort{{
+				Name:       "targetallocation",
+				Port:       port,
+				TargetPort: intstr.FromInt(allocationPort),
+			}},
+			// the collectors reach the target allocator with the IP families of their own Services
+			IPFamilyPolicy: otelcol.Spec.Service.IPFamilyPolicy,
+			IPFamilies:     otelcol.Spec.Service.IPFamilies,
+		},
+	}
+}
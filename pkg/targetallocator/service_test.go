@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestService(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{Enabled: true},
+		},
+	}
+
+	t.Run("should expose the target allocator on port 80", func(t *testing.T) {
+		// test
+		actual := Service(otelcol)
+
+		// verify
+		assert.Equal(t, "my-instance-targetallocator", actual.Name)
+		assert.Equal(t, int32(80), actual.Spec.Ports[0].Port)
+		assert.Equal(t, intstr.FromInt(8080), actual.Spec.Ports[0].TargetPort)
+	})
+
+	t.Run("should expose the target allocator on port 443 with mTLS", func(t *testing.T) {
+		// prepare
+		mtls := *otelcol.DeepCopy()
+		mtls.Spec.TargetAllocator.MTLS = &v1alpha1.TargetAllocatorMTLS{}
+
+		// test
+		actual := Service(mtls)
+
+		// verify
+		assert.Equal(t, int32(443), actual.Spec.Ports[0].Port)
+		assert.Equal(t, intstr.FromInt(8080), actual.Spec.Ports[0].TargetPort)
+	})
+
+	t.Run("should return IPv6-only service", func(t *testing.T) {
+		// prepare
+		ipv6 := *otelcol.DeepCopy()
+		policy := corev1.IPFamilyPolicySingleStack
+		ipv6.Spec.Service = v1alpha1.ServiceSpec{
+			IPFamilyPolicy: &policy,
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol},
+		}
+
+		// test
+		actual := Service(ipv6)
+
+		// verify
+		assert.Equal(t, &policy, actual.Spec.IPFamilyPolicy)
+		assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, actual.Spec.IPFamilies)
+	})
+}
@@ -19,6 +19,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
@@ -32,7 +34,7 @@ func ServiceAccountName(instance v1alpha1.OpenTelemetryCollector) string {
 }
 
 // ServiceAccount returns the service account for the given instance.
-func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccount {
+func ServiceAccount(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccount {
 	labels := Labels(otelcol)
 	labels["app.kubernetes.io/name"] = naming.TargetAllocatorServiceAccount(otelcol)
 
@@ -41,7 +43,7 @@ func ServiceAccount(otelcol v1alpha1.OpenTelemetryCollector) corev1.ServiceAccou
 			Name:        naming.TargetAllocatorServiceAccount(otelcol),
 			Namespace:   otelcol.Namespace,
 			Labels:      labels,
-			Annotations: otelcol.Annotations,
+			Annotations: collector.PropagatedAnnotations(otelcol, cfg.AnnotationsFilter()),
 		},
 	}
 }
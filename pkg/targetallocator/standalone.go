@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+// StandaloneLabels returns the common labels of the objects created for a TargetAllocator.
+func StandaloneLabels(ta v1alpha1.TargetAllocator) map[string]string {
+	// new map every time, so that we don't touch the instance's label
+	base := map[string]string{}
+	for k, v := range ta.Labels {
+		base[k] = v
+	}
+	for k, v := range standaloneSelectorLabels(ta) {
+		base[k] = v
+	}
+
+	return base
+}
+
+// standaloneSelectorLabels returns the labels selecting the TargetAllocator pods, which don't change for the lifetime
+// of the Deployment.
+func standaloneSelectorLabels(ta v1alpha1.TargetAllocator) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, ta.Namespace, ta.Name),
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  "opentelemetry-targetallocator",
+		"app.kubernetes.io/name":       naming.StandaloneTargetAllocator(ta.Name),
+	}
+}
+
+// StandaloneConfigMap builds the config of a TargetAllocator, allocating the targets among the collector pods of the
+// instances referencing it.
+func StandaloneConfigMap(cfg config.Config, ta v1alpha1.TargetAllocator) (corev1.ConfigMap, error) {
+	promConfig, err := adapters.ConfigFromString(ta.Spec.Config)
+	if err != nil {
+		return corev1.ConfigMap{}, fmt.Errorf("the config is incorrect: %w", err)
+	}
+
+	taConfig := map[interface{}]interface{}{
+		"label_selector": map[string]string{
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  "opentelemetry-collector",
+			collector.TargetAllocatorLabel: ta.Name,
+		},
+		"config":              promConfig,
+		"allocation_strategy": v1alpha1.OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted,
+	}
+	if len(ta.Spec.AllocationStrategy) > 0 {
+		taConfig["allocation_strategy"] = ta.Spec.AllocationStrategy
+	}
+	if len(ta.Spec.FilterStrategy) > 0 {
+		taConfig["filter_strategy"] = ta.Spec.FilterStrategy
+	}
+	if len(ta.Spec.AllocationRules) > 0 {
+		taConfig["allocation_rules"] = AllocationRules(ta.Spec.AllocationRules)
+	}
+	if ta.Spec.PrometheusCR.ServiceMonitorSelector != nil {
+		taConfig["service_monitor_selector"] = ta.Spec.PrometheusCR.ServiceMonitorSelector
+	}
+	if ta.Spec.PrometheusCR.PodMonitorSelector != nil {
+		taConfig["pod_monitor_selector"] = ta.Spec.PrometheusCR.PodMonitorSelector
+	}
+	if ta.Spec.PrometheusCR.ServiceMonitorNamespaceSelector != nil {
+		taConfig["service_monitor_namespace_selector"] = ta.Spec.PrometheusCR.ServiceMonitorNamespaceSelector
+	}
+	if ta.Spec.PrometheusCR.PodMonitorNamespaceSelector != nil {
+		taConfig["pod_monitor_namespace_selector"] = ta.Spec.PrometheusCR.PodMonitorNamespaceSelector
+	}
+
+	taConfigYAML, err := yaml.Marshal(taConfig)
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandaloneTargetAllocator(ta.Name),
+			Namespace: ta.Namespace,
+			Labels:    StandaloneLabels(ta),
+		},
+		Data: map[string]string{
+			cfg.TargetAllocatorConfigMapEntry(): string(taConfigYAML),
+		},
+	}, nil
+}
+
+// StandaloneDeployment builds the Deployment of a TargetAllocator.
+func StandaloneDeployment(cfg config.Config, ta v1alpha1.TargetAllocator) appsv1.Deployment {
+	labels := StandaloneLabels(ta)
+	selector := standaloneSelectorLabels(ta)
+
+	podAffinity := ta.Spec.Affinity
+	if podAffinity == nil {
+		podAffinity = affinity(ta.Spec.Replicas, selector)
+	}
+
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandaloneTargetAllocator(ta.Name),
+			Namespace: ta.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ta.Spec.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:        StandaloneServiceAccountName(ta),
+					Containers:                []corev1.Container{StandaloneContainer(cfg, ta)},
+					Volumes:                   standaloneVolumes(cfg, ta),
+					NodeSelector:              ta.Spec.NodeSelector,
+					Affinity:                  podAffinity,
+					Tolerations:               ta.Spec.Tolerations,
+					TopologySpreadConstraints: ta.Spec.TopologySpreadConstraints,
+				},
+			},
+		},
+	}
+}
+
+// StandaloneContainer builds the container of the TargetAllocator pods.
+func StandaloneContainer(cfg config.Config, ta v1alpha1.TargetAllocator) corev1.Container {
+	image := Image(cfg, ta.Spec.Image)
+
+	var args []string
+	if ta.Spec.PrometheusCR.Enabled {
+		args = append(args, "--enable-prometheus-cr-watcher")
+	}
+	return corev1.Container{
+		Name:      naming.TAContainer(),
+		Image:     image,
+		Resources: ta.Spec.Resources,
+		Env: []corev1.EnvVar{{
+			Name: "OTELCOL_NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.TAConfigMapVolume(),
+			MountPath: "/conf",
+		}},
+		Args: args,
+	}
+}
+
+func standaloneVolumes(cfg config.Config, ta v1alpha1.TargetAllocator) []corev1.Volume {
+	return []corev1.Volume{{
+		Name: naming.TAConfigMapVolume(),
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: naming.StandaloneTargetAllocator(ta.Name)},
+				Items: []corev1.KeyToPath{{
+					Key:  cfg.TargetAllocatorConfigMapEntry(),
+					Path: cfg.TargetAllocatorConfigMapEntry(),
+				}},
+			},
+		},
+	}}
+}
+
+// StandaloneService builds the Service the collectors referencing the TargetAllocator query their targets from.
+func StandaloneService(ta v1alpha1.TargetAllocator) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandaloneTAService(ta.Name),
+			Namespace: ta.Namespace,
+			Labels:    StandaloneLabels(ta),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: standaloneSelectorLabels(ta),
+			Ports: []corev1.ServicePort{{
+				Name:       "targetallocation",
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+}
+
+// StandaloneServiceAccountName returns the name of the existing or self-provisioned service account of the
+// TargetAllocator.
+func StandaloneServiceAccountName(ta v1alpha1.TargetAllocator) string {
+	if len(ta.Spec.ServiceAccount) == 0 {
+		return naming.StandaloneTargetAllocator(ta.Name)
+	}
+
+	return ta.Spec.ServiceAccount
+}
+
+// StandaloneServiceAccount builds the service account of the TargetAllocator, used when the spec doesn't set one.
+func StandaloneServiceAccount(ta v1alpha1.TargetAllocator) corev1.ServiceAccount {
+	return corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandaloneTargetAllocator(ta.Name),
+			Namespace: ta.Namespace,
+			Labels:    StandaloneLabels(ta),
+		},
+	}
+}
+
+// StandaloneHighlyAvailable returns whether the TargetAllocator runs more than one replica.
+func StandaloneHighlyAvailable(ta v1alpha1.TargetAllocator) bool {
+	return highlyAvailable(ta.Spec.Replicas)
+}
+
+// StandalonePodDisruptionBudget builds the PodDisruptionBudget of the replicated TargetAllocator pods.
+func StandalonePodDisruptionBudget(ta v1alpha1.TargetAllocator) policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandaloneTargetAllocator(ta.Name),
+			Namespace: ta.Namespace,
+			Labels:    StandaloneLabels(ta),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: standaloneSelectorLabels(ta),
+			},
+		},
+	}
+}
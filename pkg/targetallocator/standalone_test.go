@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
+)
+
+func standaloneInstance() v1alpha1.TargetAllocator {
+	return v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared",
+			Namespace: "default",
+			Labels:    map[string]string{"team": "observability"},
+		},
+		Spec: v1alpha1.TargetAllocatorSpec{
+			AllocationStrategy: "least-weighted",
+			Config: `scrape_configs:
+  - job_name: otel-collector
+    static_configs:
+      - targets: ["0.0.0.0:8888"]
+`,
+		},
+	}
+}
+
+func TestStandaloneConfigMap(t *testing.T) {
+	// prepare
+	ta := standaloneInstance()
+	cfg := config.New()
+
+	// test
+	cm, err := StandaloneConfigMap(cfg, ta)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "shared-standalone-ta", cm.Name)
+	assert.Equal(t, "observability", cm.Labels["team"])
+
+	taConfig := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[cfg.TargetAllocatorConfigMapEntry()]), &taConfig))
+	assert.Equal(t, map[interface{}]interface{}{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/component":  "opentelemetry-collector",
+		collector.TargetAllocatorLabel: "shared",
+	}, taConfig["label_selector"])
+	assert.Equal(t, "least-weighted", taConfig["allocation_strategy"])
+	assert.Contains(t, cm.Data[cfg.TargetAllocatorConfigMapEntry()], "job_name: otel-collector")
+}
+
+func TestStandaloneConfigMapAllocationRules(t *testing.T) {
+	// prepare
+	ta := standaloneInstance()
+	ta.Spec.AllocationRules = []v1alpha1.TargetAllocatorAllocationRule{
+		{Name: "probes", Jobs: []string{"blackbox"}, Action: v1alpha1.TargetAllocatorAllocationRuleActionDrop},
+	}
+	cfg := config.New()
+
+	// test
+	cm, err := StandaloneConfigMap(cfg, ta)
+
+	// verify
+	require.NoError(t, err)
+	taConfig := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[cfg.TargetAllocatorConfigMapEntry()]), &taConfig))
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{"name": "probes", "jobs": []interface{}{"blackbox"}, "action": "drop"},
+	}, taConfig["allocation_rules"])
+}
+
+func TestStandaloneConfigMapInvalidConfig(t *testing.T) {
+	// prepare
+	ta := standaloneInstance()
+	ta.Spec.Config = "scrape_configs: ["
+
+	// test
+	_, err := StandaloneConfigMap(config.New(), ta)
+
+	// verify
+	assert.ErrorContains(t, err, "the config is incorrect")
+}
+
+func TestStandaloneDeployment(t *testing.T) {
+	// prepare
+	ta := standaloneInstance()
+	ta.Spec.PrometheusCR.Enabled = true
+
+	// test
+	d := StandaloneDeployment(config.New(), ta)
+
+	// verify
+	assert.Equal(t, "shared-standalone-ta", d.Name)
+	otelcol := v1alpha1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: ta.Name, Namespace: ta.Namespace}}
+	assert.NotEqual(t, naming.TargetAllocator(otelcol), d.Name, "collides with the target allocator of a collector of the same name")
+	assert.Equal(t, "observability", d.Spec.Template.Labels["team"])
+	assert.NotContains(t, d.Spec.Selector.MatchLabels, "team")
+	assert.Equal(t, "shared-standalone-ta", d.Spec.Template.Spec.ServiceAccountName)
+	require.Len(t, d.Spec.Template.Spec.Containers, 1)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].Args, "--enable-prometheus-cr-watcher")
+	assert.Nil(t, d.Spec.Template.Spec.Affinity)
+}
+
+func TestStandaloneDeploymentHighlyAvailable(t *testing.T) {
+	// prepare
+	three := int32(3)
+	ta := standaloneInstance()
+	ta.Spec.Replicas = &three
+	ta.Spec.ServiceAccount = "my-sa"
+
+	// test
+	d := StandaloneDeployment(config.New(), ta)
+
+	// verify
+	assert.True(t, StandaloneHighlyAvailable(ta))
+	assert.Equal(t, three, *d.Spec.Replicas)
+	assert.Equal(t, "my-sa", d.Spec.Template.Spec.ServiceAccountName)
+	require.NotNil(t, d.Spec.Template.Spec.Affinity)
+	assert.NotNil(t, d.Spec.Template.Spec.Affinity.PodAntiAffinity)
+
+	pdb := StandalonePodDisruptionBudget(ta)
+	assert.Equal(t, "shared-standalone-ta", pdb.Name)
+	assert.Equal(t, d.Spec.Selector.MatchLabels, pdb.Spec.Selector.MatchLabels)
+}
+
+func TestStandaloneService(t *testing.T) {
+	// prepare
+	ta := standaloneInstance()
+
+	// test
+	svc := StandaloneService(ta)
+
+	// verify
+	assert.Equal(t, "shared-standalone-ta", svc.Name)
+	assert.Equal(t, StandaloneDeployment(config.New(), ta).Spec.Selector.MatchLabels, svc.Spec.Selector)
+	require.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, int32(80), svc.Spec.Ports[0].Port)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// pollTimeout is the time given to a target allocator to return its metrics.
+const pollTimeout = 5 * time.Second
+
+// TargetCounts polls the number of allocatable targets of the target allocators of the instances scaled on their
+// targets, outside of the reconciliations, which only read the last polled numbers.
+type TargetCounts struct {
+	log        logr.Logger
+	httpClient *http.Client
+	period     time.Duration
+	added      chan struct{}
+
+	mu      sync.RWMutex
+	urls    map[types.NamespacedName]string
+	targets map[types.NamespacedName]int
+}
+
+// NewTargetCounts returns the target counts, polled at the given period once started.
+func NewTargetCounts(logger logr.Logger, period time.Duration) *TargetCounts {
+	return &TargetCounts{
+		log:        logger,
+		httpClient: &http.Client{Timeout: pollTimeout},
+		period:     period,
+		added:      make(chan struct{}, 1),
+		urls:       map[types.NamespacedName]string{},
+		targets:    map[types.NamespacedName]int{},
+	}
+}
+
+// Watch adds the target allocator of the instance to the polled ones, a new one is polled right away.
+func (t *TargetCounts) Watch(otelcol v1alpha1.OpenTelemetryCollector) {
+	nsn := types.NamespacedName{Namespace: otelcol.Namespace, Name: otelcol.Name}
+	url := MetricsURL(otelcol)
+
+	t.mu.Lock()
+	previous, seen := t.urls[nsn]
+	t.urls[nsn] = url
+	t.mu.Unlock()
+
+	if seen && previous == url {
+		return
+	}
+	select {
+	case t.added <- struct{}{}:
+	default:
+	}
+}
+
+// Forget stops polling the target allocator of an instance, deleted or no longer scaled on its targets.
+func (t *TargetCounts) Forget(nsn types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.urls, nsn)
+	delete(t.targets, nsn)
+}
+
+// Targets returns the last polled number of targets of the instance, if its target allocator was reached.
+func (t *TargetCounts) Targets(nsn types.NamespacedName) (int, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	targets, ok := t.targets[nsn]
+	return targets, ok
+}
+
+// Start polls the target allocators until the context is done.
+func (t *TargetCounts) Start(ctx context.Context) error {
+	ticker := time.NewTicker(t.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-t.added:
+		}
+		t.poll(ctx)
+	}
+}
+
+func (t *TargetCounts) poll(ctx context.Context) {
+	t.mu.RLock()
+	urls := make(map[types.NamespacedName]string, len(t.urls))
+	for nsn, url := range t.urls {
+		urls[nsn] = url
+	}
+	t.mu.RUnlock()
+
+	for nsn, url := range urls {
+		pollCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+		targets, err := AllocatableTargets(pollCtx, t.httpClient, url)
+		cancel()
+
+		if err != nil {
+			// the previous number is kept while the target allocator can't be reached
+			t.log.V(2).Info("failed to poll the targets of the target allocator", "instance", nsn, "reason", err.Error())
+			continue
+		}
+
+		t.mu.Lock()
+		// the instance may have been forgotten while being polled
+		if _, watched := t.urls[nsn]; watched {
+			t.targets[nsn] = targets
+		}
+		t.mu.Unlock()
+	}
+}
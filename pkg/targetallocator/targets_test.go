@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTargetCountsPoll(t *testing.T) {
+	// prepare
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("# TYPE opentelemetry_allocator_targets_allocatable gauge\nopentelemetry_allocator_targets_allocatable 1234\n"))
+	}))
+	defer server.Close()
+
+	nsn := types.NamespacedName{Namespace: "observability", Name: "my-instance"}
+	counts := NewTargetCounts(logr.Discard(), time.Minute)
+	counts.urls[nsn] = server.URL
+
+	// test
+	_, ok := counts.Targets(nsn)
+	assert.False(t, ok)
+
+	counts.poll(context.Background())
+	targets, ok := counts.Targets(nsn)
+	assert.True(t, ok)
+	assert.Equal(t, 1234, targets)
+
+	// the last number is kept while the target allocator can't be reached
+	up = false
+	counts.poll(context.Background())
+	targets, ok = counts.Targets(nsn)
+	assert.True(t, ok)
+	assert.Equal(t, 1234, targets)
+
+	// verify
+	counts.Forget(nsn)
+	_, ok = counts.Targets(nsn)
+	assert.False(t, ok)
+	assert.Empty(t, counts.urls)
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	caValidity          = 10 * 365 * 24 * time.Hour
+	certificateValidity = 365 * 24 * time.Hour
+
+	// the CA is renewed early enough for the certificates it issued to expire before it does
+	caRenewBefore          = certificateValidity
+	certificateRenewBefore = 30 * 24 * time.Hour
+)
+
+// NewCertificateAuthority generates the self-signed CA issuing the TargetAllocator certificates. It returns the PEM
+// encoded certificate and private key.
+func NewCertificateAuthority(commonName string, now time.Time) ([]byte, []byte, error) {
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return createCertificate(template, nil, nil)
+}
+
+// IssueCertificate issues a certificate for the given names from the CA. It returns the PEM encoded certificate and
+// private key.
+func IssueCertificate(caCertPEM, caKeyPEM []byte, commonName string, dnsNames []string, usage x509.ExtKeyUsage, now time.Time) ([]byte, []byte, error) {
+	caCert, err := ParseCertificate(caCertPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(caKeyPEM)
+	if block == nil {
+		return nil, nil, fmt.Errorf("failed to decode the CA private key")
+	}
+	caKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the CA private key: %w", err)
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	return createCertificate(template, caCert, caKey)
+}
+
+// ParseCertificate decodes and parses a PEM encoded certificate.
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// CANeedsRenewal returns whether the self-signed CA is missing, invalid or close to its expiration.
+func CANeedsRenewal(caCertPEM []byte, now time.Time) bool {
+	caCert, err := ParseCertificate(caCertPEM)
+	if err != nil {
+		return true
+	}
+	return !caCert.IsCA || now.Add(caRenewBefore).After(caCert.NotAfter)
+}
+
+// CertificateNeedsRenewal returns whether the certificate is missing, invalid, not issued by the CA, or close to its
+// expiration. Certificates not covering the given DNS names are renewed too.
+func CertificateNeedsRenewal(certPEM, caCertPEM []byte, dnsNames []string, now time.Time) bool {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return true
+	}
+	caCert, err := ParseCertificate(caCertPEM)
+	if err != nil || cert.CheckSignatureFrom(caCert) != nil {
+		return true
+	}
+	for _, name := range dnsNames {
+		if cert.VerifyHostname(name) != nil {
+			return true
+		}
+	}
+	return now.Add(certificateRenewBefore).After(cert.NotAfter)
+}
+
+func createCertificate(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate the private key: %w", err)
+	}
+	if parent == nil {
+		// self-signed
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal the private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func serialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueCertificate(t *testing.T) {
+	// prepare
+	now := time.Now()
+	caCert, caKey, err := NewCertificateAuthority("my-ca", now)
+	require.NoError(t, err)
+
+	// test
+	cert, key, err := IssueCertificate(caCert, caKey, "my-service", []string{"my-service", "my-service.default.svc"}, x509.ExtKeyUsageServerAuth, now)
+
+	// verify
+	require.NoError(t, err)
+	assert.NotEmpty(t, key)
+	parsed, err := ParseCertificate(cert)
+	require.NoError(t, err)
+	ca, err := ParseCertificate(caCert)
+	require.NoError(t, err)
+	assert.NoError(t, parsed.CheckSignatureFrom(ca))
+	assert.Equal(t, "my-service", parsed.Subject.CommonName)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, parsed.ExtKeyUsage)
+	assert.False(t, parsed.IsCA)
+	assert.True(t, ca.IsCA)
+}
+
+func TestCertificateNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	dnsNames := []string{"my-service"}
+	caCert, caKey, err := NewCertificateAuthority("my-ca", now)
+	require.NoError(t, err)
+	otherCACert, otherCAKey, err := NewCertificateAuthority("other-ca", now)
+	require.NoError(t, err)
+	cert, _, err := IssueCertificate(caCert, caKey, "my-service", dnsNames, x509.ExtKeyUsageServerAuth, now)
+	require.NoError(t, err)
+	otherCert, _, err := IssueCertificate(otherCACert, otherCAKey, "my-service", dnsNames, x509.ExtKeyUsageServerAuth, now)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		desc     string
+		cert     []byte
+		dnsNames []string
+		now      time.Time
+		expected bool
+	}{
+		{desc: "valid", cert: cert, dnsNames: dnsNames, now: now, expected: false},
+		{desc: "missing", cert: nil, dnsNames: dnsNames, now: now, expected: true},
+		{desc: "issued by another CA", cert: otherCert, dnsNames: dnsNames, now: now, expected: true},
+		{desc: "missing a DNS name", cert: cert, dnsNames: []string{"my-service", "other-service"}, now: now, expected: true},
+		{desc: "close to its expiration", cert: cert, dnsNames: dnsNames, now: now.Add(certificateValidity - 10*24*time.Hour), expected: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CertificateNeedsRenewal(tt.cert, caCert, tt.dnsNames, tt.now))
+		})
+	}
+}
+
+func TestCANeedsRenewal(t *testing.T) {
+	now := time.Now()
+	caCert, _, err := NewCertificateAuthority("my-ca", now)
+	require.NoError(t, err)
+
+	assert.False(t, CANeedsRenewal(caCert, now))
+	assert.True(t, CANeedsRenewal(caCert, now.Add(caValidity-caRenewBefore/2)))
+	assert.True(t, CANeedsRenewal([]byte("not a certificate"), now))
+}
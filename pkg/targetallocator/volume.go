@@ -22,7 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/naming"
 )
 
-// Volumes builds the volumes for the given instance, including the config map volume.
+// Volumes builds the volumes for the given instance, including the config map volume and, with mTLS, the server certificate volume.
 func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev1.Volume {
 	volumes := []corev1.Volume{{
 		Name: naming.TAConfigMapVolume(),
@@ -38,5 +38,14 @@ func Volumes(cfg config.Config, otelcol v1alpha1.OpenTelemetryCollector) []corev
 		},
 	}}
 
+	if UsesMTLS(otelcol) {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.TATLSVolume(),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: naming.TAServerCertificate(otelcol)},
+			},
+		})
+	}
+
 	return volumes
 }
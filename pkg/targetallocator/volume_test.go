@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -41,3 +42,27 @@ func TestVolumeNewDefault(t *testing.T) {
 	// check that it's the ta-internal volume, with the config map
 	assert.Equal(t, naming.TAConfigMapVolume(), volumes[0].Name)
 }
+
+func TestVolumeWithMTLS(t *testing.T) {
+	// prepare
+	otelcol := v1alpha1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorSpec{
+			TargetAllocator: v1alpha1.OpenTelemetryTargetAllocator{
+				Enabled: true,
+				MTLS:    &v1alpha1.TargetAllocatorMTLS{},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, otelcol)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, naming.TATLSVolume(), volumes[1].Name)
+	assert.Equal(t, "my-instance-targetallocator-server", volumes[1].Secret.SecretName)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing traces the reconciliations and the admission webhooks of the operator with OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	tracerName  = "github.com/open-telemetry/opentelemetry-operator"
+	serviceName = "opentelemetry-operator"
+)
+
+// Tracer returns the tracer of the operator. Its spans are dropped until Setup installs an exporter.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Setup installs the tracer provider exporting the spans of the operator with OTLP, configured by the standard
+// OTEL_EXPORTER_OTLP_* environment variables. Tracing stays disabled unless an OTLP endpoint is set. The spans are
+// exported with gRPC, or with HTTP when the protocol is http/protobuf. The returned function flushes the pending
+// spans and stops the exporter.
+func Setup(ctx context.Context, operatorVersion string) (func(context.Context) error, error) {
+	return setup(ctx, operatorVersion, os.Getenv)
+}
+
+func setup(ctx context.Context, operatorVersion string, getenv func(string) string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if !enabled(getenv) {
+		return noop, nil
+	}
+
+	var client otlptrace.Client
+	switch protocol := envOr(getenv, "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"); protocol {
+	case "", "grpc":
+		client = otlptracegrpc.NewClient()
+	case "http/protobuf":
+		client = otlptracehttp.NewClient()
+	default:
+		return noop, fmt.Errorf("unsupported OTLP protocol %q, expected grpc or http/protobuf", protocol)
+	}
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+
+	// the resource attributes of the environment, like OTEL_SERVICE_NAME, take precedence
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(operatorVersion),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create the resource: %w", err)
+	}
+
+	// the sampler is configured by OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+func enabled(getenv func(string) string) bool {
+	if strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") || envOr(getenv, "OTEL_TRACES_EXPORTER") == "none" {
+		return false
+	}
+	return envOr(getenv, "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// envOr returns the value of the first of the environment variables that is set.
+func envOr(getenv func(string) string, keys ...string) string {
+	for _, key := range keys {
+		if value := getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// StartReconcile starts the span of the reconciliation of an object of the kind.
+func StartReconcile(ctx context.Context, kind string, nsn types.NamespacedName) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, fmt.Sprintf("Reconcile %s", kind), trace.WithAttributes(
+		attribute.String("k8s.namespace.name", nsn.Namespace),
+		attribute.String("opentelemetry-operator.object.kind", kind),
+		attribute.String("opentelemetry-operator.object.name", nsn.Name),
+	))
+}
+
+// End ends the span, with an error status when err isn't nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSetup(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		env         map[string]string
+		expectedErr string
+	}{
+		{
+			desc: "disabled without endpoint",
+		},
+		{
+			desc: "disabled with the none exporter",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4317",
+				"OTEL_TRACES_EXPORTER":        "none",
+			},
+		},
+		{
+			desc: "disabled sdk",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4317",
+				"OTEL_SDK_DISABLED":           "true",
+			},
+		},
+		{
+			desc: "unsupported protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4317",
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+			},
+			expectedErr: "unsupported OTLP protocol \"http/json\"",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			shutdown, err := setup(context.Background(), "0.0.1", func(key string) string {
+				return tt.env[key]
+			})
+
+			// verify
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestStartReconcile(t *testing.T) {
+	// prepare
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+
+	// test
+	_, span := StartReconcile(ctx, "OpenTelemetryCollector", types.NamespacedName{Namespace: "default", Name: "my-instance"})
+	End(span, errors.New("failed to reconcile"))
+	End(parent, nil)
+
+	// verify
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "parent", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "Reconcile OpenTelemetryCollector", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failed to reconcile", spans[0].Status().Description)
+}